@@ -0,0 +1,10 @@
+ALTER TABLE `agent_certificates`
+DROP FOREIGN KEY fk_agent_certificates_agent_id;
+
+ALTER TABLE `agent_certificates`
+DROP INDEX uq_agent_certificates_fingerprint;
+
+ALTER TABLE `agent_certificates`
+DROP INDEX uq_agent_certificates_subject;
+
+DROP TABLE IF EXISTS `agent_certificates`;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS `agent_certificates` (
+  `agent_id` CHAR(36) NOT NULL COMMENT "エージェントID",
+  `fingerprint` CHAR(64) COMMENT "フィンガープリント",
+  `subject` VARCHAR(255) COMMENT "サブジェクト",
+  `bound_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT "紐付け日時",
+  PRIMARY KEY (`agent_id`),
+  UNIQUE uq_agent_certificates_fingerprint (`fingerprint`),
+  UNIQUE uq_agent_certificates_subject (`subject`),
+  CONSTRAINT fk_agent_certificates_agent_id FOREIGN KEY (`agent_id`) REFERENCES `agents` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+ALTER TABLE `agent_secrets`
+DROP FOREIGN KEY fk_agent_secrets_agent_id;
+
+ALTER TABLE `agent_secrets`
+DROP INDEX uq_agent_secrets_key_id;
+
+DROP TABLE IF EXISTS `agent_secrets`;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `agent_secrets` (
+  `agent_id` CHAR(36) NOT NULL COMMENT "エージェントID",
+  `key_id` CHAR(32) NOT NULL COMMENT "キーID",
+  `secret` CHAR(32) NOT NULL COMMENT "シークレット",
+  `generated_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT "生成日時",
+  PRIMARY KEY (`agent_id`),
+  UNIQUE uq_agent_secrets_key_id (`key_id`),
+  CONSTRAINT fk_agent_secrets_agent_id FOREIGN KEY (`agent_id`) REFERENCES `agents` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+ALTER TABLE `agent_signature_nonces`
+DROP FOREIGN KEY fk_agent_signature_nonces_agent_id;
+
+ALTER TABLE `agent_signature_nonces`
+DROP INDEX idx_agent_signature_nonces_expires_at;
+
+DROP TABLE IF EXISTS `agent_signature_nonces`;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS `agent_signature_nonces` (
+  `agent_id` CHAR(36) NOT NULL COMMENT "エージェントID",
+  `nonce` VARCHAR(64) NOT NULL COMMENT "ノンス",
+  `expires_at` DATETIME (6) NOT NULL COMMENT "有効期限",
+  PRIMARY KEY (`agent_id`, `nonce`),
+  INDEX idx_agent_signature_nonces_expires_at (`expires_at`),
+  CONSTRAINT fk_agent_signature_nonces_agent_id FOREIGN KEY (`agent_id`) REFERENCES `agents` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+ALTER TABLE `agent_public_keys`
+DROP FOREIGN KEY fk_agent_public_keys_agent_id;
+
+DROP TABLE IF EXISTS `agent_public_keys`;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `agent_public_keys` (
+  `id` CHAR(36) NOT NULL COMMENT "ID",
+  `agent_id` CHAR(36) NOT NULL COMMENT "エージェントID",
+  `algorithm` ENUM ("EdDSA", "ES256", "ES384", "ES512", "RS256") NOT NULL COMMENT "アルゴリズム",
+  `public_key` TEXT NOT NULL COMMENT "公開鍵",
+  `created_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT "作成日時",
+  PRIMARY KEY (`id`),
+  CONSTRAINT fk_agent_public_keys_agent_id FOREIGN KEY (`agent_id`) REFERENCES `agents` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
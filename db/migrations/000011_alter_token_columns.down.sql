@@ -0,0 +1,11 @@
+DELETE FROM `agent_tokens`
+WHERE CHAR_LENGTH(`token`) > 32;
+
+ALTER TABLE `agent_tokens`
+MODIFY `token` CHAR(32) NOT NULL COMMENT "トークン";
+
+DELETE FROM `user_tokens`
+WHERE CHAR_LENGTH(`token`) > 32;
+
+ALTER TABLE `user_tokens`
+MODIFY `token` CHAR(32) NOT NULL COMMENT "トークン";
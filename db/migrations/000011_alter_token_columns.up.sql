@@ -0,0 +1,5 @@
+ALTER TABLE `user_tokens`
+MODIFY `token` VARCHAR(44) NOT NULL COMMENT "トークン";
+
+ALTER TABLE `agent_tokens`
+MODIFY `token` VARCHAR(44) NOT NULL COMMENT "トークン";
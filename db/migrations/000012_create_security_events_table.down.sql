@@ -0,0 +1,7 @@
+ALTER TABLE `security_events`
+DROP FOREIGN KEY fk_security_events_user_id;
+
+ALTER TABLE `security_events`
+DROP INDEX idx_security_events_user_id_occurred_at;
+
+DROP TABLE IF EXISTS `security_events`;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS `security_events` (
+  `id` CHAR(36) NOT NULL COMMENT "ID",
+  `user_id` CHAR(36) NOT NULL COMMENT "ユーザーID",
+  `type` VARCHAR(64) NOT NULL COMMENT "種別",
+  `detail` VARCHAR(255) NOT NULL COMMENT "詳細",
+  `occurred_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT "発生日時",
+  PRIMARY KEY (`id`),
+  INDEX idx_security_events_user_id_occurred_at (`user_id`, `occurred_at`),
+  CONSTRAINT fk_security_events_user_id FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,10 @@
+ALTER TABLE `agent_delegated_tokens`
+DROP FOREIGN KEY fk_agent_delegated_tokens_parent_token;
+
+ALTER TABLE `agent_delegated_tokens`
+DROP FOREIGN KEY fk_agent_delegated_tokens_agent_id;
+
+ALTER TABLE `agent_delegated_tokens`
+DROP INDEX idx_agent_delegated_tokens_agent_id_expires_at;
+
+DROP TABLE IF EXISTS `agent_delegated_tokens`;
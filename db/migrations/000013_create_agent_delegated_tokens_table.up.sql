@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `agent_delegated_tokens` (
+  `token` VARCHAR(44) NOT NULL COMMENT "トークン",
+  `agent_id` CHAR(36) NOT NULL COMMENT "エージェントID",
+  `parent_token` VARCHAR(44) NOT NULL COMMENT "親トークン",
+  `policies` JSON NOT NULL COMMENT "ポリシーID",
+  `expires_at` DATETIME (6) NOT NULL COMMENT "有効期限",
+  `created_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT "作成日時",
+  PRIMARY KEY (`token`),
+  INDEX idx_agent_delegated_tokens_agent_id_expires_at (`agent_id`, `expires_at`),
+  CONSTRAINT fk_agent_delegated_tokens_agent_id FOREIGN KEY (`agent_id`) REFERENCES `agents` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_agent_delegated_tokens_parent_token FOREIGN KEY (`parent_token`) REFERENCES `agent_tokens` (`token`) ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,6 @@
+ALTER TABLE `user_tokens`
+ADD `authenticated_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT "認証日時" AFTER `token`;
+
+-- 既存のセッションはサインイン時に認証されたものとして扱う.
+UPDATE `user_tokens`
+SET `authenticated_at` = `expires_at` - INTERVAL 30 DAY;
@@ -0,0 +1,2 @@
+ALTER TABLE `policies`
+DROP COLUMN `conditions`;
@@ -0,0 +1,2 @@
+ALTER TABLE `policies`
+ADD `conditions` JSON NULL COMMENT "適用条件" AFTER `methods`;
@@ -0,0 +1,2 @@
+ALTER TABLE `policies`
+ADD `expression` TEXT NOT NULL DEFAULT ('') COMMENT "CEL式" AFTER `conditions`;
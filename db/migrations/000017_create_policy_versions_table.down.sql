@@ -0,0 +1,7 @@
+ALTER TABLE `policy_versions`
+DROP FOREIGN KEY fk_policy_versions_policy_id;
+
+ALTER TABLE `policy_versions`
+DROP INDEX idx_policy_versions_user_id;
+
+DROP TABLE IF EXISTS `policy_versions`;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS `policy_versions` (
+  `policy_id` CHAR(36) NOT NULL COMMENT "ポリシーID",
+  `version` INT UNSIGNED NOT NULL COMMENT "バージョン",
+  `user_id` CHAR(36) NOT NULL COMMENT "ユーザーID",
+  `author_id` CHAR(36) NOT NULL COMMENT "変更者ID",
+  `operation` ENUM ("CREATE", "UPDATE", "DELETE", "RESTORE") NOT NULL COMMENT "操作",
+  `name` VARCHAR(255) NOT NULL COMMENT "ポリシー名",
+  `effect` ENUM ("ALLOW", "DENY") NOT NULL COMMENT "効果",
+  `service` ENUM ("STORAGE", "CONTENT") NOT NULL COMMENT "サービス",
+  `path` VARCHAR(255) NOT NULL COMMENT "パス",
+  `methods` JSON NOT NULL COMMENT "メソッド",
+  `conditions` JSON NULL COMMENT "適用条件",
+  `expression` TEXT NOT NULL COMMENT "CEL式",
+  `created_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT "作成日時",
+  PRIMARY KEY (`policy_id`, `version`),
+  INDEX idx_policy_versions_user_id (`user_id`),
+  CONSTRAINT fk_policy_versions_policy_id FOREIGN KEY (`policy_id`) REFERENCES `policies` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+INSERT INTO `policy_versions` (`policy_id`, `version`, `user_id`, `author_id`, `operation`, `name`, `effect`, `service`, `path`, `methods`, `conditions`, `expression`, `created_at`)
+SELECT `id`, 1, `user_id`, `user_id`, "CREATE", `name`, `effect`, `service`, `path`, `methods`, `conditions`, `expression`, `updated_at`
+FROM `policies`
+WHERE `deleted_at` IS NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE `roles`
+DROP FOREIGN KEY fk_roles_user_id;
+
+DROP TABLE IF EXISTS `roles`;
@@ -0,0 +1,7 @@
+ALTER TABLE `role_policies`
+DROP FOREIGN KEY fk_role_policies_role_id;
+
+ALTER TABLE `role_policies`
+DROP FOREIGN KEY fk_role_policies_policy_id;
+
+DROP TABLE IF EXISTS `role_policies`;
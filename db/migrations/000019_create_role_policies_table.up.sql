@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `role_policies` (
+  `role_id` CHAR(36) NOT NULL COMMENT "ロールID",
+  `policy_id` CHAR(36) NOT NULL COMMENT "ポリシーID",
+  PRIMARY KEY (`role_id`, `policy_id`),
+  CONSTRAINT fk_role_policies_role_id FOREIGN KEY (`role_id`) REFERENCES `roles` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_role_policies_policy_id FOREIGN KEY (`policy_id`) REFERENCES `policies` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+ALTER TABLE `role_agents`
+DROP FOREIGN KEY fk_role_agents_role_id;
+
+ALTER TABLE `role_agents`
+DROP FOREIGN KEY fk_role_agents_agent_id;
+
+DROP TABLE IF EXISTS `role_agents`;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `role_agents` (
+  `role_id` CHAR(36) NOT NULL COMMENT "ロールID",
+  `agent_id` CHAR(36) NOT NULL COMMENT "エージェントID",
+  PRIMARY KEY (`role_id`, `agent_id`),
+  CONSTRAINT fk_role_agents_role_id FOREIGN KEY (`role_id`) REFERENCES `roles` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_role_agents_agent_id FOREIGN KEY (`agent_id`) REFERENCES `agents` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
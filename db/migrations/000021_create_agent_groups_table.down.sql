@@ -0,0 +1,4 @@
+ALTER TABLE `agent_groups`
+DROP FOREIGN KEY fk_agent_groups_user_id;
+
+DROP TABLE IF EXISTS `agent_groups`;
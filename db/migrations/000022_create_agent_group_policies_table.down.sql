@@ -0,0 +1,7 @@
+ALTER TABLE `agent_group_policies`
+DROP FOREIGN KEY fk_agent_group_policies_agent_group_id;
+
+ALTER TABLE `agent_group_policies`
+DROP FOREIGN KEY fk_agent_group_policies_policy_id;
+
+DROP TABLE IF EXISTS `agent_group_policies`;
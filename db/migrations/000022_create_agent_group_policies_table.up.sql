@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `agent_group_policies` (
+  `agent_group_id` CHAR(36) NOT NULL COMMENT "エージェントグループID",
+  `policy_id` CHAR(36) NOT NULL COMMENT "ポリシーID",
+  PRIMARY KEY (`agent_group_id`, `policy_id`),
+  CONSTRAINT fk_agent_group_policies_agent_group_id FOREIGN KEY (`agent_group_id`) REFERENCES `agent_groups` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_agent_group_policies_policy_id FOREIGN KEY (`policy_id`) REFERENCES `policies` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
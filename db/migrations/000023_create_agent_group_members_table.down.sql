@@ -0,0 +1,7 @@
+ALTER TABLE `agent_group_members`
+DROP FOREIGN KEY fk_agent_group_members_agent_group_id;
+
+ALTER TABLE `agent_group_members`
+DROP FOREIGN KEY fk_agent_group_members_agent_id;
+
+DROP TABLE IF EXISTS `agent_group_members`;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `agent_group_members` (
+  `agent_group_id` CHAR(36) NOT NULL COMMENT "エージェントグループID",
+  `agent_id` CHAR(36) NOT NULL COMMENT "エージェントID",
+  PRIMARY KEY (`agent_group_id`, `agent_id`),
+  CONSTRAINT fk_agent_group_members_agent_group_id FOREIGN KEY (`agent_group_id`) REFERENCES `agent_groups` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_agent_group_members_agent_id FOREIGN KEY (`agent_id`) REFERENCES `agents` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
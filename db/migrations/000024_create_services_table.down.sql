@@ -0,0 +1,4 @@
+ALTER TABLE `services`
+DROP FOREIGN KEY fk_services_user_id;
+
+DROP TABLE IF EXISTS `services`;
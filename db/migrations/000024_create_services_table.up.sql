@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `services` (
+  `id` CHAR(36) NOT NULL COMMENT "ID",
+  `user_id` CHAR(36) NOT NULL COMMENT "ユーザーID",
+  `name` VARCHAR(64) NOT NULL COMMENT "サービス名",
+  `methods` JSON NOT NULL DEFAULT (JSON_ARRAY ()) COMMENT "メソッド",
+  `path_schema` VARCHAR(255) NOT NULL DEFAULT "" COMMENT "パススキーマ",
+  `created_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT "作成日時",
+  `updated_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT "更新日時",
+  `deleted_at` DATETIME (6) COMMENT "削除日時",
+  PRIMARY KEY (`id`),
+  CONSTRAINT fk_services_user_id FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+);
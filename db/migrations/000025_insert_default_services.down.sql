@@ -0,0 +1 @@
+DELETE FROM `services` WHERE `name` IN ("STORAGE", "CONTENT");
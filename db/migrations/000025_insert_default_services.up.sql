@@ -0,0 +1,5 @@
+INSERT INTO `services` (`id`, `user_id`, `name`, `methods`)
+SELECT UUID(), `id`, "STORAGE", JSON_ARRAY("DELETE", "GET", "POST", "PUT") FROM `users` WHERE `deleted_at` IS NULL;
+
+INSERT INTO `services` (`id`, `user_id`, `name`, `methods`)
+SELECT UUID(), `id`, "CONTENT", JSON_ARRAY("DELETE", "GET", "POST", "PUT") FROM `users` WHERE `deleted_at` IS NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE `policies`
+MODIFY `service` ENUM ("STORAGE", "CONTENT") NOT NULL COMMENT "サービス";
+
+ALTER TABLE `policy_versions`
+MODIFY `service` ENUM ("STORAGE", "CONTENT") NOT NULL COMMENT "サービス";
@@ -0,0 +1,5 @@
+ALTER TABLE `policies`
+MODIFY `service` VARCHAR(64) NOT NULL COMMENT "サービス";
+
+ALTER TABLE `policy_versions`
+MODIFY `service` VARCHAR(64) NOT NULL COMMENT "サービス";
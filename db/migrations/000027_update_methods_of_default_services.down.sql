@@ -0,0 +1 @@
+UPDATE `services` SET `methods` = JSON_ARRAY("DELETE", "GET", "POST", "PUT") WHERE `name` IN ("STORAGE", "CONTENT") AND `methods` = JSON_ARRAY("*") AND `deleted_at` IS NULL;
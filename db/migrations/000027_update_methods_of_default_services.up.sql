@@ -0,0 +1 @@
+UPDATE `services` SET `methods` = JSON_ARRAY("*") WHERE `name` IN ("STORAGE", "CONTENT") AND `methods` = JSON_ARRAY("DELETE", "GET", "POST", "PUT") AND `deleted_at` IS NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE `agent_attributes`
+DROP FOREIGN KEY fk_agent_attributes_agent_id;
+
+DROP TABLE IF EXISTS `agent_attributes`;
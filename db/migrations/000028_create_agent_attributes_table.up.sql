@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `agent_attributes` (
+  `agent_id` CHAR(36) NOT NULL COMMENT "エージェントID",
+  `attributes` JSON NOT NULL COMMENT "属性",
+  `updated_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT "更新日時",
+  PRIMARY KEY (`agent_id`),
+  CONSTRAINT fk_agent_attributes_agent_id FOREIGN KEY (`agent_id`) REFERENCES `agents` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+ALTER TABLE `permissions`
+DROP INDEX idx_permissions_valid_until,
+DROP COLUMN `valid_until`,
+DROP COLUMN `valid_from`;
@@ -0,0 +1,4 @@
+ALTER TABLE `permissions`
+ADD `valid_from` DATETIME (6) COMMENT "有効開始日時" AFTER `policy_id`,
+ADD `valid_until` DATETIME (6) COMMENT "有効終了日時" AFTER `valid_from`,
+ADD INDEX idx_permissions_valid_until (`valid_until`);
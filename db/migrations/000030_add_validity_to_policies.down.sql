@@ -0,0 +1,7 @@
+ALTER TABLE `policy_versions`
+DROP COLUMN `valid_until`,
+DROP COLUMN `valid_from`;
+
+ALTER TABLE `policies`
+DROP COLUMN `valid_until`,
+DROP COLUMN `valid_from`;
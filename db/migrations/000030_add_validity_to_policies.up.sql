@@ -0,0 +1,7 @@
+ALTER TABLE `policies`
+ADD `valid_from` DATETIME (6) COMMENT "有効開始日時" AFTER `expression`,
+ADD `valid_until` DATETIME (6) COMMENT "有効終了日時" AFTER `valid_from`;
+
+ALTER TABLE `policy_versions`
+ADD `valid_from` DATETIME (6) COMMENT "有効開始日時" AFTER `expression`,
+ADD `valid_until` DATETIME (6) COMMENT "有効終了日時" AFTER `valid_from`;
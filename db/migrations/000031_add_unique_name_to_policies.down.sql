@@ -0,0 +1,3 @@
+ALTER TABLE `policies`
+DROP INDEX uq_policies_user_id_active_name,
+DROP COLUMN `active_name`;
@@ -0,0 +1,5 @@
+-- 削除されていないポリシーの名前をユーザー内で一意にする. 削除済みのポリシーはNULLとなり, 一意制約の対象外となる.
+-- 既に名前が重複している場合は失敗するため, 事前に名前を変更しておく.
+ALTER TABLE `policies`
+ADD `active_name` VARCHAR(255) AS (IF(`deleted_at` IS NULL, `name`, NULL)) VIRTUAL COMMENT "削除されていないポリシー名" AFTER `deleted_at`,
+ADD UNIQUE uq_policies_user_id_active_name (`user_id`, `active_name`);
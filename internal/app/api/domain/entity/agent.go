@@ -16,13 +16,21 @@ var (
 	ErrInvalidAgentName  = status.Error(http.StatusBadRequest, "invalid agent name")
 )
 
+// Policiesは期間のない紐付けのみを持つ. 期間を指定した紐付けのうち有効期間内のものはTemporaryPoliciesに分けて持ち,
+// 判定には使うが, 紐付けの置き換えや書き出しの対象にはしない.
 type Agent struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	Name      string
-	Policies  []uuid.UUID
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	Name              string
+	Policies          []uuid.UUID
+	TemporaryPolicies []uuid.UUID
+	// TemporaryPoliciesのうち最も早く終了する紐付けの終了時刻. 終了時刻のない紐付けのみの場合はnilとなる.
+	// 展開や委任トークンによる絞り込みの後も, 判定のキャッシュの期間を縮めるために引き継ぐ.
+	TemporaryPoliciesValidUntil *time.Time
+	Groups                      []uuid.UUID
+	Roles                       []uuid.UUID
+	CreatedAt                   time.Time
+	UpdatedAt                   time.Time
 }
 
 func NewAgent(userID uuid.UUID, name string) (*Agent, error) {
@@ -32,9 +40,12 @@ func NewAgent(userID uuid.UUID, name string) (*Agent, error) {
 	}
 
 	agent := &Agent{
-		ID:       id,
-		UserID:   userID,
-		Policies: []uuid.UUID{},
+		ID:                id,
+		UserID:            userID,
+		Policies:          []uuid.UUID{},
+		TemporaryPolicies: []uuid.UUID{},
+		Groups:            []uuid.UUID{},
+		Roles:             []uuid.UUID{},
 	}
 
 	if err := agent.SetName(name); err != nil {
@@ -48,14 +59,17 @@ func NewAgent(userID uuid.UUID, name string) (*Agent, error) {
 	return agent, nil
 }
 
-func RestoreAgent(id uuid.UUID, userID uuid.UUID, name string, policies []uuid.UUID, createdAt time.Time, updatedAt time.Time) *Agent {
+func RestoreAgent(id uuid.UUID, userID uuid.UUID, name string, policies []uuid.UUID, groups []uuid.UUID, roles []uuid.UUID, createdAt time.Time, updatedAt time.Time) *Agent {
 	return &Agent{
-		ID:        id,
-		UserID:    userID,
-		Name:      name,
-		Policies:  policies,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+		ID:                id,
+		UserID:            userID,
+		Name:              name,
+		Policies:          policies,
+		TemporaryPolicies: []uuid.UUID{},
+		Groups:            groups,
+		Roles:             roles,
+		CreatedAt:         createdAt,
+		UpdatedAt:         updatedAt,
 	}
 }
 
@@ -86,3 +100,26 @@ func (a *Agent) SetPolicies(policies []*Policy) {
 	a.Policies = slices.Compact(ids)
 	a.UpdatedAt = time.Now()
 }
+
+// グループ, ロールと期間を指定した紐付けのポリシーを直接付与されたポリシーとして展開したエージェントを返す.
+// 展開後のエージェントはグループ, ロールと期間を指定した紐付けを持たない.
+func (a *Agent) Expand(groups []*PolicyBundle, roles []*PolicyBundle) *Agent {
+	policies := slices.Clone(a.Policies)
+	appendPolicies := func(ids []uuid.UUID) {
+		for _, id := range ids {
+			if !slices.Contains(policies, id) {
+				policies = append(policies, id)
+			}
+		}
+	}
+	appendPolicies(a.TemporaryPolicies)
+	for _, group := range groups {
+		appendPolicies(group.Policies)
+	}
+	for _, role := range roles {
+		appendPolicies(role.Policies)
+	}
+	expanded := RestoreAgent(a.ID, a.UserID, a.Name, policies, []uuid.UUID{}, []uuid.UUID{}, a.CreatedAt, a.UpdatedAt)
+	expanded.TemporaryPoliciesValidUntil = a.TemporaryPoliciesValidUntil
+	return expanded
+}
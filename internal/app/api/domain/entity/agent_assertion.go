@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	AgentAssertionAudience    = "holos-auth-api"
+	AgentAssertionMaxLifetime = time.Minute * 5
+)
+
+var (
+	ErrInvalidAgentAssertion = status.Error(http.StatusUnauthorized, "invalid agent assertion")
+)
+
+type AgentAssertion struct {
+	KeyID     uuid.UUID
+	Assertion string
+	ID        string
+	ExpiresAt time.Time
+}
+
+func NewAgentAssertion(assertion string) (*AgentAssertion, error) {
+	token, _, err := jwt.NewParser().ParseUnverified(assertion, &jwt.RegisteredClaims{})
+	if err != nil {
+		return nil, ErrInvalidAgentAssertion
+	}
+
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		return nil, ErrInvalidAgentAssertion
+	}
+	keyID, err := uuid.Parse(kid)
+	if err != nil {
+		return nil, ErrInvalidAgentAssertion
+	}
+
+	return &AgentAssertion{
+		KeyID:     keyID,
+		Assertion: assertion,
+	}, nil
+}
+
+// 公開鍵で署名を検証し, 検証済みのjtiと有効期限を設定する.
+// iss, subはエージェントID, audはAgentAssertionAudienceでなければならない.
+func (a *AgentAssertion) Verify(agentPublicKey *AgentPublicKey, now time.Time) error {
+	key, err := agentPublicKey.Key()
+	if err != nil {
+		return err
+	}
+
+	var claims jwt.RegisteredClaims
+	if _, err := jwt.ParseWithClaims(
+		a.Assertion,
+		&claims,
+		func(*jwt.Token) (interface{}, error) {
+			return key, nil
+		},
+		jwt.WithValidMethods([]string{agentPublicKey.Algorithm}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithAudience(AgentAssertionAudience),
+		jwt.WithIssuer(agentPublicKey.AgentID.String()),
+		jwt.WithSubject(agentPublicKey.AgentID.String()),
+		jwt.WithTimeFunc(func() time.Time {
+			return now
+		}),
+	); err != nil {
+		return ErrInvalidAgentAssertion
+	}
+
+	if claims.IssuedAt == nil || AgentAssertionMaxLifetime < claims.ExpiresAt.Sub(claims.IssuedAt.Time) {
+		return ErrInvalidAgentAssertion
+	}
+	if !regexp.MustCompile(`^[0-9A-Za-z_-]{16,64}$`).MatchString(claims.ID) {
+		return ErrInvalidAgentAssertion
+	}
+
+	a.ID = claims.ID
+	a.ExpiresAt = claims.ExpiresAt.Time
+	return nil
+}
@@ -0,0 +1,193 @@
+package entity_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func TestNewAgentAssertion(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	keyID := uuid.New()
+
+	sign := func(kid interface{}) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.RegisteredClaims{})
+		if kid != nil {
+			token.Header["kid"] = kid
+		}
+		assertion, err := token.SignedString(privateKey)
+		if err != nil {
+			t.Error(err.Error())
+		}
+		return assertion
+	}
+
+	tests := []struct {
+		name           string
+		inputAssertion string
+		expectKeyID    uuid.UUID
+		expectError    error
+	}{
+		{
+			name:           "success",
+			inputAssertion: sign(keyID.String()),
+			expectKeyID:    keyID,
+			expectError:    nil,
+		},
+		{
+			name:           "no kid",
+			inputAssertion: sign(nil),
+			expectError:    entity.ErrInvalidAgentAssertion,
+		},
+		{
+			name:           "invalid kid",
+			inputAssertion: sign("kid"),
+			expectError:    entity.ErrInvalidAgentAssertion,
+		},
+		{
+			name:           "malformed",
+			inputAssertion: "assertion",
+			expectError:    entity.ErrInvalidAgentAssertion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agentAssertion, err := entity.NewAgentAssertion(tt.inputAssertion)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil && agentAssertion.KeyID != tt.expectKeyID {
+				t.Errorf("key_id: expect %s but got %s", tt.expectKeyID, agentAssertion.KeyID)
+			}
+		})
+	}
+}
+
+func TestAgentAssertion_Verify(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	_, otherPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentPublicKey, err := entity.NewAgentPublicKey(uuid.New(), encodePublicKey(t, publicKey))
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	now := time.Now()
+	claims := func(modify func(*jwt.RegisteredClaims)) jwt.RegisteredClaims {
+		claims := jwt.RegisteredClaims{
+			Issuer:    agentPublicKey.AgentID.String(),
+			Subject:   agentPublicKey.AgentID.String(),
+			Audience:  jwt.ClaimStrings{entity.AgentAssertionAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        "jti-0123456789abcdef",
+		}
+		modify(&claims)
+		return claims
+	}
+	sign := func(key ed25519.PrivateKey, claims jwt.RegisteredClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+		token.Header["kid"] = agentPublicKey.ID.String()
+		assertion, err := token.SignedString(key)
+		if err != nil {
+			t.Error(err.Error())
+		}
+		return assertion
+	}
+
+	tests := []struct {
+		name           string
+		inputAssertion string
+		expectError    error
+	}{
+		{
+			name:           "success",
+			inputAssertion: sign(privateKey, claims(func(c *jwt.RegisteredClaims) {})),
+			expectError:    nil,
+		},
+		{
+			name:           "signed with other key",
+			inputAssertion: sign(otherPrivateKey, claims(func(c *jwt.RegisteredClaims) {})),
+			expectError:    entity.ErrInvalidAgentAssertion,
+		},
+		{
+			name: "expired",
+			inputAssertion: sign(privateKey, claims(func(c *jwt.RegisteredClaims) {
+				c.IssuedAt = jwt.NewNumericDate(now.Add(-time.Minute * 2))
+				c.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute))
+			})),
+			expectError: entity.ErrInvalidAgentAssertion,
+		},
+		{
+			name: "too long lifetime",
+			inputAssertion: sign(privateKey, claims(func(c *jwt.RegisteredClaims) {
+				c.ExpiresAt = jwt.NewNumericDate(now.Add(time.Hour))
+			})),
+			expectError: entity.ErrInvalidAgentAssertion,
+		},
+		{
+			name: "no issued at",
+			inputAssertion: sign(privateKey, claims(func(c *jwt.RegisteredClaims) {
+				c.IssuedAt = nil
+			})),
+			expectError: entity.ErrInvalidAgentAssertion,
+		},
+		{
+			name: "other audience",
+			inputAssertion: sign(privateKey, claims(func(c *jwt.RegisteredClaims) {
+				c.Audience = jwt.ClaimStrings{"audience"}
+			})),
+			expectError: entity.ErrInvalidAgentAssertion,
+		},
+		{
+			name: "other subject",
+			inputAssertion: sign(privateKey, claims(func(c *jwt.RegisteredClaims) {
+				c.Subject = uuid.New().String()
+			})),
+			expectError: entity.ErrInvalidAgentAssertion,
+		},
+		{
+			name: "no jti",
+			inputAssertion: sign(privateKey, claims(func(c *jwt.RegisteredClaims) {
+				c.ID = ""
+			})),
+			expectError: entity.ErrInvalidAgentAssertion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agentAssertion, err := entity.NewAgentAssertion(tt.inputAssertion)
+			if err != nil {
+				t.Error(err.Error())
+			}
+
+			if err := agentAssertion.Verify(agentPublicKey, now); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if agentAssertion.ID != "jti-0123456789abcdef" {
+					t.Errorf("id: expect jti-0123456789abcdef but got %s", agentAssertion.ID)
+				}
+				if agentAssertion.ExpiresAt.Unix() != now.Add(time.Minute).Unix() {
+					t.Errorf("expires_at: expect %s but got %s", now.Add(time.Minute), agentAssertion.ExpiresAt)
+				}
+			}
+		})
+	}
+}
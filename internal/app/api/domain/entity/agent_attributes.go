@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTooManyAgentAttributes     = status.Error(http.StatusBadRequest, "agent attributes must be 32 or less")
+	ErrInvalidAgentAttributeKey   = status.Error(http.StatusBadRequest, "invalid agent attribute key")
+	ErrInvalidAgentAttributeValue = status.Error(http.StatusBadRequest, "invalid agent attribute value")
+)
+
+const AgentAttributesMaxSize = 32
+
+var agentAttributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// エージェントに設定する任意の属性. ポリシーのパスの${agent.attributes.<key>}などから参照する.
+type AgentAttributes struct {
+	AgentID   uuid.UUID
+	Values    map[string]string
+	UpdatedAt time.Time
+}
+
+func NewAgentAttributes(agentID uuid.UUID, values map[string]string) (*AgentAttributes, error) {
+	agentAttributes := &AgentAttributes{
+		AgentID: agentID,
+	}
+
+	if err := agentAttributes.SetValues(values); err != nil {
+		return nil, err
+	}
+
+	return agentAttributes, nil
+}
+
+func RestoreAgentAttributes(agentID uuid.UUID, values map[string]string, updatedAt time.Time) *AgentAttributes {
+	return &AgentAttributes{
+		AgentID:   agentID,
+		Values:    values,
+		UpdatedAt: updatedAt,
+	}
+}
+
+// 値はパスのセグメントに置き換えるため, /を含められない.
+func (a *AgentAttributes) SetValues(values map[string]string) error {
+	if AgentAttributesMaxSize < len(values) {
+		return ErrTooManyAgentAttributes
+	}
+	for key, value := range values {
+		if !agentAttributeKeyPattern.MatchString(key) {
+			return ErrInvalidAgentAttributeKey
+		}
+		if value == "" || 255 < len(value) || strings.Contains(value, "/") {
+			return ErrInvalidAgentAttributeValue
+		}
+	}
+	if values == nil {
+		values = map[string]string{}
+	}
+
+	a.Values = values
+	a.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,77 @@
+package entity_test
+
+import (
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestNewAgentAttributes(t *testing.T) {
+	tooMany := map[string]string{}
+	for i := range entity.AgentAttributesMaxSize + 1 {
+		tooMany["key"+strconv.Itoa(i)] = "value"
+	}
+
+	tests := []struct {
+		name         string
+		inputValues  map[string]string
+		expectValues map[string]string
+		expectError  error
+	}{
+		{
+			name:         "success",
+			inputValues:  map[string]string{"team_id": "t1", "region": "ap-northeast-1"},
+			expectValues: map[string]string{"team_id": "t1", "region": "ap-northeast-1"},
+			expectError:  nil,
+		},
+		{
+			name:         "nil",
+			inputValues:  nil,
+			expectValues: map[string]string{},
+			expectError:  nil,
+		},
+		{
+			name:        "too many",
+			inputValues: tooMany,
+			expectError: entity.ErrTooManyAgentAttributes,
+		},
+		{
+			name:        "invalid key",
+			inputValues: map[string]string{"Team": "t1"},
+			expectError: entity.ErrInvalidAgentAttributeKey,
+		},
+		{
+			name:        "empty value",
+			inputValues: map[string]string{"team": ""},
+			expectError: entity.ErrInvalidAgentAttributeValue,
+		},
+		{
+			name:        "value with slash",
+			inputValues: map[string]string{"team": "a/b"},
+			expectError: entity.ErrInvalidAgentAttributeValue,
+		},
+		{
+			name:        "value too long",
+			inputValues: map[string]string{"team": strings.Repeat("a", 256)},
+			expectError: entity.ErrInvalidAgentAttributeValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agentAttributes, err := entity.NewAgentAttributes(uuid.New(), tt.inputValues)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if tt.expectError == nil {
+				if diff := cmp.Diff(tt.expectValues, agentAttributes.Values); diff != "" {
+					t.Error(diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRequiredAgentCertificate           = status.Error(http.StatusBadRequest, "agent certificate fingerprint or subject is required")
+	ErrInvalidAgentCertificateFingerprint = status.Error(http.StatusBadRequest, "invalid agent certificate fingerprint")
+	ErrAgentCertificateSubjectTooLong     = status.Error(http.StatusBadRequest, "agent certificate subject must be 255 characters or less")
+)
+
+type AgentCertificate struct {
+	AgentID     uuid.UUID
+	Fingerprint string
+	Subject     string
+	BoundAt     time.Time
+}
+
+func NewAgentCertificate(agentID uuid.UUID, fingerprint string, subject string) (*AgentCertificate, error) {
+	agentCertificate := &AgentCertificate{
+		AgentID: agentID,
+	}
+
+	if err := agentCertificate.SetFingerprint(fingerprint); err != nil {
+		return nil, err
+	}
+	if err := agentCertificate.SetSubject(subject); err != nil {
+		return nil, err
+	}
+	if agentCertificate.Fingerprint == "" && agentCertificate.Subject == "" {
+		return nil, ErrRequiredAgentCertificate
+	}
+
+	agentCertificate.BoundAt = time.Now()
+
+	return agentCertificate, nil
+}
+
+func RestoreAgentCertificate(agentID uuid.UUID, fingerprint string, subject string, boundAt time.Time) *AgentCertificate {
+	return &AgentCertificate{
+		AgentID:     agentID,
+		Fingerprint: fingerprint,
+		Subject:     subject,
+		BoundAt:     boundAt,
+	}
+}
+
+func (c *AgentCertificate) SetFingerprint(fingerprint string) error {
+	// "AB:CD:..."形式も受け付け, 小文字のhex文字列に正規化する.
+	fingerprint = strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+	if fingerprint != "" {
+		matched, err := regexp.MatchString(`^[0-9a-f]{64}$`, fingerprint)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			return ErrInvalidAgentCertificateFingerprint
+		}
+	}
+
+	c.Fingerprint = fingerprint
+	return nil
+}
+
+func (c *AgentCertificate) SetSubject(subject string) error {
+	subject = strings.TrimSpace(subject)
+	if 255 < len(subject) {
+		return ErrAgentCertificateSubjectTooLong
+	}
+
+	c.Subject = subject
+	return nil
+}
@@ -0,0 +1,96 @@
+package entity_test
+
+import (
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewAgentCertificate(t *testing.T) {
+	fingerprint := strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name              string
+		inputAgentID      uuid.UUID
+		inputFingerprint  string
+		inputSubject      string
+		expectFingerprint string
+		expectSubject     string
+		expectError       error
+	}{
+		{
+			name:              "success",
+			inputAgentID:      uuid.New(),
+			inputFingerprint:  fingerprint,
+			inputSubject:      "CN=agent,O=holos",
+			expectFingerprint: fingerprint,
+			expectSubject:     "CN=agent,O=holos",
+			expectError:       nil,
+		},
+		{
+			name:              "colon separated fingerprint",
+			inputAgentID:      uuid.New(),
+			inputFingerprint:  strings.TrimSuffix(strings.Repeat("AB:", 32), ":"),
+			inputSubject:      "",
+			expectFingerprint: fingerprint,
+			expectSubject:     "",
+			expectError:       nil,
+		},
+		{
+			name:              "subject only",
+			inputAgentID:      uuid.New(),
+			inputFingerprint:  "",
+			inputSubject:      " CN=agent ",
+			expectFingerprint: "",
+			expectSubject:     "CN=agent",
+			expectError:       nil,
+		},
+		{
+			name:             "invalid fingerprint",
+			inputAgentID:     uuid.New(),
+			inputFingerprint: "fingerprint",
+			inputSubject:     "",
+			expectError:      entity.ErrInvalidAgentCertificateFingerprint,
+		},
+		{
+			name:             "subject too long",
+			inputAgentID:     uuid.New(),
+			inputFingerprint: "",
+			inputSubject:     strings.Repeat("a", 256),
+			expectError:      entity.ErrAgentCertificateSubjectTooLong,
+		},
+		{
+			name:             "no fingerprint and subject",
+			inputAgentID:     uuid.New(),
+			inputFingerprint: "",
+			inputSubject:     "",
+			expectError:      entity.ErrRequiredAgentCertificate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agentCertificate, err := entity.NewAgentCertificate(tt.inputAgentID, tt.inputFingerprint, tt.inputSubject)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if agentCertificate.AgentID != tt.inputAgentID {
+					t.Errorf("agent_id: expect %s but got %s", tt.inputAgentID, agentCertificate.AgentID)
+				}
+				if agentCertificate.Fingerprint != tt.expectFingerprint {
+					t.Errorf("fingerprint: expect %s but got %s", tt.expectFingerprint, agentCertificate.Fingerprint)
+				}
+				if agentCertificate.Subject != tt.expectSubject {
+					t.Errorf("subject: expect %s but got %s", tt.expectSubject, agentCertificate.Subject)
+				}
+				if agentCertificate.BoundAt.IsZero() {
+					t.Error("bound_at: expect time but got empty")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package entity_test
+
+import (
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/pkg/token"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestNewAgentDelegatedToken(t *testing.T) {
+	agent := &entity.Agent{
+		ID:       uuid.New(),
+		UserID:   uuid.New(),
+		Name:     "name",
+		Policies: []uuid.UUID{uuid.New(), uuid.New()},
+	}
+	sortedPolicies := slices.Clone(agent.Policies)
+	slices.SortFunc(sortedPolicies, func(a, b uuid.UUID) int {
+		return slices.Compare(a[:], b[:])
+	})
+
+	tests := []struct {
+		name           string
+		inputPolicies  []uuid.UUID
+		inputTTL       time.Duration
+		expectPolicies []uuid.UUID
+		expectError    error
+	}{
+		{
+			name:           "success",
+			inputPolicies:  agent.Policies[:1],
+			inputTTL:       time.Minute,
+			expectPolicies: agent.Policies[:1],
+			expectError:    nil,
+		},
+		{
+			name:           "max ttl",
+			inputPolicies:  agent.Policies,
+			inputTTL:       entity.AgentDelegatedTokenMaxTTL,
+			expectPolicies: sortedPolicies,
+			expectError:    nil,
+		},
+		{
+			name:           "duplicated policies",
+			inputPolicies:  []uuid.UUID{sortedPolicies[0], sortedPolicies[1], sortedPolicies[0]},
+			inputTTL:       time.Minute,
+			expectPolicies: sortedPolicies,
+			expectError:    nil,
+		},
+		{
+			name:           "zero ttl",
+			inputPolicies:  agent.Policies,
+			inputTTL:       0,
+			expectPolicies: nil,
+			expectError:    entity.ErrInvalidAgentDelegatedTokenTTL,
+		},
+		{
+			name:           "too long ttl",
+			inputPolicies:  agent.Policies,
+			inputTTL:       entity.AgentDelegatedTokenMaxTTL + time.Second,
+			expectPolicies: nil,
+			expectError:    entity.ErrInvalidAgentDelegatedTokenTTL,
+		},
+		{
+			name:           "no policies",
+			inputPolicies:  []uuid.UUID{},
+			inputTTL:       time.Minute,
+			expectPolicies: nil,
+			expectError:    entity.ErrRequiredAgentDelegatedTokenPolicies,
+		},
+		{
+			name:           "policy not granted",
+			inputPolicies:  []uuid.UUID{agent.Policies[0], uuid.New()},
+			inputTTL:       time.Minute,
+			expectPolicies: nil,
+			expectError:    entity.ErrAgentDelegatedTokenPoliciesNotGranted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agentDelegatedToken, err := entity.NewAgentDelegatedToken(agent, "parent", tt.inputPolicies, tt.inputTTL)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if agentDelegatedToken.AgentID != agent.ID {
+					t.Errorf("agent_id: expect %s but got %s", agent.ID, agentDelegatedToken.AgentID)
+				}
+				if agentDelegatedToken.ParentToken != "parent" {
+					t.Errorf("parent_token: expect parent but got %s", agentDelegatedToken.ParentToken)
+				}
+				if !strings.HasPrefix(agentDelegatedToken.Token, token.AgentDelegatedTokenPrefix) {
+					t.Errorf("token: expect prefix %s", token.AgentDelegatedTokenPrefix)
+				}
+				if diff := cmp.Diff(tt.expectPolicies, agentDelegatedToken.Policies); diff != "" {
+					t.Error(diff)
+				}
+				if ttl := agentDelegatedToken.ExpiresAt.Sub(agentDelegatedToken.CreatedAt); ttl != tt.inputTTL {
+					t.Errorf("expires_at: expect ttl %s but got %s", tt.inputTTL, ttl)
+				}
+			}
+		})
+	}
+}
+
+func TestAgentDelegatedToken_Scope(t *testing.T) {
+	policyID := uuid.New()
+	agent := &entity.Agent{
+		ID:       uuid.New(),
+		UserID:   uuid.New(),
+		Name:     "name",
+		Policies: []uuid.UUID{policyID, uuid.New()},
+	}
+
+	tests := []struct {
+		name           string
+		inputPolicies  []uuid.UUID
+		expectPolicies []uuid.UUID
+	}{
+		{
+			name:           "subset",
+			inputPolicies:  []uuid.UUID{policyID},
+			expectPolicies: []uuid.UUID{policyID},
+		},
+		{
+			name:           "revoked from agent",
+			inputPolicies:  []uuid.UUID{uuid.New()},
+			expectPolicies: []uuid.UUID{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agentDelegatedToken := entity.RestoreAgentDelegatedToken(agent.ID, "parent", "token", tt.inputPolicies, time.Now().Add(time.Minute), time.Now())
+
+			result := agentDelegatedToken.Scope(agent)
+			if diff := cmp.Diff(tt.expectPolicies, result.Policies); diff != "" {
+				t.Error(diff)
+			}
+			if result.ID != agent.ID || result.UserID != agent.UserID {
+				t.Error("agent: expect same agent")
+			}
+		})
+	}
+}
+
+func TestValidateAgentDelegatedToken(t *testing.T) {
+	delegatedToken, err := token.GenerateWithPrefix(token.AgentDelegatedTokenPrefix)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentToken, err := token.GenerateWithPrefix(token.AgentTokenPrefix)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	legacyToken, err := token.Generate()
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name        string
+		inputToken  string
+		expectError error
+	}{
+		{
+			name:        "success",
+			inputToken:  delegatedToken,
+			expectError: nil,
+		},
+		{
+			name:        "agent token",
+			inputToken:  agentToken,
+			expectError: token.ErrInvalidToken,
+		},
+		{
+			name:        "legacy token",
+			inputToken:  legacyToken,
+			expectError: token.ErrInvalidToken,
+		},
+		{
+			name:        "legacy length with prefix",
+			inputToken:  token.AgentDelegatedTokenPrefix + legacyToken[len(token.AgentDelegatedTokenPrefix):],
+			expectError: token.ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := entity.ValidateAgentDelegatedToken(tt.inputToken); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidAgentPublicKey     = status.Error(http.StatusBadRequest, "invalid agent public key")
+	ErrUnsupportedAgentPublicKey = status.Error(http.StatusBadRequest, "agent public key must be Ed25519, ECDSA (P-256, P-384, P-521) or RSA (2048 bits or more)")
+)
+
+type AgentPublicKey struct {
+	ID        uuid.UUID
+	AgentID   uuid.UUID
+	Algorithm string
+	PublicKey string
+	CreatedAt time.Time
+}
+
+func NewAgentPublicKey(agentID uuid.UUID, publicKey string) (*AgentPublicKey, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	agentPublicKey := &AgentPublicKey{
+		ID:      id,
+		AgentID: agentID,
+	}
+
+	if err := agentPublicKey.SetPublicKey(publicKey); err != nil {
+		return nil, err
+	}
+
+	agentPublicKey.CreatedAt = time.Now()
+
+	return agentPublicKey, nil
+}
+
+func RestoreAgentPublicKey(id uuid.UUID, agentID uuid.UUID, algorithm string, publicKey string, createdAt time.Time) *AgentPublicKey {
+	return &AgentPublicKey{
+		ID:        id,
+		AgentID:   agentID,
+		Algorithm: algorithm,
+		PublicKey: publicKey,
+		CreatedAt: createdAt,
+	}
+}
+
+func (k *AgentPublicKey) SetPublicKey(publicKey string) error {
+	publicKey = strings.TrimSpace(publicKey)
+	key, err := parsePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	algorithm, err := publicKeyAlgorithm(key)
+	if err != nil {
+		return err
+	}
+
+	k.Algorithm = algorithm
+	k.PublicKey = publicKey
+	return nil
+}
+
+func (k *AgentPublicKey) Key() (crypto.PublicKey, error) {
+	return parsePublicKey(k.PublicKey)
+}
+
+func parsePublicKey(publicKey string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKey))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, ErrInvalidAgentPublicKey
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, ErrInvalidAgentPublicKey
+	}
+	return key, nil
+}
+
+// 公開鍵の種類からJWTの署名アルゴリズムを決定する.
+func publicKeyAlgorithm(key crypto.PublicKey) (string, error) {
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		return "EdDSA", nil
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return "ES256", nil
+		case elliptic.P384():
+			return "ES384", nil
+		case elliptic.P521():
+			return "ES512", nil
+		}
+	case *rsa.PublicKey:
+		if 2048 <= key.N.BitLen() {
+			return "RS256", nil
+		}
+	}
+	return "", ErrUnsupportedAgentPublicKey
+}
@@ -0,0 +1,115 @@
+package entity_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func encodePublicKey(t *testing.T, key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestNewAgentPublicKey(t *testing.T) {
+	ed25519Key, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	weakRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name            string
+		inputAgentID    uuid.UUID
+		inputPublicKey  string
+		expectAlgorithm string
+		expectError     error
+	}{
+		{
+			name:            "ed25519",
+			inputAgentID:    uuid.New(),
+			inputPublicKey:  encodePublicKey(t, ed25519Key),
+			expectAlgorithm: "EdDSA",
+			expectError:     nil,
+		},
+		{
+			name:            "ecdsa",
+			inputAgentID:    uuid.New(),
+			inputPublicKey:  encodePublicKey(t, &ecdsaKey.PublicKey),
+			expectAlgorithm: "ES384",
+			expectError:     nil,
+		},
+		{
+			name:            "rsa",
+			inputAgentID:    uuid.New(),
+			inputPublicKey:  encodePublicKey(t, &rsaKey.PublicKey),
+			expectAlgorithm: "RS256",
+			expectError:     nil,
+		},
+		{
+			name:           "weak rsa",
+			inputAgentID:   uuid.New(),
+			inputPublicKey: encodePublicKey(t, &weakRSAKey.PublicKey),
+			expectError:    entity.ErrUnsupportedAgentPublicKey,
+		},
+		{
+			name:           "invalid pem",
+			inputAgentID:   uuid.New(),
+			inputPublicKey: "public key",
+			expectError:    entity.ErrInvalidAgentPublicKey,
+		},
+		{
+			name:           "invalid pem type",
+			inputAgentID:   uuid.New(),
+			inputPublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")})),
+			expectError:    entity.ErrInvalidAgentPublicKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agentPublicKey, err := entity.NewAgentPublicKey(tt.inputAgentID, tt.inputPublicKey)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if agentPublicKey.ID == uuid.Nil {
+					t.Error("id: expect uuid but got empty")
+				}
+				if agentPublicKey.AgentID != tt.inputAgentID {
+					t.Errorf("agent_id: expect %s but got %s", tt.inputAgentID, agentPublicKey.AgentID)
+				}
+				if agentPublicKey.Algorithm != tt.expectAlgorithm {
+					t.Errorf("algorithm: expect %s but got %s", tt.expectAlgorithm, agentPublicKey.Algorithm)
+				}
+				if agentPublicKey.CreatedAt.IsZero() {
+					t.Error("created_at: expect time but got empty")
+				}
+			}
+		})
+	}
+}
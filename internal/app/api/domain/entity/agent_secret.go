@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"holos-auth-api/internal/app/api/domain/pkg/token"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AgentSecret struct {
+	AgentID     uuid.UUID
+	KeyID       string
+	Secret      string
+	GeneratedAt time.Time
+}
+
+func NewAgentSecret(agentID uuid.UUID) (*AgentSecret, error) {
+	keyID, err := token.Generate()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := token.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AgentSecret{
+		AgentID:     agentID,
+		KeyID:       keyID,
+		Secret:      secret,
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
+func RestoreAgentSecret(agentID uuid.UUID, keyID string, secret string, generatedAt time.Time) *AgentSecret {
+	return &AgentSecret{
+		AgentID:     agentID,
+		KeyID:       keyID,
+		Secret:      secret,
+		GeneratedAt: generatedAt,
+	}
+}
+
+func (s *AgentSecret) Sign(signature *AgentSignature) string {
+	mac := hmac.New(sha256.New, []byte(s.Secret))
+	mac.Write([]byte(signature.StringToSign()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *AgentSecret) VerifySignature(signature *AgentSignature) error {
+	if signature.KeyID != s.KeyID || !hmac.Equal([]byte(s.Sign(signature)), []byte(signature.Signature)) {
+		return ErrAuthenticationFailed
+	}
+	return nil
+}
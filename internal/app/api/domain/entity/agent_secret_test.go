@@ -0,0 +1,99 @@
+package entity_test
+
+import (
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewAgentSecret(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputAgentID uuid.UUID
+		expectError  error
+	}{
+		{
+			name:         "success",
+			inputAgentID: uuid.New(),
+			expectError:  nil,
+		},
+	}
+	for _, tt := range tests {
+		agentSecret, err := entity.NewAgentSecret(tt.inputAgentID)
+		if !errors.Is(err, tt.expectError) {
+			t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+		}
+
+		if tt.expectError == nil {
+			if agentSecret.AgentID != tt.inputAgentID {
+				t.Errorf("agent_id: expect %s but got %s", tt.inputAgentID, agentSecret.AgentID)
+			}
+			if len(agentSecret.KeyID) != 32 {
+				t.Error("key_id: must be 32 characters")
+			}
+			if len(agentSecret.Secret) != 32 {
+				t.Error("secret: must be 32 characters")
+			}
+			if agentSecret.KeyID == agentSecret.Secret {
+				t.Error("secret: must be different from key_id")
+			}
+			if agentSecret.GeneratedAt.IsZero() {
+				t.Error("generated_at: expect time but got empty")
+			}
+		}
+	}
+}
+
+func TestAgentSecret_VerifySignature(t *testing.T) {
+	agentSecret, err := entity.NewAgentSecret(uuid.New())
+	if err != nil {
+		t.Error(err.Error())
+	}
+	otherAgentSecret, err := entity.NewAgentSecret(uuid.New())
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	newSignature := func(keyID string, sign func(*entity.AgentSignature) string) *entity.AgentSignature {
+		signature, err := entity.NewAgentSignature(keyID, strings.Repeat("0", 64), strconv.FormatInt(time.Now().Unix(), 10), "nonce-0123456789", "STORAGE", "GET", "/files", strings.Repeat("a", 64))
+		if err != nil {
+			t.Error(err.Error())
+		}
+		signature.Signature = sign(signature)
+		return signature
+	}
+
+	tests := []struct {
+		name           string
+		inputSignature *entity.AgentSignature
+		expectError    error
+	}{
+		{
+			name:           "success",
+			inputSignature: newSignature(agentSecret.KeyID, agentSecret.Sign),
+			expectError:    nil,
+		},
+		{
+			name:           "signed with other secret",
+			inputSignature: newSignature(agentSecret.KeyID, otherAgentSecret.Sign),
+			expectError:    entity.ErrAuthenticationFailed,
+		},
+		{
+			name:           "other key id",
+			inputSignature: newSignature(otherAgentSecret.KeyID, agentSecret.Sign),
+			expectError:    entity.ErrAuthenticationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := agentSecret.VerifySignature(tt.inputSignature); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
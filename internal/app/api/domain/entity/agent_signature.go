@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	AgentSignatureAlgorithm = "HOLOS-HMAC-SHA256"
+	AgentSignatureClockSkew = time.Minute * 5
+)
+
+var (
+	ErrInvalidAgentSignature = status.Error(http.StatusUnauthorized, "invalid agent signature")
+	ErrAgentSignatureExpired = status.Error(http.StatusUnauthorized, "agent signature expired")
+)
+
+type AgentSignature struct {
+	KeyID         string
+	Signature     string
+	Timestamp     time.Time
+	Nonce         string
+	Service       string
+	Method        string
+	Path          string
+	ContentSHA256 string
+}
+
+func NewAgentSignature(keyID string, signature string, timestamp string, nonce string, service string, method string, path string, contentSHA256 string) (*AgentSignature, error) {
+	if keyID == "" || path == "" {
+		return nil, ErrInvalidAgentSignature
+	}
+
+	hexPattern := regexp.MustCompile(`^[0-9a-f]{64}$`)
+	if !hexPattern.MatchString(signature) || !hexPattern.MatchString(contentSHA256) {
+		return nil, ErrInvalidAgentSignature
+	}
+	if !regexp.MustCompile(`^[0-9A-Za-z_-]{16,64}$`).MatchString(nonce) {
+		return nil, ErrInvalidAgentSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidAgentSignature
+	}
+
+	return &AgentSignature{
+		KeyID:         keyID,
+		Signature:     signature,
+		Timestamp:     time.Unix(unix, 0),
+		Nonce:         nonce,
+		Service:       service,
+		Method:        strings.ToUpper(method),
+		Path:          path,
+		ContentSHA256: contentSHA256,
+	}, nil
+}
+
+// 署名対象の文字列. 各要素を改行で連結する. 署名を別のサービスへのリクエストに流用できないよう, サービスも含める.
+func (s *AgentSignature) StringToSign() string {
+	return strings.Join([]string{
+		AgentSignatureAlgorithm,
+		strconv.FormatInt(s.Timestamp.Unix(), 10),
+		s.Nonce,
+		s.Service,
+		s.Method,
+		s.Path,
+		s.ContentSHA256,
+	}, "\n")
+}
+
+func (s *AgentSignature) Validate(now time.Time) error {
+	if diff := now.Sub(s.Timestamp); diff < -AgentSignatureClockSkew || AgentSignatureClockSkew < diff {
+		return ErrAgentSignatureExpired
+	}
+	return nil
+}
+
+func (s *AgentSignature) ExpiresAt() time.Time {
+	return s.Timestamp.Add(AgentSignatureClockSkew)
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAgentSignatureNonceAlreadyUsed = status.Error(http.StatusUnauthorized, "agent signature nonce already used")
+)
+
+type AgentSignatureNonce struct {
+	AgentID   uuid.UUID
+	Nonce     string
+	ExpiresAt time.Time
+}
+
+func NewAgentSignatureNonce(agentID uuid.UUID, nonce string, expiresAt time.Time) *AgentSignatureNonce {
+	return &AgentSignatureNonce{
+		AgentID:   agentID,
+		Nonce:     nonce,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func RestoreAgentSignatureNonce(agentID uuid.UUID, nonce string, expiresAt time.Time) *AgentSignatureNonce {
+	return &AgentSignatureNonce{
+		AgentID:   agentID,
+		Nonce:     nonce,
+		ExpiresAt: expiresAt,
+	}
+}
@@ -0,0 +1,167 @@
+package entity_test
+
+import (
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewAgentSignature(t *testing.T) {
+	signature := strings.Repeat("0", 64)
+	contentSHA256 := strings.Repeat("a", 64)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	tests := []struct {
+		name               string
+		inputKeyID         string
+		inputSignature     string
+		inputTimestamp     string
+		inputNonce         string
+		inputMethod        string
+		inputPath          string
+		inputContentSHA256 string
+		expectError        error
+	}{
+		{
+			name:               "success",
+			inputKeyID:         "key_id",
+			inputSignature:     signature,
+			inputTimestamp:     timestamp,
+			inputNonce:         "nonce-0123456789",
+			inputMethod:        "get",
+			inputPath:          "/files",
+			inputContentSHA256: contentSHA256,
+			expectError:        nil,
+		},
+		{
+			name:               "no key id",
+			inputKeyID:         "",
+			inputSignature:     signature,
+			inputTimestamp:     timestamp,
+			inputNonce:         "nonce-0123456789",
+			inputMethod:        "GET",
+			inputPath:          "/files",
+			inputContentSHA256: contentSHA256,
+			expectError:        entity.ErrInvalidAgentSignature,
+		},
+		{
+			name:               "invalid signature",
+			inputKeyID:         "key_id",
+			inputSignature:     "signature",
+			inputTimestamp:     timestamp,
+			inputNonce:         "nonce-0123456789",
+			inputMethod:        "GET",
+			inputPath:          "/files",
+			inputContentSHA256: contentSHA256,
+			expectError:        entity.ErrInvalidAgentSignature,
+		},
+		{
+			name:               "invalid timestamp",
+			inputKeyID:         "key_id",
+			inputSignature:     signature,
+			inputTimestamp:     "2017-07-21T17:32:28Z",
+			inputNonce:         "nonce-0123456789",
+			inputMethod:        "GET",
+			inputPath:          "/files",
+			inputContentSHA256: contentSHA256,
+			expectError:        entity.ErrInvalidAgentSignature,
+		},
+		{
+			name:               "nonce too short",
+			inputKeyID:         "key_id",
+			inputSignature:     signature,
+			inputTimestamp:     timestamp,
+			inputNonce:         "nonce",
+			inputMethod:        "GET",
+			inputPath:          "/files",
+			inputContentSHA256: contentSHA256,
+			expectError:        entity.ErrInvalidAgentSignature,
+		},
+		{
+			name:               "invalid content sha256",
+			inputKeyID:         "key_id",
+			inputSignature:     signature,
+			inputTimestamp:     timestamp,
+			inputNonce:         "nonce-0123456789",
+			inputMethod:        "GET",
+			inputPath:          "/files",
+			inputContentSHA256: "",
+			expectError:        entity.ErrInvalidAgentSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agentSignature, err := entity.NewAgentSignature(tt.inputKeyID, tt.inputSignature, tt.inputTimestamp, tt.inputNonce, "STORAGE", tt.inputMethod, tt.inputPath, tt.inputContentSHA256)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if agentSignature.Method != strings.ToUpper(tt.inputMethod) {
+					t.Errorf("method: expect %s but got %s", strings.ToUpper(tt.inputMethod), agentSignature.Method)
+				}
+				if strconv.FormatInt(agentSignature.Timestamp.Unix(), 10) != tt.inputTimestamp {
+					t.Errorf("timestamp: expect %s but got %d", tt.inputTimestamp, agentSignature.Timestamp.Unix())
+				}
+			}
+		})
+	}
+}
+
+func TestAgentSignature_StringToSign(t *testing.T) {
+	agentSignature, err := entity.NewAgentSignature("key_id", strings.Repeat("0", 64), "1500658348", "nonce-0123456789", "STORAGE", "post", "/files", strings.Repeat("a", 64))
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	expect := "HOLOS-HMAC-SHA256\n1500658348\nnonce-0123456789\nSTORAGE\nPOST\n/files\n" + strings.Repeat("a", 64)
+	if result := agentSignature.StringToSign(); result != expect {
+		t.Errorf("\nexpect: %s\ngot: %s", expect, result)
+	}
+}
+
+func TestAgentSignature_Validate(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		inputTimestamp time.Time
+		expectError    error
+	}{
+		{
+			name:           "success",
+			inputTimestamp: now,
+			expectError:    nil,
+		},
+		{
+			name:           "within clock skew",
+			inputTimestamp: now.Add(entity.AgentSignatureClockSkew - time.Second),
+			expectError:    nil,
+		},
+		{
+			name:           "too old",
+			inputTimestamp: now.Add(-entity.AgentSignatureClockSkew - time.Second),
+			expectError:    entity.ErrAgentSignatureExpired,
+		},
+		{
+			name:           "too new",
+			inputTimestamp: now.Add(entity.AgentSignatureClockSkew + time.Second),
+			expectError:    entity.ErrAgentSignatureExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agentSignature, err := entity.NewAgentSignature("key_id", strings.Repeat("0", 64), strconv.FormatInt(tt.inputTimestamp.Unix(), 10), "nonce-0123456789", "STORAGE", "GET", "/files", strings.Repeat("a", 64))
+			if err != nil {
+				t.Error(err.Error())
+			}
+
+			if err := agentSignature.Validate(now); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"holos-auth-api/internal/app/api/domain/entity"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
@@ -191,3 +192,91 @@ func TestAgent_SetPolicies(t *testing.T) {
 		})
 	}
 }
+
+func TestAgent_Expand(t *testing.T) {
+	directPolicyID := uuid.New()
+	groupPolicyID := uuid.New()
+	rolePolicyID := uuid.New()
+	agent := entity.RestoreAgent(uuid.New(), uuid.New(), "name", []uuid.UUID{directPolicyID}, []uuid.UUID{uuid.New()}, []uuid.UUID{uuid.New()}, time.Now(), time.Now())
+
+	tests := []struct {
+		name         string
+		inputGroups  []*entity.PolicyBundle
+		inputRoles   []*entity.PolicyBundle
+		expectResult []uuid.UUID
+	}{
+		{
+			name:         "no groups and roles",
+			inputGroups:  []*entity.PolicyBundle{},
+			inputRoles:   []*entity.PolicyBundle{},
+			expectResult: []uuid.UUID{directPolicyID},
+		},
+		{
+			name: "group policies",
+			inputGroups: []*entity.PolicyBundle{
+				entity.RestorePolicyBundle(entity.PolicyBundleKindAgentGroup, uuid.New(), agent.UserID, "name", []uuid.UUID{groupPolicyID, directPolicyID}, []uuid.UUID{agent.ID}, time.Now(), time.Now()),
+			},
+			inputRoles:   []*entity.PolicyBundle{},
+			expectResult: []uuid.UUID{directPolicyID, groupPolicyID},
+		},
+		{
+			name:        "role policies",
+			inputGroups: []*entity.PolicyBundle{},
+			inputRoles: []*entity.PolicyBundle{
+				entity.RestorePolicyBundle(entity.PolicyBundleKindRole, uuid.New(), agent.UserID, "name", []uuid.UUID{rolePolicyID, directPolicyID}, []uuid.UUID{agent.ID}, time.Now(), time.Now()),
+				entity.RestorePolicyBundle(entity.PolicyBundleKindRole, uuid.New(), agent.UserID, "name", []uuid.UUID{rolePolicyID}, []uuid.UUID{agent.ID}, time.Now(), time.Now()),
+			},
+			expectResult: []uuid.UUID{directPolicyID, rolePolicyID},
+		},
+		{
+			name: "group and role policies",
+			inputGroups: []*entity.PolicyBundle{
+				entity.RestorePolicyBundle(entity.PolicyBundleKindAgentGroup, uuid.New(), agent.UserID, "name", []uuid.UUID{groupPolicyID}, []uuid.UUID{agent.ID}, time.Now(), time.Now()),
+			},
+			inputRoles: []*entity.PolicyBundle{
+				entity.RestorePolicyBundle(entity.PolicyBundleKindRole, uuid.New(), agent.UserID, "name", []uuid.UUID{rolePolicyID, groupPolicyID}, []uuid.UUID{agent.ID}, time.Now(), time.Now()),
+			},
+			expectResult: []uuid.UUID{directPolicyID, groupPolicyID, rolePolicyID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded := agent.Expand(tt.inputGroups, tt.inputRoles)
+			if diff := cmp.Diff(tt.expectResult, expanded.Policies); diff != "" {
+				t.Error(diff)
+			}
+			if len(expanded.Groups) != 0 {
+				t.Errorf("groups: expect empty but got %v", expanded.Groups)
+			}
+			if len(expanded.Roles) != 0 {
+				t.Errorf("roles: expect empty but got %v", expanded.Roles)
+			}
+			if len(agent.Policies) != 1 {
+				t.Errorf("policies of original agent has been changed: %v", agent.Policies)
+			}
+		})
+	}
+}
+
+func TestAgent_Expand_TemporaryPolicies(t *testing.T) {
+	directPolicyID := uuid.New()
+	temporaryPolicyID := uuid.New()
+	agent := entity.RestoreAgent(uuid.New(), uuid.New(), "name", []uuid.UUID{directPolicyID}, []uuid.UUID{}, []uuid.UUID{}, time.Now(), time.Now())
+	agent.TemporaryPolicies = []uuid.UUID{temporaryPolicyID, directPolicyID}
+	validUntil := time.Now().Add(time.Hour)
+	agent.TemporaryPoliciesValidUntil = &validUntil
+
+	expanded := agent.Expand([]*entity.PolicyBundle{}, []*entity.PolicyBundle{})
+	if diff := cmp.Diff([]uuid.UUID{directPolicyID, temporaryPolicyID}, expanded.Policies); diff != "" {
+		t.Error(diff)
+	}
+	if len(expanded.TemporaryPolicies) != 0 {
+		t.Errorf("temporary policies: expect empty but got %v", expanded.TemporaryPolicies)
+	}
+	if expanded.TemporaryPoliciesValidUntil != &validUntil {
+		t.Errorf("temporary policies valid until: expect %v but got %v", validUntil, expanded.TemporaryPoliciesValidUntil)
+	}
+	if diff := cmp.Diff([]uuid.UUID{directPolicyID}, agent.Policies); diff != "" {
+		t.Errorf("policies of original agent has been changed: %s", diff)
+	}
+}
@@ -14,7 +14,7 @@ type AgentToken struct {
 }
 
 func NewAgentToken(agentID uuid.UUID) (*AgentToken, error) {
-	token, err := token.Generate()
+	token, err := token.GenerateWithPrefix(token.AgentTokenPrefix)
 	if err != nil {
 		return nil, err
 	}
@@ -33,3 +33,8 @@ func RestoreAgentToken(agentID uuid.UUID, token string, generatedAt time.Time) *
 		GeneratedAt: generatedAt,
 	}
 }
+
+// 形式が不正なトークンはデータベースを参照する前に拒否する.
+func ValidateAgentToken(value string, legacyDeadline time.Time, now time.Time) error {
+	return token.Validate(token.AgentTokenPrefix, value, legacyDeadline, now)
+}
@@ -3,6 +3,8 @@ package entity_test
 import (
 	"errors"
 	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/pkg/token"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -30,8 +32,11 @@ func TestNewAgentToken(t *testing.T) {
 			if agentToken.AgentID != tt.inputAgentID {
 				t.Errorf("agent_id: expect %s but got %s", tt.inputAgentID, agentToken.AgentID)
 			}
-			if len(agentToken.Token) != 32 {
-				t.Error("token: must be 32 characters")
+			if !strings.HasPrefix(agentToken.Token, token.AgentTokenPrefix) {
+				t.Errorf("token: expect prefix %s", token.AgentTokenPrefix)
+			}
+			if len(agentToken.Token) != 44 {
+				t.Error("token: must be 44 characters")
 			}
 			if agentToken.GeneratedAt.IsZero() {
 				t.Error("generated_at: expect time but got empty")
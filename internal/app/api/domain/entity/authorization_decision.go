@@ -0,0 +1,116 @@
+package entity
+
+import "time"
+
+// ポリシーがリクエストに一致したか, 一致しなかった場合はその理由.
+const (
+	PolicyMatchResultMatched          = "MATCHED"
+	PolicyMatchResultServiceMismatch  = "SERVICE_MISMATCH"
+	PolicyMatchResultMethodMismatch   = "METHOD_MISMATCH"
+	PolicyMatchResultPathMismatch     = "PATH_MISMATCH"
+	PolicyMatchResultOutsideValidity  = "OUTSIDE_VALIDITY"
+	PolicyMatchResultConditionsNotMet = "CONDITIONS_NOT_MET"
+	PolicyMatchResultExpressionFalse  = "EXPRESSION_FALSE"
+	PolicyMatchResultExpressionError  = "EXPRESSION_ERROR"
+)
+
+// 拒否された理由.
+const (
+	AuthorizationDenyReasonNoMatchingPolicy = "NO_MATCHING_POLICY"
+	AuthorizationDenyReasonDeniedByPolicy   = "DENIED_BY_POLICY"
+	// 一括認可でリクエスト自体がサービスに受け付けられない場合.
+	AuthorizationDenyReasonServiceNotRegistered      = "SERVICE_NOT_REGISTERED"
+	AuthorizationDenyReasonMethodNotAllowedByService = "METHOD_NOT_ALLOWED_BY_SERVICE"
+	AuthorizationDenyReasonPathNotAllowedByService   = "PATH_NOT_ALLOWED_BY_SERVICE"
+)
+
+// 呼び出し元のサービスが判定をキャッシュしてよい期間.
+// ポリシーの変更やトークンの失効はこの期間だけ遅れて反映される.
+const AuthorizationDecisionCacheTTL = time.Minute
+
+type PolicyEvaluation struct {
+	Policy  *Policy
+	Matched bool
+	Result  string
+}
+
+type AuthorizationDecision struct {
+	Allowed        bool
+	DecidingPolicy *Policy
+	Evaluations    []*PolicyEvaluation
+	RejectReason   string
+	// 判定に使った期間を指定した紐付けのうち, 最も早く終了するものの終了時刻.
+	ValidUntil *time.Time
+}
+
+// 一致したDENYがあれば最初のDENYで拒否し, なければ最初に一致したALLOWで許可する.
+// 一致するポリシーがない場合は決定したポリシーなしで拒否する.
+func NewAuthorizationDecision(evaluations []*PolicyEvaluation) *AuthorizationDecision {
+	decision := &AuthorizationDecision{
+		Evaluations: evaluations,
+	}
+
+	for _, evaluation := range evaluations {
+		if !evaluation.Matched {
+			continue
+		}
+		if evaluation.Policy.Effect == "DENY" {
+			decision.Allowed = false
+			decision.DecidingPolicy = evaluation.Policy
+			return decision
+		}
+		if decision.DecidingPolicy == nil {
+			decision.Allowed = true
+			decision.DecidingPolicy = evaluation.Policy
+		}
+	}
+
+	return decision
+}
+
+// ポリシーを評価せずに拒否する.
+func NewRejectedAuthorizationDecision(reason string) *AuthorizationDecision {
+	return &AuthorizationDecision{
+		Allowed:      false,
+		RejectReason: reason,
+	}
+}
+
+// 許可された場合は空文字を返す.
+func (d *AuthorizationDecision) DenyReason() string {
+	if d.Allowed {
+		return ""
+	}
+	if d.RejectReason != "" {
+		return d.RejectReason
+	}
+	if d.DecidingPolicy == nil {
+		return AuthorizationDenyReasonNoMatchingPolicy
+	}
+	return AuthorizationDenyReasonDeniedByPolicy
+}
+
+// 条件やCEL式の評価結果は時刻やリクエストの属性によって変わるため, 条件やCEL式まで評価したポリシーがある場合はキャッシュさせない.
+// 有効期間を持つポリシーは, 次に有効期間の開始または終了を迎えるまでに期間を縮める. 期間を指定した紐付けも, 終了を迎えるまでに縮める.
+func (d *AuthorizationDecision) CacheTTL() time.Duration {
+	ttl := AuthorizationDecisionCacheTTL
+	now := time.Now()
+	if d.ValidUntil != nil && now.Before(*d.ValidUntil) && d.ValidUntil.Sub(now) < ttl {
+		ttl = d.ValidUntil.Sub(now)
+	}
+	for _, evaluation := range d.Evaluations {
+		switch evaluation.Result {
+		case PolicyMatchResultServiceMismatch, PolicyMatchResultMethodMismatch, PolicyMatchResultPathMismatch:
+			continue
+		}
+		if evaluation.Policy.Conditions != nil || evaluation.Policy.Expression != "" {
+			return 0
+		}
+		for _, boundary := range []*time.Time{evaluation.Policy.ValidFrom, evaluation.Policy.ValidUntil} {
+			if boundary != nil && now.Before(*boundary) && boundary.Sub(now) < ttl {
+				ttl = boundary.Sub(now)
+			}
+		}
+	}
+	return ttl
+}
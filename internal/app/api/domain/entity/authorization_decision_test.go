@@ -0,0 +1,283 @@
+package entity_test
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewAuthorizationDecision(t *testing.T) {
+	allowPolicy, err := entity.NewPolicy(uuid.New(), "allow", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	otherAllowPolicy, err := entity.NewPolicy(allowPolicy.UserID, "other_allow", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	denyPolicy, err := entity.NewPolicy(allowPolicy.UserID, "deny", "DENY", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                 string
+		inputEvaluations     []*entity.PolicyEvaluation
+		expectAllowed        bool
+		expectDecidingPolicy *entity.Policy
+	}{
+		{
+			name:                 "no policies",
+			inputEvaluations:     []*entity.PolicyEvaluation{},
+			expectAllowed:        false,
+			expectDecidingPolicy: nil,
+		},
+		{
+			name: "no matched policies",
+			inputEvaluations: []*entity.PolicyEvaluation{
+				{Policy: allowPolicy, Matched: false, Result: entity.PolicyMatchResultPathMismatch},
+			},
+			expectAllowed:        false,
+			expectDecidingPolicy: nil,
+		},
+		{
+			name: "first matched allow",
+			inputEvaluations: []*entity.PolicyEvaluation{
+				{Policy: allowPolicy, Matched: true, Result: entity.PolicyMatchResultMatched},
+				{Policy: otherAllowPolicy, Matched: true, Result: entity.PolicyMatchResultMatched},
+			},
+			expectAllowed:        true,
+			expectDecidingPolicy: allowPolicy,
+		},
+		{
+			name: "deny overrides allow",
+			inputEvaluations: []*entity.PolicyEvaluation{
+				{Policy: allowPolicy, Matched: true, Result: entity.PolicyMatchResultMatched},
+				{Policy: denyPolicy, Matched: true, Result: entity.PolicyMatchResultMatched},
+			},
+			expectAllowed:        false,
+			expectDecidingPolicy: denyPolicy,
+		},
+		{
+			name: "unmatched deny",
+			inputEvaluations: []*entity.PolicyEvaluation{
+				{Policy: denyPolicy, Matched: false, Result: entity.PolicyMatchResultConditionsNotMet},
+				{Policy: allowPolicy, Matched: true, Result: entity.PolicyMatchResultMatched},
+			},
+			expectAllowed:        true,
+			expectDecidingPolicy: allowPolicy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := entity.NewAuthorizationDecision(tt.inputEvaluations)
+			if decision.Allowed != tt.expectAllowed {
+				t.Errorf("allowed: expect %v but got %v", tt.expectAllowed, decision.Allowed)
+			}
+			if decision.DecidingPolicy != tt.expectDecidingPolicy {
+				t.Errorf("deciding_policy: expect %v but got %v", tt.expectDecidingPolicy, decision.DecidingPolicy)
+			}
+			if len(decision.Evaluations) != len(tt.inputEvaluations) {
+				t.Errorf("evaluations: expect %d but got %d", len(tt.inputEvaluations), len(decision.Evaluations))
+			}
+		})
+	}
+}
+
+func TestAuthorizationDecision_DenyReason(t *testing.T) {
+	allowPolicy, err := entity.NewPolicy(uuid.New(), "allow", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	denyPolicy, err := entity.NewPolicy(allowPolicy.UserID, "deny", "DENY", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name             string
+		inputEvaluations []*entity.PolicyEvaluation
+		expectResult     string
+	}{
+		{
+			name: "allowed",
+			inputEvaluations: []*entity.PolicyEvaluation{
+				{Policy: allowPolicy, Matched: true, Result: entity.PolicyMatchResultMatched},
+			},
+			expectResult: "",
+		},
+		{
+			name: "no matching policy",
+			inputEvaluations: []*entity.PolicyEvaluation{
+				{Policy: allowPolicy, Matched: false, Result: entity.PolicyMatchResultMethodMismatch},
+			},
+			expectResult: entity.AuthorizationDenyReasonNoMatchingPolicy,
+		},
+		{
+			name: "denied by policy",
+			inputEvaluations: []*entity.PolicyEvaluation{
+				{Policy: allowPolicy, Matched: true, Result: entity.PolicyMatchResultMatched},
+				{Policy: denyPolicy, Matched: true, Result: entity.PolicyMatchResultMatched},
+			},
+			expectResult: entity.AuthorizationDenyReasonDeniedByPolicy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := entity.NewAuthorizationDecision(tt.inputEvaluations).DenyReason(); result != tt.expectResult {
+				t.Errorf("\nexpect: %s\ngot: %s", tt.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestAuthorizationDecision_CacheTTL(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "policy", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	conditionalPolicy := entity.RestorePolicy(uuid.New(), policy.UserID, "conditional", "ALLOW", "STORAGE", "/", []string{"GET"}, entity.RestorePolicyConditions("UTC", []string{"MON"}, "", "", nil, nil, nil, nil, nil), "", nil, nil, nil, time.Now(), time.Now())
+	expressionPolicy := entity.RestorePolicy(uuid.New(), policy.UserID, "expression", "DENY", "STORAGE", "/", []string{"GET"}, nil, `method == "GET"`, nil, nil, nil, time.Now(), time.Now())
+
+	tests := []struct {
+		name             string
+		inputEvaluations []*entity.PolicyEvaluation
+		expectResult     time.Duration
+	}{
+		{
+			name: "no conditions",
+			inputEvaluations: []*entity.PolicyEvaluation{
+				{Policy: policy, Matched: true, Result: entity.PolicyMatchResultMatched},
+			},
+			expectResult: entity.AuthorizationDecisionCacheTTL,
+		},
+		{
+			name: "conditions evaluated",
+			inputEvaluations: []*entity.PolicyEvaluation{
+				{Policy: policy, Matched: true, Result: entity.PolicyMatchResultMatched},
+				{Policy: conditionalPolicy, Matched: true, Result: entity.PolicyMatchResultMatched},
+			},
+			expectResult: 0,
+		},
+		{
+			name: "expression evaluated",
+			inputEvaluations: []*entity.PolicyEvaluation{
+				{Policy: expressionPolicy, Matched: false, Result: entity.PolicyMatchResultExpressionFalse},
+			},
+			expectResult: 0,
+		},
+		{
+			name: "conditions not reached",
+			inputEvaluations: []*entity.PolicyEvaluation{
+				{Policy: policy, Matched: true, Result: entity.PolicyMatchResultMatched},
+				{Policy: conditionalPolicy, Matched: false, Result: entity.PolicyMatchResultPathMismatch},
+			},
+			expectResult: entity.AuthorizationDecisionCacheTTL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := entity.NewAuthorizationDecision(tt.inputEvaluations).CacheTTL(); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestAuthorizationDecision_CacheTTL_Validity(t *testing.T) {
+	userID := uuid.New()
+	newPolicy := func(validFrom *time.Time, validUntil *time.Time) *entity.Policy {
+		return entity.RestorePolicy(uuid.New(), userID, "policy", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", validFrom, validUntil, nil, time.Now(), time.Now())
+	}
+	past := time.Now().Add(-time.Hour)
+	soon := time.Now().Add(time.Second * 10)
+	later := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		inputPolicy *entity.Policy
+		expectMin   time.Duration
+		expectMax   time.Duration
+	}{
+		{
+			name:        "boundaries out of ttl",
+			inputPolicy: newPolicy(&past, &later),
+			expectMin:   entity.AuthorizationDecisionCacheTTL,
+			expectMax:   entity.AuthorizationDecisionCacheTTL,
+		},
+		{
+			name:        "expires within ttl",
+			inputPolicy: newPolicy(&past, &soon),
+			expectMin:   time.Second,
+			expectMax:   time.Second * 10,
+		},
+		{
+			name:        "starts within ttl",
+			inputPolicy: newPolicy(&soon, nil),
+			expectMin:   time.Second,
+			expectMax:   time.Second * 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := entity.NewAuthorizationDecision([]*entity.PolicyEvaluation{
+				{Policy: tt.inputPolicy, Matched: true, Result: entity.PolicyMatchResultMatched},
+			})
+			if result := decision.CacheTTL(); result < tt.expectMin || tt.expectMax < result {
+				t.Errorf("\nexpect: %v - %v\ngot: %v", tt.expectMin, tt.expectMax, result)
+			}
+		})
+	}
+}
+
+func TestAuthorizationDecision_CacheTTL_TemporaryPermission(t *testing.T) {
+	policy := entity.RestorePolicy(uuid.New(), uuid.New(), "policy", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now())
+	past := time.Now().Add(-time.Hour)
+	soon := time.Now().Add(time.Second * 10)
+	later := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name            string
+		inputValidUntil *time.Time
+		expectMin       time.Duration
+		expectMax       time.Duration
+	}{
+		{
+			name:            "no temporary permission",
+			inputValidUntil: nil,
+			expectMin:       entity.AuthorizationDecisionCacheTTL,
+			expectMax:       entity.AuthorizationDecisionCacheTTL,
+		},
+		{
+			name:            "expires out of ttl",
+			inputValidUntil: &later,
+			expectMin:       entity.AuthorizationDecisionCacheTTL,
+			expectMax:       entity.AuthorizationDecisionCacheTTL,
+		},
+		{
+			name:            "expires within ttl",
+			inputValidUntil: &soon,
+			expectMin:       time.Second,
+			expectMax:       time.Second * 10,
+		},
+		{
+			name:            "already expired",
+			inputValidUntil: &past,
+			expectMin:       entity.AuthorizationDecisionCacheTTL,
+			expectMax:       entity.AuthorizationDecisionCacheTTL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := entity.NewAuthorizationDecision([]*entity.PolicyEvaluation{
+				{Policy: policy, Matched: true, Result: entity.PolicyMatchResultMatched},
+			})
+			decision.ValidUntil = tt.inputValidUntil
+			if result := decision.CacheTTL(); result < tt.expectMin || tt.expectMax < result {
+				t.Errorf("\nexpect: %v - %v\ngot: %v", tt.expectMin, tt.expectMax, result)
+			}
+		})
+	}
+}
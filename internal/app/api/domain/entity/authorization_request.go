@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrRequiredAuthorizationRequests = status.Error(http.StatusBadRequest, "authorization requests are required")
+	ErrTooManyAuthorizationRequests  = status.Error(http.StatusBadRequest, "too many authorization requests")
+)
+
+// 一括認可で1回に判定できるリクエストの上限.
+const AuthorizationRequestBatchMaxSize = 100
+
+// 認可判定の対象となるリクエスト. ポリシーの条件はこの内容に対して評価する.
+type AuthorizationRequest struct {
+	Service     string
+	Path        string
+	Method      string
+	SourceIP    string
+	Attributes  map[string]string
+	RequestedAt time.Time
+}
+
+func NewAuthorizationRequest(service string, path string, method string, sourceIP string, attributes map[string]string) *AuthorizationRequest {
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+
+	return &AuthorizationRequest{
+		Service:     service,
+		Path:        path,
+		Method:      method,
+		SourceIP:    sourceIP,
+		Attributes:  attributes,
+		RequestedAt: time.Now(),
+	}
+}
+
+func ValidateAuthorizationRequests(requests []*AuthorizationRequest) error {
+	if len(requests) == 0 {
+		return ErrRequiredAuthorizationRequests
+	}
+	if len(requests) > AuthorizationRequestBatchMaxSize {
+		return ErrTooManyAuthorizationRequests
+	}
+	return nil
+}
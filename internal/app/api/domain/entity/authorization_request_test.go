@@ -0,0 +1,50 @@
+package entity_test
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"testing"
+)
+
+func TestValidateAuthorizationRequests(t *testing.T) {
+	newRequests := func(n int) []*entity.AuthorizationRequest {
+		requests := make([]*entity.AuthorizationRequest, n)
+		for i := range requests {
+			requests[i] = entity.NewAuthorizationRequest("STORAGE", "/", "GET", "", nil)
+		}
+		return requests
+	}
+
+	tests := []struct {
+		name          string
+		inputRequests []*entity.AuthorizationRequest
+		expectError   error
+	}{
+		{
+			name:          "success",
+			inputRequests: newRequests(1),
+			expectError:   nil,
+		},
+		{
+			name:          "max size",
+			inputRequests: newRequests(entity.AuthorizationRequestBatchMaxSize),
+			expectError:   nil,
+		},
+		{
+			name:          "empty",
+			inputRequests: newRequests(0),
+			expectError:   entity.ErrRequiredAuthorizationRequests,
+		},
+		{
+			name:          "too many",
+			inputRequests: newRequests(entity.AuthorizationRequestBatchMaxSize + 1),
+			expectError:   entity.ErrTooManyAuthorizationRequests,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := entity.ValidateAuthorizationRequests(tt.inputRequests); err != tt.expectError {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+)
+
+var (
+	ErrConfigStateChanged = status.Error(http.StatusConflict, "state changed since plan was made")
+)
+
+const (
+	ConfigResourcePolicy = "POLICY"
+	ConfigResourceAgent  = "AGENT"
+)
+
+const (
+	ConfigActionCreate = "CREATE"
+	ConfigActionUpdate = "UPDATE"
+	ConfigActionDelete = "DELETE"
+)
+
+// 計画の1つの変更. PolicyかAgentのどちらか一方に, 変更を適用した後の状態を持つ.
+type ConfigChange struct {
+	Resource string
+	Action   string
+	Name     string
+	Fields   []string
+	Policy   *Policy
+	Agent    *Agent
+}
+
+// 現在の状態を文書の状態に合わせるための変更. ポリシーの変更, エージェントの変更の順に適用する.
+type ConfigPlan struct {
+	Fingerprint string
+	Changes     []*ConfigChange
+	Result      *PolicyDocument
+}
+
+// 現在のポリシーとエージェントを文書と名前で照合して計画を作成する. 照合したポリシーとエージェントには変更を書き込む.
+// Fingerprintは現在の状態と文書の内容から求めるため, どちらかが変わると異なる値になる.
+func NewConfigPlan(document *PolicyDocument, policies []*Policy, agents []*Agent, mode string) *ConfigPlan {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n", mode)
+	ExportPolicyDocument(policies, agents).digest(h)
+	document.digest(h)
+
+	plan := &ConfigPlan{
+		Fingerprint: hex.EncodeToString(h.Sum(nil)),
+		Changes:     []*ConfigChange{},
+	}
+
+	importedPolicies := make(map[string]*Policy, len(document.Policies))
+	resultPolicies := []*Policy{}
+	for _, source := range document.Policies {
+		i := slices.IndexFunc(policies, func(policy *Policy) bool {
+			return policy.Name == source.Name
+		})
+		if i < 0 {
+			plan.Changes = append(plan.Changes, &ConfigChange{Resource: ConfigResourcePolicy, Action: ConfigActionCreate, Name: source.Name, Fields: []string{}, Policy: source})
+			importedPolicies[source.Name] = source
+			resultPolicies = append(resultPolicies, source)
+			continue
+		}
+
+		policy := policies[i]
+		importedPolicies[policy.Name] = policy
+		resultPolicies = append(resultPolicies, policy)
+		if fields := policy.Merge(source); len(fields) > 0 {
+			plan.Changes = append(plan.Changes, &ConfigChange{Resource: ConfigResourcePolicy, Action: ConfigActionUpdate, Name: policy.Name, Fields: fields, Policy: policy})
+		}
+	}
+	for _, policy := range policies {
+		if _, ok := importedPolicies[policy.Name]; ok {
+			continue
+		}
+		if mode == PolicyImportModeMerge {
+			resultPolicies = append(resultPolicies, policy)
+			continue
+		}
+		plan.Changes = append(plan.Changes, &ConfigChange{Resource: ConfigResourcePolicy, Action: ConfigActionDelete, Name: policy.Name, Fields: []string{}, Policy: policy})
+	}
+
+	resultAgents := []*Agent{}
+	for _, source := range document.Agents {
+		i := slices.IndexFunc(agents, func(agent *Agent) bool {
+			return agent.Name == source.Name
+		})
+		if i < 0 {
+			document.ApplyBindings(source, importedPolicies, mode)
+			plan.Changes = append(plan.Changes, &ConfigChange{Resource: ConfigResourceAgent, Action: ConfigActionCreate, Name: source.Name, Fields: []string{}, Agent: source})
+			resultAgents = append(resultAgents, source)
+			continue
+		}
+
+		agent := agents[i]
+		resultAgents = append(resultAgents, agent)
+		if document.ApplyBindings(agent, importedPolicies, mode) {
+			plan.Changes = append(plan.Changes, &ConfigChange{Resource: ConfigResourceAgent, Action: ConfigActionUpdate, Name: agent.Name, Fields: []string{"policies"}, Agent: agent})
+		}
+	}
+	for _, agent := range agents {
+		if slices.Contains(resultAgents, agent) {
+			continue
+		}
+		if mode == PolicyImportModeMerge {
+			resultAgents = append(resultAgents, agent)
+			continue
+		}
+		plan.Changes = append(plan.Changes, &ConfigChange{Resource: ConfigResourceAgent, Action: ConfigActionDelete, Name: agent.Name, Fields: []string{}, Agent: agent})
+	}
+
+	plan.Result = ExportPolicyDocument(resultPolicies, resultAgents)
+	return plan
+}
+
+// 計画の作成後に現在の状態か文書が変わっていないことを確かめる.
+func (p *ConfigPlan) Verify(fingerprint string) error {
+	if p.Fingerprint != fingerprint {
+		return ErrConfigStateChanged
+	}
+	return nil
+}
+
+// IDと作成, 更新日時を除いた文書の内容を書き込む. 一覧の取得順に依存しないよう, 各行を並べ替えてから書き込む.
+func (d *PolicyDocument) digest(w io.Writer) {
+	lines := []string{fmt.Sprintf("version %d", d.Version)}
+	for _, policy := range d.Policies {
+		conditions, _ := json.Marshal(policy.Conditions)
+		validity, _ := json.Marshal([]*time.Time{policy.ValidFrom, policy.ValidUntil})
+		lines = append(lines, fmt.Sprintf("policy %q %q %q %q %q %s %q %s", policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, conditions, policy.Expression, validity))
+	}
+	for _, agent := range d.Agents {
+		lines = append(lines, fmt.Sprintf("agent %q", agent.Name))
+	}
+	for _, binding := range d.Bindings {
+		policies := slices.Clone(binding.Policies)
+		slices.Sort(policies)
+		lines = append(lines, fmt.Sprintf("binding %q %q", binding.Agent, policies))
+	}
+	slices.Sort(lines)
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+}
@@ -0,0 +1,137 @@
+package entity_test
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+type configChange struct {
+	Resource string
+	Action   string
+	Name     string
+	Fields   []string
+}
+
+func TestNewConfigPlan(t *testing.T) {
+	userID := uuid.New()
+	newPolicies := func() []*entity.Policy {
+		return []*entity.Policy{
+			entity.RestorePolicy(uuid.New(), userID, "policy1", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now()),
+			entity.RestorePolicy(uuid.New(), userID, "other", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now()),
+		}
+	}
+	newAgents := func() []*entity.Agent {
+		return []*entity.Agent{
+			entity.RestoreAgent(uuid.New(), userID, "agent1", []uuid.UUID{}, nil, nil, time.Now(), time.Now()),
+			entity.RestoreAgent(uuid.New(), userID, "other_agent", []uuid.UUID{}, nil, nil, time.Now(), time.Now()),
+		}
+	}
+	newDocument := func() *entity.PolicyDocument {
+		policy1, err := entity.NewPolicy(userID, "policy1", "DENY", "STORAGE", "/", []string{"GET"})
+		if err != nil {
+			t.Error(err.Error())
+		}
+		policy2, err := entity.NewPolicy(userID, "policy2", "ALLOW", "STORAGE", "/", []string{"POST"})
+		if err != nil {
+			t.Error(err.Error())
+		}
+		agent1, err := entity.NewAgent(userID, "agent1")
+		if err != nil {
+			t.Error(err.Error())
+		}
+		agent2, err := entity.NewAgent(userID, "agent2")
+		if err != nil {
+			t.Error(err.Error())
+		}
+		document, err := entity.NewPolicyDocument(entity.PolicyDocumentVersion, []*entity.Policy{policy1, policy2}, []*entity.Agent{agent1, agent2}, []*entity.PolicyBinding{{Agent: "agent1", Policies: []string{"policy2"}}})
+		if err != nil {
+			t.Error(err.Error())
+		}
+		return document
+	}
+
+	tests := []struct {
+		name          string
+		inputMode     string
+		expectChanges []configChange
+	}{
+		{
+			name:      "replace",
+			inputMode: entity.PolicyImportModeReplace,
+			expectChanges: []configChange{
+				{Resource: entity.ConfigResourcePolicy, Action: entity.ConfigActionUpdate, Name: "policy1", Fields: []string{"effect"}},
+				{Resource: entity.ConfigResourcePolicy, Action: entity.ConfigActionCreate, Name: "policy2", Fields: []string{}},
+				{Resource: entity.ConfigResourcePolicy, Action: entity.ConfigActionDelete, Name: "other", Fields: []string{}},
+				{Resource: entity.ConfigResourceAgent, Action: entity.ConfigActionUpdate, Name: "agent1", Fields: []string{"policies"}},
+				{Resource: entity.ConfigResourceAgent, Action: entity.ConfigActionCreate, Name: "agent2", Fields: []string{}},
+				{Resource: entity.ConfigResourceAgent, Action: entity.ConfigActionDelete, Name: "other_agent", Fields: []string{}},
+			},
+		},
+		{
+			name:      "merge",
+			inputMode: entity.PolicyImportModeMerge,
+			expectChanges: []configChange{
+				{Resource: entity.ConfigResourcePolicy, Action: entity.ConfigActionUpdate, Name: "policy1", Fields: []string{"effect"}},
+				{Resource: entity.ConfigResourcePolicy, Action: entity.ConfigActionCreate, Name: "policy2", Fields: []string{}},
+				{Resource: entity.ConfigResourceAgent, Action: entity.ConfigActionUpdate, Name: "agent1", Fields: []string{"policies"}},
+				{Resource: entity.ConfigResourceAgent, Action: entity.ConfigActionCreate, Name: "agent2", Fields: []string{}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := entity.NewConfigPlan(newDocument(), newPolicies(), newAgents(), tt.inputMode)
+			changes := make([]configChange, len(plan.Changes))
+			for i, change := range plan.Changes {
+				changes[i] = configChange{Resource: change.Resource, Action: change.Action, Name: change.Name, Fields: change.Fields}
+			}
+			if diff := cmp.Diff(tt.expectChanges, changes); diff != "" {
+				t.Error(diff)
+			}
+
+			// IDと日時が異なっても, 状態と文書の内容が同じであれば同じFingerprintとなる.
+			if err := entity.NewConfigPlan(newDocument(), newPolicies(), newAgents(), tt.inputMode).Verify(plan.Fingerprint); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestConfigPlan_Verify(t *testing.T) {
+	userID := uuid.New()
+	document, err := entity.NewPolicyDocument(entity.PolicyDocumentVersion, []*entity.Policy{}, []*entity.Agent{}, []*entity.PolicyBinding{})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policies := []*entity.Policy{entity.RestorePolicy(uuid.New(), userID, "policy", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now())}
+	plan := entity.NewConfigPlan(document, policies, []*entity.Agent{}, entity.PolicyImportModeReplace)
+
+	tests := []struct {
+		name          string
+		inputPolicies []*entity.Policy
+		expectError   error
+	}{
+		{
+			name:          "same state",
+			inputPolicies: []*entity.Policy{entity.RestorePolicy(uuid.New(), userID, "policy", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now())},
+			expectError:   nil,
+		},
+		{
+			name:          "state changed",
+			inputPolicies: []*entity.Policy{entity.RestorePolicy(uuid.New(), userID, "policy", "DENY", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now())},
+			expectError:   entity.ErrConfigStateChanged,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := entity.NewConfigPlan(document, tt.inputPolicies, []*entity.Agent{}, entity.PolicyImportModeReplace).Verify(plan.Fingerprint)
+			if err != tt.expectError {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// ポリシーがエージェントに適用される経路.
+const (
+	PolicySourceTypeDirect = "DIRECT"
+	PolicySourceTypeGroup  = "GROUP"
+	PolicySourceTypeRole   = "ROLE"
+)
+
+// 直接付与された場合は期間の有無によらずエージェント, それ以外はグループまたはロールを表す.
+type PolicySource struct {
+	Type string
+	ID   uuid.UUID
+	Name string
+}
+
+type EffectivePolicy struct {
+	Policy  *Policy
+	Sources []*PolicySource
+}
+
+// エージェントに適用されるポリシーを経路とともに返す. 複数の経路で適用されるポリシーはすべての経路を持つ.
+// ポリシーの順序はpoliciesの順序に従い, 経路は直接, グループ, ロールの順に並べる.
+func NewEffectivePolicies(agent *Agent, groups []*PolicyBundle, roles []*PolicyBundle, policies []*Policy) []*EffectivePolicy {
+	effectivePolicies := []*EffectivePolicy{}
+	for _, policy := range policies {
+		sources := []*PolicySource{}
+		if slices.Contains(agent.Policies, policy.ID) || slices.Contains(agent.TemporaryPolicies, policy.ID) {
+			sources = append(sources, &PolicySource{Type: PolicySourceTypeDirect, ID: agent.ID, Name: agent.Name})
+		}
+		for _, group := range groups {
+			if slices.Contains(group.Policies, policy.ID) {
+				sources = append(sources, &PolicySource{Type: PolicySourceTypeGroup, ID: group.ID, Name: group.Name})
+			}
+		}
+		for _, role := range roles {
+			if slices.Contains(role.Policies, policy.ID) {
+				sources = append(sources, &PolicySource{Type: PolicySourceTypeRole, ID: role.ID, Name: role.Name})
+			}
+		}
+		if len(sources) == 0 {
+			continue
+		}
+		effectivePolicies = append(effectivePolicies, &EffectivePolicy{
+			Policy:  policy,
+			Sources: sources,
+		})
+	}
+	return effectivePolicies
+}
@@ -0,0 +1,98 @@
+package entity_test
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestNewEffectivePolicies(t *testing.T) {
+	userID := uuid.New()
+	directPolicy, err := entity.NewPolicy(userID, "direct", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	sharedPolicy, err := entity.NewPolicy(userID, "shared", "ALLOW", "STORAGE", "/files", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	rolePolicy, err := entity.NewPolicy(userID, "role", "DENY", "STORAGE", "/files", []string{"DELETE"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	unrelatedPolicy, err := entity.NewPolicy(userID, "unrelated", "ALLOW", "CONTENT", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	agent := entity.RestoreAgent(uuid.New(), userID, "agent", []uuid.UUID{directPolicy.ID, sharedPolicy.ID}, []uuid.UUID{}, []uuid.UUID{}, time.Now(), time.Now())
+	group := entity.RestorePolicyBundle(entity.PolicyBundleKindAgentGroup, uuid.New(), userID, "group", []uuid.UUID{sharedPolicy.ID}, []uuid.UUID{agent.ID}, time.Now(), time.Now())
+	role := entity.RestorePolicyBundle(entity.PolicyBundleKindRole, uuid.New(), userID, "role", []uuid.UUID{sharedPolicy.ID, rolePolicy.ID}, []uuid.UUID{agent.ID}, time.Now(), time.Now())
+
+	tests := []struct {
+		name          string
+		inputGroups   []*entity.PolicyBundle
+		inputRoles    []*entity.PolicyBundle
+		inputPolicies []*entity.Policy
+		expectResult  []*entity.EffectivePolicy
+	}{
+		{
+			name:          "direct only",
+			inputGroups:   []*entity.PolicyBundle{},
+			inputRoles:    []*entity.PolicyBundle{},
+			inputPolicies: []*entity.Policy{directPolicy, sharedPolicy},
+			expectResult: []*entity.EffectivePolicy{
+				{
+					Policy:  directPolicy,
+					Sources: []*entity.PolicySource{{Type: entity.PolicySourceTypeDirect, ID: agent.ID, Name: agent.Name}},
+				},
+				{
+					Policy:  sharedPolicy,
+					Sources: []*entity.PolicySource{{Type: entity.PolicySourceTypeDirect, ID: agent.ID, Name: agent.Name}},
+				},
+			},
+		},
+		{
+			name:          "multiple sources",
+			inputGroups:   []*entity.PolicyBundle{group},
+			inputRoles:    []*entity.PolicyBundle{role},
+			inputPolicies: []*entity.Policy{directPolicy, sharedPolicy, rolePolicy},
+			expectResult: []*entity.EffectivePolicy{
+				{
+					Policy:  directPolicy,
+					Sources: []*entity.PolicySource{{Type: entity.PolicySourceTypeDirect, ID: agent.ID, Name: agent.Name}},
+				},
+				{
+					Policy: sharedPolicy,
+					Sources: []*entity.PolicySource{
+						{Type: entity.PolicySourceTypeDirect, ID: agent.ID, Name: agent.Name},
+						{Type: entity.PolicySourceTypeGroup, ID: group.ID, Name: group.Name},
+						{Type: entity.PolicySourceTypeRole, ID: role.ID, Name: role.Name},
+					},
+				},
+				{
+					Policy:  rolePolicy,
+					Sources: []*entity.PolicySource{{Type: entity.PolicySourceTypeRole, ID: role.ID, Name: role.Name}},
+				},
+			},
+		},
+		{
+			name:          "policy without source",
+			inputGroups:   []*entity.PolicyBundle{},
+			inputRoles:    []*entity.PolicyBundle{},
+			inputPolicies: []*entity.Policy{unrelatedPolicy},
+			expectResult:  []*entity.EffectivePolicy{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := entity.NewEffectivePolicies(agent, tt.inputGroups, tt.inputRoles, tt.inputPolicies)
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
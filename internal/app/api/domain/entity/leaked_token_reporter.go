@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	LeakedTokenReportAlgorithm = "HOLOS-REPORTER-HMAC-SHA256"
+	LeakedTokenReportClockSkew = time.Minute * 5
+	LeakedTokenReportMaxTokens = 100
+	// 認証前に読み込むため, リクエストボディの大きさを制限する.
+	LeakedTokenReportMaxBodySize = 1 << 20
+)
+
+var (
+	ErrInvalidLeakedTokenReport = status.Error(http.StatusUnauthorized, "invalid leaked token report")
+	ErrTooManyLeakedTokens      = status.Error(http.StatusBadRequest, "leaked tokens must be 100 or less")
+)
+
+type LeakedTokenReporter struct {
+	KeyID  string
+	Secret string
+}
+
+func NewLeakedTokenReporter(keyID string, secret string) *LeakedTokenReporter {
+	return &LeakedTokenReporter{
+		KeyID:  keyID,
+		Secret: secret,
+	}
+}
+
+// タイムスタンプとリクエストボディを改行で連結した文字列のHMAC-SHA256を16進数で表現したもの.
+func (r *LeakedTokenReporter) Sign(timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(r.Secret))
+	mac.Write([]byte(timestamp + "\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (r *LeakedTokenReporter) VerifySignature(signature string, timestamp string, body []byte, now time.Time) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidLeakedTokenReport
+	}
+	if diff := now.Sub(time.Unix(unix, 0)); diff < -LeakedTokenReportClockSkew || LeakedTokenReportClockSkew < diff {
+		return ErrInvalidLeakedTokenReport
+	}
+	if !hmac.Equal([]byte(r.Sign(timestamp, body)), []byte(signature)) {
+		return ErrInvalidLeakedTokenReport
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package entity_test
+
+import (
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLeakedTokenReporter_VerifySignature(t *testing.T) {
+	reporter := entity.NewLeakedTokenReporter("scanner", "secret")
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	expiredTimestamp := strconv.FormatInt(now.Add(-entity.LeakedTokenReportClockSkew*2).Unix(), 10)
+	body := []byte(`{"tokens": ["token"]}`)
+
+	tests := []struct {
+		name           string
+		inputSignature string
+		inputTimestamp string
+		inputBody      []byte
+		expectError    error
+	}{
+		{
+			name:           "success",
+			inputSignature: reporter.Sign(timestamp, body),
+			inputTimestamp: timestamp,
+			inputBody:      body,
+			expectError:    nil,
+		},
+		{
+			name:           "signature mismatch",
+			inputSignature: entity.NewLeakedTokenReporter("scanner", "another").Sign(timestamp, body),
+			inputTimestamp: timestamp,
+			inputBody:      body,
+			expectError:    entity.ErrInvalidLeakedTokenReport,
+		},
+		{
+			name:           "tampered body",
+			inputSignature: reporter.Sign(timestamp, body),
+			inputTimestamp: timestamp,
+			inputBody:      []byte(`{"tokens": []}`),
+			expectError:    entity.ErrInvalidLeakedTokenReport,
+		},
+		{
+			name:           "expired timestamp",
+			inputSignature: reporter.Sign(expiredTimestamp, body),
+			inputTimestamp: expiredTimestamp,
+			inputBody:      body,
+			expectError:    entity.ErrInvalidLeakedTokenReport,
+		},
+		{
+			name:           "invalid timestamp",
+			inputSignature: reporter.Sign("timestamp", body),
+			inputTimestamp: "timestamp",
+			inputBody:      body,
+			expectError:    entity.ErrInvalidLeakedTokenReport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := reporter.VerifySignature(tt.inputSignature, tt.inputTimestamp, tt.inputBody, now); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// すべてのメソッドに一致するメソッド.
+const MethodAny = "*"
+
+// ポリシーとサービスに指定できるメソッド.
+var supportedMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+	MethodAny,
+}
+
+// メソッドを大文字にして並べ替え, 重複を取り除く. *を含む場合は*のみとする.
+// 指定できないメソッドを含む場合はfalseを返す.
+func normalizeMethods(methods []string) ([]string, bool) {
+	normalized := make([]string, len(methods))
+	for i, method := range methods {
+		normalized[i] = strings.ToUpper(strings.TrimSpace(method))
+		if !slices.Contains(supportedMethods, normalized[i]) {
+			return nil, false
+		}
+	}
+	if slices.Contains(normalized, MethodAny) {
+		return []string{MethodAny}, true
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized), true
+}
+
+// headImpliedByGetがtrueの場合, GETを含むメソッドはHEADにも一致する.
+func matchMethod(methods []string, method string, headImpliedByGet bool) bool {
+	if slices.Contains(methods, MethodAny) || slices.Contains(methods, method) {
+		return true
+	}
+	return headImpliedByGet && method == http.MethodHead && slices.Contains(methods, http.MethodGet)
+}
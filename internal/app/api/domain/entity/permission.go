@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidPermissionPeriod  = status.Error(http.StatusBadRequest, "valid_until must be after valid_from")
+	ErrPermissionAlreadyExpired = status.Error(http.StatusBadRequest, "valid_until must be in the future")
+)
+
+// エージェントとポリシーの紐付け. ValidFromとValidUntilがnilの場合はその側に期限がない.
+type Permission struct {
+	AgentID    uuid.UUID
+	PolicyID   uuid.UUID
+	UserID     uuid.UUID
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+}
+
+func NewPermission(agent *Agent, policy *Policy, validFrom *time.Time, validUntil *time.Time, now time.Time) (*Permission, error) {
+	if validFrom != nil && validUntil != nil && !validFrom.Before(*validUntil) {
+		return nil, ErrInvalidPermissionPeriod
+	}
+	if validUntil != nil && !now.Before(*validUntil) {
+		return nil, ErrPermissionAlreadyExpired
+	}
+
+	return &Permission{
+		AgentID:    agent.ID,
+		PolicyID:   policy.ID,
+		UserID:     agent.UserID,
+		ValidFrom:  validFrom,
+		ValidUntil: validUntil,
+	}, nil
+}
+
+func RestorePermission(agentID uuid.UUID, policyID uuid.UUID, userID uuid.UUID, validFrom *time.Time, validUntil *time.Time) *Permission {
+	return &Permission{
+		AgentID:    agentID,
+		PolicyID:   policyID,
+		UserID:     userID,
+		ValidFrom:  validFrom,
+		ValidUntil: validUntil,
+	}
+}
+
+// 有効期間の終了時刻を過ぎたか判定する. 終了時刻ちょうどは期限切れとして扱う.
+func (p *Permission) IsExpired(now time.Time) bool {
+	return p.ValidUntil != nil && !now.Before(*p.ValidUntil)
+}
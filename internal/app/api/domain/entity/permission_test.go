@@ -0,0 +1,126 @@
+package entity_test
+
+import (
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestNewPermission(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(agent.UserID, "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	farFuture := now.Add(2 * time.Hour)
+
+	tests := []struct {
+		name            string
+		inputValidFrom  *time.Time
+		inputValidUntil *time.Time
+		expectError     error
+	}{
+		{
+			name:            "permanent",
+			inputValidFrom:  nil,
+			inputValidUntil: nil,
+			expectError:     nil,
+		},
+		{
+			name:            "window",
+			inputValidFrom:  &future,
+			inputValidUntil: &farFuture,
+			expectError:     nil,
+		},
+		{
+			name:            "started in the past",
+			inputValidFrom:  &past,
+			inputValidUntil: &future,
+			expectError:     nil,
+		},
+		{
+			name:            "valid_until before valid_from",
+			inputValidFrom:  &farFuture,
+			inputValidUntil: &future,
+			expectError:     entity.ErrInvalidPermissionPeriod,
+		},
+		{
+			name:            "same valid_from and valid_until",
+			inputValidFrom:  &future,
+			inputValidUntil: &future,
+			expectError:     entity.ErrInvalidPermissionPeriod,
+		},
+		{
+			name:            "already expired",
+			inputValidFrom:  nil,
+			inputValidUntil: &past,
+			expectError:     entity.ErrPermissionAlreadyExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permission, err := entity.NewPermission(agent, policy, tt.inputValidFrom, tt.inputValidUntil, now)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				expect := entity.RestorePermission(agent.ID, policy.ID, agent.UserID, tt.inputValidFrom, tt.inputValidUntil)
+				if diff := cmp.Diff(expect, permission); diff != "" {
+					t.Error(diff)
+				}
+			}
+		})
+	}
+}
+
+func TestPermission_IsExpired(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name            string
+		inputValidUntil *time.Time
+		expectResult    bool
+	}{
+		{
+			name:            "no valid_until",
+			inputValidUntil: nil,
+			expectResult:    false,
+		},
+		{
+			name:            "future",
+			inputValidUntil: &future,
+			expectResult:    false,
+		},
+		{
+			name:            "now",
+			inputValidUntil: &now,
+			expectResult:    true,
+		},
+		{
+			name:            "past",
+			inputValidUntil: &past,
+			expectResult:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permission := entity.RestorePermission(uuid.New(), uuid.New(), uuid.New(), nil, tt.inputValidUntil)
+			if result := permission.IsExpired(now); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"holos-auth-api/internal/app/api/domain/pkg/expression"
+	"holos-auth-api/internal/app/api/domain/pkg/pathpattern"
 	"holos-auth-api/internal/app/api/pkg/status"
 	"net/http"
 	"regexp"
@@ -21,19 +23,29 @@ var (
 	ErrInvalidPolicyPath     = status.Error(http.StatusBadRequest, "invalid policy path")
 	ErrRequiredPolicyMethods = status.Error(http.StatusBadRequest, "policy methods is required")
 	ErrInvalidPolicyMethods  = status.Error(http.StatusBadRequest, "invalid policy methods")
+
+	ErrPolicyExpressionTooLong = status.Error(http.StatusBadRequest, "policy expression must be 4096 characters or less")
+	ErrInvalidPolicyPeriod     = status.Error(http.StatusBadRequest, "policy valid_until must be after valid_from")
+
+	ErrPolicyAlreadyExists = status.Error(http.StatusConflict, "policy already exists")
 )
 
+// ValidFromとValidUntilがnilの場合はその側に期限がない.
 type Policy struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	Name      string
-	Effect    string
-	Service   string
-	Path      string
-	Methods   []string
-	Agents    []uuid.UUID
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Effect     string
+	Service    string
+	Path       string
+	Methods    []string
+	Conditions *PolicyConditions
+	Expression string
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+	Agents     []uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 func NewPolicy(userID uuid.UUID, name string, effect string, service string, path string, methods []string) (*Policy, error) {
@@ -71,18 +83,22 @@ func NewPolicy(userID uuid.UUID, name string, effect string, service string, pat
 	return policy, nil
 }
 
-func RestorePolicy(id uuid.UUID, userID uuid.UUID, name string, effect string, service string, path string, methods []string, agents []uuid.UUID, createdAt time.Time, updatedAt time.Time) *Policy {
+func RestorePolicy(id uuid.UUID, userID uuid.UUID, name string, effect string, service string, path string, methods []string, conditions *PolicyConditions, expression string, validFrom *time.Time, validUntil *time.Time, agents []uuid.UUID, createdAt time.Time, updatedAt time.Time) *Policy {
 	return &Policy{
-		ID:        id,
-		UserID:    userID,
-		Name:      name,
-		Effect:    effect,
-		Service:   service,
-		Path:      path,
-		Methods:   methods,
-		Agents:    agents,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+		ID:         id,
+		UserID:     userID,
+		Name:       name,
+		Effect:     effect,
+		Service:    service,
+		Path:       path,
+		Methods:    methods,
+		Conditions: conditions,
+		Expression: expression,
+		ValidFrom:  validFrom,
+		ValidUntil: validUntil,
+		Agents:     agents,
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
 	}
 }
 
@@ -115,8 +131,9 @@ func (p *Policy) SetEffect(effect string) error {
 	return nil
 }
 
+// サービスが登録されているかはサービスレジストリで検証するため, ここでは名前の形式のみ検証する.
 func (p *Policy) SetService(service string) error {
-	if !slices.Contains([]string{"STORAGE", "CONTENT"}, service) {
+	if len(service) < 3 || 64 < len(service) || !serviceNamePattern.MatchString(service) {
 		return ErrInvalidPolicyService
 	}
 
@@ -135,13 +152,18 @@ func (p *Policy) SetPath(path string) error {
 	if path[0] != '/' || path[len(path)-1:] == "/" && 1 < len(path) {
 		return ErrInvalidPolicyPath
 	}
-	matched, err := regexp.MatchString(`^[a-z\-:/]*$`, path)
+	matched, err := regexp.MatchString(`^[a-z0-9_.\-:/*${}]*$`, path)
 	if err != nil {
 		return err
 	}
-	if !matched {
+	if !matched || !pathpattern.Validate(path) {
 		return ErrInvalidPolicyPath
 	}
+	for _, name := range pathpattern.Placeholders(path) {
+		if !IsPrincipalAttributeName(name) {
+			return ErrInvalidPolicyPath
+		}
+	}
 
 	p.Path = path
 	p.UpdatedAt = time.Now()
@@ -152,18 +174,131 @@ func (p *Policy) SetMethods(methods []string) error {
 	if len(methods) == 0 {
 		return ErrRequiredPolicyMethods
 	}
-	for _, v := range methods {
-		if !slices.Contains([]string{"GET", "POST", "PUT", "DELETE"}, v) {
-			return ErrInvalidPolicyMethods
+	methods, ok := normalizeMethods(methods)
+	if !ok {
+		return ErrInvalidPolicyMethods
+	}
+
+	p.Methods = methods
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// 条件がnilの場合は無条件で適用される.
+func (p *Policy) SetConditions(conditions *PolicyConditions) {
+	p.Conditions = conditions
+	p.UpdatedAt = time.Now()
+}
+
+// 終了日時を過ぎた有効期間も設定できる. 期間外のポリシーは判定で使わないだけで, 削除はしない.
+func (p *Policy) SetValidity(validFrom *time.Time, validUntil *time.Time) error {
+	if validFrom != nil && validUntil != nil && !validFrom.Before(*validUntil) {
+		return ErrInvalidPolicyPeriod
+	}
+
+	p.ValidFrom = validFrom
+	p.ValidUntil = validUntil
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// 有効期間内か判定する. 終了日時ちょうどは期間外として扱う.
+func (p *Policy) IsValidAt(t time.Time) bool {
+	if p.ValidFrom != nil && t.Before(*p.ValidFrom) {
+		return false
+	}
+	return p.ValidUntil == nil || t.Before(*p.ValidUntil)
+}
+
+// CEL式を設定する. 空文字の場合は式による判定を行わない.
+// コンパイルと型検査に失敗した場合はその内容をエラーとして返す.
+func (p *Policy) SetExpression(expr string) error {
+	if 4096 < len(expr) {
+		return ErrPolicyExpressionTooLong
+	}
+	if expr != "" {
+		if _, err := expression.Compile(expr); err != nil {
+			return status.Error(http.StatusBadRequest, "invalid policy expression: "+err.Error())
 		}
 	}
-	slices.Sort(methods)
 
-	p.Methods = slices.Compact(methods)
+	p.Expression = expr
 	p.UpdatedAt = time.Now()
 	return nil
 }
 
+// サービス, メソッド, パス, 条件, CEL式がすべて一致する場合にリクエストへ適用される.
+// 一致しない場合は最初に一致しなかった項目を結果として返す. headImpliedByGetがtrueの場合, GETを含むポリシーはHEADにも一致する.
+func (p *Policy) Evaluate(principal *Principal, request *AuthorizationRequest, headImpliedByGet bool) (*PolicyEvaluation, error) {
+	path, resolved := p.ResolvePath(principal)
+	return p.evaluate(principal, request, headImpliedByGet, path, resolved && path.Match(request.Path))
+}
+
+// パスの${name}をエージェントの属性に置き換える. 値がない場合, 安全側に倒してDENYは*とみなし, ALLOWはどのパスにも一致させない.
+func (p *Policy) ResolvePath(principal *Principal) (*pathpattern.Pattern, bool) {
+	return pathpattern.Compile(p.Path).Resolve(principal.Lookup, p.Effect == "DENY")
+}
+
+// pathは置き換え済みのパス, pathMatchedはリクエストのパスがそれに一致するか. 呼び出し元でまとめて照合した結果を渡せるようにする.
+func (p *Policy) evaluate(principal *Principal, request *AuthorizationRequest, headImpliedByGet bool, path *pathpattern.Pattern, pathMatched bool) (*PolicyEvaluation, error) {
+	agent := principal.Agent
+	evaluation := &PolicyEvaluation{Policy: p}
+
+	if request.Service != p.Service {
+		evaluation.Result = PolicyMatchResultServiceMismatch
+		return evaluation, nil
+	}
+	if !matchMethod(p.Methods, request.Method, headImpliedByGet) {
+		evaluation.Result = PolicyMatchResultMethodMismatch
+		return evaluation, nil
+	}
+
+	if !pathMatched {
+		evaluation.Result = PolicyMatchResultPathMismatch
+		return evaluation, nil
+	}
+	if !p.IsValidAt(request.RequestedAt) {
+		evaluation.Result = PolicyMatchResultOutsideValidity
+		return evaluation, nil
+	}
+
+	// :paramの値は条件か式で参照する場合にのみ取り出す.
+	var params map[string]string
+	if p.Conditions != nil && len(p.Conditions.PathParams) != 0 || p.Expression != "" {
+		params = path.Params(request.Path)
+	}
+	if p.Conditions != nil && (!p.Conditions.Evaluate(request) || !p.Conditions.EvaluatePathParams(params, principal)) {
+		evaluation.Result = PolicyMatchResultConditionsNotMet
+		return evaluation, nil
+	}
+
+	if p.Expression != "" {
+		result, err := expression.Evaluate(p.Expression, map[string]any{
+			"agent":       map[string]string{"id": agent.ID.String(), "name": agent.Name},
+			"user":        map[string]string{"id": agent.UserID.String(), "name": principal.UserName},
+			"service":     request.Service,
+			"path":        request.Path,
+			"path_params": params,
+			"method":      request.Method,
+			"attributes":  request.Attributes,
+		})
+		if err != nil {
+			// 評価に失敗した式は安全側に倒し, DENYは適用し, ALLOWは適用しない.
+			evaluation.Matched = p.Effect == "DENY"
+			evaluation.Result = PolicyMatchResultExpressionError
+			return evaluation, nil
+		}
+		if !result {
+			evaluation.Result = PolicyMatchResultExpressionFalse
+			return evaluation, nil
+		}
+	}
+
+	evaluation.Matched = true
+	evaluation.Result = PolicyMatchResultMatched
+	return evaluation, nil
+}
+
 func (p *Policy) SetAgents(agents []*Agent) {
 	ids := make([]uuid.UUID, len(agents))
 	for i, agent := range agents {
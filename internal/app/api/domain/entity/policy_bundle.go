@@ -0,0 +1,128 @@
+package entity
+
+import (
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRoleNameTooShort       = status.Error(http.StatusBadRequest, "role name must be 3 characters or more")
+	ErrRoleNameTooLong        = status.Error(http.StatusBadRequest, "role name must be 255 characters or less")
+	ErrInvalidRoleName        = status.Error(http.StatusBadRequest, "invalid role name")
+	ErrAgentGroupNameTooShort = status.Error(http.StatusBadRequest, "agent group name must be 3 characters or more")
+	ErrAgentGroupNameTooLong  = status.Error(http.StatusBadRequest, "agent group name must be 255 characters or less")
+	ErrInvalidAgentGroupName  = status.Error(http.StatusBadRequest, "invalid agent group name")
+)
+
+// ロールとエージェントグループは, どちらも名前を付けた複数のポリシーをエージェントに付与する単位で, 保存先と名前のみが異なる.
+type PolicyBundleKind string
+
+const (
+	// 複数のポリシーをまとめ, エージェントに付与する単位.
+	PolicyBundleKindRole PolicyBundleKind = "ROLE"
+	// 同じポリシーを持つエージェントをまとめる単位. グループのポリシーは所属するエージェントすべてに適用される.
+	PolicyBundleKindAgentGroup PolicyBundleKind = "AGENT_GROUP"
+)
+
+type policyBundleNameErrors struct {
+	tooShort error
+	tooLong  error
+	invalid  error
+}
+
+var policyBundleNameErrorsByKind = map[PolicyBundleKind]policyBundleNameErrors{
+	PolicyBundleKindRole:       {tooShort: ErrRoleNameTooShort, tooLong: ErrRoleNameTooLong, invalid: ErrInvalidRoleName},
+	PolicyBundleKindAgentGroup: {tooShort: ErrAgentGroupNameTooShort, tooLong: ErrAgentGroupNameTooLong, invalid: ErrInvalidAgentGroupName},
+}
+
+type PolicyBundle struct {
+	Kind      PolicyBundleKind
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Name      string
+	Policies  []uuid.UUID
+	Agents    []uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewPolicyBundle(kind PolicyBundleKind, userID uuid.UUID, name string) (*PolicyBundle, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &PolicyBundle{
+		Kind:     kind,
+		ID:       id,
+		UserID:   userID,
+		Policies: []uuid.UUID{},
+		Agents:   []uuid.UUID{},
+	}
+
+	if err := bundle.SetName(name); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	bundle.CreatedAt = now
+	bundle.UpdatedAt = now
+
+	return bundle, nil
+}
+
+func RestorePolicyBundle(kind PolicyBundleKind, id uuid.UUID, userID uuid.UUID, name string, policies []uuid.UUID, agents []uuid.UUID, createdAt time.Time, updatedAt time.Time) *PolicyBundle {
+	return &PolicyBundle{
+		Kind:      kind,
+		ID:        id,
+		UserID:    userID,
+		Name:      name,
+		Policies:  policies,
+		Agents:    agents,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+func (b *PolicyBundle) SetName(name string) error {
+	errs := policyBundleNameErrorsByKind[b.Kind]
+	if len(name) < 3 {
+		return errs.tooShort
+	}
+	if 255 < len(name) {
+		return errs.tooLong
+	}
+	matched, err := regexp.MatchString(`^[A-Za-z0-9_]*$`, name)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return errs.invalid
+	}
+	b.Name = name
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+func (b *PolicyBundle) SetPolicies(policies []*Policy) {
+	ids := make([]uuid.UUID, len(policies))
+	for i, policy := range policies {
+		ids[i] = policy.ID
+	}
+	b.Policies = slices.Compact(ids)
+	b.UpdatedAt = time.Now()
+}
+
+func (b *PolicyBundle) SetAgents(agents []*Agent) {
+	ids := make([]uuid.UUID, len(agents))
+	for i, agent := range agents {
+		ids[i] = agent.ID
+	}
+	b.Agents = slices.Compact(ids)
+	b.UpdatedAt = time.Now()
+}
@@ -0,0 +1,238 @@
+package entity_test
+
+import (
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestNewPolicyBundle(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputKind   entity.PolicyBundleKind
+		inputUserID uuid.UUID
+		inputName   string
+		expectError error
+	}{
+		{
+			name:        "role",
+			inputKind:   entity.PolicyBundleKindRole,
+			inputUserID: uuid.New(),
+			inputName:   "name",
+			expectError: nil,
+		},
+		{
+			name:        "agent group",
+			inputKind:   entity.PolicyBundleKindAgentGroup,
+			inputUserID: uuid.New(),
+			inputName:   "name",
+			expectError: nil,
+		},
+		{
+			name:        "invalid role name",
+			inputKind:   entity.PolicyBundleKindRole,
+			inputUserID: uuid.New(),
+			inputName:   "なまえ",
+			expectError: entity.ErrInvalidRoleName,
+		},
+		{
+			name:        "invalid agent group name",
+			inputKind:   entity.PolicyBundleKindAgentGroup,
+			inputUserID: uuid.New(),
+			inputName:   "なまえ",
+			expectError: entity.ErrInvalidAgentGroupName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle, err := entity.NewPolicyBundle(tt.inputKind, tt.inputUserID, tt.inputName)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if bundle.Kind != tt.inputKind {
+					t.Errorf("kind: expect %s but got %s", tt.inputKind, bundle.Kind)
+				}
+				if bundle.ID == uuid.Nil {
+					t.Error("id: expect uuid but got empty")
+				}
+				if bundle.UserID != tt.inputUserID {
+					t.Errorf("user_id: expect %v but got %v", tt.inputUserID, bundle.UserID)
+				}
+				if bundle.Name != tt.inputName {
+					t.Errorf("name: expect %s but got %s", tt.inputName, bundle.Name)
+				}
+				if bundle.CreatedAt.IsZero() {
+					t.Error("created_at: expect time but got empty")
+				}
+				if !bundle.CreatedAt.Equal(bundle.UpdatedAt) {
+					t.Error("expect created_at and updated_at to be equal")
+				}
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_SetName(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputKind   entity.PolicyBundleKind
+		inputName   string
+		expectError error
+	}{
+		{
+			name:        "success",
+			inputKind:   entity.PolicyBundleKindRole,
+			inputName:   "sample_name01",
+			expectError: nil,
+		},
+		{
+			name:        "hyphen",
+			inputKind:   entity.PolicyBundleKindRole,
+			inputName:   "sample-name",
+			expectError: entity.ErrInvalidRoleName,
+		},
+		{
+			name:        "2 characters",
+			inputKind:   entity.PolicyBundleKindRole,
+			inputName:   strings.Repeat("a", 2),
+			expectError: entity.ErrRoleNameTooShort,
+		},
+		{
+			name:        "256 characters",
+			inputKind:   entity.PolicyBundleKindRole,
+			inputName:   strings.Repeat("a", 256),
+			expectError: entity.ErrRoleNameTooLong,
+		},
+		{
+			name:        "agent group hyphen",
+			inputKind:   entity.PolicyBundleKindAgentGroup,
+			inputName:   "sample-name",
+			expectError: entity.ErrInvalidAgentGroupName,
+		},
+		{
+			name:        "agent group 2 characters",
+			inputKind:   entity.PolicyBundleKindAgentGroup,
+			inputName:   strings.Repeat("a", 2),
+			expectError: entity.ErrAgentGroupNameTooShort,
+		},
+		{
+			name:        "agent group 256 characters",
+			inputKind:   entity.PolicyBundleKindAgentGroup,
+			inputName:   strings.Repeat("a", 256),
+			expectError: entity.ErrAgentGroupNameTooLong,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle, err := entity.NewPolicyBundle(tt.inputKind, uuid.New(), "name")
+			if err != nil {
+				t.Error(err.Error())
+			}
+			updatedAt := bundle.UpdatedAt
+			if err := bundle.SetName(tt.inputName); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if tt.expectError == nil {
+				if !bundle.UpdatedAt.After(updatedAt) {
+					t.Error("updatedAt has not been updated")
+				}
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_SetPolicies(t *testing.T) {
+	bundle, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name          string
+		inputPolicies []*entity.Policy
+		expectResult  []uuid.UUID
+	}{
+		{
+			name:          "success",
+			inputPolicies: []*entity.Policy{policy},
+			expectResult:  []uuid.UUID{policy.ID},
+		},
+		{
+			name:          "empty",
+			inputPolicies: []*entity.Policy{},
+			expectResult:  []uuid.UUID{},
+		},
+		{
+			name:          "duplication",
+			inputPolicies: []*entity.Policy{policy, policy},
+			expectResult:  []uuid.UUID{policy.ID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updatedAt := bundle.UpdatedAt
+			bundle.SetPolicies(tt.inputPolicies)
+			if diff := cmp.Diff(bundle.Policies, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+			if !bundle.UpdatedAt.After(updatedAt) {
+				t.Error("updatedAt has not been updated")
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_SetAgents(t *testing.T) {
+	bundle, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name         string
+		inputAgents  []*entity.Agent
+		expectResult []uuid.UUID
+	}{
+		{
+			name:         "success",
+			inputAgents:  []*entity.Agent{agent},
+			expectResult: []uuid.UUID{agent.ID},
+		},
+		{
+			name:         "empty",
+			inputAgents:  []*entity.Agent{},
+			expectResult: []uuid.UUID{},
+		},
+		{
+			name:         "duplication",
+			inputAgents:  []*entity.Agent{agent, agent},
+			expectResult: []uuid.UUID{agent.ID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updatedAt := bundle.UpdatedAt
+			bundle.SetAgents(tt.inputAgents)
+			if diff := cmp.Diff(bundle.Agents, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+			if !bundle.UpdatedAt.After(updatedAt) {
+				t.Error("updatedAt has not been updated")
+			}
+		})
+	}
+}
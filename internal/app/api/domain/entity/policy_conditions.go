@@ -0,0 +1,217 @@
+package entity
+
+import (
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"net/netip"
+	"regexp"
+	"slices"
+	"time"
+
+	// 実行イメージにタイムゾーンデータベースが含まれないため埋め込む.
+	_ "time/tzdata"
+)
+
+var (
+	ErrInvalidPolicyConditionsTimeZone    = status.Error(http.StatusBadRequest, "invalid policy conditions time zone")
+	ErrInvalidPolicyConditionsDaysOfWeek  = status.Error(http.StatusBadRequest, "invalid policy conditions days of week")
+	ErrInvalidPolicyConditionsTimeOfDay   = status.Error(http.StatusBadRequest, "invalid policy conditions time of day")
+	ErrInvalidPolicyConditionsDateRange   = status.Error(http.StatusBadRequest, "invalid policy conditions date range")
+	ErrInvalidPolicyConditionsSourceCIDRs = status.Error(http.StatusBadRequest, "invalid policy conditions source cidrs")
+	ErrInvalidPolicyConditionsAttributes  = status.Error(http.StatusBadRequest, "invalid policy conditions attributes")
+	ErrInvalidPolicyConditionsPathParams  = status.Error(http.StatusBadRequest, "invalid policy conditions path params")
+)
+
+var policyConditionsDaysOfWeek = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+const policyConditionsTimeOfDayLayout = "15:04"
+
+// ポリシーを適用する条件. 設定された条件をすべて満たす場合にのみポリシーが適用される.
+type PolicyConditions struct {
+	TimeZone    string
+	DaysOfWeek  []string
+	StartTime   string
+	EndTime     string
+	NotBefore   *time.Time
+	NotAfter    *time.Time
+	SourceCIDRs []string
+	Attributes  map[string][]string
+	// パスの:paramの名前と, その値に一致しなければならないエージェントの属性の名前.
+	PathParams map[string]string
+}
+
+func NewPolicyConditions(timeZone string, daysOfWeek []string, startTime string, endTime string, notBefore *time.Time, notAfter *time.Time, sourceCIDRs []string, attributes map[string][]string, pathParams map[string]string) (*PolicyConditions, error) {
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return nil, ErrInvalidPolicyConditionsTimeZone
+	}
+
+	for _, v := range daysOfWeek {
+		if !slices.Contains(policyConditionsDaysOfWeek, v) {
+			return nil, ErrInvalidPolicyConditionsDaysOfWeek
+		}
+	}
+	daysOfWeek = append([]string{}, daysOfWeek...)
+	slices.SortFunc(daysOfWeek, func(a, b string) int {
+		return slices.Index(policyConditionsDaysOfWeek, a) - slices.Index(policyConditionsDaysOfWeek, b)
+	})
+	daysOfWeek = slices.Compact(daysOfWeek)
+
+	if (startTime == "") != (endTime == "") {
+		return nil, ErrInvalidPolicyConditionsTimeOfDay
+	}
+	if startTime != "" {
+		start, err := time.Parse(policyConditionsTimeOfDayLayout, startTime)
+		if err != nil {
+			return nil, ErrInvalidPolicyConditionsTimeOfDay
+		}
+		end, err := time.Parse(policyConditionsTimeOfDayLayout, endTime)
+		if err != nil {
+			return nil, ErrInvalidPolicyConditionsTimeOfDay
+		}
+		if start.Equal(end) {
+			return nil, ErrInvalidPolicyConditionsTimeOfDay
+		}
+		startTime = start.Format(policyConditionsTimeOfDayLayout)
+		endTime = end.Format(policyConditionsTimeOfDayLayout)
+	}
+
+	if notBefore != nil && notAfter != nil && !notBefore.Before(*notAfter) {
+		return nil, ErrInvalidPolicyConditionsDateRange
+	}
+
+	for _, v := range sourceCIDRs {
+		if _, err := netip.ParsePrefix(v); err != nil {
+			return nil, ErrInvalidPolicyConditionsSourceCIDRs
+		}
+	}
+
+	if sourceCIDRs == nil {
+		sourceCIDRs = []string{}
+	}
+
+	if attributes == nil {
+		attributes = map[string][]string{}
+	}
+	for key, values := range attributes {
+		matched, err := regexp.MatchString(`^[a-z0-9_\-]{1,64}$`, key)
+		if err != nil {
+			return nil, err
+		}
+		if !matched || len(values) == 0 {
+			return nil, ErrInvalidPolicyConditionsAttributes
+		}
+	}
+
+	if pathParams == nil {
+		pathParams = map[string]string{}
+	}
+	for name, attribute := range pathParams {
+		matched, err := regexp.MatchString(`^[a-z\-]{1,64}$`, name)
+		if err != nil {
+			return nil, err
+		}
+		if !matched || !IsPrincipalAttributeName(attribute) {
+			return nil, ErrInvalidPolicyConditionsPathParams
+		}
+	}
+
+	return &PolicyConditions{
+		TimeZone:    timeZone,
+		DaysOfWeek:  daysOfWeek,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		NotBefore:   notBefore,
+		NotAfter:    notAfter,
+		SourceCIDRs: sourceCIDRs,
+		Attributes:  attributes,
+		PathParams:  pathParams,
+	}, nil
+}
+
+func RestorePolicyConditions(timeZone string, daysOfWeek []string, startTime string, endTime string, notBefore *time.Time, notAfter *time.Time, sourceCIDRs []string, attributes map[string][]string, pathParams map[string]string) *PolicyConditions {
+	return &PolicyConditions{
+		TimeZone:    timeZone,
+		DaysOfWeek:  daysOfWeek,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		NotBefore:   notBefore,
+		NotAfter:    notAfter,
+		SourceCIDRs: sourceCIDRs,
+		Attributes:  attributes,
+		PathParams:  pathParams,
+	}
+}
+
+// 曜日と時刻はタイムゾーンでの現地時刻で判定する. 終了時刻が開始時刻より前の場合は日付をまたぐ時間帯とみなす.
+func (c *PolicyConditions) Evaluate(request *AuthorizationRequest) bool {
+	location, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return false
+	}
+	requestedAt := request.RequestedAt.In(location)
+
+	if c.NotBefore != nil && requestedAt.Before(*c.NotBefore) {
+		return false
+	}
+	if c.NotAfter != nil && !requestedAt.Before(*c.NotAfter) {
+		return false
+	}
+
+	if len(c.DaysOfWeek) != 0 && !slices.Contains(c.DaysOfWeek, policyConditionsDaysOfWeek[requestedAt.Weekday()]) {
+		return false
+	}
+
+	if c.StartTime != "" {
+		now := requestedAt.Format(policyConditionsTimeOfDayLayout)
+		if c.StartTime < c.EndTime {
+			if now < c.StartTime || c.EndTime <= now {
+				return false
+			}
+		} else {
+			if now < c.StartTime && c.EndTime <= now {
+				return false
+			}
+		}
+	}
+
+	if len(c.SourceCIDRs) != 0 {
+		addr, err := netip.ParseAddr(request.SourceIP)
+		if err != nil {
+			return false
+		}
+		addr = addr.Unmap()
+		if !slices.ContainsFunc(c.SourceCIDRs, func(v string) bool {
+			prefix, err := netip.ParsePrefix(v)
+			return err == nil && prefix.Contains(addr)
+		}) {
+			return false
+		}
+	}
+
+	for key, values := range c.Attributes {
+		value, ok := request.Attributes[key]
+		if !ok || !slices.Contains(values, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// パスの:paramの値がエージェントの属性と一致するか判定する. パラメータや属性の値がない場合は満たさないものとする.
+func (c *PolicyConditions) EvaluatePathParams(params map[string]string, principal *Principal) bool {
+	for name, attribute := range c.PathParams {
+		param, ok := params[name]
+		if !ok {
+			return false
+		}
+		value, ok := principal.Lookup(attribute)
+		if !ok || value == "" || param != value {
+			return false
+		}
+	}
+	return true
+}
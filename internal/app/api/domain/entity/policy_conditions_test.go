@@ -0,0 +1,306 @@
+package entity_test
+
+import (
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestNewPolicyConditions(t *testing.T) {
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		inputTimeZone    string
+		inputDaysOfWeek  []string
+		inputStartTime   string
+		inputEndTime     string
+		inputNotBefore   *time.Time
+		inputNotAfter    *time.Time
+		inputSourceCIDRs []string
+		inputAttributes  map[string][]string
+		inputPathParams  map[string]string
+		expectTimeZone   string
+		expectDaysOfWeek []string
+		expectError      error
+	}{
+		{
+			name:             "success",
+			inputTimeZone:    "Asia/Tokyo",
+			inputDaysOfWeek:  []string{"FRI", "MON", "MON"},
+			inputStartTime:   "09:00",
+			inputEndTime:     "18:00",
+			inputNotBefore:   &notBefore,
+			inputNotAfter:    &notAfter,
+			inputSourceCIDRs: []string{"10.0.0.0/8", "2001:db8::/32"},
+			inputAttributes:  map[string][]string{"tenant": {"holos"}},
+			inputPathParams:  map[string]string{"user-id": "user.id", "team": "agent.attributes.team"},
+			expectTimeZone:   "Asia/Tokyo",
+			expectDaysOfWeek: []string{"MON", "FRI"},
+			expectError:      nil,
+		},
+		{
+			name:             "default time zone",
+			inputTimeZone:    "",
+			expectTimeZone:   "UTC",
+			expectDaysOfWeek: []string{},
+			expectError:      nil,
+		},
+		{
+			name:          "invalid time zone",
+			inputTimeZone: "Asia/Nowhere",
+			expectError:   entity.ErrInvalidPolicyConditionsTimeZone,
+		},
+		{
+			name:            "invalid days of week",
+			inputDaysOfWeek: []string{"MONDAY"},
+			expectError:     entity.ErrInvalidPolicyConditionsDaysOfWeek,
+		},
+		{
+			name:           "start time without end time",
+			inputStartTime: "09:00",
+			expectError:    entity.ErrInvalidPolicyConditionsTimeOfDay,
+		},
+		{
+			name:           "invalid time of day",
+			inputStartTime: "09:00",
+			inputEndTime:   "25:00",
+			expectError:    entity.ErrInvalidPolicyConditionsTimeOfDay,
+		},
+		{
+			name:           "empty time of day",
+			inputStartTime: "09:00",
+			inputEndTime:   "09:00",
+			expectError:    entity.ErrInvalidPolicyConditionsTimeOfDay,
+		},
+		{
+			name:           "invalid date range",
+			inputNotBefore: &notAfter,
+			inputNotAfter:  &notBefore,
+			expectError:    entity.ErrInvalidPolicyConditionsDateRange,
+		},
+		{
+			name:             "invalid source cidrs",
+			inputSourceCIDRs: []string{"10.0.0.1"},
+			expectError:      entity.ErrInvalidPolicyConditionsSourceCIDRs,
+		},
+		{
+			name:            "invalid attribute key",
+			inputAttributes: map[string][]string{"Tenant": {"holos"}},
+			expectError:     entity.ErrInvalidPolicyConditionsAttributes,
+		},
+		{
+			name:            "empty attribute values",
+			inputAttributes: map[string][]string{"tenant": {}},
+			expectError:     entity.ErrInvalidPolicyConditionsAttributes,
+		},
+		{
+			name:            "invalid path param name",
+			inputPathParams: map[string]string{"user_id": "user.id"},
+			expectError:     entity.ErrInvalidPolicyConditionsPathParams,
+		},
+		{
+			name:            "unknown principal attribute",
+			inputPathParams: map[string]string{"user-id": "user.email"},
+			expectError:     entity.ErrInvalidPolicyConditionsPathParams,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, err := entity.NewPolicyConditions(tt.inputTimeZone, tt.inputDaysOfWeek, tt.inputStartTime, tt.inputEndTime, tt.inputNotBefore, tt.inputNotAfter, tt.inputSourceCIDRs, tt.inputAttributes, tt.inputPathParams)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if conditions.TimeZone != tt.expectTimeZone {
+					t.Errorf("time_zone: expect %s but got %s", tt.expectTimeZone, conditions.TimeZone)
+				}
+				if diff := cmp.Diff(tt.expectDaysOfWeek, conditions.DaysOfWeek); diff != "" {
+					t.Error(diff)
+				}
+			}
+		})
+	}
+}
+
+func TestPolicyConditions_Evaluate(t *testing.T) {
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		inputConditions *entity.PolicyConditions
+		inputRequest    *entity.AuthorizationRequest
+		expectResult    bool
+	}{
+		{
+			name:            "empty conditions",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "", "", nil, nil, nil, nil, nil),
+			inputRequest:    &entity.AuthorizationRequest{RequestedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			expectResult:    true,
+		},
+		{
+			name:            "day of week in time zone",
+			inputConditions: entity.RestorePolicyConditions("Asia/Tokyo", []string{"TUE"}, "", "", nil, nil, nil, nil, nil),
+			inputRequest:    &entity.AuthorizationRequest{RequestedAt: time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)},
+			expectResult:    true,
+		},
+		{
+			name:            "day of week not matched",
+			inputConditions: entity.RestorePolicyConditions("UTC", []string{"TUE"}, "", "", nil, nil, nil, nil, nil),
+			inputRequest:    &entity.AuthorizationRequest{RequestedAt: time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)},
+			expectResult:    false,
+		},
+		{
+			name:            "within time of day",
+			inputConditions: entity.RestorePolicyConditions("Asia/Tokyo", nil, "09:00", "18:00", nil, nil, nil, nil, nil),
+			inputRequest:    &entity.AuthorizationRequest{RequestedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			expectResult:    true,
+		},
+		{
+			name:            "end of time of day is exclusive",
+			inputConditions: entity.RestorePolicyConditions("Asia/Tokyo", nil, "09:00", "18:00", nil, nil, nil, nil, nil),
+			inputRequest:    &entity.AuthorizationRequest{RequestedAt: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)},
+			expectResult:    false,
+		},
+		{
+			name:            "within time of day across midnight",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "22:00", "06:00", nil, nil, nil, nil, nil),
+			inputRequest:    &entity.AuthorizationRequest{RequestedAt: time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)},
+			expectResult:    true,
+		},
+		{
+			name:            "outside time of day across midnight",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "22:00", "06:00", nil, nil, nil, nil, nil),
+			inputRequest:    &entity.AuthorizationRequest{RequestedAt: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)},
+			expectResult:    false,
+		},
+		{
+			name:            "before date range",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "", "", &notBefore, &notAfter, nil, nil, nil),
+			inputRequest:    &entity.AuthorizationRequest{RequestedAt: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)},
+			expectResult:    false,
+		},
+		{
+			name:            "within date range",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "", "", &notBefore, &notAfter, nil, nil, nil),
+			inputRequest:    &entity.AuthorizationRequest{RequestedAt: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+			expectResult:    true,
+		},
+		{
+			name:            "after date range",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "", "", &notBefore, &notAfter, nil, nil, nil),
+			inputRequest:    &entity.AuthorizationRequest{RequestedAt: notAfter},
+			expectResult:    false,
+		},
+		{
+			name:            "source ip matched",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "", "", nil, nil, []string{"192.168.0.0/16", "10.0.0.0/8"}, nil, nil),
+			inputRequest:    &entity.AuthorizationRequest{SourceIP: "10.1.2.3", RequestedAt: notBefore},
+			expectResult:    true,
+		},
+		{
+			name:            "ipv4-mapped source ip matched",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "", "", nil, nil, []string{"10.0.0.0/8"}, nil, nil),
+			inputRequest:    &entity.AuthorizationRequest{SourceIP: "::ffff:10.1.2.3", RequestedAt: notBefore},
+			expectResult:    true,
+		},
+		{
+			name:            "source ip not matched",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "", "", nil, nil, []string{"10.0.0.0/8"}, nil, nil),
+			inputRequest:    &entity.AuthorizationRequest{SourceIP: "172.16.0.1", RequestedAt: notBefore},
+			expectResult:    false,
+		},
+		{
+			name:            "source ip missing",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "", "", nil, nil, []string{"10.0.0.0/8"}, nil, nil),
+			inputRequest:    &entity.AuthorizationRequest{RequestedAt: notBefore},
+			expectResult:    false,
+		},
+		{
+			name:            "attributes matched",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "", "", nil, nil, nil, map[string][]string{"tenant": {"holos", "guest"}}, nil),
+			inputRequest:    &entity.AuthorizationRequest{Attributes: map[string]string{"tenant": "guest"}, RequestedAt: notBefore},
+			expectResult:    true,
+		},
+		{
+			name:            "attributes not matched",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "", "", nil, nil, nil, map[string][]string{"tenant": {"holos"}}, nil),
+			inputRequest:    &entity.AuthorizationRequest{Attributes: map[string]string{"tenant": "guest"}, RequestedAt: notBefore},
+			expectResult:    false,
+		},
+		{
+			name:            "attributes missing",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "", "", nil, nil, nil, map[string][]string{"tenant": {"holos"}}, nil),
+			inputRequest:    &entity.AuthorizationRequest{RequestedAt: notBefore},
+			expectResult:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.inputConditions.Evaluate(tt.inputRequest); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestPolicyConditions_EvaluatePathParams(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	principal := entity.NewPrincipal(agent, nil, nil)
+	conditions := entity.RestorePolicyConditions("UTC", nil, "", "", nil, nil, nil, nil, map[string]string{"agent-id": "agent.id"})
+
+	tests := []struct {
+		name            string
+		inputConditions *entity.PolicyConditions
+		inputParams     map[string]string
+		expectResult    bool
+	}{
+		{
+			name:            "matched",
+			inputConditions: conditions,
+			inputParams:     map[string]string{"agent-id": agent.ID.String()},
+			expectResult:    true,
+		},
+		{
+			name:            "not matched",
+			inputConditions: conditions,
+			inputParams:     map[string]string{"agent-id": "other"},
+			expectResult:    false,
+		},
+		{
+			name:            "param missing",
+			inputConditions: conditions,
+			inputParams:     map[string]string{},
+			expectResult:    false,
+		},
+		{
+			name:            "attribute missing",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "", "", nil, nil, nil, nil, map[string]string{"team": "agent.attributes.team"}),
+			inputParams:     map[string]string{"team": ""},
+			expectResult:    false,
+		},
+		{
+			name:            "no path params",
+			inputConditions: entity.RestorePolicyConditions("UTC", nil, "", "", nil, nil, nil, nil, nil),
+			inputParams:     nil,
+			expectResult:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.inputConditions.EvaluatePathParams(tt.inputParams, principal); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
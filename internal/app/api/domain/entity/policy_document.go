@@ -0,0 +1,204 @@
+package entity
+
+import (
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnsupportedPolicyDocumentVersion = status.Error(http.StatusBadRequest, "unsupported policy document version")
+	ErrDuplicatePolicyInDocument        = status.Error(http.StatusBadRequest, "policy name is duplicated in policy document")
+	ErrDuplicateAgentInDocument         = status.Error(http.StatusBadRequest, "agent name is duplicated in policy document")
+	ErrUnknownPolicyInBinding           = status.Error(http.StatusBadRequest, "binding refers to unknown policy")
+	ErrUnknownAgentInBinding            = status.Error(http.StatusBadRequest, "binding refers to unknown agent")
+	ErrInvalidPolicyImportMode          = status.Error(http.StatusBadRequest, "invalid policy import mode")
+	ErrDuplicatePolicyName              = status.Error(http.StatusBadRequest, "policy name is duplicated, rename policies before applying a document")
+)
+
+// 文書の形式を変更する場合は版を上げ, 古い版の読み込みを維持する.
+const PolicyDocumentVersion = 1
+
+// 文書の取り込み方法. 統合は文書にないポリシーとエージェントを残し, 置き換えは削除する.
+const (
+	PolicyImportModeMerge   = "MERGE"
+	PolicyImportModeReplace = "REPLACE"
+)
+
+// エージェントに直接紐付けるポリシーを名前で表す.
+type PolicyBinding struct {
+	Agent    string
+	Policies []string
+}
+
+// ポリシー, エージェント, 紐付けを名前で参照し合う文書. ポリシーとエージェントはユーザー内で名前により照合する.
+type PolicyDocument struct {
+	Version  int
+	Policies []*Policy
+	Agents   []*Agent
+	Bindings []*PolicyBinding
+}
+
+func NewPolicyDocument(version int, policies []*Policy, agents []*Agent, bindings []*PolicyBinding) (*PolicyDocument, error) {
+	if version != PolicyDocumentVersion {
+		return nil, ErrUnsupportedPolicyDocumentVersion
+	}
+
+	policyNames := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		if slices.Contains(policyNames, policy.Name) {
+			return nil, ErrDuplicatePolicyInDocument
+		}
+		policyNames = append(policyNames, policy.Name)
+	}
+
+	agentNames := make([]string, 0, len(agents))
+	for _, agent := range agents {
+		if slices.Contains(agentNames, agent.Name) {
+			return nil, ErrDuplicateAgentInDocument
+		}
+		agentNames = append(agentNames, agent.Name)
+	}
+
+	for _, binding := range bindings {
+		if !slices.Contains(agentNames, binding.Agent) {
+			return nil, ErrUnknownAgentInBinding
+		}
+		for _, policyName := range binding.Policies {
+			if !slices.Contains(policyNames, policyName) {
+				return nil, ErrUnknownPolicyInBinding
+			}
+		}
+	}
+
+	return &PolicyDocument{
+		Version:  version,
+		Policies: policies,
+		Agents:   agents,
+		Bindings: bindings,
+	}, nil
+}
+
+// 現在のポリシーとエージェントから文書を作成する. 紐付けは直接紐付けたポリシーのみを含む.
+func ExportPolicyDocument(policies []*Policy, agents []*Agent) *PolicyDocument {
+	policyNames := make(map[uuid.UUID]string, len(policies))
+	for _, policy := range policies {
+		policyNames[policy.ID] = policy.Name
+	}
+
+	bindings := []*PolicyBinding{}
+	for _, agent := range agents {
+		names := []string{}
+		for _, id := range agent.Policies {
+			if name, ok := policyNames[id]; ok {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			continue
+		}
+		bindings = append(bindings, &PolicyBinding{Agent: agent.Name, Policies: names})
+	}
+
+	return &PolicyDocument{
+		Version:  PolicyDocumentVersion,
+		Policies: policies,
+		Agents:   agents,
+		Bindings: bindings,
+	}
+}
+
+// 既存のポリシーと文書は名前で照合するため, 名前の一意性を導入する前に作成された同名のポリシーがあれば適用しない.
+func ValidateUniquePolicyNames(policies []*Policy) error {
+	names := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		if slices.Contains(names, policy.Name) {
+			return ErrDuplicatePolicyName
+		}
+		names = append(names, policy.Name)
+	}
+	return nil
+}
+
+func ValidatePolicyImportMode(mode string) error {
+	if mode != PolicyImportModeMerge && mode != PolicyImportModeReplace {
+		return ErrInvalidPolicyImportMode
+	}
+	return nil
+}
+
+// 文書の紐付けをエージェントに適用し, 変更があった場合にtrueを返す.
+// 統合では既存の紐付けに追加し, 置き換えでは文書の紐付けのみとする. policiesは名前で引ける取り込み後のポリシー.
+func (d *PolicyDocument) ApplyBindings(agent *Agent, policies map[string]*Policy, mode string) bool {
+	ids := []uuid.UUID{}
+	if mode == PolicyImportModeMerge {
+		ids = append(ids, agent.Policies...)
+	}
+	for _, binding := range d.Bindings {
+		if binding.Agent != agent.Name {
+			continue
+		}
+		for _, name := range binding.Policies {
+			if policy, ok := policies[name]; ok && !slices.Contains(ids, policy.ID) {
+				ids = append(ids, policy.ID)
+			}
+		}
+	}
+
+	// 保存済みの紐付けは順序が異なる場合があるため, 集合として比較する.
+	if len(ids) == len(agent.Policies) && !slices.ContainsFunc(ids, func(id uuid.UUID) bool {
+		return !slices.Contains(agent.Policies, id)
+	}) {
+		return false
+	}
+	agent.Policies = ids
+	agent.UpdatedAt = time.Now()
+	return true
+}
+
+// 文書のポリシーの内容を書き写し, 変更した項目を返す. 名前は照合に使うため書き写さない.
+func (p *Policy) Merge(source *Policy) []string {
+	fields := []string{}
+	if p.Effect != source.Effect {
+		fields = append(fields, "effect")
+	}
+	if p.Service != source.Service {
+		fields = append(fields, "service")
+	}
+	if p.Path != source.Path {
+		fields = append(fields, "path")
+	}
+	if !slices.Equal(p.Methods, source.Methods) {
+		fields = append(fields, "methods")
+	}
+	if !reflect.DeepEqual(p.Conditions, source.Conditions) {
+		fields = append(fields, "conditions")
+	}
+	if p.Expression != source.Expression {
+		fields = append(fields, "expression")
+	}
+	if !equalTime(p.ValidFrom, source.ValidFrom) {
+		fields = append(fields, "valid_from")
+	}
+	if !equalTime(p.ValidUntil, source.ValidUntil) {
+		fields = append(fields, "valid_until")
+	}
+	if len(fields) == 0 {
+		return fields
+	}
+
+	p.Effect = source.Effect
+	p.Service = source.Service
+	p.Path = source.Path
+	p.Methods = slices.Clone(source.Methods)
+	p.Conditions = source.Conditions
+	p.Expression = source.Expression
+	p.ValidFrom = source.ValidFrom
+	p.ValidUntil = source.ValidUntil
+	p.UpdatedAt = time.Now()
+	return fields
+}
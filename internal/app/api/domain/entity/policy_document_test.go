@@ -0,0 +1,306 @@
+package entity_test
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestNewPolicyDocument(t *testing.T) {
+	userID := uuid.New()
+	policy1, err := entity.NewPolicy(userID, "policy1", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy2, err := entity.NewPolicy(userID, "policy2", "DENY", "STORAGE", "/", []string{"POST"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agent1, err := entity.NewAgent(userID, "agent1")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agent2, err := entity.NewAgent(userID, "agent2")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name          string
+		inputVersion  int
+		inputPolicies []*entity.Policy
+		inputAgents   []*entity.Agent
+		inputBindings []*entity.PolicyBinding
+		expectError   error
+	}{
+		{
+			name:          "success",
+			inputVersion:  entity.PolicyDocumentVersion,
+			inputPolicies: []*entity.Policy{policy1, policy2},
+			inputAgents:   []*entity.Agent{agent1, agent2},
+			inputBindings: []*entity.PolicyBinding{{Agent: "agent1", Policies: []string{"policy1", "policy2"}}},
+			expectError:   nil,
+		},
+		{
+			name:          "empty",
+			inputVersion:  entity.PolicyDocumentVersion,
+			inputPolicies: []*entity.Policy{},
+			inputAgents:   []*entity.Agent{},
+			inputBindings: []*entity.PolicyBinding{},
+			expectError:   nil,
+		},
+		{
+			name:          "unsupported version",
+			inputVersion:  2,
+			inputPolicies: []*entity.Policy{policy1},
+			inputAgents:   []*entity.Agent{agent1},
+			inputBindings: []*entity.PolicyBinding{},
+			expectError:   entity.ErrUnsupportedPolicyDocumentVersion,
+		},
+		{
+			name:          "duplicate policy",
+			inputVersion:  entity.PolicyDocumentVersion,
+			inputPolicies: []*entity.Policy{policy1, policy1},
+			inputAgents:   []*entity.Agent{agent1},
+			inputBindings: []*entity.PolicyBinding{},
+			expectError:   entity.ErrDuplicatePolicyInDocument,
+		},
+		{
+			name:          "duplicate agent",
+			inputVersion:  entity.PolicyDocumentVersion,
+			inputPolicies: []*entity.Policy{policy1},
+			inputAgents:   []*entity.Agent{agent1, agent1},
+			inputBindings: []*entity.PolicyBinding{},
+			expectError:   entity.ErrDuplicateAgentInDocument,
+		},
+		{
+			name:          "unknown agent in binding",
+			inputVersion:  entity.PolicyDocumentVersion,
+			inputPolicies: []*entity.Policy{policy1},
+			inputAgents:   []*entity.Agent{agent1},
+			inputBindings: []*entity.PolicyBinding{{Agent: "agent2", Policies: []string{"policy1"}}},
+			expectError:   entity.ErrUnknownAgentInBinding,
+		},
+		{
+			name:          "unknown policy in binding",
+			inputVersion:  entity.PolicyDocumentVersion,
+			inputPolicies: []*entity.Policy{policy1},
+			inputAgents:   []*entity.Agent{agent1},
+			inputBindings: []*entity.PolicyBinding{{Agent: "agent1", Policies: []string{"policy2"}}},
+			expectError:   entity.ErrUnknownPolicyInBinding,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entity.NewPolicyDocument(tt.inputVersion, tt.inputPolicies, tt.inputAgents, tt.inputBindings)
+			if err != tt.expectError {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestExportPolicyDocument(t *testing.T) {
+	userID := uuid.New()
+	policy1 := entity.RestorePolicy(uuid.New(), userID, "policy1", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now())
+	policy2 := entity.RestorePolicy(uuid.New(), userID, "policy2", "DENY", "STORAGE", "/", []string{"POST"}, nil, "", nil, nil, nil, time.Now(), time.Now())
+	agent1 := entity.RestoreAgent(uuid.New(), userID, "agent1", []uuid.UUID{policy1.ID, policy2.ID}, nil, nil, time.Now(), time.Now())
+	agent2 := entity.RestoreAgent(uuid.New(), userID, "agent2", []uuid.UUID{}, nil, nil, time.Now(), time.Now())
+
+	document := entity.ExportPolicyDocument([]*entity.Policy{policy1, policy2}, []*entity.Agent{agent1, agent2})
+
+	if document.Version != entity.PolicyDocumentVersion {
+		t.Errorf("version: expect %d but got %d", entity.PolicyDocumentVersion, document.Version)
+	}
+	expectBindings := []*entity.PolicyBinding{{Agent: "agent1", Policies: []string{"policy1", "policy2"}}}
+	if diff := cmp.Diff(expectBindings, document.Bindings); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestValidateUniquePolicyNames(t *testing.T) {
+	userID := uuid.New()
+	policy1 := entity.RestorePolicy(uuid.New(), userID, "policy1", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now())
+	policy2 := entity.RestorePolicy(uuid.New(), userID, "policy2", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now())
+	duplicated := entity.RestorePolicy(uuid.New(), userID, "policy1", "DENY", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now())
+
+	tests := []struct {
+		name          string
+		inputPolicies []*entity.Policy
+		expectError   error
+	}{
+		{
+			name:          "unique",
+			inputPolicies: []*entity.Policy{policy1, policy2},
+			expectError:   nil,
+		},
+		{
+			name:          "duplicated",
+			inputPolicies: []*entity.Policy{policy1, policy2, duplicated},
+			expectError:   entity.ErrDuplicatePolicyName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := entity.ValidateUniquePolicyNames(tt.inputPolicies); err != tt.expectError {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestValidatePolicyImportMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputMode   string
+		expectError error
+	}{
+		{
+			name:        "merge",
+			inputMode:   entity.PolicyImportModeMerge,
+			expectError: nil,
+		},
+		{
+			name:        "replace",
+			inputMode:   entity.PolicyImportModeReplace,
+			expectError: nil,
+		},
+		{
+			name:        "invalid",
+			inputMode:   "merge",
+			expectError: entity.ErrInvalidPolicyImportMode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := entity.ValidatePolicyImportMode(tt.inputMode); err != tt.expectError {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestPolicyDocument_ApplyBindings(t *testing.T) {
+	userID := uuid.New()
+	policy1 := entity.RestorePolicy(uuid.New(), userID, "policy1", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now())
+	policy2 := entity.RestorePolicy(uuid.New(), userID, "policy2", "DENY", "STORAGE", "/", []string{"POST"}, nil, "", nil, nil, nil, time.Now(), time.Now())
+	policy3 := entity.RestorePolicy(uuid.New(), userID, "policy3", "ALLOW", "STORAGE", "/", []string{"PUT"}, nil, "", nil, nil, nil, time.Now(), time.Now())
+	policies := map[string]*entity.Policy{"policy1": policy1, "policy2": policy2}
+	document := &entity.PolicyDocument{
+		Version:  entity.PolicyDocumentVersion,
+		Bindings: []*entity.PolicyBinding{{Agent: "agent", Policies: []string{"policy1", "policy2"}}},
+	}
+
+	tests := []struct {
+		name           string
+		inputAgentName string
+		inputPolicies  []uuid.UUID
+		inputMode      string
+		expectPolicies []uuid.UUID
+		expectChanged  bool
+	}{
+		{
+			name:           "merge",
+			inputAgentName: "agent",
+			inputPolicies:  []uuid.UUID{policy3.ID},
+			inputMode:      entity.PolicyImportModeMerge,
+			expectPolicies: []uuid.UUID{policy3.ID, policy1.ID, policy2.ID},
+			expectChanged:  true,
+		},
+		{
+			name:           "replace",
+			inputAgentName: "agent",
+			inputPolicies:  []uuid.UUID{policy3.ID},
+			inputMode:      entity.PolicyImportModeReplace,
+			expectPolicies: []uuid.UUID{policy1.ID, policy2.ID},
+			expectChanged:  true,
+		},
+		{
+			name:           "not changed in different order",
+			inputAgentName: "agent",
+			inputPolicies:  []uuid.UUID{policy2.ID, policy1.ID},
+			inputMode:      entity.PolicyImportModeReplace,
+			expectPolicies: []uuid.UUID{policy2.ID, policy1.ID},
+			expectChanged:  false,
+		},
+		{
+			name:           "merge agent without binding",
+			inputAgentName: "other",
+			inputPolicies:  []uuid.UUID{policy3.ID},
+			inputMode:      entity.PolicyImportModeMerge,
+			expectPolicies: []uuid.UUID{policy3.ID},
+			expectChanged:  false,
+		},
+		{
+			name:           "replace agent without binding",
+			inputAgentName: "other",
+			inputPolicies:  []uuid.UUID{policy3.ID},
+			inputMode:      entity.PolicyImportModeReplace,
+			expectPolicies: []uuid.UUID{},
+			expectChanged:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := entity.RestoreAgent(uuid.New(), userID, tt.inputAgentName, tt.inputPolicies, nil, nil, time.Now(), time.Now())
+			changed := document.ApplyBindings(agent, policies, tt.inputMode)
+			if changed != tt.expectChanged {
+				t.Errorf("changed: expect %v but got %v", tt.expectChanged, changed)
+			}
+			if diff := cmp.Diff(tt.expectPolicies, agent.Policies); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicy_Merge(t *testing.T) {
+	userID := uuid.New()
+	source := entity.RestorePolicy(uuid.New(), userID, "name", "DENY", "STORAGE", "/files/*", []string{"GET", "POST"}, nil, `method == "GET"`, nil, nil, nil, time.Now(), time.Now())
+	validUntil := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		inputTarget  *entity.Policy
+		expectFields []string
+	}{
+		{
+			name:         "changed",
+			inputTarget:  entity.RestorePolicy(uuid.New(), userID, "name", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now()),
+			expectFields: []string{"effect", "path", "methods", "expression"},
+		},
+		{
+			name:         "validity removed",
+			inputTarget:  entity.RestorePolicy(uuid.New(), userID, "name", "DENY", "STORAGE", "/files/*", []string{"GET", "POST"}, nil, `method == "GET"`, nil, &validUntil, nil, time.Now(), time.Now()),
+			expectFields: []string{"valid_until"},
+		},
+		{
+			name:         "not changed",
+			inputTarget:  entity.RestorePolicy(uuid.New(), userID, "name", "DENY", "STORAGE", "/files/*", []string{"GET", "POST"}, nil, `method == "GET"`, nil, nil, nil, time.Now(), time.Now()),
+			expectFields: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := tt.inputTarget.ID
+			if diff := cmp.Diff(tt.expectFields, tt.inputTarget.Merge(source)); diff != "" {
+				t.Error(diff)
+			}
+			if tt.inputTarget.ID != id {
+				t.Errorf("id: expect %s but got %s", id, tt.inputTarget.ID)
+			}
+			if tt.inputTarget.Effect != source.Effect {
+				t.Errorf("effect: expect %s but got %s", source.Effect, tt.inputTarget.Effect)
+			}
+			if tt.inputTarget.Expression != source.Expression {
+				t.Errorf("expression: expect %s but got %s", source.Expression, tt.inputTarget.Expression)
+			}
+			if tt.inputTarget.ValidUntil != source.ValidUntil {
+				t.Errorf("valid_until: expect %v but got %v", source.ValidUntil, tt.inputTarget.ValidUntil)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package entity
+
+import (
+	"fmt"
+	"holos-auth-api/internal/app/api/domain/pkg/pathpattern"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 指摘の重要度. ERRORは効果のないポリシー, WARNINGは整理すべきポリシー, INFOは意図を確かめるべきポリシーを表す.
+const (
+	PolicyLintSeverityError   = "ERROR"
+	PolicyLintSeverityWarning = "WARNING"
+	PolicyLintSeverityInfo    = "INFO"
+)
+
+// 指摘の種類.
+const (
+	// 同じ内容のポリシーが複数ある.
+	PolicyLintTypeDuplicate = "DUPLICATE"
+	// ALLOWのポリシーが適用されるときに必ずDENYのポリシーも適用されるため, 許可に使われない.
+	PolicyLintTypeShadowed = "SHADOWED"
+	// 同じ効果のポリシーに含まれるため, 取り除いても判定が変わらない.
+	PolicyLintTypeRedundant = "REDUNDANT"
+	// ALLOWとDENYのポリシーが一部のリクエストに同時に一致する.
+	PolicyLintTypeConflict = "CONFLICT"
+	// どのエージェントにも適用されていない.
+	PolicyLintTypeUnattached = "UNATTACHED"
+)
+
+var policyLintSeverities = []string{PolicyLintSeverityError, PolicyLintSeverityWarning, PolicyLintSeverityInfo}
+
+// PolicyIDsは指摘の対象のポリシーを先頭に, 関係するポリシーを続けて並べる.
+type PolicyLintFinding struct {
+	Type      string
+	Severity  string
+	PolicyIDs []uuid.UUID
+	Message   string
+}
+
+// ポリシーを組み合わせて, 効果のないポリシーや重複を指摘する. unattachedはどのエージェントにも適用されていないポリシーを渡す.
+// attachmentsはエージェントごとに適用されるポリシーのIDを渡し, 同じエージェントに適用されるポリシーの組み合わせのみを判定する.
+// nilの場合はすべてのポリシーが同じエージェントに適用されるものとして扱う. 重複は適用先にかかわらず指摘する.
+// 条件とCEL式はリクエストによって結果が変わるため, 含む判定では条件のないポリシーか, 同じパスと条件のポリシーのみを含むものとして扱う.
+// 指摘は重要度の高い順に並べ, 同じ重要度ではpoliciesの順序に従う.
+func LintPolicies(policies []*Policy, attachments [][]uuid.UUID, unattached []*Policy) []*PolicyLintFinding {
+	findings := []*PolicyLintFinding{}
+
+	// i番目のポリシーが適用されるエージェントの添字.
+	agents := make([][]int, len(policies))
+	for a, policyIDs := range attachments {
+		for i, policy := range policies {
+			if slices.Contains(policyIDs, policy.ID) {
+				agents[i] = append(agents[i], a)
+			}
+		}
+	}
+	sharesAgent := func(i int, j int) bool {
+		if attachments == nil {
+			return true
+		}
+		return slices.ContainsFunc(agents[i], func(a int) bool {
+			return slices.Contains(agents[j], a)
+		})
+	}
+
+	// 重複したポリシーは最初の1つで代表し, 以降の組み合わせの判定から除く.
+	duplicated := make([]bool, len(policies))
+	for i, policy := range policies {
+		if duplicated[i] {
+			continue
+		}
+		ids := []uuid.UUID{policy.ID}
+		for j := i + 1; j < len(policies); j++ {
+			if !duplicated[j] && policy.isDuplicateOf(policies[j]) {
+				duplicated[j] = true
+				ids = append(ids, policies[j].ID)
+				// 代表するポリシーは重複したポリシーの適用先も引き継ぐ.
+				agents[i] = append(agents[i], agents[j]...)
+			}
+		}
+		if 1 < len(ids) {
+			findings = append(findings, &PolicyLintFinding{
+				Type:      PolicyLintTypeDuplicate,
+				Severity:  PolicyLintSeverityWarning,
+				PolicyIDs: ids,
+				Message:   fmt.Sprintf("policy %s has %d duplicates", policy.Name, len(ids)-1),
+			})
+		}
+	}
+
+	for i, policy := range policies {
+		if duplicated[i] {
+			continue
+		}
+		for j, other := range policies {
+			if i == j || duplicated[j] || !sharesAgent(i, j) || !other.covers(policy) {
+				continue
+			}
+			switch {
+			case policy.Effect == "ALLOW" && other.Effect == "DENY":
+				findings = append(findings, &PolicyLintFinding{
+					Type:      PolicyLintTypeShadowed,
+					Severity:  PolicyLintSeverityError,
+					PolicyIDs: []uuid.UUID{policy.ID, other.ID},
+					Message:   fmt.Sprintf("policy %s is always overridden by DENY policy %s", policy.Name, other.Name),
+				})
+			case policy.Effect == other.Effect:
+				// 互いに含む場合は後のポリシーのみを指摘する.
+				if j > i && policy.covers(other) {
+					continue
+				}
+				findings = append(findings, &PolicyLintFinding{
+					Type:      PolicyLintTypeRedundant,
+					Severity:  PolicyLintSeverityInfo,
+					PolicyIDs: []uuid.UUID{policy.ID, other.ID},
+					Message:   fmt.Sprintf("policy %s is covered by policy %s", policy.Name, other.Name),
+				})
+			}
+		}
+	}
+
+	for i, policy := range policies {
+		if duplicated[i] || policy.Effect != "ALLOW" {
+			continue
+		}
+		for j, other := range policies {
+			if duplicated[j] || other.Effect != "DENY" || !sharesAgent(i, j) || other.covers(policy) || !policy.overlaps(other) {
+				continue
+			}
+			findings = append(findings, &PolicyLintFinding{
+				Type:      PolicyLintTypeConflict,
+				Severity:  PolicyLintSeverityInfo,
+				PolicyIDs: []uuid.UUID{policy.ID, other.ID},
+				Message:   fmt.Sprintf("policy %s overlaps DENY policy %s", policy.Name, other.Name),
+			})
+		}
+	}
+
+	for _, policy := range unattached {
+		findings = append(findings, &PolicyLintFinding{
+			Type:      PolicyLintTypeUnattached,
+			Severity:  PolicyLintSeverityWarning,
+			PolicyIDs: []uuid.UUID{policy.ID},
+			Message:   fmt.Sprintf("policy %s is not attached to any agent", policy.Name),
+		})
+	}
+
+	slices.SortStableFunc(findings, func(a, b *PolicyLintFinding) int {
+		return slices.Index(policyLintSeverities, a.Severity) - slices.Index(policyLintSeverities, b.Severity)
+	})
+	return findings
+}
+
+func (p *Policy) isDuplicateOf(other *Policy) bool {
+	return p.Effect == other.Effect &&
+		p.Service == other.Service &&
+		p.Path == other.Path &&
+		slices.Equal(p.Methods, other.Methods) &&
+		reflect.DeepEqual(p.Conditions, other.Conditions) &&
+		p.Expression == other.Expression &&
+		equalTime(p.ValidFrom, other.ValidFrom) &&
+		equalTime(p.ValidUntil, other.ValidUntil)
+}
+
+// otherが一致するリクエストにpも必ず一致するか判定する.
+func (p *Policy) covers(other *Policy) bool {
+	if p.Service != other.Service || !coversMethods(p.Methods, other.Methods) {
+		return false
+	}
+	if !pathpattern.Compile(p.Path).Covers(pathpattern.Compile(other.Path)) {
+		return false
+	}
+	// 有効期間はotherの有効期間を含む必要がある.
+	if p.ValidFrom != nil && (other.ValidFrom == nil || other.ValidFrom.Before(*p.ValidFrom)) {
+		return false
+	}
+	if p.ValidUntil != nil && (other.ValidUntil == nil || p.ValidUntil.Before(*other.ValidUntil)) {
+		return false
+	}
+	if p.Conditions == nil && p.Expression == "" {
+		return true
+	}
+	// パスパラメータとCEL式はパスによって結果が変わるため, 同じパスの場合のみ同じ結果になる.
+	if p.Path != other.Path && (p.Expression != "" || (p.Conditions != nil && len(p.Conditions.PathParams) != 0)) {
+		return false
+	}
+	return reflect.DeepEqual(p.Conditions, other.Conditions) && p.Expression == other.Expression
+}
+
+// pとotherの両方に一致するリクエストが存在し得るか判定する. 有効期間は考慮し, 条件とCEL式は考慮しない.
+func (p *Policy) overlaps(other *Policy) bool {
+	if p.Service != other.Service || !overlapsMethods(p.Methods, other.Methods) {
+		return false
+	}
+	if p.ValidFrom != nil && other.ValidUntil != nil && !p.ValidFrom.Before(*other.ValidUntil) ||
+		other.ValidFrom != nil && p.ValidUntil != nil && !other.ValidFrom.Before(*p.ValidUntil) {
+		return false
+	}
+	return pathpattern.Compile(p.Path).Overlaps(pathpattern.Compile(other.Path))
+}
+
+func equalTime(t *time.Time, other *time.Time) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return t.Equal(*other)
+}
+
+func coversMethods(methods []string, others []string) bool {
+	if slices.Contains(methods, MethodAny) {
+		return true
+	}
+	for _, method := range others {
+		if !slices.Contains(methods, method) {
+			return false
+		}
+	}
+	return true
+}
+
+func overlapsMethods(methods []string, others []string) bool {
+	if slices.Contains(methods, MethodAny) || slices.Contains(others, MethodAny) {
+		return true
+	}
+	return slices.ContainsFunc(methods, func(method string) bool {
+		return slices.Contains(others, method)
+	})
+}
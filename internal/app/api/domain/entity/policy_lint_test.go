@@ -0,0 +1,211 @@
+package entity_test
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestLintPolicies(t *testing.T) {
+	userID := uuid.New()
+	newPolicy := func(name string, effect string, path string, methods []string, conditions *entity.PolicyConditions, expression string) *entity.Policy {
+		return entity.RestorePolicy(uuid.New(), userID, name, effect, "STORAGE", path, methods, conditions, expression, nil, nil, nil, time.Now(), time.Now())
+	}
+	conditions, err := entity.NewPolicyConditions("Asia/Tokyo", []string{"MON"}, "", "", nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	allowFiles := newPolicy("allow_files", "ALLOW", "/files", []string{"GET"}, nil, "")
+	allowFiles2 := newPolicy("allow_files2", "ALLOW", "/files", []string{"GET"}, nil, "")
+	allowFile := newPolicy("allow_file", "ALLOW", "/files/:id", []string{"GET"}, nil, "")
+	allowFileWildcard := newPolicy("allow_file_wildcard", "ALLOW", "/files/*", []string{"GET"}, nil, "")
+	denyAll := newPolicy("deny_all", "DENY", "/", []string{"*"}, nil, "")
+	denyMeta := newPolicy("deny_meta", "DENY", "/files/:id/meta", []string{"GET"}, nil, "")
+	denyImages := newPolicy("deny_images", "DENY", "/images", []string{"GET"}, nil, "")
+	denyFilesWithConditions := newPolicy("deny_files_with_conditions", "DENY", "/files", []string{"GET"}, conditions, "")
+	denyFilesWithExpression := newPolicy("deny_files_with_expression", "DENY", "/files", []string{"GET"}, nil, `method == "GET"`)
+	allowFilesWithConditions := newPolicy("allow_files_with_conditions", "ALLOW", "/files", []string{"GET"}, conditions, "")
+	allowFilesPost := newPolicy("allow_files_post", "ALLOW", "/files", []string{"POST"}, nil, "")
+	unattached := newPolicy("unattached", "ALLOW", "/images", []string{"GET"}, nil, "")
+	january := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	allowFilesInJanuary := entity.RestorePolicy(uuid.New(), userID, "allow_files_in_january", "ALLOW", "STORAGE", "/files", []string{"GET"}, nil, "", &january, &february, nil, time.Now(), time.Now())
+	denyAllFromFebruary := entity.RestorePolicy(uuid.New(), userID, "deny_all_from_february", "DENY", "STORAGE", "/", []string{"*"}, nil, "", &february, nil, nil, time.Now(), time.Now())
+
+	tests := []struct {
+		name             string
+		inputPolicies    []*entity.Policy
+		inputAttachments [][]uuid.UUID
+		inputUnattached  []*entity.Policy
+		expectTypes      []string
+		expectSeverities []string
+		expectPolicyIDs  [][]uuid.UUID
+	}{
+		{
+			name:             "no findings",
+			inputPolicies:    []*entity.Policy{allowFiles, denyImages, allowFilesPost},
+			inputUnattached:  nil,
+			expectTypes:      []string{},
+			expectSeverities: []string{},
+			expectPolicyIDs:  [][]uuid.UUID{},
+		},
+		{
+			name:             "duplicate",
+			inputPolicies:    []*entity.Policy{allowFiles, allowFiles2},
+			inputUnattached:  nil,
+			expectTypes:      []string{entity.PolicyLintTypeDuplicate},
+			expectSeverities: []string{entity.PolicyLintSeverityWarning},
+			expectPolicyIDs:  [][]uuid.UUID{{allowFiles.ID, allowFiles2.ID}},
+		},
+		{
+			name:             "shadowed",
+			inputPolicies:    []*entity.Policy{allowFiles, denyAll},
+			inputUnattached:  nil,
+			expectTypes:      []string{entity.PolicyLintTypeShadowed},
+			expectSeverities: []string{entity.PolicyLintSeverityError},
+			expectPolicyIDs:  [][]uuid.UUID{{allowFiles.ID, denyAll.ID}},
+		},
+		{
+			name:             "redundant",
+			inputPolicies:    []*entity.Policy{allowFiles, allowFile},
+			inputUnattached:  nil,
+			expectTypes:      []string{entity.PolicyLintTypeRedundant},
+			expectSeverities: []string{entity.PolicyLintSeverityInfo},
+			expectPolicyIDs:  [][]uuid.UUID{{allowFile.ID, allowFiles.ID}},
+		},
+		{
+			name:             "redundant each other",
+			inputPolicies:    []*entity.Policy{allowFile, allowFileWildcard},
+			inputUnattached:  nil,
+			expectTypes:      []string{entity.PolicyLintTypeRedundant},
+			expectSeverities: []string{entity.PolicyLintSeverityInfo},
+			expectPolicyIDs:  [][]uuid.UUID{{allowFileWildcard.ID, allowFile.ID}},
+		},
+		{
+			name:             "conflict",
+			inputPolicies:    []*entity.Policy{allowFiles, denyMeta},
+			inputUnattached:  nil,
+			expectTypes:      []string{entity.PolicyLintTypeConflict},
+			expectSeverities: []string{entity.PolicyLintSeverityInfo},
+			expectPolicyIDs:  [][]uuid.UUID{{allowFiles.ID, denyMeta.ID}},
+		},
+		{
+			name:             "conditional deny does not shadow",
+			inputPolicies:    []*entity.Policy{allowFiles, denyFilesWithConditions, denyFilesWithExpression},
+			inputUnattached:  nil,
+			expectTypes:      []string{entity.PolicyLintTypeConflict, entity.PolicyLintTypeConflict},
+			expectSeverities: []string{entity.PolicyLintSeverityInfo, entity.PolicyLintSeverityInfo},
+			expectPolicyIDs:  [][]uuid.UUID{{allowFiles.ID, denyFilesWithConditions.ID}, {allowFiles.ID, denyFilesWithExpression.ID}},
+		},
+		{
+			name:             "deny with validity does not shadow",
+			inputPolicies:    []*entity.Policy{allowFiles, denyAllFromFebruary},
+			inputUnattached:  nil,
+			expectTypes:      []string{entity.PolicyLintTypeConflict},
+			expectSeverities: []string{entity.PolicyLintSeverityInfo},
+			expectPolicyIDs:  [][]uuid.UUID{{allowFiles.ID, denyAllFromFebruary.ID}},
+		},
+		{
+			name:             "validity not overlapped",
+			inputPolicies:    []*entity.Policy{allowFilesInJanuary, denyAllFromFebruary},
+			inputUnattached:  nil,
+			expectTypes:      []string{},
+			expectSeverities: []string{},
+			expectPolicyIDs:  [][]uuid.UUID{},
+		},
+		{
+			name:             "deny covering validity shadows",
+			inputPolicies:    []*entity.Policy{allowFilesInJanuary, denyAll},
+			inputUnattached:  nil,
+			expectTypes:      []string{entity.PolicyLintTypeShadowed},
+			expectSeverities: []string{entity.PolicyLintSeverityError},
+			expectPolicyIDs:  [][]uuid.UUID{{allowFilesInJanuary.ID, denyAll.ID}},
+		},
+		{
+			name:             "deny with same conditions shadows",
+			inputPolicies:    []*entity.Policy{allowFilesWithConditions, denyFilesWithConditions},
+			inputUnattached:  nil,
+			expectTypes:      []string{entity.PolicyLintTypeShadowed},
+			expectSeverities: []string{entity.PolicyLintSeverityError},
+			expectPolicyIDs:  [][]uuid.UUID{{allowFilesWithConditions.ID, denyFilesWithConditions.ID}},
+		},
+		{
+			name:             "different methods",
+			inputPolicies:    []*entity.Policy{allowFilesPost, denyMeta},
+			inputUnattached:  nil,
+			expectTypes:      []string{},
+			expectSeverities: []string{},
+			expectPolicyIDs:  [][]uuid.UUID{},
+		},
+		{
+			name:             "unattached",
+			inputPolicies:    []*entity.Policy{unattached},
+			inputUnattached:  []*entity.Policy{unattached},
+			expectTypes:      []string{entity.PolicyLintTypeUnattached},
+			expectSeverities: []string{entity.PolicyLintSeverityWarning},
+			expectPolicyIDs:  [][]uuid.UUID{{unattached.ID}},
+		},
+		{
+			name:             "sorted by severity",
+			inputPolicies:    []*entity.Policy{allowFile, allowFiles, unattached, denyImages},
+			inputUnattached:  []*entity.Policy{unattached},
+			expectTypes:      []string{entity.PolicyLintTypeShadowed, entity.PolicyLintTypeUnattached, entity.PolicyLintTypeRedundant},
+			expectSeverities: []string{entity.PolicyLintSeverityError, entity.PolicyLintSeverityWarning, entity.PolicyLintSeverityInfo},
+			expectPolicyIDs:  [][]uuid.UUID{{unattached.ID, denyImages.ID}, {unattached.ID}, {allowFile.ID, allowFiles.ID}},
+		},
+		{
+			name:             "different agents",
+			inputPolicies:    []*entity.Policy{allowFile, denyAll},
+			inputAttachments: [][]uuid.UUID{{allowFile.ID}, {denyAll.ID}},
+			inputUnattached:  nil,
+			expectTypes:      []string{},
+			expectSeverities: []string{},
+			expectPolicyIDs:  [][]uuid.UUID{},
+		},
+		{
+			name:             "shared agent",
+			inputPolicies:    []*entity.Policy{allowFile, denyAll},
+			inputAttachments: [][]uuid.UUID{{allowFile.ID}, {allowFile.ID, denyAll.ID}},
+			inputUnattached:  nil,
+			expectTypes:      []string{entity.PolicyLintTypeShadowed},
+			expectSeverities: []string{entity.PolicyLintSeverityError},
+			expectPolicyIDs:  [][]uuid.UUID{{allowFile.ID, denyAll.ID}},
+		},
+		{
+			name:             "duplicate across agents",
+			inputPolicies:    []*entity.Policy{allowFiles, allowFiles2, denyAll},
+			inputAttachments: [][]uuid.UUID{{allowFiles.ID}, {allowFiles2.ID, denyAll.ID}},
+			inputUnattached:  nil,
+			expectTypes:      []string{entity.PolicyLintTypeShadowed, entity.PolicyLintTypeDuplicate},
+			expectSeverities: []string{entity.PolicyLintSeverityError, entity.PolicyLintSeverityWarning},
+			expectPolicyIDs:  [][]uuid.UUID{{allowFiles.ID, denyAll.ID}, {allowFiles.ID, allowFiles2.ID}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := entity.LintPolicies(tt.inputPolicies, tt.inputAttachments, tt.inputUnattached)
+
+			types := []string{}
+			severities := []string{}
+			policyIDs := [][]uuid.UUID{}
+			for _, finding := range findings {
+				types = append(types, finding.Type)
+				severities = append(severities, finding.Severity)
+				policyIDs = append(policyIDs, finding.PolicyIDs)
+			}
+			if diff := cmp.Diff(tt.expectTypes, types); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tt.expectSeverities, severities); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tt.expectPolicyIDs, policyIDs); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"holos-auth-api/internal/app/api/domain/pkg/pathpattern"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// コンパイル済みのポリシーを保持する期間.
+// 他のインスタンスで行われたポリシーの変更は, 判定のキャッシュと同様にこの期間だけ遅れて反映される.
+const PolicyMatcherTTL = AuthorizationDecisionCacheTTL
+
+// エージェントに適用されるポリシーを, ${name}を置き換えたうえでパスの木にまとめてコンパイルしたもの.
+// Keyはエージェントと紐付けたポリシー, 有効期間内の期間を指定したポリシー, グループ, ロールから求めるため, 紐付けが変わると別のものとして扱う.
+type PolicyMatcher struct {
+	Key       string
+	Principal *Principal
+	Policies  []*Policy
+	paths     *pathpattern.Trie
+	patterns  []*pathpattern.Pattern
+	ExpiresAt time.Time
+}
+
+func NewPolicyMatcher(principal *Principal, policies []*Policy, now time.Time) *PolicyMatcher {
+	paths := pathpattern.NewTrie()
+	patterns := make([]*pathpattern.Pattern, len(policies))
+	for i, policy := range policies {
+		pattern, resolved := policy.ResolvePath(principal)
+		if !resolved {
+			continue
+		}
+		paths.InsertPattern(pattern, i)
+		patterns[i] = pattern
+	}
+
+	return &PolicyMatcher{
+		Key:       PolicyMatcherKey(principal.Agent),
+		Principal: principal,
+		Policies:  policies,
+		paths:     paths,
+		patterns:  patterns,
+		ExpiresAt: now.Add(PolicyMatcherTTL),
+	}
+}
+
+// 展開前のエージェントから求める. 委任トークンで絞り込んだエージェントは紐付けが異なるため, 別のキーになる.
+// エージェントの名前は${agent.name}で参照するため, 名前を変えた場合も別のキーになる.
+func PolicyMatcherKey(agent *Agent) string {
+	h := sha256.New()
+	for _, ids := range [][]uuid.UUID{agent.Policies, agent.TemporaryPolicies, agent.Groups, agent.Roles} {
+		sorted := slices.Clone(ids)
+		slices.SortFunc(sorted, func(a, b uuid.UUID) int {
+			return slices.Compare(a[:], b[:])
+		})
+		for _, id := range sorted {
+			h.Write(id[:])
+		}
+		h.Write([]byte{0})
+	}
+	h.Write([]byte(agent.Name))
+	return agent.ID.String() + ":" + hex.EncodeToString(h.Sum(nil))
+}
+
+func (m *PolicyMatcher) IsExpired(now time.Time) bool {
+	return !now.Before(m.ExpiresAt)
+}
+
+// Policy.Evaluateと同じ規則で, ポリシーと同じ順に評価する. パスは木を1回走査して照合する.
+func (m *PolicyMatcher) Evaluate(request *AuthorizationRequest, headImpliedByGet bool) ([]*PolicyEvaluation, error) {
+	pathMatched := make([]bool, len(m.Policies))
+	for _, i := range m.paths.Match(request.Path) {
+		pathMatched[i] = true
+	}
+
+	evaluations := make([]*PolicyEvaluation, len(m.Policies))
+	for i, policy := range m.Policies {
+		// ${name}を置き換えられなかったALLOWのポリシーはどのパスにも一致しないため, 元のパスで代用する.
+		pattern := m.patterns[i]
+		if pattern == nil {
+			pattern = pathpattern.Compile(policy.Path)
+		}
+
+		var err error
+		evaluations[i], err = policy.evaluate(m.Principal, request, headImpliedByGet, pattern, pathMatched[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return evaluations, nil
+}
@@ -0,0 +1,164 @@
+package entity_test
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestPolicyMatcher_Evaluate(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policies := []*entity.Policy{
+		entity.RestorePolicy(uuid.New(), agent.UserID, "root", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now()),
+		entity.RestorePolicy(uuid.New(), agent.UserID, "file", "ALLOW", "STORAGE", "/files/:id", []string{"GET", "POST"}, nil, `path_params["id"] != "secret"`, nil, nil, nil, time.Now(), time.Now()),
+		entity.RestorePolicy(uuid.New(), agent.UserID, "meta", "DENY", "STORAGE", "/files/**/meta", []string{"*"}, nil, "", nil, nil, nil, time.Now(), time.Now()),
+		entity.RestorePolicy(uuid.New(), agent.UserID, "content", "ALLOW", "CONTENT", "/files/:id", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now()),
+		entity.RestorePolicy(uuid.New(), agent.UserID, "own", "ALLOW", "STORAGE", "/users/${user.name}/files", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now()),
+		entity.RestorePolicy(uuid.New(), agent.UserID, "team", "DENY", "STORAGE", "/teams/${agent.attributes.team}", []string{"*"}, nil, "", nil, nil, nil, time.Now(), time.Now()),
+	}
+	principal := entity.NewPrincipal(agent, entity.RestoreUser(agent.UserID, "alice", "", time.Now(), time.Now()), nil)
+	matcher := entity.NewPolicyMatcher(principal, policies, time.Now())
+
+	tests := []struct {
+		name         string
+		inputRequest *entity.AuthorizationRequest
+		expectResult []string
+	}{
+		{
+			name:         "root",
+			inputRequest: entity.NewAuthorizationRequest("STORAGE", "/", "GET", "", nil),
+			expectResult: []string{entity.PolicyMatchResultMatched, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultServiceMismatch, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultPathMismatch},
+		},
+		{
+			name:         "file",
+			inputRequest: entity.NewAuthorizationRequest("STORAGE", "/files/1", "POST", "", nil),
+			expectResult: []string{entity.PolicyMatchResultMethodMismatch, entity.PolicyMatchResultMatched, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultServiceMismatch, entity.PolicyMatchResultMethodMismatch, entity.PolicyMatchResultPathMismatch},
+		},
+		{
+			name:         "expression false",
+			inputRequest: entity.NewAuthorizationRequest("STORAGE", "/files/secret", "GET", "", nil),
+			expectResult: []string{entity.PolicyMatchResultMatched, entity.PolicyMatchResultExpressionFalse, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultServiceMismatch, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultPathMismatch},
+		},
+		{
+			name:         "meta",
+			inputRequest: entity.NewAuthorizationRequest("STORAGE", "/files/1/2/meta", "GET", "", nil),
+			expectResult: []string{entity.PolicyMatchResultMatched, entity.PolicyMatchResultMatched, entity.PolicyMatchResultMatched, entity.PolicyMatchResultServiceMismatch, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultPathMismatch},
+		},
+		{
+			name:         "own folder",
+			inputRequest: entity.NewAuthorizationRequest("STORAGE", "/users/alice/files/1", "GET", "", nil),
+			expectResult: []string{entity.PolicyMatchResultMatched, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultServiceMismatch, entity.PolicyMatchResultMatched, entity.PolicyMatchResultPathMismatch},
+		},
+		{
+			name:         "other user's folder",
+			inputRequest: entity.NewAuthorizationRequest("STORAGE", "/users/bob/files/1", "GET", "", nil),
+			expectResult: []string{entity.PolicyMatchResultMatched, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultServiceMismatch, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultPathMismatch},
+		},
+		{
+			name:         "unresolved deny",
+			inputRequest: entity.NewAuthorizationRequest("STORAGE", "/teams/t1", "GET", "", nil),
+			expectResult: []string{entity.PolicyMatchResultMatched, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultServiceMismatch, entity.PolicyMatchResultPathMismatch, entity.PolicyMatchResultMatched},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluations, err := matcher.Evaluate(tt.inputRequest, false)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			results := make([]string, len(evaluations))
+			for i, evaluation := range evaluations {
+				results[i] = evaluation.Result
+
+				// ポリシーを個別に評価した場合と同じ結果になる.
+				expect, err := policies[i].Evaluate(principal, tt.inputRequest, false)
+				if err != nil {
+					t.Error(err.Error())
+				}
+				if diff := cmp.Diff(expect, evaluation); diff != "" {
+					t.Error(diff)
+				}
+			}
+			if diff := cmp.Diff(tt.expectResult, results); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicyMatcherKey(t *testing.T) {
+	userID := uuid.New()
+	policy1 := uuid.New()
+	policy2 := uuid.New()
+	agent := entity.RestoreAgent(uuid.New(), userID, "name", []uuid.UUID{policy1, policy2}, []uuid.UUID{}, []uuid.UUID{}, time.Now(), time.Now())
+
+	tests := []struct {
+		name        string
+		inputAgent  *entity.Agent
+		expectEqual bool
+	}{
+		{
+			name:        "different order",
+			inputAgent:  entity.RestoreAgent(agent.ID, userID, "name", []uuid.UUID{policy2, policy1}, []uuid.UUID{}, []uuid.UUID{}, time.Now(), time.Now()),
+			expectEqual: true,
+		},
+		{
+			name:        "renamed",
+			inputAgent:  entity.RestoreAgent(agent.ID, userID, "renamed", []uuid.UUID{policy1, policy2}, []uuid.UUID{}, []uuid.UUID{}, time.Now(), time.Now()),
+			expectEqual: false,
+		},
+		{
+			name:        "scoped",
+			inputAgent:  entity.RestoreAgent(agent.ID, userID, "name", []uuid.UUID{policy1}, []uuid.UUID{}, []uuid.UUID{}, time.Now(), time.Now()),
+			expectEqual: false,
+		},
+		{
+			name:        "policy moved to group",
+			inputAgent:  entity.RestoreAgent(agent.ID, userID, "name", []uuid.UUID{policy1}, []uuid.UUID{policy2}, []uuid.UUID{}, time.Now(), time.Now()),
+			expectEqual: false,
+		},
+		{
+			name: "policy moved to temporary policies",
+			inputAgent: func() *entity.Agent {
+				agent := entity.RestoreAgent(agent.ID, userID, "name", []uuid.UUID{policy1}, []uuid.UUID{}, []uuid.UUID{}, time.Now(), time.Now())
+				agent.TemporaryPolicies = []uuid.UUID{policy2}
+				return agent
+			}(),
+			expectEqual: false,
+		},
+		{
+			name:        "other agent",
+			inputAgent:  entity.RestoreAgent(uuid.New(), userID, "name", []uuid.UUID{policy1, policy2}, []uuid.UUID{}, []uuid.UUID{}, time.Now(), time.Now()),
+			expectEqual: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if equal := entity.PolicyMatcherKey(agent) == entity.PolicyMatcherKey(tt.inputAgent); equal != tt.expectEqual {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectEqual, equal)
+			}
+		})
+	}
+}
+
+func TestPolicyMatcher_IsExpired(t *testing.T) {
+	now := time.Now()
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	matcher := entity.NewPolicyMatcher(entity.NewPrincipal(agent, nil, nil), []*entity.Policy{}, now)
+
+	if matcher.IsExpired(now) {
+		t.Error("matcher should not be expired")
+	}
+	if !matcher.IsExpired(now.Add(entity.PolicyMatcherTTL)) {
+		t.Error("matcher should be expired")
+	}
+}
@@ -3,8 +3,11 @@ package entity_test
 import (
 	"errors"
 	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
@@ -56,7 +59,7 @@ func TestNewPolicy(t *testing.T) {
 			inputUserID:  uuid.New(),
 			inputName:    "name",
 			inputEffect:  "ALLOW",
-			inputService: "SERVICE",
+			inputService: "storage",
 			inputPath:    "/",
 			inputMethods: []string{"GET"},
 			expectError:  entity.ErrInvalidPolicyService,
@@ -78,7 +81,7 @@ func TestNewPolicy(t *testing.T) {
 			inputEffect:  "ALLOW",
 			inputService: "STORAGE",
 			inputPath:    "/",
-			inputMethods: []string{"PATCH"},
+			inputMethods: []string{"TRACE"},
 			expectError:  entity.ErrInvalidPolicyMethods,
 		},
 	}
@@ -281,8 +284,18 @@ func TestPolicy_SetService(t *testing.T) {
 			expectError:  entity.ErrInvalidPolicyService,
 		},
 		{
-			name:         "no service",
-			inputService: "SERVICE",
+			name:         "user defined service",
+			inputService: "BUILD_CACHE",
+			expectError:  nil,
+		},
+		{
+			name:         "too short",
+			inputService: "AB",
+			expectError:  entity.ErrInvalidPolicyService,
+		},
+		{
+			name:         "starts with digit",
+			inputService: "1STORAGE",
 			expectError:  entity.ErrInvalidPolicyService,
 		},
 	}
@@ -352,6 +365,31 @@ func TestPolicy_SetPath(t *testing.T) {
 			inputPath:   "/",
 			expectError: nil,
 		},
+		{
+			name:        "single wildcard",
+			inputPath:   "/path/*/meta",
+			expectError: nil,
+		},
+		{
+			name:        "double wildcard",
+			inputPath:   "/path/**",
+			expectError: nil,
+		},
+		{
+			name:        "wildcard in segment",
+			inputPath:   "/path*",
+			expectError: entity.ErrInvalidPolicyPath,
+		},
+		{
+			name:        "placeholder",
+			inputPath:   "/users/${user.id}/files/${agent.attributes.team_id}",
+			expectError: nil,
+		},
+		{
+			name:        "unknown placeholder",
+			inputPath:   "/users/${user.email}",
+			expectError: entity.ErrInvalidPolicyPath,
+		},
 		{
 			name:        "not slash start",
 			inputPath:   "path",
@@ -400,19 +438,22 @@ func TestPolicy_SetMethods(t *testing.T) {
 	}
 
 	tests := []struct {
-		name         string
-		inputMethods []string
-		expectError  error
+		name          string
+		inputMethods  []string
+		expectMethods []string
+		expectError   error
 	}{
 		{
-			name:         "allow methods",
-			inputMethods: []string{"GET", "POST", "PUT", "DELETE"},
-			expectError:  nil,
+			name:          "allow methods",
+			inputMethods:  []string{"GET", "POST", "PUT", "DELETE"},
+			expectMethods: []string{"DELETE", "GET", "POST", "PUT"},
+			expectError:   nil,
 		},
 		{
-			name:         "head",
-			inputMethods: []string{"HEAD"},
-			expectError:  entity.ErrInvalidPolicyMethods,
+			name:          "head",
+			inputMethods:  []string{"HEAD"},
+			expectMethods: []string{"HEAD"},
+			expectError:   nil,
 		},
 		{
 			name:         "connect",
@@ -420,9 +461,10 @@ func TestPolicy_SetMethods(t *testing.T) {
 			expectError:  entity.ErrInvalidPolicyMethods,
 		},
 		{
-			name:         "options",
-			inputMethods: []string{"OPTIONS"},
-			expectError:  entity.ErrInvalidPolicyMethods,
+			name:          "options",
+			inputMethods:  []string{"OPTIONS"},
+			expectMethods: []string{"OPTIONS"},
+			expectError:   nil,
 		},
 		{
 			name:         "trace",
@@ -430,19 +472,34 @@ func TestPolicy_SetMethods(t *testing.T) {
 			expectError:  entity.ErrInvalidPolicyMethods,
 		},
 		{
-			name:         "patch",
-			inputMethods: []string{"PATCH"},
-			expectError:  entity.ErrInvalidPolicyMethods,
+			name:          "patch",
+			inputMethods:  []string{"PATCH"},
+			expectMethods: []string{"PATCH"},
+			expectError:   nil,
 		},
 		{
-			name:         "lower case",
-			inputMethods: []string{"get"},
-			expectError:  entity.ErrInvalidPolicyMethods,
+			name:          "any",
+			inputMethods:  []string{"*"},
+			expectMethods: []string{"*"},
+			expectError:   nil,
 		},
 		{
-			name:         "duplication",
-			inputMethods: []string{"GET", "POST", "GET"},
-			expectError:  nil,
+			name:          "any with other methods",
+			inputMethods:  []string{"GET", "*", "PATCH"},
+			expectMethods: []string{"*"},
+			expectError:   nil,
+		},
+		{
+			name:          "lower case",
+			inputMethods:  []string{"get", "Patch"},
+			expectMethods: []string{"GET", "PATCH"},
+			expectError:   nil,
+		},
+		{
+			name:          "duplication",
+			inputMethods:  []string{"GET", "POST", "get"},
+			expectMethods: []string{"GET", "POST"},
+			expectError:   nil,
 		},
 		{
 			name:         "empty",
@@ -460,6 +517,9 @@ func TestPolicy_SetMethods(t *testing.T) {
 				if !policy.UpdatedAt.After(updatedAt) {
 					t.Error("updatedAt has not been updated")
 				}
+				if diff := cmp.Diff(tt.expectMethods, policy.Methods); diff != "" {
+					t.Error(diff)
+				}
 			}
 		})
 	}
@@ -513,3 +573,358 @@ func TestPolicy_SetAgents(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicy_SetExpression(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name            string
+		inputExpression string
+		expectCode      int
+	}{
+		{
+			name:            "success",
+			inputExpression: `path_params["id"] == agent["id"]`,
+			expectCode:      0,
+		},
+		{
+			name:            "empty",
+			inputExpression: "",
+			expectCode:      0,
+		},
+		{
+			name:            "too long",
+			inputExpression: strings.Repeat("a", 4097),
+			expectCode:      http.StatusBadRequest,
+		},
+		{
+			name:            "compile error",
+			inputExpression: `method ==`,
+			expectCode:      http.StatusBadRequest,
+		},
+		{
+			name:            "not bool",
+			inputExpression: `method`,
+			expectCode:      http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.SetExpression(tt.inputExpression)
+			if tt.expectCode == 0 {
+				if err != nil {
+					t.Error(err.Error())
+				}
+				if policy.Expression != tt.inputExpression {
+					t.Errorf("expression: expect %s but got %s", tt.inputExpression, policy.Expression)
+				}
+				return
+			}
+			if code := status.FromError(err).Code(); code != tt.expectCode {
+				t.Errorf("\nexpect: %d\ngot: %d", tt.expectCode, code)
+			}
+		})
+	}
+}
+
+func TestPolicy_SetValidity(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		inputValidFrom  *time.Time
+		inputValidUntil *time.Time
+		expectError     error
+	}{
+		{
+			name:            "success",
+			inputValidFrom:  &from,
+			inputValidUntil: &until,
+			expectError:     nil,
+		},
+		{
+			name:            "without validity",
+			inputValidFrom:  nil,
+			inputValidUntil: nil,
+			expectError:     nil,
+		},
+		{
+			name:            "already expired",
+			inputValidFrom:  nil,
+			inputValidUntil: &from,
+			expectError:     nil,
+		},
+		{
+			name:            "same time",
+			inputValidFrom:  &from,
+			inputValidUntil: &from,
+			expectError:     entity.ErrInvalidPolicyPeriod,
+		},
+		{
+			name:            "reversed",
+			inputValidFrom:  &until,
+			inputValidUntil: &from,
+			expectError:     entity.ErrInvalidPolicyPeriod,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.SetValidity(tt.inputValidFrom, tt.inputValidUntil)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if tt.expectError != nil {
+				return
+			}
+			if policy.ValidFrom != tt.inputValidFrom || policy.ValidUntil != tt.inputValidUntil {
+				t.Errorf("validity: expect %v - %v but got %v - %v", tt.inputValidFrom, tt.inputValidUntil, policy.ValidFrom, policy.ValidUntil)
+			}
+		})
+	}
+}
+
+func TestPolicy_IsValidAt(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if err := policy.SetValidity(&from, &until); err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name         string
+		inputTime    time.Time
+		expectResult bool
+	}{
+		{
+			name:         "before valid_from",
+			inputTime:    from.Add(-time.Nanosecond),
+			expectResult: false,
+		},
+		{
+			name:         "at valid_from",
+			inputTime:    from,
+			expectResult: true,
+		},
+		{
+			name:         "before valid_until",
+			inputTime:    until.Add(-time.Nanosecond),
+			expectResult: true,
+		},
+		{
+			name:         "at valid_until",
+			inputTime:    until,
+			expectResult: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := policy.IsValidAt(tt.inputTime); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	newPolicy := func(effect string, expression string) *entity.Policy {
+		policy, err := entity.NewPolicy(agent.UserID, "name", effect, "STORAGE", "/agents/:agent-id/**", []string{"GET"})
+		if err != nil {
+			t.Error(err.Error())
+		}
+		if err := policy.SetExpression(expression); err != nil {
+			t.Error(err.Error())
+		}
+		return policy
+	}
+	anyMethodPolicy, err := entity.NewPolicy(agent.UserID, "name", "ALLOW", "STORAGE", "/agents/:agent-id/**", []string{"*"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	ownPolicy, err := entity.NewPolicy(agent.UserID, "name", "ALLOW", "STORAGE", "/agents/${agent.id}/files", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	pathParamsPolicy := newPolicy("ALLOW", `user["name"] == "alice"`)
+	conditions, err := entity.NewPolicyConditions("", nil, "", "", nil, nil, nil, nil, map[string]string{"agent-id": "agent.id"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	pathParamsPolicy.SetConditions(conditions)
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	expiredPolicy := newPolicy("ALLOW", "")
+	if err := expiredPolicy.SetValidity(nil, &past); err != nil {
+		t.Error(err.Error())
+	}
+	notStartedPolicy := newPolicy("DENY", "")
+	if err := notStartedPolicy.SetValidity(&future, nil); err != nil {
+		t.Error(err.Error())
+	}
+	validPolicy := newPolicy("ALLOW", "")
+	if err := validPolicy.SetValidity(&past, &future); err != nil {
+		t.Error(err.Error())
+	}
+	principal := entity.NewPrincipal(agent, entity.RestoreUser(agent.UserID, "alice", "", time.Now(), time.Now()), nil)
+
+	tests := []struct {
+		name                  string
+		inputPolicy           *entity.Policy
+		inputRequest          *entity.AuthorizationRequest
+		inputHeadImpliedByGet bool
+		expectMatched         bool
+		expectResult          string
+	}{
+		{
+			name:          "without expression",
+			inputPolicy:   newPolicy("ALLOW", ""),
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/agents/1/files", "GET", "", nil),
+			expectMatched: true,
+			expectResult:  entity.PolicyMatchResultMatched,
+		},
+		{
+			name:          "method not matched",
+			inputPolicy:   newPolicy("ALLOW", ""),
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/agents/1/files", "PATCH", "", nil),
+			expectMatched: false,
+			expectResult:  entity.PolicyMatchResultMethodMismatch,
+		},
+		{
+			name:          "any method",
+			inputPolicy:   anyMethodPolicy,
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/agents/1/files", "PATCH", "", nil),
+			expectMatched: true,
+			expectResult:  entity.PolicyMatchResultMatched,
+		},
+		{
+			name:                  "head implied by get",
+			inputPolicy:           newPolicy("ALLOW", ""),
+			inputRequest:          entity.NewAuthorizationRequest("STORAGE", "/agents/1/files", "HEAD", "", nil),
+			inputHeadImpliedByGet: true,
+			expectMatched:         true,
+			expectResult:          entity.PolicyMatchResultMatched,
+		},
+		{
+			name:                  "head not implied by get",
+			inputPolicy:           newPolicy("ALLOW", ""),
+			inputRequest:          entity.NewAuthorizationRequest("STORAGE", "/agents/1/files", "HEAD", "", nil),
+			inputHeadImpliedByGet: false,
+			expectMatched:         false,
+			expectResult:          entity.PolicyMatchResultMethodMismatch,
+		},
+		{
+			name:          "path not matched",
+			inputPolicy:   newPolicy("ALLOW", ""),
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/users/1", "GET", "", nil),
+			expectMatched: false,
+			expectResult:  entity.PolicyMatchResultPathMismatch,
+		},
+		{
+			name:          "within validity",
+			inputPolicy:   validPolicy,
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/agents/1/files", "GET", "", nil),
+			expectMatched: true,
+			expectResult:  entity.PolicyMatchResultMatched,
+		},
+		{
+			name:          "expired",
+			inputPolicy:   expiredPolicy,
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/agents/1/files", "GET", "", nil),
+			expectMatched: false,
+			expectResult:  entity.PolicyMatchResultOutsideValidity,
+		},
+		{
+			name:          "deny not started",
+			inputPolicy:   notStartedPolicy,
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/agents/1/files", "GET", "", nil),
+			expectMatched: false,
+			expectResult:  entity.PolicyMatchResultOutsideValidity,
+		},
+		{
+			name:          "expression true",
+			inputPolicy:   newPolicy("ALLOW", `path_params["agent-id"] == agent["id"] && user["id"] != ""`),
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/agents/"+agent.ID.String()+"/files", "GET", "", nil),
+			expectMatched: true,
+			expectResult:  entity.PolicyMatchResultMatched,
+		},
+		{
+			name:          "expression false",
+			inputPolicy:   newPolicy("ALLOW", `path_params["agent-id"] == agent["id"]`),
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/agents/other/files", "GET", "", nil),
+			expectMatched: false,
+			expectResult:  entity.PolicyMatchResultExpressionFalse,
+		},
+		{
+			name:          "placeholder matched",
+			inputPolicy:   ownPolicy,
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/agents/"+agent.ID.String()+"/files/1", "GET", "", nil),
+			expectMatched: true,
+			expectResult:  entity.PolicyMatchResultMatched,
+		},
+		{
+			name:          "placeholder not matched",
+			inputPolicy:   ownPolicy,
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/agents/other/files", "GET", "", nil),
+			expectMatched: false,
+			expectResult:  entity.PolicyMatchResultPathMismatch,
+		},
+		{
+			name:          "path params met",
+			inputPolicy:   pathParamsPolicy,
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/agents/"+agent.ID.String()+"/files", "GET", "", nil),
+			expectMatched: true,
+			expectResult:  entity.PolicyMatchResultMatched,
+		},
+		{
+			name:          "path params not met",
+			inputPolicy:   pathParamsPolicy,
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/agents/other/files", "GET", "", nil),
+			expectMatched: false,
+			expectResult:  entity.PolicyMatchResultConditionsNotMet,
+		},
+		{
+			name:          "allow with evaluation error",
+			inputPolicy:   newPolicy("ALLOW", `attributes["tenant"] == "holos"`),
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/agents/1", "GET", "", nil),
+			expectMatched: false,
+			expectResult:  entity.PolicyMatchResultExpressionError,
+		},
+		{
+			name:          "deny with evaluation error",
+			inputPolicy:   newPolicy("DENY", `attributes["tenant"] == "holos"`),
+			inputRequest:  entity.NewAuthorizationRequest("STORAGE", "/agents/1", "GET", "", nil),
+			expectMatched: true,
+			expectResult:  entity.PolicyMatchResultExpressionError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluation, err := tt.inputPolicy.Evaluate(principal, tt.inputRequest, tt.inputHeadImpliedByGet)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			if evaluation.Matched != tt.expectMatched {
+				t.Errorf("matched: expect %v but got %v", tt.expectMatched, evaluation.Matched)
+			}
+			if evaluation.Result != tt.expectResult {
+				t.Errorf("result: expect %s but got %s", tt.expectResult, evaluation.Result)
+			}
+		})
+	}
+}
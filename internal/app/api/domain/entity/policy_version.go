@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// バージョンを記録した操作.
+const (
+	PolicyVersionOperationCreate  = "CREATE"
+	PolicyVersionOperationUpdate  = "UPDATE"
+	PolicyVersionOperationDelete  = "DELETE"
+	PolicyVersionOperationRestore = "RESTORE"
+)
+
+// 作成, 更新, 削除のたびに記録するポリシーの内容. 記録後は変更しない.
+type PolicyVersion struct {
+	PolicyID   uuid.UUID
+	Version    int
+	UserID     uuid.UUID
+	AuthorID   uuid.UUID
+	Operation  string
+	Name       string
+	Effect     string
+	Service    string
+	Path       string
+	Methods    []string
+	Conditions *PolicyConditions
+	Expression string
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+	CreatedAt  time.Time
+}
+
+// latestは直前のバージョン. 最初のバージョンの場合はnilを渡す.
+func NewPolicyVersion(policy *Policy, latest *PolicyVersion, operation string, authorID uuid.UUID) *PolicyVersion {
+	version := 1
+	if latest != nil {
+		version = latest.Version + 1
+	}
+
+	return &PolicyVersion{
+		PolicyID:   policy.ID,
+		Version:    version,
+		UserID:     policy.UserID,
+		AuthorID:   authorID,
+		Operation:  operation,
+		Name:       policy.Name,
+		Effect:     policy.Effect,
+		Service:    policy.Service,
+		Path:       policy.Path,
+		Methods:    slices.Clone(policy.Methods),
+		Conditions: policy.Conditions,
+		Expression: policy.Expression,
+		ValidFrom:  policy.ValidFrom,
+		ValidUntil: policy.ValidUntil,
+		CreatedAt:  time.Now(),
+	}
+}
+
+func RestorePolicyVersion(policyID uuid.UUID, version int, userID uuid.UUID, authorID uuid.UUID, operation string, name string, effect string, service string, path string, methods []string, conditions *PolicyConditions, expression string, validFrom *time.Time, validUntil *time.Time, createdAt time.Time) *PolicyVersion {
+	return &PolicyVersion{
+		PolicyID:   policyID,
+		Version:    version,
+		UserID:     userID,
+		AuthorID:   authorID,
+		Operation:  operation,
+		Name:       name,
+		Effect:     effect,
+		Service:    service,
+		Path:       path,
+		Methods:    methods,
+		Conditions: conditions,
+		Expression: expression,
+		ValidFrom:  validFrom,
+		ValidUntil: validUntil,
+		CreatedAt:  createdAt,
+	}
+}
+
+// otherとの間で内容が異なる項目名を返す.
+func (v *PolicyVersion) Diff(other *PolicyVersion) []string {
+	changes := []string{}
+	if v.Name != other.Name {
+		changes = append(changes, "name")
+	}
+	if v.Effect != other.Effect {
+		changes = append(changes, "effect")
+	}
+	if v.Service != other.Service {
+		changes = append(changes, "service")
+	}
+	if v.Path != other.Path {
+		changes = append(changes, "path")
+	}
+	if !slices.Equal(v.Methods, other.Methods) {
+		changes = append(changes, "methods")
+	}
+	if !reflect.DeepEqual(v.Conditions, other.Conditions) {
+		changes = append(changes, "conditions")
+	}
+	if v.Expression != other.Expression {
+		changes = append(changes, "expression")
+	}
+	if !equalTime(v.ValidFrom, other.ValidFrom) {
+		changes = append(changes, "valid_from")
+	}
+	if !equalTime(v.ValidUntil, other.ValidUntil) {
+		changes = append(changes, "valid_until")
+	}
+	return changes
+}
+
+// バージョンの内容をポリシーに書き戻す. 記録時から検証が厳しくなった項目はエラーとなる.
+func (p *Policy) Rollback(version *PolicyVersion) error {
+	if err := p.SetName(version.Name); err != nil {
+		return err
+	}
+	if err := p.SetEffect(version.Effect); err != nil {
+		return err
+	}
+	if err := p.SetService(version.Service); err != nil {
+		return err
+	}
+	if err := p.SetPath(version.Path); err != nil {
+		return err
+	}
+	if err := p.SetMethods(slices.Clone(version.Methods)); err != nil {
+		return err
+	}
+	p.SetConditions(version.Conditions)
+	if err := p.SetExpression(version.Expression); err != nil {
+		return err
+	}
+	return p.SetValidity(version.ValidFrom, version.ValidUntil)
+}
@@ -0,0 +1,131 @@
+package entity_test
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestNewPolicyVersion(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	authorID := uuid.New()
+
+	tests := []struct {
+		name          string
+		inputLatest   *entity.PolicyVersion
+		expectVersion int
+	}{
+		{
+			name:          "first version",
+			inputLatest:   nil,
+			expectVersion: 1,
+		},
+		{
+			name:          "next version",
+			inputLatest:   entity.NewPolicyVersion(policy, entity.NewPolicyVersion(policy, nil, entity.PolicyVersionOperationCreate, authorID), entity.PolicyVersionOperationUpdate, authorID),
+			expectVersion: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policyVersion := entity.NewPolicyVersion(policy, tt.inputLatest, entity.PolicyVersionOperationUpdate, authorID)
+			if policyVersion.Version != tt.expectVersion {
+				t.Errorf("version: expect %d but got %d", tt.expectVersion, policyVersion.Version)
+			}
+			if policyVersion.PolicyID != policy.ID {
+				t.Errorf("policy_id: expect %s but got %s", policy.ID, policyVersion.PolicyID)
+			}
+			if policyVersion.AuthorID != authorID {
+				t.Errorf("author_id: expect %s but got %s", authorID, policyVersion.AuthorID)
+			}
+		})
+	}
+}
+
+func TestPolicyVersion_Diff(t *testing.T) {
+	policyID := uuid.New()
+	userID := uuid.New()
+	base := entity.RestorePolicyVersion(policyID, 1, userID, userID, entity.PolicyVersionOperationCreate, "name", "ALLOW", "STORAGE", "/files/*", []string{"GET"}, nil, "", nil, nil, time.Now())
+	validUntil := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		inputOther   *entity.PolicyVersion
+		expectResult []string
+	}{
+		{
+			name:         "no changes",
+			inputOther:   entity.RestorePolicyVersion(policyID, 2, userID, userID, entity.PolicyVersionOperationUpdate, "name", "ALLOW", "STORAGE", "/files/*", []string{"GET"}, nil, "", nil, nil, time.Now()),
+			expectResult: []string{},
+		},
+		{
+			name:         "changed",
+			inputOther:   entity.RestorePolicyVersion(policyID, 2, userID, userID, entity.PolicyVersionOperationUpdate, "name", "DENY", "STORAGE", "/files/**", []string{"GET", "POST"}, entity.RestorePolicyConditions("UTC", nil, "", "", nil, nil, nil, nil, nil), `method == "GET"`, nil, nil, time.Now()),
+			expectResult: []string{"effect", "path", "methods", "conditions", "expression"},
+		},
+		{
+			name:         "validity changed",
+			inputOther:   entity.RestorePolicyVersion(policyID, 2, userID, userID, entity.PolicyVersionOperationUpdate, "name", "ALLOW", "STORAGE", "/files/*", []string{"GET"}, nil, "", nil, &validUntil, time.Now()),
+			expectResult: []string{"valid_until"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expectResult, base.Diff(tt.inputOther)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicy_Rollback(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	validUntil := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		inputVersion *entity.PolicyVersion
+		expectError  bool
+	}{
+		{
+			name:         "success",
+			inputVersion: entity.RestorePolicyVersion(policy.ID, 1, policy.UserID, policy.UserID, entity.PolicyVersionOperationCreate, "old_name", "DENY", "CONTENT", "/files", []string{"POST"}, nil, `method == "POST"`, nil, &validUntil, time.Now()),
+			expectError:  false,
+		},
+		{
+			name:         "invalid version",
+			inputVersion: entity.RestorePolicyVersion(policy.ID, 1, policy.UserID, policy.UserID, entity.PolicyVersionOperationCreate, "old_name", "DENY", "CONTENT", "files", []string{"POST"}, nil, "", nil, nil, time.Now()),
+			expectError:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, nil, "", nil, nil, nil, policy.CreatedAt, policy.UpdatedAt)
+			err := target.Rollback(tt.inputVersion)
+			if (err != nil) != tt.expectError {
+				t.Errorf("\nexpect error: %v\ngot: %v", tt.expectError, err)
+			}
+			if err == nil {
+				if target.Name != tt.inputVersion.Name {
+					t.Errorf("name: expect %s but got %s", tt.inputVersion.Name, target.Name)
+				}
+				if target.Expression != tt.inputVersion.Expression {
+					t.Errorf("expression: expect %s but got %s", tt.inputVersion.Expression, target.Expression)
+				}
+				if target.ValidUntil != tt.inputVersion.ValidUntil {
+					t.Errorf("valid_until: expect %v but got %v", tt.inputVersion.ValidUntil, target.ValidUntil)
+				}
+			}
+		})
+	}
+}
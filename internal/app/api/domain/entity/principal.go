@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+)
+
+const principalAgentAttributesPrefix = "agent.attributes."
+
+var principalAttributeNames = []string{"agent.id", "agent.name", "user.id", "user.name"}
+
+// 認可を求めたエージェントと, それを所有するユーザーの属性.
+// ポリシーのパスの${name}と, 条件のpath_paramsで比較する値をここから参照する.
+type Principal struct {
+	Agent      *Agent
+	UserName   string
+	Attributes map[string]string
+}
+
+// ユーザーと属性が見つからない場合はnilを渡す. その場合, 対応する名前は値がないものとして扱う.
+func NewPrincipal(agent *Agent, user *User, attributes *AgentAttributes) *Principal {
+	principal := &Principal{
+		Agent:      agent,
+		Attributes: map[string]string{},
+	}
+	if user != nil {
+		principal.UserName = user.Name
+	}
+	if attributes != nil {
+		principal.Attributes = attributes.Values
+	}
+	return principal
+}
+
+// agent.id, agent.name, user.id, user.name, agent.attributes.<key>の値を返す.
+func (p *Principal) Lookup(name string) (string, bool) {
+	switch name {
+	case "agent.id":
+		return p.Agent.ID.String(), true
+	case "agent.name":
+		return p.Agent.Name, true
+	case "user.id":
+		return p.Agent.UserID.String(), true
+	case "user.name":
+		return p.UserName, p.UserName != ""
+	}
+	if key, ok := strings.CutPrefix(name, principalAgentAttributesPrefix); ok {
+		value, ok := p.Attributes[key]
+		return value, ok
+	}
+	return "", false
+}
+
+// ポリシーから参照できる属性の名前か判定する.
+func IsPrincipalAttributeName(name string) bool {
+	if slices.Contains(principalAttributeNames, name) {
+		return true
+	}
+	key, ok := strings.CutPrefix(name, principalAgentAttributesPrefix)
+	return ok && agentAttributeKeyPattern.MatchString(key)
+}
@@ -0,0 +1,91 @@
+package entity_test
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPrincipal_Lookup(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "agent")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	user := entity.RestoreUser(agent.UserID, "alice", "", time.Now(), time.Now())
+	attributes := entity.RestoreAgentAttributes(agent.ID, map[string]string{"team": "t1"}, time.Now())
+
+	tests := []struct {
+		name           string
+		inputPrincipal *entity.Principal
+		inputName      string
+		expectValue    string
+		expectOK       bool
+	}{
+		{
+			name:           "agent id",
+			inputPrincipal: entity.NewPrincipal(agent, user, attributes),
+			inputName:      "agent.id",
+			expectValue:    agent.ID.String(),
+			expectOK:       true,
+		},
+		{
+			name:           "agent name",
+			inputPrincipal: entity.NewPrincipal(agent, user, attributes),
+			inputName:      "agent.name",
+			expectValue:    "agent",
+			expectOK:       true,
+		},
+		{
+			name:           "user id",
+			inputPrincipal: entity.NewPrincipal(agent, user, attributes),
+			inputName:      "user.id",
+			expectValue:    agent.UserID.String(),
+			expectOK:       true,
+		},
+		{
+			name:           "user name",
+			inputPrincipal: entity.NewPrincipal(agent, user, attributes),
+			inputName:      "user.name",
+			expectValue:    "alice",
+			expectOK:       true,
+		},
+		{
+			name:           "user not found",
+			inputPrincipal: entity.NewPrincipal(agent, nil, attributes),
+			inputName:      "user.name",
+			expectValue:    "",
+			expectOK:       false,
+		},
+		{
+			name:           "agent attribute",
+			inputPrincipal: entity.NewPrincipal(agent, user, attributes),
+			inputName:      "agent.attributes.team",
+			expectValue:    "t1",
+			expectOK:       true,
+		},
+		{
+			name:           "agent attribute missing",
+			inputPrincipal: entity.NewPrincipal(agent, user, nil),
+			inputName:      "agent.attributes.team",
+			expectValue:    "",
+			expectOK:       false,
+		},
+		{
+			name:           "unknown",
+			inputPrincipal: entity.NewPrincipal(agent, user, attributes),
+			inputName:      "user.email",
+			expectValue:    "",
+			expectOK:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := tt.inputPrincipal.Lookup(tt.inputName)
+			if value != tt.expectValue || ok != tt.expectOK {
+				t.Errorf("\nexpect: %s, %v\ngot: %s, %v", tt.expectValue, tt.expectOK, value, ok)
+			}
+		})
+	}
+}
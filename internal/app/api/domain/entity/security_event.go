@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SecurityEventTypeUserTokenLeaked   = "USER_TOKEN_LEAKED"
+	SecurityEventTypeAgentTokenLeaked  = "AGENT_TOKEN_LEAKED"
+	SecurityEventTypePermissionExpired = "PERMISSION_EXPIRED"
+)
+
+type SecurityEvent struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Type       string
+	Detail     string
+	OccurredAt time.Time
+}
+
+func NewSecurityEvent(userID uuid.UUID, eventType string, detail string) (*SecurityEvent, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SecurityEvent{
+		ID:         id,
+		UserID:     userID,
+		Type:       eventType,
+		Detail:     detail,
+		OccurredAt: time.Now(),
+	}, nil
+}
+
+func RestoreSecurityEvent(id uuid.UUID, userID uuid.UUID, eventType string, detail string, occurredAt time.Time) *SecurityEvent {
+	return &SecurityEvent{
+		ID:         id,
+		UserID:     userID,
+		Type:       eventType,
+		Detail:     detail,
+		OccurredAt: occurredAt,
+	}
+}
@@ -0,0 +1,53 @@
+package entity_test
+
+import (
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewSecurityEvent(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputUserID uuid.UUID
+		inputType   string
+		inputDetail string
+		expectError error
+	}{
+		{
+			name:        "success",
+			inputUserID: uuid.New(),
+			inputType:   entity.SecurityEventTypeUserTokenLeaked,
+			inputDetail: "reporter=scanner",
+			expectError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			securityEvent, err := entity.NewSecurityEvent(tt.inputUserID, tt.inputType, tt.inputDetail)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if securityEvent.ID == uuid.Nil {
+					t.Error("id: expect uuid but got empty")
+				}
+				if securityEvent.UserID != tt.inputUserID {
+					t.Errorf("user_id: expect %s but got %s", tt.inputUserID, securityEvent.UserID)
+				}
+				if securityEvent.Type != tt.inputType {
+					t.Errorf("type: expect %s but got %s", tt.inputType, securityEvent.Type)
+				}
+				if securityEvent.Detail != tt.inputDetail {
+					t.Errorf("detail: expect %s but got %s", tt.inputDetail, securityEvent.Detail)
+				}
+				if securityEvent.OccurredAt.IsZero() {
+					t.Error("occurred_at: expect time but got empty")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package entity
+
+import (
+	"holos-auth-api/internal/app/api/domain/pkg/pathpattern"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrServiceNameTooShort       = status.Error(http.StatusBadRequest, "service name must be 3 characters or more")
+	ErrServiceNameTooLong        = status.Error(http.StatusBadRequest, "service name must be 64 characters or less")
+	ErrInvalidServiceName        = status.Error(http.StatusBadRequest, "invalid service name")
+	ErrRequiredServiceMethods    = status.Error(http.StatusBadRequest, "service methods is required")
+	ErrInvalidServiceMethods     = status.Error(http.StatusBadRequest, "invalid service methods")
+	ErrServicePathSchemaTooLong  = status.Error(http.StatusBadRequest, "service path schema must be 255 characters or less")
+	ErrInvalidServicePathSchema  = status.Error(http.StatusBadRequest, "invalid service path schema")
+	ErrMethodNotAllowedByService = status.Error(http.StatusBadRequest, "method is not allowed by service")
+	ErrPathNotAllowedByService   = status.Error(http.StatusBadRequest, "path does not match service path schema")
+)
+
+var serviceNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// ポリシーと認可リクエストで指定できるサービス. ユーザーごとに登録する.
+type Service struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Methods    []string
+	PathSchema string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	// 照合のたびにコンパイルしないよう, PathSchemaをコンパイルしたものを保持する.
+	pathSchema *compiledPathSchema
+}
+
+type compiledPathSchema struct {
+	regexp *regexp.Regexp
+	prog   *syntax.Prog
+}
+
+func compilePathSchema(pathSchema string) (*compiledPathSchema, error) {
+	anchored := anchorPathSchema(pathSchema)
+	re, err := regexp.Compile(anchored)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := syntax.Parse(anchored, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	prog, err := syntax.Compile(parsed.Simplify())
+	if err != nil {
+		return nil, err
+	}
+	return &compiledPathSchema{regexp: re, prog: prog}, nil
+}
+
+func NewService(userID uuid.UUID, name string, methods []string, pathSchema string) (*Service, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	service := &Service{
+		ID:     id,
+		UserID: userID,
+	}
+
+	if err := service.SetName(name); err != nil {
+		return nil, err
+	}
+	if err := service.SetMethods(methods); err != nil {
+		return nil, err
+	}
+	if err := service.SetPathSchema(pathSchema); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	service.CreatedAt = now
+	service.UpdatedAt = now
+
+	return service, nil
+}
+
+// 新しく作成したユーザーに登録するサービス. 移行時に既存のユーザーへ登録したものと同じく, すべてのメソッドとパスを許可する.
+var DefaultServiceNames = []string{"STORAGE", "CONTENT"}
+
+func NewDefaultServices(userID uuid.UUID) ([]*Service, error) {
+	services := make([]*Service, len(DefaultServiceNames))
+	for i, name := range DefaultServiceNames {
+		service, err := NewService(userID, name, []string{MethodAny}, "")
+		if err != nil {
+			return nil, err
+		}
+		services[i] = service
+	}
+	return services, nil
+}
+
+// 保存済みのパススキーマをコンパイルできない場合は, どのパスも許可しない.
+func RestoreService(id uuid.UUID, userID uuid.UUID, name string, methods []string, pathSchema string, createdAt time.Time, updatedAt time.Time) *Service {
+	service := &Service{
+		ID:         id,
+		UserID:     userID,
+		Name:       name,
+		Methods:    methods,
+		PathSchema: pathSchema,
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
+	}
+	if pathSchema != "" {
+		service.pathSchema, _ = compilePathSchema(pathSchema)
+	}
+	return service
+}
+
+func (s *Service) SetName(name string) error {
+	if len(name) < 3 {
+		return ErrServiceNameTooShort
+	}
+	if 64 < len(name) {
+		return ErrServiceNameTooLong
+	}
+	if !serviceNamePattern.MatchString(name) {
+		return ErrInvalidServiceName
+	}
+
+	s.Name = name
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *Service) SetMethods(methods []string) error {
+	if len(methods) == 0 {
+		return ErrRequiredServiceMethods
+	}
+	methods, ok := normalizeMethods(methods)
+	if !ok {
+		return ErrInvalidServiceMethods
+	}
+
+	s.Methods = methods
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// パススキーマはパス全体に一致する正規表現として扱う. 空文字の場合はパスを制限しない.
+func (s *Service) SetPathSchema(pathSchema string) error {
+	if 255 < len(pathSchema) {
+		return ErrServicePathSchemaTooLong
+	}
+	var compiled *compiledPathSchema
+	if pathSchema != "" {
+		var err error
+		if compiled, err = compilePathSchema(pathSchema); err != nil {
+			return ErrInvalidServicePathSchema
+		}
+	}
+
+	s.PathSchema = pathSchema
+	s.pathSchema = compiled
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// ポリシーのメソッドとパスがサービスで許可された範囲にあるか検証する.
+// *を指定したポリシーはサービスで許可されたメソッドのみに適用されるため, サービスのメソッドによらず許可する.
+// ポリシーのパスは:paramや*を含み, リクエストパスに前方一致するため, パススキーマに一致し得るリクエストパスを1つでも含めば許可する.
+func (s *Service) ValidatePolicy(policy *Policy) error {
+	if !slices.Equal(policy.Methods, []string{MethodAny}) {
+		for _, method := range policy.Methods {
+			if !matchMethod(s.Methods, method, false) {
+				return ErrInvalidPolicyMethods
+			}
+		}
+	}
+	if !s.matchPolicyPath(policy.Path) {
+		return ErrInvalidPolicyPath
+	}
+	return nil
+}
+
+// 認可リクエストのメソッドとパスがサービスで許可された範囲にあるか検証する.
+func (s *Service) ValidateRequest(request *AuthorizationRequest, headImpliedByGet bool) error {
+	if !matchMethod(s.Methods, request.Method, headImpliedByGet) {
+		return ErrMethodNotAllowedByService
+	}
+	if !s.matchPath(request.Path) {
+		return ErrPathNotAllowedByService
+	}
+	return nil
+}
+
+func (s *Service) matchPath(path string) bool {
+	if s.PathSchema == "" {
+		return true
+	}
+	return s.pathSchema != nil && s.pathSchema.regexp.MatchString(path)
+}
+
+// 文字どおりのセグメントのみのパスは, そのパス自体かその下のパスがパススキーマに一致し得るか判定する.
+// それ以外は最初の:paramや*より前の部分で始まるパスがパススキーマに一致し得るか判定する.
+func (s *Service) matchPolicyPath(path string) bool {
+	if s.PathSchema == "" {
+		return true
+	}
+	if s.pathSchema == nil {
+		return false
+	}
+	prefix, literal := pathpattern.LiteralPrefix(path)
+	if literal && s.matchPath(strings.TrimSuffix(prefix, "/")) {
+		return true
+	}
+	return matchProgPrefix(s.pathSchema.prog, prefix)
+}
+
+// progがprefixで始まるいずれかの文字列に一致し得るか, prefixを1文字ずつ読み進めて判定する.
+func matchProgPrefix(prog *syntax.Prog, prefix string) bool {
+	runes := []rune(prefix)
+	states := progClosure(prog, []uint32{uint32(prog.Start)}, runes, 0)
+	for i, r := range runes {
+		next := []uint32{}
+		for _, pc := range states {
+			inst := &prog.Inst[pc]
+			switch inst.Op {
+			case syntax.InstRune, syntax.InstRune1:
+				if inst.MatchRune(r) {
+					next = append(next, inst.Out)
+				}
+			case syntax.InstRuneAny:
+				next = append(next, inst.Out)
+			case syntax.InstRuneAnyNotNL:
+				if r != '\n' {
+					next = append(next, inst.Out)
+				}
+			}
+		}
+		states = progClosure(prog, next, runes, i+1)
+	}
+	return len(states) != 0
+}
+
+// pcsから文字を読まずに到達できる命令を返す. prefixの末尾では続く文字が分からないため, 位置の条件はすべて満たすものとする.
+func progClosure(prog *syntax.Prog, pcs []uint32, runes []rune, i int) []uint32 {
+	before, after := rune(-1), rune(-1)
+	if 0 < i {
+		before = runes[i-1]
+	}
+	if i < len(runes) {
+		after = runes[i]
+	}
+	context := syntax.EmptyOpContext(before, after)
+
+	visited := map[uint32]bool{}
+	states := []uint32{}
+	for len(pcs) != 0 {
+		pc := pcs[len(pcs)-1]
+		pcs = pcs[:len(pcs)-1]
+		if visited[pc] {
+			continue
+		}
+		visited[pc] = true
+
+		inst := &prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			pcs = append(pcs, inst.Out, inst.Arg)
+		case syntax.InstCapture, syntax.InstNop:
+			pcs = append(pcs, inst.Out)
+		case syntax.InstEmptyWidth:
+			if i == len(runes) || syntax.EmptyOp(inst.Arg)&^context == 0 {
+				pcs = append(pcs, inst.Out)
+			}
+		case syntax.InstMatch:
+			if i == len(runes) {
+				states = append(states, pc)
+			}
+		case syntax.InstFail:
+		default:
+			states = append(states, pc)
+		}
+	}
+	return states
+}
+
+func anchorPathSchema(pathSchema string) string {
+	return `^(?:` + pathSchema + `)$`
+}
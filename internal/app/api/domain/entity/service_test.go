@@ -0,0 +1,425 @@
+package entity_test
+
+import (
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestNewService(t *testing.T) {
+	tests := []struct {
+		name            string
+		inputUserID     uuid.UUID
+		inputName       string
+		inputMethods    []string
+		inputPathSchema string
+		expectError     error
+	}{
+		{
+			name:            "success",
+			inputUserID:     uuid.New(),
+			inputName:       "BUILD_CACHE",
+			inputMethods:    []string{"GET", "PUT"},
+			inputPathSchema: `/caches(/.*)?`,
+			expectError:     nil,
+		},
+		{
+			name:            "without path schema",
+			inputUserID:     uuid.New(),
+			inputName:       "STORAGE",
+			inputMethods:    []string{"GET"},
+			inputPathSchema: "",
+			expectError:     nil,
+		},
+		{
+			name:            "invalid name",
+			inputUserID:     uuid.New(),
+			inputName:       "storage",
+			inputMethods:    []string{"GET"},
+			inputPathSchema: "",
+			expectError:     entity.ErrInvalidServiceName,
+		},
+		{
+			name:            "no methods",
+			inputUserID:     uuid.New(),
+			inputName:       "STORAGE",
+			inputMethods:    []string{},
+			inputPathSchema: "",
+			expectError:     entity.ErrRequiredServiceMethods,
+		},
+		{
+			name:            "invalid path schema",
+			inputUserID:     uuid.New(),
+			inputName:       "STORAGE",
+			inputMethods:    []string{"GET"},
+			inputPathSchema: `/files(`,
+			expectError:     entity.ErrInvalidServicePathSchema,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, err := entity.NewService(tt.inputUserID, tt.inputName, tt.inputMethods, tt.inputPathSchema)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if service.ID == uuid.Nil {
+					t.Error("id: expect uuid but got empty")
+				}
+				if service.UserID != tt.inputUserID {
+					t.Errorf("user_id: expect %v but got %v", tt.inputUserID, service.UserID)
+				}
+				if service.Name != tt.inputName {
+					t.Errorf("name: expect %s but got %s", tt.inputName, service.Name)
+				}
+				if service.PathSchema != tt.inputPathSchema {
+					t.Errorf("path_schema: expect %s but got %s", tt.inputPathSchema, service.PathSchema)
+				}
+				if service.CreatedAt.IsZero() {
+					t.Error("created_at: expect time but got zero")
+				}
+			}
+		})
+	}
+}
+
+func TestNewDefaultServices(t *testing.T) {
+	userID := uuid.New()
+
+	services, err := entity.NewDefaultServices(userID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(services) != len(entity.DefaultServiceNames) {
+		t.Fatalf("expect %d services but got %d", len(entity.DefaultServiceNames), len(services))
+	}
+	for i, service := range services {
+		if service.UserID != userID {
+			t.Errorf("user_id: expect %s but got %s", userID, service.UserID)
+		}
+		if service.Name != entity.DefaultServiceNames[i] {
+			t.Errorf("name: expect %s but got %s", entity.DefaultServiceNames[i], service.Name)
+		}
+		if diff := cmp.Diff([]string{"*"}, service.Methods); diff != "" {
+			t.Error(diff)
+		}
+		if service.PathSchema != "" {
+			t.Errorf("path_schema: expect empty but got %s", service.PathSchema)
+		}
+	}
+}
+
+func TestService_SetName(t *testing.T) {
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name        string
+		inputName   string
+		expectError error
+	}{
+		{
+			name:        "success",
+			inputName:   "BUILD_CACHE_2",
+			expectError: nil,
+		},
+		{
+			name:        "too short",
+			inputName:   "AB",
+			expectError: entity.ErrServiceNameTooShort,
+		},
+		{
+			name:        "too long",
+			inputName:   strings.Repeat("A", 65),
+			expectError: entity.ErrServiceNameTooLong,
+		},
+		{
+			name:        "lower case",
+			inputName:   "storage",
+			expectError: entity.ErrInvalidServiceName,
+		},
+		{
+			name:        "starts with digit",
+			inputName:   "1STORAGE",
+			expectError: entity.ErrInvalidServiceName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updatedAt := service.UpdatedAt
+			if err := service.SetName(tt.inputName); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if tt.expectError == nil {
+				if service.Name != tt.inputName {
+					t.Errorf("name: expect %s but got %s", tt.inputName, service.Name)
+				}
+				if !service.UpdatedAt.After(updatedAt) {
+					t.Error("updatedAt has not been updated")
+				}
+			}
+		})
+	}
+}
+
+func TestService_SetMethods(t *testing.T) {
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name          string
+		inputMethods  []string
+		expectMethods []string
+		expectError   error
+	}{
+		{
+			name:          "sorted and compacted",
+			inputMethods:  []string{"PUT", "GET", "PUT"},
+			expectMethods: []string{"GET", "PUT"},
+			expectError:   nil,
+		},
+		{
+			name:          "any",
+			inputMethods:  []string{"GET", "*"},
+			expectMethods: []string{"*"},
+			expectError:   nil,
+		},
+		{
+			name:          "no methods",
+			inputMethods:  []string{},
+			expectMethods: nil,
+			expectError:   entity.ErrRequiredServiceMethods,
+		},
+		{
+			name:          "invalid method",
+			inputMethods:  []string{"GET", "FETCH"},
+			expectMethods: nil,
+			expectError:   entity.ErrInvalidServiceMethods,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := service.SetMethods(tt.inputMethods); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if tt.expectError == nil {
+				if diff := cmp.Diff(tt.expectMethods, service.Methods); diff != "" {
+					t.Error(diff)
+				}
+			}
+		})
+	}
+}
+
+func TestService_SetPathSchema(t *testing.T) {
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name            string
+		inputPathSchema string
+		expectError     error
+	}{
+		{
+			name:            "success",
+			inputPathSchema: `/(files|folders)(/.*)?`,
+			expectError:     nil,
+		},
+		{
+			name:            "empty",
+			inputPathSchema: "",
+			expectError:     nil,
+		},
+		{
+			name:            "too long",
+			inputPathSchema: "/" + strings.Repeat("a", 255),
+			expectError:     entity.ErrServicePathSchemaTooLong,
+		},
+		{
+			name:            "invalid regexp",
+			inputPathSchema: `/files[`,
+			expectError:     entity.ErrInvalidServicePathSchema,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := service.SetPathSchema(tt.inputPathSchema); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestService_ValidatePolicy(t *testing.T) {
+	tests := []struct {
+		name            string
+		inputPathSchema string
+		inputPath       string
+		inputMethods    []string
+		expectError     error
+	}{
+		{
+			name:            "success",
+			inputPathSchema: `/files(/.*)?`,
+			inputPath:       "/files/:id",
+			inputMethods:    []string{"GET"},
+			expectError:     nil,
+		},
+		{
+			name:            "method not allowed",
+			inputPathSchema: `/files(/.*)?`,
+			inputPath:       "/files",
+			inputMethods:    []string{"GET", "DELETE"},
+			expectError:     entity.ErrInvalidPolicyMethods,
+		},
+		{
+			name:            "any method",
+			inputPathSchema: `/files(/.*)?`,
+			inputPath:       "/files",
+			inputMethods:    []string{"*"},
+			expectError:     nil,
+		},
+		{
+			name:            "path not allowed",
+			inputPathSchema: `/files(/.*)?`,
+			inputPath:       "/folders",
+			inputMethods:    []string{"GET"},
+			expectError:     entity.ErrInvalidPolicyPath,
+		},
+		{
+			name:            "partial match",
+			inputPathSchema: `/files(/.*)?`,
+			inputPath:       "/filesystem",
+			inputMethods:    []string{"GET"},
+			expectError:     entity.ErrInvalidPolicyPath,
+		},
+		{
+			name:            "param against concrete schema",
+			inputPathSchema: `/files/[0-9]+`,
+			inputPath:       "/files/:id",
+			inputMethods:    []string{"GET"},
+			expectError:     nil,
+		},
+		{
+			name:            "wildcard against concrete schema",
+			inputPathSchema: `/files/[0-9]+/meta`,
+			inputPath:       "/files/*/meta",
+			inputMethods:    []string{"GET"},
+			expectError:     nil,
+		},
+		{
+			name:            "placeholder against concrete schema",
+			inputPathSchema: `/users/[0-9a-f-]+/files(/.*)?`,
+			inputPath:       "/users/${user.id}/files/**",
+			inputMethods:    []string{"GET"},
+			expectError:     nil,
+		},
+		{
+			name:            "literal parent of schema",
+			inputPathSchema: `/files/[0-9]+`,
+			inputPath:       "/files",
+			inputMethods:    []string{"GET"},
+			expectError:     nil,
+		},
+		{
+			name:            "prefix not allowed",
+			inputPathSchema: `/files/[0-9]+`,
+			inputPath:       "/folders/:id",
+			inputMethods:    []string{"GET"},
+			expectError:     entity.ErrInvalidPolicyPath,
+		},
+		{
+			name:            "literal not allowed",
+			inputPathSchema: `/files/[0-9]+`,
+			inputPath:       "/files/latest",
+			inputMethods:    []string{"GET"},
+			expectError:     entity.ErrInvalidPolicyPath,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := entity.RestoreService(uuid.New(), uuid.New(), "STORAGE", []string{"GET", "PUT"}, tt.inputPathSchema, time.Now(), time.Now())
+			policy, err := entity.NewPolicy(service.UserID, "name", "ALLOW", service.Name, tt.inputPath, tt.inputMethods)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			if err := service.ValidatePolicy(policy); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestService_ValidateRequest(t *testing.T) {
+	tests := []struct {
+		name                  string
+		inputService          *entity.Service
+		inputRequest          *entity.AuthorizationRequest
+		inputHeadImpliedByGet bool
+		expectError           error
+	}{
+		{
+			name:         "success",
+			inputService: entity.RestoreService(uuid.New(), uuid.New(), "STORAGE", []string{"GET"}, `/files(/.*)?`, time.Now(), time.Now()),
+			inputRequest: entity.NewAuthorizationRequest("STORAGE", "/files/1", "GET", "", nil),
+			expectError:  nil,
+		},
+		{
+			name:         "without path schema",
+			inputService: entity.RestoreService(uuid.New(), uuid.New(), "STORAGE", []string{"GET"}, "", time.Now(), time.Now()),
+			inputRequest: entity.NewAuthorizationRequest("STORAGE", "/anything", "GET", "", nil),
+			expectError:  nil,
+		},
+		{
+			name:         "method not allowed",
+			inputService: entity.RestoreService(uuid.New(), uuid.New(), "STORAGE", []string{"GET"}, "", time.Now(), time.Now()),
+			inputRequest: entity.NewAuthorizationRequest("STORAGE", "/files/1", "DELETE", "", nil),
+			expectError:  entity.ErrMethodNotAllowedByService,
+		},
+		{
+			name:         "any method",
+			inputService: entity.RestoreService(uuid.New(), uuid.New(), "STORAGE", []string{"*"}, "", time.Now(), time.Now()),
+			inputRequest: entity.NewAuthorizationRequest("STORAGE", "/files/1", "PATCH", "", nil),
+			expectError:  nil,
+		},
+		{
+			name:                  "head implied by get",
+			inputService:          entity.RestoreService(uuid.New(), uuid.New(), "STORAGE", []string{"GET"}, "", time.Now(), time.Now()),
+			inputRequest:          entity.NewAuthorizationRequest("STORAGE", "/files/1", "HEAD", "", nil),
+			inputHeadImpliedByGet: true,
+			expectError:           nil,
+		},
+		{
+			name:                  "head not implied by get",
+			inputService:          entity.RestoreService(uuid.New(), uuid.New(), "STORAGE", []string{"GET"}, "", time.Now(), time.Now()),
+			inputRequest:          entity.NewAuthorizationRequest("STORAGE", "/files/1", "HEAD", "", nil),
+			inputHeadImpliedByGet: false,
+			expectError:           entity.ErrMethodNotAllowedByService,
+		},
+		{
+			name:         "path not allowed",
+			inputService: entity.RestoreService(uuid.New(), uuid.New(), "STORAGE", []string{"GET"}, `/files(/.*)?`, time.Now(), time.Now()),
+			inputRequest: entity.NewAuthorizationRequest("STORAGE", "/folders/1", "GET", "", nil),
+			expectError:  entity.ErrPathNotAllowedByService,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.inputService.ValidateRequest(tt.inputRequest, tt.inputHeadImpliedByGet); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
@@ -7,29 +7,51 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	UserTokenReauthenticationWindow = 10 * time.Minute
+)
+
 type UserToken struct {
-	UserID    uuid.UUID
-	Token     string
-	ExpiresAt time.Time
+	UserID          uuid.UUID
+	Token           string
+	AuthenticatedAt time.Time
+	ExpiresAt       time.Time
 }
 
 func NewUserToken(userID uuid.UUID) (*UserToken, error) {
-	token, err := token.Generate()
+	token, err := token.GenerateWithPrefix(token.UserTokenPrefix)
 	if err != nil {
 		return nil, err
 	}
 
+	now := time.Now()
 	return &UserToken{
-		UserID:    userID,
-		Token:     token,
-		ExpiresAt: time.Now().Add(time.Hour * 24 * 30),
+		UserID:          userID,
+		Token:           token,
+		AuthenticatedAt: now,
+		ExpiresAt:       now.Add(time.Hour * 24 * 30),
 	}, nil
 }
 
-func RestoreUserToken(userID uuid.UUID, token string, expiresAt time.Time) *UserToken {
+func RestoreUserToken(userID uuid.UUID, token string, authenticatedAt time.Time, expiresAt time.Time) *UserToken {
 	return &UserToken{
-		UserID:    userID,
-		Token:     token,
-		ExpiresAt: expiresAt,
+		UserID:          userID,
+		Token:           token,
+		AuthenticatedAt: authenticatedAt,
+		ExpiresAt:       expiresAt,
 	}
 }
+
+func (t *UserToken) Reauthenticate() {
+	t.AuthenticatedAt = time.Now()
+}
+
+// 重要な操作の前に, 直近で本人確認が行われたかを判定する.
+func (t *UserToken) IsRecentlyAuthenticated(now time.Time) bool {
+	return now.Before(t.AuthenticatedAt.Add(UserTokenReauthenticationWindow))
+}
+
+// 形式が不正なトークンはデータベースを参照する前に拒否する.
+func ValidateUserToken(value string, legacyDeadline time.Time, now time.Time) error {
+	return token.Validate(token.UserTokenPrefix, value, legacyDeadline, now)
+}
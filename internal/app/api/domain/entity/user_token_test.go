@@ -3,6 +3,8 @@ package entity_test
 import (
 	"errors"
 	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/pkg/token"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,8 +34,11 @@ func TestNewUserToken(t *testing.T) {
 			if userToken.UserID != tt.inputUserID {
 				t.Errorf("agent_id: expect %s but got %s", tt.inputUserID, userToken.UserID)
 			}
-			if len(userToken.Token) != 32 {
-				t.Error("token: must be 32 characters")
+			if !strings.HasPrefix(userToken.Token, token.UserTokenPrefix) {
+				t.Errorf("token: expect prefix %s", token.UserTokenPrefix)
+			}
+			if len(userToken.Token) != 44 {
+				t.Error("token: must be 44 characters")
 			}
 			if userToken.ExpiresAt.IsZero() {
 				t.Error("expires_at: expect time but got empty")
@@ -44,3 +49,42 @@ func TestNewUserToken(t *testing.T) {
 		}
 	}
 }
+
+func TestUserToken_IsRecentlyAuthenticated(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name                 string
+		inputAuthenticatedAt time.Time
+		expectResult         bool
+	}{
+		{
+			name:                 "recently authenticated",
+			inputAuthenticatedAt: now.Add(-time.Minute),
+			expectResult:         true,
+		},
+		{
+			name:                 "authenticated long ago",
+			inputAuthenticatedAt: now.Add(-entity.UserTokenReauthenticationWindow),
+			expectResult:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userToken := entity.RestoreUserToken(uuid.New(), "token", tt.inputAuthenticatedAt, now.Add(time.Hour))
+			if result := userToken.IsRecentlyAuthenticated(now); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestUserToken_Reauthenticate(t *testing.T) {
+	userToken := entity.RestoreUserToken(uuid.New(), "token", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+
+	userToken.Reauthenticate()
+
+	if !userToken.IsRecentlyAuthenticated(time.Now()) {
+		t.Error("authenticated_at: expect recently authenticated")
+	}
+}
@@ -0,0 +1,145 @@
+package expression
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+)
+
+// 式の評価結果がboolでない場合のエラー.
+var ErrNotBool = errors.New("expression must evaluate to bool")
+
+// 式から参照できる変数. agentはid, name, userはid, nameをキーに持つ.
+var env = func() *cel.Env {
+	env, err := cel.NewEnv(
+		cel.Variable("agent", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("user", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("service", cel.StringType),
+		cel.Variable("path", cel.StringType),
+		cel.Variable("path_params", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("method", cel.StringType),
+		cel.Variable("attributes", cel.MapType(cel.StringType, cel.StringType)),
+	)
+	if err != nil {
+		panic(err)
+	}
+	return env
+}()
+
+const (
+	// 1回の評価で実行できる演算のコストの上限. 内包表記の入れ子などで上限を超えた場合は評価に失敗する.
+	costLimit = 10000
+	// 内包表記で中断を確かめる反復の間隔.
+	interruptCheckFrequency = 100
+	// 1回の評価にかけられる時間.
+	evaluationTimeout = 10 * time.Millisecond
+	// 保持するコンパイル済みのプログラムの数.
+	maxPrograms = 1024
+)
+
+// コンパイル済みのプログラムを式ごとに保持する. cel.Programは並行して評価できる.
+// 削除されたポリシーの式が残り続けないよう, 最も長く使われていないものから破棄する.
+var programs = newProgramCache()
+
+type programCacheEntry struct {
+	expression string
+	program    cel.Program
+}
+
+type programCache struct {
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func newProgramCache() *programCache {
+	return &programCache{
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+func (c *programCache) load(expression string) (cel.Program, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[expression]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*programCacheEntry).program, true
+}
+
+func (c *programCache) store(expression string, program cel.Program) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[expression]; ok {
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[expression] = c.order.PushFront(&programCacheEntry{expression: expression, program: program})
+	if maxPrograms < c.order.Len() {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*programCacheEntry).expression)
+	}
+}
+
+func (c *programCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// 式をコンパイルし, 型検査する.
+func Compile(expression string) (cel.Program, error) {
+	if program, ok := programs.load(expression); ok {
+		return program, nil
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, ErrNotBool
+	}
+
+	program, err := env.Program(ast, cel.CostLimit(costLimit), cel.InterruptCheckFrequency(interruptCheckFrequency))
+	if err != nil {
+		return nil, err
+	}
+
+	programs.store(expression, program)
+	return program, nil
+}
+
+// 式を評価する. 存在しないキーの参照やコストと時間の上限の超過など, 実行時のエラーはerrorとして返す.
+func Evaluate(expression string, variables map[string]any) (bool, error) {
+	program, err := Compile(expression)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), evaluationTimeout)
+	defer cancel()
+
+	out, _, err := program.ContextEval(ctx, variables)
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected result type: %s", out.Type().TypeName())
+	}
+	return result, nil
+}
@@ -0,0 +1,116 @@
+package expression_test
+
+import (
+	"errors"
+	"holos-auth-api/internal/app/api/domain/pkg/expression"
+	"strings"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputExpr   string
+		expectError bool
+	}{
+		{
+			name:        "success",
+			inputExpr:   `method == "GET" && path_params["id"] == agent["id"]`,
+			expectError: false,
+		},
+		{
+			name:        "syntax error",
+			inputExpr:   `method ==`,
+			expectError: true,
+		},
+		{
+			name:        "undeclared variable",
+			inputExpr:   `request.method == "GET"`,
+			expectError: true,
+		},
+		{
+			name:        "type mismatch",
+			inputExpr:   `attributes["level"] > 3`,
+			expectError: true,
+		},
+		{
+			name:        "not bool",
+			inputExpr:   `path`,
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := expression.Compile(tt.inputExpr)
+			if (err != nil) != tt.expectError {
+				t.Errorf("\nexpect error: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+
+	if _, err := expression.Compile(`"GET"`); !errors.Is(err, expression.ErrNotBool) {
+		t.Errorf("\nexpect: %v\ngot: %v", expression.ErrNotBool, err)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	variables := map[string]any{
+		"agent":       map[string]string{"id": "1", "name": "agent"},
+		"user":        map[string]string{"id": "2"},
+		"service":     "STORAGE",
+		"path":        "/files/1",
+		"path_params": map[string]string{"id": "1"},
+		"method":      "GET",
+		"attributes":  map[string]string{"tenant": "holos"},
+	}
+	numbers := "[" + strings.TrimSuffix(strings.Repeat("1, ", 200), ", ") + "]"
+
+	tests := []struct {
+		name         string
+		inputExpr    string
+		expectResult bool
+		expectError  bool
+	}{
+		{
+			name:         "true",
+			inputExpr:    `path_params["id"] == agent["id"] && path.startsWith("/files")`,
+			expectResult: true,
+			expectError:  false,
+		},
+		{
+			name:         "false",
+			inputExpr:    `attributes["tenant"] == "guest"`,
+			expectResult: false,
+			expectError:  false,
+		},
+		{
+			name:         "has key",
+			inputExpr:    `"level" in attributes && attributes["level"] == "high"`,
+			expectResult: false,
+			expectError:  false,
+		},
+		{
+			name:         "no such key",
+			inputExpr:    `attributes["level"] == "high"`,
+			expectResult: false,
+			expectError:  true,
+		},
+		{
+			name:         "cost limit exceeded",
+			inputExpr:    numbers + `.all(x, ` + numbers + `.all(y, x == y))`,
+			expectResult: false,
+			expectError:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := expression.Evaluate(tt.inputExpr, variables)
+			if (err != nil) != tt.expectError {
+				t.Errorf("\nexpect error: %v\ngot: %v", tt.expectError, err)
+			}
+			if result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package expression
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestProgramCache(t *testing.T) {
+	c := newProgramCache()
+	program, err := Compile(`method == "GET"`)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	for i := range maxPrograms {
+		c.store(strconv.Itoa(i), program)
+	}
+	// 最初に保存したものを使い, 次に保存したときに2番目のものが破棄されるようにする.
+	if _, ok := c.load("0"); !ok {
+		t.Error("expect 0 to be cached")
+	}
+	c.store("new", program)
+
+	if length := c.len(); length != maxPrograms {
+		t.Errorf("\nexpect: %d\ngot: %d", maxPrograms, length)
+	}
+	if _, ok := c.load("0"); !ok {
+		t.Error("expect 0 to be cached")
+	}
+	if _, ok := c.load("1"); ok {
+		t.Error("expect 1 to be evicted")
+	}
+	if _, ok := c.load("new"); !ok {
+		t.Error("expect new to be cached")
+	}
+}
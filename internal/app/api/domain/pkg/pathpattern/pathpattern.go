@@ -0,0 +1,249 @@
+package pathpattern
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	segmentPattern     = regexp.MustCompile(`^(\*|\*\*|[a-z\-:]*|\$\{[a-z][a-z0-9_.]*\})$`)
+	placeholderPattern = regexp.MustCompile(`^\$\{([a-z][a-z0-9_.]*)\}$`)
+)
+
+// ポリシーのパスで利用できる記法か検証する.
+// *と**, ${name}はセグメント全体にのみ指定できる.
+func Validate(path string) bool {
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		if !segmentPattern.MatchString(segment) {
+			return false
+		}
+	}
+	return true
+}
+
+// ポリシーのパスに含まれる${name}の名前を出現順に返す.
+func Placeholders(path string) []string {
+	names := []string{}
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		if matches := placeholderPattern.FindStringSubmatch(segment); matches != nil {
+			names = append(names, matches[1])
+		}
+	}
+	return names
+}
+
+// リクエストパスからポリシーのパスの:paramに対応するセグメントを取り出す.
+// 一致しない場合は空のmapを返す.
+func Params(path string, requestPath string) map[string]string {
+	return Compile(path).Params(requestPath)
+}
+
+// ポリシーのパスのうち, 最初の:paramや*, **, ${name}より前にある文字どおりのセグメントを/で終わる形で返す.
+// すべてのセグメントが文字どおりの場合はliteralがtrueとなる.
+func LiteralPrefix(path string) (prefix string, literal bool) {
+	prefix = "/"
+	for _, value := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		if value == "" {
+			continue
+		}
+		if parseSegment(value).kind != segmentLiteral {
+			return prefix, false
+		}
+		prefix += value + "/"
+	}
+	return prefix, true
+}
+
+type segmentKind int
+
+const (
+	segmentLiteral segmentKind = iota
+	segmentParam
+	segmentWildcard
+	segmentDoubleWildcard
+	segmentPlaceholder
+)
+
+// ポリシーのパスの1セグメント. :paramはliteralを前置きとし, 残りの1文字以上に一致する.
+// ${name}は評価時に値へ置き換えるまでどのセグメントにも一致しない.
+type segment struct {
+	kind    segmentKind
+	literal string
+	name    string
+}
+
+func parseSegment(value string) segment {
+	switch value {
+	case "**":
+		return segment{kind: segmentDoubleWildcard}
+	case "*":
+		return segment{kind: segmentWildcard}
+	}
+	if matches := placeholderPattern.FindStringSubmatch(value); matches != nil {
+		return segment{kind: segmentPlaceholder, name: matches[1]}
+	}
+	// 最初の:から末尾までを1つのパラメータとする. :の後に文字がない場合は文字どおりに扱う.
+	if i := strings.Index(value, ":"); i >= 0 && i < len(value)-1 {
+		return segment{kind: segmentParam, literal: value[:i], name: value[i+1:]}
+	}
+	return segment{kind: segmentLiteral, literal: value}
+}
+
+func (s segment) match(value string) bool {
+	switch s.kind {
+	case segmentParam:
+		return len(s.literal) < len(value) && strings.HasPrefix(value, s.literal)
+	case segmentWildcard:
+		return value != ""
+	case segmentPlaceholder:
+		return false
+	default:
+		return value == s.literal
+	}
+}
+
+// セグメント単位に分解したポリシーのパス. :paramと*は1セグメント, **は0個以上のセグメントに一致し, リクエストパスにセグメント単位で前方一致する.
+type Pattern struct {
+	segments []segment
+}
+
+// コンパイル済みのパスをポリシーのパスごとに保持する. Patternは変更しないため並行して利用できる.
+var patterns sync.Map
+
+// 分解済みのパスをすべて破棄する. ベンチマークで分解済みのものがない状態の照合を計測するために使う.
+func ClearCache() {
+	patterns.Clear()
+}
+
+// ポリシーのパスをセグメント単位に分解する. 同じパスは2回目以降に分解済みのものを返す.
+func Compile(path string) *Pattern {
+	if pattern, ok := patterns.Load(path); ok {
+		return pattern.(*Pattern)
+	}
+
+	pattern := &Pattern{}
+	for _, value := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		if value == "" {
+			continue
+		}
+		pattern.segments = append(pattern.segments, parseSegment(value))
+	}
+
+	patterns.Store(path, pattern)
+	return pattern
+}
+
+// ${name}をresolveが返す値に置き換えたパターンを返す. 値は文字どおりのセグメントとして扱い, *や:を含んでも記法として解釈しない.
+// 値が見つからない場合, 空文字や/を含む場合は, unresolvedAsWildcardがtrueなら*とみなし, falseなら置き換えられなかったことを返す.
+func (p *Pattern) Resolve(resolve func(string) (string, bool), unresolvedAsWildcard bool) (*Pattern, bool) {
+	if !slices.ContainsFunc(p.segments, func(s segment) bool { return s.kind == segmentPlaceholder }) {
+		return p, true
+	}
+
+	resolved := &Pattern{segments: make([]segment, len(p.segments))}
+	for i, s := range p.segments {
+		if s.kind != segmentPlaceholder {
+			resolved.segments[i] = s
+			continue
+		}
+		value, ok := resolve(s.name)
+		if !ok || value == "" || strings.Contains(value, "/") {
+			if !unresolvedAsWildcard {
+				return nil, false
+			}
+			resolved.segments[i] = segment{kind: segmentWildcard}
+			continue
+		}
+		resolved.segments[i] = segment{kind: segmentLiteral, literal: value}
+	}
+	return resolved, true
+}
+
+func (p *Pattern) Match(requestPath string) bool {
+	return p.match(splitRequestPath(requestPath), 0, 0, nil)
+}
+
+func (p *Pattern) Params(requestPath string) map[string]string {
+	params := map[string]string{}
+	p.match(splitRequestPath(requestPath), 0, 0, params)
+	return params
+}
+
+// i番目以降のセグメントがリクエストパスのj番目以降のセグメントに一致するか判定し, paramsに:paramの値を書き込む.
+// **は正規表現の最長一致と同じ値を取り出せるよう, 多くのセグメントに一致させる方から試す.
+func (p *Pattern) match(request *requestPath, i int, j int, params map[string]string) bool {
+	if request == nil {
+		return false
+	}
+	if i == len(p.segments) {
+		return request.tailAllowed(j)
+	}
+
+	s := p.segments[i]
+	if s.kind == segmentDoubleWildcard {
+		for k := request.wildcardEnd(j); j <= k; k-- {
+			if p.match(request, i+1, k, params) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if j == len(request.segments) || !s.match(request.segments[j]) {
+		return false
+	}
+	if !p.match(request, i+1, j+1, params) {
+		return false
+	}
+	if s.kind == segmentParam && params != nil {
+		params[s.name] = request.segments[j][len(s.literal):]
+	}
+	return true
+}
+
+// /で区切ったリクエストパス. 空文字は0個のセグメントとして扱う.
+type requestPath struct {
+	segments []string
+	// i番目以降のセグメントに改行を含まないか. 正規表現で照合していた頃の末尾の(/.*)?は改行に一致しなかったため, これに合わせる.
+	clean []bool
+}
+
+// /で始まらないリクエストパスはどのポリシーのパスにも一致しないため, nilを返す.
+// .と..のセグメント, 末尾以外の空のセグメントを含むリクエストパスも, 呼び出し元のサービスが正規化すると別のパスを指すため, nilを返す.
+// 例えば/public/../secretは/public/**に一致させない. 末尾の/は許容する.
+func splitRequestPath(path string) *requestPath {
+	if path == "" {
+		return &requestPath{segments: []string{}, clean: []bool{true}}
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil
+	}
+
+	segments := strings.Split(path[1:], "/")
+	for i, segment := range segments {
+		if segment == "." || segment == ".." || (segment == "" && i != len(segments)-1) {
+			return nil
+		}
+	}
+	clean := make([]bool, len(segments)+1)
+	clean[len(segments)] = true
+	for i := len(segments) - 1; i >= 0; i-- {
+		clean[i] = clean[i+1] && !strings.Contains(segments[i], "\n")
+	}
+	return &requestPath{segments: segments, clean: clean}
+}
+
+// j番目以降のセグメントを残して一致を終えられるか判定する.
+func (r *requestPath) tailAllowed(j int) bool {
+	return r.clean[j]
+}
+
+// j番目から**が一致できる最後の位置を返す. **は空でないセグメントにのみ一致する.
+func (r *requestPath) wildcardEnd(j int) int {
+	for j < len(r.segments) && r.segments[j] != "" {
+		j++
+	}
+	return j
+}
@@ -0,0 +1,468 @@
+package pathpattern_test
+
+import (
+	"holos-auth-api/internal/app/api/domain/pkg/pathpattern"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var paramPattern = regexp.MustCompile(`:[^/]+`)
+
+// ポリシーのパスをリクエストパスにセグメント単位で前方一致する正規表現に変換する.
+// Compileしたパターンの照合結果を確かめる基準として, 以前の正規表現による照合を残している.
+func toRegexp(path string) string {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		switch segment {
+		case "**":
+			pattern.WriteString(`(/[^/]+)*`)
+		case "*":
+			pattern.WriteString(`/[^/]+`)
+		default:
+			pattern.WriteString("/" + paramPattern.ReplaceAllString(regexp.QuoteMeta(segment), `[^/]+`))
+		}
+	}
+	// /filesが/filesystemに一致しないよう, 続きはセグメントの区切りから始まる場合のみ許容する.
+	pattern.WriteString(`(/.*)?$`)
+	return pattern.String()
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputPath    string
+		expectResult bool
+	}{
+		{
+			name:         "root",
+			inputPath:    "/",
+			expectResult: true,
+		},
+		{
+			name:         "param",
+			inputPath:    "/files/:id",
+			expectResult: true,
+		},
+		{
+			name:         "single wildcard",
+			inputPath:    "/files/*/meta",
+			expectResult: true,
+		},
+		{
+			name:         "double wildcard",
+			inputPath:    "/files/**",
+			expectResult: true,
+		},
+		{
+			name:         "wildcard in segment",
+			inputPath:    "/files/a*",
+			expectResult: false,
+		},
+		{
+			name:         "triple wildcard",
+			inputPath:    "/files/***",
+			expectResult: false,
+		},
+		{
+			name:         "placeholder",
+			inputPath:    "/users/${agent.attributes.team_id}/files",
+			expectResult: true,
+		},
+		{
+			name:         "placeholder in segment",
+			inputPath:    "/users/user-${user.id}",
+			expectResult: false,
+		},
+		{
+			name:         "invalid placeholder name",
+			inputPath:    "/users/${User.id}",
+			expectResult: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := pathpattern.Validate(tt.inputPath); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestToRegexp(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputPattern string
+		inputPath    string
+		expectResult bool
+	}{
+		{
+			name:         "root",
+			inputPattern: "/",
+			inputPath:    "/files/1",
+			expectResult: true,
+		},
+		{
+			name:         "param",
+			inputPattern: "/files/:id",
+			inputPath:    "/files/1",
+			expectResult: true,
+		},
+		{
+			name:         "root matches root",
+			inputPattern: "/",
+			inputPath:    "/",
+			expectResult: true,
+		},
+		{
+			name:         "prefix on segment boundary",
+			inputPattern: "/files/:id",
+			inputPath:    "/files/1/meta",
+			expectResult: true,
+		},
+		{
+			name:         "prefix not on segment boundary",
+			inputPattern: "/files",
+			inputPath:    "/filesystem",
+			expectResult: false,
+		},
+		{
+			name:         "trailing slash",
+			inputPattern: "/files",
+			inputPath:    "/files/",
+			expectResult: true,
+		},
+		{
+			name:         "param not matched",
+			inputPattern: "/files/:id/meta",
+			inputPath:    "/files/meta",
+			expectResult: false,
+		},
+		{
+			name:         "single wildcard",
+			inputPattern: "/files/*/meta",
+			inputPath:    "/files/1/meta",
+			expectResult: true,
+		},
+		{
+			name:         "single wildcard does not cross segments",
+			inputPattern: "/files/*/meta",
+			inputPath:    "/files/1/2/meta",
+			expectResult: false,
+		},
+		{
+			name:         "double wildcard",
+			inputPattern: "/files/**/meta",
+			inputPath:    "/files/1/2/meta",
+			expectResult: true,
+		},
+		{
+			name:         "double wildcard matches zero segments",
+			inputPattern: "/files/**/meta",
+			inputPath:    "/files/meta",
+			expectResult: true,
+		},
+		{
+			name:         "trailing double wildcard",
+			inputPattern: "/files/**",
+			inputPath:    "/files/a/b/c",
+			expectResult: true,
+		},
+		{
+			name:         "other folder",
+			inputPattern: "/files/**",
+			inputPath:    "/images/a",
+			expectResult: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, err := regexp.MatchString(toRegexp(tt.inputPattern), tt.inputPath)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			if matched != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, matched)
+			}
+		})
+	}
+}
+
+func TestParams(t *testing.T) {
+	tests := []struct {
+		name             string
+		inputPath        string
+		inputRequestPath string
+		expectResult     map[string]string
+	}{
+		{
+			name:             "no param",
+			inputPath:        "/files",
+			inputRequestPath: "/files/1",
+			expectResult:     map[string]string{},
+		},
+		{
+			name:             "param",
+			inputPath:        "/files/:id",
+			inputRequestPath: "/files/1/meta",
+			expectResult:     map[string]string{"id": "1"},
+		},
+		{
+			name:             "params with wildcards",
+			inputPath:        "/users/:user-id/**/files/:file-id",
+			inputRequestPath: "/users/u1/a/b/files/f1",
+			expectResult:     map[string]string{"user-id": "u1", "file-id": "f1"},
+		},
+		{
+			name:             "not matched",
+			inputPath:        "/files/:id",
+			inputRequestPath: "/users/1",
+			expectResult:     map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expectResult, pathpattern.Params(tt.inputPath, tt.inputRequestPath)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	patterns := []string{"/", "/files", "/files/:id", "/files/:id/meta", "/files/*/meta", "/files/**", "/files/**/meta", "/files/file-:id", "/files/:", "/**/:id/**"}
+	paths := []string{"", "/", "files", "/files", "/files/", "/filesystem", "/files/1", "/files/1/meta", "/files/1/2/meta", "/files/meta", "/files//meta", "/files/file-", "/files/file-1", "/files/:", "/files/1\n", "/files/1/\n", "/images/a"}
+
+	// .と..のセグメント, 末尾以外の空のセグメントを含むパスはどのパターンにも一致しない.
+	rejected := map[string]bool{"/files//meta": true}
+
+	// toRegexpの正規表現と同じリクエストパスに一致することを確かめる.
+	for _, pattern := range patterns {
+		for _, path := range paths {
+			t.Run(pattern+" "+path, func(t *testing.T) {
+				expect, err := regexp.MatchString(toRegexp(pattern), path)
+				if err != nil {
+					t.Error(err.Error())
+				}
+				expect = expect && !rejected[path]
+				if result := pathpattern.Compile(pattern).Match(path); result != expect {
+					t.Errorf("\nexpect: %v\ngot: %v", expect, result)
+				}
+			})
+		}
+	}
+}
+
+func TestPattern_Match(t *testing.T) {
+	tests := []struct {
+		name             string
+		inputPath        string
+		inputRequestPath string
+		expectResult     bool
+	}{
+		{
+			name:             "parent segment",
+			inputPath:        "/public/**",
+			inputRequestPath: "/public/../secret",
+			expectResult:     false,
+		},
+		{
+			name:             "trailing parent segment",
+			inputPath:        "/public",
+			inputRequestPath: "/public/..",
+			expectResult:     false,
+		},
+		{
+			name:             "current segment",
+			inputPath:        "/public/*/meta",
+			inputRequestPath: "/public/./meta",
+			expectResult:     false,
+		},
+		{
+			name:             "empty segment",
+			inputPath:        "/public/**",
+			inputRequestPath: "/public//secret",
+			expectResult:     false,
+		},
+		{
+			name:             "root parent segment",
+			inputPath:        "/",
+			inputRequestPath: "/../secret",
+			expectResult:     false,
+		},
+		{
+			name:             "dots in segment",
+			inputPath:        "/public/**",
+			inputRequestPath: "/public/file..txt",
+			expectResult:     true,
+		},
+		{
+			name:             "trailing slash",
+			inputPath:        "/public/:id",
+			inputRequestPath: "/public/1/",
+			expectResult:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := pathpattern.Compile(tt.inputPath).Match(tt.inputRequestPath); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputPath    string
+		expectResult []string
+	}{
+		{
+			name:         "no placeholder",
+			inputPath:    "/files/:id",
+			expectResult: []string{},
+		},
+		{
+			name:         "placeholders",
+			inputPath:    "/users/${user.id}/agents/${agent.id}/**",
+			expectResult: []string{"user.id", "agent.id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expectResult, pathpattern.Placeholders(tt.inputPath)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestLiteralPrefix(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputPath     string
+		expectPrefix  string
+		expectLiteral bool
+	}{
+		{
+			name:          "root",
+			inputPath:     "/",
+			expectPrefix:  "/",
+			expectLiteral: true,
+		},
+		{
+			name:          "literal",
+			inputPath:     "/files/public",
+			expectPrefix:  "/files/public/",
+			expectLiteral: true,
+		},
+		{
+			name:          "param",
+			inputPath:     "/files/:id/meta",
+			expectPrefix:  "/files/",
+			expectLiteral: false,
+		},
+		{
+			name:          "double wildcard",
+			inputPath:     "/**",
+			expectPrefix:  "/",
+			expectLiteral: false,
+		},
+		{
+			name:          "placeholder",
+			inputPath:     "/users/${user.id}/files",
+			expectPrefix:  "/users/",
+			expectLiteral: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, literal := pathpattern.LiteralPrefix(tt.inputPath)
+			if prefix != tt.expectPrefix {
+				t.Errorf("prefix: expect %s but got %s", tt.expectPrefix, prefix)
+			}
+			if literal != tt.expectLiteral {
+				t.Errorf("literal: expect %t but got %t", tt.expectLiteral, literal)
+			}
+		})
+	}
+}
+
+func TestPattern_Resolve(t *testing.T) {
+	values := map[string]string{"user.id": "u1", "agent.name": "*", "agent.attributes.path": "a/b"}
+	resolve := func(name string) (string, bool) {
+		value, ok := values[name]
+		return value, ok
+	}
+
+	tests := []struct {
+		name                      string
+		inputPath                 string
+		inputUnresolvedAsWildcard bool
+		expectResolved            bool
+		expectMatched             []string
+		expectNotMatched          []string
+	}{
+		{
+			name:                      "resolved",
+			inputPath:                 "/users/${user.id}/files",
+			inputUnresolvedAsWildcard: false,
+			expectResolved:            true,
+			expectMatched:             []string{"/users/u1/files", "/users/u1/files/1"},
+			expectNotMatched:          []string{"/users/u2/files", "/users/${user.id}/files"},
+		},
+		{
+			name:                      "value is literal",
+			inputPath:                 "/agents/${agent.name}",
+			inputUnresolvedAsWildcard: false,
+			expectResolved:            true,
+			expectMatched:             []string{"/agents/*"},
+			expectNotMatched:          []string{"/agents/agent1"},
+		},
+		{
+			name:                      "unresolved",
+			inputPath:                 "/users/${agent.attributes.team}/files",
+			inputUnresolvedAsWildcard: false,
+			expectResolved:            false,
+		},
+		{
+			name:                      "value with slash",
+			inputPath:                 "/files/${agent.attributes.path}",
+			inputUnresolvedAsWildcard: false,
+			expectResolved:            false,
+		},
+		{
+			name:                      "unresolved as wildcard",
+			inputPath:                 "/users/${agent.attributes.team}/files",
+			inputUnresolvedAsWildcard: true,
+			expectResolved:            true,
+			expectMatched:             []string{"/users/u1/files", "/users/u2/files"},
+			expectNotMatched:          []string{"/users/files"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, resolved := pathpattern.Compile(tt.inputPath).Resolve(resolve, tt.inputUnresolvedAsWildcard)
+			if resolved != tt.expectResolved {
+				t.Fatalf("resolved: expect %v but got %v", tt.expectResolved, resolved)
+			}
+			for _, path := range tt.expectMatched {
+				if !pattern.Match(path) {
+					t.Errorf("expect %s to match", path)
+				}
+			}
+			for _, path := range tt.expectNotMatched {
+				if pattern.Match(path) {
+					t.Errorf("expect %s not to match", path)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package pathpattern
+
+import "strings"
+
+// qが一致するリクエストパスにpもすべて一致するか判定する.
+// ${name}は同じ名前の${name}のみを含むものとして扱う. 判定できない組み合わせはfalseを返す.
+func (p *Pattern) Covers(q *Pattern) bool {
+	// covered[i][j]はpのi番目以降のセグメントがqのj番目以降のセグメントを含むか.
+	covered := make([][]bool, len(p.segments)+1)
+	for i := range covered {
+		covered[i] = make([]bool, len(q.segments)+1)
+	}
+	for i := len(p.segments); 0 <= i; i-- {
+		for j := len(q.segments); 0 <= j; j-- {
+			switch {
+			case i == len(p.segments):
+				// パスはセグメント単位の前方一致のため, 残りのセグメントは何であってもよい.
+				covered[i][j] = true
+			case p.segments[i].kind == segmentDoubleWildcard:
+				covered[i][j] = covered[i+1][j] || (j < len(q.segments) && covered[i][j+1])
+			case j == len(q.segments) || q.segments[j].kind == segmentDoubleWildcard:
+				covered[i][j] = false
+			default:
+				covered[i][j] = p.segments[i].covers(q.segments[j]) && covered[i+1][j+1]
+			}
+		}
+	}
+	return covered[0][0]
+}
+
+// pとqの両方に一致するリクエストパスが存在し得るか判定する.
+// ${name}はどのような値にも置き換わり得るため*として扱う.
+func (p *Pattern) Overlaps(q *Pattern) bool {
+	// overlapped[i][j]はpのi番目以降のセグメントとqのj番目以降のセグメントに同時に一致するパスがあるか.
+	overlapped := make([][]bool, len(p.segments)+1)
+	for i := range overlapped {
+		overlapped[i] = make([]bool, len(q.segments)+1)
+	}
+	for i := len(p.segments); 0 <= i; i-- {
+		for j := len(q.segments); 0 <= j; j-- {
+			switch {
+			case i == len(p.segments) || j == len(q.segments):
+				overlapped[i][j] = true
+			case p.segments[i].kind == segmentDoubleWildcard || q.segments[j].kind == segmentDoubleWildcard:
+				overlapped[i][j] = overlapped[i+1][j] || overlapped[i][j+1]
+			default:
+				overlapped[i][j] = p.segments[i].overlaps(q.segments[j]) && overlapped[i+1][j+1]
+			}
+		}
+	}
+	return overlapped[0][0]
+}
+
+// tが一致する1セグメントにsもすべて一致するか判定する. **は呼び出し元で扱う.
+func (s segment) covers(t segment) bool {
+	switch s.kind {
+	case segmentWildcard:
+		return true
+	case segmentParam:
+		switch t.kind {
+		case segmentLiteral:
+			return s.match(t.literal)
+		case segmentParam:
+			return strings.HasPrefix(t.literal, s.literal)
+		default:
+			return s.literal == ""
+		}
+	case segmentPlaceholder:
+		return t.kind == segmentPlaceholder && t.name == s.name
+	default:
+		return t.kind == segmentLiteral && t.literal == s.literal
+	}
+}
+
+// sとtの両方に一致する1セグメントが存在し得るか判定する. **は呼び出し元で扱う.
+func (s segment) overlaps(t segment) bool {
+	switch {
+	case s.kind == segmentWildcard || s.kind == segmentPlaceholder || t.kind == segmentWildcard || t.kind == segmentPlaceholder:
+		return true
+	case s.kind == segmentLiteral && t.kind == segmentLiteral:
+		return s.literal == t.literal
+	case s.kind == segmentLiteral:
+		return t.match(s.literal)
+	case t.kind == segmentLiteral:
+		return s.match(t.literal)
+	default:
+		return strings.HasPrefix(s.literal, t.literal) || strings.HasPrefix(t.literal, s.literal)
+	}
+}
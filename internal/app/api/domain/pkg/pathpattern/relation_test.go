@@ -0,0 +1,171 @@
+package pathpattern_test
+
+import (
+	"holos-auth-api/internal/app/api/domain/pkg/pathpattern"
+	"testing"
+)
+
+func TestPattern_Covers(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputPath    string
+		inputOther   string
+		expectResult bool
+	}{
+		{
+			name:         "root",
+			inputPath:    "/",
+			inputOther:   "/files/**",
+			expectResult: true,
+		},
+		{
+			name:         "same path",
+			inputPath:    "/files/:id",
+			inputOther:   "/files/:id",
+			expectResult: true,
+		},
+		{
+			name:         "prefix",
+			inputPath:    "/files",
+			inputOther:   "/files/:id/meta",
+			expectResult: true,
+		},
+		{
+			name:         "longer path",
+			inputPath:    "/files/:id",
+			inputOther:   "/files",
+			expectResult: false,
+		},
+		{
+			name:         "wildcard covers param",
+			inputPath:    "/files/*",
+			inputOther:   "/files/:id",
+			expectResult: true,
+		},
+		{
+			name:         "param with prefix",
+			inputPath:    "/files/file-:id",
+			inputOther:   "/files/*",
+			expectResult: false,
+		},
+		{
+			name:         "literal",
+			inputPath:    "/files/meta",
+			inputOther:   "/files/*",
+			expectResult: false,
+		},
+		{
+			name:         "double wildcard",
+			inputPath:    "/files/**/meta",
+			inputOther:   "/files/*/*/meta",
+			expectResult: true,
+		},
+		{
+			name:         "double wildcard not covered by wildcard",
+			inputPath:    "/files/*/meta",
+			inputOther:   "/files/**/meta",
+			expectResult: false,
+		},
+		{
+			name:         "double wildcard covers double wildcard",
+			inputPath:    "/**/meta",
+			inputOther:   "/files/**/meta",
+			expectResult: true,
+		},
+		{
+			name:         "same placeholder",
+			inputPath:    "/users/${user.name}",
+			inputOther:   "/users/${user.name}/files",
+			expectResult: true,
+		},
+		{
+			name:         "different placeholder",
+			inputPath:    "/users/${user.name}",
+			inputOther:   "/users/${user.id}",
+			expectResult: false,
+		},
+		{
+			name:         "wildcard covers placeholder",
+			inputPath:    "/users/*",
+			inputOther:   "/users/${user.name}",
+			expectResult: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := pathpattern.Compile(tt.inputPath).Covers(pathpattern.Compile(tt.inputOther)); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestPattern_Overlaps(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputPath    string
+		inputOther   string
+		expectResult bool
+	}{
+		{
+			name:         "prefix",
+			inputPath:    "/files",
+			inputOther:   "/files/meta",
+			expectResult: true,
+		},
+		{
+			name:         "different literal",
+			inputPath:    "/files",
+			inputOther:   "/images",
+			expectResult: false,
+		},
+		{
+			name:         "param and literal",
+			inputPath:    "/files/:id",
+			inputOther:   "/files/meta",
+			expectResult: true,
+		},
+		{
+			name:         "params with different prefixes",
+			inputPath:    "/files/file-:id",
+			inputOther:   "/files/image-:id",
+			expectResult: false,
+		},
+		{
+			name:         "params with common prefix",
+			inputPath:    "/files/file-:id",
+			inputOther:   "/files/file-a:id",
+			expectResult: true,
+		},
+		{
+			name:         "double wildcard",
+			inputPath:    "/**/meta",
+			inputOther:   "/files/1/meta",
+			expectResult: true,
+		},
+		{
+			name:         "double wildcard with different tail",
+			inputPath:    "/files/**/meta",
+			inputOther:   "/images/**/meta",
+			expectResult: false,
+		},
+		{
+			name:         "placeholder",
+			inputPath:    "/users/${user.name}",
+			inputOther:   "/users/admin",
+			expectResult: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := pathpattern.Compile(tt.inputPath)
+			q := pathpattern.Compile(tt.inputOther)
+			if result := p.Overlaps(q); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+			if result := q.Overlaps(p); result != tt.expectResult {
+				t.Errorf("reversed\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
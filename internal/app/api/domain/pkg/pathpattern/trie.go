@@ -0,0 +1,119 @@
+package pathpattern
+
+import "slices"
+
+// 複数のポリシーのパスをセグメントごとの木にまとめたもの.
+// 共通の前置きを持つパスを1回の走査で照合し, リクエストパスに一致したパスの値を返す.
+type Trie struct {
+	root *node
+}
+
+type node struct {
+	values         []int
+	literals       map[string]*node
+	params         []*paramNode
+	wildcard       *node
+	doubleWildcard *node
+}
+
+type paramNode struct {
+	segment segment
+	node    *node
+}
+
+func NewTrie() *Trie {
+	return &Trie{root: &node{}}
+}
+
+// ポリシーのパスと値を登録する. 同じパスに複数の値を登録できる.
+func (t *Trie) Insert(path string, value int) {
+	t.InsertPattern(Compile(path), value)
+}
+
+// コンパイル済みのパスと値を登録する. ${name}を置き換えたパターンの登録に使う.
+func (t *Trie) InsertPattern(pattern *Pattern, value int) {
+	n := t.root
+	for _, s := range pattern.segments {
+		n = n.child(s)
+	}
+	n.values = append(n.values, value)
+}
+
+func (n *node) child(s segment) *node {
+	switch s.kind {
+	case segmentDoubleWildcard:
+		if n.doubleWildcard == nil {
+			n.doubleWildcard = &node{}
+		}
+		return n.doubleWildcard
+	case segmentWildcard:
+		if n.wildcard == nil {
+			n.wildcard = &node{}
+		}
+		return n.wildcard
+	case segmentPlaceholder:
+		// 置き換えられていない${name}はどのセグメントにも一致しないため, 木から辿れないノードに登録する.
+		return &node{}
+	case segmentParam:
+		// パラメータ名は照合に影響しないため, 前置きが同じパラメータは同じノードにまとめる.
+		for _, param := range n.params {
+			if param.segment.literal == s.literal {
+				return param.node
+			}
+		}
+		param := &paramNode{segment: s, node: &node{}}
+		n.params = append(n.params, param)
+		return param.node
+	default:
+		if n.literals == nil {
+			n.literals = map[string]*node{}
+		}
+		child, ok := n.literals[s.literal]
+		if !ok {
+			child = &node{}
+			n.literals[s.literal] = child
+		}
+		return child
+	}
+}
+
+// リクエストパスに一致したパスの値を昇順に返す.
+func (t *Trie) Match(requestPath string) []int {
+	request := splitRequestPath(requestPath)
+	if request == nil {
+		return []int{}
+	}
+
+	values := []int{}
+	t.root.match(request, 0, &values)
+	slices.Sort(values)
+	return slices.Compact(values)
+}
+
+func (n *node) match(request *requestPath, j int, values *[]int) {
+	if request.tailAllowed(j) {
+		*values = append(*values, n.values...)
+	}
+
+	if n.doubleWildcard != nil {
+		for k := j; k <= request.wildcardEnd(j); k++ {
+			n.doubleWildcard.match(request, k, values)
+		}
+	}
+
+	if j == len(request.segments) || request.segments[j] == "" {
+		return
+	}
+	value := request.segments[j]
+	if child, ok := n.literals[value]; ok {
+		child.match(request, j+1, values)
+	}
+	for _, param := range n.params {
+		if param.segment.match(value) {
+			param.node.match(request, j+1, values)
+		}
+	}
+	if n.wildcard != nil {
+		n.wildcard.match(request, j+1, values)
+	}
+}
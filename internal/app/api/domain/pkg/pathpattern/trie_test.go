@@ -0,0 +1,134 @@
+package pathpattern_test
+
+import (
+	"holos-auth-api/internal/app/api/domain/pkg/pathpattern"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTrie_Match(t *testing.T) {
+	trie := pathpattern.NewTrie()
+	trie.Insert("/", 0)
+	trie.Insert("/files/:id", 1)
+	trie.Insert("/files/*/meta", 2)
+	trie.Insert("/files/**/meta", 3)
+	trie.Insert("/images/:id", 4)
+	trie.Insert("/files/:file-id", 5)
+	trie.Insert("/users/${user.id}", 6)
+
+	tests := []struct {
+		name             string
+		inputRequestPath string
+		expectResult     []int
+	}{
+		{
+			name:             "root",
+			inputRequestPath: "/",
+			expectResult:     []int{0},
+		},
+		{
+			name:             "param",
+			inputRequestPath: "/files/1",
+			expectResult:     []int{0, 1, 5},
+		},
+		{
+			name:             "wildcards",
+			inputRequestPath: "/files/1/meta",
+			expectResult:     []int{0, 1, 2, 3, 5},
+		},
+		{
+			name:             "double wildcard",
+			inputRequestPath: "/files/1/2/meta",
+			expectResult:     []int{0, 1, 3, 5},
+		},
+		{
+			name:             "double wildcard matches zero segments",
+			inputRequestPath: "/files/meta",
+			expectResult:     []int{0, 1, 3, 5},
+		},
+		{
+			name:             "unresolved placeholder",
+			inputRequestPath: "/users/${user.id}",
+			expectResult:     []int{0},
+		},
+		{
+			name:             "not starting with slash",
+			inputRequestPath: "files/1",
+			expectResult:     []int{},
+		},
+		{
+			name:             "parent segment",
+			inputRequestPath: "/files/../images/1",
+			expectResult:     []int{},
+		},
+		{
+			name:             "current segment",
+			inputRequestPath: "/files/./meta",
+			expectResult:     []int{},
+		},
+		{
+			name:             "empty segment",
+			inputRequestPath: "/files//meta",
+			expectResult:     []int{},
+		},
+		{
+			name:             "trailing slash",
+			inputRequestPath: "/files/1/",
+			expectResult:     []int{0, 1, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expectResult, trie.Match(tt.inputRequestPath)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+// ベンチマークで照合する100個のポリシーのパス. 正規表現への変換, 個別の照合, 木による照合を比較する.
+func benchmarkPaths() []string {
+	paths := make([]string, 100)
+	for i := range paths {
+		paths[i] = "/tenants/:tenant-id/buckets/bucket" + strconv.Itoa(i) + "/**/objects/:id"
+	}
+	return paths
+}
+
+const benchmarkRequestPath = "/tenants/t1/buckets/bucket50/a/b/objects/o1"
+
+func BenchmarkToRegexp(b *testing.B) {
+	paths := benchmarkPaths()
+	b.ResetTimer()
+	for range b.N {
+		for _, path := range paths {
+			if _, err := regexp.MatchString(toRegexp(path), benchmarkRequestPath); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkCompile(b *testing.B) {
+	paths := benchmarkPaths()
+	b.ResetTimer()
+	for range b.N {
+		for _, path := range paths {
+			pathpattern.Compile(path).Match(benchmarkRequestPath)
+		}
+	}
+}
+
+func BenchmarkTrie_Match(b *testing.B) {
+	trie := pathpattern.NewTrie()
+	for i, path := range benchmarkPaths() {
+		trie.Insert(path, i)
+	}
+	b.ResetTimer()
+	for range b.N {
+		trie.Match(benchmarkRequestPath)
+	}
+}
@@ -3,11 +3,30 @@ package token
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"fmt"
+	"hash/crc32"
 	"holos-auth-api/internal/app/api/pkg/status"
 	"net/http"
+	"regexp"
+	"strings"
+	"time"
 )
 
-var ErrTokenTooLong = status.Error(http.StatusInternalServerError, "token must be 32 characters or less")
+const (
+	UserTokenPrefix           = "hsu_"
+	AgentTokenPrefix          = "hsa_"
+	AgentDelegatedTokenPrefix = "hsd_"
+)
+
+var (
+	ErrTokenTooLong = status.Error(http.StatusInternalServerError, "token must be 32 characters or less")
+	ErrInvalidToken = status.Error(http.StatusUnauthorized, "invalid token")
+)
+
+var (
+	bodyPattern     = regexp.MustCompile(`^[0-9A-Za-z_-]{32}$`)
+	checksumPattern = regexp.MustCompile(`^[0-9a-f]{8}$`)
+)
 
 func Generate() (string, error) {
 	buf := make([]byte, 24)
@@ -20,3 +39,43 @@ func Generate() (string, error) {
 	}
 	return token, nil
 }
+
+// シークレットスキャナで検出できるよう, 種別を表すプレフィックスとCRC32チェックサムを付与する.
+// 形式は<prefix><32文字のランダム文字列><8文字のチェックサム>.
+func GenerateWithPrefix(prefix string) (string, error) {
+	body, err := Generate()
+	if err != nil {
+		return "", err
+	}
+	return prefix + body + checksum(prefix+body), nil
+}
+
+// プレフィックスのない旧形式のトークンか判定する. 旧形式はプレフィックスで始まる値も取りうる.
+func IsLegacy(token string) bool {
+	return bodyPattern.MatchString(token)
+}
+
+// トークンの形式とチェックサムを検証する.
+// プレフィックスのない旧形式のトークンはlegacyDeadlineまで受け付ける. legacyDeadlineがゼロ値の場合は期限なし.
+func Validate(prefix string, token string, legacyDeadline time.Time, now time.Time) error {
+	if IsLegacy(token) {
+		if legacyDeadline.IsZero() || now.Before(legacyDeadline) {
+			return nil
+		}
+		return ErrInvalidToken
+	}
+
+	if !strings.HasPrefix(token, prefix) || len(token) != len(prefix)+32+8 {
+		return ErrInvalidToken
+	}
+	body := token[len(prefix) : len(prefix)+32]
+	sum := token[len(prefix)+32:]
+	if !bodyPattern.MatchString(body) || !checksumPattern.MatchString(sum) || checksum(prefix+body) != sum {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+func checksum(value string) string {
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(value)))
+}
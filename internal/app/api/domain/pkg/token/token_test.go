@@ -0,0 +1,176 @@
+package token_test
+
+import (
+	"errors"
+	"holos-auth-api/internal/app/api/domain/pkg/token"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateWithPrefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputPrefix string
+		expectError error
+	}{
+		{
+			name:        "user token",
+			inputPrefix: token.UserTokenPrefix,
+			expectError: nil,
+		},
+		{
+			name:        "agent token",
+			inputPrefix: token.AgentTokenPrefix,
+			expectError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := token.GenerateWithPrefix(tt.inputPrefix)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if !strings.HasPrefix(result, tt.inputPrefix) {
+					t.Errorf("token: expect prefix %s but got %s", tt.inputPrefix, result)
+				}
+				if len(result) != len(tt.inputPrefix)+40 {
+					t.Errorf("token: expect %d characters but got %d", len(tt.inputPrefix)+40, len(result))
+				}
+				if err := token.Validate(tt.inputPrefix, result, time.Time{}, time.Now()); err != nil {
+					t.Error(err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	userToken, err := token.GenerateWithPrefix(token.UserTokenPrefix)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentToken, err := token.GenerateWithPrefix(token.AgentTokenPrefix)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	legacyToken, err := token.Generate()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name                string
+		inputPrefix         string
+		inputToken          string
+		inputLegacyDeadline time.Time
+		expectError         error
+	}{
+		{
+			name:                "success",
+			inputPrefix:         token.UserTokenPrefix,
+			inputToken:          userToken,
+			inputLegacyDeadline: time.Time{},
+			expectError:         nil,
+		},
+		{
+			name:                "wrong prefix",
+			inputPrefix:         token.UserTokenPrefix,
+			inputToken:          agentToken,
+			inputLegacyDeadline: time.Time{},
+			expectError:         token.ErrInvalidToken,
+		},
+		{
+			name:                "checksum mismatch",
+			inputPrefix:         token.UserTokenPrefix,
+			inputToken:          userToken[:len(userToken)-8] + "00000000",
+			inputLegacyDeadline: time.Time{},
+			expectError:         token.ErrInvalidToken,
+		},
+		{
+			name:                "too short",
+			inputPrefix:         token.UserTokenPrefix,
+			inputToken:          userToken[:len(userToken)-1],
+			inputLegacyDeadline: time.Time{},
+			expectError:         token.ErrInvalidToken,
+		},
+		{
+			name:                "invalid characters",
+			inputPrefix:         token.UserTokenPrefix,
+			inputToken:          token.UserTokenPrefix + strings.Repeat("!", 40),
+			inputLegacyDeadline: time.Time{},
+			expectError:         token.ErrInvalidToken,
+		},
+		{
+			name:                "legacy token without deadline",
+			inputPrefix:         token.UserTokenPrefix,
+			inputToken:          legacyToken,
+			inputLegacyDeadline: time.Time{},
+			expectError:         nil,
+		},
+		{
+			name:                "legacy token before deadline",
+			inputPrefix:         token.UserTokenPrefix,
+			inputToken:          legacyToken,
+			inputLegacyDeadline: now.Add(time.Hour),
+			expectError:         nil,
+		},
+		{
+			name:                "legacy token after deadline",
+			inputPrefix:         token.UserTokenPrefix,
+			inputToken:          legacyToken,
+			inputLegacyDeadline: now.Add(-time.Hour),
+			expectError:         token.ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := token.Validate(tt.inputPrefix, tt.inputToken, tt.inputLegacyDeadline, now); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestIsLegacy(t *testing.T) {
+	agentToken, err := token.GenerateWithPrefix(token.AgentTokenPrefix)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	legacyToken, err := token.Generate()
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name         string
+		inputToken   string
+		expectResult bool
+	}{
+		{
+			name:         "legacy token",
+			inputToken:   legacyToken,
+			expectResult: true,
+		},
+		{
+			name:         "legacy length with prefix",
+			inputToken:   token.AgentDelegatedTokenPrefix + legacyToken[len(token.AgentDelegatedTokenPrefix):],
+			expectResult: true,
+		},
+		{
+			name:         "prefixed token",
+			inputToken:   agentToken,
+			expectResult: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := token.IsLegacy(tt.inputToken); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
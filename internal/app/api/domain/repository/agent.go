@@ -14,7 +14,11 @@ type AgentRepository interface {
 	Delete(context.Context, *entity.Agent) error
 	FindOneByIDAndUserIDAndNotDeleted(context.Context, uuid.UUID, uuid.UUID) (*entity.Agent, error)
 	FindOneByTokenAndNotDeleted(context.Context, string) (*entity.Agent, error)
+	FindOneByCertificateAndNotDeleted(context.Context, string, string) (*entity.Agent, error)
+	FindOneBySecretKeyIDAndNotDeleted(context.Context, string) (*entity.Agent, error)
+	FindOneByPublicKeyIDAndNotDeleted(context.Context, uuid.UUID) (*entity.Agent, error)
 	FindByNamePrefixAndUserIDAndNotDeleted(context.Context, string, uuid.UUID) ([]*entity.Agent, error)
+	FindByUserIDAndNotDeletedForUpdate(context.Context, uuid.UUID) ([]*entity.Agent, error)
 	FindByIDsAndUserIDAndNotDeleted(context.Context, []uuid.UUID, uuid.UUID) ([]*entity.Agent, error)
 	FindByIDsAndNamePrefixAndUserIDAndNotDeleted(context.Context, []uuid.UUID, string, uuid.UUID) ([]*entity.Agent, error)
 }
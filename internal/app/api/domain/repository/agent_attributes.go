@@ -0,0 +1,14 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../../test/mock/domain/repository/$GOFILE
+package repository
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type AgentAttributesRepository interface {
+	Save(context.Context, *entity.AgentAttributes) error
+	FindOneByAgentID(context.Context, uuid.UUID) (*entity.AgentAttributes, error)
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../../test/mock/domain/repository/$GOFILE
+package repository
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type AgentCertificateRepository interface {
+	Save(context.Context, *entity.AgentCertificate) error
+	Delete(context.Context, *entity.AgentCertificate) error
+	FindOneByAgentIDAndUserID(context.Context, uuid.UUID, uuid.UUID) (*entity.AgentCertificate, error)
+	FindOneByFingerprintOrSubjectForUpdate(context.Context, string, string) (*entity.AgentCertificate, error)
+}
@@ -0,0 +1,15 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../../test/mock/domain/repository/$GOFILE
+package repository
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type AgentDelegatedTokenRepository interface {
+	Create(context.Context, *entity.AgentDelegatedToken) error
+	DeleteExpiredByAgentID(context.Context, uuid.UUID) error
+	FindOneByTokenAndNotExpired(context.Context, string) (*entity.AgentDelegatedToken, error)
+}
@@ -0,0 +1,17 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../../test/mock/domain/repository/$GOFILE
+package repository
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type AgentPublicKeyRepository interface {
+	Create(context.Context, *entity.AgentPublicKey) error
+	Delete(context.Context, *entity.AgentPublicKey) error
+	FindOneByID(context.Context, uuid.UUID) (*entity.AgentPublicKey, error)
+	FindOneByIDAndAgentIDAndUserID(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) (*entity.AgentPublicKey, error)
+	FindByAgentIDAndUserID(context.Context, uuid.UUID, uuid.UUID) ([]*entity.AgentPublicKey, error)
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../../test/mock/domain/repository/$GOFILE
+package repository
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type AgentSecretRepository interface {
+	Save(context.Context, *entity.AgentSecret) error
+	Delete(context.Context, *entity.AgentSecret) error
+	FindOneByAgentIDAndUserID(context.Context, uuid.UUID, uuid.UUID) (*entity.AgentSecret, error)
+	FindOneByKeyID(context.Context, string) (*entity.AgentSecret, error)
+}
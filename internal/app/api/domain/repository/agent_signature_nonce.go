@@ -0,0 +1,14 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../../test/mock/domain/repository/$GOFILE
+package repository
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type AgentSignatureNonceRepository interface {
+	Create(context.Context, *entity.AgentSignatureNonce) error
+	DeleteExpiredByAgentID(context.Context, uuid.UUID) error
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../../test/mock/domain/repository/$GOFILE
+package repository
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PermissionRepository interface {
+	Save(context.Context, *entity.Permission) error
+	Delete(context.Context, *entity.Permission) error
+	FindOneByAgentIDAndPolicyIDAndUserID(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) (*entity.Permission, error)
+	FindByAgentIDAndUserID(context.Context, uuid.UUID, uuid.UUID) ([]*entity.Permission, error)
+	FindExpired(context.Context, time.Time) ([]*entity.Permission, error)
+}
@@ -13,7 +13,11 @@ type PolicyRepository interface {
 	Update(context.Context, *entity.Policy) error
 	Delete(context.Context, *entity.Policy) error
 	FindOneByIDAndUserIDAndNotDeleted(context.Context, uuid.UUID, uuid.UUID) (*entity.Policy, error)
+	FindOneByIDAndUserIDAndNotDeletedForUpdate(context.Context, uuid.UUID, uuid.UUID) (*entity.Policy, error)
+	FindOneByNameAndUserIDAndNotDeleted(context.Context, string, uuid.UUID) (*entity.Policy, error)
 	FindByNamePrefixAndUserIDAndNotDeleted(context.Context, string, uuid.UUID) ([]*entity.Policy, error)
+	FindByUserIDAndNotDeletedForUpdate(context.Context, uuid.UUID) ([]*entity.Policy, error)
 	FindByIDsAndUserIDAndNotDeleted(context.Context, []uuid.UUID, uuid.UUID) ([]*entity.Policy, error)
 	FindByIDsAndNamePrefixAndUserIDAndNotDeleted(context.Context, []uuid.UUID, string, uuid.UUID) ([]*entity.Policy, error)
+	FindUnattachedByUserIDAndNotDeleted(context.Context, uuid.UUID) ([]*entity.Policy, error)
 }
@@ -0,0 +1,19 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../../test/mock/domain/repository/$GOFILE
+package repository
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// ロールとエージェントグループで共通のリポジトリ. 1つのリポジトリは1種類のPolicyBundleのみを扱う.
+type PolicyBundleRepository interface {
+	Create(context.Context, *entity.PolicyBundle) error
+	Update(context.Context, *entity.PolicyBundle) error
+	Delete(context.Context, *entity.PolicyBundle) error
+	FindOneByIDAndUserIDAndNotDeleted(context.Context, uuid.UUID, uuid.UUID) (*entity.PolicyBundle, error)
+	FindByNamePrefixAndUserIDAndNotDeleted(context.Context, string, uuid.UUID) ([]*entity.PolicyBundle, error)
+	FindByIDsAndUserIDAndNotDeleted(context.Context, []uuid.UUID, uuid.UUID) ([]*entity.PolicyBundle, error)
+}
@@ -0,0 +1,13 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../../test/mock/domain/repository/$GOFILE
+package repository
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+)
+
+type PolicyMatcherRepository interface {
+	Save(context.Context, *entity.PolicyMatcher) error
+	DeleteAll(context.Context) error
+	FindOneByKeyAndNotExpired(context.Context, string) (*entity.PolicyMatcher, error)
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../../test/mock/domain/repository/$GOFILE
+package repository
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type PolicyVersionRepository interface {
+	Create(context.Context, *entity.PolicyVersion) error
+	FindByPolicyIDAndUserID(context.Context, uuid.UUID, uuid.UUID) ([]*entity.PolicyVersion, error)
+	FindOneByPolicyIDAndVersionAndUserID(context.Context, uuid.UUID, int, uuid.UUID) (*entity.PolicyVersion, error)
+	FindOneLatestByPolicyID(context.Context, uuid.UUID) (*entity.PolicyVersion, error)
+}
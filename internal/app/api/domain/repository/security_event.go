@@ -0,0 +1,14 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../../test/mock/domain/repository/$GOFILE
+package repository
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type SecurityEventRepository interface {
+	Create(context.Context, *entity.SecurityEvent) error
+	FindByUserID(context.Context, uuid.UUID) ([]*entity.SecurityEvent, error)
+}
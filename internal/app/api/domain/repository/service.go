@@ -0,0 +1,18 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../../test/mock/domain/repository/$GOFILE
+package repository
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type ServiceRepository interface {
+	Create(context.Context, *entity.Service) error
+	Update(context.Context, *entity.Service) error
+	Delete(context.Context, *entity.Service) error
+	FindOneByIDAndUserIDAndNotDeleted(context.Context, uuid.UUID, uuid.UUID) (*entity.Service, error)
+	FindOneByNameAndUserIDAndNotDeleted(context.Context, string, uuid.UUID) (*entity.Service, error)
+	FindByNamePrefixAndUserIDAndNotDeleted(context.Context, string, uuid.UUID) ([]*entity.Service, error)
+}
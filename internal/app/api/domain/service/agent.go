@@ -7,9 +7,8 @@ import (
 	"holos-auth-api/internal/app/api/domain/repository"
 	"holos-auth-api/internal/app/api/pkg/status"
 	"net/http"
-	"regexp"
 	"slices"
-	"sort"
+	"time"
 )
 
 var (
@@ -18,16 +17,34 @@ var (
 
 type AgentService interface {
 	GetPolicies(context.Context, *entity.Agent, string) ([]*entity.Policy, error)
-	HasPermission(context.Context, *entity.Agent, string, string, string) (bool, error)
+	HasPermission(context.Context, *entity.Agent, *entity.AuthorizationRequest) (bool, error)
+	Evaluate(context.Context, *entity.Agent, *entity.AuthorizationRequest) (*entity.AuthorizationDecision, error)
+	EvaluateBatch(context.Context, *entity.Agent, []*entity.AuthorizationRequest) ([]*entity.AuthorizationDecision, error)
+	Expand(context.Context, *entity.Agent) (*entity.Agent, error)
+	GetEffectivePolicies(context.Context, *entity.Agent) ([]*entity.EffectivePolicy, error)
 }
 
 type agentService struct {
-	policyRepository repository.PolicyRepository
+	policyRepository          repository.PolicyRepository
+	agentGroupRepository      repository.PolicyBundleRepository
+	roleRepository            repository.PolicyBundleRepository
+	serviceRepository         repository.ServiceRepository
+	policyMatcherRepository   repository.PolicyMatcherRepository
+	userRepository            repository.UserRepository
+	agentAttributesRepository repository.AgentAttributesRepository
+	headImpliedByGet          bool
 }
 
-func NewAgentService(policyRepository repository.PolicyRepository) AgentService {
+func NewAgentService(policyRepository repository.PolicyRepository, agentGroupRepository repository.PolicyBundleRepository, roleRepository repository.PolicyBundleRepository, serviceRepository repository.ServiceRepository, policyMatcherRepository repository.PolicyMatcherRepository, userRepository repository.UserRepository, agentAttributesRepository repository.AgentAttributesRepository, headImpliedByGet bool) AgentService {
 	return &agentService{
-		policyRepository: policyRepository,
+		policyRepository:          policyRepository,
+		agentGroupRepository:      agentGroupRepository,
+		roleRepository:            roleRepository,
+		serviceRepository:         serviceRepository,
+		policyMatcherRepository:   policyMatcherRepository,
+		userRepository:            userRepository,
+		agentAttributesRepository: agentAttributesRepository,
+		headImpliedByGet:          headImpliedByGet,
 	}
 }
 
@@ -39,39 +56,194 @@ func (s *agentService) GetPolicies(ctx context.Context, agent *entity.Agent, key
 	return s.policyRepository.FindByIDsAndNamePrefixAndUserIDAndNotDeleted(ctx, agent.Policies, keyword, agent.UserID)
 }
 
-func (s *agentService) HasPermission(ctx context.Context, agent *entity.Agent, service string, path string, method string) (bool, error) {
-	policies, err := s.policyRepository.FindByIDsAndUserIDAndNotDeleted(ctx, agent.Policies, agent.UserID)
+func (s *agentService) HasPermission(ctx context.Context, agent *entity.Agent, request *entity.AuthorizationRequest) (bool, error) {
+	decision, err := s.Evaluate(ctx, agent, request)
 	if err != nil {
 		return false, err
 	}
-	if len(policies) == 0 {
-		return false, nil
+	return decision.Allowed, nil
+}
+
+// エージェントのポリシーをすべて評価し, 一致したポリシーにDENYが1つでもあれば拒否する. 一致するALLOWがない場合も拒否する.
+// 条件やCEL式を満たさないポリシーは一致しないものとして扱う. グループとロールのポリシーも直接付与されたポリシーと同様に評価する.
+// サービスレジストリに登録されていないサービスや, サービスが許可しないメソッドとパスへのリクエストはエラーとする.
+func (s *agentService) Evaluate(ctx context.Context, agent *entity.Agent, request *entity.AuthorizationRequest) (*entity.AuthorizationDecision, error) {
+	decisions, errs, err := s.evaluate(ctx, agent, []*entity.AuthorizationRequest{request})
+	if err != nil {
+		return nil, err
 	}
+	if errs[0] != nil {
+		return nil, errs[0]
+	}
+	return decisions[0], nil
+}
 
-	sort.Slice(policies, func(i, j int) bool {
-		return policies[j].Path < policies[i].Path
-	})
+// 複数のリクエストをEvaluateと同じ規則で評価し, リクエストと同じ順に判定を返す.
+// サービスに受け付けられないリクエストはバッチ全体をエラーとせず, その理由で拒否した判定とする.
+func (s *agentService) EvaluateBatch(ctx context.Context, agent *entity.Agent, requests []*entity.AuthorizationRequest) ([]*entity.AuthorizationDecision, error) {
+	decisions, errs, err := s.evaluate(ctx, agent, requests)
+	if err != nil {
+		return nil, err
+	}
+	for i, err := range errs {
+		if err != nil {
+			decisions[i] = entity.NewRejectedAuthorizationDecision(rejectReason(err))
+		}
+	}
+	return decisions, nil
+}
 
-	for _, policy := range policies {
-		if service != policy.Service || !slices.Contains(policy.Methods, method) {
+// サービスに受け付けられないリクエストのエラーはリクエストごとに返し, 判定はnilとする.
+// サービスは名前ごとに1回のみ取得する. エージェントのポリシーはコンパイル済みのものがあれば再利用し, なければ1回のみ取得する.
+func (s *agentService) evaluate(ctx context.Context, agent *entity.Agent, requests []*entity.AuthorizationRequest) ([]*entity.AuthorizationDecision, []error, error) {
+	if agent == nil {
+		return nil, nil, ErrRequiredAgent
+	}
+
+	services := map[string]*entity.Service{}
+	errs := make([]error, len(requests))
+	for i, request := range requests {
+		service, ok := services[request.Service]
+		if !ok {
+			var err error
+			service, err = s.serviceRepository.FindOneByNameAndUserIDAndNotDeleted(ctx, request.Service, agent.UserID)
+			if err != nil {
+				return nil, nil, err
+			}
+			services[request.Service] = service
+		}
+		if service == nil {
+			errs[i] = ErrServiceNotRegistered
 			continue
 		}
+		errs[i] = service.ValidateRequest(request, s.headImpliedByGet)
+	}
+
+	decisions := make([]*entity.AuthorizationDecision, len(requests))
+	if !slices.Contains(errs, nil) {
+		return decisions, errs, nil
+	}
 
-		pattern, err := regexp.Compile(`:[^/]+`)
+	policyMatcher, err := s.findPolicyMatcher(ctx, agent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, request := range requests {
+		if errs[i] != nil {
+			continue
+		}
+		evaluations, err := policyMatcher.Evaluate(request, s.headImpliedByGet)
 		if err != nil {
-			return false, err
+			return nil, nil, err
 		}
-		matched, err := regexp.MatchString("^"+pattern.ReplaceAllString(policy.Path, `[^/]+`), path)
+		decisions[i] = entity.NewAuthorizationDecision(evaluations)
+		decisions[i].ValidUntil = agent.TemporaryPoliciesValidUntil
+	}
+
+	return decisions, errs, nil
+}
+
+func rejectReason(err error) string {
+	switch err {
+	case ErrServiceNotRegistered:
+		return entity.AuthorizationDenyReasonServiceNotRegistered
+	case entity.ErrMethodNotAllowedByService:
+		return entity.AuthorizationDenyReasonMethodNotAllowedByService
+	default:
+		return entity.AuthorizationDenyReasonPathNotAllowedByService
+	}
+}
+
+// 期限内のコンパイル済みのポリシーがなければ, グループとロールを展開してポリシーを取得し, コンパイルして保存する.
+// パスの${name}はコンパイル時にユーザーとエージェントの属性で置き換える.
+func (s *agentService) findPolicyMatcher(ctx context.Context, agent *entity.Agent) (*entity.PolicyMatcher, error) {
+	policyMatcher, err := s.policyMatcherRepository.FindOneByKeyAndNotExpired(ctx, entity.PolicyMatcherKey(agent))
+	if err != nil {
+		return nil, err
+	}
+	if policyMatcher != nil {
+		return policyMatcher, nil
+	}
+
+	expanded, err := s.Expand(ctx, agent)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := s.policyRepository.FindByIDsAndUserIDAndNotDeleted(ctx, expanded.Policies, agent.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.FindOneByIDAndNotDeleted(ctx, agent.UserID)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := s.agentAttributesRepository.FindOneByAgentID(ctx, agent.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	policyMatcher = entity.NewPolicyMatcher(entity.NewPrincipal(agent, user, attributes), policies, time.Now())
+	if err := s.policyMatcherRepository.Save(ctx, policyMatcher); err != nil {
+		return nil, err
+	}
+	return policyMatcher, nil
+}
+
+// グループ, ロールと期間を指定した紐付けのポリシーを展開したエージェントを返す. いずれも持たない場合はそのまま返す.
+func (s *agentService) Expand(ctx context.Context, agent *entity.Agent) (*entity.Agent, error) {
+	if agent == nil {
+		return nil, ErrRequiredAgent
+	}
+	if len(agent.Groups) == 0 && len(agent.Roles) == 0 && len(agent.TemporaryPolicies) == 0 {
+		return agent, nil
+	}
+
+	groups, roles, err := s.findGroupsAndRoles(ctx, agent)
+	if err != nil {
+		return nil, err
+	}
+
+	return agent.Expand(groups, roles), nil
+}
+
+func (s *agentService) GetEffectivePolicies(ctx context.Context, agent *entity.Agent) ([]*entity.EffectivePolicy, error) {
+	if agent == nil {
+		return nil, ErrRequiredAgent
+	}
+
+	groups, roles, err := s.findGroupsAndRoles(ctx, agent)
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := s.policyRepository.FindByIDsAndUserIDAndNotDeleted(ctx, agent.Expand(groups, roles).Policies, agent.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewEffectivePolicies(agent, groups, roles, policies), nil
+}
+
+func (s *agentService) findGroupsAndRoles(ctx context.Context, agent *entity.Agent) ([]*entity.PolicyBundle, []*entity.PolicyBundle, error) {
+	groups := []*entity.PolicyBundle{}
+	if len(agent.Groups) != 0 {
+		var err error
+		groups, err = s.agentGroupRepository.FindByIDsAndUserIDAndNotDeleted(ctx, agent.Groups, agent.UserID)
 		if err != nil {
-			return false, err
+			return nil, nil, err
 		}
-		if matched {
-			if policy.Effect == "ALLOW" {
-				return true, nil
-			}
-			break
+	}
+
+	roles := []*entity.PolicyBundle{}
+	if len(agent.Roles) != 0 {
+		var err error
+		roles, err = s.roleRepository.FindByIDsAndUserIDAndNotDeleted(ctx, agent.Roles, agent.UserID)
+		if err != nil {
+			return nil, nil, err
 		}
 	}
 
-	return false, nil
+	return groups, roles, nil
 }
@@ -8,6 +8,7 @@ import (
 	"holos-auth-api/internal/app/api/domain/service"
 	mockRepository "holos-auth-api/test/mock/domain/repository"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
@@ -91,7 +92,7 @@ func TestAgent_GetPolicies(t *testing.T) {
 
 			tt.setMockPolicyRepository(ctx, pr)
 
-			as := service.NewAgentService(pr)
+			as := service.NewAgentService(pr, nil, nil, nil, nil, nil, nil, false)
 			result, err := as.GetPolicies(ctx, tt.inputAgent, tt.inputKeyword)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -124,6 +125,32 @@ func TestAgent_HasPermission(t *testing.T) {
 	if err != nil {
 		t.Error(err.Error())
 	}
+	globAllowPolicy, err := entity.NewPolicy(agent.UserID, "name", "ALLOW", "STORAGE", "/path/**", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	anyMethodAllowPolicy, err := entity.NewPolicy(agent.UserID, "name", "ALLOW", "STORAGE", "/path/:id", []string{"*"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	prefixAllowPolicy, err := entity.NewPolicy(agent.UserID, "name", "ALLOW", "STORAGE", "/pa", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	networkConditions, err := entity.NewPolicyConditions("", nil, "", "", nil, nil, []string{"10.0.0.0/8"}, nil, nil)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	conditionalAllowPolicy, err := entity.NewPolicy(agent.UserID, "name", "ALLOW", "STORAGE", "/path/:id", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	conditionalAllowPolicy.SetConditions(networkConditions)
+	conditionalDenyPolicy, err := entity.NewPolicy(agent.UserID, "name", "DENY", "STORAGE", "/path/:id", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	conditionalDenyPolicy.SetConditions(networkConditions)
 
 	tests := []struct {
 		name                    string
@@ -131,6 +158,8 @@ func TestAgent_HasPermission(t *testing.T) {
 		inputService            string
 		inputPath               string
 		inputMethod             string
+		inputSourceIP           string
+		inputHeadImpliedByGet   bool
 		expectResult            bool
 		expectError             error
 		setMockPolicyRepository func(context.Context, *mockRepository.MockPolicyRepository)
@@ -146,7 +175,7 @@ func TestAgent_HasPermission(t *testing.T) {
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
 					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
-					Return([]*entity.Policy{rootDenyPolicy, allowPolicy}, nil).
+					Return([]*entity.Policy{allowPolicy}, nil).
 					Times(1)
 			},
 		},
@@ -165,6 +194,112 @@ func TestAgent_HasPermission(t *testing.T) {
 					Times(1)
 			},
 		},
+		{
+			name:         "has glob permission",
+			inputAgent:   agent,
+			inputService: "STORAGE",
+			inputPath:    "/path/1/2",
+			inputMethod:  "GET",
+			expectResult: true,
+			expectError:  nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return([]*entity.Policy{globAllowPolicy}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "deny overrides more specific allow",
+			inputAgent:   agent,
+			inputService: "STORAGE",
+			inputPath:    "/path/1",
+			inputMethod:  "GET",
+			expectResult: false,
+			expectError:  nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return([]*entity.Policy{rootDenyPolicy, allowPolicy}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "deny overrides allow regardless of order",
+			inputAgent:   agent,
+			inputService: "STORAGE",
+			inputPath:    "/path/1",
+			inputMethod:  "GET",
+			expectResult: false,
+			expectError:  nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return([]*entity.Policy{globAllowPolicy, rootDenyPolicy}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "prefix not on segment boundary",
+			inputAgent:   agent,
+			inputService: "STORAGE",
+			inputPath:    "/path/1",
+			inputMethod:  "GET",
+			expectResult: false,
+			expectError:  nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return([]*entity.Policy{prefixAllowPolicy}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "has any method permission",
+			inputAgent:   agent,
+			inputService: "STORAGE",
+			inputPath:    "/path/1",
+			inputMethod:  "PATCH",
+			expectResult: true,
+			expectError:  nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return([]*entity.Policy{anyMethodAllowPolicy}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "has head permission implied by get",
+			inputAgent:            agent,
+			inputService:          "STORAGE",
+			inputPath:             "/path/1",
+			inputMethod:           "HEAD",
+			inputHeadImpliedByGet: true,
+			expectResult:          true,
+			expectError:           nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return([]*entity.Policy{allowPolicy}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "does not have head permission",
+			inputAgent:   agent,
+			inputService: "STORAGE",
+			inputPath:    "/path/1",
+			inputMethod:  "HEAD",
+			expectResult: false,
+			expectError:  nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return([]*entity.Policy{allowPolicy}, nil).
+					Times(1)
+			},
+		},
 		{
 			name:         "does not have permission",
 			inputAgent:   agent,
@@ -225,6 +360,54 @@ func TestAgent_HasPermission(t *testing.T) {
 					Times(1)
 			},
 		},
+		{
+			name:          "conditions satisfied",
+			inputAgent:    agent,
+			inputService:  "STORAGE",
+			inputPath:     "/path/1",
+			inputMethod:   "GET",
+			inputSourceIP: "10.1.2.3",
+			expectResult:  true,
+			expectError:   nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return([]*entity.Policy{conditionalAllowPolicy}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "conditions not satisfied",
+			inputAgent:    agent,
+			inputService:  "STORAGE",
+			inputPath:     "/path/1",
+			inputMethod:   "GET",
+			inputSourceIP: "192.168.0.1",
+			expectResult:  false,
+			expectError:   nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return([]*entity.Policy{conditionalAllowPolicy}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "deny ignored when conditions not satisfied",
+			inputAgent:    agent,
+			inputService:  "STORAGE",
+			inputPath:     "/path/1",
+			inputMethod:   "GET",
+			inputSourceIP: "192.168.0.1",
+			expectResult:  true,
+			expectError:   nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return([]*entity.Policy{conditionalDenyPolicy, allowPolicy}, nil).
+					Times(1)
+			},
+		},
 		{
 			name:         "method not matched",
 			inputAgent:   agent,
@@ -247,13 +430,38 @@ func TestAgent_HasPermission(t *testing.T) {
 			defer ctrl.Finish()
 
 			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			sr := mockRepository.NewMockServiceRepository(ctrl)
 
 			ctx := context.Background()
 
 			tt.setMockPolicyRepository(ctx, pr)
+			sr.EXPECT().
+				FindOneByNameAndUserIDAndNotDeleted(ctx, tt.inputService, agent.UserID).
+				Return(entity.RestoreService(uuid.New(), agent.UserID, tt.inputService, []string{"*"}, "", time.Now(), time.Now()), nil).
+				AnyTimes()
+			mr := mockRepository.NewMockPolicyMatcherRepository(ctrl)
+			mr.EXPECT().
+				FindOneByKeyAndNotExpired(ctx, gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+			mr.EXPECT().
+				Save(ctx, gomock.Any()).
+				Return(nil).
+				AnyTimes()
 
-			as := service.NewAgentService(pr)
-			result, err := as.HasPermission(ctx, tt.inputAgent, tt.inputService, tt.inputPath, tt.inputMethod)
+			ur := mockRepository.NewMockUserRepository(ctrl)
+			ur.EXPECT().
+				FindOneByIDAndNotDeleted(ctx, gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+			atr := mockRepository.NewMockAgentAttributesRepository(ctrl)
+			atr.EXPECT().
+				FindOneByAgentID(ctx, gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+
+			as := service.NewAgentService(pr, nil, nil, sr, mr, ur, atr, tt.inputHeadImpliedByGet)
+			result, err := as.HasPermission(ctx, tt.inputAgent, entity.NewAuthorizationRequest(tt.inputService, tt.inputPath, tt.inputMethod, tt.inputSourceIP, nil))
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -263,3 +471,869 @@ func TestAgent_HasPermission(t *testing.T) {
 		})
 	}
 }
+
+func TestAgent_Evaluate(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	allowPolicy, err := entity.NewPolicy(agent.UserID, "allow", "ALLOW", "STORAGE", "/path/:id", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	denyPolicy, err := entity.NewPolicy(agent.UserID, "deny", "DENY", "STORAGE", "/path/:id/secret", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	storage, err := entity.NewService(agent.UserID, "STORAGE", []string{"GET"}, `/path(/.*)?`)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                     string
+		inputPath                string
+		expectAllowed            bool
+		expectDecidingPolicy     *entity.Policy
+		expectEvaluationResult   []string
+		expectError              error
+		setMockPolicyRepository  func(context.Context, *mockRepository.MockPolicyRepository)
+		setMockServiceRepository func(context.Context, *mockRepository.MockServiceRepository)
+	}{
+		{
+			name:                   "allowed",
+			inputPath:              "/path/1",
+			expectAllowed:          true,
+			expectDecidingPolicy:   allowPolicy,
+			expectEvaluationResult: []string{entity.PolicyMatchResultMatched, entity.PolicyMatchResultPathMismatch},
+			expectError:            nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, agent.Policies, agent.UserID).
+					Return([]*entity.Policy{allowPolicy, denyPolicy}, nil).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", agent.UserID).
+					Return(storage, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "denied",
+			inputPath:              "/path/1/secret",
+			expectAllowed:          false,
+			expectDecidingPolicy:   denyPolicy,
+			expectEvaluationResult: []string{entity.PolicyMatchResultMatched, entity.PolicyMatchResultMatched},
+			expectError:            nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, agent.Policies, agent.UserID).
+					Return([]*entity.Policy{allowPolicy, denyPolicy}, nil).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", agent.UserID).
+					Return(storage, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "find error",
+			inputPath:              "/path/1",
+			expectAllowed:          false,
+			expectDecidingPolicy:   nil,
+			expectEvaluationResult: nil,
+			expectError:            sql.ErrConnDone,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, agent.Policies, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", agent.UserID).
+					Return(storage, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                    "service not registered",
+			inputPath:               "/path/1",
+			expectAllowed:           false,
+			expectDecidingPolicy:    nil,
+			expectEvaluationResult:  nil,
+			expectError:             service.ErrServiceNotRegistered,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                    "path not allowed by service",
+			inputPath:               "/other",
+			expectAllowed:           false,
+			expectDecidingPolicy:    nil,
+			expectEvaluationResult:  nil,
+			expectError:             entity.ErrPathNotAllowedByService,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", agent.UserID).
+					Return(storage, nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			sr := mockRepository.NewMockServiceRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockPolicyRepository(ctx, pr)
+			tt.setMockServiceRepository(ctx, sr)
+			mr := mockRepository.NewMockPolicyMatcherRepository(ctrl)
+			mr.EXPECT().
+				FindOneByKeyAndNotExpired(ctx, gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+			mr.EXPECT().
+				Save(ctx, gomock.Any()).
+				Return(nil).
+				AnyTimes()
+
+			ur := mockRepository.NewMockUserRepository(ctrl)
+			ur.EXPECT().
+				FindOneByIDAndNotDeleted(ctx, gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+			atr := mockRepository.NewMockAgentAttributesRepository(ctrl)
+			atr.EXPECT().
+				FindOneByAgentID(ctx, gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+
+			as := service.NewAgentService(pr, nil, nil, sr, mr, ur, atr, false)
+			decision, err := as.Evaluate(ctx, agent, entity.NewAuthorizationRequest("STORAGE", tt.inputPath, "GET", "", nil))
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if tt.expectError != nil {
+				return
+			}
+
+			if decision.Allowed != tt.expectAllowed {
+				t.Errorf("allowed: expect %v but got %v", tt.expectAllowed, decision.Allowed)
+			}
+			if decision.DecidingPolicy != tt.expectDecidingPolicy {
+				t.Errorf("deciding_policy: expect %v but got %v", tt.expectDecidingPolicy, decision.DecidingPolicy)
+			}
+			results := make([]string, len(decision.Evaluations))
+			for i, evaluation := range decision.Evaluations {
+				results[i] = evaluation.Result
+			}
+			if diff := cmp.Diff(tt.expectEvaluationResult, results); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAgent_Evaluate_TemporaryPermission(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(agent.UserID, "allow", "ALLOW", "STORAGE", "/path/:id", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	storage, err := entity.NewService(agent.UserID, "STORAGE", []string{"GET"}, `/path(/.*)?`)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	validUntil := time.Now().Add(time.Second * 10)
+	agent.TemporaryPolicies = []uuid.UUID{policy.ID}
+	agent.TemporaryPoliciesValidUntil = &validUntil
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	pr := mockRepository.NewMockPolicyRepository(ctrl)
+	pr.EXPECT().
+		FindByIDsAndUserIDAndNotDeleted(ctx, []uuid.UUID{policy.ID}, agent.UserID).
+		Return([]*entity.Policy{policy}, nil).
+		Times(1)
+	sr := mockRepository.NewMockServiceRepository(ctrl)
+	sr.EXPECT().
+		FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", agent.UserID).
+		Return(storage, nil).
+		Times(1)
+	mr := mockRepository.NewMockPolicyMatcherRepository(ctrl)
+	mr.EXPECT().
+		FindOneByKeyAndNotExpired(ctx, gomock.Any()).
+		Return(nil, nil).
+		Times(1)
+	mr.EXPECT().
+		Save(ctx, gomock.Any()).
+		Return(nil).
+		Times(1)
+	ur := mockRepository.NewMockUserRepository(ctrl)
+	ur.EXPECT().
+		FindOneByIDAndNotDeleted(ctx, agent.UserID).
+		Return(nil, nil).
+		Times(1)
+	atr := mockRepository.NewMockAgentAttributesRepository(ctrl)
+	atr.EXPECT().
+		FindOneByAgentID(ctx, agent.ID).
+		Return(nil, nil).
+		Times(1)
+
+	as := service.NewAgentService(pr, nil, nil, sr, mr, ur, atr, false)
+	decision, err := as.Evaluate(ctx, agent, entity.NewAuthorizationRequest("STORAGE", "/path/1", "GET", "", nil))
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if !decision.Allowed {
+		t.Error("allowed: expect true but got false")
+	}
+	// 期間を指定した紐付けが終了するまでしかキャッシュさせない.
+	if ttl := decision.CacheTTL(); ttl < time.Second || time.Second*10 < ttl {
+		t.Errorf("cache_ttl: expect %v - %v but got %v", time.Second, time.Second*10, ttl)
+	}
+}
+
+func TestAgent_EvaluateBatch(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	allowPolicy, err := entity.NewPolicy(agent.UserID, "allow", "ALLOW", "STORAGE", "/path/:id", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	denyPolicy, err := entity.NewPolicy(agent.UserID, "deny", "DENY", "STORAGE", "/path/:id/secret", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	storage, err := entity.NewService(agent.UserID, "STORAGE", []string{"GET"}, `/path(/.*)?`)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	content, err := entity.NewService(agent.UserID, "CONTENT", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyMatcher := entity.NewPolicyMatcher(entity.NewPrincipal(agent, nil, nil), []*entity.Policy{allowPolicy}, time.Now())
+	requests := []*entity.AuthorizationRequest{
+		entity.NewAuthorizationRequest("STORAGE", "/path/1", "GET", "", nil),
+		entity.NewAuthorizationRequest("STORAGE", "/path/1/secret", "GET", "", nil),
+		entity.NewAuthorizationRequest("CONTENT", "/path/1", "GET", "", nil),
+	}
+
+	tests := []struct {
+		name                           string
+		inputRequests                  []*entity.AuthorizationRequest
+		expectAllowed                  []bool
+		expectDenyReasons              []string
+		expectError                    error
+		setMockPolicyRepository        func(context.Context, *mockRepository.MockPolicyRepository)
+		setMockServiceRepository       func(context.Context, *mockRepository.MockServiceRepository)
+		setMockPolicyMatcherRepository func(context.Context, *mockRepository.MockPolicyMatcherRepository)
+	}{
+		{
+			name:          "success",
+			inputRequests: requests,
+			expectAllowed: []bool{true, false, false},
+			expectDenyReasons: []string{
+				"",
+				entity.AuthorizationDenyReasonDeniedByPolicy,
+				entity.AuthorizationDenyReasonNoMatchingPolicy,
+			},
+			expectError: nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, agent.Policies, agent.UserID).
+					Return([]*entity.Policy{allowPolicy, denyPolicy}, nil).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", agent.UserID).
+					Return(storage, nil).
+					Times(1)
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "CONTENT", agent.UserID).
+					Return(content, nil).
+					Times(1)
+			},
+			setMockPolicyMatcherRepository: func(ctx context.Context, mr *mockRepository.MockPolicyMatcherRepository) {
+				mr.EXPECT().
+					FindOneByKeyAndNotExpired(ctx, entity.PolicyMatcherKey(agent)).
+					Return(nil, nil).
+					Times(1)
+				mr.EXPECT().
+					Save(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                    "cached",
+			inputRequests:           requests,
+			expectAllowed:           []bool{true, true, false},
+			expectDenyReasons:       []string{"", "", entity.AuthorizationDenyReasonNoMatchingPolicy},
+			expectError:             nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", agent.UserID).
+					Return(storage, nil).
+					Times(1)
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "CONTENT", agent.UserID).
+					Return(content, nil).
+					Times(1)
+			},
+			setMockPolicyMatcherRepository: func(ctx context.Context, mr *mockRepository.MockPolicyMatcherRepository) {
+				mr.EXPECT().
+					FindOneByKeyAndNotExpired(ctx, entity.PolicyMatcherKey(agent)).
+					Return(policyMatcher, nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "find error",
+			inputRequests: requests[:1],
+			expectAllowed: nil,
+			expectError:   sql.ErrConnDone,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, agent.Policies, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", agent.UserID).
+					Return(storage, nil).
+					Times(1)
+			},
+			setMockPolicyMatcherRepository: func(ctx context.Context, mr *mockRepository.MockPolicyMatcherRepository) {
+				mr.EXPECT().
+					FindOneByKeyAndNotExpired(ctx, entity.PolicyMatcherKey(agent)).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "service not registered",
+			inputRequests: []*entity.AuthorizationRequest{
+				entity.NewAuthorizationRequest("STORAGE", "/path/1", "GET", "", nil),
+				entity.NewAuthorizationRequest("OTHER", "/path/1", "GET", "", nil),
+			},
+			expectAllowed:           []bool{true, false},
+			expectDenyReasons:       []string{"", entity.AuthorizationDenyReasonServiceNotRegistered},
+			expectError:             nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", agent.UserID).
+					Return(storage, nil).
+					Times(1)
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "OTHER", agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockPolicyMatcherRepository: func(ctx context.Context, mr *mockRepository.MockPolicyMatcherRepository) {
+				mr.EXPECT().
+					FindOneByKeyAndNotExpired(ctx, entity.PolicyMatcherKey(agent)).
+					Return(policyMatcher, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "path not allowed by service",
+			inputRequests: []*entity.AuthorizationRequest{
+				entity.NewAuthorizationRequest("STORAGE", "/path/1", "GET", "", nil),
+				entity.NewAuthorizationRequest("STORAGE", "/other", "GET", "", nil),
+			},
+			expectAllowed:           []bool{true, false},
+			expectDenyReasons:       []string{"", entity.AuthorizationDenyReasonPathNotAllowedByService},
+			expectError:             nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", agent.UserID).
+					Return(storage, nil).
+					Times(1)
+			},
+			setMockPolicyMatcherRepository: func(ctx context.Context, mr *mockRepository.MockPolicyMatcherRepository) {
+				mr.EXPECT().
+					FindOneByKeyAndNotExpired(ctx, entity.PolicyMatcherKey(agent)).
+					Return(policyMatcher, nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			sr := mockRepository.NewMockServiceRepository(ctrl)
+			mr := mockRepository.NewMockPolicyMatcherRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockPolicyRepository(ctx, pr)
+			tt.setMockServiceRepository(ctx, sr)
+			tt.setMockPolicyMatcherRepository(ctx, mr)
+
+			ur := mockRepository.NewMockUserRepository(ctrl)
+			ur.EXPECT().
+				FindOneByIDAndNotDeleted(ctx, gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+			atr := mockRepository.NewMockAgentAttributesRepository(ctrl)
+			atr.EXPECT().
+				FindOneByAgentID(ctx, gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+
+			as := service.NewAgentService(pr, nil, nil, sr, mr, ur, atr, false)
+			decisions, err := as.EvaluateBatch(ctx, agent, tt.inputRequests)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if tt.expectError != nil {
+				return
+			}
+
+			allowed := make([]bool, len(decisions))
+			denyReasons := make([]string, len(decisions))
+			for i, decision := range decisions {
+				allowed[i] = decision.Allowed
+				denyReasons[i] = decision.DenyReason()
+			}
+			if diff := cmp.Diff(tt.expectAllowed, allowed); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tt.expectDenyReasons, denyReasons); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAgent_EvaluateWithPrincipal(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	user := entity.RestoreUser(agent.UserID, "alice", "", time.Now(), time.Now())
+	attributes := entity.RestoreAgentAttributes(agent.ID, map[string]string{"team": "t1"}, time.Now())
+	policies := []*entity.Policy{
+		entity.RestorePolicy(uuid.New(), agent.UserID, "own", "ALLOW", "STORAGE", "/users/${user.name}/**", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now()),
+		entity.RestorePolicy(uuid.New(), agent.UserID, "team", "ALLOW", "STORAGE", "/teams/${agent.attributes.team}", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now()),
+	}
+	storage := entity.RestoreService(uuid.New(), agent.UserID, "STORAGE", []string{"*"}, "", time.Now(), time.Now())
+
+	tests := []struct {
+		name                             string
+		inputPath                        string
+		expectAllowed                    bool
+		expectError                      error
+		setMockUserRepository            func(context.Context, *mockRepository.MockUserRepository)
+		setMockAgentAttributesRepository func(context.Context, *mockRepository.MockAgentAttributesRepository)
+	}{
+		{
+			name:          "own folder",
+			inputPath:     "/users/alice/files",
+			expectAllowed: true,
+			expectError:   nil,
+			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {
+				ur.EXPECT().
+					FindOneByIDAndNotDeleted(ctx, agent.UserID).
+					Return(user, nil).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {
+				atr.EXPECT().
+					FindOneByAgentID(ctx, agent.ID).
+					Return(attributes, nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "other user's folder",
+			inputPath:     "/users/bob/files",
+			expectAllowed: false,
+			expectError:   nil,
+			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {
+				ur.EXPECT().
+					FindOneByIDAndNotDeleted(ctx, agent.UserID).
+					Return(user, nil).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {
+				atr.EXPECT().
+					FindOneByAgentID(ctx, agent.ID).
+					Return(attributes, nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "agent attribute",
+			inputPath:     "/teams/t1",
+			expectAllowed: true,
+			expectError:   nil,
+			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {
+				ur.EXPECT().
+					FindOneByIDAndNotDeleted(ctx, agent.UserID).
+					Return(user, nil).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {
+				atr.EXPECT().
+					FindOneByAgentID(ctx, agent.ID).
+					Return(attributes, nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "agent attribute not set",
+			inputPath:     "/teams/t1",
+			expectAllowed: false,
+			expectError:   nil,
+			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {
+				ur.EXPECT().
+					FindOneByIDAndNotDeleted(ctx, agent.UserID).
+					Return(user, nil).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {
+				atr.EXPECT().
+					FindOneByAgentID(ctx, agent.ID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "find user error",
+			inputPath:     "/users/alice/files",
+			expectAllowed: false,
+			expectError:   sql.ErrConnDone,
+			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {
+				ur.EXPECT().
+					FindOneByIDAndNotDeleted(ctx, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {},
+		},
+		{
+			name:          "find agent attributes error",
+			inputPath:     "/users/alice/files",
+			expectAllowed: false,
+			expectError:   sql.ErrConnDone,
+			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {
+				ur.EXPECT().
+					FindOneByIDAndNotDeleted(ctx, agent.UserID).
+					Return(user, nil).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {
+				atr.EXPECT().
+					FindOneByAgentID(ctx, agent.ID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			sr := mockRepository.NewMockServiceRepository(ctrl)
+			mr := mockRepository.NewMockPolicyMatcherRepository(ctrl)
+			ur := mockRepository.NewMockUserRepository(ctrl)
+			atr := mockRepository.NewMockAgentAttributesRepository(ctrl)
+
+			ctx := context.Background()
+
+			pr.EXPECT().
+				FindByIDsAndUserIDAndNotDeleted(ctx, agent.Policies, agent.UserID).
+				Return(policies, nil).
+				Times(1)
+			sr.EXPECT().
+				FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", agent.UserID).
+				Return(storage, nil).
+				Times(1)
+			mr.EXPECT().
+				FindOneByKeyAndNotExpired(ctx, entity.PolicyMatcherKey(agent)).
+				Return(nil, nil).
+				Times(1)
+			mr.EXPECT().
+				Save(ctx, gomock.Any()).
+				Return(nil).
+				AnyTimes()
+			tt.setMockUserRepository(ctx, ur)
+			tt.setMockAgentAttributesRepository(ctx, atr)
+
+			as := service.NewAgentService(pr, nil, nil, sr, mr, ur, atr, false)
+			decision, err := as.Evaluate(ctx, agent, entity.NewAuthorizationRequest("STORAGE", tt.inputPath, "GET", "", nil))
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if tt.expectError != nil {
+				return
+			}
+
+			if decision.Allowed != tt.expectAllowed {
+				t.Errorf("allowed: expect %v but got %v", tt.expectAllowed, decision.Allowed)
+			}
+		})
+	}
+}
+
+func TestAgent_Expand(t *testing.T) {
+	directPolicyID := uuid.New()
+	groupPolicyID := uuid.New()
+	rolePolicyID := uuid.New()
+	agentWithoutGroupsAndRoles := entity.RestoreAgent(uuid.New(), uuid.New(), "name", []uuid.UUID{directPolicyID}, []uuid.UUID{}, []uuid.UUID{}, time.Now(), time.Now())
+	agentWithGroupsAndRoles := entity.RestoreAgent(uuid.New(), agentWithoutGroupsAndRoles.UserID, "name", []uuid.UUID{directPolicyID}, []uuid.UUID{uuid.New()}, []uuid.UUID{uuid.New()}, time.Now(), time.Now())
+	group := entity.RestorePolicyBundle(entity.PolicyBundleKindAgentGroup, agentWithGroupsAndRoles.Groups[0], agentWithGroupsAndRoles.UserID, "name", []uuid.UUID{groupPolicyID}, []uuid.UUID{agentWithGroupsAndRoles.ID}, time.Now(), time.Now())
+	role := entity.RestorePolicyBundle(entity.PolicyBundleKindRole, agentWithGroupsAndRoles.Roles[0], agentWithGroupsAndRoles.UserID, "name", []uuid.UUID{rolePolicyID}, []uuid.UUID{agentWithGroupsAndRoles.ID}, time.Now(), time.Now())
+	temporaryPolicyID := uuid.New()
+	agentWithTemporaryPolicies := entity.RestoreAgent(uuid.New(), agentWithoutGroupsAndRoles.UserID, "name", []uuid.UUID{directPolicyID}, []uuid.UUID{}, []uuid.UUID{}, time.Now(), time.Now())
+	agentWithTemporaryPolicies.TemporaryPolicies = []uuid.UUID{temporaryPolicyID}
+
+	tests := []struct {
+		name                        string
+		inputAgent                  *entity.Agent
+		expectPolicies              []uuid.UUID
+		expectError                 error
+		setMockAgentGroupRepository func(context.Context, *mockRepository.MockPolicyBundleRepository)
+		setMockRoleRepository       func(context.Context, *mockRepository.MockPolicyBundleRepository)
+	}{
+		{
+			name:                        "without groups and roles",
+			inputAgent:                  agentWithoutGroupsAndRoles,
+			expectPolicies:              []uuid.UUID{directPolicyID},
+			expectError:                 nil,
+			setMockAgentGroupRepository: func(ctx context.Context, agr *mockRepository.MockPolicyBundleRepository) {},
+			setMockRoleRepository:       func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {},
+		},
+		{
+			name:                        "with temporary policies",
+			inputAgent:                  agentWithTemporaryPolicies,
+			expectPolicies:              []uuid.UUID{directPolicyID, temporaryPolicyID},
+			expectError:                 nil,
+			setMockAgentGroupRepository: func(ctx context.Context, agr *mockRepository.MockPolicyBundleRepository) {},
+			setMockRoleRepository:       func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {},
+		},
+		{
+			name:           "with groups and roles",
+			inputAgent:     agentWithGroupsAndRoles,
+			expectPolicies: []uuid.UUID{directPolicyID, groupPolicyID, rolePolicyID},
+			expectError:    nil,
+			setMockAgentGroupRepository: func(ctx context.Context, agr *mockRepository.MockPolicyBundleRepository) {
+				agr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, agentWithGroupsAndRoles.Groups, agentWithGroupsAndRoles.UserID).
+					Return([]*entity.PolicyBundle{group}, nil).
+					Times(1)
+			},
+			setMockRoleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, agentWithGroupsAndRoles.Roles, agentWithGroupsAndRoles.UserID).
+					Return([]*entity.PolicyBundle{role}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "find groups error",
+			inputAgent:     agentWithGroupsAndRoles,
+			expectPolicies: nil,
+			expectError:    sql.ErrConnDone,
+			setMockAgentGroupRepository: func(ctx context.Context, agr *mockRepository.MockPolicyBundleRepository) {
+				agr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, agentWithGroupsAndRoles.Groups, agentWithGroupsAndRoles.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockRoleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {},
+		},
+		{
+			name:           "find roles error",
+			inputAgent:     agentWithGroupsAndRoles,
+			expectPolicies: nil,
+			expectError:    sql.ErrConnDone,
+			setMockAgentGroupRepository: func(ctx context.Context, agr *mockRepository.MockPolicyBundleRepository) {
+				agr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, agentWithGroupsAndRoles.Groups, agentWithGroupsAndRoles.UserID).
+					Return([]*entity.PolicyBundle{group}, nil).
+					Times(1)
+			},
+			setMockRoleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, agentWithGroupsAndRoles.Roles, agentWithGroupsAndRoles.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:                        "no agent",
+			inputAgent:                  nil,
+			expectPolicies:              nil,
+			expectError:                 service.ErrRequiredAgent,
+			setMockAgentGroupRepository: func(ctx context.Context, agr *mockRepository.MockPolicyBundleRepository) {},
+			setMockRoleRepository:       func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			agr := mockRepository.NewMockPolicyBundleRepository(ctrl)
+			rr := mockRepository.NewMockPolicyBundleRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockAgentGroupRepository(ctx, agr)
+			tt.setMockRoleRepository(ctx, rr)
+
+			as := service.NewAgentService(nil, agr, rr, nil, nil, nil, nil, false)
+			result, err := as.Expand(ctx, tt.inputAgent)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if tt.expectError != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tt.expectPolicies, result.Policies); diff != "" {
+				t.Error(diff)
+			}
+			if len(result.Groups) != 0 {
+				t.Errorf("groups: expect empty but got %v", result.Groups)
+			}
+			if len(result.Roles) != 0 {
+				t.Errorf("roles: expect empty but got %v", result.Roles)
+			}
+		})
+	}
+}
+
+func TestAgent_GetEffectivePolicies(t *testing.T) {
+	agent := entity.RestoreAgent(uuid.New(), uuid.New(), "name", []uuid.UUID{}, []uuid.UUID{uuid.New()}, []uuid.UUID{}, time.Now(), time.Now())
+	policy, err := entity.NewPolicy(agent.UserID, "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agent.Policies = []uuid.UUID{policy.ID}
+	group := entity.RestorePolicyBundle(entity.PolicyBundleKindAgentGroup, agent.Groups[0], agent.UserID, "group_name", []uuid.UUID{policy.ID}, []uuid.UUID{agent.ID}, time.Now(), time.Now())
+
+	tests := []struct {
+		name                        string
+		inputAgent                  *entity.Agent
+		expectResult                []*entity.EffectivePolicy
+		expectError                 error
+		setMockPolicyRepository     func(context.Context, *mockRepository.MockPolicyRepository)
+		setMockAgentGroupRepository func(context.Context, *mockRepository.MockPolicyBundleRepository)
+	}{
+		{
+			name:       "success",
+			inputAgent: agent,
+			expectResult: []*entity.EffectivePolicy{
+				{
+					Policy: policy,
+					Sources: []*entity.PolicySource{
+						{Type: entity.PolicySourceTypeDirect, ID: agent.ID, Name: agent.Name},
+						{Type: entity.PolicySourceTypeGroup, ID: group.ID, Name: group.Name},
+					},
+				},
+			},
+			expectError: nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, []uuid.UUID{policy.ID}, agent.UserID).
+					Return([]*entity.Policy{policy}, nil).
+					Times(1)
+			},
+			setMockAgentGroupRepository: func(ctx context.Context, agr *mockRepository.MockPolicyBundleRepository) {
+				agr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, agent.Groups, agent.UserID).
+					Return([]*entity.PolicyBundle{group}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "find policies error",
+			inputAgent:   agent,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, []uuid.UUID{policy.ID}, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentGroupRepository: func(ctx context.Context, agr *mockRepository.MockPolicyBundleRepository) {
+				agr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, agent.Groups, agent.UserID).
+					Return([]*entity.PolicyBundle{group}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                        "no agent",
+			inputAgent:                  nil,
+			expectResult:                nil,
+			expectError:                 service.ErrRequiredAgent,
+			setMockPolicyRepository:     func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockAgentGroupRepository: func(ctx context.Context, agr *mockRepository.MockPolicyBundleRepository) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			agr := mockRepository.NewMockPolicyBundleRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockPolicyRepository(ctx, pr)
+			tt.setMockAgentGroupRepository(ctx, agr)
+
+			as := service.NewAgentService(pr, agr, nil, nil, nil, nil, nil, false)
+			result, err := as.GetEffectivePolicies(ctx, tt.inputAgent)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
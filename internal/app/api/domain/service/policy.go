@@ -10,20 +10,24 @@ import (
 )
 
 var (
-	ErrRequiredPolicy = status.Error(http.StatusInternalServerError, "policy is required")
+	ErrRequiredPolicy       = status.Error(http.StatusInternalServerError, "policy is required")
+	ErrServiceNotRegistered = status.Error(http.StatusBadRequest, "service is not registered")
 )
 
 type PolicyService interface {
 	GetAgents(context.Context, *entity.Policy, string) ([]*entity.Agent, error)
+	ValidateService(context.Context, *entity.Policy) error
 }
 
 type policyService struct {
-	agentRepository repository.AgentRepository
+	agentRepository   repository.AgentRepository
+	serviceRepository repository.ServiceRepository
 }
 
-func NewPolicyService(agentRepository repository.AgentRepository) PolicyService {
+func NewPolicyService(agentRepository repository.AgentRepository, serviceRepository repository.ServiceRepository) PolicyService {
 	return &policyService{
-		agentRepository: agentRepository,
+		agentRepository:   agentRepository,
+		serviceRepository: serviceRepository,
 	}
 }
 
@@ -34,3 +38,20 @@ func (s *policyService) GetAgents(ctx context.Context, polisy *entity.Policy, ke
 
 	return s.agentRepository.FindByIDsAndNamePrefixAndUserIDAndNotDeleted(ctx, polisy.Agents, keyword, polisy.UserID)
 }
+
+// ポリシーのサービスがサービスレジストリに登録され, メソッドとパスがサービスの定義に沿っているか検証する.
+func (s *policyService) ValidateService(ctx context.Context, policy *entity.Policy) error {
+	if policy == nil {
+		return ErrRequiredPolicy
+	}
+
+	service, err := s.serviceRepository.FindOneByNameAndUserIDAndNotDeleted(ctx, policy.Service, policy.UserID)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return ErrServiceNotRegistered
+	}
+
+	return service.ValidatePolicy(policy)
+}
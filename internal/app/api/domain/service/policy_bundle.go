@@ -0,0 +1,47 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../../test/mock/domain/service/$GOFILE
+package service
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+)
+
+var (
+	ErrRequiredPolicyBundle = status.Error(http.StatusInternalServerError, "policy bundle is required")
+)
+
+type PolicyBundleService interface {
+	GetPolicies(context.Context, *entity.PolicyBundle, string) ([]*entity.Policy, error)
+	GetAgents(context.Context, *entity.PolicyBundle, string) ([]*entity.Agent, error)
+}
+
+type policyBundleService struct {
+	policyRepository repository.PolicyRepository
+	agentRepository  repository.AgentRepository
+}
+
+func NewPolicyBundleService(policyRepository repository.PolicyRepository, agentRepository repository.AgentRepository) PolicyBundleService {
+	return &policyBundleService{
+		policyRepository: policyRepository,
+		agentRepository:  agentRepository,
+	}
+}
+
+func (s *policyBundleService) GetPolicies(ctx context.Context, bundle *entity.PolicyBundle, keyword string) ([]*entity.Policy, error) {
+	if bundle == nil {
+		return nil, ErrRequiredPolicyBundle
+	}
+
+	return s.policyRepository.FindByIDsAndNamePrefixAndUserIDAndNotDeleted(ctx, bundle.Policies, keyword, bundle.UserID)
+}
+
+func (s *policyBundleService) GetAgents(ctx context.Context, bundle *entity.PolicyBundle, keyword string) ([]*entity.Agent, error) {
+	if bundle == nil {
+		return nil, ErrRequiredPolicyBundle
+	}
+
+	return s.agentRepository.FindByIDsAndNamePrefixAndUserIDAndNotDeleted(ctx, bundle.Agents, keyword, bundle.UserID)
+}
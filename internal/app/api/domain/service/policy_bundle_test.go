@@ -0,0 +1,159 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/service"
+	mockRepository "holos-auth-api/test/mock/domain/repository"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestPolicyBundle_GetPolicies(t *testing.T) {
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(role.UserID, "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                    string
+		inputBundle             *entity.PolicyBundle
+		expectResult            []*entity.Policy
+		expectError             error
+		setMockPolicyRepository func(context.Context, *mockRepository.MockPolicyRepository)
+	}{
+		{
+			name:         "success",
+			inputBundle:  role,
+			expectResult: []*entity.Policy{policy},
+			expectError:  nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndNamePrefixAndUserIDAndNotDeleted(ctx, role.Policies, "name", role.UserID).
+					Return([]*entity.Policy{policy}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "find error",
+			inputBundle:  role,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndNamePrefixAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:                    "no bundle",
+			inputBundle:             nil,
+			expectResult:            nil,
+			expectError:             service.ErrRequiredPolicyBundle,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockPolicyRepository(ctx, pr)
+
+			bs := service.NewPolicyBundleService(pr, nil)
+			result, err := bs.GetPolicies(ctx, tt.inputBundle, "name")
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_GetAgents(t *testing.T) {
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agent, err := entity.NewAgent(role.UserID, "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		inputBundle            *entity.PolicyBundle
+		expectResult           []*entity.Agent
+		expectError            error
+		setMockAgentRepository func(context.Context, *mockRepository.MockAgentRepository)
+	}{
+		{
+			name:         "success",
+			inputBundle:  role,
+			expectResult: []*entity.Agent{agent},
+			expectError:  nil,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByIDsAndNamePrefixAndUserIDAndNotDeleted(ctx, role.Agents, "name", role.UserID).
+					Return([]*entity.Agent{agent}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "find error",
+			inputBundle:  role,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByIDsAndNamePrefixAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no bundle",
+			inputBundle:            nil,
+			expectResult:           nil,
+			expectError:            service.ErrRequiredPolicyBundle,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockAgentRepository(ctx, ar)
+
+			bs := service.NewPolicyBundleService(nil, ar)
+			result, err := bs.GetAgents(ctx, tt.inputBundle, "name")
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
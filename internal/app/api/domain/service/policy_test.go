@@ -91,7 +91,7 @@ func TestPolicy_GetAgents(t *testing.T) {
 
 			tt.setMockAgentRepository(ctx, ar)
 
-			as := service.NewPolicyService(ar)
+			as := service.NewPolicyService(ar, nil)
 			result, err := as.GetAgents(ctx, tt.inputPolicy, tt.inputKeyword)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -102,3 +102,93 @@ func TestPolicy_GetAgents(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicy_ValidateService(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/files/a", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	registered, err := entity.NewService(policy.UserID, "STORAGE", []string{"GET", "PUT"}, `/files(/.*)?`)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	restricted, err := entity.NewService(policy.UserID, "STORAGE", []string{"PUT"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                     string
+		inputPolicy              *entity.Policy
+		expectError              error
+		setMockServiceRepository func(context.Context, *mockRepository.MockServiceRepository)
+	}{
+		{
+			name:        "success",
+			inputPolicy: policy,
+			expectError: nil,
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, policy.Service, policy.UserID).
+					Return(registered, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "service not registered",
+			inputPolicy: policy,
+			expectError: service.ErrServiceNotRegistered,
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, policy.Service, policy.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "method not allowed by service",
+			inputPolicy: policy,
+			expectError: entity.ErrInvalidPolicyMethods,
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, policy.Service, policy.UserID).
+					Return(restricted, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "find error",
+			inputPolicy: policy,
+			expectError: sql.ErrConnDone,
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, policy.Service, policy.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:                     "no policy",
+			inputPolicy:              nil,
+			expectError:              service.ErrRequiredPolicy,
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sr := mockRepository.NewMockServiceRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockServiceRepository(ctx, sr)
+
+			ps := service.NewPolicyService(nil, sr)
+			if err := ps.ValidateService(ctx, tt.inputPolicy); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
@@ -8,3 +8,31 @@ import (
 type TransactionObject interface {
 	Transaction(context.Context, func(context.Context) error) error
 }
+
+type afterCommitKey struct{}
+
+type afterCommitHooks struct {
+	fns []func()
+}
+
+// コミット後の処理を登録できるコンテキストと, 登録された処理を順に実行する関数を返す.
+// TransactionObjectの実装がコミットに成功した後に実行する.
+func WithAfterCommit(ctx context.Context) (context.Context, func()) {
+	hooks := &afterCommitHooks{}
+	return context.WithValue(ctx, afterCommitKey{}, hooks), func() {
+		for _, fn := range hooks.fns {
+			fn()
+		}
+	}
+}
+
+// トランザクション内であればコミット後に, トランザクション外であれば直ちにfnを実行する.
+// ロールバックした場合は実行しない.
+func AfterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks)
+	if !ok {
+		fn()
+		return
+	}
+	hooks.fns = append(hooks.fns, fn)
+}
@@ -94,10 +94,17 @@ func (r *agentDBRepository) FindOneByIDAndUserIDAndNotDeleted(ctx context.Contex
 			agents.name,
 			agents.created_at,
 			agents.updated_at,
-			GROUP_CONCAT(permissions.policy_id ORDER BY permissions.policy_id) as policies
+			GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+			GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+			GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
 		FROM
 			agents
-			LEFT JOIN permissions ON agents.id = permissions.agent_id
+			LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+			LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+			LEFT JOIN role_agents ON agents.id = role_agents.agent_id
 		WHERE
 			agents.id = ?
 			AND agents.user_id = ?
@@ -129,11 +136,18 @@ func (r *agentDBRepository) FindOneByTokenAndNotDeleted(ctx context.Context, tok
 			agents.name,
 			agents.created_at,
 			agents.updated_at,
-			GROUP_CONCAT(permissions.policy_id ORDER BY permissions.policy_id) as policies
+			GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+			GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+			GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
 		FROM
 			agents
 			INNER JOIN agent_tokens ON agents.id = agent_tokens.agent_id
-			LEFT JOIN permissions ON agents.id = permissions.agent_id
+			LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+			LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+			LEFT JOIN role_agents ON agents.id = role_agents.agent_id
 		WHERE
 			agent_tokens.token = ?
 			AND agents.deleted_at IS NULL
@@ -151,6 +165,136 @@ func (r *agentDBRepository) FindOneByTokenAndNotDeleted(ctx context.Context, tok
 	return transformer.ToAgentEntity(&agent)
 }
 
+// 紐付けたフィンガープリントとサブジェクトがいずれも一致するエージェントを返す. nullの側は照合しない.
+// 複数のエージェントが一致する場合は, フィンガープリントを指定した紐付け, サブジェクトを指定した紐付けの順に優先する.
+func (r *agentDBRepository) FindOneByCertificateAndNotDeleted(ctx context.Context, fingerprint string, subject string) (*entity.Agent, error) {
+	var agent model.AgentModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT
+			agents.id,
+			agents.user_id,
+			agents.name,
+			agents.created_at,
+			agents.updated_at,
+			GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+			GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+			GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
+		FROM
+			agents
+			INNER JOIN agent_certificates ON agents.id = agent_certificates.agent_id
+			LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+			LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+			LEFT JOIN role_agents ON agents.id = role_agents.agent_id
+		WHERE
+			(agent_certificates.fingerprint IS NULL OR agent_certificates.fingerprint = ?)
+			AND (agent_certificates.subject IS NULL OR agent_certificates.subject = ?)
+			AND agents.deleted_at IS NULL
+		GROUP BY
+			agents.id
+		ORDER BY
+			agent_certificates.fingerprint IS NULL,
+			agent_certificates.subject IS NULL
+		LIMIT 1;`,
+		fingerprint,
+		subject,
+	).StructScan(&agent); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToAgentEntity(&agent)
+}
+
+func (r *agentDBRepository) FindOneBySecretKeyIDAndNotDeleted(ctx context.Context, keyID string) (*entity.Agent, error) {
+	var agent model.AgentModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT
+			agents.id,
+			agents.user_id,
+			agents.name,
+			agents.created_at,
+			agents.updated_at,
+			GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+			GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+			GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
+		FROM
+			agents
+			INNER JOIN agent_secrets ON agents.id = agent_secrets.agent_id
+			LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+			LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+			LEFT JOIN role_agents ON agents.id = role_agents.agent_id
+		WHERE
+			agent_secrets.key_id = ?
+			AND agents.deleted_at IS NULL
+		GROUP BY
+			agents.id
+		LIMIT 1;`,
+		keyID,
+	).StructScan(&agent); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToAgentEntity(&agent)
+}
+
+func (r *agentDBRepository) FindOneByPublicKeyIDAndNotDeleted(ctx context.Context, keyID uuid.UUID) (*entity.Agent, error) {
+	var agent model.AgentModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT
+			agents.id,
+			agents.user_id,
+			agents.name,
+			agents.created_at,
+			agents.updated_at,
+			GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+			GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+			GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
+		FROM
+			agents
+			INNER JOIN agent_public_keys ON agents.id = agent_public_keys.agent_id
+			LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+			LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+			LEFT JOIN role_agents ON agents.id = role_agents.agent_id
+		WHERE
+			agent_public_keys.id = ?
+			AND agents.deleted_at IS NULL
+		GROUP BY
+			agents.id
+		LIMIT 1;`,
+		keyID,
+	).StructScan(&agent); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToAgentEntity(&agent)
+}
+
 func (r *agentDBRepository) FindByNamePrefixAndUserIDAndNotDeleted(ctx context.Context, keyword string, userID uuid.UUID) ([]*entity.Agent, error) {
 	agents := []*model.AgentModel{}
 	driver := getDriver(ctx, r.db)
@@ -177,6 +321,32 @@ func (r *agentDBRepository) FindByNamePrefixAndUserIDAndNotDeleted(ctx context.C
 	return transformer.ToAgentEntities(agents)
 }
 
+// ユーザーのエージェントをすべて返す. トランザクションの終了まで行をロックする.
+func (r *agentDBRepository) FindByUserIDAndNotDeletedForUpdate(ctx context.Context, userID uuid.UUID) ([]*entity.Agent, error) {
+	agents := []*model.AgentModel{}
+	driver := getDriver(ctx, r.db)
+
+	rows, err := driver.QueryxContext(
+		ctx,
+		`SELECT id, user_id, name, created_at, updated_at FROM agents WHERE user_id = ? AND deleted_at IS NULL FOR UPDATE;`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var agent model.AgentModel
+		if err := rows.StructScan(&agent); err != nil {
+			return nil, err
+		}
+		agents = append(agents, &agent)
+	}
+
+	return transformer.ToAgentEntities(agents)
+}
+
 func (r *agentDBRepository) FindByIDsAndUserIDAndNotDeleted(ctx context.Context, ids []uuid.UUID, userID uuid.UUID) ([]*entity.Agent, error) {
 	if len(ids) == 0 {
 		return []*entity.Agent{}, nil
@@ -260,12 +430,14 @@ func (r *agentDBRepository) FindByIDsAndNamePrefixAndUserIDAndNotDeleted(ctx con
 	return transformer.ToAgentEntities(agents)
 }
 
+// 期間を指定した紐付けはPermissionRepositoryで管理するため, 期間のない紐付けのみを置き換える.
+// 同じポリシーに期間を指定した紐付けがある場合は, 期間のない紐付けに置き換える.
 func (r *agentDBRepository) updatePolicies(ctx context.Context, id uuid.UUID, policieIDs []uuid.UUID) error {
 	driver := getDriver(ctx, r.db)
 
 	if _, err := driver.NamedExecContext(
 		ctx,
-		`DELETE FROM permissions WHERE agent_id = :agent_id;`,
+		`DELETE FROM permissions WHERE agent_id = :agent_id AND valid_from IS NULL AND valid_until IS NULL;`,
 		map[string]interface{}{"agent_id": id},
 	); err != nil {
 		return err
@@ -284,7 +456,7 @@ func (r *agentDBRepository) updatePolicies(ctx context.Context, id uuid.UUID, po
 	}
 	_, err := driver.NamedExecContext(
 		ctx,
-		`INSERT INTO permissions (agent_id, policy_id) VALUES (:agent_id, :policy_id);`,
+		`INSERT INTO permissions (agent_id, policy_id) VALUES (:agent_id, :policy_id) ON DUPLICATE KEY UPDATE valid_from = NULL, valid_until = NULL;`,
 		args,
 	)
 
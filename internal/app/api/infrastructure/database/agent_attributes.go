@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+	"holos-auth-api/internal/app/api/infrastructure/transformer"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrRequiredAgentAttributes = status.Error(http.StatusInternalServerError, "agent attributes is required")
+)
+
+type agentAttributesDBRepository struct {
+	db *sqlx.DB
+}
+
+func NewAgentAttributesDBRepository(db *sqlx.DB) repository.AgentAttributesRepository {
+	return &agentAttributesDBRepository{
+		db: db,
+	}
+}
+
+func (r *agentAttributesDBRepository) Save(ctx context.Context, agentAttributes *entity.AgentAttributes) error {
+	if agentAttributes == nil {
+		return ErrRequiredAgentAttributes
+	}
+
+	driver := getDriver(ctx, r.db)
+	agentAttributesModel, err := transformer.ToAgentAttributesModel(agentAttributes)
+	if err != nil {
+		return err
+	}
+
+	_, err = driver.NamedExecContext(
+		ctx,
+		`REPLACE agent_attributes (agent_id, attributes, updated_at) VALUES (:agent_id, :attributes, :updated_at);`,
+		agentAttributesModel,
+	)
+
+	return err
+}
+
+func (r *agentAttributesDBRepository) FindOneByAgentID(ctx context.Context, agentID uuid.UUID) (*entity.AgentAttributes, error) {
+	var agentAttributes model.AgentAttributesModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT
+			agent_id,
+			attributes,
+			updated_at
+		FROM
+			agent_attributes
+		WHERE
+			agent_id = ?
+		LIMIT 1;`,
+		agentID,
+	).StructScan(&agentAttributes); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToAgentAttributesEntity(&agentAttributes)
+}
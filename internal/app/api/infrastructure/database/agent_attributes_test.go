@@ -0,0 +1,190 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/database"
+	"holos-auth-api/test"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestAgentAttributes_Save(t *testing.T) {
+	agentAttributes, err := entity.NewAgentAttributes(uuid.New(), map[string]string{"team": "t1"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                 string
+		inputAgentAttributes *entity.AgentAttributes
+		expectError          error
+		setMockDB            func(sqlmock.Sqlmock)
+	}{
+		{
+			name:                 "success",
+			inputAgentAttributes: agentAttributes,
+			expectError:          nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("REPLACE agent_attributes (agent_id, attributes, updated_at) VALUES (?, ?, ?);")).
+					WithArgs(agentAttributes.AgentID, []byte(`{"team":"t1"}`), agentAttributes.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:                 "save error",
+			inputAgentAttributes: agentAttributes,
+			expectError:          sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("REPLACE agent_attributes (agent_id, attributes, updated_at) VALUES (?, ?, ?);")).
+					WithArgs(agentAttributes.AgentID, []byte(`{"team":"t1"}`), agentAttributes.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:                 "no agent attributes",
+			inputAgentAttributes: nil,
+			expectError:          database.ErrRequiredAgentAttributes,
+			setMockDB:            func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentAttributesDBRepository(db)
+			if err := r.Save(ctx, tt.inputAgentAttributes); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgentAttributes_FindOneByAgentID(t *testing.T) {
+	agentAttributes, err := entity.NewAgentAttributes(uuid.New(), map[string]string{"team": "t1"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name         string
+		inputAgentID uuid.UUID
+		expectResult *entity.AgentAttributes
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputAgentID: agentAttributes.AgentID,
+			expectResult: agentAttributes,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agent_id,
+						attributes,
+						updated_at
+					FROM
+						agent_attributes
+					WHERE
+						agent_id = ?
+					LIMIT 1;`,
+				)).
+					WithArgs(agentAttributes.AgentID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "attributes", "updated_at"}).
+							AddRow(agentAttributes.AgentID, []byte(`{"team":"t1"}`), agentAttributes.UpdatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputAgentID: agentAttributes.AgentID,
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agent_id,
+						attributes,
+						updated_at
+					FROM
+						agent_attributes
+					WHERE
+						agent_id = ?
+					LIMIT 1;`,
+				)).
+					WithArgs(agentAttributes.AgentID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "attributes", "updated_at"}),
+					).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			inputAgentID: agentAttributes.AgentID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agent_id,
+						attributes,
+						updated_at
+					FROM
+						agent_attributes
+					WHERE
+						agent_id = ?
+					LIMIT 1;`,
+				)).
+					WithArgs(agentAttributes.AgentID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "attributes", "updated_at"}),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentAttributesDBRepository(db)
+			result, err := r.FindOneByAgentID(ctx, tt.inputAgentID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+	"holos-auth-api/internal/app/api/infrastructure/transformer"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrRequiredAgentCertificate = status.Error(http.StatusInternalServerError, "agent certificate is required")
+)
+
+type agentCertificateDBRepository struct {
+	db *sqlx.DB
+}
+
+func NewAgentCertificateDBRepository(db *sqlx.DB) repository.AgentCertificateRepository {
+	return &agentCertificateDBRepository{
+		db: db,
+	}
+}
+
+// フィンガープリントとサブジェクトにも一意制約があるため, 他のエージェントの行と重複した場合は何も変更しない.
+func (r *agentCertificateDBRepository) Save(ctx context.Context, agentCertificate *entity.AgentCertificate) error {
+	if agentCertificate == nil {
+		return ErrRequiredAgentCertificate
+	}
+
+	driver := getDriver(ctx, r.db)
+	agentCertificateModel := transformer.ToAgentCertificateModel(agentCertificate)
+
+	_, err := driver.NamedExecContext(
+		ctx,
+		`INSERT INTO agent_certificates (agent_id, fingerprint, subject, bound_at) VALUES (:agent_id, :fingerprint, :subject, :bound_at) ON DUPLICATE KEY UPDATE fingerprint = IF(agent_id = VALUES(agent_id), VALUES(fingerprint), fingerprint), subject = IF(agent_id = VALUES(agent_id), VALUES(subject), subject), bound_at = IF(agent_id = VALUES(agent_id), VALUES(bound_at), bound_at);`,
+		agentCertificateModel,
+	)
+
+	return err
+}
+
+func (r *agentCertificateDBRepository) Delete(ctx context.Context, agentCertificate *entity.AgentCertificate) error {
+	if agentCertificate == nil {
+		return ErrRequiredAgentCertificate
+	}
+
+	driver := getDriver(ctx, r.db)
+	agentCertificateModel := transformer.ToAgentCertificateModel(agentCertificate)
+
+	_, err := driver.NamedExecContext(
+		ctx,
+		`DELETE FROM agent_certificates WHERE agent_id = :agent_id;`,
+		agentCertificateModel,
+	)
+
+	return err
+}
+
+func (r *agentCertificateDBRepository) FindOneByAgentIDAndUserID(ctx context.Context, agentID uuid.UUID, userID uuid.UUID) (*entity.AgentCertificate, error) {
+	var agentCertificate model.AgentCertificateModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT
+			agent_certificates.agent_id,
+			agent_certificates.fingerprint,
+			agent_certificates.subject,
+			agent_certificates.bound_at
+		FROM
+			agent_certificates
+			INNER JOIN agents ON agent_certificates.agent_id = agents.id
+		WHERE
+			agent_certificates.agent_id = ?
+			AND agents.user_id = ?
+		LIMIT 1;`,
+		agentID,
+		userID,
+	).StructScan(&agentCertificate); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToAgentCertificateEntity(&agentCertificate), nil
+}
+
+// 紐付けの競合を確かめるため, ユーザーを問わず検索し, 行をロックする.
+func (r *agentCertificateDBRepository) FindOneByFingerprintOrSubjectForUpdate(ctx context.Context, fingerprint string, subject string) (*entity.AgentCertificate, error) {
+	var agentCertificate model.AgentCertificateModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT agent_id, fingerprint, subject, bound_at FROM agent_certificates WHERE fingerprint = ? OR subject = ? LIMIT 1 FOR UPDATE;`,
+		sql.NullString{String: fingerprint, Valid: fingerprint != ""},
+		sql.NullString{String: subject, Valid: subject != ""},
+	).StructScan(&agentCertificate); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToAgentCertificateEntity(&agentCertificate), nil
+}
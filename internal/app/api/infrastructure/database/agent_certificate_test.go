@@ -0,0 +1,354 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/database"
+	"holos-auth-api/test"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestAgentCertificate_Save(t *testing.T) {
+	agentCertificate, err := entity.NewAgentCertificate(uuid.New(), strings.Repeat("ab", 32), "CN=agent")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                  string
+		inputAgentCertificate *entity.AgentCertificate
+		expectError           error
+		setMockDB             func(sqlmock.Sqlmock)
+	}{
+		{
+			name:                  "success",
+			inputAgentCertificate: agentCertificate,
+			expectError:           nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO agent_certificates (agent_id, fingerprint, subject, bound_at) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE fingerprint = IF(agent_id = VALUES(agent_id), VALUES(fingerprint), fingerprint), subject = IF(agent_id = VALUES(agent_id), VALUES(subject), subject), bound_at = IF(agent_id = VALUES(agent_id), VALUES(bound_at), bound_at);")).
+					WithArgs(agentCertificate.AgentID, agentCertificate.Fingerprint, agentCertificate.Subject, agentCertificate.BoundAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:                  "save error",
+			inputAgentCertificate: agentCertificate,
+			expectError:           sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO agent_certificates (agent_id, fingerprint, subject, bound_at) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE fingerprint = IF(agent_id = VALUES(agent_id), VALUES(fingerprint), fingerprint), subject = IF(agent_id = VALUES(agent_id), VALUES(subject), subject), bound_at = IF(agent_id = VALUES(agent_id), VALUES(bound_at), bound_at);")).
+					WithArgs(agentCertificate.AgentID, agentCertificate.Fingerprint, agentCertificate.Subject, agentCertificate.BoundAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:                  "no agent certificate",
+			inputAgentCertificate: nil,
+			expectError:           database.ErrRequiredAgentCertificate,
+			setMockDB:             func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentCertificateDBRepository(db)
+			if err := r.Save(ctx, tt.inputAgentCertificate); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgentCertificate_Delete(t *testing.T) {
+	agentCertificate, err := entity.NewAgentCertificate(uuid.New(), strings.Repeat("ab", 32), "CN=agent")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                  string
+		inputAgentCertificate *entity.AgentCertificate
+		expectError           error
+		setMockDB             func(sqlmock.Sqlmock)
+	}{
+		{
+			name:                  "success",
+			inputAgentCertificate: agentCertificate,
+			expectError:           nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM agent_certificates WHERE agent_id = ?;")).
+					WithArgs(agentCertificate.AgentID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:                  "delete error",
+			inputAgentCertificate: agentCertificate,
+			expectError:           sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM agent_certificates WHERE agent_id = ?;")).
+					WithArgs(agentCertificate.AgentID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:                  "no agent certificate",
+			inputAgentCertificate: nil,
+			expectError:           database.ErrRequiredAgentCertificate,
+			setMockDB:             func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentCertificateDBRepository(db)
+			if err := r.Delete(ctx, tt.inputAgentCertificate); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgentCertificate_FindOneByAgentIDAndUserID(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentCertificate, err := entity.NewAgentCertificate(agent.ID, "", "CN=agent")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name         string
+		inputAgentID uuid.UUID
+		inputUserID  uuid.UUID
+		expectResult *entity.AgentCertificate
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: agentCertificate,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agent_certificates.agent_id,
+						agent_certificates.fingerprint,
+						agent_certificates.subject,
+						agent_certificates.bound_at
+					FROM
+						agent_certificates
+						INNER JOIN agents ON agent_certificates.agent_id = agents.id
+					WHERE
+						agent_certificates.agent_id = ?
+						AND agents.user_id = ?
+					LIMIT 1;`,
+				)).
+					WithArgs(agent.ID, agent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "fingerprint", "subject", "bound_at"}).
+							AddRow(agentCertificate.AgentID, nil, agentCertificate.Subject, agentCertificate.BoundAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agent_certificates.agent_id,
+						agent_certificates.fingerprint,
+						agent_certificates.subject,
+						agent_certificates.bound_at
+					FROM
+						agent_certificates
+						INNER JOIN agents ON agent_certificates.agent_id = agents.id
+					WHERE
+						agent_certificates.agent_id = ?
+						AND agents.user_id = ?
+					LIMIT 1;`,
+				)).
+					WithArgs(agent.ID, agent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "fingerprint", "subject", "bound_at"}),
+					).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agent_certificates.agent_id,
+						agent_certificates.fingerprint,
+						agent_certificates.subject,
+						agent_certificates.bound_at
+					FROM
+						agent_certificates
+						INNER JOIN agents ON agent_certificates.agent_id = agents.id
+					WHERE
+						agent_certificates.agent_id = ?
+						AND agents.user_id = ?
+					LIMIT 1;`,
+				)).
+					WithArgs(agent.ID, agent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "fingerprint", "subject", "bound_at"}),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentCertificateDBRepository(db)
+			result, err := r.FindOneByAgentIDAndUserID(ctx, tt.inputAgentID, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgentCertificate_FindOneByFingerprintOrSubjectForUpdate(t *testing.T) {
+	fingerprint := strings.Repeat("ab", 32)
+	agentCertificate, err := entity.NewAgentCertificate(uuid.New(), fingerprint, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name             string
+		inputFingerprint string
+		inputSubject     string
+		expectResult     *entity.AgentCertificate
+		expectError      error
+		setMockDB        func(sqlmock.Sqlmock)
+	}{
+		{
+			name:             "bound to another agent",
+			inputFingerprint: fingerprint,
+			inputSubject:     "",
+			expectResult:     agentCertificate,
+			expectError:      nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT agent_id, fingerprint, subject, bound_at FROM agent_certificates WHERE fingerprint = ? OR subject = ? LIMIT 1 FOR UPDATE;")).
+					WithArgs(fingerprint, nil).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "fingerprint", "subject", "bound_at"}).
+							AddRow(agentCertificate.AgentID, fingerprint, nil, agentCertificate.BoundAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:             "not bound",
+			inputFingerprint: "",
+			inputSubject:     "CN=agent",
+			expectResult:     nil,
+			expectError:      nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT agent_id, fingerprint, subject, bound_at FROM agent_certificates WHERE fingerprint = ? OR subject = ? LIMIT 1 FOR UPDATE;")).
+					WithArgs(nil, "CN=agent").
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "fingerprint", "subject", "bound_at"}),
+					).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:             "find error",
+			inputFingerprint: fingerprint,
+			inputSubject:     "",
+			expectResult:     nil,
+			expectError:      sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT agent_id, fingerprint, subject, bound_at FROM agent_certificates WHERE fingerprint = ? OR subject = ? LIMIT 1 FOR UPDATE;")).
+					WithArgs(fingerprint, nil).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentCertificateDBRepository(db)
+			result, err := r.FindOneByFingerprintOrSubjectForUpdate(ctx, tt.inputFingerprint, tt.inputSubject)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+	"holos-auth-api/internal/app/api/infrastructure/transformer"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrRequiredAgentDelegatedToken = status.Error(http.StatusInternalServerError, "agent delegated token is required")
+)
+
+type agentDelegatedTokenDBRepository struct {
+	db *sqlx.DB
+}
+
+func NewAgentDelegatedTokenDBRepository(db *sqlx.DB) repository.AgentDelegatedTokenRepository {
+	return &agentDelegatedTokenDBRepository{
+		db: db,
+	}
+}
+
+func (r *agentDelegatedTokenDBRepository) Create(ctx context.Context, agentDelegatedToken *entity.AgentDelegatedToken) error {
+	if agentDelegatedToken == nil {
+		return ErrRequiredAgentDelegatedToken
+	}
+
+	driver := getDriver(ctx, r.db)
+	agentDelegatedTokenModel, err := transformer.ToAgentDelegatedTokenModel(agentDelegatedToken)
+	if err != nil {
+		return err
+	}
+
+	_, err = driver.NamedExecContext(
+		ctx,
+		`INSERT INTO agent_delegated_tokens (agent_id, parent_token, token, policies, expires_at, created_at) VALUES (:agent_id, :parent_token, :token, :policies, :expires_at, :created_at);`,
+		agentDelegatedTokenModel,
+	)
+
+	return err
+}
+
+func (r *agentDelegatedTokenDBRepository) DeleteExpiredByAgentID(ctx context.Context, agentID uuid.UUID) error {
+	driver := getDriver(ctx, r.db)
+
+	_, err := driver.NamedExecContext(
+		ctx,
+		`DELETE FROM agent_delegated_tokens WHERE agent_id = :agent_id AND expires_at <= NOW(6);`,
+		map[string]interface{}{"agent_id": agentID},
+	)
+
+	return err
+}
+
+func (r *agentDelegatedTokenDBRepository) FindOneByTokenAndNotExpired(ctx context.Context, token string) (*entity.AgentDelegatedToken, error) {
+	var agentDelegatedToken model.AgentDelegatedTokenModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT
+			agent_delegated_tokens.agent_id,
+			agent_delegated_tokens.parent_token,
+			agent_delegated_tokens.token,
+			agent_delegated_tokens.policies,
+			agent_delegated_tokens.expires_at,
+			agent_delegated_tokens.created_at
+		FROM
+			agent_delegated_tokens
+			INNER JOIN agent_tokens ON agent_delegated_tokens.parent_token = agent_tokens.token
+		WHERE
+			agent_delegated_tokens.token = ?
+			AND NOW(6) < agent_delegated_tokens.expires_at
+		LIMIT 1;`,
+		token,
+	).StructScan(&agentDelegatedToken); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToAgentDelegatedTokenEntity(&agentDelegatedToken)
+}
@@ -0,0 +1,236 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/database"
+	"holos-auth-api/test"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestAgentDelegatedToken_Create(t *testing.T) {
+	agent := &entity.Agent{
+		ID:       uuid.New(),
+		UserID:   uuid.New(),
+		Name:     "name",
+		Policies: []uuid.UUID{uuid.New()},
+	}
+	agentDelegatedToken, err := entity.NewAgentDelegatedToken(agent, "hsa_GyTPPWGLe32H_2lZuoM7x0AV8OS_Yvite5938bb7", agent.Policies, time.Minute)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policies, err := json.Marshal(agentDelegatedToken.Policies)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                     string
+		inputAgentDelegatedToken *entity.AgentDelegatedToken
+		expectError              error
+		setMockDB                func(sqlmock.Sqlmock)
+	}{
+		{
+			name:                     "success",
+			inputAgentDelegatedToken: agentDelegatedToken,
+			expectError:              nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO agent_delegated_tokens (agent_id, parent_token, token, policies, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?);")).
+					WithArgs(agentDelegatedToken.AgentID, agentDelegatedToken.ParentToken, agentDelegatedToken.Token, policies, agentDelegatedToken.ExpiresAt, agentDelegatedToken.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:                     "create error",
+			inputAgentDelegatedToken: agentDelegatedToken,
+			expectError:              sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO agent_delegated_tokens (agent_id, parent_token, token, policies, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?);")).
+					WithArgs(agentDelegatedToken.AgentID, agentDelegatedToken.ParentToken, agentDelegatedToken.Token, policies, agentDelegatedToken.ExpiresAt, agentDelegatedToken.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:                     "no agent delegated token",
+			inputAgentDelegatedToken: nil,
+			expectError:              database.ErrRequiredAgentDelegatedToken,
+			setMockDB:                func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentDelegatedTokenDBRepository(db)
+			if err := r.Create(ctx, tt.inputAgentDelegatedToken); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgentDelegatedToken_DeleteExpiredByAgentID(t *testing.T) {
+	agentID := uuid.New()
+
+	tests := []struct {
+		name         string
+		inputAgentID uuid.UUID
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "success",
+			inputAgentID: agentID,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM agent_delegated_tokens WHERE agent_id = ? AND expires_at <= NOW(6);")).
+					WithArgs(agentID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "delete error",
+			inputAgentID: agentID,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM agent_delegated_tokens WHERE agent_id = ? AND expires_at <= NOW(6);")).
+					WithArgs(agentID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentDelegatedTokenDBRepository(db)
+			if err := r.DeleteExpiredByAgentID(ctx, tt.inputAgentID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgentDelegatedToken_FindOneByTokenAndNotExpired(t *testing.T) {
+	policyID := uuid.New()
+	agentDelegatedToken := entity.RestoreAgentDelegatedToken(uuid.New(), "hsa_GyTPPWGLe32H_2lZuoM7x0AV8OS_Yvite5938bb7", "hsd_1Ty1HKTPKTt8xEi-_3HTbWf2SCHOdqOSfdf6f507", []uuid.UUID{policyID}, time.Now().Add(time.Minute), time.Now())
+	query := regexp.QuoteMeta(
+		`SELECT
+			agent_delegated_tokens.agent_id,
+			agent_delegated_tokens.parent_token,
+			agent_delegated_tokens.token,
+			agent_delegated_tokens.policies,
+			agent_delegated_tokens.expires_at,
+			agent_delegated_tokens.created_at
+		FROM
+			agent_delegated_tokens
+			INNER JOIN agent_tokens ON agent_delegated_tokens.parent_token = agent_tokens.token
+		WHERE
+			agent_delegated_tokens.token = ?
+			AND NOW(6) < agent_delegated_tokens.expires_at
+		LIMIT 1;`,
+	)
+	columns := []string{"agent_id", "parent_token", "token", "policies", "expires_at", "created_at"}
+
+	tests := []struct {
+		name         string
+		inputToken   string
+		expectResult *entity.AgentDelegatedToken
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputToken:   agentDelegatedToken.Token,
+			expectResult: agentDelegatedToken,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(agentDelegatedToken.Token).
+					WillReturnRows(
+						sqlmock.NewRows(columns).
+							AddRow(agentDelegatedToken.AgentID, agentDelegatedToken.ParentToken, agentDelegatedToken.Token, `["`+policyID.String()+`"]`, agentDelegatedToken.ExpiresAt, agentDelegatedToken.CreatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputToken:   agentDelegatedToken.Token,
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(agentDelegatedToken.Token).
+					WillReturnRows(sqlmock.NewRows(columns)).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			inputToken:   agentDelegatedToken.Token,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(agentDelegatedToken.Token).
+					WillReturnRows(sqlmock.NewRows(columns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentDelegatedTokenDBRepository(db)
+			result, err := r.FindOneByTokenAndNotExpired(ctx, tt.inputToken)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+	"holos-auth-api/internal/app/api/infrastructure/transformer"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrRequiredAgentPublicKey = status.Error(http.StatusInternalServerError, "agent public key is required")
+)
+
+type agentPublicKeyDBRepository struct {
+	db *sqlx.DB
+}
+
+func NewAgentPublicKeyDBRepository(db *sqlx.DB) repository.AgentPublicKeyRepository {
+	return &agentPublicKeyDBRepository{
+		db: db,
+	}
+}
+
+func (r *agentPublicKeyDBRepository) Create(ctx context.Context, agentPublicKey *entity.AgentPublicKey) error {
+	if agentPublicKey == nil {
+		return ErrRequiredAgentPublicKey
+	}
+
+	driver := getDriver(ctx, r.db)
+	agentPublicKeyModel := transformer.ToAgentPublicKeyModel(agentPublicKey)
+
+	_, err := driver.NamedExecContext(
+		ctx,
+		`INSERT INTO agent_public_keys (id, agent_id, algorithm, public_key, created_at) VALUES (:id, :agent_id, :algorithm, :public_key, :created_at);`,
+		agentPublicKeyModel,
+	)
+
+	return err
+}
+
+func (r *agentPublicKeyDBRepository) Delete(ctx context.Context, agentPublicKey *entity.AgentPublicKey) error {
+	if agentPublicKey == nil {
+		return ErrRequiredAgentPublicKey
+	}
+
+	driver := getDriver(ctx, r.db)
+	agentPublicKeyModel := transformer.ToAgentPublicKeyModel(agentPublicKey)
+
+	_, err := driver.NamedExecContext(
+		ctx,
+		`DELETE FROM agent_public_keys WHERE id = :id;`,
+		agentPublicKeyModel,
+	)
+
+	return err
+}
+
+func (r *agentPublicKeyDBRepository) FindOneByID(ctx context.Context, id uuid.UUID) (*entity.AgentPublicKey, error) {
+	var agentPublicKey model.AgentPublicKeyModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT id, agent_id, algorithm, public_key, created_at FROM agent_public_keys WHERE id = ? LIMIT 1;`,
+		id,
+	).StructScan(&agentPublicKey); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToAgentPublicKeyEntity(&agentPublicKey), nil
+}
+
+func (r *agentPublicKeyDBRepository) FindOneByIDAndAgentIDAndUserID(ctx context.Context, id uuid.UUID, agentID uuid.UUID, userID uuid.UUID) (*entity.AgentPublicKey, error) {
+	var agentPublicKey model.AgentPublicKeyModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT
+			agent_public_keys.id,
+			agent_public_keys.agent_id,
+			agent_public_keys.algorithm,
+			agent_public_keys.public_key,
+			agent_public_keys.created_at
+		FROM
+			agent_public_keys
+			INNER JOIN agents ON agent_public_keys.agent_id = agents.id
+		WHERE
+			agent_public_keys.id = ?
+			AND agent_public_keys.agent_id = ?
+			AND agents.user_id = ?
+		LIMIT 1;`,
+		id,
+		agentID,
+		userID,
+	).StructScan(&agentPublicKey); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToAgentPublicKeyEntity(&agentPublicKey), nil
+}
+
+func (r *agentPublicKeyDBRepository) FindByAgentIDAndUserID(ctx context.Context, agentID uuid.UUID, userID uuid.UUID) ([]*entity.AgentPublicKey, error) {
+	agentPublicKeys := []*model.AgentPublicKeyModel{}
+	driver := getDriver(ctx, r.db)
+
+	rows, err := driver.QueryxContext(
+		ctx,
+		`SELECT
+			agent_public_keys.id,
+			agent_public_keys.agent_id,
+			agent_public_keys.algorithm,
+			agent_public_keys.public_key,
+			agent_public_keys.created_at
+		FROM
+			agent_public_keys
+			INNER JOIN agents ON agent_public_keys.agent_id = agents.id
+		WHERE
+			agent_public_keys.agent_id = ?
+			AND agents.user_id = ?
+		ORDER BY
+			agent_public_keys.created_at;`,
+		agentID,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var agentPublicKey model.AgentPublicKeyModel
+		if err := rows.StructScan(&agentPublicKey); err != nil {
+			return nil, err
+		}
+		agentPublicKeys = append(agentPublicKeys, &agentPublicKey)
+	}
+
+	return transformer.ToAgentPublicKeyEntities(agentPublicKeys), nil
+}
@@ -0,0 +1,426 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/database"
+	"holos-auth-api/test"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestAgentPublicKey_Create(t *testing.T) {
+	agentPublicKey := entity.RestoreAgentPublicKey(uuid.New(), uuid.New(), "EdDSA", "public_key", time.Now())
+
+	tests := []struct {
+		name                string
+		inputAgentPublicKey *entity.AgentPublicKey
+		expectError         error
+		setMockDB           func(sqlmock.Sqlmock)
+	}{
+		{
+			name:                "success",
+			inputAgentPublicKey: agentPublicKey,
+			expectError:         nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO agent_public_keys (id, agent_id, algorithm, public_key, created_at) VALUES (?, ?, ?, ?, ?);")).
+					WithArgs(agentPublicKey.ID, agentPublicKey.AgentID, agentPublicKey.Algorithm, agentPublicKey.PublicKey, agentPublicKey.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:                "create error",
+			inputAgentPublicKey: agentPublicKey,
+			expectError:         sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO agent_public_keys (id, agent_id, algorithm, public_key, created_at) VALUES (?, ?, ?, ?, ?);")).
+					WithArgs(agentPublicKey.ID, agentPublicKey.AgentID, agentPublicKey.Algorithm, agentPublicKey.PublicKey, agentPublicKey.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:                "no agent public key",
+			inputAgentPublicKey: nil,
+			expectError:         database.ErrRequiredAgentPublicKey,
+			setMockDB:           func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentPublicKeyDBRepository(db)
+			if err := r.Create(ctx, tt.inputAgentPublicKey); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgentPublicKey_Delete(t *testing.T) {
+	agentPublicKey := entity.RestoreAgentPublicKey(uuid.New(), uuid.New(), "EdDSA", "public_key", time.Now())
+
+	tests := []struct {
+		name                string
+		inputAgentPublicKey *entity.AgentPublicKey
+		expectError         error
+		setMockDB           func(sqlmock.Sqlmock)
+	}{
+		{
+			name:                "success",
+			inputAgentPublicKey: agentPublicKey,
+			expectError:         nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM agent_public_keys WHERE id = ?;")).
+					WithArgs(agentPublicKey.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:                "delete error",
+			inputAgentPublicKey: agentPublicKey,
+			expectError:         sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM agent_public_keys WHERE id = ?;")).
+					WithArgs(agentPublicKey.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:                "no agent public key",
+			inputAgentPublicKey: nil,
+			expectError:         database.ErrRequiredAgentPublicKey,
+			setMockDB:           func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentPublicKeyDBRepository(db)
+			if err := r.Delete(ctx, tt.inputAgentPublicKey); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgentPublicKey_FindOneByID(t *testing.T) {
+	agentPublicKey := entity.RestoreAgentPublicKey(uuid.New(), uuid.New(), "EdDSA", "public_key", time.Now())
+
+	tests := []struct {
+		name         string
+		inputID      uuid.UUID
+		expectResult *entity.AgentPublicKey
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputID:      agentPublicKey.ID,
+			expectResult: agentPublicKey,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, agent_id, algorithm, public_key, created_at FROM agent_public_keys WHERE id = ? LIMIT 1;")).
+					WithArgs(agentPublicKey.ID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "agent_id", "algorithm", "public_key", "created_at"}).
+							AddRow(agentPublicKey.ID, agentPublicKey.AgentID, agentPublicKey.Algorithm, agentPublicKey.PublicKey, agentPublicKey.CreatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputID:      agentPublicKey.ID,
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, agent_id, algorithm, public_key, created_at FROM agent_public_keys WHERE id = ? LIMIT 1;")).
+					WithArgs(agentPublicKey.ID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "agent_id", "algorithm", "public_key", "created_at"}),
+					).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			inputID:      agentPublicKey.ID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, agent_id, algorithm, public_key, created_at FROM agent_public_keys WHERE id = ? LIMIT 1;")).
+					WithArgs(agentPublicKey.ID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "agent_id", "algorithm", "public_key", "created_at"}),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentPublicKeyDBRepository(db)
+			result, err := r.FindOneByID(ctx, tt.inputID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgentPublicKey_FindOneByIDAndAgentIDAndUserID(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentPublicKey := entity.RestoreAgentPublicKey(uuid.New(), agent.ID, "EdDSA", "public_key", time.Now())
+
+	query := regexp.QuoteMeta(
+		`SELECT
+			agent_public_keys.id,
+			agent_public_keys.agent_id,
+			agent_public_keys.algorithm,
+			agent_public_keys.public_key,
+			agent_public_keys.created_at
+		FROM
+			agent_public_keys
+			INNER JOIN agents ON agent_public_keys.agent_id = agents.id
+		WHERE
+			agent_public_keys.id = ?
+			AND agent_public_keys.agent_id = ?
+			AND agents.user_id = ?
+		LIMIT 1;`,
+	)
+
+	tests := []struct {
+		name         string
+		inputID      uuid.UUID
+		inputAgentID uuid.UUID
+		inputUserID  uuid.UUID
+		expectResult *entity.AgentPublicKey
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputID:      agentPublicKey.ID,
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: agentPublicKey,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(agentPublicKey.ID, agent.ID, agent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "agent_id", "algorithm", "public_key", "created_at"}).
+							AddRow(agentPublicKey.ID, agentPublicKey.AgentID, agentPublicKey.Algorithm, agentPublicKey.PublicKey, agentPublicKey.CreatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputID:      agentPublicKey.ID,
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(agentPublicKey.ID, agent.ID, agent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "agent_id", "algorithm", "public_key", "created_at"}),
+					).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			inputID:      agentPublicKey.ID,
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(agentPublicKey.ID, agent.ID, agent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "agent_id", "algorithm", "public_key", "created_at"}),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentPublicKeyDBRepository(db)
+			result, err := r.FindOneByIDAndAgentIDAndUserID(ctx, tt.inputID, tt.inputAgentID, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgentPublicKey_FindByAgentIDAndUserID(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentPublicKey := entity.RestoreAgentPublicKey(uuid.New(), agent.ID, "EdDSA", "public_key", time.Now())
+
+	query := regexp.QuoteMeta(
+		`SELECT
+			agent_public_keys.id,
+			agent_public_keys.agent_id,
+			agent_public_keys.algorithm,
+			agent_public_keys.public_key,
+			agent_public_keys.created_at
+		FROM
+			agent_public_keys
+			INNER JOIN agents ON agent_public_keys.agent_id = agents.id
+		WHERE
+			agent_public_keys.agent_id = ?
+			AND agents.user_id = ?
+		ORDER BY
+			agent_public_keys.created_at;`,
+	)
+
+	tests := []struct {
+		name         string
+		inputAgentID uuid.UUID
+		inputUserID  uuid.UUID
+		expectResult []*entity.AgentPublicKey
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: []*entity.AgentPublicKey{agentPublicKey},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(agent.ID, agent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "agent_id", "algorithm", "public_key", "created_at"}).
+							AddRow(agentPublicKey.ID, agentPublicKey.AgentID, agentPublicKey.Algorithm, agentPublicKey.PublicKey, agentPublicKey.CreatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: []*entity.AgentPublicKey{},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(agent.ID, agent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "agent_id", "algorithm", "public_key", "created_at"}),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(agent.ID, agent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "agent_id", "algorithm", "public_key", "created_at"}),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentPublicKeyDBRepository(db)
+			result, err := r.FindByAgentIDAndUserID(ctx, tt.inputAgentID, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
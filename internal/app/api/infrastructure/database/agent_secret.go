@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+	"holos-auth-api/internal/app/api/infrastructure/transformer"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrRequiredAgentSecret = status.Error(http.StatusInternalServerError, "agent secret is required")
+)
+
+type agentSecretDBRepository struct {
+	db *sqlx.DB
+}
+
+func NewAgentSecretDBRepository(db *sqlx.DB) repository.AgentSecretRepository {
+	return &agentSecretDBRepository{
+		db: db,
+	}
+}
+
+func (r *agentSecretDBRepository) Save(ctx context.Context, agentSecret *entity.AgentSecret) error {
+	if agentSecret == nil {
+		return ErrRequiredAgentSecret
+	}
+
+	driver := getDriver(ctx, r.db)
+	agentSecretModel := transformer.ToAgentSecretModel(agentSecret)
+
+	_, err := driver.NamedExecContext(
+		ctx,
+		`REPLACE agent_secrets (agent_id, key_id, secret, generated_at) VALUES (:agent_id, :key_id, :secret, :generated_at);`,
+		agentSecretModel,
+	)
+
+	return err
+}
+
+func (r *agentSecretDBRepository) Delete(ctx context.Context, agentSecret *entity.AgentSecret) error {
+	if agentSecret == nil {
+		return ErrRequiredAgentSecret
+	}
+
+	driver := getDriver(ctx, r.db)
+	agentSecretModel := transformer.ToAgentSecretModel(agentSecret)
+
+	_, err := driver.NamedExecContext(
+		ctx,
+		`DELETE FROM agent_secrets WHERE agent_id = :agent_id;`,
+		agentSecretModel,
+	)
+
+	return err
+}
+
+func (r *agentSecretDBRepository) FindOneByAgentIDAndUserID(ctx context.Context, agentID uuid.UUID, userID uuid.UUID) (*entity.AgentSecret, error) {
+	var agentSecret model.AgentSecretModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT
+			agent_secrets.agent_id,
+			agent_secrets.key_id,
+			agent_secrets.secret,
+			agent_secrets.generated_at
+		FROM
+			agent_secrets
+			INNER JOIN agents ON agent_secrets.agent_id = agents.id
+		WHERE
+			agent_secrets.agent_id = ?
+			AND agents.user_id = ?
+		LIMIT 1;`,
+		agentID,
+		userID,
+	).StructScan(&agentSecret); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToAgentSecretEntity(&agentSecret), nil
+}
+
+func (r *agentSecretDBRepository) FindOneByKeyID(ctx context.Context, keyID string) (*entity.AgentSecret, error) {
+	var agentSecret model.AgentSecretModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT agent_id, key_id, secret, generated_at FROM agent_secrets WHERE key_id = ? LIMIT 1;`,
+		keyID,
+	).StructScan(&agentSecret); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToAgentSecretEntity(&agentSecret), nil
+}
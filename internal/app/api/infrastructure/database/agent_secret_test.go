@@ -0,0 +1,351 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/database"
+	"holos-auth-api/test"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestAgentSecret_Save(t *testing.T) {
+	agentSecret, err := entity.NewAgentSecret(uuid.New())
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name             string
+		inputAgentSecret *entity.AgentSecret
+		expectError      error
+		setMockDB        func(sqlmock.Sqlmock)
+	}{
+		{
+			name:             "success",
+			inputAgentSecret: agentSecret,
+			expectError:      nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("REPLACE agent_secrets (agent_id, key_id, secret, generated_at) VALUES (?, ?, ?, ?);")).
+					WithArgs(agentSecret.AgentID, agentSecret.KeyID, agentSecret.Secret, agentSecret.GeneratedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:             "save error",
+			inputAgentSecret: agentSecret,
+			expectError:      sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("REPLACE agent_secrets (agent_id, key_id, secret, generated_at) VALUES (?, ?, ?, ?);")).
+					WithArgs(agentSecret.AgentID, agentSecret.KeyID, agentSecret.Secret, agentSecret.GeneratedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:             "no agent secret",
+			inputAgentSecret: nil,
+			expectError:      database.ErrRequiredAgentSecret,
+			setMockDB:        func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentSecretDBRepository(db)
+			if err := r.Save(ctx, tt.inputAgentSecret); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgentSecret_Delete(t *testing.T) {
+	agentSecret, err := entity.NewAgentSecret(uuid.New())
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name             string
+		inputAgentSecret *entity.AgentSecret
+		expectError      error
+		setMockDB        func(sqlmock.Sqlmock)
+	}{
+		{
+			name:             "success",
+			inputAgentSecret: agentSecret,
+			expectError:      nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM agent_secrets WHERE agent_id = ?;")).
+					WithArgs(agentSecret.AgentID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:             "delete error",
+			inputAgentSecret: agentSecret,
+			expectError:      sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM agent_secrets WHERE agent_id = ?;")).
+					WithArgs(agentSecret.AgentID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:             "no agent secret",
+			inputAgentSecret: nil,
+			expectError:      database.ErrRequiredAgentSecret,
+			setMockDB:        func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentSecretDBRepository(db)
+			if err := r.Delete(ctx, tt.inputAgentSecret); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgentSecret_FindOneByAgentIDAndUserID(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentSecret, err := entity.NewAgentSecret(agent.ID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name         string
+		inputAgentID uuid.UUID
+		inputUserID  uuid.UUID
+		expectResult *entity.AgentSecret
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: agentSecret,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agent_secrets.agent_id,
+						agent_secrets.key_id,
+						agent_secrets.secret,
+						agent_secrets.generated_at
+					FROM
+						agent_secrets
+						INNER JOIN agents ON agent_secrets.agent_id = agents.id
+					WHERE
+						agent_secrets.agent_id = ?
+						AND agents.user_id = ?
+					LIMIT 1;`,
+				)).
+					WithArgs(agent.ID, agent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "key_id", "secret", "generated_at"}).
+							AddRow(agentSecret.AgentID, agentSecret.KeyID, agentSecret.Secret, agentSecret.GeneratedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agent_secrets.agent_id,
+						agent_secrets.key_id,
+						agent_secrets.secret,
+						agent_secrets.generated_at
+					FROM
+						agent_secrets
+						INNER JOIN agents ON agent_secrets.agent_id = agents.id
+					WHERE
+						agent_secrets.agent_id = ?
+						AND agents.user_id = ?
+					LIMIT 1;`,
+				)).
+					WithArgs(agent.ID, agent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "key_id", "secret", "generated_at"}),
+					).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agent_secrets.agent_id,
+						agent_secrets.key_id,
+						agent_secrets.secret,
+						agent_secrets.generated_at
+					FROM
+						agent_secrets
+						INNER JOIN agents ON agent_secrets.agent_id = agents.id
+					WHERE
+						agent_secrets.agent_id = ?
+						AND agents.user_id = ?
+					LIMIT 1;`,
+				)).
+					WithArgs(agent.ID, agent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "key_id", "secret", "generated_at"}),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentSecretDBRepository(db)
+			result, err := r.FindOneByAgentIDAndUserID(ctx, tt.inputAgentID, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgentSecret_FindOneByKeyID(t *testing.T) {
+	agentSecret, err := entity.NewAgentSecret(uuid.New())
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name         string
+		inputKeyID   string
+		expectResult *entity.AgentSecret
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputKeyID:   agentSecret.KeyID,
+			expectResult: agentSecret,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT agent_id, key_id, secret, generated_at FROM agent_secrets WHERE key_id = ? LIMIT 1;")).
+					WithArgs(agentSecret.KeyID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "key_id", "secret", "generated_at"}).
+							AddRow(agentSecret.AgentID, agentSecret.KeyID, agentSecret.Secret, agentSecret.GeneratedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputKeyID:   agentSecret.KeyID,
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT agent_id, key_id, secret, generated_at FROM agent_secrets WHERE key_id = ? LIMIT 1;")).
+					WithArgs(agentSecret.KeyID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "key_id", "secret", "generated_at"}),
+					).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			inputKeyID:   agentSecret.KeyID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT agent_id, key_id, secret, generated_at FROM agent_secrets WHERE key_id = ? LIMIT 1;")).
+					WithArgs(agentSecret.KeyID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "key_id", "secret", "generated_at"}),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentSecretDBRepository(db)
+			result, err := r.FindOneByKeyID(ctx, tt.inputKeyID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/infrastructure/transformer"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const mysqlErrDuplicateEntry = 1062
+
+var (
+	ErrRequiredAgentSignatureNonce = status.Error(http.StatusInternalServerError, "agent signature nonce is required")
+)
+
+type agentSignatureNonceDBRepository struct {
+	db *sqlx.DB
+}
+
+func NewAgentSignatureNonceDBRepository(db *sqlx.DB) repository.AgentSignatureNonceRepository {
+	return &agentSignatureNonceDBRepository{
+		db: db,
+	}
+}
+
+// 同時に同じノンスを使ったリクエストの一方のみが成功するよう, 読み込まずに挿入し, 主キーの重複をリプレイとして扱う.
+func (r *agentSignatureNonceDBRepository) Create(ctx context.Context, agentSignatureNonce *entity.AgentSignatureNonce) error {
+	if agentSignatureNonce == nil {
+		return ErrRequiredAgentSignatureNonce
+	}
+
+	driver := getDriver(ctx, r.db)
+	agentSignatureNonceModel := transformer.ToAgentSignatureNonceModel(agentSignatureNonce)
+
+	if _, err := driver.NamedExecContext(
+		ctx,
+		`INSERT INTO agent_signature_nonces (agent_id, nonce, expires_at) VALUES (:agent_id, :nonce, :expires_at);`,
+		agentSignatureNonceModel,
+	); err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+			return entity.ErrAgentSignatureNonceAlreadyUsed
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *agentSignatureNonceDBRepository) DeleteExpiredByAgentID(ctx context.Context, agentID uuid.UUID) error {
+	driver := getDriver(ctx, r.db)
+
+	_, err := driver.NamedExecContext(
+		ctx,
+		`DELETE FROM agent_signature_nonces WHERE agent_id = :agent_id AND expires_at <= NOW(6);`,
+		map[string]interface{}{"agent_id": agentID},
+	)
+
+	return err
+}
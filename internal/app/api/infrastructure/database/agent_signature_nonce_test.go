@@ -0,0 +1,139 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/database"
+	"holos-auth-api/test"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+)
+
+func TestAgentSignatureNonce_Create(t *testing.T) {
+	agentSignatureNonce := entity.NewAgentSignatureNonce(uuid.New(), "nonce-0123456789", time.Now().Add(entity.AgentSignatureClockSkew))
+
+	tests := []struct {
+		name                     string
+		inputAgentSignatureNonce *entity.AgentSignatureNonce
+		expectError              error
+		setMockDB                func(sqlmock.Sqlmock)
+	}{
+		{
+			name:                     "success",
+			inputAgentSignatureNonce: agentSignatureNonce,
+			expectError:              nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO agent_signature_nonces (agent_id, nonce, expires_at) VALUES (?, ?, ?);")).
+					WithArgs(agentSignatureNonce.AgentID, agentSignatureNonce.Nonce, agentSignatureNonce.ExpiresAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:                     "already used",
+			inputAgentSignatureNonce: agentSignatureNonce,
+			expectError:              entity.ErrAgentSignatureNonceAlreadyUsed,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO agent_signature_nonces (agent_id, nonce, expires_at) VALUES (?, ?, ?);")).
+					WithArgs(agentSignatureNonce.AgentID, agentSignatureNonce.Nonce, agentSignatureNonce.ExpiresAt).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+			},
+		},
+		{
+			name:                     "create error",
+			inputAgentSignatureNonce: agentSignatureNonce,
+			expectError:              sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO agent_signature_nonces (agent_id, nonce, expires_at) VALUES (?, ?, ?);")).
+					WithArgs(agentSignatureNonce.AgentID, agentSignatureNonce.Nonce, agentSignatureNonce.ExpiresAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:                     "no agent signature nonce",
+			inputAgentSignatureNonce: nil,
+			expectError:              database.ErrRequiredAgentSignatureNonce,
+			setMockDB:                func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentSignatureNonceDBRepository(db)
+			if err := r.Create(ctx, tt.inputAgentSignatureNonce); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgentSignatureNonce_DeleteExpiredByAgentID(t *testing.T) {
+	agentID := uuid.New()
+
+	tests := []struct {
+		name         string
+		inputAgentID uuid.UUID
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "success",
+			inputAgentID: agentID,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM agent_signature_nonces WHERE agent_id = ? AND expires_at <= NOW(6);")).
+					WithArgs(agentID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "delete error",
+			inputAgentID: agentID,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM agent_signature_nonces WHERE agent_id = ? AND expires_at <= NOW(6);")).
+					WithArgs(agentID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentSignatureNonceDBRepository(db)
+			if err := r.DeleteExpiredByAgentID(ctx, tt.inputAgentID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
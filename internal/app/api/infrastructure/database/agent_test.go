@@ -8,6 +8,7 @@ import (
 	"holos-auth-api/internal/app/api/infrastructure/database"
 	"holos-auth-api/test"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -108,7 +109,7 @@ func TestAgent_Update(t *testing.T) {
 					WithArgs(agentWithoutPolicies.UserID, agentWithoutPolicies.Name, agentWithoutPolicies.UpdatedAt, agentWithoutPolicies.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
-				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE agent_id = ?;")).
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE agent_id = ? AND valid_from IS NULL AND valid_until IS NULL;")).
 					WithArgs(agentWithoutPolicies.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
@@ -123,11 +124,11 @@ func TestAgent_Update(t *testing.T) {
 					WithArgs(agentWithPolicies.UserID, agentWithPolicies.Name, agentWithPolicies.UpdatedAt, agentWithPolicies.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
-				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE agent_id = ?;")).
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE agent_id = ? AND valid_from IS NULL AND valid_until IS NULL;")).
 					WithArgs(agentWithPolicies.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
-				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO permissions (agent_id, policy_id) VALUES (?, ?);")).
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO permissions (agent_id, policy_id) VALUES (?, ?) ON DUPLICATE KEY UPDATE valid_from = NULL, valid_until = NULL;")).
 					WithArgs(agentWithPolicies.ID, agentWithPolicies.Policies[0]).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
@@ -153,7 +154,7 @@ func TestAgent_Update(t *testing.T) {
 					WithArgs(agentWithoutPolicies.UserID, agentWithoutPolicies.Name, agentWithoutPolicies.UpdatedAt, agentWithoutPolicies.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
-				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE agent_id = ?;")).
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE agent_id = ? AND valid_from IS NULL AND valid_until IS NULL;")).
 					WithArgs(agentWithoutPolicies.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(sql.ErrConnDone)
@@ -168,11 +169,11 @@ func TestAgent_Update(t *testing.T) {
 					WithArgs(agentWithPolicies.UserID, agentWithPolicies.Name, agentWithPolicies.UpdatedAt, agentWithPolicies.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
-				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE agent_id = ?;")).
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE agent_id = ? AND valid_from IS NULL AND valid_until IS NULL;")).
 					WithArgs(agentWithPolicies.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
-				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO permissions (agent_id, policy_id) VALUES (?, ?);")).
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO permissions (agent_id, policy_id) VALUES (?, ?) ON DUPLICATE KEY UPDATE valid_from = NULL, valid_until = NULL;")).
 					WithArgs(agentWithPolicies.ID, agentWithPolicies.Policies[0]).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(sql.ErrConnDone)
@@ -296,10 +297,17 @@ func TestAgent_FindOneByIDAndUserIDAndNotDeleted(t *testing.T) {
 						agents.name,
 						agents.created_at,
 						agents.updated_at,
-						GROUP_CONCAT(permissions.policy_id ORDER BY permissions.policy_id) as policies
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
 					FROM
 						agents
-						LEFT JOIN permissions ON agents.id = permissions.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
 					WHERE
 						agents.id = ?
 						AND agents.user_id = ?
@@ -330,10 +338,17 @@ func TestAgent_FindOneByIDAndUserIDAndNotDeleted(t *testing.T) {
 						agents.name,
 						agents.created_at,
 						agents.updated_at,
-						GROUP_CONCAT(permissions.policy_id ORDER BY permissions.policy_id) as policies
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
 					FROM
 						agents
-						LEFT JOIN permissions ON agents.id = permissions.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
 					WHERE
 						agents.id = ?
 						AND agents.user_id = ?
@@ -363,10 +378,17 @@ func TestAgent_FindOneByIDAndUserIDAndNotDeleted(t *testing.T) {
 						agents.name,
 						agents.created_at,
 						agents.updated_at,
-						GROUP_CONCAT(permissions.policy_id ORDER BY permissions.policy_id) as policies
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
 					FROM
 						agents
-						LEFT JOIN permissions ON agents.id = permissions.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
 					WHERE
 						agents.id = ?
 						AND agents.user_id = ?
@@ -438,11 +460,18 @@ func TestAgent_FindOneByTokenAndNotDeleted(t *testing.T) {
 						agents.name,
 						agents.created_at,
 						agents.updated_at,
-						GROUP_CONCAT(permissions.policy_id ORDER BY permissions.policy_id) as policies
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
 					FROM
 						agents
 						INNER JOIN agent_tokens ON agents.id = agent_tokens.agent_id
-						LEFT JOIN permissions ON agents.id = permissions.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
 					WHERE
 						agent_tokens.token = ?
 						AND agents.deleted_at IS NULL
@@ -471,11 +500,18 @@ func TestAgent_FindOneByTokenAndNotDeleted(t *testing.T) {
 						agents.name,
 						agents.created_at,
 						agents.updated_at,
-						GROUP_CONCAT(permissions.policy_id ORDER BY permissions.policy_id) as policies
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
 					FROM
 						agents
 						INNER JOIN agent_tokens ON agents.id = agent_tokens.agent_id
-						LEFT JOIN permissions ON agents.id = permissions.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
 					WHERE
 						agent_tokens.token = ?
 						AND agents.deleted_at IS NULL
@@ -503,11 +539,18 @@ func TestAgent_FindOneByTokenAndNotDeleted(t *testing.T) {
 						agents.name,
 						agents.created_at,
 						agents.updated_at,
-						GROUP_CONCAT(permissions.policy_id ORDER BY permissions.policy_id) as policies
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
 					FROM
 						agents
 						INNER JOIN agent_tokens ON agents.id = agent_tokens.agent_id
-						LEFT JOIN permissions ON agents.id = permissions.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
 					WHERE
 						agent_tokens.token = ?
 						AND agents.deleted_at IS NULL
@@ -548,6 +591,502 @@ func TestAgent_FindOneByTokenAndNotDeleted(t *testing.T) {
 	}
 }
 
+func TestAgent_FindOneByCertificateAndNotDeleted(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentCertificate, err := entity.NewAgentCertificate(agent.ID, strings.Repeat("ab", 32), "CN=agent")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name             string
+		inputFingerprint string
+		inputSubject     string
+		expectResult     *entity.Agent
+		expectError      error
+		setMockDB        func(sqlmock.Sqlmock)
+	}{
+		{
+			name:             "found",
+			inputFingerprint: agentCertificate.Fingerprint,
+			inputSubject:     agentCertificate.Subject,
+			expectResult:     agent,
+			expectError:      nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agents.id,
+						agents.user_id,
+						agents.name,
+						agents.created_at,
+						agents.updated_at,
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
+					FROM
+						agents
+						INNER JOIN agent_certificates ON agents.id = agent_certificates.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
+					WHERE
+						(agent_certificates.fingerprint IS NULL OR agent_certificates.fingerprint = ?)
+						AND (agent_certificates.subject IS NULL OR agent_certificates.subject = ?)
+						AND agents.deleted_at IS NULL
+					GROUP BY
+						agents.id
+					ORDER BY
+						agent_certificates.fingerprint IS NULL,
+						agent_certificates.subject IS NULL
+					LIMIT 1;`,
+				)).
+					WithArgs(agentCertificate.Fingerprint, agentCertificate.Subject).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at"}).
+							AddRow(agent.ID, agent.UserID, agent.Name, agent.CreatedAt, agent.UpdatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:             "not found",
+			inputFingerprint: agentCertificate.Fingerprint,
+			inputSubject:     agentCertificate.Subject,
+			expectResult:     nil,
+			expectError:      nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agents.id,
+						agents.user_id,
+						agents.name,
+						agents.created_at,
+						agents.updated_at,
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
+					FROM
+						agents
+						INNER JOIN agent_certificates ON agents.id = agent_certificates.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
+					WHERE
+						(agent_certificates.fingerprint IS NULL OR agent_certificates.fingerprint = ?)
+						AND (agent_certificates.subject IS NULL OR agent_certificates.subject = ?)
+						AND agents.deleted_at IS NULL
+					GROUP BY
+						agents.id
+					ORDER BY
+						agent_certificates.fingerprint IS NULL,
+						agent_certificates.subject IS NULL
+					LIMIT 1;`,
+				)).
+					WithArgs(agentCertificate.Fingerprint, agentCertificate.Subject).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at"}),
+					).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:             "find error",
+			inputFingerprint: agentCertificate.Fingerprint,
+			inputSubject:     agentCertificate.Subject,
+			expectResult:     nil,
+			expectError:      sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agents.id,
+						agents.user_id,
+						agents.name,
+						agents.created_at,
+						agents.updated_at,
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
+					FROM
+						agents
+						INNER JOIN agent_certificates ON agents.id = agent_certificates.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
+					WHERE
+						(agent_certificates.fingerprint IS NULL OR agent_certificates.fingerprint = ?)
+						AND (agent_certificates.subject IS NULL OR agent_certificates.subject = ?)
+						AND agents.deleted_at IS NULL
+					GROUP BY
+						agents.id
+					ORDER BY
+						agent_certificates.fingerprint IS NULL,
+						agent_certificates.subject IS NULL
+					LIMIT 1;`,
+				)).
+					WithArgs(agentCertificate.Fingerprint, agentCertificate.Subject).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at"}),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentDBRepository(db)
+			result, err := r.FindOneByCertificateAndNotDeleted(ctx, tt.inputFingerprint, tt.inputSubject)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgent_FindOneBySecretKeyIDAndNotDeleted(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentSecret, err := entity.NewAgentSecret(agent.ID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name         string
+		inputKeyID   string
+		expectResult *entity.Agent
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputKeyID:   agentSecret.KeyID,
+			expectResult: agent,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agents.id,
+						agents.user_id,
+						agents.name,
+						agents.created_at,
+						agents.updated_at,
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
+					FROM
+						agents
+						INNER JOIN agent_secrets ON agents.id = agent_secrets.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
+					WHERE
+						agent_secrets.key_id = ?
+						AND agents.deleted_at IS NULL
+					GROUP BY
+						agents.id
+					LIMIT 1;`,
+				)).
+					WithArgs(agentSecret.KeyID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at"}).
+							AddRow(agent.ID, agent.UserID, agent.Name, agent.CreatedAt, agent.UpdatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputKeyID:   agentSecret.KeyID,
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agents.id,
+						agents.user_id,
+						agents.name,
+						agents.created_at,
+						agents.updated_at,
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
+					FROM
+						agents
+						INNER JOIN agent_secrets ON agents.id = agent_secrets.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
+					WHERE
+						agent_secrets.key_id = ?
+						AND agents.deleted_at IS NULL
+					GROUP BY
+						agents.id
+					LIMIT 1;`,
+				)).
+					WithArgs(agentSecret.KeyID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at"}),
+					).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			inputKeyID:   agentSecret.KeyID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agents.id,
+						agents.user_id,
+						agents.name,
+						agents.created_at,
+						agents.updated_at,
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
+					FROM
+						agents
+						INNER JOIN agent_secrets ON agents.id = agent_secrets.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
+					WHERE
+						agent_secrets.key_id = ?
+						AND agents.deleted_at IS NULL
+					GROUP BY
+						agents.id
+					LIMIT 1;`,
+				)).
+					WithArgs(agentSecret.KeyID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at"}),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentDBRepository(db)
+			result, err := r.FindOneBySecretKeyIDAndNotDeleted(ctx, tt.inputKeyID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestAgent_FindOneByPublicKeyIDAndNotDeleted(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	keyID := uuid.New()
+
+	tests := []struct {
+		name         string
+		inputKeyID   uuid.UUID
+		expectResult *entity.Agent
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputKeyID:   keyID,
+			expectResult: agent,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agents.id,
+						agents.user_id,
+						agents.name,
+						agents.created_at,
+						agents.updated_at,
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
+					FROM
+						agents
+						INNER JOIN agent_public_keys ON agents.id = agent_public_keys.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
+					WHERE
+						agent_public_keys.id = ?
+						AND agents.deleted_at IS NULL
+					GROUP BY
+						agents.id
+					LIMIT 1;`,
+				)).
+					WithArgs(keyID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at"}).
+							AddRow(agent.ID, agent.UserID, agent.Name, agent.CreatedAt, agent.UpdatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputKeyID:   keyID,
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agents.id,
+						agents.user_id,
+						agents.name,
+						agents.created_at,
+						agents.updated_at,
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
+					FROM
+						agents
+						INNER JOIN agent_public_keys ON agents.id = agent_public_keys.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
+					WHERE
+						agent_public_keys.id = ?
+						AND agents.deleted_at IS NULL
+					GROUP BY
+						agents.id
+					LIMIT 1;`,
+				)).
+					WithArgs(keyID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at"}),
+					).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			inputKeyID:   keyID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						agents.id,
+						agents.user_id,
+						agents.name,
+						agents.created_at,
+						agents.updated_at,
+						GROUP_CONCAT(DISTINCT permissions.policy_id ORDER BY permissions.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT temporary_permissions.policy_id ORDER BY temporary_permissions.policy_id) as temporary_policies,
+			MIN(temporary_permissions.valid_until) as temporary_policies_valid_until,
+						GROUP_CONCAT(DISTINCT agent_group_members.agent_group_id ORDER BY agent_group_members.agent_group_id) as agent_groups,
+						GROUP_CONCAT(DISTINCT role_agents.role_id ORDER BY role_agents.role_id) as roles
+					FROM
+						agents
+						INNER JOIN agent_public_keys ON agents.id = agent_public_keys.agent_id
+						LEFT JOIN permissions ON agents.id = permissions.agent_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+			LEFT JOIN permissions AS temporary_permissions ON agents.id = temporary_permissions.agent_id AND (temporary_permissions.valid_from IS NOT NULL OR temporary_permissions.valid_until IS NOT NULL) AND (temporary_permissions.valid_from IS NULL OR temporary_permissions.valid_from <= NOW(6)) AND (temporary_permissions.valid_until IS NULL OR NOW(6) < temporary_permissions.valid_until)
+						LEFT JOIN agent_group_members ON agents.id = agent_group_members.agent_id
+						LEFT JOIN role_agents ON agents.id = role_agents.agent_id
+					WHERE
+						agent_public_keys.id = ?
+						AND agents.deleted_at IS NULL
+					GROUP BY
+						agents.id
+					LIMIT 1;`,
+				)).
+					WithArgs(keyID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at"}),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentDBRepository(db)
+			result, err := r.FindOneByPublicKeyIDAndNotDeleted(ctx, tt.inputKeyID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
 func TestAgent_FindByNamePrefixAndUserIDAndNotDeleted(t *testing.T) {
 	agent, err := entity.NewAgent(uuid.New(), "name")
 	if err != nil {
@@ -634,6 +1173,71 @@ func TestAgent_FindByNamePrefixAndUserIDAndNotDeleted(t *testing.T) {
 	}
 }
 
+func TestAgent_FindByUserIDAndNotDeletedForUpdate(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name         string
+		inputUserID  uuid.UUID
+		expectResult []*entity.Agent
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputUserID:  agent.UserID,
+			expectResult: []*entity.Agent{agent},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, created_at, updated_at FROM agents WHERE user_id = ? AND deleted_at IS NULL FOR UPDATE;")).
+					WithArgs(agent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at"}).
+							AddRow(agent.ID, agent.UserID, agent.Name, agent.CreatedAt, agent.UpdatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, created_at, updated_at FROM agents WHERE user_id = ? AND deleted_at IS NULL FOR UPDATE;")).
+					WithArgs(agent.UserID).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewAgentDBRepository(db)
+			result, err := r.FindByUserIDAndNotDeletedForUpdate(ctx, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
 func TestAgent_FindByIDsAndUserIDAndNotDeleted(t *testing.T) {
 	agent, err := entity.NewAgent(uuid.New(), "name")
 	if err != nil {
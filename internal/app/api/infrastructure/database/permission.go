@@ -0,0 +1,182 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+	"holos-auth-api/internal/app/api/infrastructure/transformer"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrRequiredPermission = status.Error(http.StatusInternalServerError, "permission is required")
+)
+
+type permissionDBRepository struct {
+	db *sqlx.DB
+}
+
+func NewPermissionDBRepository(db *sqlx.DB) repository.PermissionRepository {
+	return &permissionDBRepository{
+		db: db,
+	}
+}
+
+func (r *permissionDBRepository) Save(ctx context.Context, permission *entity.Permission) error {
+	if permission == nil {
+		return ErrRequiredPermission
+	}
+
+	driver := getDriver(ctx, r.db)
+	permissionModel := transformer.ToPermissionModel(permission)
+
+	_, err := driver.NamedExecContext(
+		ctx,
+		`REPLACE permissions (agent_id, policy_id, valid_from, valid_until) VALUES (:agent_id, :policy_id, :valid_from, :valid_until);`,
+		permissionModel,
+	)
+
+	return err
+}
+
+func (r *permissionDBRepository) Delete(ctx context.Context, permission *entity.Permission) error {
+	if permission == nil {
+		return ErrRequiredPermission
+	}
+
+	driver := getDriver(ctx, r.db)
+	permissionModel := transformer.ToPermissionModel(permission)
+
+	_, err := driver.NamedExecContext(
+		ctx,
+		`DELETE FROM permissions WHERE agent_id = :agent_id AND policy_id = :policy_id;`,
+		permissionModel,
+	)
+
+	return err
+}
+
+func (r *permissionDBRepository) FindOneByAgentIDAndPolicyIDAndUserID(ctx context.Context, agentID uuid.UUID, policyID uuid.UUID, userID uuid.UUID) (*entity.Permission, error) {
+	var permission model.PermissionModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT
+			permissions.agent_id,
+			permissions.policy_id,
+			agents.user_id,
+			permissions.valid_from,
+			permissions.valid_until
+		FROM
+			permissions
+			INNER JOIN agents ON permissions.agent_id = agents.id
+		WHERE
+			permissions.agent_id = ?
+			AND permissions.policy_id = ?
+			AND agents.user_id = ?
+			AND agents.deleted_at IS NULL
+		LIMIT 1;`,
+		agentID,
+		policyID,
+		userID,
+	).StructScan(&permission); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToPermissionEntity(&permission), nil
+}
+
+// 有効期間外の紐付けも含めて返す.
+func (r *permissionDBRepository) FindByAgentIDAndUserID(ctx context.Context, agentID uuid.UUID, userID uuid.UUID) ([]*entity.Permission, error) {
+	permissions := []*model.PermissionModel{}
+	driver := getDriver(ctx, r.db)
+
+	rows, err := driver.QueryxContext(
+		ctx,
+		`SELECT
+			permissions.agent_id,
+			permissions.policy_id,
+			agents.user_id,
+			permissions.valid_from,
+			permissions.valid_until
+		FROM
+			permissions
+			INNER JOIN agents ON permissions.agent_id = agents.id
+			INNER JOIN policies ON permissions.policy_id = policies.id
+		WHERE
+			permissions.agent_id = ?
+			AND agents.user_id = ?
+			AND agents.deleted_at IS NULL
+			AND policies.deleted_at IS NULL
+		ORDER BY
+			permissions.policy_id;`,
+		agentID,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var permission model.PermissionModel
+		if err := rows.StructScan(&permission); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, &permission)
+	}
+
+	return transformer.ToPermissionEntities(permissions), nil
+}
+
+// 有効期間の終了日時がnow以前の紐付けを, すべてのユーザーについて返す.
+// 複数のインスタンスが同時に削除しないよう, トランザクションの終了まで行をロックし, 他でロックされた行は飛ばす.
+func (r *permissionDBRepository) FindExpired(ctx context.Context, now time.Time) ([]*entity.Permission, error) {
+	permissions := []*model.PermissionModel{}
+	driver := getDriver(ctx, r.db)
+
+	rows, err := driver.QueryxContext(
+		ctx,
+		`SELECT
+			permissions.agent_id,
+			permissions.policy_id,
+			agents.user_id,
+			permissions.valid_from,
+			permissions.valid_until
+		FROM
+			permissions
+			INNER JOIN agents ON permissions.agent_id = agents.id
+		WHERE
+			permissions.valid_until <= ?
+		ORDER BY
+			permissions.valid_until
+		FOR UPDATE OF permissions SKIP LOCKED;`,
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var permission model.PermissionModel
+		if err := rows.StructScan(&permission); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, &permission)
+	}
+
+	return transformer.ToPermissionEntities(permissions), nil
+}
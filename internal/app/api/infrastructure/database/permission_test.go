@@ -0,0 +1,502 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/database"
+	"holos-auth-api/test"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestPermission_Save(t *testing.T) {
+	validUntil := time.Now().Add(time.Hour)
+	permission := entity.RestorePermission(uuid.New(), uuid.New(), uuid.New(), nil, &validUntil)
+
+	tests := []struct {
+		name            string
+		inputPermission *entity.Permission
+		expectError     error
+		setMockDB       func(sqlmock.Sqlmock)
+	}{
+		{
+			name:            "success",
+			inputPermission: permission,
+			expectError:     nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("REPLACE permissions (agent_id, policy_id, valid_from, valid_until) VALUES (?, ?, ?, ?);")).
+					WithArgs(permission.AgentID, permission.PolicyID, nil, validUntil).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:            "save error",
+			inputPermission: permission,
+			expectError:     sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("REPLACE permissions (agent_id, policy_id, valid_from, valid_until) VALUES (?, ?, ?, ?);")).
+					WithArgs(permission.AgentID, permission.PolicyID, nil, validUntil).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:            "no permission",
+			inputPermission: nil,
+			expectError:     database.ErrRequiredPermission,
+			setMockDB:       func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPermissionDBRepository(db)
+			if err := r.Save(ctx, tt.inputPermission); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestPermission_Delete(t *testing.T) {
+	permission := entity.RestorePermission(uuid.New(), uuid.New(), uuid.New(), nil, nil)
+
+	tests := []struct {
+		name            string
+		inputPermission *entity.Permission
+		expectError     error
+		setMockDB       func(sqlmock.Sqlmock)
+	}{
+		{
+			name:            "success",
+			inputPermission: permission,
+			expectError:     nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE agent_id = ? AND policy_id = ?;")).
+					WithArgs(permission.AgentID, permission.PolicyID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:            "delete error",
+			inputPermission: permission,
+			expectError:     sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE agent_id = ? AND policy_id = ?;")).
+					WithArgs(permission.AgentID, permission.PolicyID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:            "no permission",
+			inputPermission: nil,
+			expectError:     database.ErrRequiredPermission,
+			setMockDB:       func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPermissionDBRepository(db)
+			if err := r.Delete(ctx, tt.inputPermission); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestPermission_FindOneByAgentIDAndPolicyIDAndUserID(t *testing.T) {
+	validFrom := time.Now()
+	validUntil := validFrom.Add(time.Hour)
+	permission := entity.RestorePermission(uuid.New(), uuid.New(), uuid.New(), &validFrom, &validUntil)
+
+	tests := []struct {
+		name          string
+		inputAgentID  uuid.UUID
+		inputPolicyID uuid.UUID
+		inputUserID   uuid.UUID
+		expectResult  *entity.Permission
+		expectError   error
+		setMockDB     func(sqlmock.Sqlmock)
+	}{
+		{
+			name:          "found",
+			inputAgentID:  permission.AgentID,
+			inputPolicyID: permission.PolicyID,
+			inputUserID:   permission.UserID,
+			expectResult:  permission,
+			expectError:   nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						permissions.agent_id,
+						permissions.policy_id,
+						agents.user_id,
+						permissions.valid_from,
+						permissions.valid_until
+					FROM
+						permissions
+						INNER JOIN agents ON permissions.agent_id = agents.id
+					WHERE
+						permissions.agent_id = ?
+						AND permissions.policy_id = ?
+						AND agents.user_id = ?
+						AND agents.deleted_at IS NULL
+					LIMIT 1;`,
+				)).
+					WithArgs(permission.AgentID, permission.PolicyID, permission.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "policy_id", "user_id", "valid_from", "valid_until"}).
+							AddRow(permission.AgentID, permission.PolicyID, permission.UserID, validFrom, validUntil),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:          "not found",
+			inputAgentID:  permission.AgentID,
+			inputPolicyID: permission.PolicyID,
+			inputUserID:   permission.UserID,
+			expectResult:  nil,
+			expectError:   nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						permissions.agent_id,
+						permissions.policy_id,
+						agents.user_id,
+						permissions.valid_from,
+						permissions.valid_until
+					FROM
+						permissions
+						INNER JOIN agents ON permissions.agent_id = agents.id
+					WHERE
+						permissions.agent_id = ?
+						AND permissions.policy_id = ?
+						AND agents.user_id = ?
+						AND agents.deleted_at IS NULL
+					LIMIT 1;`,
+				)).
+					WithArgs(permission.AgentID, permission.PolicyID, permission.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "policy_id", "user_id", "valid_from", "valid_until"}),
+					).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:          "find error",
+			inputAgentID:  permission.AgentID,
+			inputPolicyID: permission.PolicyID,
+			inputUserID:   permission.UserID,
+			expectResult:  nil,
+			expectError:   sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						permissions.agent_id,
+						permissions.policy_id,
+						agents.user_id,
+						permissions.valid_from,
+						permissions.valid_until
+					FROM
+						permissions
+						INNER JOIN agents ON permissions.agent_id = agents.id
+					WHERE
+						permissions.agent_id = ?
+						AND permissions.policy_id = ?
+						AND agents.user_id = ?
+						AND agents.deleted_at IS NULL
+					LIMIT 1;`,
+				)).
+					WithArgs(permission.AgentID, permission.PolicyID, permission.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "policy_id", "user_id", "valid_from", "valid_until"}),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPermissionDBRepository(db)
+			result, err := r.FindOneByAgentIDAndPolicyIDAndUserID(ctx, tt.inputAgentID, tt.inputPolicyID, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestPermission_FindByAgentIDAndUserID(t *testing.T) {
+	validUntil := time.Now().Add(time.Hour)
+	agentID := uuid.New()
+	userID := uuid.New()
+	permanent := entity.RestorePermission(agentID, uuid.New(), userID, nil, nil)
+	temporary := entity.RestorePermission(agentID, uuid.New(), userID, nil, &validUntil)
+
+	tests := []struct {
+		name         string
+		inputAgentID uuid.UUID
+		inputUserID  uuid.UUID
+		expectResult []*entity.Permission
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputAgentID: agentID,
+			inputUserID:  userID,
+			expectResult: []*entity.Permission{permanent, temporary},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						permissions.agent_id,
+						permissions.policy_id,
+						agents.user_id,
+						permissions.valid_from,
+						permissions.valid_until
+					FROM
+						permissions
+						INNER JOIN agents ON permissions.agent_id = agents.id
+						INNER JOIN policies ON permissions.policy_id = policies.id
+					WHERE
+						permissions.agent_id = ?
+						AND agents.user_id = ?
+						AND agents.deleted_at IS NULL
+						AND policies.deleted_at IS NULL
+					ORDER BY
+						permissions.policy_id;`,
+				)).
+					WithArgs(agentID, userID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "policy_id", "user_id", "valid_from", "valid_until"}).
+							AddRow(permanent.AgentID, permanent.PolicyID, permanent.UserID, nil, nil).
+							AddRow(temporary.AgentID, temporary.PolicyID, temporary.UserID, nil, validUntil),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			inputAgentID: agentID,
+			inputUserID:  userID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						permissions.agent_id,
+						permissions.policy_id,
+						agents.user_id,
+						permissions.valid_from,
+						permissions.valid_until
+					FROM
+						permissions
+						INNER JOIN agents ON permissions.agent_id = agents.id
+						INNER JOIN policies ON permissions.policy_id = policies.id
+					WHERE
+						permissions.agent_id = ?
+						AND agents.user_id = ?
+						AND agents.deleted_at IS NULL
+						AND policies.deleted_at IS NULL
+					ORDER BY
+						permissions.policy_id;`,
+				)).
+					WithArgs(agentID, userID).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPermissionDBRepository(db)
+			result, err := r.FindByAgentIDAndUserID(ctx, tt.inputAgentID, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestPermission_FindExpired(t *testing.T) {
+	now := time.Now()
+	validUntil := now.Add(-time.Minute)
+	permission := entity.RestorePermission(uuid.New(), uuid.New(), uuid.New(), nil, &validUntil)
+
+	tests := []struct {
+		name         string
+		inputNow     time.Time
+		expectResult []*entity.Permission
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputNow:     now,
+			expectResult: []*entity.Permission{permission},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						permissions.agent_id,
+						permissions.policy_id,
+						agents.user_id,
+						permissions.valid_from,
+						permissions.valid_until
+					FROM
+						permissions
+						INNER JOIN agents ON permissions.agent_id = agents.id
+					WHERE
+						permissions.valid_until <= ?
+					ORDER BY
+						permissions.valid_until
+					FOR UPDATE OF permissions SKIP LOCKED;`,
+				)).
+					WithArgs(now).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "policy_id", "user_id", "valid_from", "valid_until"}).
+							AddRow(permission.AgentID, permission.PolicyID, permission.UserID, nil, validUntil),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputNow:     now,
+			expectResult: []*entity.Permission{},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						permissions.agent_id,
+						permissions.policy_id,
+						agents.user_id,
+						permissions.valid_from,
+						permissions.valid_until
+					FROM
+						permissions
+						INNER JOIN agents ON permissions.agent_id = agents.id
+					WHERE
+						permissions.valid_until <= ?
+					ORDER BY
+						permissions.valid_until
+					FOR UPDATE OF permissions SKIP LOCKED;`,
+				)).
+					WithArgs(now).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"agent_id", "policy_id", "user_id", "valid_from", "valid_until"}),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			inputNow:     now,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						permissions.agent_id,
+						permissions.policy_id,
+						agents.user_id,
+						permissions.valid_from,
+						permissions.valid_until
+					FROM
+						permissions
+						INNER JOIN agents ON permissions.agent_id = agents.id
+					WHERE
+						permissions.valid_until <= ?
+					ORDER BY
+						permissions.valid_until
+					FOR UPDATE OF permissions SKIP LOCKED;`,
+				)).
+					WithArgs(now).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPermissionDBRepository(db)
+			result, err := r.FindExpired(ctx, tt.inputNow)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
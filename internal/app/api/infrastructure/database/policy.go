@@ -10,7 +10,9 @@ import (
 	"holos-auth-api/internal/app/api/infrastructure/transformer"
 	"holos-auth-api/internal/app/api/pkg/status"
 	"net/http"
+	"strings"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
@@ -42,11 +44,11 @@ func (r *policyDBRepository) Create(ctx context.Context, policy *entity.Policy)
 
 	_, err = driver.NamedExecContext(
 		ctx,
-		`INSERT INTO policies (id, user_id, name, effect, service, path, methods, created_at, updated_at) VALUES (:id, :user_id, :name, :effect, :service, :path, :methods, :created_at, :updated_at);`,
+		`INSERT INTO policies (id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at) VALUES (:id, :user_id, :name, :effect, :service, :path, :methods, :conditions, :expression, :valid_from, :valid_until, :created_at, :updated_at);`,
 		policyModel,
 	)
 
-	return err
+	return toPolicyNameError(err)
 }
 
 func (r *policyDBRepository) Update(ctx context.Context, policy *entity.Policy) error {
@@ -62,10 +64,10 @@ func (r *policyDBRepository) Update(ctx context.Context, policy *entity.Policy)
 
 	if _, err := driver.NamedExecContext(
 		ctx,
-		`UPDATE policies SET user_id = :user_id, name = :name, effect = :effect, service = :service, path = :path, methods = :methods, updated_at = :updated_at WHERE id = :id AND deleted_at IS NULL LIMIT 1;`,
+		`UPDATE policies SET user_id = :user_id, name = :name, effect = :effect, service = :service, path = :path, methods = :methods, conditions = :conditions, expression = :expression, valid_from = :valid_from, valid_until = :valid_until, updated_at = :updated_at WHERE id = :id AND deleted_at IS NULL LIMIT 1;`,
 		policyModel,
 	); err != nil {
-		return err
+		return toPolicyNameError(err)
 	}
 
 	return r.updateAgents(ctx, policy.ID, policy.Agents)
@@ -92,6 +94,15 @@ func (r *policyDBRepository) Delete(ctx context.Context, policy *entity.Policy)
 }
 
 func (r *policyDBRepository) FindOneByIDAndUserIDAndNotDeleted(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.Policy, error) {
+	return r.findOneByIDAndUserIDAndNotDeleted(ctx, id, userID, "")
+}
+
+// 並行した変更が同じバージョンの番号を採番しないよう, トランザクションの終了までポリシーの行をロックする.
+func (r *policyDBRepository) FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.Policy, error) {
+	return r.findOneByIDAndUserIDAndNotDeleted(ctx, id, userID, " FOR UPDATE OF policies")
+}
+
+func (r *policyDBRepository) findOneByIDAndUserIDAndNotDeleted(ctx context.Context, id uuid.UUID, userID uuid.UUID, lock string) (*entity.Policy, error) {
 	var policy model.PolicyModel
 	driver := getDriver(ctx, r.db)
 
@@ -105,19 +116,23 @@ func (r *policyDBRepository) FindOneByIDAndUserIDAndNotDeleted(ctx context.Conte
 			policies.service,
 			policies.path,
 			policies.methods,
+			policies.conditions,
+			policies.expression,
+			policies.valid_from,
+			policies.valid_until,
 			policies.created_at,
 			policies.updated_at,
 			GROUP_CONCAT(permissions.agent_id ORDER BY permissions.agent_id) as agents
 		FROM
 			policies
-			LEFT JOIN permissions ON policies.id = permissions.policy_id
+			LEFT JOIN permissions ON policies.id = permissions.policy_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
 		WHERE
 			policies.id = ?
 			AND policies.user_id = ?
 			AND policies.deleted_at IS NULL
 		GROUP BY
 			policies.id
-		LIMIT 1;`,
+		LIMIT 1`+lock+`;`,
 		id,
 		userID,
 	).StructScan(&policy); err != nil {
@@ -130,13 +145,56 @@ func (r *policyDBRepository) FindOneByIDAndUserIDAndNotDeleted(ctx context.Conte
 	return transformer.ToPolicyEntity(&policy)
 }
 
+func (r *policyDBRepository) FindOneByNameAndUserIDAndNotDeleted(ctx context.Context, name string, userID uuid.UUID) (*entity.Policy, error) {
+	var policy model.PolicyModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT
+			policies.id,
+			policies.user_id,
+			policies.name,
+			policies.effect,
+			policies.service,
+			policies.path,
+			policies.methods,
+			policies.conditions,
+			policies.expression,
+			policies.valid_from,
+			policies.valid_until,
+			policies.created_at,
+			policies.updated_at,
+			GROUP_CONCAT(permissions.agent_id ORDER BY permissions.agent_id) as agents
+		FROM
+			policies
+			LEFT JOIN permissions ON policies.id = permissions.policy_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+		WHERE
+			policies.name = ?
+			AND policies.user_id = ?
+			AND policies.deleted_at IS NULL
+		GROUP BY
+			policies.id
+		LIMIT 1;`,
+		name,
+		userID,
+	).StructScan(&policy); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToPolicyEntity(&policy)
+}
+
 func (r *policyDBRepository) FindByNamePrefixAndUserIDAndNotDeleted(ctx context.Context, keyword string, userID uuid.UUID) ([]*entity.Policy, error) {
 	var policies []*model.PolicyModel
 	driver := getDriver(ctx, r.db)
 
 	rows, err := driver.QueryxContext(
 		ctx,
-		`SELECT id, user_id, name, effect, service, path, methods, created_at, updated_at FROM policies WHERE name LIKE ? AND user_id = ? AND deleted_at IS NULL;`,
+		`SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE name LIKE ? AND user_id = ? AND deleted_at IS NULL;`,
 		keyword+"%",
 		userID,
 	)
@@ -156,6 +214,32 @@ func (r *policyDBRepository) FindByNamePrefixAndUserIDAndNotDeleted(ctx context.
 	return transformer.ToPolicyEntities(policies)
 }
 
+// ユーザーのポリシーをすべて返す. トランザクションの終了まで行をロックする.
+func (r *policyDBRepository) FindByUserIDAndNotDeletedForUpdate(ctx context.Context, userID uuid.UUID) ([]*entity.Policy, error) {
+	var policies []*model.PolicyModel
+	driver := getDriver(ctx, r.db)
+
+	rows, err := driver.QueryxContext(
+		ctx,
+		`SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE user_id = ? AND deleted_at IS NULL FOR UPDATE;`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var policy model.PolicyModel
+		if err := rows.StructScan(&policy); err != nil {
+			return nil, err
+		}
+		policies = append(policies, &policy)
+	}
+
+	return transformer.ToPolicyEntities(policies)
+}
+
 func (r *policyDBRepository) FindByIDsAndUserIDAndNotDeleted(ctx context.Context, ids []uuid.UUID, userID uuid.UUID) ([]*entity.Policy, error) {
 	if len(ids) == 0 {
 		return nil, nil
@@ -165,7 +249,7 @@ func (r *policyDBRepository) FindByIDsAndUserIDAndNotDeleted(ctx context.Context
 	driver := getDriver(ctx, r.db)
 
 	query, args, err := sqlx.Named(
-		`SELECT id, user_id, name, effect, service, path, methods, created_at, updated_at FROM policies WHERE id IN (:ids) AND user_id = :user_id AND deleted_at IS NULL;`,
+		`SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE id IN (:ids) AND user_id = :user_id AND deleted_at IS NULL;`,
 		map[string]interface{}{
 			"ids":     ids,
 			"user_id": userID,
@@ -206,7 +290,7 @@ func (r *policyDBRepository) FindByIDsAndNamePrefixAndUserIDAndNotDeleted(ctx co
 	driver := getDriver(ctx, r.db)
 
 	query, args, err := sqlx.Named(
-		`SELECT id, user_id, name, effect, service, path, methods, created_at, updated_at FROM policies WHERE id IN (:ids) AND name LIKE :keyword AND user_id = :user_id AND deleted_at IS NULL;`,
+		`SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE id IN (:ids) AND name LIKE :keyword AND user_id = :user_id AND deleted_at IS NULL;`,
 		map[string]interface{}{
 			"ids":     ids,
 			"keyword": keyword + "%",
@@ -239,12 +323,77 @@ func (r *policyDBRepository) FindByIDsAndNamePrefixAndUserIDAndNotDeleted(ctx co
 	return transformer.ToPolicyEntities(policies)
 }
 
+// 削除されていないエージェントに, 直接もグループやロールを通しても適用されていないポリシーを返す.
+func (r *policyDBRepository) FindUnattachedByUserIDAndNotDeleted(ctx context.Context, userID uuid.UUID) ([]*entity.Policy, error) {
+	var policies []*model.PolicyModel
+	driver := getDriver(ctx, r.db)
+
+	rows, err := driver.QueryxContext(
+		ctx,
+		`SELECT
+			policies.id,
+			policies.user_id,
+			policies.name,
+			policies.effect,
+			policies.service,
+			policies.path,
+			policies.methods,
+			policies.conditions,
+			policies.expression,
+			policies.valid_from,
+			policies.valid_until,
+			policies.created_at,
+			policies.updated_at
+		FROM
+			policies
+		WHERE
+			policies.user_id = ?
+			AND policies.deleted_at IS NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM permissions
+					INNER JOIN agents ON permissions.agent_id = agents.id
+				WHERE permissions.policy_id = policies.id AND agents.deleted_at IS NULL
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM agent_group_policies
+					INNER JOIN agent_groups ON agent_group_policies.agent_group_id = agent_groups.id
+					INNER JOIN agent_group_members ON agent_groups.id = agent_group_members.agent_group_id
+					INNER JOIN agents ON agent_group_members.agent_id = agents.id
+				WHERE agent_group_policies.policy_id = policies.id AND agent_groups.deleted_at IS NULL AND agents.deleted_at IS NULL
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM role_policies
+					INNER JOIN roles ON role_policies.role_id = roles.id
+					INNER JOIN role_agents ON roles.id = role_agents.role_id
+					INNER JOIN agents ON role_agents.agent_id = agents.id
+				WHERE role_policies.policy_id = policies.id AND roles.deleted_at IS NULL AND agents.deleted_at IS NULL
+			);`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var policy model.PolicyModel
+		if err := rows.StructScan(&policy); err != nil {
+			return nil, err
+		}
+		policies = append(policies, &policy)
+	}
+
+	return transformer.ToPolicyEntities(policies)
+}
+
+// 期間を指定した紐付けはPermissionRepositoryで管理するため, 期間のない紐付けのみを置き換える.
+// 同じエージェントに期間を指定した紐付けがある場合は, 期間のない紐付けに置き換える.
 func (r *policyDBRepository) updateAgents(ctx context.Context, id uuid.UUID, agentIDs []uuid.UUID) error {
 	driver := getDriver(ctx, r.db)
 
 	if _, err := driver.NamedExecContext(
 		ctx,
-		`DELETE FROM permissions WHERE policy_id = :policy_id;`,
+		`DELETE FROM permissions WHERE policy_id = :policy_id AND valid_from IS NULL AND valid_until IS NULL;`,
 		map[string]interface{}{"policy_id": id},
 	); err != nil {
 		return err
@@ -263,7 +412,7 @@ func (r *policyDBRepository) updateAgents(ctx context.Context, id uuid.UUID, age
 	}
 	if _, err := driver.NamedExecContext(
 		ctx,
-		`INSERT INTO permissions (agent_id, policy_id) VALUES (:agent_id, :policy_id);`,
+		`INSERT INTO permissions (agent_id, policy_id) VALUES (:agent_id, :policy_id) ON DUPLICATE KEY UPDATE valid_from = NULL, valid_until = NULL;`,
 		args,
 	); err != nil {
 		return err
@@ -271,3 +420,12 @@ func (r *policyDBRepository) updateAgents(ctx context.Context, id uuid.UUID, age
 
 	return nil
 }
+
+// 削除されていないポリシーの名前の一意制約に違反した場合は, 名前の重複として扱う.
+func toPolicyNameError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry && strings.Contains(mysqlErr.Message, "uq_policies_user_id_active_name") {
+		return entity.ErrPolicyAlreadyExists
+	}
+	return err
+}
@@ -0,0 +1,293 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+	"holos-auth-api/internal/app/api/infrastructure/transformer"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrRequiredPolicyBundle = status.Error(http.StatusInternalServerError, "policy bundle is required")
+)
+
+// 種類ごとの保存先. クエリの{table}, {policies}, {agents}, {key}をそれぞれの名前に置き換える.
+var policyBundleTables = map[entity.PolicyBundleKind]*strings.Replacer{
+	entity.PolicyBundleKindRole: strings.NewReplacer(
+		"{table}", "roles",
+		"{policies}", "role_policies",
+		"{agents}", "role_agents",
+		"{key}", "role_id",
+	),
+	entity.PolicyBundleKindAgentGroup: strings.NewReplacer(
+		"{table}", "agent_groups",
+		"{policies}", "agent_group_policies",
+		"{agents}", "agent_group_members",
+		"{key}", "agent_group_id",
+	),
+}
+
+type policyBundleDBRepository struct {
+	db     *sqlx.DB
+	kind   entity.PolicyBundleKind
+	tables *strings.Replacer
+}
+
+func NewPolicyBundleDBRepository(db *sqlx.DB, kind entity.PolicyBundleKind) repository.PolicyBundleRepository {
+	return &policyBundleDBRepository{
+		db:     db,
+		kind:   kind,
+		tables: policyBundleTables[kind],
+	}
+}
+
+func (r *policyBundleDBRepository) query(query string) string {
+	return r.tables.Replace(query)
+}
+
+func (r *policyBundleDBRepository) Create(ctx context.Context, bundle *entity.PolicyBundle) error {
+	if bundle == nil {
+		return ErrRequiredPolicyBundle
+	}
+
+	driver := getDriver(ctx, r.db)
+	bundleModel := transformer.ToPolicyBundleModel(bundle)
+
+	_, err := driver.NamedExecContext(
+		ctx,
+		r.query(`INSERT INTO {table} (id, user_id, name, created_at, updated_at) VALUES (:id, :user_id, :name, :created_at, :updated_at);`),
+		bundleModel,
+	)
+
+	return err
+}
+
+func (r *policyBundleDBRepository) Update(ctx context.Context, bundle *entity.PolicyBundle) error {
+	if bundle == nil {
+		return ErrRequiredPolicyBundle
+	}
+
+	driver := getDriver(ctx, r.db)
+	bundleModel := transformer.ToPolicyBundleModel(bundle)
+
+	if _, err := driver.NamedExecContext(
+		ctx,
+		r.query(`UPDATE {table} SET user_id = :user_id, name = :name, updated_at = :updated_at WHERE id = :id AND deleted_at IS NULL LIMIT 1;`),
+		bundleModel,
+	); err != nil {
+		return err
+	}
+
+	if err := r.updatePolicies(ctx, bundle.ID, bundle.Policies); err != nil {
+		return err
+	}
+	return r.updateAgents(ctx, bundle.ID, bundle.Agents)
+}
+
+func (r *policyBundleDBRepository) Delete(ctx context.Context, bundle *entity.PolicyBundle) error {
+	if bundle == nil {
+		return ErrRequiredPolicyBundle
+	}
+
+	driver := getDriver(ctx, r.db)
+	bundleModel := transformer.ToPolicyBundleModel(bundle)
+
+	_, err := driver.NamedExecContext(
+		ctx,
+		r.query(`UPDATE {table} SET updated_at = updated_at, deleted_at = NOW(6) WHERE id = :id AND deleted_at IS NULL LIMIT 1;`),
+		bundleModel,
+	)
+
+	return err
+}
+
+func (r *policyBundleDBRepository) FindOneByIDAndUserIDAndNotDeleted(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.PolicyBundle, error) {
+	var bundle model.PolicyBundleModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		r.query(`SELECT
+			{table}.id,
+			{table}.user_id,
+			{table}.name,
+			{table}.created_at,
+			{table}.updated_at,
+			GROUP_CONCAT(DISTINCT {policies}.policy_id ORDER BY {policies}.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT {agents}.agent_id ORDER BY {agents}.agent_id) as agents
+		FROM
+			{table}
+			LEFT JOIN {policies} ON {table}.id = {policies}.{key}
+			LEFT JOIN {agents} ON {table}.id = {agents}.{key}
+		WHERE
+			{table}.id = ?
+			AND {table}.user_id = ?
+			AND {table}.deleted_at IS NULL
+		GROUP BY
+			{table}.id
+		LIMIT 1;`),
+		id,
+		userID,
+	).StructScan(&bundle); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToPolicyBundleEntity(r.kind, &bundle)
+}
+
+func (r *policyBundleDBRepository) FindByNamePrefixAndUserIDAndNotDeleted(ctx context.Context, keyword string, userID uuid.UUID) ([]*entity.PolicyBundle, error) {
+	bundles := []*model.PolicyBundleModel{}
+	driver := getDriver(ctx, r.db)
+
+	rows, err := driver.QueryxContext(
+		ctx,
+		r.query(`SELECT id, user_id, name, created_at, updated_at FROM {table} WHERE name LIKE ? AND user_id = ? AND deleted_at IS NULL;`),
+		keyword+"%",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bundle model.PolicyBundleModel
+		if err := rows.StructScan(&bundle); err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, &bundle)
+	}
+
+	return transformer.ToPolicyBundleEntities(r.kind, bundles)
+}
+
+// 認可の評価に使うため, ポリシーも取得する.
+func (r *policyBundleDBRepository) FindByIDsAndUserIDAndNotDeleted(ctx context.Context, ids []uuid.UUID, userID uuid.UUID) ([]*entity.PolicyBundle, error) {
+	if len(ids) == 0 {
+		return []*entity.PolicyBundle{}, nil
+	}
+
+	bundles := []*model.PolicyBundleModel{}
+	driver := getDriver(ctx, r.db)
+
+	query, args, err := sqlx.Named(
+		r.query(`SELECT
+			{table}.id,
+			{table}.user_id,
+			{table}.name,
+			{table}.created_at,
+			{table}.updated_at,
+			GROUP_CONCAT({policies}.policy_id ORDER BY {policies}.policy_id) as policies
+		FROM
+			{table}
+			LEFT JOIN {policies} ON {table}.id = {policies}.{key}
+		WHERE
+			{table}.id IN (:ids)
+			AND {table}.user_id = :user_id
+			AND {table}.deleted_at IS NULL
+		GROUP BY
+			{table}.id;`),
+		map[string]interface{}{
+			"ids":     ids,
+			"user_id": userID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	query = driver.Rebind(query)
+
+	rows, err := driver.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bundle model.PolicyBundleModel
+		if err := rows.StructScan(&bundle); err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, &bundle)
+	}
+
+	return transformer.ToPolicyBundleEntities(r.kind, bundles)
+}
+
+func (r *policyBundleDBRepository) updatePolicies(ctx context.Context, id uuid.UUID, policyIDs []uuid.UUID) error {
+	driver := getDriver(ctx, r.db)
+
+	if _, err := driver.NamedExecContext(
+		ctx,
+		r.query(`DELETE FROM {policies} WHERE {key} = :bundle_id;`),
+		map[string]interface{}{"bundle_id": id},
+	); err != nil {
+		return err
+	}
+
+	if len(policyIDs) == 0 {
+		return nil
+	}
+
+	args := make([]map[string]interface{}, len(policyIDs))
+	for i, policyID := range policyIDs {
+		args[i] = map[string]interface{}{
+			"bundle_id": id,
+			"policy_id": policyID,
+		}
+	}
+	_, err := driver.NamedExecContext(
+		ctx,
+		r.query(`INSERT INTO {policies} ({key}, policy_id) VALUES (:bundle_id, :policy_id);`),
+		args,
+	)
+
+	return err
+}
+
+func (r *policyBundleDBRepository) updateAgents(ctx context.Context, id uuid.UUID, agentIDs []uuid.UUID) error {
+	driver := getDriver(ctx, r.db)
+
+	if _, err := driver.NamedExecContext(
+		ctx,
+		r.query(`DELETE FROM {agents} WHERE {key} = :bundle_id;`),
+		map[string]interface{}{"bundle_id": id},
+	); err != nil {
+		return err
+	}
+
+	if len(agentIDs) == 0 {
+		return nil
+	}
+
+	args := make([]map[string]interface{}, len(agentIDs))
+	for i, agentID := range agentIDs {
+		args[i] = map[string]interface{}{
+			"bundle_id": id,
+			"agent_id":  agentID,
+		}
+	}
+	_, err := driver.NamedExecContext(
+		ctx,
+		r.query(`INSERT INTO {agents} ({key}, agent_id) VALUES (:bundle_id, :agent_id);`),
+		args,
+	)
+
+	return err
+}
@@ -0,0 +1,483 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/database"
+	"holos-auth-api/test"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestPolicyBundle_Create(t *testing.T) {
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name        string
+		inputBundle *entity.PolicyBundle
+		expectError error
+		setMockDB   func(sqlmock.Sqlmock)
+	}{
+		{
+			name:        "success",
+			inputBundle: role,
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO roles (id, user_id, name, created_at, updated_at) VALUES (?, ?, ?, ?, ?);")).
+					WithArgs(role.ID, role.UserID, role.Name, role.CreatedAt, role.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "create error",
+			inputBundle: role,
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO roles (id, user_id, name, created_at, updated_at) VALUES (?, ?, ?, ?, ?);")).
+					WithArgs(role.ID, role.UserID, role.Name, role.CreatedAt, role.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:        "no bundle",
+			inputBundle: nil,
+			expectError: database.ErrRequiredPolicyBundle,
+			setMockDB:   func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPolicyBundleDBRepository(db, entity.PolicyBundleKindRole)
+			if err := r.Create(ctx, tt.inputBundle); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_Update(t *testing.T) {
+	roleWithoutBindings, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	policy, err := entity.NewPolicy(roleWithoutBindings.UserID, "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agent, err := entity.NewAgent(roleWithoutBindings.UserID, "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	roleWithBindings, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, roleWithoutBindings.UserID, "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	roleWithBindings.SetPolicies([]*entity.Policy{policy})
+	roleWithBindings.SetAgents([]*entity.Agent{agent})
+
+	tests := []struct {
+		name        string
+		inputBundle *entity.PolicyBundle
+		expectError error
+		setMockDB   func(sqlmock.Sqlmock)
+	}{
+		{
+			name:        "without bindings",
+			inputBundle: roleWithoutBindings,
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE roles SET user_id = ?, name = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
+					WithArgs(roleWithoutBindings.UserID, roleWithoutBindings.Name, roleWithoutBindings.UpdatedAt, roleWithoutBindings.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM role_policies WHERE role_id = ?;")).
+					WithArgs(roleWithoutBindings.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM role_agents WHERE role_id = ?;")).
+					WithArgs(roleWithoutBindings.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "with bindings",
+			inputBundle: roleWithBindings,
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE roles SET user_id = ?, name = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
+					WithArgs(roleWithBindings.UserID, roleWithBindings.Name, roleWithBindings.UpdatedAt, roleWithBindings.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM role_policies WHERE role_id = ?;")).
+					WithArgs(roleWithBindings.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO role_policies (role_id, policy_id) VALUES (?, ?);")).
+					WithArgs(roleWithBindings.ID, policy.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM role_agents WHERE role_id = ?;")).
+					WithArgs(roleWithBindings.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO role_agents (role_id, agent_id) VALUES (?, ?);")).
+					WithArgs(roleWithBindings.ID, agent.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "update role error",
+			inputBundle: roleWithoutBindings,
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE roles SET user_id = ?, name = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
+					WithArgs(roleWithoutBindings.UserID, roleWithoutBindings.Name, roleWithoutBindings.UpdatedAt, roleWithoutBindings.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:        "delete role agents error",
+			inputBundle: roleWithoutBindings,
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE roles SET user_id = ?, name = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
+					WithArgs(roleWithoutBindings.UserID, roleWithoutBindings.Name, roleWithoutBindings.UpdatedAt, roleWithoutBindings.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM role_policies WHERE role_id = ?;")).
+					WithArgs(roleWithoutBindings.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM role_agents WHERE role_id = ?;")).
+					WithArgs(roleWithoutBindings.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:        "no bundle",
+			inputBundle: nil,
+			expectError: database.ErrRequiredPolicyBundle,
+			setMockDB:   func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPolicyBundleDBRepository(db, entity.PolicyBundleKindRole)
+			if err := r.Update(ctx, tt.inputBundle); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_FindOneByIDAndUserIDAndNotDeleted(t *testing.T) {
+	policyID := uuid.New()
+	agentID := uuid.New()
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	role.Policies = []uuid.UUID{policyID}
+	role.Agents = []uuid.UUID{agentID}
+
+	query := `SELECT
+			roles.id,
+			roles.user_id,
+			roles.name,
+			roles.created_at,
+			roles.updated_at,
+			GROUP_CONCAT(DISTINCT role_policies.policy_id ORDER BY role_policies.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT role_agents.agent_id ORDER BY role_agents.agent_id) as agents
+		FROM
+			roles
+			LEFT JOIN role_policies ON roles.id = role_policies.role_id
+			LEFT JOIN role_agents ON roles.id = role_agents.role_id
+		WHERE
+			roles.id = ?
+			AND roles.user_id = ?
+			AND roles.deleted_at IS NULL
+		GROUP BY
+			roles.id
+		LIMIT 1;`
+
+	tests := []struct {
+		name         string
+		expectResult *entity.PolicyBundle
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			expectResult: role,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(role.ID, role.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at", "policies", "agents"}).
+							AddRow(role.ID, role.UserID, role.Name, role.CreatedAt, role.UpdatedAt, policyID.String(), agentID.String()),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(role.ID, role.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at", "policies", "agents"}),
+					).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(role.ID, role.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at", "policies", "agents"}),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPolicyBundleDBRepository(db, entity.PolicyBundleKindRole)
+			result, err := r.FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_FindByIDsAndUserIDAndNotDeleted(t *testing.T) {
+	policyID := uuid.New()
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	role.Policies = []uuid.UUID{policyID}
+	role.Agents = []uuid.UUID{}
+
+	query := `SELECT
+			roles.id,
+			roles.user_id,
+			roles.name,
+			roles.created_at,
+			roles.updated_at,
+			GROUP_CONCAT(role_policies.policy_id ORDER BY role_policies.policy_id) as policies
+		FROM
+			roles
+			LEFT JOIN role_policies ON roles.id = role_policies.role_id
+		WHERE
+			roles.id IN (?)
+			AND roles.user_id = ?
+			AND roles.deleted_at IS NULL
+		GROUP BY
+			roles.id;`
+
+	tests := []struct {
+		name         string
+		inputIDs     []uuid.UUID
+		expectResult []*entity.PolicyBundle
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputIDs:     []uuid.UUID{role.ID},
+			expectResult: []*entity.PolicyBundle{role},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(role.ID, role.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at", "policies"}).
+							AddRow(role.ID, role.UserID, role.Name, role.CreatedAt, role.UpdatedAt, policyID.String()),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "empty ids",
+			inputIDs:     []uuid.UUID{},
+			expectResult: []*entity.PolicyBundle{},
+			expectError:  nil,
+			setMockDB:    func(mock sqlmock.Sqlmock) {},
+		},
+		{
+			name:         "find error",
+			inputIDs:     []uuid.UUID{role.ID},
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(role.ID, role.UserID).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPolicyBundleDBRepository(db, entity.PolicyBundleKindRole)
+			result, err := r.FindByIDsAndUserIDAndNotDeleted(ctx, tt.inputIDs, role.UserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_AgentGroupTables(t *testing.T) {
+	group, err := entity.NewPolicyBundle(entity.PolicyBundleKindAgentGroup, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(group.UserID, "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agent, err := entity.NewAgent(group.UserID, "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	group.SetPolicies([]*entity.Policy{policy})
+	group.SetAgents([]*entity.Agent{agent})
+
+	query := `SELECT
+			agent_groups.id,
+			agent_groups.user_id,
+			agent_groups.name,
+			agent_groups.created_at,
+			agent_groups.updated_at,
+			GROUP_CONCAT(DISTINCT agent_group_policies.policy_id ORDER BY agent_group_policies.policy_id) as policies,
+			GROUP_CONCAT(DISTINCT agent_group_members.agent_id ORDER BY agent_group_members.agent_id) as agents
+		FROM
+			agent_groups
+			LEFT JOIN agent_group_policies ON agent_groups.id = agent_group_policies.agent_group_id
+			LEFT JOIN agent_group_members ON agent_groups.id = agent_group_members.agent_group_id
+		WHERE
+			agent_groups.id = ?
+			AND agent_groups.user_id = ?
+			AND agent_groups.deleted_at IS NULL
+		GROUP BY
+			agent_groups.id
+		LIMIT 1;`
+
+	db, mock := test.NewMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE agent_groups SET user_id = ?, name = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
+		WithArgs(group.UserID, group.Name, group.UpdatedAt, group.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM agent_group_policies WHERE agent_group_id = ?;")).
+		WithArgs(group.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO agent_group_policies (agent_group_id, policy_id) VALUES (?, ?);")).
+		WithArgs(group.ID, policy.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM agent_group_members WHERE agent_group_id = ?;")).
+		WithArgs(group.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO agent_group_members (agent_group_id, agent_id) VALUES (?, ?);")).
+		WithArgs(group.ID, agent.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(group.ID, group.UserID).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "user_id", "name", "created_at", "updated_at", "policies", "agents"}).
+				AddRow(group.ID, group.UserID, group.Name, group.CreatedAt, group.UpdatedAt, policy.ID.String(), agent.ID.String()),
+		)
+
+	r := database.NewPolicyBundleDBRepository(db, entity.PolicyBundleKindAgentGroup)
+	if err := r.Update(ctx, group); err != nil {
+		t.Error(err.Error())
+	}
+	result, err := r.FindOneByIDAndUserIDAndNotDeleted(ctx, group.ID, group.UserID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if diff := cmp.Diff(result, group); diff != "" {
+		t.Error(diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
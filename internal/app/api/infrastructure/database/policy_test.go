@@ -11,8 +11,10 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 )
@@ -23,6 +25,21 @@ func TestPolicy_Create(t *testing.T) {
 		t.Error(err.Error())
 	}
 
+	conditions, err := entity.NewPolicyConditions("Asia/Tokyo", []string{"MON"}, "09:00", "18:00", nil, nil, []string{"10.0.0.0/8"}, map[string][]string{"tenant": {"holos"}}, nil)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyWithConditions, err := entity.NewPolicy(policy.UserID, "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyWithConditions.SetConditions(conditions)
+	validFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	validUntil := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	if err := policyWithConditions.SetValidity(&validFrom, &validUntil); err != nil {
+		t.Error(err.Error())
+	}
+
 	tests := []struct {
 		name        string
 		inputPolicy *entity.Policy
@@ -34,8 +51,33 @@ func TestPolicy_Create(t *testing.T) {
 			inputPolicy: policy,
 			expectError: nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO policies (id, user_id, name, effect, service, path, methods, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);")).
-					WithArgs(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, []byte(`["GET"]`), policy.CreatedAt, policy.UpdatedAt).
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO policies (id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, []byte(`["GET"]`), []byte(nil), "", nil, nil, policy.CreatedAt, policy.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "success with conditions",
+			inputPolicy: policyWithConditions,
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO policies (id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(
+						policyWithConditions.ID,
+						policyWithConditions.UserID,
+						policyWithConditions.Name,
+						policyWithConditions.Effect,
+						policyWithConditions.Service,
+						policyWithConditions.Path,
+						[]byte(`["GET"]`),
+						[]byte(`{"time_zone":"Asia/Tokyo","days_of_week":["MON"],"start_time":"09:00","end_time":"18:00","not_before":null,"not_after":null,"source_cidrs":["10.0.0.0/8"],"attributes":{"tenant":["holos"]},"path_params":{}}`),
+						"",
+						validFrom,
+						validUntil,
+						policyWithConditions.CreatedAt,
+						policyWithConditions.UpdatedAt,
+					).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
 			},
@@ -45,12 +87,32 @@ func TestPolicy_Create(t *testing.T) {
 			inputPolicy: policy,
 			expectError: sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO policies (id, user_id, name, effect, service, path, methods, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);")).
-					WithArgs(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, []byte(`["GET"]`), policy.CreatedAt, policy.UpdatedAt).
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO policies (id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, []byte(`["GET"]`), []byte(nil), "", nil, nil, policy.CreatedAt, policy.UpdatedAt).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(sql.ErrConnDone)
 			},
 		},
+		{
+			name:        "duplicate name",
+			inputPolicy: policy,
+			expectError: entity.ErrPolicyAlreadyExists,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO policies (id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, []byte(`["GET"]`), []byte(nil), "", nil, nil, policy.CreatedAt, policy.UpdatedAt).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry for key 'policies.uq_policies_user_id_active_name'"})
+			},
+		},
+		{
+			name:        "duplicate primary key",
+			inputPolicy: policy,
+			expectError: &mysql.MySQLError{Number: 1062, Message: "Duplicate entry for key 'policies.PRIMARY'"},
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO policies (id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, []byte(`["GET"]`), []byte(nil), "", nil, nil, policy.CreatedAt, policy.UpdatedAt).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry for key 'policies.PRIMARY'"})
+			},
+		},
 		{
 			name:        "no policy",
 			inputPolicy: nil,
@@ -106,11 +168,11 @@ func TestPolicy_Update(t *testing.T) {
 			inputPolicy: policyWithoutAgents,
 			expectError: nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta("UPDATE policies SET user_id = ?, name = ?, effect = ?, service = ?, path = ?, methods = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
-					WithArgs(policyWithoutAgents.UserID, policyWithoutAgents.Name, policyWithoutAgents.Effect, policyWithoutAgents.Service, policyWithoutAgents.Path, []byte(`["GET"]`), policyWithoutAgents.UpdatedAt, policyWithoutAgents.ID).
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE policies SET user_id = ?, name = ?, effect = ?, service = ?, path = ?, methods = ?, conditions = ?, expression = ?, valid_from = ?, valid_until = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
+					WithArgs(policyWithoutAgents.UserID, policyWithoutAgents.Name, policyWithoutAgents.Effect, policyWithoutAgents.Service, policyWithoutAgents.Path, []byte(`["GET"]`), []byte(nil), "", nil, nil, policyWithoutAgents.UpdatedAt, policyWithoutAgents.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
-				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE policy_id = ?;")).
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE policy_id = ? AND valid_from IS NULL AND valid_until IS NULL;")).
 					WithArgs(policyWithoutAgents.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
@@ -121,15 +183,15 @@ func TestPolicy_Update(t *testing.T) {
 			inputPolicy: policyWithAgents,
 			expectError: nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta("UPDATE policies SET user_id = ?, name = ?, effect = ?, service = ?, path = ?, methods = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
-					WithArgs(policyWithAgents.UserID, policyWithAgents.Name, policyWithAgents.Effect, policyWithAgents.Service, policyWithAgents.Path, []byte(`["GET"]`), policyWithAgents.UpdatedAt, policyWithAgents.ID).
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE policies SET user_id = ?, name = ?, effect = ?, service = ?, path = ?, methods = ?, conditions = ?, expression = ?, valid_from = ?, valid_until = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
+					WithArgs(policyWithAgents.UserID, policyWithAgents.Name, policyWithAgents.Effect, policyWithAgents.Service, policyWithAgents.Path, []byte(`["GET"]`), []byte(nil), "", nil, nil, policyWithAgents.UpdatedAt, policyWithAgents.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
-				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE policy_id = ?;")).
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE policy_id = ? AND valid_from IS NULL AND valid_until IS NULL;")).
 					WithArgs(policyWithAgents.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
-				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO permissions (agent_id, policy_id) VALUES (?, ?);")).
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO permissions (agent_id, policy_id) VALUES (?, ?) ON DUPLICATE KEY UPDATE valid_from = NULL, valid_until = NULL;")).
 					WithArgs(policyWithAgents.Agents[0], policyWithAgents.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
@@ -140,22 +202,32 @@ func TestPolicy_Update(t *testing.T) {
 			inputPolicy: policyWithoutAgents,
 			expectError: sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta("UPDATE policies SET user_id = ?, name = ?, effect = ?, service = ?, path = ?, methods = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
-					WithArgs(policyWithoutAgents.UserID, policyWithoutAgents.Name, policyWithoutAgents.Effect, policyWithoutAgents.Service, policyWithoutAgents.Path, []byte(`["GET"]`), policyWithoutAgents.UpdatedAt, policyWithoutAgents.ID).
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE policies SET user_id = ?, name = ?, effect = ?, service = ?, path = ?, methods = ?, conditions = ?, expression = ?, valid_from = ?, valid_until = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
+					WithArgs(policyWithoutAgents.UserID, policyWithoutAgents.Name, policyWithoutAgents.Effect, policyWithoutAgents.Service, policyWithoutAgents.Path, []byte(`["GET"]`), []byte(nil), "", nil, nil, policyWithoutAgents.UpdatedAt, policyWithoutAgents.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(sql.ErrConnDone)
 			},
 		},
+		{
+			name:        "duplicate name",
+			inputPolicy: policyWithoutAgents,
+			expectError: entity.ErrPolicyAlreadyExists,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE policies SET user_id = ?, name = ?, effect = ?, service = ?, path = ?, methods = ?, conditions = ?, expression = ?, valid_from = ?, valid_until = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
+					WithArgs(policyWithoutAgents.UserID, policyWithoutAgents.Name, policyWithoutAgents.Effect, policyWithoutAgents.Service, policyWithoutAgents.Path, []byte(`["GET"]`), []byte(nil), "", nil, nil, policyWithoutAgents.UpdatedAt, policyWithoutAgents.ID).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry for key 'policies.uq_policies_user_id_active_name'"})
+			},
+		},
 		{
 			name:        "delete permissions error",
 			inputPolicy: policyWithoutAgents,
 			expectError: sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta("UPDATE policies SET user_id = ?, name = ?, effect = ?, service = ?, path = ?, methods = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
-					WithArgs(policyWithoutAgents.UserID, policyWithoutAgents.Name, policyWithoutAgents.Effect, policyWithoutAgents.Service, policyWithoutAgents.Path, []byte(`["GET"]`), policyWithoutAgents.UpdatedAt, policyWithoutAgents.ID).
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE policies SET user_id = ?, name = ?, effect = ?, service = ?, path = ?, methods = ?, conditions = ?, expression = ?, valid_from = ?, valid_until = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
+					WithArgs(policyWithoutAgents.UserID, policyWithoutAgents.Name, policyWithoutAgents.Effect, policyWithoutAgents.Service, policyWithoutAgents.Path, []byte(`["GET"]`), []byte(nil), "", nil, nil, policyWithoutAgents.UpdatedAt, policyWithoutAgents.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
-				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE policy_id = ?;")).
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE policy_id = ? AND valid_from IS NULL AND valid_until IS NULL;")).
 					WithArgs(policyWithoutAgents.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(sql.ErrConnDone)
@@ -166,15 +238,15 @@ func TestPolicy_Update(t *testing.T) {
 			inputPolicy: policyWithAgents,
 			expectError: sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta("UPDATE policies SET user_id = ?, name = ?, effect = ?, service = ?, path = ?, methods = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
-					WithArgs(policyWithAgents.UserID, policyWithAgents.Name, policyWithAgents.Effect, policyWithAgents.Service, policyWithAgents.Path, []byte(`["GET"]`), policyWithAgents.UpdatedAt, policyWithAgents.ID).
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE policies SET user_id = ?, name = ?, effect = ?, service = ?, path = ?, methods = ?, conditions = ?, expression = ?, valid_from = ?, valid_until = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
+					WithArgs(policyWithAgents.UserID, policyWithAgents.Name, policyWithAgents.Effect, policyWithAgents.Service, policyWithAgents.Path, []byte(`["GET"]`), []byte(nil), "", nil, nil, policyWithAgents.UpdatedAt, policyWithAgents.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
-				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE policy_id = ?;")).
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM permissions WHERE policy_id = ? AND valid_from IS NULL AND valid_until IS NULL;")).
 					WithArgs(policyWithAgents.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
-				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO permissions (agent_id, policy_id) VALUES (?, ?);")).
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO permissions (agent_id, policy_id) VALUES (?, ?) ON DUPLICATE KEY UPDATE valid_from = NULL, valid_until = NULL;")).
 					WithArgs(policyWithAgents.Agents[0], policyWithAgents.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(sql.ErrConnDone)
@@ -276,6 +348,16 @@ func TestPolicy_FindOneByIDAndUserIDAndNotDeleted(t *testing.T) {
 		t.Error(err.Error())
 	}
 
+	conditions, err := entity.NewPolicyConditions("UTC", nil, "", "", nil, nil, []string{"10.0.0.0/8"}, nil, nil)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyWithConditions, err := entity.NewPolicy(policy.UserID, "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyWithConditions.SetConditions(conditions)
+
 	tests := []struct {
 		name         string
 		inputID      uuid.UUID
@@ -300,12 +382,16 @@ func TestPolicy_FindOneByIDAndUserIDAndNotDeleted(t *testing.T) {
 						policies.service,
 						policies.path,
 						policies.methods,
+						policies.conditions,
+						policies.expression,
+						policies.valid_from,
+						policies.valid_until,
 						policies.created_at,
 						policies.updated_at,
 						GROUP_CONCAT(permissions.agent_id ORDER BY permissions.agent_id) as agents
 					FROM
 						policies
-						LEFT JOIN permissions ON policies.id = permissions.policy_id
+						LEFT JOIN permissions ON policies.id = permissions.policy_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
 					WHERE
 						policies.id = ?
 						AND policies.user_id = ?
@@ -316,8 +402,62 @@ func TestPolicy_FindOneByIDAndUserIDAndNotDeleted(t *testing.T) {
 				)).
 					WithArgs(policy.ID, policy.UserID).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "created_at", "updated_at"}).
-							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), policy.CreatedAt, policy.UpdatedAt),
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}).
+							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), nil, "", policy.CreatedAt, policy.UpdatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "found with conditions",
+			inputID:      policyWithConditions.ID,
+			inputUserID:  policyWithConditions.UserID,
+			expectResult: policyWithConditions,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						policies.id,
+						policies.user_id,
+						policies.name,
+						policies.effect,
+						policies.service,
+						policies.path,
+						policies.methods,
+						policies.conditions,
+						policies.expression,
+						policies.valid_from,
+						policies.valid_until,
+						policies.created_at,
+						policies.updated_at,
+						GROUP_CONCAT(permissions.agent_id ORDER BY permissions.agent_id) as agents
+					FROM
+						policies
+						LEFT JOIN permissions ON policies.id = permissions.policy_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+					WHERE
+						policies.id = ?
+						AND policies.user_id = ?
+						AND policies.deleted_at IS NULL
+					GROUP BY
+						policies.id
+					LIMIT 1;`,
+				)).
+					WithArgs(policyWithConditions.ID, policyWithConditions.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}).
+							AddRow(
+								policyWithConditions.ID,
+								policyWithConditions.UserID,
+								policyWithConditions.Name,
+								policyWithConditions.Effect,
+								policyWithConditions.Service,
+								policyWithConditions.Path,
+								`["GET"]`,
+								`{"time_zone":"UTC","days_of_week":[],"start_time":"","end_time":"","not_before":null,"not_after":null,"source_cidrs":["10.0.0.0/8"],"attributes":{}}`,
+								"",
+								policyWithConditions.CreatedAt,
+								policyWithConditions.UpdatedAt,
+							),
 					).
 					WillReturnError(nil)
 			},
@@ -338,12 +478,16 @@ func TestPolicy_FindOneByIDAndUserIDAndNotDeleted(t *testing.T) {
 						policies.service,
 						policies.path,
 						policies.methods,
+						policies.conditions,
+						policies.expression,
+						policies.valid_from,
+						policies.valid_until,
 						policies.created_at,
 						policies.updated_at,
 						GROUP_CONCAT(permissions.agent_id ORDER BY permissions.agent_id) as agents
 					FROM
 						policies
-						LEFT JOIN permissions ON policies.id = permissions.policy_id
+						LEFT JOIN permissions ON policies.id = permissions.policy_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
 					WHERE
 						policies.id = ?
 						AND policies.user_id = ?
@@ -354,8 +498,8 @@ func TestPolicy_FindOneByIDAndUserIDAndNotDeleted(t *testing.T) {
 				)).
 					WithArgs(policy.ID, policy.UserID).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "created_at", "updated_at"}).
-							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), policy.CreatedAt, policy.UpdatedAt),
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}).
+							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), nil, "", policy.CreatedAt, policy.UpdatedAt),
 					).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -376,12 +520,16 @@ func TestPolicy_FindOneByIDAndUserIDAndNotDeleted(t *testing.T) {
 						policies.service,
 						policies.path,
 						policies.methods,
+						policies.conditions,
+						policies.expression,
+						policies.valid_from,
+						policies.valid_until,
 						policies.created_at,
 						policies.updated_at,
 						GROUP_CONCAT(permissions.agent_id ORDER BY permissions.agent_id) as agents
 					FROM
 						policies
-						LEFT JOIN permissions ON policies.id = permissions.policy_id
+						LEFT JOIN permissions ON policies.id = permissions.policy_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
 					WHERE
 						policies.id = ?
 						AND policies.user_id = ?
@@ -392,8 +540,8 @@ func TestPolicy_FindOneByIDAndUserIDAndNotDeleted(t *testing.T) {
 				)).
 					WithArgs(policy.ID, policy.UserID).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "created_at", "updated_at"}).
-							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), policy.CreatedAt, policy.UpdatedAt),
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}).
+							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), nil, "", policy.CreatedAt, policy.UpdatedAt),
 					).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -424,6 +572,279 @@ func TestPolicy_FindOneByIDAndUserIDAndNotDeleted(t *testing.T) {
 	}
 }
 
+func TestPolicy_FindOneByIDAndUserIDAndNotDeletedForUpdate(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	query := regexp.QuoteMeta(
+		`SELECT
+			policies.id,
+			policies.user_id,
+			policies.name,
+			policies.effect,
+			policies.service,
+			policies.path,
+			policies.methods,
+			policies.conditions,
+			policies.expression,
+			policies.valid_from,
+			policies.valid_until,
+			policies.created_at,
+			policies.updated_at,
+			GROUP_CONCAT(permissions.agent_id ORDER BY permissions.agent_id) as agents
+		FROM
+			policies
+			LEFT JOIN permissions ON policies.id = permissions.policy_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+		WHERE
+			policies.id = ?
+			AND policies.user_id = ?
+			AND policies.deleted_at IS NULL
+		GROUP BY
+			policies.id
+		LIMIT 1 FOR UPDATE OF policies;`,
+	)
+
+	tests := []struct {
+		name         string
+		inputID      uuid.UUID
+		inputUserID  uuid.UUID
+		expectResult *entity.Policy
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputID:      policy.ID,
+			inputUserID:  policy.UserID,
+			expectResult: policy,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(policy.ID, policy.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}).
+							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), nil, "", policy.CreatedAt, policy.UpdatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputID:      policy.ID,
+			inputUserID:  policy.UserID,
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(policy.ID, policy.UserID).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			inputID:      policy.ID,
+			inputUserID:  policy.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(policy.ID, policy.UserID).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPolicyDBRepository(db)
+			result, err := r.FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, tt.inputID, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestPolicy_FindOneByNameAndUserIDAndNotDeleted(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name         string
+		inputName    string
+		inputUserID  uuid.UUID
+		expectResult *entity.Policy
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputName:    policy.Name,
+			inputUserID:  policy.UserID,
+			expectResult: policy,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						policies.id,
+						policies.user_id,
+						policies.name,
+						policies.effect,
+						policies.service,
+						policies.path,
+						policies.methods,
+						policies.conditions,
+						policies.expression,
+						policies.valid_from,
+						policies.valid_until,
+						policies.created_at,
+						policies.updated_at,
+						GROUP_CONCAT(permissions.agent_id ORDER BY permissions.agent_id) as agents
+					FROM
+						policies
+						LEFT JOIN permissions ON policies.id = permissions.policy_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+					WHERE
+						policies.name = ?
+						AND policies.user_id = ?
+						AND policies.deleted_at IS NULL
+					GROUP BY
+						policies.id
+					LIMIT 1;`,
+				)).
+					WithArgs(policy.Name, policy.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}).
+							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), nil, "", policy.CreatedAt, policy.UpdatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputName:    policy.Name,
+			inputUserID:  policy.UserID,
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						policies.id,
+						policies.user_id,
+						policies.name,
+						policies.effect,
+						policies.service,
+						policies.path,
+						policies.methods,
+						policies.conditions,
+						policies.expression,
+						policies.valid_from,
+						policies.valid_until,
+						policies.created_at,
+						policies.updated_at,
+						GROUP_CONCAT(permissions.agent_id ORDER BY permissions.agent_id) as agents
+					FROM
+						policies
+						LEFT JOIN permissions ON policies.id = permissions.policy_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+					WHERE
+						policies.name = ?
+						AND policies.user_id = ?
+						AND policies.deleted_at IS NULL
+					GROUP BY
+						policies.id
+					LIMIT 1;`,
+				)).
+					WithArgs(policy.Name, policy.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}).
+							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), nil, "", policy.CreatedAt, policy.UpdatedAt),
+					).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			inputName:    policy.Name,
+			inputUserID:  policy.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT
+						policies.id,
+						policies.user_id,
+						policies.name,
+						policies.effect,
+						policies.service,
+						policies.path,
+						policies.methods,
+						policies.conditions,
+						policies.expression,
+						policies.valid_from,
+						policies.valid_until,
+						policies.created_at,
+						policies.updated_at,
+						GROUP_CONCAT(permissions.agent_id ORDER BY permissions.agent_id) as agents
+					FROM
+						policies
+						LEFT JOIN permissions ON policies.id = permissions.policy_id AND permissions.valid_from IS NULL AND permissions.valid_until IS NULL
+					WHERE
+						policies.name = ?
+						AND policies.user_id = ?
+						AND policies.deleted_at IS NULL
+					GROUP BY
+						policies.id
+					LIMIT 1;`,
+				)).
+					WithArgs(policy.Name, policy.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}).
+							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), nil, "", policy.CreatedAt, policy.UpdatedAt),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPolicyDBRepository(db)
+			result, err := r.FindOneByNameAndUserIDAndNotDeleted(ctx, tt.inputName, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
 func TestPolicy_FindByNamePrefixAndUserIDAndNotDeleted(t *testing.T) {
 	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
 	if err != nil {
@@ -445,11 +866,11 @@ func TestPolicy_FindByNamePrefixAndUserIDAndNotDeleted(t *testing.T) {
 			expectResult: []*entity.Policy{policy},
 			expectError:  nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, created_at, updated_at FROM policies WHERE name LIKE ? AND user_id = ? AND deleted_at IS NULL;")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE name LIKE ? AND user_id = ? AND deleted_at IS NULL;")).
 					WithArgs("name%", policy.UserID).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "created_at", "updated_at"}).
-							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), policy.CreatedAt, policy.UpdatedAt),
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}).
+							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), nil, "", policy.CreatedAt, policy.UpdatedAt),
 					).
 					WillReturnError(nil)
 			},
@@ -461,10 +882,10 @@ func TestPolicy_FindByNamePrefixAndUserIDAndNotDeleted(t *testing.T) {
 			expectResult: []*entity.Policy{},
 			expectError:  nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, created_at, updated_at FROM policies WHERE name LIKE ? AND user_id = ? AND deleted_at IS NULL;")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE name LIKE ? AND user_id = ? AND deleted_at IS NULL;")).
 					WithArgs("keyword%", policy.UserID).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "created_at", "updated_at"}),
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}),
 					).
 					WillReturnError(nil)
 			},
@@ -476,10 +897,10 @@ func TestPolicy_FindByNamePrefixAndUserIDAndNotDeleted(t *testing.T) {
 			expectResult: nil,
 			expectError:  sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, created_at, updated_at FROM policies WHERE name LIKE ? AND user_id = ? AND deleted_at IS NULL;")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE name LIKE ? AND user_id = ? AND deleted_at IS NULL;")).
 					WithArgs("name%", policy.UserID).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "created_at", "updated_at"}),
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}),
 					).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -510,6 +931,71 @@ func TestPolicy_FindByNamePrefixAndUserIDAndNotDeleted(t *testing.T) {
 	}
 }
 
+func TestPolicy_FindByUserIDAndNotDeletedForUpdate(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name         string
+		inputUserID  uuid.UUID
+		expectResult []*entity.Policy
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputUserID:  policy.UserID,
+			expectResult: []*entity.Policy{policy},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE user_id = ? AND deleted_at IS NULL FOR UPDATE;")).
+					WithArgs(policy.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}).
+							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), nil, "", policy.CreatedAt, policy.UpdatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			inputUserID:  policy.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE user_id = ? AND deleted_at IS NULL FOR UPDATE;")).
+					WithArgs(policy.UserID).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPolicyDBRepository(db)
+			result, err := r.FindByUserIDAndNotDeletedForUpdate(ctx, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
 func TestPolicy_FindByIDsAndUserIDAndNotDeleted(t *testing.T) {
 	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
 	if err != nil {
@@ -531,11 +1017,11 @@ func TestPolicy_FindByIDsAndUserIDAndNotDeleted(t *testing.T) {
 			expectResult: []*entity.Policy{policy},
 			expectError:  nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, created_at, updated_at FROM policies WHERE id IN (?) AND user_id = ? AND deleted_at IS NULL;")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE id IN (?) AND user_id = ? AND deleted_at IS NULL;")).
 					WithArgs(policy.ID, policy.UserID).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "created_at", "updated_at"}).
-							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), policy.CreatedAt, policy.UpdatedAt),
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}).
+							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), nil, "", policy.CreatedAt, policy.UpdatedAt),
 					).
 					WillReturnError(nil)
 			},
@@ -547,10 +1033,10 @@ func TestPolicy_FindByIDsAndUserIDAndNotDeleted(t *testing.T) {
 			expectResult: []*entity.Policy{},
 			expectError:  nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, created_at, updated_at FROM policies WHERE id IN (?) AND user_id = ? AND deleted_at IS NULL;")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE id IN (?) AND user_id = ? AND deleted_at IS NULL;")).
 					WithArgs(policy.ID, policy.UserID).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "created_at", "updated_at"}),
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}),
 					).
 					WillReturnError(nil)
 			},
@@ -562,10 +1048,10 @@ func TestPolicy_FindByIDsAndUserIDAndNotDeleted(t *testing.T) {
 			expectResult: nil,
 			expectError:  sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, created_at, updated_at FROM policies WHERE id IN (?) AND user_id = ? AND deleted_at IS NULL;")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE id IN (?) AND user_id = ? AND deleted_at IS NULL;")).
 					WithArgs(policy.ID, policy.UserID).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "created_at", "updated_at"}),
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}),
 					).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -619,11 +1105,11 @@ func TestPolicy_FindByIDsAndNamePrefixAndUserIDAndNotDeleted(t *testing.T) {
 			expectResult: []*entity.Policy{policy},
 			expectError:  nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, created_at, updated_at FROM policies WHERE id IN (?) AND name LIKE ? AND user_id = ? AND deleted_at IS NULL;")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE id IN (?) AND name LIKE ? AND user_id = ? AND deleted_at IS NULL;")).
 					WithArgs(policy.ID, "name%", policy.UserID).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "created_at", "updated_at"}).
-							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), policy.CreatedAt, policy.UpdatedAt),
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}).
+							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), nil, "", policy.CreatedAt, policy.UpdatedAt),
 					).
 					WillReturnError(nil)
 			},
@@ -636,10 +1122,10 @@ func TestPolicy_FindByIDsAndNamePrefixAndUserIDAndNotDeleted(t *testing.T) {
 			expectResult: []*entity.Policy{},
 			expectError:  nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, created_at, updated_at FROM policies WHERE id IN (?) AND name LIKE ? AND user_id = ? AND deleted_at IS NULL;")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE id IN (?) AND name LIKE ? AND user_id = ? AND deleted_at IS NULL;")).
 					WithArgs(policy.ID, "keyword%", policy.UserID).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "created_at", "updated_at"}),
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}),
 					).
 					WillReturnError(nil)
 			},
@@ -652,10 +1138,10 @@ func TestPolicy_FindByIDsAndNamePrefixAndUserIDAndNotDeleted(t *testing.T) {
 			expectResult: nil,
 			expectError:  sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, created_at, updated_at FROM policies WHERE id IN (?) AND name LIKE ? AND user_id = ? AND deleted_at IS NULL;")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at, updated_at FROM policies WHERE id IN (?) AND name LIKE ? AND user_id = ? AND deleted_at IS NULL;")).
 					WithArgs(policy.ID, "name%", policy.UserID).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "created_at", "updated_at"}),
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}),
 					).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -685,3 +1171,123 @@ func TestPolicy_FindByIDsAndNamePrefixAndUserIDAndNotDeleted(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicy_FindUnattachedByUserIDAndNotDeleted(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	query := regexp.QuoteMeta(
+		`SELECT
+					policies.id,
+					policies.user_id,
+					policies.name,
+					policies.effect,
+					policies.service,
+					policies.path,
+					policies.methods,
+					policies.conditions,
+					policies.expression,
+					policies.valid_from,
+					policies.valid_until,
+					policies.created_at,
+					policies.updated_at
+		FROM
+					policies
+		WHERE
+					policies.user_id = ?
+					AND policies.deleted_at IS NULL
+					AND NOT EXISTS (
+						SELECT 1 FROM permissions
+							INNER JOIN agents ON permissions.agent_id = agents.id
+						WHERE permissions.policy_id = policies.id AND agents.deleted_at IS NULL
+					)
+					AND NOT EXISTS (
+						SELECT 1 FROM agent_group_policies
+							INNER JOIN agent_groups ON agent_group_policies.agent_group_id = agent_groups.id
+							INNER JOIN agent_group_members ON agent_groups.id = agent_group_members.agent_group_id
+							INNER JOIN agents ON agent_group_members.agent_id = agents.id
+						WHERE agent_group_policies.policy_id = policies.id AND agent_groups.deleted_at IS NULL AND agents.deleted_at IS NULL
+					)
+					AND NOT EXISTS (
+						SELECT 1 FROM role_policies
+							INNER JOIN roles ON role_policies.role_id = roles.id
+							INNER JOIN role_agents ON roles.id = role_agents.role_id
+							INNER JOIN agents ON role_agents.agent_id = agents.id
+						WHERE role_policies.policy_id = policies.id AND roles.deleted_at IS NULL AND agents.deleted_at IS NULL
+					);`,
+	)
+
+	tests := []struct {
+		name         string
+		inputUserID  uuid.UUID
+		expectResult []*entity.Policy
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputUserID:  policy.UserID,
+			expectResult: []*entity.Policy{policy},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(policy.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}).
+							AddRow(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, fmt.Sprintf(`["%s"]`, strings.Join(policy.Methods, ",")), nil, "", policy.CreatedAt, policy.UpdatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputUserID:  policy.UserID,
+			expectResult: []*entity.Policy{},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(policy.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "effect", "service", "path", "methods", "conditions", "expression", "created_at", "updated_at"}),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			inputUserID:  policy.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(policy.UserID).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPolicyDBRepository(db)
+			result, err := r.FindUnattachedByUserIDAndNotDeleted(ctx, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+	"holos-auth-api/internal/app/api/infrastructure/transformer"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrRequiredPolicyVersion = status.Error(http.StatusInternalServerError, "policy version is required")
+)
+
+type policyVersionDBRepository struct {
+	db *sqlx.DB
+}
+
+func NewPolicyVersionDBRepository(db *sqlx.DB) repository.PolicyVersionRepository {
+	return &policyVersionDBRepository{
+		db: db,
+	}
+}
+
+func (r *policyVersionDBRepository) Create(ctx context.Context, policyVersion *entity.PolicyVersion) error {
+	if policyVersion == nil {
+		return ErrRequiredPolicyVersion
+	}
+
+	driver := getDriver(ctx, r.db)
+	policyVersionModel, err := transformer.ToPolicyVersionModel(policyVersion)
+	if err != nil {
+		return err
+	}
+
+	_, err = driver.NamedExecContext(
+		ctx,
+		`INSERT INTO policy_versions (policy_id, version, user_id, author_id, operation, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at) VALUES (:policy_id, :version, :user_id, :author_id, :operation, :name, :effect, :service, :path, :methods, :conditions, :expression, :valid_from, :valid_until, :created_at);`,
+		policyVersionModel,
+	)
+
+	return err
+}
+
+// 削除済みのポリシーのバージョンも返す. 新しいバージョンから順に返す.
+func (r *policyVersionDBRepository) FindByPolicyIDAndUserID(ctx context.Context, policyID uuid.UUID, userID uuid.UUID) ([]*entity.PolicyVersion, error) {
+	policyVersions := []*model.PolicyVersionModel{}
+	driver := getDriver(ctx, r.db)
+
+	rows, err := driver.QueryxContext(
+		ctx,
+		`SELECT policy_id, version, user_id, author_id, operation, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at FROM policy_versions WHERE policy_id = ? AND user_id = ? ORDER BY version DESC;`,
+		policyID,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var policyVersion model.PolicyVersionModel
+		if err := rows.StructScan(&policyVersion); err != nil {
+			return nil, err
+		}
+		policyVersions = append(policyVersions, &policyVersion)
+	}
+
+	return transformer.ToPolicyVersionEntities(policyVersions)
+}
+
+func (r *policyVersionDBRepository) FindOneByPolicyIDAndVersionAndUserID(ctx context.Context, policyID uuid.UUID, version int, userID uuid.UUID) (*entity.PolicyVersion, error) {
+	var policyVersion model.PolicyVersionModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT policy_id, version, user_id, author_id, operation, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at FROM policy_versions WHERE policy_id = ? AND version = ? AND user_id = ? LIMIT 1;`,
+		policyID,
+		version,
+		userID,
+	).StructScan(&policyVersion); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToPolicyVersionEntity(&policyVersion)
+}
+
+// 並行した変更が同じ番号を採番しないよう, 呼び出し元でポリシーの行をロックしておく. 重複した場合は主キーの制約でエラーとなる.
+func (r *policyVersionDBRepository) FindOneLatestByPolicyID(ctx context.Context, policyID uuid.UUID) (*entity.PolicyVersion, error) {
+	var policyVersion model.PolicyVersionModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT policy_id, version, user_id, author_id, operation, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at FROM policy_versions WHERE policy_id = ? ORDER BY version DESC LIMIT 1 FOR UPDATE;`,
+		policyID,
+	).StructScan(&policyVersion); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToPolicyVersionEntity(&policyVersion)
+}
@@ -0,0 +1,293 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/database"
+	"holos-auth-api/test"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestPolicyVersion_Create(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyVersion := entity.NewPolicyVersion(policy, nil, entity.PolicyVersionOperationCreate, policy.UserID)
+
+	tests := []struct {
+		name               string
+		inputPolicyVersion *entity.PolicyVersion
+		expectError        error
+		setMockDB          func(sqlmock.Sqlmock)
+	}{
+		{
+			name:               "success",
+			inputPolicyVersion: policyVersion,
+			expectError:        nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO policy_versions (policy_id, version, user_id, author_id, operation, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(policyVersion.PolicyID, policyVersion.Version, policyVersion.UserID, policyVersion.AuthorID, policyVersion.Operation, policyVersion.Name, policyVersion.Effect, policyVersion.Service, policyVersion.Path, []byte(`["GET"]`), []byte(nil), policyVersion.Expression, nil, nil, policyVersion.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:               "create error",
+			inputPolicyVersion: policyVersion,
+			expectError:        sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO policy_versions (policy_id, version, user_id, author_id, operation, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(policyVersion.PolicyID, policyVersion.Version, policyVersion.UserID, policyVersion.AuthorID, policyVersion.Operation, policyVersion.Name, policyVersion.Effect, policyVersion.Service, policyVersion.Path, []byte(`["GET"]`), []byte(nil), policyVersion.Expression, nil, nil, policyVersion.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:               "no policy version",
+			inputPolicyVersion: nil,
+			expectError:        database.ErrRequiredPolicyVersion,
+			setMockDB:          func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPolicyVersionDBRepository(db)
+			if err := r.Create(ctx, tt.inputPolicyVersion); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestPolicyVersion_FindByPolicyIDAndUserID(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyVersion := entity.NewPolicyVersion(policy, nil, entity.PolicyVersionOperationCreate, policy.UserID)
+	columns := []string{"policy_id", "version", "user_id", "author_id", "operation", "name", "effect", "service", "path", "methods", "conditions", "expression", "valid_from", "valid_until", "created_at"}
+
+	tests := []struct {
+		name         string
+		expectResult []*entity.PolicyVersion
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			expectResult: []*entity.PolicyVersion{policyVersion},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT policy_id, version, user_id, author_id, operation, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at FROM policy_versions WHERE policy_id = ? AND user_id = ? ORDER BY version DESC;")).
+					WithArgs(policy.ID, policy.UserID).
+					WillReturnRows(
+						sqlmock.NewRows(columns).
+							AddRow(policyVersion.PolicyID, policyVersion.Version, policyVersion.UserID, policyVersion.AuthorID, policyVersion.Operation, policyVersion.Name, policyVersion.Effect, policyVersion.Service, policyVersion.Path, []byte(`["GET"]`), nil, policyVersion.Expression, nil, nil, policyVersion.CreatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			expectResult: []*entity.PolicyVersion{},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT policy_id, version, user_id, author_id, operation, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at FROM policy_versions WHERE policy_id = ? AND user_id = ? ORDER BY version DESC;")).
+					WithArgs(policy.ID, policy.UserID).
+					WillReturnRows(sqlmock.NewRows(columns)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT policy_id, version, user_id, author_id, operation, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at FROM policy_versions WHERE policy_id = ? AND user_id = ? ORDER BY version DESC;")).
+					WithArgs(policy.ID, policy.UserID).
+					WillReturnRows(sqlmock.NewRows(columns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPolicyVersionDBRepository(db)
+			result, err := r.FindByPolicyIDAndUserID(ctx, policy.ID, policy.UserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestPolicyVersion_FindOneByPolicyIDAndVersionAndUserID(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyVersion := entity.NewPolicyVersion(policy, nil, entity.PolicyVersionOperationCreate, policy.UserID)
+	columns := []string{"policy_id", "version", "user_id", "author_id", "operation", "name", "effect", "service", "path", "methods", "conditions", "expression", "valid_from", "valid_until", "created_at"}
+
+	tests := []struct {
+		name         string
+		expectResult *entity.PolicyVersion
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			expectResult: policyVersion,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT policy_id, version, user_id, author_id, operation, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at FROM policy_versions WHERE policy_id = ? AND version = ? AND user_id = ? LIMIT 1;")).
+					WithArgs(policy.ID, 1, policy.UserID).
+					WillReturnRows(
+						sqlmock.NewRows(columns).
+							AddRow(policyVersion.PolicyID, policyVersion.Version, policyVersion.UserID, policyVersion.AuthorID, policyVersion.Operation, policyVersion.Name, policyVersion.Effect, policyVersion.Service, policyVersion.Path, []byte(`["GET"]`), nil, policyVersion.Expression, nil, nil, policyVersion.CreatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT policy_id, version, user_id, author_id, operation, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at FROM policy_versions WHERE policy_id = ? AND version = ? AND user_id = ? LIMIT 1;")).
+					WithArgs(policy.ID, 1, policy.UserID).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT policy_id, version, user_id, author_id, operation, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at FROM policy_versions WHERE policy_id = ? AND version = ? AND user_id = ? LIMIT 1;")).
+					WithArgs(policy.ID, 1, policy.UserID).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPolicyVersionDBRepository(db)
+			result, err := r.FindOneByPolicyIDAndVersionAndUserID(ctx, policy.ID, 1, policy.UserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestPolicyVersion_FindOneLatestByPolicyID(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyVersion := entity.NewPolicyVersion(policy, nil, entity.PolicyVersionOperationCreate, policy.UserID)
+	columns := []string{"policy_id", "version", "user_id", "author_id", "operation", "name", "effect", "service", "path", "methods", "conditions", "expression", "valid_from", "valid_until", "created_at"}
+
+	tests := []struct {
+		name         string
+		expectResult *entity.PolicyVersion
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			expectResult: policyVersion,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT policy_id, version, user_id, author_id, operation, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at FROM policy_versions WHERE policy_id = ? ORDER BY version DESC LIMIT 1 FOR UPDATE;")).
+					WithArgs(policy.ID).
+					WillReturnRows(
+						sqlmock.NewRows(columns).
+							AddRow(policyVersion.PolicyID, policyVersion.Version, policyVersion.UserID, policyVersion.AuthorID, policyVersion.Operation, policyVersion.Name, policyVersion.Effect, policyVersion.Service, policyVersion.Path, []byte(`["GET"]`), nil, policyVersion.Expression, nil, nil, policyVersion.CreatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT policy_id, version, user_id, author_id, operation, name, effect, service, path, methods, conditions, expression, valid_from, valid_until, created_at FROM policy_versions WHERE policy_id = ? ORDER BY version DESC LIMIT 1 FOR UPDATE;")).
+					WithArgs(policy.ID).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewPolicyVersionDBRepository(db)
+			result, err := r.FindOneLatestByPolicyID(ctx, policy.ID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
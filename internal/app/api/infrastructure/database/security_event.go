@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+	"holos-auth-api/internal/app/api/infrastructure/transformer"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrRequiredSecurityEvent = status.Error(http.StatusInternalServerError, "security event is required")
+)
+
+type securityEventDBRepository struct {
+	db *sqlx.DB
+}
+
+func NewSecurityEventDBRepository(db *sqlx.DB) repository.SecurityEventRepository {
+	return &securityEventDBRepository{
+		db: db,
+	}
+}
+
+func (r *securityEventDBRepository) Create(ctx context.Context, securityEvent *entity.SecurityEvent) error {
+	if securityEvent == nil {
+		return ErrRequiredSecurityEvent
+	}
+
+	driver := getDriver(ctx, r.db)
+	securityEventModel := transformer.ToSecurityEventModel(securityEvent)
+
+	_, err := driver.NamedExecContext(
+		ctx,
+		`INSERT INTO security_events (id, user_id, type, detail, occurred_at) VALUES (:id, :user_id, :type, :detail, :occurred_at);`,
+		securityEventModel,
+	)
+
+	return err
+}
+
+func (r *securityEventDBRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.SecurityEvent, error) {
+	securityEvents := []*model.SecurityEventModel{}
+	driver := getDriver(ctx, r.db)
+
+	rows, err := driver.QueryxContext(
+		ctx,
+		`SELECT id, user_id, type, detail, occurred_at FROM security_events WHERE user_id = ? ORDER BY occurred_at DESC;`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var securityEvent model.SecurityEventModel
+		if err := rows.StructScan(&securityEvent); err != nil {
+			return nil, err
+		}
+		securityEvents = append(securityEvents, &securityEvent)
+	}
+
+	return transformer.ToSecurityEventEntities(securityEvents), nil
+}
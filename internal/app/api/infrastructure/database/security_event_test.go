@@ -0,0 +1,160 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/database"
+	"holos-auth-api/test"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestSecurityEvent_Create(t *testing.T) {
+	securityEvent, err := entity.NewSecurityEvent(uuid.New(), entity.SecurityEventTypeUserTokenLeaked, "reporter=scanner")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name               string
+		inputSecurityEvent *entity.SecurityEvent
+		expectError        error
+		setMockDB          func(sqlmock.Sqlmock)
+	}{
+		{
+			name:               "success",
+			inputSecurityEvent: securityEvent,
+			expectError:        nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO security_events (id, user_id, type, detail, occurred_at) VALUES (?, ?, ?, ?, ?);")).
+					WithArgs(securityEvent.ID, securityEvent.UserID, securityEvent.Type, securityEvent.Detail, securityEvent.OccurredAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:               "create error",
+			inputSecurityEvent: securityEvent,
+			expectError:        sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO security_events (id, user_id, type, detail, occurred_at) VALUES (?, ?, ?, ?, ?);")).
+					WithArgs(securityEvent.ID, securityEvent.UserID, securityEvent.Type, securityEvent.Detail, securityEvent.OccurredAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:               "no security event",
+			inputSecurityEvent: nil,
+			expectError:        database.ErrRequiredSecurityEvent,
+			setMockDB:          func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewSecurityEventDBRepository(db)
+			if err := r.Create(ctx, tt.inputSecurityEvent); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestSecurityEvent_FindByUserID(t *testing.T) {
+	securityEvent, err := entity.NewSecurityEvent(uuid.New(), entity.SecurityEventTypeUserTokenLeaked, "reporter=scanner")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name         string
+		inputUserID  uuid.UUID
+		expectResult []*entity.SecurityEvent
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			inputUserID:  securityEvent.UserID,
+			expectResult: []*entity.SecurityEvent{securityEvent},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, type, detail, occurred_at FROM security_events WHERE user_id = ? ORDER BY occurred_at DESC;")).
+					WithArgs(securityEvent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "type", "detail", "occurred_at"}).
+							AddRow(securityEvent.ID, securityEvent.UserID, securityEvent.Type, securityEvent.Detail, securityEvent.OccurredAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			inputUserID:  securityEvent.UserID,
+			expectResult: []*entity.SecurityEvent{},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, type, detail, occurred_at FROM security_events WHERE user_id = ? ORDER BY occurred_at DESC;")).
+					WithArgs(securityEvent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "type", "detail", "occurred_at"}),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			inputUserID:  securityEvent.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, type, detail, occurred_at FROM security_events WHERE user_id = ? ORDER BY occurred_at DESC;")).
+					WithArgs(securityEvent.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "type", "detail", "occurred_at"}),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewSecurityEventDBRepository(db)
+			result, err := r.FindByUserID(ctx, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+	"holos-auth-api/internal/app/api/infrastructure/transformer"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrRequiredService = status.Error(http.StatusInternalServerError, "service is required")
+)
+
+type serviceDBRepository struct {
+	db *sqlx.DB
+}
+
+func NewServiceDBRepository(db *sqlx.DB) repository.ServiceRepository {
+	return &serviceDBRepository{
+		db: db,
+	}
+}
+
+func (r *serviceDBRepository) Create(ctx context.Context, service *entity.Service) error {
+	if service == nil {
+		return ErrRequiredService
+	}
+
+	driver := getDriver(ctx, r.db)
+	serviceModel, err := transformer.ToServiceModel(service)
+	if err != nil {
+		return err
+	}
+
+	_, err = driver.NamedExecContext(
+		ctx,
+		`INSERT INTO services (id, user_id, name, methods, path_schema, created_at, updated_at) VALUES (:id, :user_id, :name, :methods, :path_schema, :created_at, :updated_at);`,
+		serviceModel,
+	)
+
+	return err
+}
+
+func (r *serviceDBRepository) Update(ctx context.Context, service *entity.Service) error {
+	if service == nil {
+		return ErrRequiredService
+	}
+
+	driver := getDriver(ctx, r.db)
+	serviceModel, err := transformer.ToServiceModel(service)
+	if err != nil {
+		return err
+	}
+
+	_, err = driver.NamedExecContext(
+		ctx,
+		`UPDATE services SET user_id = :user_id, name = :name, methods = :methods, path_schema = :path_schema, updated_at = :updated_at WHERE id = :id AND deleted_at IS NULL LIMIT 1;`,
+		serviceModel,
+	)
+
+	return err
+}
+
+func (r *serviceDBRepository) Delete(ctx context.Context, service *entity.Service) error {
+	if service == nil {
+		return ErrRequiredService
+	}
+
+	driver := getDriver(ctx, r.db)
+	serviceModel, err := transformer.ToServiceModel(service)
+	if err != nil {
+		return err
+	}
+
+	_, err = driver.NamedExecContext(
+		ctx,
+		`UPDATE services SET updated_at = updated_at, deleted_at = NOW(6) WHERE id = :id AND deleted_at IS NULL LIMIT 1;`,
+		serviceModel,
+	)
+
+	return err
+}
+
+func (r *serviceDBRepository) FindOneByIDAndUserIDAndNotDeleted(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.Service, error) {
+	var service model.ServiceModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT id, user_id, name, methods, path_schema, created_at, updated_at FROM services WHERE id = ? AND user_id = ? AND deleted_at IS NULL LIMIT 1;`,
+		id,
+		userID,
+	).StructScan(&service); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToServiceEntity(&service)
+}
+
+func (r *serviceDBRepository) FindOneByNameAndUserIDAndNotDeleted(ctx context.Context, name string, userID uuid.UUID) (*entity.Service, error) {
+	var service model.ServiceModel
+	driver := getDriver(ctx, r.db)
+
+	if err := driver.QueryRowxContext(
+		ctx,
+		`SELECT id, user_id, name, methods, path_schema, created_at, updated_at FROM services WHERE name = ? AND user_id = ? AND deleted_at IS NULL LIMIT 1;`,
+		name,
+		userID,
+	).StructScan(&service); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transformer.ToServiceEntity(&service)
+}
+
+func (r *serviceDBRepository) FindByNamePrefixAndUserIDAndNotDeleted(ctx context.Context, keyword string, userID uuid.UUID) ([]*entity.Service, error) {
+	services := []*model.ServiceModel{}
+	driver := getDriver(ctx, r.db)
+
+	rows, err := driver.QueryxContext(
+		ctx,
+		`SELECT id, user_id, name, methods, path_schema, created_at, updated_at FROM services WHERE name LIKE ? AND user_id = ? AND deleted_at IS NULL;`,
+		keyword+"%",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var service model.ServiceModel
+		if err := rows.StructScan(&service); err != nil {
+			return nil, err
+		}
+		services = append(services, &service)
+	}
+
+	return transformer.ToServiceEntities(services)
+}
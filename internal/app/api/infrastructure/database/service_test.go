@@ -0,0 +1,221 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/database"
+	"holos-auth-api/test"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+)
+
+func TestService_Create(t *testing.T) {
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET", "PUT"}, `/files(/.*)?`)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name         string
+		inputService *entity.Service
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "success",
+			inputService: service,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO services (id, user_id, name, methods, path_schema, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(service.ID, service.UserID, service.Name, []byte(`["GET","PUT"]`), service.PathSchema, service.CreatedAt, service.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "create error",
+			inputService: service,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO services (id, user_id, name, methods, path_schema, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(service.ID, service.UserID, service.Name, []byte(`["GET","PUT"]`), service.PathSchema, service.CreatedAt, service.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:         "no service",
+			inputService: nil,
+			expectError:  database.ErrRequiredService,
+			setMockDB:    func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewServiceDBRepository(db)
+			if err := r.Create(ctx, tt.inputService); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name         string
+		inputService *entity.Service
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "success",
+			inputService: service,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE services SET user_id = ?, name = ?, methods = ?, path_schema = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
+					WithArgs(service.UserID, service.Name, []byte(`["GET"]`), service.PathSchema, service.UpdatedAt, service.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "update error",
+			inputService: service,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE services SET user_id = ?, name = ?, methods = ?, path_schema = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL LIMIT 1;")).
+					WithArgs(service.UserID, service.Name, []byte(`["GET"]`), service.PathSchema, service.UpdatedAt, service.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:         "no service",
+			inputService: nil,
+			expectError:  database.ErrRequiredService,
+			setMockDB:    func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewServiceDBRepository(db)
+			if err := r.Update(ctx, tt.inputService); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
+
+func TestService_FindOneByNameAndUserIDAndNotDeleted(t *testing.T) {
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET", "PUT"}, `/files(/.*)?`)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	query := "SELECT id, user_id, name, methods, path_schema, created_at, updated_at FROM services WHERE name = ? AND user_id = ? AND deleted_at IS NULL LIMIT 1;"
+
+	tests := []struct {
+		name         string
+		expectResult *entity.Service
+		expectError  error
+		setMockDB    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:         "found",
+			expectResult: service,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(service.Name, service.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "methods", "path_schema", "created_at", "updated_at"}).
+							AddRow(service.ID, service.UserID, service.Name, []byte(`["GET","PUT"]`), service.PathSchema, service.CreatedAt, service.UpdatedAt),
+					).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			expectResult: nil,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(service.Name, service.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "methods", "path_schema", "created_at", "updated_at"}),
+					).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(service.Name, service.UserID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "name", "methods", "path_schema", "created_at", "updated_at"}),
+					).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := test.NewMockDB(t)
+			defer db.Close()
+
+			ctx := context.Background()
+
+			tt.setMockDB(mock)
+
+			r := database.NewServiceDBRepository(db)
+			result, err := r.FindOneByNameAndUserIDAndNotDeleted(ctx, service.Name, service.UserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult, cmpopts.IgnoreUnexported(entity.Service{})); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
@@ -36,6 +36,7 @@ func (to *transactionObject) Transaction(ctx context.Context, fn func(context.Co
 	}()
 
 	ctx = context.WithValue(ctx, transactionKey{}, tx)
+	ctx, afterCommit := domain.WithAfterCommit(ctx)
 
 	if err := fn(ctx); err != nil {
 		if err := tx.Rollback(); err != nil {
@@ -46,7 +47,9 @@ func (to *transactionObject) Transaction(ctx context.Context, fn func(context.Co
 
 	if err := tx.Commit(); err != nil {
 		log.Println(err.Error())
+		return nil
 	}
+	afterCommit()
 
 	return nil
 }
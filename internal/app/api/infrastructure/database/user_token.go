@@ -38,7 +38,7 @@ func (r *userTokenDBRepository) Save(ctx context.Context, userToken *entity.User
 
 	_, err := driver.NamedExecContext(
 		ctx,
-		`REPLACE user_tokens (user_id, token, expires_at) VALUES (:user_id, :token, :expires_at);`,
+		`REPLACE user_tokens (user_id, token, authenticated_at, expires_at) VALUES (:user_id, :token, :authenticated_at, :expires_at);`,
 		userTokenModel,
 	)
 
@@ -68,7 +68,7 @@ func (r *userTokenDBRepository) FindOneByTokenAndNotExpired(ctx context.Context,
 
 	if err := driver.QueryRowxContext(
 		ctx,
-		`SELECT user_id, token, expires_at FROM user_tokens WHERE token = ? AND NOW(6) < expires_at LIMIT 1;`,
+		`SELECT user_id, token, authenticated_at, expires_at FROM user_tokens WHERE token = ? AND NOW(6) < expires_at LIMIT 1;`,
 		token,
 	).StructScan(&userToken); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -32,8 +32,8 @@ func TestUserToken_Save(t *testing.T) {
 			inputUserToken: userToken,
 			expectError:    nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta("REPLACE user_tokens (user_id, token, expires_at) VALUES (?, ?, ?);")).
-					WithArgs(userToken.UserID, userToken.Token, userToken.ExpiresAt).
+				mock.ExpectExec(regexp.QuoteMeta("REPLACE user_tokens (user_id, token, authenticated_at, expires_at) VALUES (?, ?, ?, ?);")).
+					WithArgs(userToken.UserID, userToken.Token, userToken.AuthenticatedAt, userToken.ExpiresAt).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
 			},
@@ -43,8 +43,8 @@ func TestUserToken_Save(t *testing.T) {
 			inputUserToken: userToken,
 			expectError:    sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta("REPLACE user_tokens (user_id, token, expires_at) VALUES (?, ?, ?);")).
-					WithArgs(userToken.UserID, userToken.Token, userToken.ExpiresAt).
+				mock.ExpectExec(regexp.QuoteMeta("REPLACE user_tokens (user_id, token, authenticated_at, expires_at) VALUES (?, ?, ?, ?);")).
+					WithArgs(userToken.UserID, userToken.Token, userToken.AuthenticatedAt, userToken.ExpiresAt).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -158,11 +158,11 @@ func TestUserToken_FindOneByTokenAndNotExpired(t *testing.T) {
 			expectResult: userToken,
 			expectError:  nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT user_id, token, expires_at FROM user_tokens WHERE token = ? AND NOW(6) < expires_at LIMIT 1;")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT user_id, token, authenticated_at, expires_at FROM user_tokens WHERE token = ? AND NOW(6) < expires_at LIMIT 1;")).
 					WithArgs(userToken.Token).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"user_id", "token", "expires_at"}).
-							AddRow(userToken.UserID, userToken.Token, userToken.ExpiresAt),
+						sqlmock.NewRows([]string{"user_id", "token", "authenticated_at", "expires_at"}).
+							AddRow(userToken.UserID, userToken.Token, userToken.AuthenticatedAt, userToken.ExpiresAt),
 					).
 					WillReturnError(nil)
 			},
@@ -173,11 +173,11 @@ func TestUserToken_FindOneByTokenAndNotExpired(t *testing.T) {
 			expectResult: nil,
 			expectError:  nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT user_id, token, expires_at FROM user_tokens WHERE token = ? AND NOW(6) < expires_at LIMIT 1;")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT user_id, token, authenticated_at, expires_at FROM user_tokens WHERE token = ? AND NOW(6) < expires_at LIMIT 1;")).
 					WithArgs(userToken.Token).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"user_id", "token", "expires_at"}).
-							AddRow(userToken.UserID, userToken.Token, userToken.ExpiresAt),
+						sqlmock.NewRows([]string{"user_id", "token", "authenticated_at", "expires_at"}).
+							AddRow(userToken.UserID, userToken.Token, userToken.AuthenticatedAt, userToken.ExpiresAt),
 					).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -188,11 +188,11 @@ func TestUserToken_FindOneByTokenAndNotExpired(t *testing.T) {
 			expectResult: nil,
 			expectError:  sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT user_id, token, expires_at FROM user_tokens WHERE token = ? AND NOW(6) < expires_at LIMIT 1;")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT user_id, token, authenticated_at, expires_at FROM user_tokens WHERE token = ? AND NOW(6) < expires_at LIMIT 1;")).
 					WithArgs(userToken.Token).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"user_id", "token", "expires_at"}).
-							AddRow(userToken.UserID, userToken.Token, userToken.ExpiresAt),
+						sqlmock.NewRows([]string{"user_id", "token", "authenticated_at", "expires_at"}).
+							AddRow(userToken.UserID, userToken.Token, userToken.AuthenticatedAt, userToken.ExpiresAt),
 					).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"log"
+)
+
+// ポリシー, グループ, ロールの変更は複数のエージェントに影響するため, 保存したトランザクションのコミット後にコンパイル済みのポリシーをすべて破棄する.
+// エージェントへの紐付けの変更はPolicyMatcherのKeyが変わるため, 破棄しなくても古いものは使われない.
+// 期間を指定した紐付けも有効期間内のものだけを読み込むため, 期間の開始と終了でKeyが変わる.
+// ユーザーの名前とエージェントの属性は${name}の置き換えに使うため, 変更した場合も破棄する.
+
+// コミット前に破棄すると, コミットまでの間に他のリクエストが変更前の内容で再びコンパイルして保持するため, コミット後に破棄する.
+func invalidateAfterCommit(ctx context.Context, policyMatcherRepository repository.PolicyMatcherRepository) {
+	domain.AfterCommit(ctx, func() {
+		if err := policyMatcherRepository.DeleteAll(ctx); err != nil {
+			log.Println(err.Error())
+		}
+	})
+}
+
+type policyInvalidationRepository struct {
+	repository.PolicyRepository
+	policyMatcherRepository repository.PolicyMatcherRepository
+}
+
+func NewPolicyInvalidationRepository(policyRepository repository.PolicyRepository, policyMatcherRepository repository.PolicyMatcherRepository) repository.PolicyRepository {
+	return &policyInvalidationRepository{
+		PolicyRepository:        policyRepository,
+		policyMatcherRepository: policyMatcherRepository,
+	}
+}
+
+func (r *policyInvalidationRepository) Create(ctx context.Context, policy *entity.Policy) error {
+	if err := r.PolicyRepository.Create(ctx, policy); err != nil {
+		return err
+	}
+	invalidateAfterCommit(ctx, r.policyMatcherRepository)
+	return nil
+}
+
+func (r *policyInvalidationRepository) Update(ctx context.Context, policy *entity.Policy) error {
+	if err := r.PolicyRepository.Update(ctx, policy); err != nil {
+		return err
+	}
+	invalidateAfterCommit(ctx, r.policyMatcherRepository)
+	return nil
+}
+
+func (r *policyInvalidationRepository) Delete(ctx context.Context, policy *entity.Policy) error {
+	if err := r.PolicyRepository.Delete(ctx, policy); err != nil {
+		return err
+	}
+	invalidateAfterCommit(ctx, r.policyMatcherRepository)
+	return nil
+}
+
+type policyBundleInvalidationRepository struct {
+	repository.PolicyBundleRepository
+	policyMatcherRepository repository.PolicyMatcherRepository
+}
+
+func NewPolicyBundleInvalidationRepository(policyBundleRepository repository.PolicyBundleRepository, policyMatcherRepository repository.PolicyMatcherRepository) repository.PolicyBundleRepository {
+	return &policyBundleInvalidationRepository{
+		PolicyBundleRepository:  policyBundleRepository,
+		policyMatcherRepository: policyMatcherRepository,
+	}
+}
+
+func (r *policyBundleInvalidationRepository) Update(ctx context.Context, bundle *entity.PolicyBundle) error {
+	if err := r.PolicyBundleRepository.Update(ctx, bundle); err != nil {
+		return err
+	}
+	invalidateAfterCommit(ctx, r.policyMatcherRepository)
+	return nil
+}
+
+func (r *policyBundleInvalidationRepository) Delete(ctx context.Context, bundle *entity.PolicyBundle) error {
+	if err := r.PolicyBundleRepository.Delete(ctx, bundle); err != nil {
+		return err
+	}
+	invalidateAfterCommit(ctx, r.policyMatcherRepository)
+	return nil
+}
+
+type agentAttributesInvalidationRepository struct {
+	repository.AgentAttributesRepository
+	policyMatcherRepository repository.PolicyMatcherRepository
+}
+
+func NewAgentAttributesInvalidationRepository(agentAttributesRepository repository.AgentAttributesRepository, policyMatcherRepository repository.PolicyMatcherRepository) repository.AgentAttributesRepository {
+	return &agentAttributesInvalidationRepository{
+		AgentAttributesRepository: agentAttributesRepository,
+		policyMatcherRepository:   policyMatcherRepository,
+	}
+}
+
+func (r *agentAttributesInvalidationRepository) Save(ctx context.Context, agentAttributes *entity.AgentAttributes) error {
+	if err := r.AgentAttributesRepository.Save(ctx, agentAttributes); err != nil {
+		return err
+	}
+	invalidateAfterCommit(ctx, r.policyMatcherRepository)
+	return nil
+}
+
+type userInvalidationRepository struct {
+	repository.UserRepository
+	policyMatcherRepository repository.PolicyMatcherRepository
+}
+
+func NewUserInvalidationRepository(userRepository repository.UserRepository, policyMatcherRepository repository.PolicyMatcherRepository) repository.UserRepository {
+	return &userInvalidationRepository{
+		UserRepository:          userRepository,
+		policyMatcherRepository: policyMatcherRepository,
+	}
+}
+
+func (r *userInvalidationRepository) Update(ctx context.Context, user *entity.User) error {
+	if err := r.UserRepository.Update(ctx, user); err != nil {
+		return err
+	}
+	invalidateAfterCommit(ctx, r.policyMatcherRepository)
+	return nil
+}
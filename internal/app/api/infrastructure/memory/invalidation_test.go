@@ -0,0 +1,281 @@
+package memory_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/memory"
+	mockRepository "holos-auth-api/test/mock/domain/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func TestPolicyInvalidation_Update(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(agent.UserID, "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                    string
+		expectError             error
+		expectDeleted           bool
+		setMockPolicyRepository func(context.Context, *mockRepository.MockPolicyRepository)
+	}{
+		{
+			name:          "success",
+			expectError:   nil,
+			expectDeleted: true,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					Update(ctx, policy).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "update error",
+			expectError:   sql.ErrConnDone,
+			expectDeleted: false,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					Update(ctx, policy).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockPolicyRepository(ctx, pr)
+
+			mr := memory.NewPolicyMatcherMemoryRepository()
+			policyMatcher := entity.NewPolicyMatcher(entity.NewPrincipal(agent, nil, nil), []*entity.Policy{policy}, time.Now())
+			if err := mr.Save(ctx, policyMatcher); err != nil {
+				t.Error(err.Error())
+			}
+
+			r := memory.NewPolicyInvalidationRepository(pr, mr)
+			if err := r.Update(ctx, policy); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			result, err := mr.FindOneByKeyAndNotExpired(ctx, policyMatcher.Key)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			if deleted := result == nil; deleted != tt.expectDeleted {
+				t.Errorf("deleted: expect %v but got %v", tt.expectDeleted, deleted)
+			}
+		})
+	}
+}
+
+func TestAgentAttributesInvalidation_Save(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentAttributes, err := entity.NewAgentAttributes(agent.ID, map[string]string{"team": "t1"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                             string
+		expectError                      error
+		expectDeleted                    bool
+		setMockAgentAttributesRepository func(context.Context, *mockRepository.MockAgentAttributesRepository)
+	}{
+		{
+			name:          "success",
+			expectError:   nil,
+			expectDeleted: true,
+			setMockAgentAttributesRepository: func(ctx context.Context, ar *mockRepository.MockAgentAttributesRepository) {
+				ar.EXPECT().
+					Save(ctx, agentAttributes).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "save error",
+			expectError:   sql.ErrConnDone,
+			expectDeleted: false,
+			setMockAgentAttributesRepository: func(ctx context.Context, ar *mockRepository.MockAgentAttributesRepository) {
+				ar.EXPECT().
+					Save(ctx, agentAttributes).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ar := mockRepository.NewMockAgentAttributesRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockAgentAttributesRepository(ctx, ar)
+
+			mr := memory.NewPolicyMatcherMemoryRepository()
+			policyMatcher := entity.NewPolicyMatcher(entity.NewPrincipal(agent, nil, nil), []*entity.Policy{}, time.Now())
+			if err := mr.Save(ctx, policyMatcher); err != nil {
+				t.Error(err.Error())
+			}
+
+			r := memory.NewAgentAttributesInvalidationRepository(ar, mr)
+			if err := r.Save(ctx, agentAttributes); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			result, err := mr.FindOneByKeyAndNotExpired(ctx, policyMatcher.Key)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			if deleted := result == nil; deleted != tt.expectDeleted {
+				t.Errorf("deleted: expect %v but got %v", tt.expectDeleted, deleted)
+			}
+		})
+	}
+}
+
+func TestUserInvalidation_Update(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	user := entity.RestoreUser(agent.UserID, "alice", "", time.Now(), time.Now())
+
+	tests := []struct {
+		name                  string
+		expectError           error
+		expectDeleted         bool
+		setMockUserRepository func(context.Context, *mockRepository.MockUserRepository)
+	}{
+		{
+			name:          "success",
+			expectError:   nil,
+			expectDeleted: true,
+			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {
+				ur.EXPECT().
+					Update(ctx, user).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "update error",
+			expectError:   sql.ErrConnDone,
+			expectDeleted: false,
+			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {
+				ur.EXPECT().
+					Update(ctx, user).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ur := mockRepository.NewMockUserRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockUserRepository(ctx, ur)
+
+			mr := memory.NewPolicyMatcherMemoryRepository()
+			policyMatcher := entity.NewPolicyMatcher(entity.NewPrincipal(agent, user, nil), []*entity.Policy{}, time.Now())
+			if err := mr.Save(ctx, policyMatcher); err != nil {
+				t.Error(err.Error())
+			}
+
+			r := memory.NewUserInvalidationRepository(ur, mr)
+			if err := r.Update(ctx, user); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			result, err := mr.FindOneByKeyAndNotExpired(ctx, policyMatcher.Key)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			if deleted := result == nil; deleted != tt.expectDeleted {
+				t.Errorf("deleted: expect %v but got %v", tt.expectDeleted, deleted)
+			}
+		})
+	}
+}
+
+func TestPolicyInvalidation_UpdateInTransaction(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(agent.UserID, "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, afterCommit := domain.WithAfterCommit(context.Background())
+
+	pr := mockRepository.NewMockPolicyRepository(ctrl)
+	pr.EXPECT().
+		Update(ctx, policy).
+		Return(nil).
+		Times(1)
+
+	mr := memory.NewPolicyMatcherMemoryRepository()
+	policyMatcher := entity.NewPolicyMatcher(entity.NewPrincipal(agent, nil, nil), []*entity.Policy{policy}, time.Now())
+	if err := mr.Save(ctx, policyMatcher); err != nil {
+		t.Error(err.Error())
+	}
+
+	r := memory.NewPolicyInvalidationRepository(pr, mr)
+	if err := r.Update(ctx, policy); err != nil {
+		t.Error(err.Error())
+	}
+
+	// コミットするまでは破棄しない.
+	result, err := mr.FindOneByKeyAndNotExpired(ctx, policyMatcher.Key)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if result == nil {
+		t.Error("deleted before commit")
+	}
+
+	afterCommit()
+
+	result, err = mr.FindOneByKeyAndNotExpired(ctx, policyMatcher.Key)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if result != nil {
+		t.Error("not deleted after commit")
+	}
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	ErrRequiredPolicyMatcher = status.Error(http.StatusInternalServerError, "policy matcher is required")
+)
+
+// 保持するコンパイル済みのポリシーの上限. 超えた場合は期限切れのものを破棄し, それでも超える場合はすべて破棄する.
+const policyMatcherMaxSize = 10000
+
+type policyMatcherMemoryRepository struct {
+	mu             sync.RWMutex
+	policyMatchers map[string]*entity.PolicyMatcher
+}
+
+// コンパイル済みのポリシーをプロセス内に保持する. 複数のインスタンス間では共有しない.
+func NewPolicyMatcherMemoryRepository() repository.PolicyMatcherRepository {
+	return &policyMatcherMemoryRepository{
+		policyMatchers: map[string]*entity.PolicyMatcher{},
+	}
+}
+
+func (r *policyMatcherMemoryRepository) Save(ctx context.Context, policyMatcher *entity.PolicyMatcher) error {
+	if policyMatcher == nil {
+		return ErrRequiredPolicyMatcher
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if policyMatcherMaxSize <= len(r.policyMatchers) {
+		now := time.Now()
+		for key, cached := range r.policyMatchers {
+			if cached.IsExpired(now) {
+				delete(r.policyMatchers, key)
+			}
+		}
+		if policyMatcherMaxSize <= len(r.policyMatchers) {
+			clear(r.policyMatchers)
+		}
+	}
+	r.policyMatchers[policyMatcher.Key] = policyMatcher
+
+	return nil
+}
+
+func (r *policyMatcherMemoryRepository) DeleteAll(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clear(r.policyMatchers)
+
+	return nil
+}
+
+func (r *policyMatcherMemoryRepository) FindOneByKeyAndNotExpired(ctx context.Context, key string) (*entity.PolicyMatcher, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	policyMatcher, ok := r.policyMatchers[key]
+	if !ok || policyMatcher.IsExpired(time.Now()) {
+		return nil, nil
+	}
+
+	return policyMatcher, nil
+}
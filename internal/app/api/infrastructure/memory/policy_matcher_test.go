@@ -0,0 +1,98 @@
+package memory_test
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/memory"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPolicyMatcher_FindOneByKeyAndNotExpired(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyMatcher := entity.NewPolicyMatcher(entity.NewPrincipal(agent, nil, nil), []*entity.Policy{}, time.Now())
+	expiredPolicyMatcher := entity.NewPolicyMatcher(entity.NewPrincipal(agent, nil, nil), []*entity.Policy{}, time.Now().Add(-entity.PolicyMatcherTTL))
+
+	tests := []struct {
+		name         string
+		inputSaved   *entity.PolicyMatcher
+		inputKey     string
+		expectResult *entity.PolicyMatcher
+	}{
+		{
+			name:         "found",
+			inputSaved:   policyMatcher,
+			inputKey:     policyMatcher.Key,
+			expectResult: policyMatcher,
+		},
+		{
+			name:         "not found",
+			inputSaved:   policyMatcher,
+			inputKey:     "other",
+			expectResult: nil,
+		},
+		{
+			name:         "expired",
+			inputSaved:   expiredPolicyMatcher,
+			inputKey:     expiredPolicyMatcher.Key,
+			expectResult: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			r := memory.NewPolicyMatcherMemoryRepository()
+			if err := r.Save(ctx, tt.inputSaved); err != nil {
+				t.Error(err.Error())
+			}
+
+			result, err := r.FindOneByKeyAndNotExpired(ctx, tt.inputKey)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			if result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestPolicyMatcher_Save(t *testing.T) {
+	ctx := context.Background()
+	r := memory.NewPolicyMatcherMemoryRepository()
+
+	if err := r.Save(ctx, nil); err != memory.ErrRequiredPolicyMatcher {
+		t.Errorf("\nexpect: %v\ngot: %v", memory.ErrRequiredPolicyMatcher, err)
+	}
+}
+
+func TestPolicyMatcher_DeleteAll(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyMatcher := entity.NewPolicyMatcher(entity.NewPrincipal(agent, nil, nil), []*entity.Policy{}, time.Now())
+
+	ctx := context.Background()
+	r := memory.NewPolicyMatcherMemoryRepository()
+	if err := r.Save(ctx, policyMatcher); err != nil {
+		t.Error(err.Error())
+	}
+	if err := r.DeleteAll(ctx); err != nil {
+		t.Error(err.Error())
+	}
+
+	result, err := r.FindOneByKeyAndNotExpired(ctx, policyMatcher.Key)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if result != nil {
+		t.Errorf("\nexpect: %v\ngot: %v", nil, result)
+	}
+}
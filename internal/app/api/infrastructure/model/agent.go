@@ -7,10 +7,14 @@ import (
 )
 
 type AgentModel struct {
-	ID        uuid.UUID `db:"id"`
-	UserID    uuid.UUID `db:"user_id"`
-	Name      string    `db:"name"`
-	Policies  *string   `db:"policies"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID                          uuid.UUID  `db:"id"`
+	UserID                      uuid.UUID  `db:"user_id"`
+	Name                        string     `db:"name"`
+	Policies                    *string    `db:"policies"`
+	TemporaryPolicies           *string    `db:"temporary_policies"`
+	TemporaryPoliciesValidUntil *time.Time `db:"temporary_policies_valid_until"`
+	Groups                      *string    `db:"agent_groups"`
+	Roles                       *string    `db:"roles"`
+	CreatedAt                   time.Time  `db:"created_at"`
+	UpdatedAt                   time.Time  `db:"updated_at"`
 }
@@ -0,0 +1,13 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AgentAttributesModel struct {
+	AgentID    uuid.UUID `db:"agent_id"`
+	Attributes []byte    `db:"attributes"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
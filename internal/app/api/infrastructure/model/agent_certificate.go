@@ -0,0 +1,14 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AgentCertificateModel struct {
+	AgentID     uuid.UUID `db:"agent_id"`
+	Fingerprint *string   `db:"fingerprint"`
+	Subject     *string   `db:"subject"`
+	BoundAt     time.Time `db:"bound_at"`
+}
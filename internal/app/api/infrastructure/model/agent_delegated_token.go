@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AgentDelegatedTokenModel struct {
+	AgentID     uuid.UUID `db:"agent_id"`
+	ParentToken string    `db:"parent_token"`
+	Token       string    `db:"token"`
+	Policies    []byte    `db:"policies"`
+	ExpiresAt   time.Time `db:"expires_at"`
+	CreatedAt   time.Time `db:"created_at"`
+}
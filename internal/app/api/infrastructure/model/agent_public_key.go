@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AgentPublicKeyModel struct {
+	ID        uuid.UUID `db:"id"`
+	AgentID   uuid.UUID `db:"agent_id"`
+	Algorithm string    `db:"algorithm"`
+	PublicKey string    `db:"public_key"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AgentSecretModel struct {
+	AgentID     uuid.UUID `db:"agent_id"`
+	KeyID       string    `db:"key_id"`
+	Secret      string    `db:"secret"`
+	GeneratedAt time.Time `db:"generated_at"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AgentSignatureNonceModel struct {
+	AgentID   uuid.UUID `db:"agent_id"`
+	Nonce     string    `db:"nonce"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PermissionModel struct {
+	AgentID    uuid.UUID  `db:"agent_id"`
+	PolicyID   uuid.UUID  `db:"policy_id"`
+	UserID     uuid.UUID  `db:"user_id"`
+	ValidFrom  *time.Time `db:"valid_from"`
+	ValidUntil *time.Time `db:"valid_until"`
+}
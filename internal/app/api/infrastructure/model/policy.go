@@ -7,14 +7,30 @@ import (
 )
 
 type PolicyModel struct {
-	ID        uuid.UUID `db:"id"`
-	UserID    uuid.UUID `db:"user_id"`
-	Name      string    `db:"name"`
-	Effect    string    `db:"effect"`
-	Service   string    `db:"service"`
-	Path      string    `db:"path"`
-	Methods   []byte    `db:"methods"`
-	Agents    *string   `db:"agents"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID         uuid.UUID  `db:"id"`
+	UserID     uuid.UUID  `db:"user_id"`
+	Name       string     `db:"name"`
+	Effect     string     `db:"effect"`
+	Service    string     `db:"service"`
+	Path       string     `db:"path"`
+	Methods    []byte     `db:"methods"`
+	Conditions []byte     `db:"conditions"`
+	Expression string     `db:"expression"`
+	ValidFrom  *time.Time `db:"valid_from"`
+	ValidUntil *time.Time `db:"valid_until"`
+	Agents     *string    `db:"agents"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+}
+
+type PolicyConditionsModel struct {
+	TimeZone    string              `json:"time_zone"`
+	DaysOfWeek  []string            `json:"days_of_week"`
+	StartTime   string              `json:"start_time"`
+	EndTime     string              `json:"end_time"`
+	NotBefore   *time.Time          `json:"not_before"`
+	NotAfter    *time.Time          `json:"not_after"`
+	SourceCIDRs []string            `json:"source_cidrs"`
+	Attributes  map[string][]string `json:"attributes"`
+	PathParams  map[string]string   `json:"path_params"`
 }
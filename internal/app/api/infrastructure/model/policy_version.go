@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PolicyVersionModel struct {
+	PolicyID   uuid.UUID  `db:"policy_id"`
+	Version    int        `db:"version"`
+	UserID     uuid.UUID  `db:"user_id"`
+	AuthorID   uuid.UUID  `db:"author_id"`
+	Operation  string     `db:"operation"`
+	Name       string     `db:"name"`
+	Effect     string     `db:"effect"`
+	Service    string     `db:"service"`
+	Path       string     `db:"path"`
+	Methods    []byte     `db:"methods"`
+	Conditions []byte     `db:"conditions"`
+	Expression string     `db:"expression"`
+	ValidFrom  *time.Time `db:"valid_from"`
+	ValidUntil *time.Time `db:"valid_until"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SecurityEventModel struct {
+	ID         uuid.UUID `db:"id"`
+	UserID     uuid.UUID `db:"user_id"`
+	Type       string    `db:"type"`
+	Detail     string    `db:"detail"`
+	OccurredAt time.Time `db:"occurred_at"`
+}
@@ -7,7 +7,8 @@ import (
 )
 
 type UserTokenModel struct {
-	UserID    uuid.UUID `db:"user_id"`
-	Token     string    `db:"token"`
-	ExpiresAt time.Time `db:"expires_at"`
+	UserID          uuid.UUID `db:"user_id"`
+	Token           string    `db:"token"`
+	AuthenticatedAt time.Time `db:"authenticated_at"`
+	ExpiresAt       time.Time `db:"expires_at"`
 }
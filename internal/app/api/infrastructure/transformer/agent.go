@@ -9,45 +9,56 @@ import (
 )
 
 func ToAgentModel(agent *entity.Agent) *model.AgentModel {
-	var policies string
-	if len(agent.Policies) != 0 {
-		policyIDs := make([]string, len(agent.Policies))
-		for i, policy := range agent.Policies {
-			policyIDs[i] = policy.String()
-		}
-		policies = strings.Join(policyIDs, ",")
-	}
+	policies := joinIDs(agent.Policies)
+	temporaryPolicies := joinIDs(agent.TemporaryPolicies)
+	groups := joinIDs(agent.Groups)
+	roles := joinIDs(agent.Roles)
 
 	return &model.AgentModel{
-		ID:        agent.ID,
-		UserID:    agent.UserID,
-		Name:      agent.Name,
-		Policies:  &policies,
-		CreatedAt: agent.CreatedAt,
-		UpdatedAt: agent.UpdatedAt,
+		ID:                          agent.ID,
+		UserID:                      agent.UserID,
+		Name:                        agent.Name,
+		Policies:                    &policies,
+		TemporaryPolicies:           &temporaryPolicies,
+		TemporaryPoliciesValidUntil: agent.TemporaryPoliciesValidUntil,
+		Groups:                      &groups,
+		Roles:                       &roles,
+		CreatedAt:                   agent.CreatedAt,
+		UpdatedAt:                   agent.UpdatedAt,
 	}
 }
 
 func ToAgentEntity(agent *model.AgentModel) (*entity.Agent, error) {
-	policies := []uuid.UUID{}
-	if agent.Policies != nil {
-		for _, policyID := range strings.Split(*agent.Policies, ",") {
-			policy, err := uuid.Parse(policyID)
-			if err != nil {
-				return nil, err
-			}
-			policies = append(policies, policy)
-		}
+	policies, err := splitIDs(agent.Policies)
+	if err != nil {
+		return nil, err
+	}
+	temporaryPolicies, err := splitIDs(agent.TemporaryPolicies)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := splitIDs(agent.Groups)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := splitIDs(agent.Roles)
+	if err != nil {
+		return nil, err
 	}
 
-	return entity.RestoreAgent(
+	restored := entity.RestoreAgent(
 		agent.ID,
 		agent.UserID,
 		agent.Name,
 		policies,
+		groups,
+		roles,
 		agent.CreatedAt,
 		agent.UpdatedAt,
-	), nil
+	)
+	restored.TemporaryPolicies = temporaryPolicies
+	restored.TemporaryPoliciesValidUntil = agent.TemporaryPoliciesValidUntil
+	return restored, nil
 }
 
 func ToAgentEntities(agents []*model.AgentModel) ([]*entity.Agent, error) {
@@ -61,3 +72,31 @@ func ToAgentEntities(agents []*model.AgentModel) ([]*entity.Agent, error) {
 	}
 	return entities, nil
 }
+
+// GROUP_CONCATで集約したIDの形式に変換する.
+func joinIDs(ids []uuid.UUID) string {
+	if len(ids) == 0 {
+		return ""
+	}
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return strings.Join(values, ",")
+}
+
+// GROUP_CONCATで集約したIDを分割する. 結合先の行がない場合はnilとなる.
+func splitIDs(value *string) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	if value == nil {
+		return ids, nil
+	}
+	for _, v := range strings.Split(*value, ",") {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
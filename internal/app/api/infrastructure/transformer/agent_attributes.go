@@ -0,0 +1,33 @@
+package transformer
+
+import (
+	"encoding/json"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+)
+
+func ToAgentAttributesModel(agentAttributes *entity.AgentAttributes) (*model.AgentAttributesModel, error) {
+	attributes, err := json.Marshal(agentAttributes.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.AgentAttributesModel{
+		AgentID:    agentAttributes.AgentID,
+		Attributes: attributes,
+		UpdatedAt:  agentAttributes.UpdatedAt,
+	}, nil
+}
+
+func ToAgentAttributesEntity(agentAttributes *model.AgentAttributesModel) (*entity.AgentAttributes, error) {
+	var values map[string]string
+	if err := json.Unmarshal(agentAttributes.Attributes, &values); err != nil {
+		return nil, err
+	}
+
+	return entity.RestoreAgentAttributes(
+		agentAttributes.AgentID,
+		values,
+		agentAttributes.UpdatedAt,
+	), nil
+}
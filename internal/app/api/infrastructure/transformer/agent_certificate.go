@@ -0,0 +1,40 @@
+package transformer
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+)
+
+func ToAgentCertificateModel(agentCertificate *entity.AgentCertificate) *model.AgentCertificateModel {
+	var fingerprint, subject *string
+	if agentCertificate.Fingerprint != "" {
+		fingerprint = &agentCertificate.Fingerprint
+	}
+	if agentCertificate.Subject != "" {
+		subject = &agentCertificate.Subject
+	}
+
+	return &model.AgentCertificateModel{
+		AgentID:     agentCertificate.AgentID,
+		Fingerprint: fingerprint,
+		Subject:     subject,
+		BoundAt:     agentCertificate.BoundAt,
+	}
+}
+
+func ToAgentCertificateEntity(agentCertificate *model.AgentCertificateModel) *entity.AgentCertificate {
+	var fingerprint, subject string
+	if agentCertificate.Fingerprint != nil {
+		fingerprint = *agentCertificate.Fingerprint
+	}
+	if agentCertificate.Subject != nil {
+		subject = *agentCertificate.Subject
+	}
+
+	return entity.RestoreAgentCertificate(
+		agentCertificate.AgentID,
+		fingerprint,
+		subject,
+		agentCertificate.BoundAt,
+	)
+}
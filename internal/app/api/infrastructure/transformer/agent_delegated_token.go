@@ -0,0 +1,41 @@
+package transformer
+
+import (
+	"encoding/json"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+
+	"github.com/google/uuid"
+)
+
+func ToAgentDelegatedTokenModel(agentDelegatedToken *entity.AgentDelegatedToken) (*model.AgentDelegatedTokenModel, error) {
+	policies, err := json.Marshal(agentDelegatedToken.Policies)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.AgentDelegatedTokenModel{
+		AgentID:     agentDelegatedToken.AgentID,
+		ParentToken: agentDelegatedToken.ParentToken,
+		Token:       agentDelegatedToken.Token,
+		Policies:    policies,
+		ExpiresAt:   agentDelegatedToken.ExpiresAt,
+		CreatedAt:   agentDelegatedToken.CreatedAt,
+	}, nil
+}
+
+func ToAgentDelegatedTokenEntity(agentDelegatedToken *model.AgentDelegatedTokenModel) (*entity.AgentDelegatedToken, error) {
+	var policies []uuid.UUID
+	if err := json.Unmarshal(agentDelegatedToken.Policies, &policies); err != nil {
+		return nil, err
+	}
+
+	return entity.RestoreAgentDelegatedToken(
+		agentDelegatedToken.AgentID,
+		agentDelegatedToken.ParentToken,
+		agentDelegatedToken.Token,
+		policies,
+		agentDelegatedToken.ExpiresAt,
+		agentDelegatedToken.CreatedAt,
+	), nil
+}
@@ -0,0 +1,34 @@
+package transformer
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+)
+
+func ToAgentPublicKeyModel(agentPublicKey *entity.AgentPublicKey) *model.AgentPublicKeyModel {
+	return &model.AgentPublicKeyModel{
+		ID:        agentPublicKey.ID,
+		AgentID:   agentPublicKey.AgentID,
+		Algorithm: agentPublicKey.Algorithm,
+		PublicKey: agentPublicKey.PublicKey,
+		CreatedAt: agentPublicKey.CreatedAt,
+	}
+}
+
+func ToAgentPublicKeyEntity(agentPublicKey *model.AgentPublicKeyModel) *entity.AgentPublicKey {
+	return entity.RestoreAgentPublicKey(
+		agentPublicKey.ID,
+		agentPublicKey.AgentID,
+		agentPublicKey.Algorithm,
+		agentPublicKey.PublicKey,
+		agentPublicKey.CreatedAt,
+	)
+}
+
+func ToAgentPublicKeyEntities(agentPublicKeys []*model.AgentPublicKeyModel) []*entity.AgentPublicKey {
+	entities := make([]*entity.AgentPublicKey, len(agentPublicKeys))
+	for i, agentPublicKey := range agentPublicKeys {
+		entities[i] = ToAgentPublicKeyEntity(agentPublicKey)
+	}
+	return entities
+}
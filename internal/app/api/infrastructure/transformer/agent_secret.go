@@ -0,0 +1,24 @@
+package transformer
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+)
+
+func ToAgentSecretModel(agentSecret *entity.AgentSecret) *model.AgentSecretModel {
+	return &model.AgentSecretModel{
+		AgentID:     agentSecret.AgentID,
+		KeyID:       agentSecret.KeyID,
+		Secret:      agentSecret.Secret,
+		GeneratedAt: agentSecret.GeneratedAt,
+	}
+}
+
+func ToAgentSecretEntity(agentSecret *model.AgentSecretModel) *entity.AgentSecret {
+	return entity.RestoreAgentSecret(
+		agentSecret.AgentID,
+		agentSecret.KeyID,
+		agentSecret.Secret,
+		agentSecret.GeneratedAt,
+	)
+}
@@ -0,0 +1,22 @@
+package transformer
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+)
+
+func ToAgentSignatureNonceModel(agentSignatureNonce *entity.AgentSignatureNonce) *model.AgentSignatureNonceModel {
+	return &model.AgentSignatureNonceModel{
+		AgentID:   agentSignatureNonce.AgentID,
+		Nonce:     agentSignatureNonce.Nonce,
+		ExpiresAt: agentSignatureNonce.ExpiresAt,
+	}
+}
+
+func ToAgentSignatureNonceEntity(agentSignatureNonce *model.AgentSignatureNonceModel) *entity.AgentSignatureNonce {
+	return entity.RestoreAgentSignatureNonce(
+		agentSignatureNonce.AgentID,
+		agentSignatureNonce.Nonce,
+		agentSignatureNonce.ExpiresAt,
+	)
+}
@@ -0,0 +1,34 @@
+package transformer
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+)
+
+func ToPermissionModel(permission *entity.Permission) *model.PermissionModel {
+	return &model.PermissionModel{
+		AgentID:    permission.AgentID,
+		PolicyID:   permission.PolicyID,
+		UserID:     permission.UserID,
+		ValidFrom:  permission.ValidFrom,
+		ValidUntil: permission.ValidUntil,
+	}
+}
+
+func ToPermissionEntity(permission *model.PermissionModel) *entity.Permission {
+	return entity.RestorePermission(
+		permission.AgentID,
+		permission.PolicyID,
+		permission.UserID,
+		permission.ValidFrom,
+		permission.ValidUntil,
+	)
+}
+
+func ToPermissionEntities(permissions []*model.PermissionModel) []*entity.Permission {
+	entities := make([]*entity.Permission, len(permissions))
+	for i, permission := range permissions {
+		entities[i] = ToPermissionEntity(permission)
+	}
+	return entities
+}
@@ -15,6 +15,11 @@ func ToPolicyModel(policy *entity.Policy) (*model.PolicyModel, error) {
 		return nil, err
 	}
 
+	conditions, err := toPolicyConditionsJSON(policy.Conditions)
+	if err != nil {
+		return nil, err
+	}
+
 	var agents string
 	if len(policy.Agents) != 0 {
 		agentIDs := make([]string, len(policy.Agents))
@@ -25,16 +30,20 @@ func ToPolicyModel(policy *entity.Policy) (*model.PolicyModel, error) {
 	}
 
 	return &model.PolicyModel{
-		ID:        policy.ID,
-		UserID:    policy.UserID,
-		Name:      policy.Name,
-		Effect:    policy.Effect,
-		Service:   policy.Service,
-		Path:      policy.Path,
-		Methods:   methods,
-		Agents:    &agents,
-		CreatedAt: policy.CreatedAt,
-		UpdatedAt: policy.UpdatedAt,
+		ID:         policy.ID,
+		UserID:     policy.UserID,
+		Name:       policy.Name,
+		Effect:     policy.Effect,
+		Service:    policy.Service,
+		Path:       policy.Path,
+		Methods:    methods,
+		Conditions: conditions,
+		Expression: policy.Expression,
+		ValidFrom:  policy.ValidFrom,
+		ValidUntil: policy.ValidUntil,
+		Agents:     &agents,
+		CreatedAt:  policy.CreatedAt,
+		UpdatedAt:  policy.UpdatedAt,
 	}, nil
 }
 
@@ -44,6 +53,11 @@ func ToPolicyEntity(policy *model.PolicyModel) (*entity.Policy, error) {
 		return nil, err
 	}
 
+	conditions, err := toPolicyConditionsEntity(policy.Conditions)
+	if err != nil {
+		return nil, err
+	}
+
 	agents := []uuid.UUID{}
 	if policy.Agents != nil {
 		for _, agentID := range strings.Split(*policy.Agents, ",") {
@@ -63,6 +77,10 @@ func ToPolicyEntity(policy *model.PolicyModel) (*entity.Policy, error) {
 		policy.Service,
 		policy.Path,
 		methods,
+		conditions,
+		policy.Expression,
+		policy.ValidFrom,
+		policy.ValidUntil,
 		agents,
 		policy.CreatedAt,
 		policy.UpdatedAt,
@@ -80,3 +98,48 @@ func ToPolicyEntities(policies []*model.PolicyModel) ([]*entity.Policy, error) {
 	}
 	return entities, nil
 }
+
+// 条件がない場合はNULLとして保存する.
+func toPolicyConditionsJSON(conditions *entity.PolicyConditions) ([]byte, error) {
+	if conditions == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(&model.PolicyConditionsModel{
+		TimeZone:    conditions.TimeZone,
+		DaysOfWeek:  conditions.DaysOfWeek,
+		StartTime:   conditions.StartTime,
+		EndTime:     conditions.EndTime,
+		NotBefore:   conditions.NotBefore,
+		NotAfter:    conditions.NotAfter,
+		SourceCIDRs: conditions.SourceCIDRs,
+		Attributes:  conditions.Attributes,
+		PathParams:  conditions.PathParams,
+	})
+}
+
+func toPolicyConditionsEntity(conditions []byte) (*entity.PolicyConditions, error) {
+	if conditions == nil {
+		return nil, nil
+	}
+
+	var conditionsModel model.PolicyConditionsModel
+	if err := json.Unmarshal(conditions, &conditionsModel); err != nil {
+		return nil, err
+	}
+	// path_paramsを追加する前に保存した条件は, 新しく作成した条件と比較できるよう空のmapとして扱う.
+	if conditionsModel.PathParams == nil {
+		conditionsModel.PathParams = map[string]string{}
+	}
+	return entity.RestorePolicyConditions(
+		conditionsModel.TimeZone,
+		conditionsModel.DaysOfWeek,
+		conditionsModel.StartTime,
+		conditionsModel.EndTime,
+		conditionsModel.NotBefore,
+		conditionsModel.NotAfter,
+		conditionsModel.SourceCIDRs,
+		conditionsModel.Attributes,
+		conditionsModel.PathParams,
+	), nil
+}
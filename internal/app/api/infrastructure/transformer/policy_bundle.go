@@ -0,0 +1,55 @@
+package transformer
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+)
+
+func ToPolicyBundleModel(bundle *entity.PolicyBundle) *model.PolicyBundleModel {
+	policies := joinIDs(bundle.Policies)
+	agents := joinIDs(bundle.Agents)
+
+	return &model.PolicyBundleModel{
+		ID:        bundle.ID,
+		UserID:    bundle.UserID,
+		Name:      bundle.Name,
+		Policies:  &policies,
+		Agents:    &agents,
+		CreatedAt: bundle.CreatedAt,
+		UpdatedAt: bundle.UpdatedAt,
+	}
+}
+
+func ToPolicyBundleEntity(kind entity.PolicyBundleKind, bundle *model.PolicyBundleModel) (*entity.PolicyBundle, error) {
+	policies, err := splitIDs(bundle.Policies)
+	if err != nil {
+		return nil, err
+	}
+	agents, err := splitIDs(bundle.Agents)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.RestorePolicyBundle(
+		kind,
+		bundle.ID,
+		bundle.UserID,
+		bundle.Name,
+		policies,
+		agents,
+		bundle.CreatedAt,
+		bundle.UpdatedAt,
+	), nil
+}
+
+func ToPolicyBundleEntities(kind entity.PolicyBundleKind, bundles []*model.PolicyBundleModel) ([]*entity.PolicyBundle, error) {
+	entities := make([]*entity.PolicyBundle, len(bundles))
+	var err error
+	for i, bundle := range bundles {
+		entities[i], err = ToPolicyBundleEntity(kind, bundle)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entities, nil
+}
@@ -0,0 +1,79 @@
+package transformer
+
+import (
+	"encoding/json"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+)
+
+func ToPolicyVersionModel(policyVersion *entity.PolicyVersion) (*model.PolicyVersionModel, error) {
+	methods, err := json.Marshal(policyVersion.Methods)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, err := toPolicyConditionsJSON(policyVersion.Conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PolicyVersionModel{
+		PolicyID:   policyVersion.PolicyID,
+		Version:    policyVersion.Version,
+		UserID:     policyVersion.UserID,
+		AuthorID:   policyVersion.AuthorID,
+		Operation:  policyVersion.Operation,
+		Name:       policyVersion.Name,
+		Effect:     policyVersion.Effect,
+		Service:    policyVersion.Service,
+		Path:       policyVersion.Path,
+		Methods:    methods,
+		Conditions: conditions,
+		Expression: policyVersion.Expression,
+		ValidFrom:  policyVersion.ValidFrom,
+		ValidUntil: policyVersion.ValidUntil,
+		CreatedAt:  policyVersion.CreatedAt,
+	}, nil
+}
+
+func ToPolicyVersionEntity(policyVersion *model.PolicyVersionModel) (*entity.PolicyVersion, error) {
+	var methods []string
+	if err := json.Unmarshal(policyVersion.Methods, &methods); err != nil {
+		return nil, err
+	}
+
+	conditions, err := toPolicyConditionsEntity(policyVersion.Conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.RestorePolicyVersion(
+		policyVersion.PolicyID,
+		policyVersion.Version,
+		policyVersion.UserID,
+		policyVersion.AuthorID,
+		policyVersion.Operation,
+		policyVersion.Name,
+		policyVersion.Effect,
+		policyVersion.Service,
+		policyVersion.Path,
+		methods,
+		conditions,
+		policyVersion.Expression,
+		policyVersion.ValidFrom,
+		policyVersion.ValidUntil,
+		policyVersion.CreatedAt,
+	), nil
+}
+
+func ToPolicyVersionEntities(policyVersions []*model.PolicyVersionModel) ([]*entity.PolicyVersion, error) {
+	entities := make([]*entity.PolicyVersion, len(policyVersions))
+	var err error
+	for i, policyVersion := range policyVersions {
+		entities[i], err = ToPolicyVersionEntity(policyVersion)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entities, nil
+}
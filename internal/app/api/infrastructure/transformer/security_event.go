@@ -0,0 +1,34 @@
+package transformer
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+)
+
+func ToSecurityEventModel(securityEvent *entity.SecurityEvent) *model.SecurityEventModel {
+	return &model.SecurityEventModel{
+		ID:         securityEvent.ID,
+		UserID:     securityEvent.UserID,
+		Type:       securityEvent.Type,
+		Detail:     securityEvent.Detail,
+		OccurredAt: securityEvent.OccurredAt,
+	}
+}
+
+func ToSecurityEventEntity(securityEvent *model.SecurityEventModel) *entity.SecurityEvent {
+	return entity.RestoreSecurityEvent(
+		securityEvent.ID,
+		securityEvent.UserID,
+		securityEvent.Type,
+		securityEvent.Detail,
+		securityEvent.OccurredAt,
+	)
+}
+
+func ToSecurityEventEntities(securityEvents []*model.SecurityEventModel) []*entity.SecurityEvent {
+	entities := make([]*entity.SecurityEvent, len(securityEvents))
+	for i, securityEvent := range securityEvents {
+		entities[i] = ToSecurityEventEntity(securityEvent)
+	}
+	return entities
+}
@@ -0,0 +1,53 @@
+package transformer
+
+import (
+	"encoding/json"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/infrastructure/model"
+)
+
+func ToServiceModel(service *entity.Service) (*model.ServiceModel, error) {
+	methods, err := json.Marshal(service.Methods)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ServiceModel{
+		ID:         service.ID,
+		UserID:     service.UserID,
+		Name:       service.Name,
+		Methods:    methods,
+		PathSchema: service.PathSchema,
+		CreatedAt:  service.CreatedAt,
+		UpdatedAt:  service.UpdatedAt,
+	}, nil
+}
+
+func ToServiceEntity(service *model.ServiceModel) (*entity.Service, error) {
+	var methods []string
+	if err := json.Unmarshal(service.Methods, &methods); err != nil {
+		return nil, err
+	}
+
+	return entity.RestoreService(
+		service.ID,
+		service.UserID,
+		service.Name,
+		methods,
+		service.PathSchema,
+		service.CreatedAt,
+		service.UpdatedAt,
+	), nil
+}
+
+func ToServiceEntities(services []*model.ServiceModel) ([]*entity.Service, error) {
+	entities := make([]*entity.Service, len(services))
+	var err error
+	for i, service := range services {
+		entities[i], err = ToServiceEntity(service)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entities, nil
+}
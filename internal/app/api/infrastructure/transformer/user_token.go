@@ -7,9 +7,10 @@ import (
 
 func ToUserTokenModel(userToken *entity.UserToken) *model.UserTokenModel {
 	return &model.UserTokenModel{
-		UserID:    userToken.UserID,
-		Token:     userToken.Token,
-		ExpiresAt: userToken.ExpiresAt,
+		UserID:          userToken.UserID,
+		Token:           userToken.Token,
+		AuthenticatedAt: userToken.AuthenticatedAt,
+		ExpiresAt:       userToken.ExpiresAt,
 	}
 }
 
@@ -17,6 +18,7 @@ func ToUserTokenEntity(userToken *model.UserTokenModel) *entity.UserToken {
 	return entity.RestoreUserToken(
 		userToken.UserID,
 		userToken.Token,
+		userToken.AuthenticatedAt,
 		userToken.ExpiresAt,
 	)
 }
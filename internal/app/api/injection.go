@@ -1,11 +1,14 @@
 package api
 
 import (
+	"holos-auth-api/internal/app/api/domain/entity"
 	"holos-auth-api/internal/app/api/domain/service"
 	"holos-auth-api/internal/app/api/infrastructure/database"
+	"holos-auth-api/internal/app/api/infrastructure/memory"
 	"holos-auth-api/internal/app/api/interface/handler"
 	"holos-auth-api/internal/app/api/interface/middleware"
 	"holos-auth-api/internal/app/api/usecase"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -13,34 +16,70 @@ import (
 var (
 	authMiddleware middleware.AuthMiddleware
 
-	userHandler   handler.UserHandler
-	agentHandler  handler.AgentHandler
-	policyHandler handler.PolicyHandler
-	authHandler   handler.AuthHandler
+	userHandler       handler.UserHandler
+	agentHandler      handler.AgentHandler
+	agentGroupHandler handler.PolicyBundleHandler
+	policyHandler     handler.PolicyHandler
+	roleHandler       handler.PolicyBundleHandler
+	serviceHandler    handler.ServiceHandler
+	configHandler     handler.ConfigHandler
+	authHandler       handler.AuthHandler
+	permissionHandler handler.PermissionHandler
+
+	securityEventHandler handler.SecurityEventHandler
+
+	permissionUsecase usecase.PermissionUsecase
 )
 
-func inject(db *sqlx.DB) {
+func inject(db *sqlx.DB, legacyTokenDeadline time.Time, leakedTokenReporters []*entity.LeakedTokenReporter, stepUpRoutes []string, headImpliedByGet bool) {
 	transactionObject := database.NewDBTransactionObject(db)
 
-	userDBRepository := database.NewUserDBRepository(db)
+	policyMatcherMemoryRepository := memory.NewPolicyMatcherMemoryRepository()
+
+	userDBRepository := memory.NewUserInvalidationRepository(database.NewUserDBRepository(db), policyMatcherMemoryRepository)
 	userTokenDBRepository := database.NewUserTokenDBRepository(db)
 	agentDBRepository := database.NewAgentDBRepository(db)
 	agentTokenDBRepository := database.NewAgentTokenDBRepository(db)
-	policyDBRepository := database.NewPolicyDBRepository(db)
+	agentCertificateDBRepository := database.NewAgentCertificateDBRepository(db)
+	agentSecretDBRepository := database.NewAgentSecretDBRepository(db)
+	agentPublicKeyDBRepository := database.NewAgentPublicKeyDBRepository(db)
+	agentSignatureNonceDBRepository := database.NewAgentSignatureNonceDBRepository(db)
+	agentDelegatedTokenDBRepository := database.NewAgentDelegatedTokenDBRepository(db)
+	agentAttributesDBRepository := memory.NewAgentAttributesInvalidationRepository(database.NewAgentAttributesDBRepository(db), policyMatcherMemoryRepository)
+	agentGroupDBRepository := memory.NewPolicyBundleInvalidationRepository(database.NewPolicyBundleDBRepository(db, entity.PolicyBundleKindAgentGroup), policyMatcherMemoryRepository)
+	policyDBRepository := memory.NewPolicyInvalidationRepository(database.NewPolicyDBRepository(db), policyMatcherMemoryRepository)
+	policyVersionDBRepository := database.NewPolicyVersionDBRepository(db)
+	roleDBRepository := memory.NewPolicyBundleInvalidationRepository(database.NewPolicyBundleDBRepository(db, entity.PolicyBundleKindRole), policyMatcherMemoryRepository)
+	serviceDBRepository := database.NewServiceDBRepository(db)
+	securityEventDBRepository := database.NewSecurityEventDBRepository(db)
+	permissionDBRepository := database.NewPermissionDBRepository(db)
 
 	userService := service.NewUserService(userDBRepository)
-	agentService := service.NewAgentService(policyDBRepository)
-	policyService := service.NewPolicyService(agentDBRepository)
+	agentService := service.NewAgentService(policyDBRepository, agentGroupDBRepository, roleDBRepository, serviceDBRepository, policyMatcherMemoryRepository, userDBRepository, agentAttributesDBRepository, headImpliedByGet)
+	policyBundleService := service.NewPolicyBundleService(policyDBRepository, agentDBRepository)
+	policyService := service.NewPolicyService(agentDBRepository, serviceDBRepository)
 
-	userUsecase := usecase.NewUserUsecase(transactionObject, userDBRepository, userService)
-	agentUsecase := usecase.NewAgentUsecase(transactionObject, agentDBRepository, agentTokenDBRepository, policyDBRepository, agentService)
-	policyUsecase := usecase.NewPolicyUsecase(transactionObject, policyDBRepository, agentDBRepository, policyService)
-	authUsecase := usecase.NewAuthUsecase(transactionObject, userDBRepository, userTokenDBRepository, agentDBRepository, agentService)
+	userUsecase := usecase.NewUserUsecase(transactionObject, userDBRepository, serviceDBRepository, userService)
+	agentUsecase := usecase.NewAgentUsecase(transactionObject, agentDBRepository, agentTokenDBRepository, agentCertificateDBRepository, agentSecretDBRepository, agentPublicKeyDBRepository, agentAttributesDBRepository, policyDBRepository, agentService)
+	agentGroupUsecase := usecase.NewPolicyBundleUsecase(entity.PolicyBundleKindAgentGroup, transactionObject, agentGroupDBRepository, policyDBRepository, agentDBRepository, policyBundleService)
+	policyUsecase := usecase.NewPolicyUsecase(transactionObject, policyDBRepository, policyVersionDBRepository, agentDBRepository, policyService, agentService)
+	roleUsecase := usecase.NewPolicyBundleUsecase(entity.PolicyBundleKindRole, transactionObject, roleDBRepository, policyDBRepository, agentDBRepository, policyBundleService)
+	serviceUsecase := usecase.NewServiceUsecase(transactionObject, serviceDBRepository)
+	configUsecase := usecase.NewConfigUsecase(transactionObject, policyDBRepository, policyVersionDBRepository, agentDBRepository, policyService)
+	authUsecase := usecase.NewAuthUsecase(transactionObject, userDBRepository, userTokenDBRepository, agentDBRepository, agentSecretDBRepository, agentPublicKeyDBRepository, agentSignatureNonceDBRepository, agentDelegatedTokenDBRepository, agentService, legacyTokenDeadline)
+	permissionUsecase = usecase.NewPermissionUsecase(transactionObject, permissionDBRepository, agentDBRepository, policyDBRepository, securityEventDBRepository)
+	securityEventUsecase := usecase.NewSecurityEventUsecase(transactionObject, userTokenDBRepository, agentDBRepository, agentTokenDBRepository, securityEventDBRepository, leakedTokenReporters)
 
-	authMiddleware = middleware.NewAuthMiddleware(authUsecase)
+	authMiddleware = middleware.NewAuthMiddleware(authUsecase, stepUpRoutes)
 
 	userHandler = handler.NewUserHandler(userUsecase)
 	agentHandler = handler.NewAgentHandler(agentUsecase)
+	agentGroupHandler = handler.NewPolicyBundleHandler(agentGroupUsecase)
 	policyHandler = handler.NewPolicyHandler(policyUsecase)
+	roleHandler = handler.NewPolicyBundleHandler(roleUsecase)
+	serviceHandler = handler.NewServiceHandler(serviceUsecase)
+	configHandler = handler.NewConfigHandler(configUsecase)
 	authHandler = handler.NewAuthHandler(authUsecase)
+	securityEventHandler = handler.NewSecurityEventHandler(securityEventUsecase)
+	permissionHandler = handler.NewPermissionHandler(permissionUsecase)
 }
@@ -27,3 +27,57 @@ func ToAgentTokenResponse(agentToken *dto.AgentTokenDTO) *response.AgentTokenRes
 		GeneratedAt: agentToken.GeneratedAt,
 	}
 }
+
+func ToAgentCertificateResponse(agentCertificate *dto.AgentCertificateDTO) *response.AgentCertificateResponse {
+	return &response.AgentCertificateResponse{
+		Fingerprint: agentCertificate.Fingerprint,
+		Subject:     agentCertificate.Subject,
+		BoundAt:     agentCertificate.BoundAt,
+	}
+}
+
+func ToAgentAttributesResponse(agentAttributes *dto.AgentAttributesDTO) *response.AgentAttributesResponse {
+	return &response.AgentAttributesResponse{
+		Attributes: agentAttributes.Values,
+	}
+}
+
+func ToAgentSecretResponse(agentSecret *dto.AgentSecretDTO) *response.AgentSecretResponse {
+	return &response.AgentSecretResponse{
+		KeyID:       agentSecret.KeyID,
+		GeneratedAt: agentSecret.GeneratedAt,
+	}
+}
+
+func ToGeneratedAgentSecretResponse(agentSecret *dto.AgentSecretDTO) *response.AgentSecretResponse {
+	return &response.AgentSecretResponse{
+		KeyID:       agentSecret.KeyID,
+		Secret:      agentSecret.Secret,
+		GeneratedAt: agentSecret.GeneratedAt,
+	}
+}
+
+func ToAgentPublicKeyResponse(agentPublicKey *dto.AgentPublicKeyDTO) *response.AgentPublicKeyResponse {
+	return &response.AgentPublicKeyResponse{
+		ID:        agentPublicKey.ID,
+		Algorithm: agentPublicKey.Algorithm,
+		PublicKey: agentPublicKey.PublicKey,
+		CreatedAt: agentPublicKey.CreatedAt,
+	}
+}
+
+func ToAgentPublicKeyResponses(agentPublicKeys []*dto.AgentPublicKeyDTO) []*response.AgentPublicKeyResponse {
+	responses := make([]*response.AgentPublicKeyResponse, len(agentPublicKeys))
+	for i, agentPublicKey := range agentPublicKeys {
+		responses[i] = ToAgentPublicKeyResponse(agentPublicKey)
+	}
+	return responses
+}
+
+func ToAgentDelegatedTokenResponse(agentDelegatedToken *dto.AgentDelegatedTokenDTO) *response.AgentDelegatedTokenResponse {
+	return &response.AgentDelegatedTokenResponse{
+		Token:     agentDelegatedToken.Token,
+		PolicyIDs: agentDelegatedToken.Policies,
+		ExpiresAt: agentDelegatedToken.ExpiresAt,
+	}
+}
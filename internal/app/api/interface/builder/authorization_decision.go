@@ -0,0 +1,70 @@
+package builder
+
+import (
+	"holos-auth-api/internal/app/api/interface/response"
+	"holos-auth-api/internal/app/api/usecase/dto"
+
+	"github.com/google/uuid"
+)
+
+func ToAuthorizationSimulationResponse(decision *dto.AuthorizationDecisionDTO) *response.AuthorizationSimulationResponse {
+	var decidingPolicyID *uuid.UUID
+	if decision.DecidingPolicy != nil {
+		decidingPolicyID = &decision.DecidingPolicy.ID
+	}
+
+	policies := make([]*response.PolicyEvaluationResponse, len(decision.Evaluations))
+	for i, evaluation := range decision.Evaluations {
+		policies[i] = &response.PolicyEvaluationResponse{
+			ID:      evaluation.Policy.ID,
+			Name:    evaluation.Policy.Name,
+			Effect:  evaluation.Policy.Effect,
+			Matched: evaluation.Matched,
+			Result:  evaluation.Result,
+		}
+	}
+
+	return &response.AuthorizationSimulationResponse{
+		Allowed:          decision.Allowed,
+		DecidingPolicyID: decidingPolicyID,
+		Policies:         policies,
+	}
+}
+
+func ToAuthorizationResponses(results []*dto.AuthorizationResultDTO) []*response.AuthorizationResponse {
+	responses := make([]*response.AuthorizationResponse, len(results))
+	for i, result := range results {
+		responses[i] = ToAuthorizationResponse(result)
+	}
+	return responses
+}
+
+func ToAuthorizationResponse(result *dto.AuthorizationResultDTO) *response.AuthorizationResponse {
+	var agentID *uuid.UUID
+	if result.AgentID != uuid.Nil {
+		agentID = &result.AgentID
+	}
+
+	var decidingPolicyID *uuid.UUID
+	var decidingPolicyName *string
+	if result.DecidingPolicy != nil {
+		decidingPolicyID = &result.DecidingPolicy.ID
+		decidingPolicyName = &result.DecidingPolicy.Name
+	}
+
+	var denyReason *string
+	if result.DenyReason != "" {
+		denyReason = &result.DenyReason
+	}
+
+	return &response.AuthorizationResponse{
+		Allowed:            result.Allowed,
+		OperatorType:       result.OperatorType,
+		UserID:             result.UserID,
+		AgentID:            agentID,
+		DecidingPolicyID:   decidingPolicyID,
+		DecidingPolicyName: decidingPolicyName,
+		DenyReason:         denyReason,
+		CacheTTL:           int(result.CacheTTL.Seconds()),
+	}
+}
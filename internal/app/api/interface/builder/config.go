@@ -0,0 +1,23 @@
+package builder
+
+import (
+	"holos-auth-api/internal/app/api/interface/response"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToConfigPlanResponse(plan *dto.ConfigPlanDTO) *response.ConfigPlanResponse {
+	changes := make([]*response.ConfigChangeResponse, len(plan.Changes))
+	for i, change := range plan.Changes {
+		changes[i] = &response.ConfigChangeResponse{
+			Resource: change.Resource,
+			Action:   change.Action,
+			Name:     change.Name,
+			Fields:   change.Fields,
+		}
+	}
+
+	return &response.ConfigPlanResponse{
+		Fingerprint: plan.Fingerprint,
+		Changes:     changes,
+	}
+}
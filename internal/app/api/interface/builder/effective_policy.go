@@ -0,0 +1,30 @@
+package builder
+
+import (
+	"holos-auth-api/internal/app/api/interface/response"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToEffectivePolicyResponse(effectivePolicy *dto.EffectivePolicyDTO) *response.EffectivePolicyResponse {
+	sources := make([]*response.PolicySourceResponse, len(effectivePolicy.Sources))
+	for i, source := range effectivePolicy.Sources {
+		sources[i] = &response.PolicySourceResponse{
+			Type: source.Type,
+			ID:   source.ID,
+			Name: source.Name,
+		}
+	}
+
+	return &response.EffectivePolicyResponse{
+		PolicyResponse: ToPolicyResponse(effectivePolicy.Policy),
+		Sources:        sources,
+	}
+}
+
+func ToEffectivePolicyResponses(effectivePolicies []*dto.EffectivePolicyDTO) []*response.EffectivePolicyResponse {
+	responses := make([]*response.EffectivePolicyResponse, len(effectivePolicies))
+	for i, effectivePolicy := range effectivePolicies {
+		responses[i] = ToEffectivePolicyResponse(effectivePolicy)
+	}
+	return responses
+}
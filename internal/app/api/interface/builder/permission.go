@@ -0,0 +1,23 @@
+package builder
+
+import (
+	"holos-auth-api/internal/app/api/interface/response"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToPermissionResponse(permission *dto.PermissionDTO) *response.PermissionResponse {
+	return &response.PermissionResponse{
+		AgentID:    permission.AgentID,
+		PolicyID:   permission.PolicyID,
+		ValidFrom:  permission.ValidFrom,
+		ValidUntil: permission.ValidUntil,
+	}
+}
+
+func ToPermissionResponses(permissions []*dto.PermissionDTO) []*response.PermissionResponse {
+	responses := make([]*response.PermissionResponse, len(permissions))
+	for i, permission := range permissions {
+		responses[i] = ToPermissionResponse(permission)
+	}
+	return responses
+}
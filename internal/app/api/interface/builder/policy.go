@@ -7,14 +7,18 @@ import (
 
 func ToPolicyResponse(policy *dto.PolicyDTO) *response.PolicyResponse {
 	return &response.PolicyResponse{
-		ID:        policy.ID,
-		Name:      policy.Name,
-		Effect:    policy.Effect,
-		Service:   policy.Service,
-		Path:      policy.Path,
-		Methods:   policy.Methods,
-		CreatedAt: policy.CreatedAt,
-		UpdatedAt: policy.UpdatedAt,
+		ID:         policy.ID,
+		Name:       policy.Name,
+		Effect:     policy.Effect,
+		Service:    policy.Service,
+		Path:       policy.Path,
+		Methods:    policy.Methods,
+		Conditions: ToPolicyConditionsResponse(policy.Conditions),
+		Expression: policy.Expression,
+		ValidFrom:  policy.ValidFrom,
+		ValidUntil: policy.ValidUntil,
+		CreatedAt:  policy.CreatedAt,
+		UpdatedAt:  policy.UpdatedAt,
 	}
 }
 
@@ -25,3 +29,21 @@ func ToPolicyResponses(policies []*dto.PolicyDTO) []*response.PolicyResponse {
 	}
 	return responses
 }
+
+func ToPolicyConditionsResponse(conditions *dto.PolicyConditionsDTO) *response.PolicyConditionsResponse {
+	if conditions == nil {
+		return nil
+	}
+
+	return &response.PolicyConditionsResponse{
+		TimeZone:    conditions.TimeZone,
+		DaysOfWeek:  conditions.DaysOfWeek,
+		StartTime:   conditions.StartTime,
+		EndTime:     conditions.EndTime,
+		NotBefore:   conditions.NotBefore,
+		NotAfter:    conditions.NotAfter,
+		SourceCIDRs: conditions.SourceCIDRs,
+		Attributes:  conditions.Attributes,
+		PathParams:  conditions.PathParams,
+	}
+}
@@ -0,0 +1,23 @@
+package builder
+
+import (
+	"holos-auth-api/internal/app/api/interface/response"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToPolicyBundleResponse(bundle *dto.PolicyBundleDTO) *response.PolicyBundleResponse {
+	return &response.PolicyBundleResponse{
+		ID:        bundle.ID,
+		Name:      bundle.Name,
+		CreatedAt: bundle.CreatedAt,
+		UpdatedAt: bundle.UpdatedAt,
+	}
+}
+
+func ToPolicyBundleResponses(bundles []*dto.PolicyBundleDTO) []*response.PolicyBundleResponse {
+	responses := make([]*response.PolicyBundleResponse, len(bundles))
+	for i, bundle := range bundles {
+		responses[i] = ToPolicyBundleResponse(bundle)
+	}
+	return responses
+}
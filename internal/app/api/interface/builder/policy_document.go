@@ -0,0 +1,45 @@
+package builder
+
+import (
+	"holos-auth-api/internal/app/api/interface/response"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToPolicyDocumentResponse(document *dto.PolicyDocumentDTO) *response.PolicyDocumentResponse {
+	policies := make([]*response.PolicyDocumentPolicyResponse, len(document.Policies))
+	for i, policy := range document.Policies {
+		policies[i] = &response.PolicyDocumentPolicyResponse{
+			Name:       policy.Name,
+			Effect:     policy.Effect,
+			Service:    policy.Service,
+			Path:       policy.Path,
+			Methods:    policy.Methods,
+			Conditions: ToPolicyConditionsResponse(policy.Conditions),
+			Expression: policy.Expression,
+			ValidFrom:  policy.ValidFrom,
+			ValidUntil: policy.ValidUntil,
+		}
+	}
+
+	agents := make([]*response.PolicyDocumentAgentResponse, len(document.Agents))
+	for i, agent := range document.Agents {
+		agents[i] = &response.PolicyDocumentAgentResponse{
+			Name: agent.Name,
+		}
+	}
+
+	bindings := make([]*response.PolicyBindingResponse, len(document.Bindings))
+	for i, binding := range document.Bindings {
+		bindings[i] = &response.PolicyBindingResponse{
+			Agent:    binding.Agent,
+			Policies: binding.Policies,
+		}
+	}
+
+	return &response.PolicyDocumentResponse{
+		Version:  document.Version,
+		Policies: policies,
+		Agents:   agents,
+		Bindings: bindings,
+	}
+}
@@ -0,0 +1,23 @@
+package builder
+
+import (
+	"holos-auth-api/internal/app/api/interface/response"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToPolicyLintFindingResponse(finding *dto.PolicyLintFindingDTO) *response.PolicyLintFindingResponse {
+	return &response.PolicyLintFindingResponse{
+		Type:      finding.Type,
+		Severity:  finding.Severity,
+		PolicyIDs: finding.PolicyIDs,
+		Message:   finding.Message,
+	}
+}
+
+func ToPolicyLintFindingResponses(findings []*dto.PolicyLintFindingDTO) []*response.PolicyLintFindingResponse {
+	responses := make([]*response.PolicyLintFindingResponse, len(findings))
+	for i, finding := range findings {
+		responses[i] = ToPolicyLintFindingResponse(finding)
+	}
+	return responses
+}
@@ -0,0 +1,40 @@
+package builder
+
+import (
+	"holos-auth-api/internal/app/api/interface/response"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToPolicyVersionResponse(policyVersion *dto.PolicyVersionDTO) *response.PolicyVersionResponse {
+	return &response.PolicyVersionResponse{
+		Version:    policyVersion.Version,
+		AuthorID:   policyVersion.AuthorID,
+		Operation:  policyVersion.Operation,
+		Name:       policyVersion.Name,
+		Effect:     policyVersion.Effect,
+		Service:    policyVersion.Service,
+		Path:       policyVersion.Path,
+		Methods:    policyVersion.Methods,
+		Conditions: ToPolicyConditionsResponse(policyVersion.Conditions),
+		Expression: policyVersion.Expression,
+		ValidFrom:  policyVersion.ValidFrom,
+		ValidUntil: policyVersion.ValidUntil,
+		CreatedAt:  policyVersion.CreatedAt,
+	}
+}
+
+func ToPolicyVersionResponses(policyVersions []*dto.PolicyVersionDTO) []*response.PolicyVersionResponse {
+	responses := make([]*response.PolicyVersionResponse, len(policyVersions))
+	for i, policyVersion := range policyVersions {
+		responses[i] = ToPolicyVersionResponse(policyVersion)
+	}
+	return responses
+}
+
+func ToPolicyVersionDiffResponse(diff *dto.PolicyVersionDiffDTO) *response.PolicyVersionDiffResponse {
+	return &response.PolicyVersionDiffResponse{
+		From:    ToPolicyVersionResponse(diff.From),
+		To:      ToPolicyVersionResponse(diff.To),
+		Changes: diff.Changes,
+	}
+}
@@ -0,0 +1,23 @@
+package builder
+
+import (
+	"holos-auth-api/internal/app/api/interface/response"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToSecurityEventResponse(securityEvent *dto.SecurityEventDTO) *response.SecurityEventResponse {
+	return &response.SecurityEventResponse{
+		ID:         securityEvent.ID,
+		Type:       securityEvent.Type,
+		Detail:     securityEvent.Detail,
+		OccurredAt: securityEvent.OccurredAt,
+	}
+}
+
+func ToSecurityEventResponses(securityEvents []*dto.SecurityEventDTO) []*response.SecurityEventResponse {
+	responses := make([]*response.SecurityEventResponse, len(securityEvents))
+	for i, securityEvent := range securityEvents {
+		responses[i] = ToSecurityEventResponse(securityEvent)
+	}
+	return responses
+}
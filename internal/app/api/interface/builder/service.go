@@ -0,0 +1,25 @@
+package builder
+
+import (
+	"holos-auth-api/internal/app/api/interface/response"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToServiceResponse(service *dto.ServiceDTO) *response.ServiceResponse {
+	return &response.ServiceResponse{
+		ID:         service.ID,
+		Name:       service.Name,
+		Methods:    service.Methods,
+		PathSchema: service.PathSchema,
+		CreatedAt:  service.CreatedAt,
+		UpdatedAt:  service.UpdatedAt,
+	}
+}
+
+func ToServiceResponses(services []*dto.ServiceDTO) []*response.ServiceResponse {
+	responses := make([]*response.ServiceResponse, len(services))
+	for i, service := range services {
+		responses[i] = ToServiceResponse(service)
+	}
+	return responses
+}
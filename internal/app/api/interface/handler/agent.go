@@ -21,9 +21,22 @@ type AgentHandler interface {
 	Gets(*gin.Context)
 	UpdatePolicies(*gin.Context)
 	GetPolicies(*gin.Context)
+	GetEffectivePolicies(*gin.Context)
 	GenerateToken(*gin.Context)
 	DeleteToken(*gin.Context)
 	GetToken(*gin.Context)
+	BindCertificate(*gin.Context)
+	UnbindCertificate(*gin.Context)
+	GetCertificate(*gin.Context)
+	UpdateAttributes(*gin.Context)
+	GetAttributes(*gin.Context)
+	GenerateSecret(*gin.Context)
+	DeleteSecret(*gin.Context)
+	GetSecret(*gin.Context)
+	CreatePublicKey(*gin.Context)
+	DeletePublicKey(*gin.Context)
+	GetPublicKeys(*gin.Context)
+	Simulate(*gin.Context)
 }
 
 type agentHandler struct {
@@ -257,6 +270,36 @@ func (h *agentHandler) GetPolicies(c *gin.Context) {
 	c.JSON(http.StatusOK, builder.ToPolicyResponses(dtos))
 }
 
+func (h *agentHandler) GetEffectivePolicies(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dtos, err := h.agentUsecase.GetEffectivePolicies(ctx, id, userID)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToEffectivePolicyResponses(dtos))
+}
+
 func (h *agentHandler) GenerateToken(c *gin.Context) {
 	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
 	if err != nil {
@@ -349,3 +392,408 @@ func (h *agentHandler) GetToken(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, builder.ToAgentTokenResponse(dto))
 }
+
+func (h *agentHandler) BindCertificate(c *gin.Context) {
+	var req request.BindAgentCertificateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.agentUsecase.BindCertificate(ctx, id, userID, req.Fingerprint, req.Subject)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToAgentCertificateResponse(dto))
+}
+
+func (h *agentHandler) UnbindCertificate(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := h.agentUsecase.UnbindCertificate(ctx, id, userID); err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *agentHandler) GetCertificate(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.agentUsecase.GetCertificate(ctx, id, userID)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	if dto == nil {
+		c.JSON(http.StatusOK, nil)
+		return
+	}
+	c.JSON(http.StatusOK, builder.ToAgentCertificateResponse(dto))
+}
+
+func (h *agentHandler) UpdateAttributes(c *gin.Context) {
+	var req request.UpdateAgentAttributesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.agentUsecase.UpdateAttributes(ctx, id, userID, req.Attributes)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToAgentAttributesResponse(dto))
+}
+
+func (h *agentHandler) GetAttributes(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.agentUsecase.GetAttributes(ctx, id, userID)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToAgentAttributesResponse(dto))
+}
+
+func (h *agentHandler) GenerateSecret(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.agentUsecase.GenerateSecret(ctx, id, userID)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToGeneratedAgentSecretResponse(dto))
+}
+
+func (h *agentHandler) DeleteSecret(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := h.agentUsecase.DeleteSecret(ctx, id, userID); err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *agentHandler) GetSecret(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.agentUsecase.GetSecret(ctx, id, userID)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	if dto == nil {
+		c.JSON(http.StatusOK, nil)
+		return
+	}
+	c.JSON(http.StatusOK, builder.ToAgentSecretResponse(dto))
+}
+
+func (h *agentHandler) CreatePublicKey(c *gin.Context) {
+	var req request.CreateAgentPublicKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.agentUsecase.CreatePublicKey(ctx, id, userID, req.PublicKey)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusCreated, builder.ToAgentPublicKeyResponse(dto))
+}
+
+func (h *agentHandler) DeletePublicKey(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	keyID, err := parameter.GetPathParameter[uuid.UUID](c, "key_id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := h.agentUsecase.DeletePublicKey(ctx, id, userID, keyID); err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *agentHandler) GetPublicKeys(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dtos, err := h.agentUsecase.GetPublicKeys(ctx, id, userID)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToAgentPublicKeyResponses(dtos))
+}
+
+func (h *agentHandler) Simulate(c *gin.Context) {
+	var req request.SimulateAgentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.agentUsecase.Simulate(ctx, id, userID, req.Service, req.Path, req.Method, req.SourceIP, req.Attributes)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToAuthorizationSimulationResponse(dto))
+}
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -644,6 +645,97 @@ func TestAgent_GetPolicies(t *testing.T) {
 	}
 }
 
+func TestAgent_GetEffectivePolicies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockAgentUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					GetEffectivePolicies(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*dto.EffectivePolicyDTO{{Policy: mapper.ToPolicyDTO(policy), Sources: []*dto.PolicySourceDTO{{Type: "DIRECT", ID: agent.ID, Name: agent.Name}}}}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "get effective policies error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					GetEffectivePolicies(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/agents/:id/effective-policies", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agent.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", agent.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAgentUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAgentHandler(u)
+			h.GetEffectivePolicies(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
 func TestAgent_GenerateToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -912,3 +1004,1179 @@ func TestAgent_GetToken(t *testing.T) {
 		})
 	}
 }
+
+func TestAgent_BindCertificate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentCertificate, err := entity.NewAgentCertificate(agent.ID, "", "CN=agent")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		requestJSON            string
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockAgentUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"subject": "CN=agent"}`,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					BindCertificate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mapper.ToAgentCertificateDTO(agentCertificate), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"subject": "CN=agent"}`,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			requestJSON:            `{"subject": "CN=agent"}`,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "invalid request",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            "",
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "bind certificate error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"subject": "CN=agent"}`,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					BindCertificate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("PUT", "/agents/:id/certificate", bytes.NewBuffer([]byte(tt.requestJSON)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agent.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", agent.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAgentUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAgentHandler(u)
+			h.BindCertificate(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestAgent_UnbindCertificate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockAgentUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					UnbindCertificate(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "unbind certificate error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					UnbindCertificate(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("DELETE", "/agents/:id/certificate", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agent.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", agent.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAgentUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAgentHandler(u)
+			h.UnbindCertificate(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestAgent_GetCertificate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentCertificate, err := entity.NewAgentCertificate(agent.ID, "", "CN=agent")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockAgentUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					GetCertificate(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mapper.ToAgentCertificateDTO(agentCertificate), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "not bound",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					GetCertificate(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "get certificate error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					GetCertificate(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/agents/:id/certificate", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agent.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", agent.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAgentUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAgentHandler(u)
+			h.GetCertificate(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestAgent_UpdateAttributes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentAttributes, err := entity.NewAgentAttributes(agent.ID, map[string]string{"team": "t1"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		requestJSON            string
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockAgentUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"attributes": {"team": "t1"}}`,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					UpdateAttributes(gomock.Any(), agent.ID, agent.UserID, map[string]string{"team": "t1"}).
+					Return(mapper.ToAgentAttributesDTO(agentAttributes), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"attributes": {"team": "t1"}}`,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			requestJSON:            `{"attributes": {"team": "t1"}}`,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "invalid request",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            "",
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "invalid attributes",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"attributes": {"Team": "t1"}}`,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					UpdateAttributes(gomock.Any(), agent.ID, agent.UserID, map[string]string{"Team": "t1"}).
+					Return(nil, entity.ErrInvalidAgentAttributeKey).
+					Times(1)
+			},
+		},
+		{
+			name:                   "update attributes error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"attributes": {"team": "t1"}}`,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					UpdateAttributes(gomock.Any(), agent.ID, agent.UserID, map[string]string{"team": "t1"}).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("PUT", "/agents/:id/attributes", bytes.NewBuffer([]byte(tt.requestJSON)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agent.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", agent.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAgentUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAgentHandler(u)
+			h.UpdateAttributes(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestAgent_GetAttributes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockAgentUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					GetAttributes(gomock.Any(), agent.ID, agent.UserID).
+					Return(&dto.AgentAttributesDTO{AgentID: agent.ID, Values: map[string]string{}}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "get attributes error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					GetAttributes(gomock.Any(), agent.ID, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/agents/:id/attributes", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agent.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", agent.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAgentUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAgentHandler(u)
+			h.GetAttributes(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestAgent_GenerateSecret(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentSecret, err := entity.NewAgentSecret(agent.ID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockAgentUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					GenerateSecret(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mapper.ToAgentSecretDTO(agentSecret), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "generate secret error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					GenerateSecret(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/agents/:id/secret", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agent.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", agent.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAgentUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAgentHandler(u)
+			h.GenerateSecret(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestAgent_DeleteSecret(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockAgentUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					DeleteSecret(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "delete secret error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					DeleteSecret(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("DELETE", "/agents/:id/secret", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agent.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", agent.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAgentUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAgentHandler(u)
+			h.DeleteSecret(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestAgent_GetSecret(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentSecret, err := entity.NewAgentSecret(agent.ID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockAgentUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					GetSecret(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mapper.ToAgentSecretDTO(agentSecret), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "not bound",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					GetSecret(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "get secret error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					GetSecret(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/agents/:id/secret", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agent.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", agent.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAgentUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAgentHandler(u)
+			h.GetSecret(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestAgent_CreatePublicKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentPublicKey := entity.RestoreAgentPublicKey(uuid.New(), agent.ID, "EdDSA", "public_key", time.Now())
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		requestJSON            string
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockAgentUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"public_key": "public_key"}`,
+			expectStatusCode:       http.StatusCreated,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					CreatePublicKey(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mapper.ToAgentPublicKeyDTO(agentPublicKey), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"public_key": "public_key"}`,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			requestJSON:            `{"public_key": "public_key"}`,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "invalid request",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            "",
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "create public key error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"public_key": "public_key"}`,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					CreatePublicKey(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/agents/:id/keys", bytes.NewBuffer([]byte(tt.requestJSON)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agent.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", agent.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAgentUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAgentHandler(u)
+			h.CreatePublicKey(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestAgent_DeletePublicKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                      string
+		isSetIDToPathParameter    bool
+		isSetKeyIDToPathParameter bool
+		isSetUserIDToContext      bool
+		expectStatusCode          int
+		setMockUsecase            func(*mockUsecase.MockAgentUsecase)
+	}{
+		{
+			name:                      "success",
+			isSetIDToPathParameter:    true,
+			isSetKeyIDToPathParameter: true,
+			isSetUserIDToContext:      true,
+			expectStatusCode:          http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					DeletePublicKey(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                      "no id in path parameter",
+			isSetIDToPathParameter:    false,
+			isSetKeyIDToPathParameter: true,
+			isSetUserIDToContext:      true,
+			expectStatusCode:          http.StatusBadRequest,
+			setMockUsecase:            func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                      "no key id in path parameter",
+			isSetIDToPathParameter:    true,
+			isSetKeyIDToPathParameter: false,
+			isSetUserIDToContext:      true,
+			expectStatusCode:          http.StatusBadRequest,
+			setMockUsecase:            func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                      "no user id in context",
+			isSetIDToPathParameter:    true,
+			isSetKeyIDToPathParameter: true,
+			isSetUserIDToContext:      false,
+			expectStatusCode:          http.StatusInternalServerError,
+			setMockUsecase:            func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                      "delete public key error",
+			isSetIDToPathParameter:    true,
+			isSetKeyIDToPathParameter: true,
+			isSetUserIDToContext:      true,
+			expectStatusCode:          http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					DeletePublicKey(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("DELETE", "/agents/:id/keys/:key_id", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agent.ID.String()})
+			}
+			if tt.isSetKeyIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "key_id", Value: uuid.New().String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", agent.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAgentUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAgentHandler(u)
+			h.DeletePublicKey(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestAgent_GetPublicKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentPublicKey := entity.RestoreAgentPublicKey(uuid.New(), agent.ID, "EdDSA", "public_key", time.Now())
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockAgentUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					GetPublicKeys(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*dto.AgentPublicKeyDTO{mapper.ToAgentPublicKeyDTO(agentPublicKey)}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "get public keys error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					GetPublicKeys(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/agents/:id/keys", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agent.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", agent.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAgentUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAgentHandler(u)
+			h.GetPublicKeys(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestAgent_Simulate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(agent.UserID, "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	decision := entity.NewAuthorizationDecision([]*entity.PolicyEvaluation{{Policy: policy, Matched: true, Result: entity.PolicyMatchResultMatched}})
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		requestJSON            string
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockAgentUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"service": "STORAGE", "path": "/", "method": "GET", "source_ip": "10.0.0.1", "attributes": {"tenant": "holos"}}`,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					Simulate(gomock.Any(), agent.ID, agent.UserID, "STORAGE", "/", "GET", "10.0.0.1", map[string]string{"tenant": "holos"}).
+					Return(mapper.ToAuthorizationDecisionDTO(decision), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"service": "STORAGE", "path": "/", "method": "GET"}`,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			requestJSON:            `{"service": "STORAGE", "path": "/", "method": "GET"}`,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "invalid request",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            "",
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockAgentUsecase) {},
+		},
+		{
+			name:                   "simulate error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"service": "STORAGE", "path": "/", "method": "GET"}`,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAgentUsecase) {
+				u.EXPECT().
+					Simulate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/agents/:id/simulate", bytes.NewBuffer([]byte(tt.requestJSON)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agent.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", agent.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAgentUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAgentHandler(u)
+			h.Simulate(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
@@ -1,20 +1,30 @@
 package handler
 
 import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"holos-auth-api/internal/app/api/interface/builder"
 	"holos-auth-api/internal/app/api/interface/pkg/errors"
 	"holos-auth-api/internal/app/api/interface/request"
 	"holos-auth-api/internal/app/api/usecase"
+	"holos-auth-api/internal/app/api/usecase/dto"
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 type AuthHandler interface {
 	Signin(*gin.Context)
 	Signout(*gin.Context)
+	Reauthenticate(*gin.Context)
 	Authorize(*gin.Context)
+	AuthorizeBatch(*gin.Context)
+	CreateDelegatedToken(*gin.Context)
 }
 
 type authHandler struct {
@@ -70,7 +80,7 @@ func (h *authHandler) Signout(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
-func (h *authHandler) Authorize(c *gin.Context) {
+func (h *authHandler) Reauthenticate(c *gin.Context) {
 	bearerToken := strings.Split(c.Request.Header.Get("Authorization"), " ")
 	if len(bearerToken) != 2 || bearerToken[0] != "Bearer" {
 		status := errors.StatusUnauthorized
@@ -79,15 +89,121 @@ func (h *authHandler) Authorize(c *gin.Context) {
 		return
 	}
 
+	var req request.ReauthenticateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := h.authUsecase.Reauthenticate(ctx, bearerToken[1], req.Password); err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *authHandler) Authorize(c *gin.Context) {
 	operatorType := c.Request.Header.Get("Holos-Operator-Type")
 
 	service := c.Query("service")
 	path := c.Query("path")
 	method := c.Query("method")
+	// ポリシーの条件評価に使うリクエスト元のIPアドレスと属性. 属性は attributes[key]=value の形式で受け取る.
+	sourceIP := c.Query("source_ip")
+	attributes := c.QueryMap("attributes")
+
+	ctx := c.Request.Context()
+
+	// Authorizationヘッダがない場合は検証済みのクライアント証明書でエージェントを認証する.
+	if certificate := getVerifiedClientCertificate(c); certificate != nil && operatorType == "AGENT" && c.Request.Header.Get("Authorization") == "" {
+		fingerprint := sha256.Sum256(certificate.Raw)
+
+		result, err := h.authUsecase.AuthorizeByCertificate(ctx, hex.EncodeToString(fingerprint[:]), certificate.Subject.String(), service, path, method, sourceIP, attributes)
+		writeAuthorizationResult(c, result, err)
+		return
+	}
+
+	// 署名付きリクエストの場合はHMAC署名でエージェントを認証する.
+	if scheme, credential, _ := strings.Cut(c.Request.Header.Get("Authorization"), " "); scheme == "HOLOS-HMAC-SHA256" && operatorType == "AGENT" {
+		keyID, signature := parseSignatureCredential(credential)
+
+		result, err := h.authUsecase.AuthorizeBySignature(
+			ctx,
+			keyID,
+			signature,
+			c.Request.Header.Get("Holos-Timestamp"),
+			c.Request.Header.Get("Holos-Nonce"),
+			c.Request.Header.Get("Holos-Content-SHA256"),
+			service,
+			path,
+			method,
+			sourceIP,
+			attributes,
+		)
+		writeAuthorizationResult(c, result, err)
+		return
+	}
+
+	// 署名付きJWTアサーションの場合は登録済みの公開鍵でエージェントを認証する.
+	if scheme, assertion, _ := strings.Cut(c.Request.Header.Get("Authorization"), " "); scheme == "Assertion" && operatorType == "AGENT" {
+		result, err := h.authUsecase.AuthorizeByAssertion(ctx, assertion, service, path, method, sourceIP, attributes)
+		writeAuthorizationResult(c, result, err)
+		return
+	}
+
+	bearerToken := strings.Split(c.Request.Header.Get("Authorization"), " ")
+	if len(bearerToken) != 2 || bearerToken[0] != "Bearer" {
+		status := errors.StatusUnauthorized
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	result, err := h.authUsecase.Authorize(ctx, bearerToken[1], operatorType, service, path, method, sourceIP, attributes)
+	writeAuthorizationResult(c, result, err)
+}
+
+// 1つのトークンで複数のリクエストを判定する. 拒否された判定を含む場合も200で, リクエストと同じ順に判定の内容を返す.
+func (h *authHandler) AuthorizeBatch(c *gin.Context) {
+	operatorType := c.Request.Header.Get("Holos-Operator-Type")
+
+	bearerToken := strings.Split(c.Request.Header.Get("Authorization"), " ")
+	if len(bearerToken) != 2 || bearerToken[0] != "Bearer" {
+		status := errors.StatusUnauthorized
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	var req request.AuthorizeBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	requests := make([]*dto.AuthorizationRequestDTO, len(req.Requests))
+	for i, r := range req.Requests {
+		requests[i] = &dto.AuthorizationRequestDTO{
+			Service:    r.Service,
+			Path:       r.Path,
+			Method:     r.Method,
+			SourceIP:   r.SourceIP,
+			Attributes: r.Attributes,
+		}
+	}
 
 	ctx := c.Request.Context()
 
-	userID, err := h.authUsecase.Authorize(ctx, bearerToken[1], operatorType, service, path, method)
+	results, err := h.authUsecase.AuthorizeBatch(ctx, bearerToken[1], operatorType, requests)
 	if err != nil {
 		status := errors.HandleError(err)
 		log.Println(status.Message())
@@ -95,5 +211,79 @@ func (h *authHandler) Authorize(c *gin.Context) {
 		return
 	}
 
-	c.String(http.StatusOK, userID.String())
+	c.JSON(http.StatusOK, builder.ToAuthorizationResponses(results))
+}
+
+func (h *authHandler) CreateDelegatedToken(c *gin.Context) {
+	bearerToken := strings.Split(c.Request.Header.Get("Authorization"), " ")
+	if len(bearerToken) != 2 || bearerToken[0] != "Bearer" {
+		status := errors.StatusUnauthorized
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	var req request.CreateDelegatedTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	agentDelegatedToken, err := h.authUsecase.CreateDelegatedToken(ctx, bearerToken[1], req.PolicyIDs, time.Duration(req.TTL)*time.Second)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusCreated, builder.ToAgentDelegatedTokenResponse(agentDelegatedToken))
+}
+
+// Acceptヘッダでapplication/jsonを指定された場合のみ, 判定の内容をJSONで返す. ポリシーで拒否された場合も403とともに返す.
+// 指定がない場合は従来どおり, 許可したユーザーのIDを文字列で返す.
+func writeAuthorizationResult(c *gin.Context, result *dto.AuthorizationResultDTO, err error) {
+	asJSON := c.NegotiateFormat(binding.MIMEPlain, binding.MIMEJSON) == binding.MIMEJSON
+
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		if asJSON && result != nil {
+			c.JSON(status.Code(), builder.ToAuthorizationResponse(result))
+			return
+		}
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	if asJSON {
+		c.JSON(http.StatusOK, builder.ToAuthorizationResponse(result))
+		return
+	}
+	c.String(http.StatusOK, result.UserID.String())
+}
+
+func getVerifiedClientCertificate(c *gin.Context) *x509.Certificate {
+	if c.Request.TLS == nil || len(c.Request.TLS.VerifiedChains) == 0 || len(c.Request.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return c.Request.TLS.VerifiedChains[0][0]
+}
+
+func parseSignatureCredential(credential string) (string, string) {
+	var keyID, signature string
+	for _, param := range strings.Split(credential, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		switch key {
+		case "KeyId":
+			keyID = value
+		case "Signature":
+			signature = value
+		}
+	}
+	return keyID, signature
 }
@@ -2,13 +2,20 @@ package handler_test
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"database/sql"
 	"holos-auth-api/internal/app/api/domain/entity"
 	"holos-auth-api/internal/app/api/interface/handler"
+	"holos-auth-api/internal/app/api/usecase"
+	"holos-auth-api/internal/app/api/usecase/dto"
 	mockUsecase "holos-auth-api/test/mock/usecase"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -163,11 +170,34 @@ func TestAuth_Authorize(t *testing.T) {
 	if err != nil {
 		t.Error(err.Error())
 	}
+	certificate := &x509.Certificate{
+		Raw:     []byte("certificate"),
+		Subject: pkix.Name{CommonName: "agent"},
+	}
+	result := &dto.AuthorizationResultDTO{
+		OperatorType: "AGENT",
+		UserID:       userToken.UserID,
+		AgentID:      uuid.New(),
+		Allowed:      true,
+		CacheTTL:     entity.AuthorizationDecisionCacheTTL,
+	}
+	deniedResult := &dto.AuthorizationResultDTO{
+		OperatorType: "AGENT",
+		UserID:       userToken.UserID,
+		AgentID:      result.AgentID,
+		Allowed:      false,
+		DenyReason:   entity.AuthorizationDenyReasonNoMatchingPolicy,
+	}
 
 	tests := []struct {
 		name                string
+		query               string
 		authorizationHeader string
+		operatorTypeHeader  string
+		clientCertificate   *x509.Certificate
+		acceptHeader        string
 		expectStatusCode    int
+		expectContentType   string
 		setMockUsecase      func(*mockUsecase.MockAuthUsecase)
 	}{
 		{
@@ -176,8 +206,151 @@ func TestAuth_Authorize(t *testing.T) {
 			expectStatusCode:    http.StatusOK,
 			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
 				u.EXPECT().
-					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(userToken.UserID, nil).
+					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(result, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "success with json",
+			authorizationHeader: "Bearer " + userToken.Token,
+			operatorTypeHeader:  "AGENT",
+			acceptHeader:        "application/json",
+			expectStatusCode:    http.StatusOK,
+			expectContentType:   "application/json",
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(result, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "denied",
+			authorizationHeader: "Bearer " + userToken.Token,
+			operatorTypeHeader:  "AGENT",
+			expectStatusCode:    http.StatusForbidden,
+			expectContentType:   "text/plain",
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(deniedResult, usecase.ErrAuthorizationFaild).
+					Times(1)
+			},
+		},
+		{
+			name:                "denied with json",
+			authorizationHeader: "Bearer " + userToken.Token,
+			operatorTypeHeader:  "AGENT",
+			acceptHeader:        "application/json",
+			expectStatusCode:    http.StatusForbidden,
+			expectContentType:   "application/json",
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(deniedResult, usecase.ErrAuthorizationFaild).
+					Times(1)
+			},
+		},
+		{
+			name:                "authentication error with json",
+			authorizationHeader: "Bearer " + userToken.Token,
+			operatorTypeHeader:  "AGENT",
+			acceptHeader:        "application/json",
+			expectStatusCode:    http.StatusUnauthorized,
+			expectContentType:   "text/plain",
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, usecase.ErrAuthenticationFailed).
+					Times(1)
+			},
+		},
+		{
+			name:                "success with source ip and attributes",
+			query:               "service=STORAGE&path=/&method=GET&source_ip=10.0.0.1&attributes[tenant]=holos",
+			authorizationHeader: "Bearer " + userToken.Token,
+			operatorTypeHeader:  "AGENT",
+			expectStatusCode:    http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					Authorize(gomock.Any(), userToken.Token, "AGENT", "STORAGE", "/", "GET", "10.0.0.1", map[string]string{"tenant": "holos"}).
+					Return(result, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "success with client certificate",
+			authorizationHeader: "",
+			operatorTypeHeader:  "AGENT",
+			clientCertificate:   certificate,
+			expectStatusCode:    http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					AuthorizeByCertificate(gomock.Any(), gomock.Any(), "CN=agent", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(result, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "success with signature",
+			authorizationHeader: "HOLOS-HMAC-SHA256 KeyId=key_id, Signature=signature",
+			operatorTypeHeader:  "AGENT",
+			expectStatusCode:    http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					AuthorizeBySignature(gomock.Any(), "key_id", "signature", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(result, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "signature with user operator",
+			authorizationHeader: "HOLOS-HMAC-SHA256 KeyId=key_id, Signature=signature",
+			operatorTypeHeader:  "USER",
+			expectStatusCode:    http.StatusUnauthorized,
+			setMockUsecase:      func(u *mockUsecase.MockAuthUsecase) {},
+		},
+		{
+			name:                "authorize by signature error",
+			authorizationHeader: "HOLOS-HMAC-SHA256 KeyId=key_id, Signature=signature",
+			operatorTypeHeader:  "AGENT",
+			expectStatusCode:    http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					AuthorizeBySignature(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:                "success with assertion",
+			authorizationHeader: "Assertion assertion",
+			operatorTypeHeader:  "AGENT",
+			expectStatusCode:    http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					AuthorizeByAssertion(gomock.Any(), "assertion", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(result, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "assertion with user operator",
+			authorizationHeader: "Assertion assertion",
+			operatorTypeHeader:  "USER",
+			expectStatusCode:    http.StatusUnauthorized,
+			setMockUsecase:      func(u *mockUsecase.MockAuthUsecase) {},
+		},
+		{
+			name:                "authorize by assertion error",
+			authorizationHeader: "Assertion assertion",
+			operatorTypeHeader:  "AGENT",
+			expectStatusCode:    http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					AuthorizeByAssertion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
 		},
@@ -187,25 +360,55 @@ func TestAuth_Authorize(t *testing.T) {
 			expectStatusCode:    http.StatusUnauthorized,
 			setMockUsecase:      func(u *mockUsecase.MockAuthUsecase) {},
 		},
+		{
+			name:                "client certificate with user operator",
+			authorizationHeader: "",
+			operatorTypeHeader:  "USER",
+			clientCertificate:   certificate,
+			expectStatusCode:    http.StatusUnauthorized,
+			setMockUsecase:      func(u *mockUsecase.MockAuthUsecase) {},
+		},
+		{
+			name:                "authorize by certificate error",
+			authorizationHeader: "",
+			operatorTypeHeader:  "AGENT",
+			clientCertificate:   certificate,
+			expectStatusCode:    http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					AuthorizeByCertificate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
 		{
 			name:                "signout error",
 			authorizationHeader: "Bearer " + userToken.Token,
 			expectStatusCode:    http.StatusInternalServerError,
 			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
 				u.EXPECT().
-					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(uuid.Nil, sql.ErrConnDone).
+					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			req, err := http.NewRequest("GET", "/auth/user_id", nil)
+			req, err := http.NewRequest("GET", "/auth/authorization?"+tt.query, nil)
 			if err != nil {
 				t.Error(err.Error())
 			}
 			req.Header.Add("Authorization", tt.authorizationHeader)
+			req.Header.Add("Holos-Operator-Type", tt.operatorTypeHeader)
+			if tt.acceptHeader != "" {
+				req.Header.Add("Accept", tt.acceptHeader)
+			}
+			if tt.clientCertificate != nil {
+				req.TLS = &tls.ConnectionState{
+					VerifiedChains: [][]*x509.Certificate{{tt.clientCertificate}},
+				}
+			}
 			w := httptest.NewRecorder()
 
 			ctx, _ := gin.CreateTestContext(w)
@@ -220,6 +423,319 @@ func TestAuth_Authorize(t *testing.T) {
 			h := handler.NewAuthHandler(u)
 			h.Authorize(ctx)
 
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+			if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, tt.expectContentType) {
+				t.Errorf("\nexpect: %s \ngot: %s", tt.expectContentType, contentType)
+			}
+		})
+	}
+}
+
+func TestAuth_AuthorizeBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agentToken, err := entity.NewAgentToken(uuid.New())
+	if err != nil {
+		t.Error(err.Error())
+	}
+	results := []*dto.AuthorizationResultDTO{
+		{OperatorType: "AGENT", UserID: uuid.New(), AgentID: agentToken.AgentID, Allowed: true, CacheTTL: entity.AuthorizationDecisionCacheTTL},
+		{OperatorType: "AGENT", UserID: uuid.New(), AgentID: agentToken.AgentID, Allowed: false, DenyReason: entity.AuthorizationDenyReasonNoMatchingPolicy},
+	}
+	requestJSON := []byte(`{"requests": [{"service": "STORAGE", "path": "/", "method": "GET"}, {"service": "STORAGE", "path": "/", "method": "POST", "source_ip": "192.0.2.1", "attributes": {"team": "a"}}]}`)
+
+	tests := []struct {
+		name                string
+		authorizationHeader string
+		requestJSON         []byte
+		expectStatusCode    int
+		setMockUsecase      func(*mockUsecase.MockAuthUsecase)
+	}{
+		{
+			name:                "success",
+			authorizationHeader: "Bearer " + agentToken.Token,
+			requestJSON:         requestJSON,
+			expectStatusCode:    http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					AuthorizeBatch(gomock.Any(), agentToken.Token, "AGENT", []*dto.AuthorizationRequestDTO{
+						{Service: "STORAGE", Path: "/", Method: "GET"},
+						{Service: "STORAGE", Path: "/", Method: "POST", SourceIP: "192.0.2.1", Attributes: map[string]string{"team": "a"}},
+					}).
+					Return(results, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "invalid header",
+			authorizationHeader: "",
+			requestJSON:         requestJSON,
+			expectStatusCode:    http.StatusUnauthorized,
+			setMockUsecase:      func(u *mockUsecase.MockAuthUsecase) {},
+		},
+		{
+			name:                "invalid request",
+			authorizationHeader: "Bearer " + agentToken.Token,
+			requestJSON:         nil,
+			expectStatusCode:    http.StatusBadRequest,
+			setMockUsecase:      func(u *mockUsecase.MockAuthUsecase) {},
+		},
+		{
+			name:                "too many requests",
+			authorizationHeader: "Bearer " + agentToken.Token,
+			requestJSON:         requestJSON,
+			expectStatusCode:    http.StatusBadRequest,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					AuthorizeBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, entity.ErrTooManyAuthorizationRequests).
+					Times(1)
+			},
+		},
+		{
+			name:                "authentication failed",
+			authorizationHeader: "Bearer " + agentToken.Token,
+			requestJSON:         requestJSON,
+			expectStatusCode:    http.StatusUnauthorized,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					AuthorizeBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, usecase.ErrAuthenticationFailed).
+					Times(1)
+			},
+		},
+		{
+			name:                "authorize batch error",
+			authorizationHeader: "Bearer " + agentToken.Token,
+			requestJSON:         requestJSON,
+			expectStatusCode:    http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					AuthorizeBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/auth/authorization/batch", bytes.NewBuffer(tt.requestJSON))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			req.Header.Add("Authorization", tt.authorizationHeader)
+			req.Header.Add("Holos-Operator-Type", "AGENT")
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAuthUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAuthHandler(u)
+			h.AuthorizeBatch(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestAuth_CreateDelegatedToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agentToken, err := entity.NewAgentToken(uuid.New())
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyID := uuid.New()
+
+	tests := []struct {
+		name                string
+		authorizationHeader string
+		requestJSON         []byte
+		expectStatusCode    int
+		setMockUsecase      func(*mockUsecase.MockAuthUsecase)
+	}{
+		{
+			name:                "success",
+			authorizationHeader: "Bearer " + agentToken.Token,
+			requestJSON:         []byte(`{"policy_ids": ["` + policyID.String() + `"], "ttl": 300}`),
+			expectStatusCode:    http.StatusCreated,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					CreateDelegatedToken(gomock.Any(), agentToken.Token, []uuid.UUID{policyID}, 300*time.Second).
+					Return(&dto.AgentDelegatedTokenDTO{
+						AgentID:   agentToken.AgentID,
+						Token:     "hsd_1Ty1HKTPKTt8xEi-_3HTbWf2SCHOdqOSfdf6f507",
+						Policies:  []uuid.UUID{policyID},
+						ExpiresAt: time.Now().Add(300 * time.Second),
+						CreatedAt: time.Now(),
+					}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "invalid header",
+			authorizationHeader: "",
+			requestJSON:         []byte(`{"policy_ids": ["` + policyID.String() + `"], "ttl": 300}`),
+			expectStatusCode:    http.StatusUnauthorized,
+			setMockUsecase:      func(u *mockUsecase.MockAuthUsecase) {},
+		},
+		{
+			name:                "invalid request",
+			authorizationHeader: "Bearer " + agentToken.Token,
+			requestJSON:         nil,
+			expectStatusCode:    http.StatusBadRequest,
+			setMockUsecase:      func(u *mockUsecase.MockAuthUsecase) {},
+		},
+		{
+			name:                "policy not granted",
+			authorizationHeader: "Bearer " + agentToken.Token,
+			requestJSON:         []byte(`{"policy_ids": ["` + policyID.String() + `"], "ttl": 300}`),
+			expectStatusCode:    http.StatusBadRequest,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					CreateDelegatedToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, entity.ErrAgentDelegatedTokenPoliciesNotGranted).
+					Times(1)
+			},
+		},
+		{
+			name:                "create delegated token error",
+			authorizationHeader: "Bearer " + agentToken.Token,
+			requestJSON:         []byte(`{"policy_ids": ["` + policyID.String() + `"], "ttl": 300}`),
+			expectStatusCode:    http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					CreateDelegatedToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/auth/delegated-tokens", bytes.NewBuffer(tt.requestJSON))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			req.Header.Add("Authorization", tt.authorizationHeader)
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAuthUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAuthHandler(u)
+			h.CreateDelegatedToken(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestAuth_Reauthenticate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userToken, err := entity.NewUserToken(uuid.New())
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                string
+		authorizationHeader string
+		requestJSON         []byte
+		expectStatusCode    int
+		setMockUsecase      func(*mockUsecase.MockAuthUsecase)
+	}{
+		{
+			name:                "success",
+			authorizationHeader: "Bearer " + userToken.Token,
+			requestJSON:         []byte(`{"password": "password"}`),
+			expectStatusCode:    http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					Reauthenticate(gomock.Any(), userToken.Token, "password").
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "invalid header",
+			authorizationHeader: "",
+			requestJSON:         []byte(`{"password": "password"}`),
+			expectStatusCode:    http.StatusUnauthorized,
+			setMockUsecase:      func(u *mockUsecase.MockAuthUsecase) {},
+		},
+		{
+			name:                "invalid request",
+			authorizationHeader: "Bearer " + userToken.Token,
+			requestJSON:         nil,
+			expectStatusCode:    http.StatusBadRequest,
+			setMockUsecase:      func(u *mockUsecase.MockAuthUsecase) {},
+		},
+		{
+			name:                "wrong password",
+			authorizationHeader: "Bearer " + userToken.Token,
+			requestJSON:         []byte(`{"password": "wrong"}`),
+			expectStatusCode:    http.StatusUnauthorized,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					Reauthenticate(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entity.ErrAuthenticationFailed).
+					Times(1)
+			},
+		},
+		{
+			name:                "reauthenticate error",
+			authorizationHeader: "Bearer " + userToken.Token,
+			requestJSON:         []byte(`{"password": "password"}`),
+			expectStatusCode:    http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					Reauthenticate(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/auth/reauthenticate", bytes.NewBuffer(tt.requestJSON))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			req.Header.Add("Authorization", tt.authorizationHeader)
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAuthUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewAuthHandler(u)
+			h.Reauthenticate(ctx)
+
 			if w.Code != tt.expectStatusCode {
 				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
 			}
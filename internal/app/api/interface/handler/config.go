@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"holos-auth-api/internal/app/api/interface/builder"
+	"holos-auth-api/internal/app/api/interface/pkg/errors"
+	"holos-auth-api/internal/app/api/interface/pkg/parameter"
+	"holos-auth-api/internal/app/api/interface/request"
+	"holos-auth-api/internal/app/api/usecase"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ConfigHandler interface {
+	Plan(*gin.Context)
+	Apply(*gin.Context)
+}
+
+type configHandler struct {
+	configUsecase usecase.ConfigUsecase
+}
+
+func NewConfigHandler(configUsecase usecase.ConfigUsecase) ConfigHandler {
+	return &configHandler{
+		configUsecase: configUsecase,
+	}
+}
+
+func (h *configHandler) Plan(c *gin.Context) {
+	var req request.PolicyDocumentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.configUsecase.Plan(ctx, userID, toPolicyDocumentDTO(&req))
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToConfigPlanResponse(dto))
+}
+
+// 計画と同じ文書を送り, 計画で返されたfingerprintをクエリで指定する.
+func (h *configHandler) Apply(c *gin.Context) {
+	var req request.PolicyDocumentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	fingerprint := c.Query("fingerprint")
+	if fingerprint == "" {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.configUsecase.Apply(ctx, userID, toPolicyDocumentDTO(&req), fingerprint)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToConfigPlanResponse(dto))
+}
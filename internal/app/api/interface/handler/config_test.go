@@ -0,0 +1,217 @@
+package handler_test
+
+import (
+	"bytes"
+	"database/sql"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/interface/handler"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	mockUsecase "holos-auth-api/test/mock/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func TestConfig_Plan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	plan := &dto.ConfigPlanDTO{
+		Fingerprint: "fingerprint",
+		Changes:     []*dto.ConfigChangeDTO{{Resource: entity.ConfigResourcePolicy, Action: entity.ConfigActionCreate, Name: "policy", Fields: []string{}}},
+	}
+	requestJSON := `{"version": 1, "policies": [{"name": "policy", "effect": "ALLOW", "service": "STORAGE", "path": "/", "methods": ["GET"]}]}`
+
+	tests := []struct {
+		name                 string
+		isSetUserIDToContext bool
+		requestBody          string
+		expectStatusCode     int
+		setMockUsecase       func(*mockUsecase.MockConfigUsecase)
+	}{
+		{
+			name:                 "success",
+			isSetUserIDToContext: true,
+			requestBody:          requestJSON,
+			expectStatusCode:     http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockConfigUsecase) {
+				u.EXPECT().
+					Plan(gomock.Any(), userID, gomock.Any()).
+					Return(plan, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                 "no user id in context",
+			isSetUserIDToContext: false,
+			requestBody:          requestJSON,
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase:       func(u *mockUsecase.MockConfigUsecase) {},
+		},
+		{
+			name:                 "invalid request",
+			isSetUserIDToContext: true,
+			requestBody:          "",
+			expectStatusCode:     http.StatusBadRequest,
+			setMockUsecase:       func(u *mockUsecase.MockConfigUsecase) {},
+		},
+		{
+			name:                 "plan error",
+			isSetUserIDToContext: true,
+			requestBody:          requestJSON,
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockConfigUsecase) {
+				u.EXPECT().
+					Plan(gomock.Any(), userID, gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/config/plan", bytes.NewBuffer([]byte(tt.requestBody)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", userID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockConfigUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewConfigHandler(u)
+			h.Plan(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestConfig_Apply(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	plan := &dto.ConfigPlanDTO{
+		Fingerprint: "fingerprint",
+		Changes:     []*dto.ConfigChangeDTO{{Resource: entity.ConfigResourcePolicy, Action: entity.ConfigActionCreate, Name: "policy", Fields: []string{}}},
+	}
+	requestJSON := `{"version": 1, "policies": [{"name": "policy", "effect": "ALLOW", "service": "STORAGE", "path": "/", "methods": ["GET"]}]}`
+
+	tests := []struct {
+		name                 string
+		isSetUserIDToContext bool
+		requestBody          string
+		requestQuery         string
+		expectStatusCode     int
+		setMockUsecase       func(*mockUsecase.MockConfigUsecase)
+	}{
+		{
+			name:                 "success",
+			isSetUserIDToContext: true,
+			requestBody:          requestJSON,
+			requestQuery:         "?fingerprint=fingerprint",
+			expectStatusCode:     http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockConfigUsecase) {
+				u.EXPECT().
+					Apply(gomock.Any(), userID, gomock.Any(), "fingerprint").
+					Return(plan, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                 "no user id in context",
+			isSetUserIDToContext: false,
+			requestBody:          requestJSON,
+			requestQuery:         "?fingerprint=fingerprint",
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase:       func(u *mockUsecase.MockConfigUsecase) {},
+		},
+		{
+			name:                 "invalid request",
+			isSetUserIDToContext: true,
+			requestBody:          "",
+			requestQuery:         "?fingerprint=fingerprint",
+			expectStatusCode:     http.StatusBadRequest,
+			setMockUsecase:       func(u *mockUsecase.MockConfigUsecase) {},
+		},
+		{
+			name:                 "no fingerprint",
+			isSetUserIDToContext: true,
+			requestBody:          requestJSON,
+			requestQuery:         "",
+			expectStatusCode:     http.StatusBadRequest,
+			setMockUsecase:       func(u *mockUsecase.MockConfigUsecase) {},
+		},
+		{
+			name:                 "state changed",
+			isSetUserIDToContext: true,
+			requestBody:          requestJSON,
+			requestQuery:         "?fingerprint=fingerprint",
+			expectStatusCode:     http.StatusConflict,
+			setMockUsecase: func(u *mockUsecase.MockConfigUsecase) {
+				u.EXPECT().
+					Apply(gomock.Any(), userID, gomock.Any(), "fingerprint").
+					Return(nil, entity.ErrConfigStateChanged).
+					Times(1)
+			},
+		},
+		{
+			name:                 "apply error",
+			isSetUserIDToContext: true,
+			requestBody:          requestJSON,
+			requestQuery:         "?fingerprint=fingerprint",
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockConfigUsecase) {
+				u.EXPECT().
+					Apply(gomock.Any(), userID, gomock.Any(), "fingerprint").
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/config/apply"+tt.requestQuery, bytes.NewBuffer([]byte(tt.requestBody)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", userID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockConfigUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewConfigHandler(u)
+			h.Apply(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
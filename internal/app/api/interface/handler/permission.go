@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"holos-auth-api/internal/app/api/interface/builder"
+	"holos-auth-api/internal/app/api/interface/pkg/errors"
+	"holos-auth-api/internal/app/api/interface/pkg/parameter"
+	"holos-auth-api/internal/app/api/interface/request"
+	"holos-auth-api/internal/app/api/usecase"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PermissionHandler interface {
+	Gets(*gin.Context)
+	Grant(*gin.Context)
+	Revoke(*gin.Context)
+}
+
+type permissionHandler struct {
+	permissionUsecase usecase.PermissionUsecase
+}
+
+func NewPermissionHandler(permissionUsecase usecase.PermissionUsecase) PermissionHandler {
+	return &permissionHandler{
+		permissionUsecase: permissionUsecase,
+	}
+}
+
+func (h *permissionHandler) Gets(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dtos, err := h.permissionUsecase.Gets(ctx, id, userID)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToPermissionResponses(dtos))
+}
+
+func (h *permissionHandler) Grant(c *gin.Context) {
+	var req request.GrantPermissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	policyID, err := parameter.GetPathParameter[uuid.UUID](c, "policy_id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.permissionUsecase.Grant(ctx, id, policyID, userID, req.ValidFrom, req.ValidUntil)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToPermissionResponse(dto))
+}
+
+func (h *permissionHandler) Revoke(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	policyID, err := parameter.GetPathParameter[uuid.UUID](c, "policy_id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := h.permissionUsecase.Revoke(ctx, id, policyID, userID); err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
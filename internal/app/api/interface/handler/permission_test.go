@@ -0,0 +1,316 @@
+package handler_test
+
+import (
+	"bytes"
+	"database/sql"
+	"holos-auth-api/internal/app/api/interface/handler"
+	"holos-auth-api/internal/app/api/usecase"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	mockUsecase "holos-auth-api/test/mock/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func TestPermission_Gets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agentID := uuid.New()
+	userID := uuid.New()
+	validUntil := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockPermissionUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPermissionUsecase) {
+				u.EXPECT().
+					Gets(gomock.Any(), agentID, userID).
+					Return([]*dto.PermissionDTO{{AgentID: agentID, PolicyID: uuid.New(), ValidUntil: &validUntil}}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPermissionUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockPermissionUsecase) {},
+		},
+		{
+			name:                   "agent not found",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusNotFound,
+			setMockUsecase: func(u *mockUsecase.MockPermissionUsecase) {
+				u.EXPECT().
+					Gets(gomock.Any(), agentID, userID).
+					Return(nil, usecase.ErrAgentNotFound).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/agents/:id/permissions", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agentID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", userID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPermissionUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPermissionHandler(u)
+			h.Gets(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPermission_Grant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agentID := uuid.New()
+	policyID := uuid.New()
+	userID := uuid.New()
+	validUntil := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                         string
+		isSetIDToPathParameter       bool
+		isSetPolicyIDToPathParameter bool
+		isSetUserIDToContext         bool
+		requestJSON                  string
+		expectStatusCode             int
+		setMockUsecase               func(*mockUsecase.MockPermissionUsecase)
+	}{
+		{
+			name:                         "success",
+			isSetIDToPathParameter:       true,
+			isSetPolicyIDToPathParameter: true,
+			isSetUserIDToContext:         true,
+			requestJSON:                  `{"valid_until": "2030-01-01T00:00:00Z"}`,
+			expectStatusCode:             http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPermissionUsecase) {
+				u.EXPECT().
+					Grant(gomock.Any(), agentID, policyID, userID, nil, &validUntil).
+					Return(&dto.PermissionDTO{AgentID: agentID, PolicyID: policyID, ValidUntil: &validUntil}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                         "no id in path parameter",
+			isSetIDToPathParameter:       false,
+			isSetPolicyIDToPathParameter: true,
+			isSetUserIDToContext:         true,
+			requestJSON:                  `{}`,
+			expectStatusCode:             http.StatusBadRequest,
+			setMockUsecase:               func(u *mockUsecase.MockPermissionUsecase) {},
+		},
+		{
+			name:                         "no policy id in path parameter",
+			isSetIDToPathParameter:       true,
+			isSetPolicyIDToPathParameter: false,
+			isSetUserIDToContext:         true,
+			requestJSON:                  `{}`,
+			expectStatusCode:             http.StatusBadRequest,
+			setMockUsecase:               func(u *mockUsecase.MockPermissionUsecase) {},
+		},
+		{
+			name:                         "no user id in context",
+			isSetIDToPathParameter:       true,
+			isSetPolicyIDToPathParameter: true,
+			isSetUserIDToContext:         false,
+			requestJSON:                  `{}`,
+			expectStatusCode:             http.StatusInternalServerError,
+			setMockUsecase:               func(u *mockUsecase.MockPermissionUsecase) {},
+		},
+		{
+			name:                         "invalid request",
+			isSetIDToPathParameter:       true,
+			isSetPolicyIDToPathParameter: true,
+			isSetUserIDToContext:         true,
+			requestJSON:                  `{"valid_until": "tomorrow"}`,
+			expectStatusCode:             http.StatusBadRequest,
+			setMockUsecase:               func(u *mockUsecase.MockPermissionUsecase) {},
+		},
+		{
+			name:                         "grant error",
+			isSetIDToPathParameter:       true,
+			isSetPolicyIDToPathParameter: true,
+			isSetUserIDToContext:         true,
+			requestJSON:                  `{}`,
+			expectStatusCode:             http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockPermissionUsecase) {
+				u.EXPECT().
+					Grant(gomock.Any(), agentID, policyID, userID, nil, nil).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("PUT", "/agents/:id/permissions/:policy_id", bytes.NewBuffer([]byte(tt.requestJSON)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agentID.String()})
+			}
+			if tt.isSetPolicyIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "policy_id", Value: policyID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", userID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPermissionUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPermissionHandler(u)
+			h.Grant(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPermission_Revoke(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agentID := uuid.New()
+	policyID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name                         string
+		isSetIDToPathParameter       bool
+		isSetPolicyIDToPathParameter bool
+		isSetUserIDToContext         bool
+		expectStatusCode             int
+		setMockUsecase               func(*mockUsecase.MockPermissionUsecase)
+	}{
+		{
+			name:                         "success",
+			isSetIDToPathParameter:       true,
+			isSetPolicyIDToPathParameter: true,
+			isSetUserIDToContext:         true,
+			expectStatusCode:             http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPermissionUsecase) {
+				u.EXPECT().
+					Revoke(gomock.Any(), agentID, policyID, userID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                         "no policy id in path parameter",
+			isSetIDToPathParameter:       true,
+			isSetPolicyIDToPathParameter: false,
+			isSetUserIDToContext:         true,
+			expectStatusCode:             http.StatusBadRequest,
+			setMockUsecase:               func(u *mockUsecase.MockPermissionUsecase) {},
+		},
+		{
+			name:                         "no user id in context",
+			isSetIDToPathParameter:       true,
+			isSetPolicyIDToPathParameter: true,
+			isSetUserIDToContext:         false,
+			expectStatusCode:             http.StatusInternalServerError,
+			setMockUsecase:               func(u *mockUsecase.MockPermissionUsecase) {},
+		},
+		{
+			name:                         "permission not found",
+			isSetIDToPathParameter:       true,
+			isSetPolicyIDToPathParameter: true,
+			isSetUserIDToContext:         true,
+			expectStatusCode:             http.StatusNotFound,
+			setMockUsecase: func(u *mockUsecase.MockPermissionUsecase) {
+				u.EXPECT().
+					Revoke(gomock.Any(), agentID, policyID, userID).
+					Return(usecase.ErrPermissionNotFound).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("DELETE", "/agents/:id/permissions/:policy_id", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: agentID.String()})
+			}
+			if tt.isSetPolicyIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "policy_id", Value: policyID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", userID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPermissionUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPermissionHandler(u)
+			h.Revoke(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"holos-auth-api/internal/app/api/interface/pkg/parameter"
 	"holos-auth-api/internal/app/api/interface/request"
 	"holos-auth-api/internal/app/api/usecase"
+	"holos-auth-api/internal/app/api/usecase/dto"
 	"log"
 	"net/http"
 
@@ -21,6 +22,12 @@ type PolicyHandler interface {
 	Gets(*gin.Context)
 	UpdateAgents(*gin.Context)
 	GetAgents(*gin.Context)
+	GetVersions(*gin.Context)
+	DiffVersions(*gin.Context)
+	RestoreVersion(*gin.Context)
+	Export(*gin.Context)
+	Import(*gin.Context)
+	Lint(*gin.Context)
 }
 
 type policyHandler struct {
@@ -52,7 +59,7 @@ func (h *policyHandler) Create(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	dto, err := h.policyUsecase.Create(ctx, userID, req.Name, req.Effect, req.Service, req.Path, req.Methods)
+	dto, err := h.policyUsecase.Create(ctx, userID, req.Name, req.Effect, req.Service, req.Path, req.Methods, toPolicyConditionsDTO(req.Conditions), req.Expression, req.ValidFrom, req.ValidUntil)
 	if err != nil {
 		status := errors.HandleError(err)
 		log.Println(status.Message())
@@ -90,7 +97,7 @@ func (h *policyHandler) Update(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	dto, err := h.policyUsecase.Update(ctx, id, userID, req.Name, req.Effect, req.Service, req.Path, req.Methods)
+	dto, err := h.policyUsecase.Update(ctx, id, userID, req.Name, req.Effect, req.Service, req.Path, req.Methods, toPolicyConditionsDTO(req.Conditions), req.Expression, req.ValidFrom, req.ValidUntil)
 	if err != nil {
 		status := errors.HandleError(err)
 		log.Println(status.Message())
@@ -253,3 +260,269 @@ func (h *policyHandler) GetAgents(c *gin.Context) {
 
 	c.JSON(http.StatusOK, builder.ToAgentResponses(dtos))
 }
+
+func (h *policyHandler) GetVersions(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dtos, err := h.policyUsecase.GetVersions(ctx, id, userID)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToPolicyVersionResponses(dtos))
+}
+
+func (h *policyHandler) DiffVersions(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	from, err := parameter.GetQueryParameter[int](c, "from")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	to, err := parameter.GetQueryParameter[int](c, "to")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.policyUsecase.DiffVersions(ctx, id, userID, from, to)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToPolicyVersionDiffResponse(dto))
+}
+
+func (h *policyHandler) RestoreVersion(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	version, err := parameter.GetPathParameter[int](c, "version")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.policyUsecase.RestoreVersion(ctx, id, userID, version)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToPolicyResponse(dto))
+}
+
+// format=yamlの場合はYAMLで, それ以外はJSONで返す.
+func (h *policyHandler) Export(c *gin.Context) {
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.policyUsecase.Export(ctx, userID)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	if c.Query("format") == "yaml" {
+		c.YAML(http.StatusOK, builder.ToPolicyDocumentResponse(dto))
+		return
+	}
+	c.JSON(http.StatusOK, builder.ToPolicyDocumentResponse(dto))
+}
+
+// Content-Typeに応じてJSONかYAMLとして読み込む.
+func (h *policyHandler) Import(c *gin.Context) {
+	var req request.PolicyDocumentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	mode := c.Query("mode")
+
+	ctx := c.Request.Context()
+
+	dto, err := h.policyUsecase.Import(ctx, userID, toPolicyDocumentDTO(&req), mode)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToPolicyDocumentResponse(dto))
+}
+
+func toPolicyDocumentDTO(document *request.PolicyDocumentRequest) *dto.PolicyDocumentDTO {
+	policies := make([]*dto.PolicyDocumentPolicyDTO, len(document.Policies))
+	for i, policy := range document.Policies {
+		policies[i] = &dto.PolicyDocumentPolicyDTO{
+			Name:       policy.Name,
+			Effect:     policy.Effect,
+			Service:    policy.Service,
+			Path:       policy.Path,
+			Methods:    policy.Methods,
+			Conditions: toPolicyConditionsDTO(policy.Conditions),
+			Expression: policy.Expression,
+			ValidFrom:  policy.ValidFrom,
+			ValidUntil: policy.ValidUntil,
+		}
+	}
+
+	agents := make([]*dto.PolicyDocumentAgentDTO, len(document.Agents))
+	for i, agent := range document.Agents {
+		agents[i] = &dto.PolicyDocumentAgentDTO{
+			Name: agent.Name,
+		}
+	}
+
+	bindings := make([]*dto.PolicyBindingDTO, len(document.Bindings))
+	for i, binding := range document.Bindings {
+		bindings[i] = &dto.PolicyBindingDTO{
+			Agent:    binding.Agent,
+			Policies: binding.Policies,
+		}
+	}
+
+	return &dto.PolicyDocumentDTO{
+		Version:  document.Version,
+		Policies: policies,
+		Agents:   agents,
+		Bindings: bindings,
+	}
+}
+
+func toPolicyConditionsDTO(conditions *request.PolicyConditionsRequest) *dto.PolicyConditionsDTO {
+	if conditions == nil {
+		return nil
+	}
+
+	return &dto.PolicyConditionsDTO{
+		TimeZone:    conditions.TimeZone,
+		DaysOfWeek:  conditions.DaysOfWeek,
+		StartTime:   conditions.StartTime,
+		EndTime:     conditions.EndTime,
+		NotBefore:   conditions.NotBefore,
+		NotAfter:    conditions.NotAfter,
+		SourceCIDRs: conditions.SourceCIDRs,
+		Attributes:  conditions.Attributes,
+		PathParams:  conditions.PathParams,
+	}
+}
+
+// クエリパラメータagent_idを指定した場合は, そのエージェントに適用されるポリシーのみを検査する.
+func (h *policyHandler) Lint(c *gin.Context) {
+	agentID := uuid.Nil
+	if c.Query("agent_id") != "" {
+		var err error
+		agentID, err = parameter.GetQueryParameter[uuid.UUID](c, "agent_id")
+		if err != nil {
+			status := errors.HandleError(err)
+			log.Println(status.Message())
+			c.String(status.Code(), status.Message())
+			return
+		}
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dtos, err := h.policyUsecase.Lint(ctx, agentID, userID)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToPolicyLintFindingResponses(dtos))
+}
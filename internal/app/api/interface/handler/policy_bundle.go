@@ -0,0 +1,328 @@
+package handler
+
+import (
+	"holos-auth-api/internal/app/api/interface/builder"
+	"holos-auth-api/internal/app/api/interface/pkg/errors"
+	"holos-auth-api/internal/app/api/interface/pkg/parameter"
+	"holos-auth-api/internal/app/api/interface/request"
+	"holos-auth-api/internal/app/api/usecase"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ロールとエージェントグループで共通のハンドラ. 扱う種類は渡したユースケースで決まる.
+type PolicyBundleHandler interface {
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+	Get(*gin.Context)
+	Gets(*gin.Context)
+	UpdatePolicies(*gin.Context)
+	GetPolicies(*gin.Context)
+	UpdateAgents(*gin.Context)
+	GetAgents(*gin.Context)
+}
+
+type policyBundleHandler struct {
+	policyBundleUsecase usecase.PolicyBundleUsecase
+}
+
+func NewPolicyBundleHandler(policyBundleUsecase usecase.PolicyBundleUsecase) PolicyBundleHandler {
+	return &policyBundleHandler{
+		policyBundleUsecase: policyBundleUsecase,
+	}
+}
+
+func (h *policyBundleHandler) Create(c *gin.Context) {
+	var req request.CreatePolicyBundleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.policyBundleUsecase.Create(ctx, userID, req.Name)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusCreated, builder.ToPolicyBundleResponse(dto))
+}
+
+func (h *policyBundleHandler) Update(c *gin.Context) {
+	var req request.UpdatePolicyBundleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.policyBundleUsecase.Update(ctx, id, userID, req.Name)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToPolicyBundleResponse(dto))
+}
+
+func (h *policyBundleHandler) Delete(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := h.policyBundleUsecase.Delete(ctx, id, userID); err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *policyBundleHandler) Get(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dto, err := h.policyBundleUsecase.Get(ctx, id, userID)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToPolicyBundleResponse(dto))
+}
+
+func (h *policyBundleHandler) Gets(c *gin.Context) {
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	keyword := c.Query("keyword")
+
+	ctx := c.Request.Context()
+
+	dtos, err := h.policyBundleUsecase.Gets(ctx, keyword, userID)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToPolicyBundleResponses(dtos))
+}
+
+func (h *policyBundleHandler) UpdatePolicies(c *gin.Context) {
+	var req request.UpdatePolicyBundlePoliciesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dtos, err := h.policyBundleUsecase.UpdatePolicies(ctx, id, userID, req.PolicyIDs)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToPolicyResponses(dtos))
+}
+
+func (h *policyBundleHandler) GetPolicies(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	keyword := c.Query("keyword")
+
+	ctx := c.Request.Context()
+
+	dtos, err := h.policyBundleUsecase.GetPolicies(ctx, id, userID, keyword)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToPolicyResponses(dtos))
+}
+
+func (h *policyBundleHandler) UpdateAgents(c *gin.Context) {
+	var req request.UpdatePolicyBundleAgentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dtos, err := h.policyBundleUsecase.UpdateAgents(ctx, id, userID, req.AgentIDs)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToAgentResponses(dtos))
+}
+
+func (h *policyBundleHandler) GetAgents(c *gin.Context) {
+	id, err := parameter.GetPathParameter[uuid.UUID](c, "id")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	keyword := c.Query("keyword")
+
+	ctx := c.Request.Context()
+
+	dtos, err := h.policyBundleUsecase.GetAgents(ctx, id, userID, keyword)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToAgentResponses(dtos))
+}
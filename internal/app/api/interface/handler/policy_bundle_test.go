@@ -0,0 +1,840 @@
+package handler_test
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/interface/handler"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	"holos-auth-api/internal/app/api/usecase/mapper"
+	mockUsecase "holos-auth-api/test/mock/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func TestPolicyBundle_Create(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                 string
+		isSetUserIDToContext bool
+		requestJSON          string
+		expectStatusCode     int
+		setMockUsecase       func(*mockUsecase.MockPolicyBundleUsecase)
+	}{
+		{
+			name:                 "success",
+			isSetUserIDToContext: true,
+			requestJSON:          `{"name": "name"}`,
+			expectStatusCode:     http.StatusCreated,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mapper.ToPolicyBundleDTO(role), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                 "no user id in context",
+			isSetUserIDToContext: false,
+			requestJSON:          `{"name": "name"}`,
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase:       func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                 "invalid request",
+			isSetUserIDToContext: true,
+			requestJSON:          "",
+			expectStatusCode:     http.StatusBadRequest,
+			setMockUsecase:       func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                 "create error",
+			isSetUserIDToContext: true,
+			requestJSON:          `{"name": "name"}`,
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/role", bytes.NewBuffer([]byte(tt.requestJSON)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", role.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyBundleUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyBundleHandler(u)
+			h.Create(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_Update(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		requestJSON            string
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockPolicyBundleUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"name": "name"}`,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mapper.ToPolicyBundleDTO(role), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"name": "name"}`,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			requestJSON:            `{"name": "name"}`,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "invalid request",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            "",
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "update error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"name": "name"}`,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("PUT", "/role/:id", bytes.NewBuffer([]byte(tt.requestJSON)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: role.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", role.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyBundleUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyBundleHandler(u)
+			h.Update(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_Delete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockPolicyBundleUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					Delete(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "delete error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					Delete(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("DELETE", "/roles/:id", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: role.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", role.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyBundleUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyBundleHandler(u)
+			h.Delete(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_Get(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockPolicyBundleUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mapper.ToPolicyBundleDTO(role), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "get error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/roles/:id", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: role.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", role.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyBundleUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyBundleHandler(u)
+			h.Get(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_Gets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                 string
+		isSetUserIDToContext bool
+		expectStatusCode     int
+		setMockUsecase       func(*mockUsecase.MockPolicyBundleUsecase)
+	}{
+		{
+			name:                 "success",
+			isSetUserIDToContext: true,
+			expectStatusCode:     http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					Gets(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*dto.PolicyBundleDTO{mapper.ToPolicyBundleDTO(role)}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                 "no user id in context",
+			isSetUserIDToContext: false,
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase:       func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                 "get error",
+			isSetUserIDToContext: true,
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					Gets(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/roles", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", role.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyBundleUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyBundleHandler(u)
+			h.Gets(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_UpdatePolicies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		requestJSON            string
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockPolicyBundleUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            fmt.Sprintf(`{"policy_ids": ["%s"]}`, policy.ID),
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					UpdatePolicies(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*dto.PolicyDTO{mapper.ToPolicyDTO(policy)}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			requestJSON:            fmt.Sprintf(`{"policy_ids": ["%s"]}`, policy.ID),
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			requestJSON:            fmt.Sprintf(`{"policy_ids": ["%s"]}`, policy.ID),
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "invalid request",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            "",
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "update policies error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            fmt.Sprintf(`{"policy_ids": ["%s"]}`, policy.ID),
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					UpdatePolicies(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("PUT", "/roles/:id/policies", bytes.NewBuffer([]byte(tt.requestJSON)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: role.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", role.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyBundleUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyBundleHandler(u)
+			h.UpdatePolicies(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_GetPolicies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockPolicyBundleUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					GetPolicies(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*dto.PolicyDTO{mapper.ToPolicyDTO(policy)}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "get policies error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					GetPolicies(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/roles/:id/policies", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: role.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", role.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyBundleUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyBundleHandler(u)
+			h.GetPolicies(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_UpdateAgents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		requestJSON            string
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockPolicyBundleUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            fmt.Sprintf(`{"agent_ids": ["%s"]}`, agent.ID),
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					UpdateAgents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*dto.AgentDTO{mapper.ToAgentDTO(agent)}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			requestJSON:            fmt.Sprintf(`{"agent_ids": ["%s"]}`, agent.ID),
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			requestJSON:            fmt.Sprintf(`{"agent_ids": ["%s"]}`, agent.ID),
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "invalid request",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            "",
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "update agents error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            fmt.Sprintf(`{"agent_ids": ["%s"]}`, agent.ID),
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					UpdateAgents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("PUT", "/roles/:id/agents", bytes.NewBuffer([]byte(tt.requestJSON)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: role.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", role.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyBundleUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyBundleHandler(u)
+			h.UpdateAgents(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_GetAgents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockPolicyBundleUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					GetAgents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*dto.AgentDTO{mapper.ToAgentDTO(agent)}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyBundleUsecase) {},
+		},
+		{
+			name:                   "aget agents error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockPolicyBundleUsecase) {
+				u.EXPECT().
+					GetAgents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/roles/:id/agents", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: role.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", role.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyBundleUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyBundleHandler(u)
+			h.GetAgents(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"holos-auth-api/internal/app/api/domain/entity"
 	"holos-auth-api/internal/app/api/interface/handler"
+	"holos-auth-api/internal/app/api/usecase"
 	"holos-auth-api/internal/app/api/usecase/dto"
 	"holos-auth-api/internal/app/api/usecase/mapper"
 	mockUsecase "holos-auth-api/test/mock/usecase"
@@ -40,7 +41,7 @@ func TestPolicy_Create(t *testing.T) {
 			expectStatusCode:     http.StatusCreated,
 			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
 				u.EXPECT().
-					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(mapper.ToPolicyDTO(policy), nil).
 					Times(1)
 			},
@@ -66,7 +67,7 @@ func TestPolicy_Create(t *testing.T) {
 			expectStatusCode:     http.StatusInternalServerError,
 			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
 				u.EXPECT().
-					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
@@ -126,7 +127,7 @@ func TestPolicy_Update(t *testing.T) {
 			expectStatusCode:       http.StatusOK,
 			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
 				u.EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(mapper.ToPolicyDTO(policy), nil).
 					Times(1)
 			},
@@ -163,7 +164,7 @@ func TestPolicy_Update(t *testing.T) {
 			expectStatusCode:       http.StatusInternalServerError,
 			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
 				u.EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
@@ -643,3 +644,618 @@ func TestPolicy_GetAgents(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicy_GetVersions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyVersion := entity.NewPolicyVersion(policy, nil, entity.PolicyVersionOperationCreate, policy.UserID)
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockPolicyUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					GetVersions(gomock.Any(), policy.ID, policy.UserID).
+					Return(mapper.ToPolicyVersionDTOs([]*entity.PolicyVersion{policyVersion}), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyUsecase) {},
+		},
+		{
+			name:                   "get versions error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					GetVersions(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/policies/:id/versions", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: policy.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", policy.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyHandler(u)
+			h.GetVersions(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPolicy_DiffVersions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyVersion := mapper.ToPolicyVersionDTO(entity.NewPolicyVersion(policy, nil, entity.PolicyVersionOperationCreate, policy.UserID))
+
+	tests := []struct {
+		name                   string
+		query                  string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockPolicyUsecase)
+	}{
+		{
+			name:                   "success",
+			query:                  "from=1&to=2",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					DiffVersions(gomock.Any(), policy.ID, policy.UserID, 1, 2).
+					Return(&dto.PolicyVersionDiffDTO{From: policyVersion, To: policyVersion, Changes: []string{}}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			query:                  "from=1&to=2",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyUsecase) {},
+		},
+		{
+			name:                   "invalid version in query",
+			query:                  "from=1&to=latest",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			query:                  "from=1&to=2",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyUsecase) {},
+		},
+		{
+			name:                   "diff versions error",
+			query:                  "from=1&to=2",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					DiffVersions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/policies/:id/versions/diff?"+tt.query, nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: policy.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", policy.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyHandler(u)
+			h.DiffVersions(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPolicy_RestoreVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		version                string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockPolicyUsecase)
+	}{
+		{
+			name:                   "success",
+			version:                "1",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					RestoreVersion(gomock.Any(), policy.ID, policy.UserID, 1).
+					Return(mapper.ToPolicyDTO(policy), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			version:                "1",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyUsecase) {},
+		},
+		{
+			name:                   "invalid version in path parameter",
+			version:                "v1",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			version:                "1",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockPolicyUsecase) {},
+		},
+		{
+			name:                   "restore version error",
+			version:                "1",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					RestoreVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/policies/:id/versions/:version/restore", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: policy.ID.String()})
+			}
+			ctx.Params = append(ctx.Params, gin.Param{Key: "version", Value: tt.version})
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", policy.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyHandler(u)
+			h.RestoreVersion(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPolicy_Export(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	document := &dto.PolicyDocumentDTO{
+		Version:  entity.PolicyDocumentVersion,
+		Policies: []*dto.PolicyDocumentPolicyDTO{{Name: "policy", Effect: "ALLOW", Service: "STORAGE", Path: "/", Methods: []string{"GET"}}},
+		Agents:   []*dto.PolicyDocumentAgentDTO{{Name: "agent"}},
+		Bindings: []*dto.PolicyBindingDTO{{Agent: "agent", Policies: []string{"policy"}}},
+	}
+
+	tests := []struct {
+		name                 string
+		isSetUserIDToContext bool
+		requestQuery         string
+		expectStatusCode     int
+		expectContentType    string
+		setMockUsecase       func(*mockUsecase.MockPolicyUsecase)
+	}{
+		{
+			name:                 "json",
+			isSetUserIDToContext: true,
+			requestQuery:         "",
+			expectStatusCode:     http.StatusOK,
+			expectContentType:    "application/json; charset=utf-8",
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					Export(gomock.Any(), userID).
+					Return(document, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                 "yaml",
+			isSetUserIDToContext: true,
+			requestQuery:         "?format=yaml",
+			expectStatusCode:     http.StatusOK,
+			expectContentType:    "application/yaml; charset=utf-8",
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					Export(gomock.Any(), userID).
+					Return(document, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                 "no user id in context",
+			isSetUserIDToContext: false,
+			requestQuery:         "",
+			expectStatusCode:     http.StatusInternalServerError,
+			expectContentType:    "text/plain; charset=utf-8",
+			setMockUsecase:       func(u *mockUsecase.MockPolicyUsecase) {},
+		},
+		{
+			name:                 "export error",
+			isSetUserIDToContext: true,
+			requestQuery:         "",
+			expectStatusCode:     http.StatusInternalServerError,
+			expectContentType:    "text/plain; charset=utf-8",
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					Export(gomock.Any(), userID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/policies/export"+tt.requestQuery, nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", userID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyHandler(u)
+			h.Export(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+			if contentType := w.Header().Get("Content-Type"); contentType != tt.expectContentType {
+				t.Errorf("\nexpect: %s \ngot: %s", tt.expectContentType, contentType)
+			}
+		})
+	}
+}
+
+func TestPolicy_Import(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	document := &dto.PolicyDocumentDTO{
+		Version:  entity.PolicyDocumentVersion,
+		Policies: []*dto.PolicyDocumentPolicyDTO{{Name: "policy", Effect: "ALLOW", Service: "STORAGE", Path: "/", Methods: []string{"GET"}}},
+		Agents:   []*dto.PolicyDocumentAgentDTO{{Name: "agent"}},
+		Bindings: []*dto.PolicyBindingDTO{{Agent: "agent", Policies: []string{"policy"}}},
+	}
+	requestJSON := `{"version": 1, "policies": [{"name": "policy", "effect": "ALLOW", "service": "STORAGE", "path": "/", "methods": ["GET"]}], "agents": [{"name": "agent"}], "bindings": [{"agent": "agent", "policies": ["policy"]}]}`
+	requestYAML := "version: 1\npolicies:\n  - name: policy\n    effect: ALLOW\n    service: STORAGE\n    path: /\n    methods: [GET]\nagents:\n  - name: agent\nbindings:\n  - agent: agent\n    policies: [policy]\n"
+
+	tests := []struct {
+		name                 string
+		isSetUserIDToContext bool
+		requestContentType   string
+		requestBody          string
+		requestQuery         string
+		expectStatusCode     int
+		setMockUsecase       func(*mockUsecase.MockPolicyUsecase)
+	}{
+		{
+			name:                 "json",
+			isSetUserIDToContext: true,
+			requestContentType:   "application/json",
+			requestBody:          requestJSON,
+			requestQuery:         "",
+			expectStatusCode:     http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					Import(gomock.Any(), userID, document, "").
+					Return(document, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                 "yaml",
+			isSetUserIDToContext: true,
+			requestContentType:   "application/yaml",
+			requestBody:          requestYAML,
+			requestQuery:         "?mode=REPLACE",
+			expectStatusCode:     http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					Import(gomock.Any(), userID, document, "REPLACE").
+					Return(document, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                 "no user id in context",
+			isSetUserIDToContext: false,
+			requestContentType:   "application/json",
+			requestBody:          requestJSON,
+			requestQuery:         "",
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase:       func(u *mockUsecase.MockPolicyUsecase) {},
+		},
+		{
+			name:                 "invalid request",
+			isSetUserIDToContext: true,
+			requestContentType:   "application/json",
+			requestBody:          "",
+			requestQuery:         "",
+			expectStatusCode:     http.StatusBadRequest,
+			setMockUsecase:       func(u *mockUsecase.MockPolicyUsecase) {},
+		},
+		{
+			name:                 "invalid document",
+			isSetUserIDToContext: true,
+			requestContentType:   "application/json",
+			requestBody:          requestJSON,
+			requestQuery:         "",
+			expectStatusCode:     http.StatusBadRequest,
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					Import(gomock.Any(), userID, document, "").
+					Return(nil, entity.ErrUnknownPolicyInBinding).
+					Times(1)
+			},
+		},
+		{
+			name:                 "import error",
+			isSetUserIDToContext: true,
+			requestContentType:   "application/json",
+			requestBody:          requestJSON,
+			requestQuery:         "",
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					Import(gomock.Any(), userID, document, "").
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/policies/import"+tt.requestQuery, bytes.NewBuffer([]byte(tt.requestBody)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			req.Header.Set("Content-Type", tt.requestContentType)
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", userID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyHandler(u)
+			h.Import(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPolicy_Lint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	agentID := uuid.New()
+	findings := []*dto.PolicyLintFindingDTO{
+		{Type: entity.PolicyLintTypeUnattached, Severity: entity.PolicyLintSeverityWarning, PolicyIDs: []uuid.UUID{uuid.New()}, Message: "policy name is not attached to any agent"},
+	}
+
+	tests := []struct {
+		name                 string
+		isSetUserIDToContext bool
+		requestQuery         string
+		expectStatusCode     int
+		setMockUsecase       func(*mockUsecase.MockPolicyUsecase)
+	}{
+		{
+			name:                 "all policies",
+			isSetUserIDToContext: true,
+			requestQuery:         "",
+			expectStatusCode:     http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					Lint(gomock.Any(), uuid.Nil, userID).
+					Return(findings, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                 "agent policies",
+			isSetUserIDToContext: true,
+			requestQuery:         "?agent_id=" + agentID.String(),
+			expectStatusCode:     http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					Lint(gomock.Any(), agentID, userID).
+					Return([]*dto.PolicyLintFindingDTO{}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                 "invalid agent id",
+			isSetUserIDToContext: true,
+			requestQuery:         "?agent_id=invalid",
+			expectStatusCode:     http.StatusBadRequest,
+			setMockUsecase:       func(u *mockUsecase.MockPolicyUsecase) {},
+		},
+		{
+			name:                 "no user id in context",
+			isSetUserIDToContext: false,
+			requestQuery:         "",
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase:       func(u *mockUsecase.MockPolicyUsecase) {},
+		},
+		{
+			name:                 "agent not found",
+			isSetUserIDToContext: true,
+			requestQuery:         "?agent_id=" + agentID.String(),
+			expectStatusCode:     http.StatusNotFound,
+			setMockUsecase: func(u *mockUsecase.MockPolicyUsecase) {
+				u.EXPECT().
+					Lint(gomock.Any(), agentID, userID).
+					Return(nil, usecase.ErrAgentNotFound).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/policies/lint"+tt.requestQuery, nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", userID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockPolicyUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewPolicyHandler(u)
+			h.Lint(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/interface/builder"
+	"holos-auth-api/internal/app/api/interface/pkg/errors"
+	"holos-auth-api/internal/app/api/interface/pkg/parameter"
+	"holos-auth-api/internal/app/api/interface/request"
+	"holos-auth-api/internal/app/api/usecase"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SecurityEventHandler interface {
+	ReportLeakedTokens(*gin.Context)
+	Gets(*gin.Context)
+}
+
+type securityEventHandler struct {
+	securityEventUsecase usecase.SecurityEventUsecase
+}
+
+func NewSecurityEventHandler(securityEventUsecase usecase.SecurityEventUsecase) SecurityEventHandler {
+	return &securityEventHandler{
+		securityEventUsecase: securityEventUsecase,
+	}
+}
+
+func (h *securityEventHandler) ReportLeakedTokens(c *gin.Context) {
+	scheme, credential, _ := strings.Cut(c.Request.Header.Get("Authorization"), " ")
+	if scheme != "HOLOS-REPORTER-HMAC-SHA256" {
+		status := errors.StatusUnauthorized
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	// 署名はリクエストボディそのものに対して検証するため, バインド前に読み込む.
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, entity.LeakedTokenReportMaxBodySize))
+	if err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+	var req request.ReportLeakedTokensRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		status := errors.StatusBadRequest
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	keyID, signature := parseSignatureCredential(credential)
+
+	ctx := c.Request.Context()
+
+	if err := h.securityEventUsecase.ReportLeakedTokens(ctx, keyID, signature, c.Request.Header.Get("Holos-Timestamp"), body, req.Tokens); err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+func (h *securityEventHandler) Gets(c *gin.Context) {
+	userID, err := parameter.GetContextParameter[uuid.UUID](c, "userID")
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	dtos, err := h.securityEventUsecase.Gets(ctx, userID)
+	if err != nil {
+		status := errors.HandleError(err)
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToSecurityEventResponses(dtos))
+}
@@ -0,0 +1,188 @@
+package handler_test
+
+import (
+	"bytes"
+	"database/sql"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/interface/handler"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	"holos-auth-api/internal/app/api/usecase/mapper"
+	mockUsecase "holos-auth-api/test/mock/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func TestSecurityEvent_ReportLeakedTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name                string
+		authorizationHeader string
+		requestJSON         string
+		expectStatusCode    int
+		setMockUsecase      func(*mockUsecase.MockSecurityEventUsecase)
+	}{
+		{
+			name:                "success",
+			authorizationHeader: "HOLOS-REPORTER-HMAC-SHA256 KeyId=scanner, Signature=signature",
+			requestJSON:         `{"tokens": ["token"]}`,
+			expectStatusCode:    http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockSecurityEventUsecase) {
+				u.EXPECT().
+					ReportLeakedTokens(gomock.Any(), "scanner", "signature", gomock.Any(), []byte(`{"tokens": ["token"]}`), []string{"token"}).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "invalid header",
+			authorizationHeader: "Bearer token",
+			requestJSON:         `{"tokens": ["token"]}`,
+			expectStatusCode:    http.StatusUnauthorized,
+			setMockUsecase:      func(u *mockUsecase.MockSecurityEventUsecase) {},
+		},
+		{
+			name:                "invalid request",
+			authorizationHeader: "HOLOS-REPORTER-HMAC-SHA256 KeyId=scanner, Signature=signature",
+			requestJSON:         "",
+			expectStatusCode:    http.StatusBadRequest,
+			setMockUsecase:      func(u *mockUsecase.MockSecurityEventUsecase) {},
+		},
+		{
+			name:                "too large request",
+			authorizationHeader: "HOLOS-REPORTER-HMAC-SHA256 KeyId=scanner, Signature=signature",
+			requestJSON:         `{"tokens": ["` + strings.Repeat("a", entity.LeakedTokenReportMaxBodySize) + `"]}`,
+			expectStatusCode:    http.StatusBadRequest,
+			setMockUsecase:      func(u *mockUsecase.MockSecurityEventUsecase) {},
+		},
+		{
+			name:                "invalid signature",
+			authorizationHeader: "HOLOS-REPORTER-HMAC-SHA256 KeyId=scanner, Signature=signature",
+			requestJSON:         `{"tokens": ["token"]}`,
+			expectStatusCode:    http.StatusUnauthorized,
+			setMockUsecase: func(u *mockUsecase.MockSecurityEventUsecase) {
+				u.EXPECT().
+					ReportLeakedTokens(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entity.ErrInvalidLeakedTokenReport).
+					Times(1)
+			},
+		},
+		{
+			name:                "report leaked tokens error",
+			authorizationHeader: "HOLOS-REPORTER-HMAC-SHA256 KeyId=scanner, Signature=signature",
+			requestJSON:         `{"tokens": ["token"]}`,
+			expectStatusCode:    http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockSecurityEventUsecase) {
+				u.EXPECT().
+					ReportLeakedTokens(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/leaked-tokens", bytes.NewBuffer([]byte(tt.requestJSON)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			req.Header.Set("Authorization", tt.authorizationHeader)
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockSecurityEventUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewSecurityEventHandler(u)
+			h.ReportLeakedTokens(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestSecurityEvent_Gets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	securityEvent, err := entity.NewSecurityEvent(uuid.New(), entity.SecurityEventTypeUserTokenLeaked, "reporter=scanner")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                 string
+		isSetUserIDToContext bool
+		expectStatusCode     int
+		setMockUsecase       func(*mockUsecase.MockSecurityEventUsecase)
+	}{
+		{
+			name:                 "success",
+			isSetUserIDToContext: true,
+			expectStatusCode:     http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockSecurityEventUsecase) {
+				u.EXPECT().
+					Gets(gomock.Any(), gomock.Any()).
+					Return([]*dto.SecurityEventDTO{mapper.ToSecurityEventDTO(securityEvent)}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                 "no user id in context",
+			isSetUserIDToContext: false,
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase:       func(u *mockUsecase.MockSecurityEventUsecase) {},
+		},
+		{
+			name:                 "get security events error",
+			isSetUserIDToContext: true,
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockSecurityEventUsecase) {
+				u.EXPECT().
+					Gets(gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/security-events", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", securityEvent.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockSecurityEventUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewSecurityEventHandler(u)
+			h.Gets(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
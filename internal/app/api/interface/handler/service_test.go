@@ -0,0 +1,449 @@
+package handler_test
+
+import (
+	"bytes"
+	"database/sql"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/interface/handler"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	"holos-auth-api/internal/app/api/usecase/mapper"
+	mockUsecase "holos-auth-api/test/mock/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func TestService_Create(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                 string
+		isSetUserIDToContext bool
+		requestJSON          string
+		expectStatusCode     int
+		setMockUsecase       func(*mockUsecase.MockServiceUsecase)
+	}{
+		{
+			name:                 "success",
+			isSetUserIDToContext: true,
+			requestJSON:          `{"name": "STORAGE", "methods": ["GET"], "path_schema": ""}`,
+			expectStatusCode:     http.StatusCreated,
+			setMockUsecase: func(u *mockUsecase.MockServiceUsecase) {
+				u.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mapper.ToServiceDTO(service), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                 "no user id in context",
+			isSetUserIDToContext: false,
+			requestJSON:          `{"name": "STORAGE", "methods": ["GET"], "path_schema": ""}`,
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase:       func(u *mockUsecase.MockServiceUsecase) {},
+		},
+		{
+			name:                 "invalid request",
+			isSetUserIDToContext: true,
+			requestJSON:          "",
+			expectStatusCode:     http.StatusBadRequest,
+			setMockUsecase:       func(u *mockUsecase.MockServiceUsecase) {},
+		},
+		{
+			name:                 "create error",
+			isSetUserIDToContext: true,
+			requestJSON:          `{"name": "STORAGE", "methods": ["GET"], "path_schema": ""}`,
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockServiceUsecase) {
+				u.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/service", bytes.NewBuffer([]byte(tt.requestJSON)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", service.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockServiceUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewServiceHandler(u)
+			h.Create(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		requestJSON            string
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockServiceUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"name": "STORAGE", "methods": ["GET"], "path_schema": ""}`,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockServiceUsecase) {
+				u.EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mapper.ToServiceDTO(service), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"name": "STORAGE", "methods": ["GET"], "path_schema": ""}`,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockServiceUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			requestJSON:            `{"name": "STORAGE", "methods": ["GET"], "path_schema": ""}`,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockServiceUsecase) {},
+		},
+		{
+			name:                   "invalid request",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            "",
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockServiceUsecase) {},
+		},
+		{
+			name:                   "update error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			requestJSON:            `{"name": "STORAGE", "methods": ["GET"], "path_schema": ""}`,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockServiceUsecase) {
+				u.EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("PUT", "/service/:id", bytes.NewBuffer([]byte(tt.requestJSON)))
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: service.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", service.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockServiceUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewServiceHandler(u)
+			h.Update(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockServiceUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockServiceUsecase) {
+				u.EXPECT().
+					Delete(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockServiceUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockServiceUsecase) {},
+		},
+		{
+			name:                   "delete error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockServiceUsecase) {
+				u.EXPECT().
+					Delete(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("DELETE", "/services/:id", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: service.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", service.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockServiceUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewServiceHandler(u)
+			h.Delete(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestService_Get(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                   string
+		isSetIDToPathParameter bool
+		isSetUserIDToContext   bool
+		expectStatusCode       int
+		setMockUsecase         func(*mockUsecase.MockServiceUsecase)
+	}{
+		{
+			name:                   "success",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockServiceUsecase) {
+				u.EXPECT().
+					Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mapper.ToServiceDTO(service), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "no id in path parameter",
+			isSetIDToPathParameter: false,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusBadRequest,
+			setMockUsecase:         func(u *mockUsecase.MockServiceUsecase) {},
+		},
+		{
+			name:                   "no user id in context",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   false,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase:         func(u *mockUsecase.MockServiceUsecase) {},
+		},
+		{
+			name:                   "get error",
+			isSetIDToPathParameter: true,
+			isSetUserIDToContext:   true,
+			expectStatusCode:       http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockServiceUsecase) {
+				u.EXPECT().
+					Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/services/:id", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetIDToPathParameter {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: service.ID.String()})
+			}
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", service.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockServiceUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewServiceHandler(u)
+			h.Get(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestService_Gets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                 string
+		isSetUserIDToContext bool
+		expectStatusCode     int
+		setMockUsecase       func(*mockUsecase.MockServiceUsecase)
+	}{
+		{
+			name:                 "success",
+			isSetUserIDToContext: true,
+			expectStatusCode:     http.StatusOK,
+			setMockUsecase: func(u *mockUsecase.MockServiceUsecase) {
+				u.EXPECT().
+					Gets(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*dto.ServiceDTO{mapper.ToServiceDTO(service)}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                 "no user id in context",
+			isSetUserIDToContext: false,
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase:       func(u *mockUsecase.MockServiceUsecase) {},
+		},
+		{
+			name:                 "get error",
+			isSetUserIDToContext: true,
+			expectStatusCode:     http.StatusInternalServerError,
+			setMockUsecase: func(u *mockUsecase.MockServiceUsecase) {
+				u.EXPECT().
+					Gets(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/services", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			w := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			if tt.isSetUserIDToContext {
+				ctx.Set("userID", service.UserID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockServiceUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			h := handler.NewServiceHandler(u)
+			h.Gets(ctx)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
@@ -5,22 +5,51 @@ import (
 	"holos-auth-api/internal/app/api/interface/pkg/errors"
 	"holos-auth-api/internal/app/api/usecase"
 	"log"
+	"slices"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+// STEP_UP_ROUTESを指定しない場合に, 直近の再認証を必要とするルート.
+var DefaultStepUpRoutes = []string{
+	"POST /agents/:id/token",
+	"POST /agents/:id/secret",
+	"POST /agents/:id/keys",
+	"PUT /agents/:id/certificate",
+	"PUT /agents/:id/policies",
+	"PUT /agents/:id/permissions/:policy_id",
+	"POST /config/apply",
+	"POST /policies/import",
+	"PUT /policies/:id/agents",
+	"POST /policies/:id/versions/:version/restore",
+	"PUT /roles/:id/agents",
+	"PUT /roles/:id/policies",
+	"PUT /agent-groups/:id/agents",
+	"PUT /agent-groups/:id/policies",
+}
+
 type AuthMiddleware interface {
 	Authenticate(*gin.Context)
+	RequireRecentAuthentication(*gin.Context)
 }
 
 type authMiddleware struct {
-	authUsecase usecase.AuthUsecase
+	authUsecase  usecase.AuthUsecase
+	stepUpRoutes []string
 }
 
-func NewAuthMiddleware(authUsecase usecase.AuthUsecase) AuthMiddleware {
+// stepUpRoutesは"POST /agents/:id/token"の形式で, 直近の再認証を必要とするルートを指定する.
+func NewAuthMiddleware(authUsecase usecase.AuthUsecase, stepUpRoutes []string) AuthMiddleware {
+	routes := make([]string, len(stepUpRoutes))
+	for i, route := range stepUpRoutes {
+		method, path, _ := strings.Cut(strings.TrimSpace(route), " ")
+		routes[i] = stepUpRoute(method, path)
+	}
+
 	return &authMiddleware{
-		authUsecase: authUsecase,
+		authUsecase:  authUsecase,
+		stepUpRoutes: routes,
 	}
 }
 
@@ -48,3 +77,40 @@ func (m *authMiddleware) Authenticate(c *gin.Context) {
 	c.Set("userID", userID)
 	c.Next()
 }
+
+func (m *authMiddleware) RequireRecentAuthentication(c *gin.Context) {
+	if !slices.Contains(m.stepUpRoutes, stepUpRoute(c.Request.Method, c.FullPath())) {
+		c.Next()
+		return
+	}
+
+	bearerToken := strings.Split(c.Request.Header.Get("Authorization"), " ")
+	if len(bearerToken) != 2 || bearerToken[0] != "Bearer" {
+		status := errors.StatusUnauthorized
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		c.Abort()
+		return
+	}
+
+	ctx := context.Background()
+
+	if err := m.authUsecase.VerifyRecentAuthentication(ctx, bearerToken[1]); err != nil {
+		status := errors.HandleError(err)
+		if err == usecase.ErrReauthenticationRequired {
+			// RFC 9470に従い, クライアントに再認証を促す.
+			status = errors.StatusReauthenticationRequired
+			c.Header("WWW-Authenticate", `Bearer error="insufficient_user_authentication", error_description="reauthentication required"`)
+		}
+		log.Println(status.Message())
+		c.String(status.Code(), status.Message())
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+func stepUpRoute(method string, path string) string {
+	return strings.ToUpper(method) + " " + strings.TrimSuffix(path, "/")
+}
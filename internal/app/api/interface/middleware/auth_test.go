@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"holos-auth-api/internal/app/api/domain/entity"
 	"holos-auth-api/internal/app/api/interface/middleware"
+	"holos-auth-api/internal/app/api/usecase"
 	mockUsecase "holos-auth-api/test/mock/usecase"
 	"net/http"
 	"net/http/httptest"
@@ -83,7 +84,7 @@ func TestAuth_Authenticate(t *testing.T) {
 			u := mockUsecase.NewMockAuthUsecase(ctrl)
 			tt.setMockUsecase(u)
 
-			m := middleware.NewAuthMiddleware(u)
+			m := middleware.NewAuthMiddleware(u, nil)
 			m.Authenticate(ctx)
 
 			if w.Code != tt.expectStatusCode {
@@ -92,3 +93,241 @@ func TestAuth_Authenticate(t *testing.T) {
 		})
 	}
 }
+
+func TestAuth_RequireRecentAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userToken, err := entity.NewUserToken(uuid.New())
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                  string
+		method                string
+		authorizationHeader   string
+		expectStatusCode      int
+		expectWWWAuthenticate string
+		setMockUsecase        func(*mockUsecase.MockAuthUsecase)
+	}{
+		{
+			name:                  "recently authenticated",
+			method:                "POST",
+			authorizationHeader:   "Bearer " + userToken.Token,
+			expectStatusCode:      http.StatusOK,
+			expectWWWAuthenticate: "",
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					VerifyRecentAuthentication(gomock.Any(), userToken.Token).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "not step-up route",
+			method:                "GET",
+			authorizationHeader:   "Bearer " + userToken.Token,
+			expectStatusCode:      http.StatusOK,
+			expectWWWAuthenticate: "",
+			setMockUsecase:        func(u *mockUsecase.MockAuthUsecase) {},
+		},
+		{
+			name:                  "reauthentication required",
+			method:                "POST",
+			authorizationHeader:   "Bearer " + userToken.Token,
+			expectStatusCode:      http.StatusUnauthorized,
+			expectWWWAuthenticate: `Bearer error="insufficient_user_authentication", error_description="reauthentication required"`,
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					VerifyRecentAuthentication(gomock.Any(), userToken.Token).
+					Return(usecase.ErrReauthenticationRequired).
+					Times(1)
+			},
+		},
+		{
+			name:                  "invalid header",
+			method:                "POST",
+			authorizationHeader:   userToken.Token,
+			expectStatusCode:      http.StatusUnauthorized,
+			expectWWWAuthenticate: "",
+			setMockUsecase:        func(u *mockUsecase.MockAuthUsecase) {},
+		},
+		{
+			name:                  "verify error",
+			method:                "POST",
+			authorizationHeader:   "Bearer " + userToken.Token,
+			expectStatusCode:      http.StatusInternalServerError,
+			expectWWWAuthenticate: "",
+			setMockUsecase: func(u *mockUsecase.MockAuthUsecase) {
+				u.EXPECT().
+					VerifyRecentAuthentication(gomock.Any(), userToken.Token).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, "/agents/c99fc6e0-6e62-4de2-8a7e-5c608ceaa8c6/token", nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			req.Header.Add("Authorization", tt.authorizationHeader)
+			w := httptest.NewRecorder()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAuthUsecase(ctrl)
+			tt.setMockUsecase(u)
+
+			m := middleware.NewAuthMiddleware(u, []string{"POST /agents/:id/token"})
+
+			_, r := gin.CreateTestContext(w)
+			r.Any("/agents/:id/token", m.RequireRecentAuthentication, func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+			if header := w.Header().Get("WWW-Authenticate"); header != tt.expectWWWAuthenticate {
+				t.Errorf("\nexpect: %s \ngot: %s", tt.expectWWWAuthenticate, header)
+			}
+		})
+	}
+}
+
+func TestAuth_RequireRecentAuthentication_DefaultStepUpRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userToken, err := entity.NewUserToken(uuid.New())
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name             string
+		method           string
+		path             string
+		route            string
+		expectStatusCode int
+	}{
+		{
+			name:             "config apply",
+			method:           "POST",
+			path:             "/config/apply",
+			route:            "/config/apply",
+			expectStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:             "policies import",
+			method:           "POST",
+			path:             "/policies/import",
+			route:            "/policies/import",
+			expectStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:             "agent secret",
+			method:           "POST",
+			path:             "/agents/c99fc6e0-6e62-4de2-8a7e-5c608ceaa8c6/secret",
+			route:            "/agents/:id/secret",
+			expectStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:             "agent keys",
+			method:           "POST",
+			path:             "/agents/c99fc6e0-6e62-4de2-8a7e-5c608ceaa8c6/keys",
+			route:            "/agents/:id/keys",
+			expectStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:             "agent certificate",
+			method:           "PUT",
+			path:             "/agents/c99fc6e0-6e62-4de2-8a7e-5c608ceaa8c6/certificate",
+			route:            "/agents/:id/certificate",
+			expectStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:             "policy agents",
+			method:           "PUT",
+			path:             "/policies/c99fc6e0-6e62-4de2-8a7e-5c608ceaa8c6/agents",
+			route:            "/policies/:id/agents",
+			expectStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:             "policy version restore",
+			method:           "POST",
+			path:             "/policies/c99fc6e0-6e62-4de2-8a7e-5c608ceaa8c6/versions/2/restore",
+			route:            "/policies/:id/versions/:version/restore",
+			expectStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:             "role agents",
+			method:           "PUT",
+			path:             "/roles/c99fc6e0-6e62-4de2-8a7e-5c608ceaa8c6/agents",
+			route:            "/roles/:id/agents",
+			expectStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:             "role policies",
+			method:           "PUT",
+			path:             "/roles/c99fc6e0-6e62-4de2-8a7e-5c608ceaa8c6/policies",
+			route:            "/roles/:id/policies",
+			expectStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:             "agent group agents",
+			method:           "PUT",
+			path:             "/agent-groups/c99fc6e0-6e62-4de2-8a7e-5c608ceaa8c6/agents",
+			route:            "/agent-groups/:id/agents",
+			expectStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:             "agent group policies",
+			method:           "PUT",
+			path:             "/agent-groups/c99fc6e0-6e62-4de2-8a7e-5c608ceaa8c6/policies",
+			route:            "/agent-groups/:id/policies",
+			expectStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:             "config plan",
+			method:           "POST",
+			path:             "/config/plan",
+			route:            "/config/plan",
+			expectStatusCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.path, nil)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			req.Header.Add("Authorization", "Bearer "+userToken.Token)
+			w := httptest.NewRecorder()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mockUsecase.NewMockAuthUsecase(ctrl)
+			u.EXPECT().
+				VerifyRecentAuthentication(gomock.Any(), userToken.Token).
+				Return(usecase.ErrReauthenticationRequired).
+				AnyTimes()
+
+			m := middleware.NewAuthMiddleware(u, middleware.DefaultStepUpRoutes)
+
+			_, r := gin.CreateTestContext(w)
+			r.Handle(tt.method, tt.route, m.RequireRecentAuthentication, func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectStatusCode {
+				t.Errorf("\nexpect: %d \ngot: %d", tt.expectStatusCode, w.Code)
+			}
+		})
+	}
+}
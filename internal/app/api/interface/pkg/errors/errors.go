@@ -12,6 +12,8 @@ var (
 	StatusForbidden           = status.New(http.StatusForbidden, "forbidden")
 	StatusNotFound            = status.New(http.StatusNotFound, "resource not found")
 	StatusInternalServerError = status.New(http.StatusInternalServerError, "internal server error")
+
+	StatusReauthenticationRequired = status.New(http.StatusUnauthorized, "reauthentication required")
 )
 
 func HandleError(err error) *status.Status {
@@ -22,8 +24,8 @@ func HandleError(err error) *status.Status {
 	s := status.FromError(err)
 
 	switch s.Code() {
-	case http.StatusBadRequest:
-		// bad requestの時のみstatusをそのまま返却する.
+	case http.StatusBadRequest, http.StatusConflict:
+		// bad requestとconflictの時のみstatusをそのまま返却する.
 		return s
 	case http.StatusUnauthorized:
 		return StatusUnauthorized
@@ -4,14 +4,22 @@ import (
 	"fmt"
 	"holos-auth-api/internal/app/api/pkg/status"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 func GetPathParameter[T any](c *gin.Context, name string) (T, error) {
+	return parseParameter[T](c.Param(name), "invalid path parameter type")
+}
+
+func GetQueryParameter[T any](c *gin.Context, name string) (T, error) {
+	return parseParameter[T](c.Query(name), "invalid query parameter type")
+}
+
+func parseParameter[T any](param string, invalidTypeMessage string) (T, error) {
 	var zero T
-	param := c.Param(name)
 
 	switch any(zero).(type) {
 	case uuid.UUID:
@@ -20,8 +28,14 @@ func GetPathParameter[T any](c *gin.Context, name string) (T, error) {
 			return zero, status.Error(http.StatusBadRequest, err.Error())
 		}
 		return any(v).(T), nil
+	case int:
+		v, err := strconv.Atoi(param)
+		if err != nil {
+			return zero, status.Error(http.StatusBadRequest, err.Error())
+		}
+		return any(v).(T), nil
 	default:
-		return zero, status.Error(http.StatusInternalServerError, "invalid path parameter type")
+		return zero, status.Error(http.StatusInternalServerError, invalidTypeMessage)
 	}
 }
 
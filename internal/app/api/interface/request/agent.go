@@ -13,3 +13,24 @@ type UpdateAgentRequest struct {
 type UpdateAgentPoliciesRequest struct {
 	PolicyIDs []uuid.UUID `json:"policy_ids"`
 }
+
+type BindAgentCertificateRequest struct {
+	Fingerprint string `json:"fingerprint"`
+	Subject     string `json:"subject"`
+}
+
+type UpdateAgentAttributesRequest struct {
+	Attributes map[string]string `json:"attributes"`
+}
+
+type CreateAgentPublicKeyRequest struct {
+	PublicKey string `json:"public_key"`
+}
+
+type SimulateAgentRequest struct {
+	Service    string            `json:"service"`
+	Path       string            `json:"path"`
+	Method     string            `json:"method"`
+	SourceIP   string            `json:"source_ip"`
+	Attributes map[string]string `json:"attributes"`
+}
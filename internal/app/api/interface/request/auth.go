@@ -1,6 +1,29 @@
 package request
 
+import "github.com/google/uuid"
+
 type SigninRequest struct {
 	UserName string `json:"user_name"`
 	Password string `json:"password"`
 }
+
+type ReauthenticateRequest struct {
+	Password string `json:"password"`
+}
+
+type CreateDelegatedTokenRequest struct {
+	PolicyIDs []uuid.UUID `json:"policy_ids"`
+	TTL       int         `json:"ttl"`
+}
+
+type AuthorizeBatchRequest struct {
+	Requests []AuthorizationTupleRequest `json:"requests"`
+}
+
+type AuthorizationTupleRequest struct {
+	Service    string            `json:"service"`
+	Path       string            `json:"path"`
+	Method     string            `json:"method"`
+	SourceIP   string            `json:"source_ip"`
+	Attributes map[string]string `json:"attributes"`
+}
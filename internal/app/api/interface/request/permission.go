@@ -0,0 +1,8 @@
+package request
+
+import "time"
+
+type GrantPermissionRequest struct {
+	ValidFrom  *time.Time `json:"valid_from"`
+	ValidUntil *time.Time `json:"valid_until"`
+}
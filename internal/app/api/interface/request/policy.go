@@ -1,21 +1,45 @@
 package request
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type CreatePolicyRequest struct {
-	Name    string   `json:"name"`
-	Effect  string   `json:"effect"`
-	Service string   `json:"service"`
-	Path    string   `json:"path"`
-	Methods []string `json:"methods"`
+	Name       string                   `json:"name"`
+	Effect     string                   `json:"effect"`
+	Service    string                   `json:"service"`
+	Path       string                   `json:"path"`
+	Methods    []string                 `json:"methods"`
+	Conditions *PolicyConditionsRequest `json:"conditions"`
+	Expression string                   `json:"expression"`
+	ValidFrom  *time.Time               `json:"valid_from"`
+	ValidUntil *time.Time               `json:"valid_until"`
 }
 
 type UpdatePolicyRequest struct {
-	Name    string   `json:"name"`
-	Effect  string   `json:"effect"`
-	Service string   `json:"service"`
-	Path    string   `json:"path"`
-	Methods []string `json:"methods"`
+	Name       string                   `json:"name"`
+	Effect     string                   `json:"effect"`
+	Service    string                   `json:"service"`
+	Path       string                   `json:"path"`
+	Methods    []string                 `json:"methods"`
+	Conditions *PolicyConditionsRequest `json:"conditions"`
+	Expression string                   `json:"expression"`
+	ValidFrom  *time.Time               `json:"valid_from"`
+	ValidUntil *time.Time               `json:"valid_until"`
+}
+
+type PolicyConditionsRequest struct {
+	TimeZone    string              `json:"time_zone" yaml:"time_zone"`
+	DaysOfWeek  []string            `json:"days_of_week" yaml:"days_of_week"`
+	StartTime   string              `json:"start_time" yaml:"start_time"`
+	EndTime     string              `json:"end_time" yaml:"end_time"`
+	NotBefore   *time.Time          `json:"not_before" yaml:"not_before"`
+	NotAfter    *time.Time          `json:"not_after" yaml:"not_after"`
+	SourceCIDRs []string            `json:"source_cidrs" yaml:"source_cidrs"`
+	Attributes  map[string][]string `json:"attributes" yaml:"attributes"`
+	PathParams  map[string]string   `json:"path_params" yaml:"path_params"`
 }
 
 type UpdatePolicyAgentsRequest struct {
@@ -0,0 +1,19 @@
+package request
+
+import "github.com/google/uuid"
+
+type CreatePolicyBundleRequest struct {
+	Name string `json:"name"`
+}
+
+type UpdatePolicyBundleRequest struct {
+	Name string `json:"name"`
+}
+
+type UpdatePolicyBundlePoliciesRequest struct {
+	PolicyIDs []uuid.UUID `json:"policy_ids"`
+}
+
+type UpdatePolicyBundleAgentsRequest struct {
+	AgentIDs []uuid.UUID `json:"agent_ids"`
+}
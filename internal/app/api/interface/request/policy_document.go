@@ -0,0 +1,32 @@
+package request
+
+import "time"
+
+// インポートと設定の計画, 適用で共通の文書. JSONとYAMLのどちらでも受け付ける.
+type PolicyDocumentRequest struct {
+	Version  int                           `json:"version" yaml:"version"`
+	Policies []PolicyDocumentPolicyRequest `json:"policies" yaml:"policies"`
+	Agents   []PolicyDocumentAgentRequest  `json:"agents" yaml:"agents"`
+	Bindings []PolicyBindingRequest        `json:"bindings" yaml:"bindings"`
+}
+
+type PolicyDocumentPolicyRequest struct {
+	Name       string                   `json:"name" yaml:"name"`
+	Effect     string                   `json:"effect" yaml:"effect"`
+	Service    string                   `json:"service" yaml:"service"`
+	Path       string                   `json:"path" yaml:"path"`
+	Methods    []string                 `json:"methods" yaml:"methods"`
+	Conditions *PolicyConditionsRequest `json:"conditions" yaml:"conditions"`
+	Expression string                   `json:"expression" yaml:"expression"`
+	ValidFrom  *time.Time               `json:"valid_from" yaml:"valid_from"`
+	ValidUntil *time.Time               `json:"valid_until" yaml:"valid_until"`
+}
+
+type PolicyDocumentAgentRequest struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type PolicyBindingRequest struct {
+	Agent    string   `json:"agent" yaml:"agent"`
+	Policies []string `json:"policies" yaml:"policies"`
+}
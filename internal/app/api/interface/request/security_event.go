@@ -0,0 +1,5 @@
+package request
+
+type ReportLeakedTokensRequest struct {
+	Tokens []string `json:"tokens"`
+}
@@ -0,0 +1,13 @@
+package request
+
+type CreateServiceRequest struct {
+	Name       string   `json:"name"`
+	Methods    []string `json:"methods"`
+	PathSchema string   `json:"path_schema"`
+}
+
+type UpdateServiceRequest struct {
+	Name       string   `json:"name"`
+	Methods    []string `json:"methods"`
+	PathSchema string   `json:"path_schema"`
+}
@@ -16,3 +16,32 @@ type AgentResponse struct {
 type AgentTokenResponse struct {
 	GeneratedAt time.Time `json:"generated_at"`
 }
+
+type AgentCertificateResponse struct {
+	Fingerprint string    `json:"fingerprint"`
+	Subject     string    `json:"subject"`
+	BoundAt     time.Time `json:"bound_at"`
+}
+
+type AgentAttributesResponse struct {
+	Attributes map[string]string `json:"attributes"`
+}
+
+type AgentSecretResponse struct {
+	KeyID       string    `json:"key_id"`
+	Secret      string    `json:"secret,omitempty"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+type AgentPublicKeyResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Algorithm string    `json:"algorithm"`
+	PublicKey string    `json:"public_key"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AgentDelegatedTokenResponse struct {
+	Token     string      `json:"token"`
+	PolicyIDs []uuid.UUID `json:"policy_ids"`
+	ExpiresAt time.Time   `json:"expires_at"`
+}
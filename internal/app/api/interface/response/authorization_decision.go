@@ -0,0 +1,28 @@
+package response
+
+import "github.com/google/uuid"
+
+type AuthorizationSimulationResponse struct {
+	Allowed          bool                        `json:"allowed"`
+	DecidingPolicyID *uuid.UUID                  `json:"deciding_policy_id"`
+	Policies         []*PolicyEvaluationResponse `json:"policies"`
+}
+
+type PolicyEvaluationResponse struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Effect  string    `json:"effect"`
+	Matched bool      `json:"matched"`
+	Result  string    `json:"result"`
+}
+
+type AuthorizationResponse struct {
+	Allowed            bool       `json:"allowed"`
+	OperatorType       string     `json:"operator_type"`
+	UserID             uuid.UUID  `json:"user_id"`
+	AgentID            *uuid.UUID `json:"agent_id"`
+	DecidingPolicyID   *uuid.UUID `json:"deciding_policy_id"`
+	DecidingPolicyName *string    `json:"deciding_policy_name"`
+	DenyReason         *string    `json:"deny_reason"`
+	CacheTTL           int        `json:"cache_ttl"`
+}
@@ -0,0 +1,13 @@
+package response
+
+type ConfigPlanResponse struct {
+	Fingerprint string                  `json:"fingerprint"`
+	Changes     []*ConfigChangeResponse `json:"changes"`
+}
+
+type ConfigChangeResponse struct {
+	Resource string   `json:"resource"`
+	Action   string   `json:"action"`
+	Name     string   `json:"name"`
+	Fields   []string `json:"fields"`
+}
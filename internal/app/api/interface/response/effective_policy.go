@@ -0,0 +1,14 @@
+package response
+
+import "github.com/google/uuid"
+
+type EffectivePolicyResponse struct {
+	*PolicyResponse
+	Sources []*PolicySourceResponse `json:"sources"`
+}
+
+type PolicySourceResponse struct {
+	Type string    `json:"type"`
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
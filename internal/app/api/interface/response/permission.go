@@ -0,0 +1,14 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PermissionResponse struct {
+	AgentID    uuid.UUID  `json:"agent_id"`
+	PolicyID   uuid.UUID  `json:"policy_id"`
+	ValidFrom  *time.Time `json:"valid_from"`
+	ValidUntil *time.Time `json:"valid_until"`
+}
@@ -0,0 +1,14 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PolicyBundleResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
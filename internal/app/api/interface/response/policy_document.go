@@ -0,0 +1,31 @@
+package response
+
+import "time"
+
+type PolicyDocumentResponse struct {
+	Version  int                             `json:"version" yaml:"version"`
+	Policies []*PolicyDocumentPolicyResponse `json:"policies" yaml:"policies"`
+	Agents   []*PolicyDocumentAgentResponse  `json:"agents" yaml:"agents"`
+	Bindings []*PolicyBindingResponse        `json:"bindings" yaml:"bindings"`
+}
+
+type PolicyDocumentPolicyResponse struct {
+	Name       string                    `json:"name" yaml:"name"`
+	Effect     string                    `json:"effect" yaml:"effect"`
+	Service    string                    `json:"service" yaml:"service"`
+	Path       string                    `json:"path" yaml:"path"`
+	Methods    []string                  `json:"methods" yaml:"methods"`
+	Conditions *PolicyConditionsResponse `json:"conditions" yaml:"conditions"`
+	Expression string                    `json:"expression" yaml:"expression"`
+	ValidFrom  *time.Time                `json:"valid_from" yaml:"valid_from"`
+	ValidUntil *time.Time                `json:"valid_until" yaml:"valid_until"`
+}
+
+type PolicyDocumentAgentResponse struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type PolicyBindingResponse struct {
+	Agent    string   `json:"agent" yaml:"agent"`
+	Policies []string `json:"policies" yaml:"policies"`
+}
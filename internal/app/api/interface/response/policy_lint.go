@@ -0,0 +1,10 @@
+package response
+
+import "github.com/google/uuid"
+
+type PolicyLintFindingResponse struct {
+	Type      string      `json:"type"`
+	Severity  string      `json:"severity"`
+	PolicyIDs []uuid.UUID `json:"policy_ids"`
+	Message   string      `json:"message"`
+}
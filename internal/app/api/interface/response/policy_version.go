@@ -0,0 +1,29 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PolicyVersionResponse struct {
+	Version    int                       `json:"version"`
+	AuthorID   uuid.UUID                 `json:"author_id"`
+	Operation  string                    `json:"operation"`
+	Name       string                    `json:"name"`
+	Effect     string                    `json:"effect"`
+	Service    string                    `json:"service"`
+	Path       string                    `json:"path"`
+	Methods    []string                  `json:"methods"`
+	Conditions *PolicyConditionsResponse `json:"conditions"`
+	Expression string                    `json:"expression"`
+	ValidFrom  *time.Time                `json:"valid_from"`
+	ValidUntil *time.Time                `json:"valid_until"`
+	CreatedAt  time.Time                 `json:"created_at"`
+}
+
+type PolicyVersionDiffResponse struct {
+	From    *PolicyVersionResponse `json:"from"`
+	To      *PolicyVersionResponse `json:"to"`
+	Changes []string               `json:"changes"`
+}
@@ -0,0 +1,14 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SecurityEventResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Type       string    `json:"type"`
+	Detail     string    `json:"detail"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,16 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ServiceResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Methods    []string  `json:"methods"`
+	PathSchema string    `json:"path_schema"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
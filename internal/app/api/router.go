@@ -6,13 +6,13 @@ func registerRouter(r *gin.Engine) {
 	users := r.Group("users")
 	{
 		users.POST("/", userHandler.Create)
-		users.DELETE("/", authMiddleware.Authenticate, userHandler.Delete)
-		users.PUT("/password", authMiddleware.Authenticate, userHandler.UpdatePassword)
+		users.DELETE("/", authMiddleware.Authenticate, authMiddleware.RequireRecentAuthentication, userHandler.Delete)
+		users.PUT("/password", authMiddleware.Authenticate, authMiddleware.RequireRecentAuthentication, userHandler.UpdatePassword)
 	}
 
 	agents := r.Group("agents")
 	{
-		agents.Use(authMiddleware.Authenticate)
+		agents.Use(authMiddleware.Authenticate, authMiddleware.RequireRecentAuthentication)
 		agents.GET("/", agentHandler.Gets)
 		agents.POST("/", agentHandler.Create)
 		agents.GET("/:id", agentHandler.Get)
@@ -20,27 +20,100 @@ func registerRouter(r *gin.Engine) {
 		agents.DELETE("/:id", agentHandler.Delete)
 		agents.GET("/:id/policies", agentHandler.GetPolicies)
 		agents.PUT("/:id/policies", agentHandler.UpdatePolicies)
+		agents.GET("/:id/permissions", permissionHandler.Gets)
+		agents.PUT("/:id/permissions/:policy_id", permissionHandler.Grant)
+		agents.DELETE("/:id/permissions/:policy_id", permissionHandler.Revoke)
+		agents.GET("/:id/effective-policies", agentHandler.GetEffectivePolicies)
 		agents.GET("/:id/token", agentHandler.GetToken)
 		agents.POST("/:id/token", agentHandler.GenerateToken)
 		agents.DELETE("/:id/token", agentHandler.DeleteToken)
+		agents.GET("/:id/certificate", agentHandler.GetCertificate)
+		agents.PUT("/:id/certificate", agentHandler.BindCertificate)
+		agents.DELETE("/:id/certificate", agentHandler.UnbindCertificate)
+		agents.GET("/:id/attributes", agentHandler.GetAttributes)
+		agents.PUT("/:id/attributes", agentHandler.UpdateAttributes)
+		agents.GET("/:id/secret", agentHandler.GetSecret)
+		agents.POST("/:id/secret", agentHandler.GenerateSecret)
+		agents.DELETE("/:id/secret", agentHandler.DeleteSecret)
+		agents.GET("/:id/keys", agentHandler.GetPublicKeys)
+		agents.POST("/:id/keys", agentHandler.CreatePublicKey)
+		agents.DELETE("/:id/keys/:key_id", agentHandler.DeletePublicKey)
+		agents.POST("/:id/simulate", agentHandler.Simulate)
 	}
 
 	policies := r.Group("policies")
 	{
-		policies.Use(authMiddleware.Authenticate)
+		policies.Use(authMiddleware.Authenticate, authMiddleware.RequireRecentAuthentication)
 		policies.GET("/", policyHandler.Gets)
 		policies.POST("/", policyHandler.Create)
+		policies.GET("/export", policyHandler.Export)
+		policies.POST("/import", policyHandler.Import)
+		policies.GET("/lint", policyHandler.Lint)
 		policies.GET("/:id", policyHandler.Get)
 		policies.PUT("/:id", policyHandler.Update)
 		policies.DELETE("/:id", policyHandler.Delete)
 		policies.GET("/:id/agents", policyHandler.GetAgents)
 		policies.PUT("/:id/agents", policyHandler.UpdateAgents)
+		policies.GET("/:id/versions", policyHandler.GetVersions)
+		policies.GET("/:id/versions/diff", policyHandler.DiffVersions)
+		policies.POST("/:id/versions/:version/restore", policyHandler.RestoreVersion)
+	}
+
+	agentGroups := r.Group("agent-groups")
+	{
+		agentGroups.Use(authMiddleware.Authenticate, authMiddleware.RequireRecentAuthentication)
+		agentGroups.GET("/", agentGroupHandler.Gets)
+		agentGroups.POST("/", agentGroupHandler.Create)
+		agentGroups.GET("/:id", agentGroupHandler.Get)
+		agentGroups.PUT("/:id", agentGroupHandler.Update)
+		agentGroups.DELETE("/:id", agentGroupHandler.Delete)
+		agentGroups.GET("/:id/policies", agentGroupHandler.GetPolicies)
+		agentGroups.PUT("/:id/policies", agentGroupHandler.UpdatePolicies)
+		agentGroups.GET("/:id/agents", agentGroupHandler.GetAgents)
+		agentGroups.PUT("/:id/agents", agentGroupHandler.UpdateAgents)
+	}
+
+	roles := r.Group("roles")
+	{
+		roles.Use(authMiddleware.Authenticate, authMiddleware.RequireRecentAuthentication)
+		roles.GET("/", roleHandler.Gets)
+		roles.POST("/", roleHandler.Create)
+		roles.GET("/:id", roleHandler.Get)
+		roles.PUT("/:id", roleHandler.Update)
+		roles.DELETE("/:id", roleHandler.Delete)
+		roles.GET("/:id/policies", roleHandler.GetPolicies)
+		roles.PUT("/:id/policies", roleHandler.UpdatePolicies)
+		roles.GET("/:id/agents", roleHandler.GetAgents)
+		roles.PUT("/:id/agents", roleHandler.UpdateAgents)
+	}
+
+	services := r.Group("services")
+	{
+		services.Use(authMiddleware.Authenticate, authMiddleware.RequireRecentAuthentication)
+		services.GET("/", serviceHandler.Gets)
+		services.POST("/", serviceHandler.Create)
+		services.GET("/:id", serviceHandler.Get)
+		services.PUT("/:id", serviceHandler.Update)
+		services.DELETE("/:id", serviceHandler.Delete)
+	}
+
+	config := r.Group("config")
+	{
+		config.Use(authMiddleware.Authenticate, authMiddleware.RequireRecentAuthentication)
+		config.POST("/plan", configHandler.Plan)
+		config.POST("/apply", configHandler.Apply)
 	}
 
 	auth := r.Group("auth")
 	{
 		auth.GET("/authorization", authHandler.Authorize)
+		auth.POST("/authorization/batch", authHandler.AuthorizeBatch)
 		auth.POST("/signin", authHandler.Signin)
 		auth.DELETE("/signout", authHandler.Signout)
+		auth.POST("/reauthenticate", authHandler.Reauthenticate)
+		auth.POST("/delegated-tokens", authHandler.CreateDelegatedToken)
 	}
+
+	r.POST("/leaked-tokens", securityEventHandler.ReportLeakedTokens)
+	r.GET("/security-events", authMiddleware.Authenticate, authMiddleware.RequireRecentAuthentication, securityEventHandler.Gets)
 }
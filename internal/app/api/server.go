@@ -2,11 +2,17 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/interface/middleware"
 	"holos-auth-api/internal/pkg/config"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -40,7 +46,17 @@ func Serve() {
 		log.Fatalln(err)
 	}
 
-	inject(db)
+	legacyTokenDeadline, err := parseLegacyTokenDeadline()
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	leakedTokenReporters, err := parseLeakedTokenReporters()
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	inject(db, legacyTokenDeadline, leakedTokenReporters, parseStepUpRoutes(), parseHeadImpliedByGet())
 
 	r := gin.Default()
 	registerRouter(r)
@@ -49,16 +65,31 @@ func Serve() {
 		Addr:    ":8000",
 		Handler: r,
 	}
+	if config.TLSCertFile != "" {
+		tlsConfig, err := newTLSConfig()
+		if err != nil {
+			log.Fatalln(err.Error())
+		}
+		srv.TLSConfig = tlsConfig
+	}
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt, os.Kill)
 	defer stop()
 
 	go func() {
-		if err := srv.ListenAndServe(); err != nil {
+		var err error
+		if srv.TLSConfig != nil {
+			err = srv.ListenAndServeTLS(config.TLSCertFile, config.TLSKeyFile)
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil {
 			log.Println(err.Error())
 		}
 	}()
 
+	go deleteExpiredPermissions(ctx)
+
 	<-ctx.Done()
 
 	ctx, stop = context.WithTimeout(context.Background(), 10*time.Second)
@@ -68,3 +99,79 @@ func Serve() {
 		log.Fatalln(err.Error())
 	}
 }
+
+// 有効期間の終了した紐付けを定期的に削除する. 判定には使われないため, 失敗しても次の実行で再び削除する.
+func deleteExpiredPermissions(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := permissionUsecase.DeleteExpired(ctx); err != nil {
+				log.Println(err.Error())
+			}
+		}
+	}
+}
+
+func newTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if config.TLSClientCAFile != "" {
+		bundle, err := os.ReadFile(config.TLSClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, errors.New("no client ca certificates found")
+		}
+		tlsConfig.ClientCAs = pool
+		// ユーザーはクライアント証明書を持たないため, 提示された場合のみ検証する.
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return tlsConfig, nil
+}
+
+// 旧形式のトークンを受け付ける期限. 未設定の場合は期限なし.
+func parseLegacyTokenDeadline() (time.Time, error) {
+	if config.LegacyTokenDeadline == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, config.LegacyTokenDeadline)
+}
+
+// 漏洩トークン通知元の鍵. 形式は"keyid:secret,keyid2:secret2".
+func parseLeakedTokenReporters() ([]*entity.LeakedTokenReporter, error) {
+	if config.LeakedTokenReporterKeys == "" {
+		return nil, nil
+	}
+	var reporters []*entity.LeakedTokenReporter
+	for _, pair := range strings.Split(config.LeakedTokenReporterKeys, ",") {
+		keyID, secret, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || keyID == "" || secret == "" {
+			return nil, errors.New("invalid leaked token reporter key")
+		}
+		reporters = append(reporters, entity.NewLeakedTokenReporter(keyID, secret))
+	}
+	return reporters, nil
+}
+
+// 直近の再認証を必要とするルート. 形式は"POST /agents/:id/token,PUT /agents/:id/policies"で, 未指定の場合は既定のルートを用いる.
+func parseStepUpRoutes() []string {
+	if config.StepUpRoutes == "" {
+		return middleware.DefaultStepUpRoutes
+	}
+	return strings.Split(config.StepUpRoutes, ",")
+}
+
+// GETを許可するポリシーとサービスでHEADも許可するか. 既定では許可しない.
+func parseHeadImpliedByGet() bool {
+	return config.HeadImpliedByGet == "true"
+}
@@ -19,6 +19,11 @@ var (
 	ErrAgentAlreadyExists = status.Error(http.StatusBadRequest, "agent already exists")
 	ErrAgentNotFound      = status.Error(http.StatusNotFound, "agent not found")
 	ErrAgentTokenNotFound = status.Error(http.StatusNotFound, "agent token not found")
+
+	ErrAgentCertificateNotFound     = status.Error(http.StatusNotFound, "agent certificate not found")
+	ErrAgentCertificateAlreadyBound = status.Error(http.StatusBadRequest, "agent certificate is already bound to another agent")
+	ErrAgentSecretNotFound          = status.Error(http.StatusNotFound, "agent secret not found")
+	ErrAgentPublicKeyNotFound       = status.Error(http.StatusNotFound, "agent public key not found")
 )
 
 type AgentUsecase interface {
@@ -29,32 +34,57 @@ type AgentUsecase interface {
 	Gets(context.Context, string, uuid.UUID) ([]*dto.AgentDTO, error)
 	UpdatePolicies(context.Context, uuid.UUID, uuid.UUID, []uuid.UUID) ([]*dto.PolicyDTO, error)
 	GetPolicies(context.Context, uuid.UUID, uuid.UUID, string) ([]*dto.PolicyDTO, error)
+	GetEffectivePolicies(context.Context, uuid.UUID, uuid.UUID) ([]*dto.EffectivePolicyDTO, error)
 	GenerateToken(context.Context, uuid.UUID, uuid.UUID) (string, error)
 	DeleteToken(context.Context, uuid.UUID, uuid.UUID) error
 	GetToken(context.Context, uuid.UUID, uuid.UUID) (*dto.AgentTokenDTO, error)
+	BindCertificate(context.Context, uuid.UUID, uuid.UUID, string, string) (*dto.AgentCertificateDTO, error)
+	UnbindCertificate(context.Context, uuid.UUID, uuid.UUID) error
+	GetCertificate(context.Context, uuid.UUID, uuid.UUID) (*dto.AgentCertificateDTO, error)
+	UpdateAttributes(context.Context, uuid.UUID, uuid.UUID, map[string]string) (*dto.AgentAttributesDTO, error)
+	GetAttributes(context.Context, uuid.UUID, uuid.UUID) (*dto.AgentAttributesDTO, error)
+	GenerateSecret(context.Context, uuid.UUID, uuid.UUID) (*dto.AgentSecretDTO, error)
+	DeleteSecret(context.Context, uuid.UUID, uuid.UUID) error
+	GetSecret(context.Context, uuid.UUID, uuid.UUID) (*dto.AgentSecretDTO, error)
+	CreatePublicKey(context.Context, uuid.UUID, uuid.UUID, string) (*dto.AgentPublicKeyDTO, error)
+	DeletePublicKey(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error
+	GetPublicKeys(context.Context, uuid.UUID, uuid.UUID) ([]*dto.AgentPublicKeyDTO, error)
+	Simulate(context.Context, uuid.UUID, uuid.UUID, string, string, string, string, map[string]string) (*dto.AuthorizationDecisionDTO, error)
 }
 
 type agentUsecase struct {
-	transactionObject    domain.TransactionObject
-	agentRepository      repository.AgentRepository
-	agentTokenRepository repository.AgentTokenRepository
-	policyRepository     repository.PolicyRepository
-	agentService         service.AgentService
+	transactionObject          domain.TransactionObject
+	agentRepository            repository.AgentRepository
+	agentTokenRepository       repository.AgentTokenRepository
+	agentCertificateRepository repository.AgentCertificateRepository
+	agentSecretRepository      repository.AgentSecretRepository
+	agentPublicKeyRepository   repository.AgentPublicKeyRepository
+	agentAttributesRepository  repository.AgentAttributesRepository
+	policyRepository           repository.PolicyRepository
+	agentService               service.AgentService
 }
 
 func NewAgentUsecase(
 	transactionObject domain.TransactionObject,
 	agentRepository repository.AgentRepository,
 	agentTokenRepository repository.AgentTokenRepository,
+	agentCertificateRepository repository.AgentCertificateRepository,
+	agentSecretRepository repository.AgentSecretRepository,
+	agentPublicKeyRepository repository.AgentPublicKeyRepository,
+	agentAttributesRepository repository.AgentAttributesRepository,
 	policyRepository repository.PolicyRepository,
 	agentService service.AgentService,
 ) AgentUsecase {
 	return &agentUsecase{
-		transactionObject:    transactionObject,
-		agentRepository:      agentRepository,
-		agentTokenRepository: agentTokenRepository,
-		policyRepository:     policyRepository,
-		agentService:         agentService,
+		transactionObject:          transactionObject,
+		agentRepository:            agentRepository,
+		agentTokenRepository:       agentTokenRepository,
+		agentCertificateRepository: agentCertificateRepository,
+		agentSecretRepository:      agentSecretRepository,
+		agentPublicKeyRepository:   agentPublicKeyRepository,
+		agentAttributesRepository:  agentAttributesRepository,
+		policyRepository:           policyRepository,
+		agentService:               agentService,
 	}
 }
 
@@ -179,6 +209,27 @@ func (u *agentUsecase) GetPolicies(ctx context.Context, id uuid.UUID, userID uui
 	return mapper.ToPolicyDTOs(policies), nil
 }
 
+func (u *agentUsecase) GetEffectivePolicies(ctx context.Context, id uuid.UUID, userID uuid.UUID) ([]*dto.EffectivePolicyDTO, error) {
+	effectivePolicies := []*entity.EffectivePolicy{}
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		agent, err := u.agentRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+		if agent == nil {
+			return ErrAgentNotFound
+		}
+
+		effectivePolicies, err = u.agentService.GetEffectivePolicies(ctx, agent)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToEffectivePolicyDTOs(effectivePolicies), nil
+}
+
 func (u *agentUsecase) GenerateToken(ctx context.Context, id uuid.UUID, userID uuid.UUID) (string, error) {
 	var agentToken *entity.AgentToken
 
@@ -229,3 +280,230 @@ func (u *agentUsecase) GetToken(ctx context.Context, id uuid.UUID, userID uuid.U
 
 	return mapper.ToAgentTokenDTO(agentToken), nil
 }
+
+func (u *agentUsecase) BindCertificate(ctx context.Context, id uuid.UUID, userID uuid.UUID, fingerprint string, subject string) (*dto.AgentCertificateDTO, error) {
+	var agentCertificate *entity.AgentCertificate
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		agent, err := u.agentRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+		if agent == nil {
+			return ErrAgentNotFound
+		}
+
+		agentCertificate, err = entity.NewAgentCertificate(agent.ID, fingerprint, subject)
+		if err != nil {
+			return err
+		}
+
+		// 他のエージェントの証明書を奪えないよう, 同じフィンガープリントかサブジェクトの紐付けがあれば拒否する.
+		bound, err := u.agentCertificateRepository.FindOneByFingerprintOrSubjectForUpdate(ctx, agentCertificate.Fingerprint, agentCertificate.Subject)
+		if err != nil {
+			return err
+		}
+		if bound != nil && bound.AgentID != agent.ID {
+			return ErrAgentCertificateAlreadyBound
+		}
+
+		return u.agentCertificateRepository.Save(ctx, agentCertificate)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToAgentCertificateDTO(agentCertificate), nil
+}
+
+func (u *agentUsecase) UnbindCertificate(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	return u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		agentCertificate, err := u.agentCertificateRepository.FindOneByAgentIDAndUserID(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+		if agentCertificate == nil {
+			return ErrAgentCertificateNotFound
+		}
+
+		return u.agentCertificateRepository.Delete(ctx, agentCertificate)
+	})
+}
+
+func (u *agentUsecase) GetCertificate(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.AgentCertificateDTO, error) {
+	agentCertificate, err := u.agentCertificateRepository.FindOneByAgentIDAndUserID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if agentCertificate == nil {
+		return nil, nil
+	}
+
+	return mapper.ToAgentCertificateDTO(agentCertificate), nil
+}
+
+func (u *agentUsecase) UpdateAttributes(ctx context.Context, id uuid.UUID, userID uuid.UUID, values map[string]string) (*dto.AgentAttributesDTO, error) {
+	var agentAttributes *entity.AgentAttributes
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		agent, err := u.agentRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+		if agent == nil {
+			return ErrAgentNotFound
+		}
+
+		agentAttributes, err = entity.NewAgentAttributes(agent.ID, values)
+		if err != nil {
+			return err
+		}
+
+		return u.agentAttributesRepository.Save(ctx, agentAttributes)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToAgentAttributesDTO(agentAttributes), nil
+}
+
+// 属性を設定していないエージェントは空の属性を返す.
+func (u *agentUsecase) GetAttributes(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.AgentAttributesDTO, error) {
+	agent, err := u.agentRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if agent == nil {
+		return nil, ErrAgentNotFound
+	}
+
+	agentAttributes, err := u.agentAttributesRepository.FindOneByAgentID(ctx, agent.ID)
+	if err != nil {
+		return nil, err
+	}
+	if agentAttributes == nil {
+		return &dto.AgentAttributesDTO{AgentID: agent.ID, Values: map[string]string{}}, nil
+	}
+
+	return mapper.ToAgentAttributesDTO(agentAttributes), nil
+}
+
+func (u *agentUsecase) GenerateSecret(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.AgentSecretDTO, error) {
+	var agentSecret *entity.AgentSecret
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		agent, err := u.agentRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+		if agent == nil {
+			return ErrAgentNotFound
+		}
+
+		agentSecret, err = entity.NewAgentSecret(agent.ID)
+		if err != nil {
+			return err
+		}
+
+		return u.agentSecretRepository.Save(ctx, agentSecret)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToAgentSecretDTO(agentSecret), nil
+}
+
+func (u *agentUsecase) DeleteSecret(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	return u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		agentSecret, err := u.agentSecretRepository.FindOneByAgentIDAndUserID(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+		if agentSecret == nil {
+			return ErrAgentSecretNotFound
+		}
+
+		return u.agentSecretRepository.Delete(ctx, agentSecret)
+	})
+}
+
+func (u *agentUsecase) GetSecret(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.AgentSecretDTO, error) {
+	agentSecret, err := u.agentSecretRepository.FindOneByAgentIDAndUserID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if agentSecret == nil {
+		return nil, nil
+	}
+
+	return mapper.ToAgentSecretDTO(agentSecret), nil
+}
+
+func (u *agentUsecase) CreatePublicKey(ctx context.Context, id uuid.UUID, userID uuid.UUID, publicKey string) (*dto.AgentPublicKeyDTO, error) {
+	var agentPublicKey *entity.AgentPublicKey
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		agent, err := u.agentRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+		if agent == nil {
+			return ErrAgentNotFound
+		}
+
+		agentPublicKey, err = entity.NewAgentPublicKey(agent.ID, publicKey)
+		if err != nil {
+			return err
+		}
+
+		return u.agentPublicKeyRepository.Create(ctx, agentPublicKey)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToAgentPublicKeyDTO(agentPublicKey), nil
+}
+
+func (u *agentUsecase) DeletePublicKey(ctx context.Context, id uuid.UUID, userID uuid.UUID, keyID uuid.UUID) error {
+	return u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		agentPublicKey, err := u.agentPublicKeyRepository.FindOneByIDAndAgentIDAndUserID(ctx, keyID, id, userID)
+		if err != nil {
+			return err
+		}
+		if agentPublicKey == nil {
+			return ErrAgentPublicKeyNotFound
+		}
+
+		return u.agentPublicKeyRepository.Delete(ctx, agentPublicKey)
+	})
+}
+
+func (u *agentUsecase) GetPublicKeys(ctx context.Context, id uuid.UUID, userID uuid.UUID) ([]*dto.AgentPublicKeyDTO, error) {
+	agentPublicKeys, err := u.agentPublicKeyRepository.FindByAgentIDAndUserID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToAgentPublicKeyDTOs(agentPublicKeys), nil
+}
+
+// エージェントのトークンを使わずに, リクエストに対する認可の判定と各ポリシーの評価結果を返す.
+func (u *agentUsecase) Simulate(ctx context.Context, id uuid.UUID, userID uuid.UUID, service string, path string, method string, sourceIP string, attributes map[string]string) (*dto.AuthorizationDecisionDTO, error) {
+	var decision *entity.AuthorizationDecision
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		agent, err := u.agentRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+		if agent == nil {
+			return ErrAgentNotFound
+		}
+
+		decision, err = u.agentService.Evaluate(ctx, agent, entity.NewAuthorizationRequest(service, path, method, sourceIP, attributes))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToAuthorizationDecisionDTO(decision), nil
+}
@@ -2,7 +2,11 @@ package usecase_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
 	"database/sql"
+	"encoding/pem"
 	"errors"
 	"holos-auth-api/internal/app/api/domain/entity"
 	"holos-auth-api/internal/app/api/usecase"
@@ -10,7 +14,9 @@ import (
 	mockDomain "holos-auth-api/test/mock/domain"
 	mockRepository "holos-auth-api/test/mock/domain/repository"
 	mockService "holos-auth-api/test/mock/domain/service"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
@@ -78,7 +84,7 @@ func TestAgent_Create(t *testing.T) {
 
 			tt.setMockAgentRepository(ctx, ar)
 
-			au := usecase.NewAgentUsecase(nil, ar, nil, nil, nil)
+			au := usecase.NewAgentUsecase(nil, ar, nil, nil, nil, nil, nil, nil, nil)
 			result, err := au.Create(ctx, tt.inputUserID, tt.inputName)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -127,7 +133,7 @@ func TestAgent_Update(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
-					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt), nil).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
 					Times(1)
 				ar.EXPECT().
 					Update(ctx, gomock.Any()).
@@ -153,7 +159,7 @@ func TestAgent_Update(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
-					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt), nil).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
 					Times(1)
 			},
 		},
@@ -219,7 +225,7 @@ func TestAgent_Update(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
-					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt), nil).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
 					Times(1)
 				ar.EXPECT().
 					Update(ctx, gomock.Any()).
@@ -241,7 +247,7 @@ func TestAgent_Update(t *testing.T) {
 			tt.setMockTransactionObject(ctx, to)
 			tt.setMockAgentRepository(ctx, ar)
 
-			au := usecase.NewAgentUsecase(to, ar, nil, nil, nil)
+			au := usecase.NewAgentUsecase(to, ar, nil, nil, nil, nil, nil, nil, nil)
 			result, err := au.Update(ctx, tt.inputID, tt.inputUserID, tt.inputName)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -286,7 +292,7 @@ func TestAgent_Delete(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
-					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt), nil).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
 					Times(1)
 				ar.EXPECT().
 					Delete(ctx, gomock.Any()).
@@ -350,7 +356,7 @@ func TestAgent_Delete(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
-					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt), nil).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
 					Times(1)
 				ar.EXPECT().
 					Delete(ctx, gomock.Any()).
@@ -372,7 +378,7 @@ func TestAgent_Delete(t *testing.T) {
 			tt.setMockTransactionObject(ctx, to)
 			tt.setMockAgentRepository(ctx, ar)
 
-			au := usecase.NewAgentUsecase(to, ar, nil, nil, nil)
+			au := usecase.NewAgentUsecase(to, ar, nil, nil, nil, nil, nil, nil, nil)
 			if err := au.Delete(ctx, tt.inputID, tt.inputUserID); !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -403,7 +409,7 @@ func TestAgent_Get(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
-					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt), nil).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
 					Times(1)
 			},
 		},
@@ -445,7 +451,7 @@ func TestAgent_Get(t *testing.T) {
 
 			tt.setMockAgentRepository(ctx, ar)
 
-			au := usecase.NewAgentUsecase(nil, ar, nil, nil, nil)
+			au := usecase.NewAgentUsecase(nil, ar, nil, nil, nil, nil, nil, nil, nil)
 			result, err := au.Get(ctx, tt.inputID, tt.inputUserID)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -483,7 +489,7 @@ func TestAgent_Gets(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindByNamePrefixAndUserIDAndNotDeleted(ctx, gomock.Any(), agent.UserID).
-					Return([]*entity.Agent{entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt)}, nil).
+					Return([]*entity.Agent{entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt)}, nil).
 					Times(1)
 			},
 		},
@@ -525,7 +531,7 @@ func TestAgent_Gets(t *testing.T) {
 
 			tt.setMockAgentRepository(ctx, ar)
 
-			au := usecase.NewAgentUsecase(nil, ar, nil, nil, nil)
+			au := usecase.NewAgentUsecase(nil, ar, nil, nil, nil, nil, nil, nil, nil)
 			result, err := au.Gets(ctx, tt.inputKeyword, tt.inputUserID)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -576,7 +582,7 @@ func TestAgent_UpdatePolicies(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
-					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt), nil).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
 					Times(1)
 				ar.EXPECT().
 					Update(ctx, gomock.Any()).
@@ -586,7 +592,7 @@ func TestAgent_UpdatePolicies(t *testing.T) {
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
 					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
-					Return([]*entity.Policy{entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt)}, nil).
+					Return([]*entity.Policy{entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt)}, nil).
 					Times(1)
 			},
 		},
@@ -654,7 +660,7 @@ func TestAgent_UpdatePolicies(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
-					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt), nil).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
 					Times(1)
 			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
@@ -682,7 +688,7 @@ func TestAgent_UpdatePolicies(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
-					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt), nil).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
 					Times(1)
 				ar.EXPECT().
 					Update(ctx, gomock.Any()).
@@ -692,7 +698,7 @@ func TestAgent_UpdatePolicies(t *testing.T) {
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
 					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
-					Return([]*entity.Policy{entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt)}, nil).
+					Return([]*entity.Policy{entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt)}, nil).
 					Times(1)
 			},
 		},
@@ -712,7 +718,7 @@ func TestAgent_UpdatePolicies(t *testing.T) {
 			tt.setMockAgentRepository(ctx, ar)
 			tt.setMockPolicyRepository(ctx, pr)
 
-			au := usecase.NewAgentUsecase(to, ar, nil, pr, nil)
+			au := usecase.NewAgentUsecase(to, ar, nil, nil, nil, nil, nil, pr, nil)
 			result, err := au.UpdatePolicies(ctx, tt.inputID, tt.inputUserID, tt.inputPolicyIDs)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -763,13 +769,13 @@ func TestAgent_GetPolicies(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
-					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt), nil).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
 					Times(1)
 			},
 			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
 				as.EXPECT().
 					GetPolicies(ctx, gomock.Any(), gomock.Any()).
-					Return([]*entity.Policy{entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt)}, nil).
+					Return([]*entity.Policy{entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt)}, nil).
 					Times(1)
 			},
 		},
@@ -814,7 +820,7 @@ func TestAgent_GetPolicies(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
-					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt), nil).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
 					Times(1)
 			},
 			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
@@ -865,7 +871,7 @@ func TestAgent_GetPolicies(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
-					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt), nil).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
 					Times(1)
 			},
 			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
@@ -891,7 +897,7 @@ func TestAgent_GetPolicies(t *testing.T) {
 			tt.setMockAgentRepository(ctx, ar)
 			tt.setMockAgentService(ctx, as)
 
-			au := usecase.NewAgentUsecase(to, ar, nil, nil, as)
+			au := usecase.NewAgentUsecase(to, ar, nil, nil, nil, nil, nil, nil, as)
 			result, err := au.GetPolicies(ctx, tt.inputID, tt.inputUserID, tt.inputKeyword)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -903,6 +909,131 @@ func TestAgent_GetPolicies(t *testing.T) {
 	}
 }
 
+func TestAgent_GetEffectivePolicies(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(agent.UserID, "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	group, err := entity.NewPolicyBundle(entity.PolicyBundleKindAgentGroup, agent.UserID, "group_name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                     string
+		expectResult             []*dto.EffectivePolicyDTO
+		expectError              error
+		setMockTransactionObject func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentRepository   func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentService      func(context.Context, *mockService.MockAgentService)
+	}{
+		{
+			name: "success",
+			expectResult: []*dto.EffectivePolicyDTO{
+				{
+					Policy:  &dto.PolicyDTO{ID: policy.ID, UserID: policy.UserID, Name: policy.Name, Effect: policy.Effect, Service: policy.Service, Path: policy.Path, Methods: policy.Methods, Agents: []uuid.UUID{}, CreatedAt: policy.CreatedAt, UpdatedAt: policy.UpdatedAt},
+					Sources: []*dto.PolicySourceDTO{{Type: entity.PolicySourceTypeGroup, ID: group.ID, Name: group.Name}},
+				},
+			},
+			expectError: nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					GetEffectivePolicies(ctx, gomock.Any()).
+					Return([]*entity.EffectivePolicy{{Policy: policy, Sources: []*entity.PolicySource{{Type: entity.PolicySourceTypeGroup, ID: group.ID, Name: group.Name}}}}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "agent not found",
+			expectResult: nil,
+			expectError:  usecase.ErrAgentNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:         "get effective policies error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					GetEffectivePolicies(ctx, gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			as := mockService.NewMockAgentService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentService(ctx, as)
+
+			au := usecase.NewAgentUsecase(to, ar, nil, nil, nil, nil, nil, nil, as)
+			result, err := au.GetEffectivePolicies(ctx, agent.ID, agent.UserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
 func TestAgent_GenerateToken(t *testing.T) {
 	agent, err := entity.NewAgent(uuid.New(), "name")
 	if err != nil {
@@ -934,7 +1065,7 @@ func TestAgent_GenerateToken(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
-					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt), nil).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
 					Times(1)
 			},
 			setMockAgentTokenRepository: func(ctx context.Context, pr *mockRepository.MockAgentTokenRepository) {
@@ -1002,7 +1133,7 @@ func TestAgent_GenerateToken(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
-					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt), nil).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
 					Times(1)
 			},
 			setMockAgentTokenRepository: func(ctx context.Context, pr *mockRepository.MockAgentTokenRepository) {
@@ -1028,7 +1159,7 @@ func TestAgent_GenerateToken(t *testing.T) {
 			tt.setMockAgentRepository(ctx, ar)
 			tt.setMockAgentTokenRepository(ctx, atr)
 
-			au := usecase.NewAgentUsecase(to, ar, atr, nil, nil)
+			au := usecase.NewAgentUsecase(to, ar, atr, nil, nil, nil, nil, nil, nil)
 			_, err := au.GenerateToken(ctx, tt.inputID, tt.inputUserID)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -1157,7 +1288,7 @@ func TestAgent_DeleteToken(t *testing.T) {
 			tt.setMockTransactionObject(ctx, to)
 			tt.setMockAgentTokenRepository(ctx, atr)
 
-			au := usecase.NewAgentUsecase(to, nil, atr, nil, nil)
+			au := usecase.NewAgentUsecase(to, nil, atr, nil, nil, nil, nil, nil, nil)
 			if err := au.DeleteToken(ctx, tt.inputID, tt.inputUserID); !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -1234,7 +1365,7 @@ func TestAgent_GetToken(t *testing.T) {
 
 			tt.setMockAgentTokenRepository(ctx, atr)
 
-			au := usecase.NewAgentUsecase(nil, nil, atr, nil, nil)
+			au := usecase.NewAgentUsecase(nil, nil, atr, nil, nil, nil, nil, nil, nil)
 			result, err := au.GetToken(ctx, tt.inputID, tt.inputUserID)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -1245,3 +1376,1562 @@ func TestAgent_GetToken(t *testing.T) {
 		})
 	}
 }
+
+func TestAgent_BindCertificate(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	fingerprint := strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name                              string
+		inputID                           uuid.UUID
+		inputUserID                       uuid.UUID
+		inputFingerprint                  string
+		inputSubject                      string
+		expectError                       error
+		setMockTransactionObject          func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentRepository            func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentCertificateRepository func(context.Context, *mockRepository.MockAgentCertificateRepository)
+	}{
+		{
+			name:             "success",
+			inputID:          agent.ID,
+			inputUserID:      agent.UserID,
+			inputFingerprint: fingerprint,
+			inputSubject:     "CN=agent",
+			expectError:      nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentCertificateRepository: func(ctx context.Context, acr *mockRepository.MockAgentCertificateRepository) {
+				acr.EXPECT().
+					FindOneByFingerprintOrSubjectForUpdate(ctx, fingerprint, "CN=agent").
+					Return(nil, nil).
+					Times(1)
+				acr.EXPECT().
+					Save(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:             "agent not found",
+			inputID:          agent.ID,
+			inputUserID:      agent.UserID,
+			inputFingerprint: fingerprint,
+			inputSubject:     "CN=agent",
+			expectError:      usecase.ErrAgentNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentCertificateRepository: func(ctx context.Context, acr *mockRepository.MockAgentCertificateRepository) {},
+		},
+		{
+			name:             "find agent error",
+			inputID:          agent.ID,
+			inputUserID:      agent.UserID,
+			inputFingerprint: fingerprint,
+			inputSubject:     "CN=agent",
+			expectError:      sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentCertificateRepository: func(ctx context.Context, acr *mockRepository.MockAgentCertificateRepository) {},
+		},
+		{
+			name:             "save agent certificate error",
+			inputID:          agent.ID,
+			inputUserID:      agent.UserID,
+			inputFingerprint: fingerprint,
+			inputSubject:     "CN=agent",
+			expectError:      sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentCertificateRepository: func(ctx context.Context, acr *mockRepository.MockAgentCertificateRepository) {
+				acr.EXPECT().
+					FindOneByFingerprintOrSubjectForUpdate(ctx, fingerprint, "CN=agent").
+					Return(nil, nil).
+					Times(1)
+				acr.EXPECT().
+					Save(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:             "bound to another agent",
+			inputID:          agent.ID,
+			inputUserID:      agent.UserID,
+			inputFingerprint: fingerprint,
+			inputSubject:     "CN=agent",
+			expectError:      usecase.ErrAgentCertificateAlreadyBound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentCertificateRepository: func(ctx context.Context, acr *mockRepository.MockAgentCertificateRepository) {
+				acr.EXPECT().
+					FindOneByFingerprintOrSubjectForUpdate(ctx, fingerprint, "CN=agent").
+					Return(entity.RestoreAgentCertificate(uuid.New(), fingerprint, "", time.Now()), nil).
+					Times(1)
+			},
+		},
+		{
+			name:             "rebind own certificate",
+			inputID:          agent.ID,
+			inputUserID:      agent.UserID,
+			inputFingerprint: fingerprint,
+			inputSubject:     "CN=agent",
+			expectError:      nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentCertificateRepository: func(ctx context.Context, acr *mockRepository.MockAgentCertificateRepository) {
+				acr.EXPECT().
+					FindOneByFingerprintOrSubjectForUpdate(ctx, fingerprint, "CN=agent").
+					Return(entity.RestoreAgentCertificate(agent.ID, fingerprint, "", time.Now()), nil).
+					Times(1)
+				acr.EXPECT().
+					Save(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:             "invalid certificate",
+			inputID:          agent.ID,
+			inputUserID:      agent.UserID,
+			inputFingerprint: "fingerprint",
+			inputSubject:     "",
+			expectError:      entity.ErrInvalidAgentCertificateFingerprint,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentCertificateRepository: func(ctx context.Context, acr *mockRepository.MockAgentCertificateRepository) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			acr := mockRepository.NewMockAgentCertificateRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentCertificateRepository(ctx, acr)
+
+			au := usecase.NewAgentUsecase(to, ar, nil, acr, nil, nil, nil, nil, nil)
+			_, err := au.BindCertificate(ctx, tt.inputID, tt.inputUserID, tt.inputFingerprint, tt.inputSubject)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestAgent_UnbindCertificate(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentCertificate, err := entity.NewAgentCertificate(agent.ID, "", "CN=agent")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                              string
+		inputID                           uuid.UUID
+		inputUserID                       uuid.UUID
+		expectError                       error
+		setMockTransactionObject          func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentCertificateRepository func(context.Context, *mockRepository.MockAgentCertificateRepository)
+	}{
+		{
+			name:        "success",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			expectError: nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentCertificateRepository: func(ctx context.Context, acr *mockRepository.MockAgentCertificateRepository) {
+				acr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(agentCertificate, nil).
+					Times(1)
+				acr.EXPECT().
+					Delete(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "agent certificate not found",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			expectError: usecase.ErrAgentCertificateNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentCertificateRepository: func(ctx context.Context, acr *mockRepository.MockAgentCertificateRepository) {
+				acr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "find agent certificate error",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			expectError: sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentCertificateRepository: func(ctx context.Context, acr *mockRepository.MockAgentCertificateRepository) {
+				acr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:        "delete agent certificate error",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			expectError: sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentCertificateRepository: func(ctx context.Context, acr *mockRepository.MockAgentCertificateRepository) {
+				acr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(agentCertificate, nil).
+					Times(1)
+				acr.EXPECT().
+					Delete(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			acr := mockRepository.NewMockAgentCertificateRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentCertificateRepository(ctx, acr)
+
+			au := usecase.NewAgentUsecase(to, nil, nil, acr, nil, nil, nil, nil, nil)
+			if err := au.UnbindCertificate(ctx, tt.inputID, tt.inputUserID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestAgent_GetCertificate(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentCertificate, err := entity.NewAgentCertificate(agent.ID, "", "CN=agent")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                              string
+		inputID                           uuid.UUID
+		inputUserID                       uuid.UUID
+		expectResult                      *dto.AgentCertificateDTO
+		expectError                       error
+		setMockAgentCertificateRepository func(context.Context, *mockRepository.MockAgentCertificateRepository)
+	}{
+		{
+			name:         "success",
+			inputID:      agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: &dto.AgentCertificateDTO{AgentID: agentCertificate.AgentID, Fingerprint: agentCertificate.Fingerprint, Subject: agentCertificate.Subject, BoundAt: agentCertificate.BoundAt},
+			expectError:  nil,
+			setMockAgentCertificateRepository: func(ctx context.Context, acr *mockRepository.MockAgentCertificateRepository) {
+				acr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(agentCertificate, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "agent certificate not found",
+			inputID:      agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  nil,
+			setMockAgentCertificateRepository: func(ctx context.Context, acr *mockRepository.MockAgentCertificateRepository) {
+				acr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "find agent certificate error",
+			inputID:      agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockAgentCertificateRepository: func(ctx context.Context, acr *mockRepository.MockAgentCertificateRepository) {
+				acr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			acr := mockRepository.NewMockAgentCertificateRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockAgentCertificateRepository(ctx, acr)
+
+			au := usecase.NewAgentUsecase(nil, nil, nil, acr, nil, nil, nil, nil, nil)
+			result, err := au.GetCertificate(ctx, tt.inputID, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAgent_UpdateAttributes(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                             string
+		inputID                          uuid.UUID
+		inputUserID                      uuid.UUID
+		inputAttributes                  map[string]string
+		expectError                      error
+		setMockTransactionObject         func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentRepository           func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentAttributesRepository func(context.Context, *mockRepository.MockAgentAttributesRepository)
+	}{
+		{
+			name:            "success",
+			inputID:         agent.ID,
+			inputUserID:     agent.UserID,
+			inputAttributes: map[string]string{"team": "t1"},
+			expectError:     nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {
+				atr.EXPECT().
+					Save(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "agent not found",
+			inputID:         agent.ID,
+			inputUserID:     agent.UserID,
+			inputAttributes: map[string]string{"team": "t1"},
+			expectError:     usecase.ErrAgentNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {},
+		},
+		{
+			name:            "find agent error",
+			inputID:         agent.ID,
+			inputUserID:     agent.UserID,
+			inputAttributes: map[string]string{"team": "t1"},
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {},
+		},
+		{
+			name:            "save agent attributes error",
+			inputID:         agent.ID,
+			inputUserID:     agent.UserID,
+			inputAttributes: map[string]string{"team": "t1"},
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {
+				atr.EXPECT().
+					Save(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:            "invalid attributes",
+			inputID:         agent.ID,
+			inputUserID:     agent.UserID,
+			inputAttributes: map[string]string{"Team": "t1"},
+			expectError:     entity.ErrInvalidAgentAttributeKey,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			atr := mockRepository.NewMockAgentAttributesRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentAttributesRepository(ctx, atr)
+
+			au := usecase.NewAgentUsecase(to, ar, nil, nil, nil, nil, atr, nil, nil)
+			_, err := au.UpdateAttributes(ctx, tt.inputID, tt.inputUserID, tt.inputAttributes)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestAgent_GetAttributes(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentAttributes, err := entity.NewAgentAttributes(agent.ID, map[string]string{"team": "t1"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                             string
+		inputID                          uuid.UUID
+		inputUserID                      uuid.UUID
+		expectResult                     *dto.AgentAttributesDTO
+		expectError                      error
+		setMockAgentRepository           func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentAttributesRepository func(context.Context, *mockRepository.MockAgentAttributesRepository)
+	}{
+		{
+			name:         "found",
+			inputID:      agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: &dto.AgentAttributesDTO{AgentID: agent.ID, Values: map[string]string{"team": "t1"}},
+			expectError:  nil,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {
+				atr.EXPECT().
+					FindOneByAgentID(ctx, agent.ID).
+					Return(agentAttributes, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "not set",
+			inputID:      agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: &dto.AgentAttributesDTO{AgentID: agent.ID, Values: map[string]string{}},
+			expectError:  nil,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {
+				atr.EXPECT().
+					FindOneByAgentID(ctx, agent.ID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "agent not found",
+			inputID:      agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  usecase.ErrAgentNotFound,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {},
+		},
+		{
+			name:         "find agent attributes error",
+			inputID:      agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentAttributesRepository: func(ctx context.Context, atr *mockRepository.MockAgentAttributesRepository) {
+				atr.EXPECT().
+					FindOneByAgentID(ctx, agent.ID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			atr := mockRepository.NewMockAgentAttributesRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentAttributesRepository(ctx, atr)
+
+			au := usecase.NewAgentUsecase(nil, ar, nil, nil, nil, nil, atr, nil, nil)
+			result, err := au.GetAttributes(ctx, tt.inputID, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAgent_GenerateSecret(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                         string
+		inputID                      uuid.UUID
+		inputUserID                  uuid.UUID
+		expectError                  error
+		setMockTransactionObject     func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentRepository       func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentSecretRepository func(context.Context, *mockRepository.MockAgentSecretRepository)
+	}{
+		{
+			name:        "success",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			expectError: nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					Save(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "agent not found",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			expectError: usecase.ErrAgentNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {},
+		},
+		{
+			name:        "find agent error",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			expectError: sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {},
+		},
+		{
+			name:        "save agent secret error",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			expectError: sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					Save(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			asr := mockRepository.NewMockAgentSecretRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentSecretRepository(ctx, asr)
+
+			au := usecase.NewAgentUsecase(to, ar, nil, nil, asr, nil, nil, nil, nil)
+			_, err := au.GenerateSecret(ctx, tt.inputID, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestAgent_DeleteSecret(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentSecret, err := entity.NewAgentSecret(agent.ID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                         string
+		inputID                      uuid.UUID
+		inputUserID                  uuid.UUID
+		expectError                  error
+		setMockTransactionObject     func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentSecretRepository func(context.Context, *mockRepository.MockAgentSecretRepository)
+	}{
+		{
+			name:        "success",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			expectError: nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(agentSecret, nil).
+					Times(1)
+				asr.EXPECT().
+					Delete(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "agent secret not found",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			expectError: usecase.ErrAgentSecretNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "find agent secret error",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			expectError: sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:        "delete agent secret error",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			expectError: sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(agentSecret, nil).
+					Times(1)
+				asr.EXPECT().
+					Delete(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			asr := mockRepository.NewMockAgentSecretRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentSecretRepository(ctx, asr)
+
+			au := usecase.NewAgentUsecase(to, nil, nil, nil, asr, nil, nil, nil, nil)
+			if err := au.DeleteSecret(ctx, tt.inputID, tt.inputUserID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestAgent_GetSecret(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentSecret, err := entity.NewAgentSecret(agent.ID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                         string
+		inputID                      uuid.UUID
+		inputUserID                  uuid.UUID
+		expectResult                 *dto.AgentSecretDTO
+		expectError                  error
+		setMockAgentSecretRepository func(context.Context, *mockRepository.MockAgentSecretRepository)
+	}{
+		{
+			name:         "success",
+			inputID:      agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: &dto.AgentSecretDTO{AgentID: agentSecret.AgentID, KeyID: agentSecret.KeyID, Secret: agentSecret.Secret, GeneratedAt: agentSecret.GeneratedAt},
+			expectError:  nil,
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(agentSecret, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "agent secret not found",
+			inputID:      agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  nil,
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "find agent secret error",
+			inputID:      agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			asr := mockRepository.NewMockAgentSecretRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockAgentSecretRepository(ctx, asr)
+
+			au := usecase.NewAgentUsecase(nil, nil, nil, nil, asr, nil, nil, nil, nil)
+			result, err := au.GetSecret(ctx, tt.inputID, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAgent_CreatePublicKey(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	key, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	tests := []struct {
+		name                            string
+		inputID                         uuid.UUID
+		inputUserID                     uuid.UUID
+		inputPublicKey                  string
+		expectError                     error
+		setMockTransactionObject        func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentRepository          func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentPublicKeyRepository func(context.Context, *mockRepository.MockAgentPublicKeyRepository)
+	}{
+		{
+			name:           "success",
+			inputID:        agent.ID,
+			inputUserID:    agent.UserID,
+			inputPublicKey: publicKey,
+			expectError:    nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "invalid public key",
+			inputID:        agent.ID,
+			inputUserID:    agent.UserID,
+			inputPublicKey: "public_key",
+			expectError:    entity.ErrInvalidAgentPublicKey,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {},
+		},
+		{
+			name:           "agent not found",
+			inputID:        agent.ID,
+			inputUserID:    agent.UserID,
+			inputPublicKey: publicKey,
+			expectError:    usecase.ErrAgentNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {},
+		},
+		{
+			name:           "find agent error",
+			inputID:        agent.ID,
+			inputUserID:    agent.UserID,
+			inputPublicKey: publicKey,
+			expectError:    sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {},
+		},
+		{
+			name:           "create agent public key error",
+			inputID:        agent.ID,
+			inputUserID:    agent.UserID,
+			inputPublicKey: publicKey,
+			expectError:    sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			apkr := mockRepository.NewMockAgentPublicKeyRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentPublicKeyRepository(ctx, apkr)
+
+			au := usecase.NewAgentUsecase(to, ar, nil, nil, nil, apkr, nil, nil, nil)
+			_, err := au.CreatePublicKey(ctx, tt.inputID, tt.inputUserID, tt.inputPublicKey)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestAgent_DeletePublicKey(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentPublicKey := entity.RestoreAgentPublicKey(uuid.New(), agent.ID, "EdDSA", "public_key", time.Now())
+
+	tests := []struct {
+		name                            string
+		inputID                         uuid.UUID
+		inputUserID                     uuid.UUID
+		inputKeyID                      uuid.UUID
+		expectError                     error
+		setMockTransactionObject        func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentPublicKeyRepository func(context.Context, *mockRepository.MockAgentPublicKeyRepository)
+	}{
+		{
+			name:        "success",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			inputKeyID:  agentPublicKey.ID,
+			expectError: nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					FindOneByIDAndAgentIDAndUserID(ctx, agentPublicKey.ID, agent.ID, agent.UserID).
+					Return(agentPublicKey, nil).
+					Times(1)
+				apkr.EXPECT().
+					Delete(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "agent public key not found",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			inputKeyID:  agentPublicKey.ID,
+			expectError: usecase.ErrAgentPublicKeyNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					FindOneByIDAndAgentIDAndUserID(ctx, agentPublicKey.ID, agent.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "find agent public key error",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			inputKeyID:  agentPublicKey.ID,
+			expectError: sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					FindOneByIDAndAgentIDAndUserID(ctx, agentPublicKey.ID, agent.ID, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:        "delete agent public key error",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			inputKeyID:  agentPublicKey.ID,
+			expectError: sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					FindOneByIDAndAgentIDAndUserID(ctx, agentPublicKey.ID, agent.ID, agent.UserID).
+					Return(agentPublicKey, nil).
+					Times(1)
+				apkr.EXPECT().
+					Delete(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			apkr := mockRepository.NewMockAgentPublicKeyRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentPublicKeyRepository(ctx, apkr)
+
+			au := usecase.NewAgentUsecase(to, nil, nil, nil, nil, apkr, nil, nil, nil)
+			if err := au.DeletePublicKey(ctx, tt.inputID, tt.inputUserID, tt.inputKeyID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestAgent_GetPublicKeys(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentPublicKey := entity.RestoreAgentPublicKey(uuid.New(), agent.ID, "EdDSA", "public_key", time.Now())
+
+	tests := []struct {
+		name                            string
+		inputID                         uuid.UUID
+		inputUserID                     uuid.UUID
+		expectResult                    []*dto.AgentPublicKeyDTO
+		expectError                     error
+		setMockAgentPublicKeyRepository func(context.Context, *mockRepository.MockAgentPublicKeyRepository)
+	}{
+		{
+			name:         "success",
+			inputID:      agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: []*dto.AgentPublicKeyDTO{{ID: agentPublicKey.ID, AgentID: agentPublicKey.AgentID, Algorithm: agentPublicKey.Algorithm, PublicKey: agentPublicKey.PublicKey, CreatedAt: agentPublicKey.CreatedAt}},
+			expectError:  nil,
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					FindByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return([]*entity.AgentPublicKey{agentPublicKey}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "find agent public keys error",
+			inputID:      agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					FindByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apkr := mockRepository.NewMockAgentPublicKeyRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockAgentPublicKeyRepository(ctx, apkr)
+
+			au := usecase.NewAgentUsecase(nil, nil, nil, nil, nil, apkr, nil, nil, nil)
+			result, err := au.GetPublicKeys(ctx, tt.inputID, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAgent_Simulate(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(agent.UserID, "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyDTO := &dto.PolicyDTO{ID: policy.ID, UserID: policy.UserID, Name: policy.Name, Effect: policy.Effect, Service: policy.Service, Path: policy.Path, Methods: policy.Methods, Agents: []uuid.UUID{}, CreatedAt: policy.CreatedAt, UpdatedAt: policy.UpdatedAt}
+
+	tests := []struct {
+		name                     string
+		inputID                  uuid.UUID
+		inputUserID              uuid.UUID
+		expectResult             *dto.AuthorizationDecisionDTO
+		expectError              error
+		setMockTransactionObject func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentRepository   func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentService      func(context.Context, *mockService.MockAgentService)
+	}{
+		{
+			name:        "success",
+			inputID:     agent.ID,
+			inputUserID: agent.UserID,
+			expectResult: &dto.AuthorizationDecisionDTO{
+				Allowed:        true,
+				DecidingPolicy: policyDTO,
+				Evaluations:    []*dto.PolicyEvaluationDTO{{Policy: policyDTO, Matched: true, Result: entity.PolicyMatchResultMatched}},
+			},
+			expectError: nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Evaluate(ctx, gomock.Any(), gomock.Any()).
+					Return(entity.NewAuthorizationDecision([]*entity.PolicyEvaluation{{Policy: policy, Matched: true, Result: entity.PolicyMatchResultMatched}}), nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "agent not found",
+			inputID:      agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  usecase.ErrAgentNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:         "evaluate error",
+			inputID:      agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Evaluate(ctx, gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			as := mockService.NewMockAgentService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentService(ctx, as)
+
+			au := usecase.NewAgentUsecase(to, ar, nil, nil, nil, nil, nil, nil, as)
+			result, err := au.Simulate(ctx, tt.inputID, tt.inputUserID, "STORAGE", "/", "GET", "", nil)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -3,12 +3,16 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"holos-auth-api/internal/app/api/domain"
 	"holos-auth-api/internal/app/api/domain/entity"
 	"holos-auth-api/internal/app/api/domain/repository"
 	"holos-auth-api/internal/app/api/domain/service"
 	"holos-auth-api/internal/app/api/pkg/status"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	"holos-auth-api/internal/app/api/usecase/mapper"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -16,21 +20,35 @@ import (
 var (
 	ErrAuthenticationFailed = status.Error(http.StatusUnauthorized, "authentication failed")
 	ErrAuthorizationFaild   = status.Error(http.StatusForbidden, "authorization failed")
+
+	ErrReauthenticationRequired = status.Error(http.StatusUnauthorized, "reauthentication required")
 )
 
 type AuthUsecase interface {
 	Signin(context.Context, string, string) (string, error)
 	Signout(context.Context, string) error
+	Reauthenticate(context.Context, string, string) error
 	Authenticate(context.Context, string) (uuid.UUID, error)
-	Authorize(context.Context, string, string, string, string, string) (uuid.UUID, error)
+	VerifyRecentAuthentication(context.Context, string) error
+	Authorize(context.Context, string, string, string, string, string, string, map[string]string) (*dto.AuthorizationResultDTO, error)
+	AuthorizeByCertificate(context.Context, string, string, string, string, string, string, map[string]string) (*dto.AuthorizationResultDTO, error)
+	AuthorizeBySignature(context.Context, string, string, string, string, string, string, string, string, string, map[string]string) (*dto.AuthorizationResultDTO, error)
+	AuthorizeByAssertion(context.Context, string, string, string, string, string, map[string]string) (*dto.AuthorizationResultDTO, error)
+	AuthorizeBatch(context.Context, string, string, []*dto.AuthorizationRequestDTO) ([]*dto.AuthorizationResultDTO, error)
+	CreateDelegatedToken(context.Context, string, []uuid.UUID, time.Duration) (*dto.AgentDelegatedTokenDTO, error)
 }
 
 type authUsecase struct {
-	transactionObject   domain.TransactionObject
-	userRepository      repository.UserRepository
-	userTokenRepository repository.UserTokenRepository
-	agentRepository     repository.AgentRepository
-	agentService        service.AgentService
+	transactionObject             domain.TransactionObject
+	userRepository                repository.UserRepository
+	userTokenRepository           repository.UserTokenRepository
+	agentRepository               repository.AgentRepository
+	agentSecretRepository         repository.AgentSecretRepository
+	agentPublicKeyRepository      repository.AgentPublicKeyRepository
+	agentSignatureNonceRepository repository.AgentSignatureNonceRepository
+	agentDelegatedTokenRepository repository.AgentDelegatedTokenRepository
+	agentService                  service.AgentService
+	legacyTokenDeadline           time.Time
 }
 
 func NewAuthUsecase(
@@ -38,14 +56,24 @@ func NewAuthUsecase(
 	userRepository repository.UserRepository,
 	userTokenRepository repository.UserTokenRepository,
 	agentRepository repository.AgentRepository,
+	agentSecretRepository repository.AgentSecretRepository,
+	agentPublicKeyRepository repository.AgentPublicKeyRepository,
+	agentSignatureNonceRepository repository.AgentSignatureNonceRepository,
+	agentDelegatedTokenRepository repository.AgentDelegatedTokenRepository,
 	agentService service.AgentService,
+	legacyTokenDeadline time.Time,
 ) AuthUsecase {
 	return &authUsecase{
-		transactionObject:   transactionObject,
-		userRepository:      userRepository,
-		userTokenRepository: userTokenRepository,
-		agentRepository:     agentRepository,
-		agentService:        agentService,
+		transactionObject:             transactionObject,
+		userRepository:                userRepository,
+		userTokenRepository:           userTokenRepository,
+		agentRepository:               agentRepository,
+		agentSecretRepository:         agentSecretRepository,
+		agentPublicKeyRepository:      agentPublicKeyRepository,
+		agentSignatureNonceRepository: agentSignatureNonceRepository,
+		agentDelegatedTokenRepository: agentDelegatedTokenRepository,
+		agentService:                  agentService,
+		legacyTokenDeadline:           legacyTokenDeadline,
 	}
 }
 
@@ -79,6 +107,10 @@ func (u *authUsecase) Signin(ctx context.Context, userName string, password stri
 }
 
 func (u *authUsecase) Signout(ctx context.Context, token string) error {
+	if err := entity.ValidateUserToken(token, u.legacyTokenDeadline, time.Now()); err != nil {
+		return err
+	}
+
 	return u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
 		userToken, err := u.userTokenRepository.FindOneByTokenAndNotExpired(ctx, token)
 		if err != nil {
@@ -92,7 +124,44 @@ func (u *authUsecase) Signout(ctx context.Context, token string) error {
 	})
 }
 
+// パスワードで本人確認を行い, セッションを直近で認証済みとして記録する.
+func (u *authUsecase) Reauthenticate(ctx context.Context, token string, password string) error {
+	if err := entity.ValidateUserToken(token, u.legacyTokenDeadline, time.Now()); err != nil {
+		return err
+	}
+
+	return u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		userToken, err := u.userTokenRepository.FindOneByTokenAndNotExpired(ctx, token)
+		if err != nil {
+			return err
+		}
+		if userToken == nil {
+			return ErrAuthenticationFailed
+		}
+
+		user, err := u.userRepository.FindOneByIDAndNotDeleted(ctx, userToken.UserID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return ErrAuthenticationFailed
+		}
+
+		if err := user.ComparePassword(password); err != nil {
+			return err
+		}
+
+		userToken.Reauthenticate()
+
+		return u.userTokenRepository.Save(ctx, userToken)
+	})
+}
+
 func (u *authUsecase) Authenticate(ctx context.Context, token string) (uuid.UUID, error) {
+	if err := entity.ValidateUserToken(token, u.legacyTokenDeadline, time.Now()); err != nil {
+		return uuid.Nil, err
+	}
+
 	userToken, err := u.userTokenRepository.FindOneByTokenAndNotExpired(ctx, token)
 	if err != nil {
 		return uuid.Nil, err
@@ -104,39 +173,303 @@ func (u *authUsecase) Authenticate(ctx context.Context, token string) (uuid.UUID
 	return userToken.UserID, nil
 }
 
-func (u *authUsecase) Authorize(ctx context.Context, token string, operatorType string, service string, path string, method string) (uuid.UUID, error) {
+func (u *authUsecase) VerifyRecentAuthentication(ctx context.Context, token string) error {
+	if err := entity.ValidateUserToken(token, u.legacyTokenDeadline, time.Now()); err != nil {
+		return err
+	}
+
+	userToken, err := u.userTokenRepository.FindOneByTokenAndNotExpired(ctx, token)
+	if err != nil {
+		return err
+	}
+	if userToken == nil {
+		return ErrAuthenticationFailed
+	}
+
+	if !userToken.IsRecentlyAuthenticated(time.Now()) {
+		return ErrReauthenticationRequired
+	}
+	return nil
+}
+
+func (u *authUsecase) Authorize(ctx context.Context, token string, operatorType string, service string, path string, method string, sourceIP string, attributes map[string]string) (*dto.AuthorizationResultDTO, error) {
 	switch operatorType {
 	case "USER":
-		return u.Authenticate(ctx, token)
+		userID, err := u.Authenticate(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.AuthorizationResultDTO{
+			OperatorType: operatorType,
+			UserID:       userID,
+			Allowed:      true,
+			CacheTTL:     entity.AuthorizationDecisionCacheTTL,
+		}, nil
 	case "AGENT":
-		var userID uuid.UUID
-		if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
-			agent, err := u.agentRepository.FindOneByTokenAndNotDeleted(ctx, token)
-			if err != nil {
-				return err
+		if entity.IsAgentDelegatedToken(token) {
+			return u.authorizeDelegatedToken(ctx, token, entity.NewAuthorizationRequest(service, path, method, sourceIP, attributes))
+		}
+		if err := entity.ValidateAgentToken(token, u.legacyTokenDeadline, time.Now()); err != nil {
+			return nil, err
+		}
+		return u.authorizeAgent(ctx, func(ctx context.Context) (*entity.Agent, error) {
+			return u.agentRepository.FindOneByTokenAndNotDeleted(ctx, token)
+		}, entity.NewAuthorizationRequest(service, path, method, sourceIP, attributes))
+	default:
+		return nil, ErrAuthenticationFailed
+	}
+}
+
+func (u *authUsecase) AuthorizeByCertificate(ctx context.Context, fingerprint string, subject string, service string, path string, method string, sourceIP string, attributes map[string]string) (*dto.AuthorizationResultDTO, error) {
+	return u.authorizeAgent(ctx, func(ctx context.Context) (*entity.Agent, error) {
+		return u.agentRepository.FindOneByCertificateAndNotDeleted(ctx, fingerprint, subject)
+	}, entity.NewAuthorizationRequest(service, path, method, sourceIP, attributes))
+}
+
+func (u *authUsecase) AuthorizeBySignature(ctx context.Context, keyID string, signature string, timestamp string, nonce string, contentSHA256 string, service string, path string, method string, sourceIP string, attributes map[string]string) (*dto.AuthorizationResultDTO, error) {
+	agentSignature, err := entity.NewAgentSignature(keyID, signature, timestamp, nonce, service, method, path, contentSHA256)
+	if err != nil {
+		return nil, err
+	}
+	if err := agentSignature.Validate(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return u.authorizeAgent(ctx, func(ctx context.Context) (*entity.Agent, error) {
+		agentSecret, err := u.agentSecretRepository.FindOneByKeyID(ctx, agentSignature.KeyID)
+		if err != nil {
+			return nil, err
+		}
+		if agentSecret == nil {
+			return nil, nil
+		}
+		if err := agentSecret.VerifySignature(agentSignature); err != nil {
+			return nil, err
+		}
+
+		// 有効期限内に同じノンスが使われていればリプレイとみなす.
+		if err := u.agentSignatureNonceRepository.DeleteExpiredByAgentID(ctx, agentSecret.AgentID); err != nil {
+			return nil, err
+		}
+		if err := u.agentSignatureNonceRepository.Create(ctx, entity.NewAgentSignatureNonce(agentSecret.AgentID, agentSignature.Nonce, agentSignature.ExpiresAt())); err != nil {
+			if errors.Is(err, entity.ErrAgentSignatureNonceAlreadyUsed) {
+				return nil, nil
 			}
-			if agent == nil {
-				return ErrAuthenticationFailed
+			return nil, err
+		}
+
+		return u.agentRepository.FindOneBySecretKeyIDAndNotDeleted(ctx, agentSignature.KeyID)
+	}, entity.NewAuthorizationRequest(service, path, method, sourceIP, attributes))
+}
+
+func (u *authUsecase) AuthorizeByAssertion(ctx context.Context, assertion string, service string, path string, method string, sourceIP string, attributes map[string]string) (*dto.AuthorizationResultDTO, error) {
+	agentAssertion, err := entity.NewAgentAssertion(assertion)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.authorizeAgent(ctx, func(ctx context.Context) (*entity.Agent, error) {
+		agentPublicKey, err := u.agentPublicKeyRepository.FindOneByID(ctx, agentAssertion.KeyID)
+		if err != nil {
+			return nil, err
+		}
+		if agentPublicKey == nil {
+			return nil, nil
+		}
+		if err := agentAssertion.Verify(agentPublicKey, time.Now()); err != nil {
+			return nil, err
+		}
+
+		// jtiをノンスとして扱い, 有効期限内の再利用をリプレイとみなす.
+		if err := u.agentSignatureNonceRepository.DeleteExpiredByAgentID(ctx, agentPublicKey.AgentID); err != nil {
+			return nil, err
+		}
+		if err := u.agentSignatureNonceRepository.Create(ctx, entity.NewAgentSignatureNonce(agentPublicKey.AgentID, agentAssertion.ID, agentAssertion.ExpiresAt)); err != nil {
+			if errors.Is(err, entity.ErrAgentSignatureNonceAlreadyUsed) {
+				return nil, nil
 			}
+			return nil, err
+		}
+
+		return u.agentRepository.FindOneByPublicKeyIDAndNotDeleted(ctx, agentPublicKey.ID)
+	}, entity.NewAuthorizationRequest(service, path, method, sourceIP, attributes))
+}
+
+// 1つのトークンで複数のリクエストを判定し, リクエストと同じ順に結果を返す. エージェントとポリシーの取得は1回のみ行う.
+// 拒否された判定もエラーとせず, 結果のAllowedで呼び出し元に伝える.
+func (u *authUsecase) AuthorizeBatch(ctx context.Context, token string, operatorType string, requests []*dto.AuthorizationRequestDTO) ([]*dto.AuthorizationResultDTO, error) {
+	authorizationRequests := make([]*entity.AuthorizationRequest, len(requests))
+	for i, request := range requests {
+		authorizationRequests[i] = entity.NewAuthorizationRequest(request.Service, request.Path, request.Method, request.SourceIP, request.Attributes)
+	}
+	if err := entity.ValidateAuthorizationRequests(authorizationRequests); err != nil {
+		return nil, err
+	}
 
-			hasPermission, err := u.agentService.HasPermission(ctx, agent, service, path, method)
-			if err != nil {
-				return err
+	var findAgent func(context.Context) (*entity.Agent, error)
+	switch operatorType {
+	case "USER":
+		userID, err := u.Authenticate(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		results := make([]*dto.AuthorizationResultDTO, len(requests))
+		for i := range results {
+			results[i] = &dto.AuthorizationResultDTO{
+				OperatorType: operatorType,
+				UserID:       userID,
+				Allowed:      true,
+				CacheTTL:     entity.AuthorizationDecisionCacheTTL,
 			}
-			if hasPermission {
-				userID = agent.UserID
+		}
+		return results, nil
+	case "AGENT":
+		if entity.IsAgentDelegatedToken(token) {
+			if err := entity.ValidateAgentDelegatedToken(token); err != nil {
+				return nil, err
 			}
+			findAgent = func(ctx context.Context) (*entity.Agent, error) {
+				return u.findDelegatedAgent(ctx, token)
+			}
+			break
+		}
+		if err := entity.ValidateAgentToken(token, u.legacyTokenDeadline, time.Now()); err != nil {
+			return nil, err
+		}
+		findAgent = func(ctx context.Context) (*entity.Agent, error) {
+			return u.agentRepository.FindOneByTokenAndNotDeleted(ctx, token)
+		}
+	default:
+		return nil, ErrAuthenticationFailed
+	}
 
-			return nil
-		}); err != nil {
-			return uuid.Nil, err
+	var results []*dto.AuthorizationResultDTO
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		agent, err := findAgent(ctx)
+		if err != nil {
+			return err
+		}
+		if agent == nil {
+			return ErrAuthenticationFailed
 		}
 
-		if userID != uuid.Nil {
-			return userID, nil
+		decisions, err := u.agentService.EvaluateBatch(ctx, agent, authorizationRequests)
+		if err != nil {
+			return err
 		}
-		return uuid.Nil, ErrAuthorizationFaild
-	default:
-		return uuid.Nil, ErrAuthenticationFailed
+		results = make([]*dto.AuthorizationResultDTO, len(decisions))
+		for i, decision := range decisions {
+			results[i] = mapper.ToAgentAuthorizationResultDTO(agent, decision)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// エージェントトークンを親として, 権限を絞った短命の委任トークンを発行する.
+func (u *authUsecase) CreateDelegatedToken(ctx context.Context, token string, policyIDs []uuid.UUID, ttl time.Duration) (*dto.AgentDelegatedTokenDTO, error) {
+	if err := entity.ValidateAgentToken(token, u.legacyTokenDeadline, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var agentDelegatedToken *entity.AgentDelegatedToken
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		agent, err := u.agentRepository.FindOneByTokenAndNotDeleted(ctx, token)
+		if err != nil {
+			return err
+		}
+		if agent == nil {
+			return ErrAuthenticationFailed
+		}
+
+		// グループとロールのポリシーも委任できるよう, 展開したエージェントを親とする.
+		agent, err = u.agentService.Expand(ctx, agent)
+		if err != nil {
+			return err
+		}
+
+		agentDelegatedToken, err = entity.NewAgentDelegatedToken(agent, token, policyIDs, ttl)
+		if err != nil {
+			return err
+		}
+
+		if err := u.agentDelegatedTokenRepository.DeleteExpiredByAgentID(ctx, agent.ID); err != nil {
+			return err
+		}
+		return u.agentDelegatedTokenRepository.Create(ctx, agentDelegatedToken)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToAgentDelegatedTokenDTO(agentDelegatedToken), nil
+}
+
+// 委任トークンは親トークンが有効な間のみ, 発行時に指定したポリシーの範囲でエージェントとして認可する.
+func (u *authUsecase) authorizeDelegatedToken(ctx context.Context, token string, request *entity.AuthorizationRequest) (*dto.AuthorizationResultDTO, error) {
+	if err := entity.ValidateAgentDelegatedToken(token); err != nil {
+		return nil, err
+	}
+
+	return u.authorizeAgent(ctx, func(ctx context.Context) (*entity.Agent, error) {
+		return u.findDelegatedAgent(ctx, token)
+	}, request)
+}
+
+// 委任トークンの親トークンでエージェントを取得し, 委任されたポリシーに絞り込む.
+func (u *authUsecase) findDelegatedAgent(ctx context.Context, token string) (*entity.Agent, error) {
+	agentDelegatedToken, err := u.agentDelegatedTokenRepository.FindOneByTokenAndNotExpired(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if agentDelegatedToken == nil {
+		return nil, nil
+	}
+
+	agent, err := u.agentRepository.FindOneByTokenAndNotDeleted(ctx, agentDelegatedToken.ParentToken)
+	if err != nil {
+		return nil, err
+	}
+	if agent == nil {
+		return nil, nil
+	}
+
+	agent, err = u.agentService.Expand(ctx, agent)
+	if err != nil {
+		return nil, err
+	}
+
+	return agentDelegatedToken.Scope(agent), nil
+}
+
+// ポリシーで拒否された場合も, 判定の内容を呼び出し元に伝えるため結果とともにエラーを返す.
+func (u *authUsecase) authorizeAgent(ctx context.Context, findAgent func(context.Context) (*entity.Agent, error), request *entity.AuthorizationRequest) (*dto.AuthorizationResultDTO, error) {
+	var result *dto.AuthorizationResultDTO
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		agent, err := findAgent(ctx)
+		if err != nil {
+			return err
+		}
+		if agent == nil {
+			return ErrAuthenticationFailed
+		}
+
+		decision, err := u.agentService.Evaluate(ctx, agent, request)
+		if err != nil {
+			return err
+		}
+		result = mapper.ToAgentAuthorizationResultDTO(agent, decision)
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if !result.Allowed {
+		return result, ErrAuthorizationFaild
 	}
+	return result, nil
 }
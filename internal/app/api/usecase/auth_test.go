@@ -2,16 +2,31 @@ package usecase_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
 	"database/sql"
+	"encoding/pem"
 	"errors"
 	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/pkg/pathpattern"
+	"holos-auth-api/internal/app/api/domain/pkg/token"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/domain/service"
+	"holos-auth-api/internal/app/api/infrastructure/memory"
 	"holos-auth-api/internal/app/api/usecase"
+	"holos-auth-api/internal/app/api/usecase/dto"
 	mockDomain "holos-auth-api/test/mock/domain"
 	mockRepository "holos-auth-api/test/mock/domain/repository"
 	mockService "holos-auth-api/test/mock/domain/service"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 )
 
@@ -161,7 +176,7 @@ func TestAuth_Signin(t *testing.T) {
 			tt.setMockUserRepository(ctx, ur)
 			tt.setMockUserTokenRepository(ctx, utr)
 
-			au := usecase.NewAuthUsecase(to, ur, utr, nil, nil)
+			au := usecase.NewAuthUsecase(to, ur, utr, nil, nil, nil, nil, nil, nil, time.Time{})
 			_, err = au.Signin(ctx, tt.inputUserName, tt.inputPassword)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -202,7 +217,7 @@ func TestAuth_Signout(t *testing.T) {
 					Times(1)
 				utr.EXPECT().
 					FindOneByTokenAndNotExpired(ctx, userToken.Token).
-					Return(entity.RestoreUserToken(userToken.UserID, userToken.Token, userToken.ExpiresAt), nil).
+					Return(entity.RestoreUserToken(userToken.UserID, userToken.Token, userToken.AuthenticatedAt, userToken.ExpiresAt), nil).
 					Times(1)
 			},
 		},
@@ -263,10 +278,17 @@ func TestAuth_Signout(t *testing.T) {
 					Times(1)
 				utr.EXPECT().
 					FindOneByTokenAndNotExpired(ctx, userToken.Token).
-					Return(entity.RestoreUserToken(userToken.UserID, userToken.Token, userToken.ExpiresAt), nil).
+					Return(entity.RestoreUserToken(userToken.UserID, userToken.Token, userToken.AuthenticatedAt, userToken.ExpiresAt), nil).
 					Times(1)
 			},
 		},
+		{
+			name:                       "malformed token",
+			inputToken:                 "token",
+			expectError:                token.ErrInvalidToken,
+			setMockTransactionObject:   func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -281,7 +303,7 @@ func TestAuth_Signout(t *testing.T) {
 			tt.setMockTransactionObject(ctx, to)
 			tt.setMockUserTokenRepository(ctx, utr)
 
-			au := usecase.NewAuthUsecase(to, nil, utr, nil, nil)
+			au := usecase.NewAuthUsecase(to, nil, utr, nil, nil, nil, nil, nil, nil, time.Time{})
 			if err := au.Signout(ctx, tt.inputToken); !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -310,7 +332,7 @@ func TestAuth_Authenticate(t *testing.T) {
 			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
 				utr.EXPECT().
 					FindOneByTokenAndNotExpired(ctx, userToken.Token).
-					Return(entity.RestoreUserToken(userToken.UserID, userToken.Token, userToken.ExpiresAt), nil).
+					Return(entity.RestoreUserToken(userToken.UserID, userToken.Token, userToken.AuthenticatedAt, userToken.ExpiresAt), nil).
 					Times(1)
 			},
 		},
@@ -338,6 +360,13 @@ func TestAuth_Authenticate(t *testing.T) {
 					Times(1)
 			},
 		},
+		{
+			name:                       "malformed token",
+			inputToken:                 "token",
+			expectResult:               uuid.Nil,
+			expectError:                token.ErrInvalidToken,
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -350,7 +379,7 @@ func TestAuth_Authenticate(t *testing.T) {
 
 			tt.setMockUserTokenRepository(ctx, utr)
 
-			au := usecase.NewAuthUsecase(nil, nil, utr, nil, nil)
+			au := usecase.NewAuthUsecase(nil, nil, utr, nil, nil, nil, nil, nil, nil, time.Time{})
 			result, err := au.Authenticate(ctx, tt.inputToken)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -403,7 +432,7 @@ func TestAuth_Authorize(t *testing.T) {
 			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
 				utr.EXPECT().
 					FindOneByTokenAndNotExpired(ctx, userToken.Token).
-					Return(entity.RestoreUserToken(userToken.UserID, userToken.Token, userToken.ExpiresAt), nil).
+					Return(entity.RestoreUserToken(userToken.UserID, userToken.Token, userToken.AuthenticatedAt, userToken.ExpiresAt), nil).
 					Times(1)
 			},
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
@@ -454,8 +483,8 @@ func TestAuth_Authorize(t *testing.T) {
 			},
 			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
 				as.EXPECT().
-					HasPermission(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(true, nil).
+					Evaluate(ctx, gomock.Any(), gomock.Any()).
+					Return(&entity.AuthorizationDecision{Allowed: true}, nil).
 					Times(1)
 			},
 		},
@@ -485,8 +514,8 @@ func TestAuth_Authorize(t *testing.T) {
 			},
 			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
 				as.EXPECT().
-					HasPermission(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(false, nil).
+					Evaluate(ctx, gomock.Any(), gomock.Any()).
+					Return(&entity.AuthorizationDecision{Allowed: false}, nil).
 					Times(1)
 			},
 		},
@@ -568,8 +597,8 @@ func TestAuth_Authorize(t *testing.T) {
 			},
 			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
 				as.EXPECT().
-					HasPermission(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(false, sql.ErrConnDone).
+					Evaluate(ctx, gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
 		},
@@ -587,6 +616,20 @@ func TestAuth_Authorize(t *testing.T) {
 			setMockAgentRepository:     func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
 			setMockAgentService:        func(ctx context.Context, as *mockService.MockAgentService) {},
 		},
+		{
+			name:                       "malformed agent token",
+			inputToken:                 userToken.Token,
+			inputOperatorType:          "AGENT",
+			inputService:               "STORAGE",
+			inputPath:                  "/",
+			inputMethod:                "GET",
+			expectResult:               uuid.Nil,
+			expectError:                token.ErrInvalidToken,
+			setMockTransactionObject:   func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+			setMockAgentRepository:     func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentService:        func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -605,13 +648,1726 @@ func TestAuth_Authorize(t *testing.T) {
 			tt.setMockAgentRepository(ctx, ar)
 			tt.setMockAgentService(ctx, as)
 
-			au := usecase.NewAuthUsecase(to, nil, utr, ar, as)
-			result, err := au.Authorize(ctx, tt.inputToken, tt.inputOperatorType, tt.inputService, tt.inputPath, tt.inputMethod)
+			au := usecase.NewAuthUsecase(to, nil, utr, ar, nil, nil, nil, nil, as, time.Time{})
+			result, err := au.Authorize(ctx, tt.inputToken, tt.inputOperatorType, tt.inputService, tt.inputPath, tt.inputMethod, "", nil)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
-			if result != tt.expectResult {
-				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			var userID uuid.UUID
+			if err == nil {
+				userID = result.UserID
+			}
+			if userID != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, userID)
+			}
+			if errors.Is(err, usecase.ErrAuthorizationFaild) && result.DenyReason == "" {
+				t.Error("deny reason is required")
+			}
+		})
+	}
+}
+
+func TestAuth_AuthorizeBatch(t *testing.T) {
+	userToken, err := entity.NewUserToken(uuid.New())
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agent, err := entity.NewAgent(userToken.UserID, "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentToken, err := entity.NewAgentToken(agent.ID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	requests := []*dto.AuthorizationRequestDTO{
+		{Service: "STORAGE", Path: "/", Method: "GET"},
+		{Service: "STORAGE", Path: "/", Method: "POST"},
+	}
+	tooManyRequests := make([]*dto.AuthorizationRequestDTO, entity.AuthorizationRequestBatchMaxSize+1)
+	for i := range tooManyRequests {
+		tooManyRequests[i] = &dto.AuthorizationRequestDTO{Service: "STORAGE", Path: "/", Method: "GET"}
+	}
+
+	tests := []struct {
+		name                       string
+		inputToken                 string
+		inputOperatorType          string
+		inputRequests              []*dto.AuthorizationRequestDTO
+		expectAllowed              []bool
+		expectError                error
+		setMockTransactionObject   func(context.Context, *mockDomain.MockTransactionObject)
+		setMockUserTokenRepository func(context.Context, *mockRepository.MockUserTokenRepository)
+		setMockAgentRepository     func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentService        func(context.Context, *mockService.MockAgentService)
+	}{
+		{
+			name:                     "successful authentication of user access",
+			inputToken:               userToken.Token,
+			inputOperatorType:        "USER",
+			inputRequests:            requests,
+			expectAllowed:            []bool{true, true},
+			expectError:              nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
+				utr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, userToken.Token).
+					Return(entity.RestoreUserToken(userToken.UserID, userToken.Token, userToken.AuthenticatedAt, userToken.ExpiresAt), nil).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentService:    func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:              "successful authorization of agent access",
+			inputToken:        agentToken.Token,
+			inputOperatorType: "AGENT",
+			inputRequests:     requests,
+			expectAllowed:     []bool{true, false},
+			expectError:       nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByTokenAndNotDeleted(ctx, agentToken.Token).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					EvaluateBatch(ctx, agent, gomock.Len(2)).
+					Return([]*entity.AuthorizationDecision{{Allowed: true}, {Allowed: false}}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:              "agent not found",
+			inputToken:        agentToken.Token,
+			inputOperatorType: "AGENT",
+			inputRequests:     requests,
+			expectAllowed:     nil,
+			expectError:       usecase.ErrAuthenticationFailed,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByTokenAndNotDeleted(ctx, agentToken.Token).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:              "evaluate error",
+			inputToken:        agentToken.Token,
+			inputOperatorType: "AGENT",
+			inputRequests:     requests,
+			expectAllowed:     nil,
+			expectError:       sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByTokenAndNotDeleted(ctx, agentToken.Token).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					EvaluateBatch(ctx, agent, gomock.Len(2)).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:                       "empty requests",
+			inputToken:                 agentToken.Token,
+			inputOperatorType:          "AGENT",
+			inputRequests:              []*dto.AuthorizationRequestDTO{},
+			expectAllowed:              nil,
+			expectError:                entity.ErrRequiredAuthorizationRequests,
+			setMockTransactionObject:   func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+			setMockAgentRepository:     func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentService:        func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                       "too many requests",
+			inputToken:                 agentToken.Token,
+			inputOperatorType:          "AGENT",
+			inputRequests:              tooManyRequests,
+			expectAllowed:              nil,
+			expectError:                entity.ErrTooManyAuthorizationRequests,
+			setMockTransactionObject:   func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+			setMockAgentRepository:     func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentService:        func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                       "invalid operator type",
+			inputToken:                 userToken.Token,
+			inputOperatorType:          "OPERATOR",
+			inputRequests:              requests,
+			expectAllowed:              nil,
+			expectError:                usecase.ErrAuthenticationFailed,
+			setMockTransactionObject:   func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+			setMockAgentRepository:     func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentService:        func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                       "malformed agent token",
+			inputToken:                 userToken.Token,
+			inputOperatorType:          "AGENT",
+			inputRequests:              requests,
+			expectAllowed:              nil,
+			expectError:                token.ErrInvalidToken,
+			setMockTransactionObject:   func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+			setMockAgentRepository:     func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentService:        func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			utr := mockRepository.NewMockUserTokenRepository(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			as := mockService.NewMockAgentService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockUserTokenRepository(ctx, utr)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentService(ctx, as)
+
+			au := usecase.NewAuthUsecase(to, nil, utr, ar, nil, nil, nil, nil, as, time.Time{})
+			results, err := au.AuthorizeBatch(ctx, tt.inputToken, tt.inputOperatorType, tt.inputRequests)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if tt.expectError != nil {
+				return
+			}
+
+			allowed := make([]bool, len(results))
+			for i, result := range results {
+				allowed[i] = result.Allowed
+				if result.UserID != userToken.UserID {
+					t.Errorf("user_id: expect %v but got %v", userToken.UserID, result.UserID)
+				}
+			}
+			if diff := cmp.Diff(tt.expectAllowed, allowed); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+func TestAuth_AuthorizeByCertificate(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentCertificate, err := entity.NewAgentCertificate(agent.ID, strings.Repeat("ab", 32), "CN=agent")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                     string
+		inputFingerprint         string
+		inputSubject             string
+		inputService             string
+		inputPath                string
+		inputMethod              string
+		expectResult             uuid.UUID
+		expectError              error
+		setMockTransactionObject func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentRepository   func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentService      func(context.Context, *mockService.MockAgentService)
+	}{
+		{
+			name:             "success",
+			inputFingerprint: agentCertificate.Fingerprint,
+			inputSubject:     agentCertificate.Subject,
+			inputService:     "STORAGE",
+			inputPath:        "/",
+			inputMethod:      "GET",
+			expectResult:     agent.UserID,
+			expectError:      nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByCertificateAndNotDeleted(ctx, agentCertificate.Fingerprint, agentCertificate.Subject).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Evaluate(ctx, gomock.Any(), gomock.Any()).
+					Return(&entity.AuthorizationDecision{Allowed: true}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:             "does not have permission",
+			inputFingerprint: agentCertificate.Fingerprint,
+			inputSubject:     agentCertificate.Subject,
+			inputService:     "STORAGE",
+			inputPath:        "/",
+			inputMethod:      "GET",
+			expectResult:     uuid.Nil,
+			expectError:      usecase.ErrAuthorizationFaild,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByCertificateAndNotDeleted(ctx, agentCertificate.Fingerprint, agentCertificate.Subject).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Evaluate(ctx, gomock.Any(), gomock.Any()).
+					Return(&entity.AuthorizationDecision{Allowed: false}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:             "agent not found",
+			inputFingerprint: agentCertificate.Fingerprint,
+			inputSubject:     agentCertificate.Subject,
+			inputService:     "STORAGE",
+			inputPath:        "/",
+			inputMethod:      "GET",
+			expectResult:     uuid.Nil,
+			expectError:      usecase.ErrAuthenticationFailed,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByCertificateAndNotDeleted(ctx, agentCertificate.Fingerprint, agentCertificate.Subject).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:             "find agent error",
+			inputFingerprint: agentCertificate.Fingerprint,
+			inputSubject:     agentCertificate.Subject,
+			inputService:     "STORAGE",
+			inputPath:        "/",
+			inputMethod:      "GET",
+			expectResult:     uuid.Nil,
+			expectError:      sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByCertificateAndNotDeleted(ctx, agentCertificate.Fingerprint, agentCertificate.Subject).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			as := mockService.NewMockAgentService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentService(ctx, as)
+
+			au := usecase.NewAuthUsecase(to, nil, nil, ar, nil, nil, nil, nil, as, time.Time{})
+			result, err := au.AuthorizeByCertificate(ctx, tt.inputFingerprint, tt.inputSubject, tt.inputService, tt.inputPath, tt.inputMethod, "", nil)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			var userID uuid.UUID
+			if err == nil {
+				userID = result.UserID
+			}
+			if userID != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, userID)
+			}
+		})
+	}
+}
+
+func TestAuth_AuthorizeBySignature(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentSecret, err := entity.NewAgentSecret(agent.ID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	contentSHA256 := strings.Repeat("a", 64)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	nonce := "nonce-0123456789"
+	agentSignature, err := entity.NewAgentSignature(agentSecret.KeyID, strings.Repeat("0", 64), timestamp, nonce, "STORAGE", "GET", "/", contentSHA256)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	signature := agentSecret.Sign(agentSignature)
+	expiredTimestamp := strconv.FormatInt(time.Now().Add(-entity.AgentSignatureClockSkew*2).Unix(), 10)
+
+	setMockTransactionObject := func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+		to.EXPECT().
+			Transaction(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}).
+			Times(1)
+	}
+
+	tests := []struct {
+		name                                 string
+		inputSignature                       string
+		inputTimestamp                       string
+		expectResult                         uuid.UUID
+		expectError                          error
+		setMockTransactionObject             func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentRepository               func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentSecretRepository         func(context.Context, *mockRepository.MockAgentSecretRepository)
+		setMockAgentSignatureNonceRepository func(context.Context, *mockRepository.MockAgentSignatureNonceRepository)
+		setMockAgentService                  func(context.Context, *mockService.MockAgentService)
+	}{
+		{
+			name:                     "success",
+			inputSignature:           signature,
+			inputTimestamp:           timestamp,
+			expectResult:             agent.UserID,
+			expectError:              nil,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneBySecretKeyIDAndNotDeleted(ctx, agentSecret.KeyID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					FindOneByKeyID(ctx, agentSecret.KeyID).
+					Return(agentSecret, nil).
+					Times(1)
+			},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {
+				asnr.EXPECT().
+					DeleteExpiredByAgentID(ctx, agent.ID).
+					Return(nil).
+					Times(1)
+				asnr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Evaluate(ctx, gomock.Any(), gomock.Any()).
+					Return(&entity.AuthorizationDecision{Allowed: true}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                     "does not have permission",
+			inputSignature:           signature,
+			inputTimestamp:           timestamp,
+			expectResult:             uuid.Nil,
+			expectError:              usecase.ErrAuthorizationFaild,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneBySecretKeyIDAndNotDeleted(ctx, agentSecret.KeyID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					FindOneByKeyID(ctx, agentSecret.KeyID).
+					Return(agentSecret, nil).
+					Times(1)
+			},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {
+				asnr.EXPECT().
+					DeleteExpiredByAgentID(ctx, agent.ID).
+					Return(nil).
+					Times(1)
+				asnr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Evaluate(ctx, gomock.Any(), gomock.Any()).
+					Return(&entity.AuthorizationDecision{Allowed: false}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                                 "invalid signature",
+			inputSignature:                       "signature",
+			inputTimestamp:                       timestamp,
+			expectResult:                         uuid.Nil,
+			expectError:                          entity.ErrInvalidAgentSignature,
+			setMockTransactionObject:             func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockAgentRepository:               func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentSecretRepository:         func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {},
+			setMockAgentService:                  func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                                 "expired signature",
+			inputSignature:                       signature,
+			inputTimestamp:                       expiredTimestamp,
+			expectResult:                         uuid.Nil,
+			expectError:                          entity.ErrAgentSignatureExpired,
+			setMockTransactionObject:             func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockAgentRepository:               func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentSecretRepository:         func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {},
+			setMockAgentService:                  func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                     "agent secret not found",
+			inputSignature:           signature,
+			inputTimestamp:           timestamp,
+			expectResult:             uuid.Nil,
+			expectError:              usecase.ErrAuthenticationFailed,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository:   func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					FindOneByKeyID(ctx, agentSecret.KeyID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {},
+			setMockAgentService:                  func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                     "signature mismatch",
+			inputSignature:           strings.Repeat("0", 64),
+			inputTimestamp:           timestamp,
+			expectResult:             uuid.Nil,
+			expectError:              entity.ErrAuthenticationFailed,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository:   func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					FindOneByKeyID(ctx, agentSecret.KeyID).
+					Return(agentSecret, nil).
+					Times(1)
+			},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {},
+			setMockAgentService:                  func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                     "replayed nonce",
+			inputSignature:           signature,
+			inputTimestamp:           timestamp,
+			expectResult:             uuid.Nil,
+			expectError:              usecase.ErrAuthenticationFailed,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository:   func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					FindOneByKeyID(ctx, agentSecret.KeyID).
+					Return(agentSecret, nil).
+					Times(1)
+			},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {
+				asnr.EXPECT().
+					DeleteExpiredByAgentID(ctx, agent.ID).
+					Return(nil).
+					Times(1)
+				asnr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(entity.ErrAgentSignatureNonceAlreadyUsed).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                     "find agent secret error",
+			inputSignature:           signature,
+			inputTimestamp:           timestamp,
+			expectResult:             uuid.Nil,
+			expectError:              sql.ErrConnDone,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository:   func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					FindOneByKeyID(ctx, agentSecret.KeyID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {},
+			setMockAgentService:                  func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                     "create nonce error",
+			inputSignature:           signature,
+			inputTimestamp:           timestamp,
+			expectResult:             uuid.Nil,
+			expectError:              sql.ErrConnDone,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository:   func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentSecretRepository: func(ctx context.Context, asr *mockRepository.MockAgentSecretRepository) {
+				asr.EXPECT().
+					FindOneByKeyID(ctx, agentSecret.KeyID).
+					Return(agentSecret, nil).
+					Times(1)
+			},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {
+				asnr.EXPECT().
+					DeleteExpiredByAgentID(ctx, agent.ID).
+					Return(nil).
+					Times(1)
+				asnr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			asr := mockRepository.NewMockAgentSecretRepository(ctrl)
+			asnr := mockRepository.NewMockAgentSignatureNonceRepository(ctrl)
+			as := mockService.NewMockAgentService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentSecretRepository(ctx, asr)
+			tt.setMockAgentSignatureNonceRepository(ctx, asnr)
+			tt.setMockAgentService(ctx, as)
+
+			au := usecase.NewAuthUsecase(to, nil, nil, ar, asr, nil, asnr, nil, as, time.Time{})
+			result, err := au.AuthorizeBySignature(ctx, agentSecret.KeyID, tt.inputSignature, tt.inputTimestamp, nonce, contentSHA256, "STORAGE", "/", "GET", "", nil)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			var userID uuid.UUID
+			if err == nil {
+				userID = result.UserID
+			}
+			if userID != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, userID)
+			}
+		})
+	}
+}
+
+func TestAuth_AuthorizeByAssertion(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentPublicKey, err := entity.NewAgentPublicKey(agent.ID, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	if err != nil {
+		t.Error(err.Error())
+	}
+	jti := "jti-0123456789abcdef"
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.RegisteredClaims{
+		Issuer:    agent.ID.String(),
+		Subject:   agent.ID.String(),
+		Audience:  jwt.ClaimStrings{entity.AgentAssertionAudience},
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ID:        jti,
+	})
+	token.Header["kid"] = agentPublicKey.ID.String()
+	assertion, err := token.SignedString(privateKey)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	setMockTransactionObject := func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+		to.EXPECT().
+			Transaction(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}).
+			Times(1)
+	}
+
+	tests := []struct {
+		name                                 string
+		inputAssertion                       string
+		expectResult                         uuid.UUID
+		expectError                          error
+		setMockTransactionObject             func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentRepository               func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentPublicKeyRepository      func(context.Context, *mockRepository.MockAgentPublicKeyRepository)
+		setMockAgentSignatureNonceRepository func(context.Context, *mockRepository.MockAgentSignatureNonceRepository)
+		setMockAgentService                  func(context.Context, *mockService.MockAgentService)
+	}{
+		{
+			name:                     "success",
+			inputAssertion:           assertion,
+			expectResult:             agent.UserID,
+			expectError:              nil,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByPublicKeyIDAndNotDeleted(ctx, agentPublicKey.ID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					FindOneByID(ctx, agentPublicKey.ID).
+					Return(agentPublicKey, nil).
+					Times(1)
+			},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {
+				asnr.EXPECT().
+					DeleteExpiredByAgentID(ctx, agent.ID).
+					Return(nil).
+					Times(1)
+				asnr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Evaluate(ctx, gomock.Any(), gomock.Any()).
+					Return(&entity.AuthorizationDecision{Allowed: true}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                     "does not have permission",
+			inputAssertion:           assertion,
+			expectResult:             uuid.Nil,
+			expectError:              usecase.ErrAuthorizationFaild,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByPublicKeyIDAndNotDeleted(ctx, agentPublicKey.ID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					FindOneByID(ctx, agentPublicKey.ID).
+					Return(agentPublicKey, nil).
+					Times(1)
+			},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {
+				asnr.EXPECT().
+					DeleteExpiredByAgentID(ctx, agent.ID).
+					Return(nil).
+					Times(1)
+				asnr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Evaluate(ctx, gomock.Any(), gomock.Any()).
+					Return(&entity.AuthorizationDecision{Allowed: false}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                                 "invalid assertion",
+			inputAssertion:                       "assertion",
+			expectResult:                         uuid.Nil,
+			expectError:                          entity.ErrInvalidAgentAssertion,
+			setMockTransactionObject:             func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockAgentRepository:               func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentPublicKeyRepository:      func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {},
+			setMockAgentService:                  func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                     "agent public key not found",
+			inputAssertion:           assertion,
+			expectResult:             uuid.Nil,
+			expectError:              usecase.ErrAuthenticationFailed,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository:   func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					FindOneByID(ctx, agentPublicKey.ID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {},
+			setMockAgentService:                  func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                     "signed by another agent",
+			inputAssertion:           assertion,
+			expectResult:             uuid.Nil,
+			expectError:              entity.ErrInvalidAgentAssertion,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository:   func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					FindOneByID(ctx, agentPublicKey.ID).
+					Return(entity.RestoreAgentPublicKey(agentPublicKey.ID, uuid.New(), agentPublicKey.Algorithm, agentPublicKey.PublicKey, agentPublicKey.CreatedAt), nil).
+					Times(1)
+			},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {},
+			setMockAgentService:                  func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                     "replayed assertion",
+			inputAssertion:           assertion,
+			expectResult:             uuid.Nil,
+			expectError:              usecase.ErrAuthenticationFailed,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository:   func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					FindOneByID(ctx, agentPublicKey.ID).
+					Return(agentPublicKey, nil).
+					Times(1)
+			},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {
+				asnr.EXPECT().
+					DeleteExpiredByAgentID(ctx, agent.ID).
+					Return(nil).
+					Times(1)
+				asnr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(entity.ErrAgentSignatureNonceAlreadyUsed).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                     "find agent public key error",
+			inputAssertion:           assertion,
+			expectResult:             uuid.Nil,
+			expectError:              sql.ErrConnDone,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository:   func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					FindOneByID(ctx, agentPublicKey.ID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {},
+			setMockAgentService:                  func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                     "create nonce error",
+			inputAssertion:           assertion,
+			expectResult:             uuid.Nil,
+			expectError:              sql.ErrConnDone,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository:   func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentPublicKeyRepository: func(ctx context.Context, apkr *mockRepository.MockAgentPublicKeyRepository) {
+				apkr.EXPECT().
+					FindOneByID(ctx, agentPublicKey.ID).
+					Return(agentPublicKey, nil).
+					Times(1)
+			},
+			setMockAgentSignatureNonceRepository: func(ctx context.Context, asnr *mockRepository.MockAgentSignatureNonceRepository) {
+				asnr.EXPECT().
+					DeleteExpiredByAgentID(ctx, agent.ID).
+					Return(nil).
+					Times(1)
+				asnr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			apkr := mockRepository.NewMockAgentPublicKeyRepository(ctrl)
+			asnr := mockRepository.NewMockAgentSignatureNonceRepository(ctrl)
+			as := mockService.NewMockAgentService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentPublicKeyRepository(ctx, apkr)
+			tt.setMockAgentSignatureNonceRepository(ctx, asnr)
+			tt.setMockAgentService(ctx, as)
+
+			au := usecase.NewAuthUsecase(to, nil, nil, ar, nil, apkr, asnr, nil, as, time.Time{})
+			result, err := au.AuthorizeByAssertion(ctx, tt.inputAssertion, "STORAGE", "/", "GET", "", nil)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			var userID uuid.UUID
+			if err == nil {
+				userID = result.UserID
+			}
+			if userID != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, userID)
+			}
+		})
+	}
+}
+
+func TestAuth_AuthorizeDelegatedToken(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyID := uuid.New()
+	agent.Policies = []uuid.UUID{policyID, uuid.New()}
+	agentToken, err := entity.NewAgentToken(agent.ID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentDelegatedToken, err := entity.NewAgentDelegatedToken(agent, agentToken.Token, []uuid.UUID{policyID}, time.Minute)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                                 string
+		inputToken                           string
+		expectResult                         uuid.UUID
+		expectError                          error
+		setMockTransactionObject             func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentRepository               func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentDelegatedTokenRepository func(context.Context, *mockRepository.MockAgentDelegatedTokenRepository)
+		setMockAgentService                  func(context.Context, *mockService.MockAgentService)
+	}{
+		{
+			name:         "success",
+			inputToken:   agentDelegatedToken.Token,
+			expectResult: agent.UserID,
+			expectError:  nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByTokenAndNotDeleted(ctx, agentToken.Token).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentDelegatedTokenRepository: func(ctx context.Context, adtr *mockRepository.MockAgentDelegatedTokenRepository) {
+				adtr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, agentDelegatedToken.Token).
+					Return(agentDelegatedToken, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Expand(ctx, agent).
+					Return(agent, nil).
+					Times(1)
+				as.EXPECT().
+					Evaluate(ctx, agentDelegatedToken.Scope(agent), gomock.Any()).
+					Return(&entity.AuthorizationDecision{Allowed: true}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "delegated token not found",
+			inputToken:   agentDelegatedToken.Token,
+			expectResult: uuid.Nil,
+			expectError:  usecase.ErrAuthenticationFailed,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentDelegatedTokenRepository: func(ctx context.Context, adtr *mockRepository.MockAgentDelegatedTokenRepository) {
+				adtr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, agentDelegatedToken.Token).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:         "parent token revoked",
+			inputToken:   agentDelegatedToken.Token,
+			expectResult: uuid.Nil,
+			expectError:  usecase.ErrAuthenticationFailed,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByTokenAndNotDeleted(ctx, agentToken.Token).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentDelegatedTokenRepository: func(ctx context.Context, adtr *mockRepository.MockAgentDelegatedTokenRepository) {
+				adtr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, agentDelegatedToken.Token).
+					Return(agentDelegatedToken, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:         "find delegated token error",
+			inputToken:   agentDelegatedToken.Token,
+			expectResult: uuid.Nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentDelegatedTokenRepository: func(ctx context.Context, adtr *mockRepository.MockAgentDelegatedTokenRepository) {
+				adtr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, agentDelegatedToken.Token).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:                                 "malformed delegated token",
+			inputToken:                           agentDelegatedToken.Token[:len(agentDelegatedToken.Token)-1],
+			expectResult:                         uuid.Nil,
+			expectError:                          token.ErrInvalidToken,
+			setMockTransactionObject:             func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockAgentRepository:               func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentDelegatedTokenRepository: func(ctx context.Context, adtr *mockRepository.MockAgentDelegatedTokenRepository) {},
+			setMockAgentService:                  func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			adtr := mockRepository.NewMockAgentDelegatedTokenRepository(ctrl)
+			as := mockService.NewMockAgentService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentDelegatedTokenRepository(ctx, adtr)
+			tt.setMockAgentService(ctx, as)
+
+			au := usecase.NewAuthUsecase(to, nil, nil, ar, nil, nil, nil, adtr, as, time.Time{})
+			result, err := au.Authorize(ctx, tt.inputToken, "AGENT", "STORAGE", "/", "GET", "", nil)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			var userID uuid.UUID
+			if err == nil {
+				userID = result.UserID
+			}
+			if userID != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, userID)
+			}
+		})
+	}
+}
+
+func TestAuth_CreateDelegatedToken(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agent.Policies = []uuid.UUID{uuid.New()}
+	agentToken, err := entity.NewAgentToken(agent.ID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	rolePolicyID := uuid.New()
+	role := entity.RestorePolicyBundle(entity.PolicyBundleKindRole, uuid.New(), agent.UserID, "name", []uuid.UUID{rolePolicyID}, []uuid.UUID{agent.ID}, time.Now(), time.Now())
+
+	tests := []struct {
+		name                                 string
+		inputToken                           string
+		inputPolicyIDs                       []uuid.UUID
+		inputTTL                             time.Duration
+		expectError                          error
+		setMockTransactionObject             func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentRepository               func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentDelegatedTokenRepository func(context.Context, *mockRepository.MockAgentDelegatedTokenRepository)
+		setMockAgentService                  func(context.Context, *mockService.MockAgentService)
+	}{
+		{
+			name:           "success",
+			inputToken:     agentToken.Token,
+			inputPolicyIDs: agent.Policies,
+			inputTTL:       time.Minute,
+			expectError:    nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByTokenAndNotDeleted(ctx, agentToken.Token).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentDelegatedTokenRepository: func(ctx context.Context, adtr *mockRepository.MockAgentDelegatedTokenRepository) {
+				adtr.EXPECT().
+					DeleteExpiredByAgentID(ctx, agent.ID).
+					Return(nil).
+					Times(1)
+				adtr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Expand(ctx, agent).
+					Return(agent, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "role policy",
+			inputToken:     agentToken.Token,
+			inputPolicyIDs: []uuid.UUID{rolePolicyID},
+			inputTTL:       time.Minute,
+			expectError:    nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByTokenAndNotDeleted(ctx, agentToken.Token).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentDelegatedTokenRepository: func(ctx context.Context, adtr *mockRepository.MockAgentDelegatedTokenRepository) {
+				adtr.EXPECT().
+					DeleteExpiredByAgentID(ctx, agent.ID).
+					Return(nil).
+					Times(1)
+				adtr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Expand(ctx, agent).
+					Return(agent.Expand([]*entity.PolicyBundle{}, []*entity.PolicyBundle{role}), nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "policy not granted",
+			inputToken:     agentToken.Token,
+			inputPolicyIDs: []uuid.UUID{uuid.New()},
+			inputTTL:       time.Minute,
+			expectError:    entity.ErrAgentDelegatedTokenPoliciesNotGranted,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByTokenAndNotDeleted(ctx, agentToken.Token).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentDelegatedTokenRepository: func(ctx context.Context, adtr *mockRepository.MockAgentDelegatedTokenRepository) {},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Expand(ctx, agent).
+					Return(agent, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "agent not found",
+			inputToken:     agentToken.Token,
+			inputPolicyIDs: agent.Policies,
+			inputTTL:       time.Minute,
+			expectError:    usecase.ErrAuthenticationFailed,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByTokenAndNotDeleted(ctx, agentToken.Token).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentDelegatedTokenRepository: func(ctx context.Context, adtr *mockRepository.MockAgentDelegatedTokenRepository) {},
+			setMockAgentService:                  func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:           "create error",
+			inputToken:     agentToken.Token,
+			inputPolicyIDs: agent.Policies,
+			inputTTL:       time.Minute,
+			expectError:    sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByTokenAndNotDeleted(ctx, agentToken.Token).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentDelegatedTokenRepository: func(ctx context.Context, adtr *mockRepository.MockAgentDelegatedTokenRepository) {
+				adtr.EXPECT().
+					DeleteExpiredByAgentID(ctx, agent.ID).
+					Return(nil).
+					Times(1)
+				adtr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Expand(ctx, agent).
+					Return(agent, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                                 "delegated token cannot delegate",
+			inputToken:                           "hsd_1Ty1HKTPKTt8xEi-_3HTbWf2SCHOdqOSfdf6f507",
+			inputPolicyIDs:                       agent.Policies,
+			inputTTL:                             time.Minute,
+			expectError:                          token.ErrInvalidToken,
+			setMockTransactionObject:             func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockAgentRepository:               func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentDelegatedTokenRepository: func(ctx context.Context, adtr *mockRepository.MockAgentDelegatedTokenRepository) {},
+			setMockAgentService:                  func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			adtr := mockRepository.NewMockAgentDelegatedTokenRepository(ctrl)
+			as := mockService.NewMockAgentService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentDelegatedTokenRepository(ctx, adtr)
+			tt.setMockAgentService(ctx, as)
+
+			au := usecase.NewAuthUsecase(to, nil, nil, ar, nil, nil, nil, adtr, as, time.Time{})
+			result, err := au.CreateDelegatedToken(ctx, tt.inputToken, tt.inputPolicyIDs, tt.inputTTL)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if diff := cmp.Diff(tt.inputPolicyIDs, result.Policies); diff != "" {
+					t.Error(diff)
+				}
+				if result.AgentID != agent.ID {
+					t.Errorf("agent_id: expect %s but got %s", agent.ID, result.AgentID)
+				}
+			}
+		})
+	}
+}
+
+func TestAuth_Reauthenticate(t *testing.T) {
+	user, err := entity.NewUser("name", "password", "password")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	userToken, err := entity.NewUserToken(user.ID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                       string
+		inputToken                 string
+		inputPassword              string
+		expectError                error
+		setMockTransactionObject   func(context.Context, *mockDomain.MockTransactionObject)
+		setMockUserRepository      func(context.Context, *mockRepository.MockUserRepository)
+		setMockUserTokenRepository func(context.Context, *mockRepository.MockUserTokenRepository)
+	}{
+		{
+			name:          "success",
+			inputToken:    userToken.Token,
+			inputPassword: "password",
+			expectError:   nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {
+				ur.EXPECT().
+					FindOneByIDAndNotDeleted(ctx, user.ID).
+					Return(user, nil).
+					Times(1)
+			},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
+				utr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, userToken.Token).
+					Return(entity.RestoreUserToken(userToken.UserID, userToken.Token, time.Now().Add(-time.Hour), userToken.ExpiresAt), nil).
+					Times(1)
+				utr.EXPECT().
+					Save(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userToken *entity.UserToken) error {
+						if !userToken.IsRecentlyAuthenticated(time.Now()) {
+							t.Error("authenticated_at: expect recently authenticated")
+						}
+						return nil
+					}).
+					Times(1)
+			},
+		},
+		{
+			name:          "wrong password",
+			inputToken:    userToken.Token,
+			inputPassword: "wrong",
+			expectError:   entity.ErrAuthenticationFailed,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {
+				ur.EXPECT().
+					FindOneByIDAndNotDeleted(ctx, user.ID).
+					Return(user, nil).
+					Times(1)
+			},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
+				utr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, userToken.Token).
+					Return(userToken, nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "user token not found",
+			inputToken:    userToken.Token,
+			inputPassword: "password",
+			expectError:   usecase.ErrAuthenticationFailed,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
+				utr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, userToken.Token).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "user not found",
+			inputToken:    userToken.Token,
+			inputPassword: "password",
+			expectError:   usecase.ErrAuthenticationFailed,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {
+				ur.EXPECT().
+					FindOneByIDAndNotDeleted(ctx, user.ID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
+				utr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, userToken.Token).
+					Return(userToken, nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "save error",
+			inputToken:    userToken.Token,
+			inputPassword: "password",
+			expectError:   sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {
+				ur.EXPECT().
+					FindOneByIDAndNotDeleted(ctx, user.ID).
+					Return(user, nil).
+					Times(1)
+			},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
+				utr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, userToken.Token).
+					Return(userToken, nil).
+					Times(1)
+				utr.EXPECT().
+					Save(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:                       "malformed token",
+			inputToken:                 "invalid",
+			inputPassword:              "password",
+			expectError:                token.ErrInvalidToken,
+			setMockTransactionObject:   func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockUserRepository:      func(ctx context.Context, ur *mockRepository.MockUserRepository) {},
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			ur := mockRepository.NewMockUserRepository(ctrl)
+			utr := mockRepository.NewMockUserTokenRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockUserRepository(ctx, ur)
+			tt.setMockUserTokenRepository(ctx, utr)
+
+			au := usecase.NewAuthUsecase(to, ur, utr, nil, nil, nil, nil, nil, nil, time.Time{})
+			if err := au.Reauthenticate(ctx, tt.inputToken, tt.inputPassword); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestAuth_VerifyRecentAuthentication(t *testing.T) {
+	userToken, err := entity.NewUserToken(uuid.New())
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                       string
+		inputToken                 string
+		expectError                error
+		setMockUserTokenRepository func(context.Context, *mockRepository.MockUserTokenRepository)
+	}{
+		{
+			name:        "recently authenticated",
+			inputToken:  userToken.Token,
+			expectError: nil,
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
+				utr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, userToken.Token).
+					Return(userToken, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "reauthentication required",
+			inputToken:  userToken.Token,
+			expectError: usecase.ErrReauthenticationRequired,
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
+				utr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, userToken.Token).
+					Return(entity.RestoreUserToken(userToken.UserID, userToken.Token, time.Now().Add(-time.Hour), userToken.ExpiresAt), nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "user token not found",
+			inputToken:  userToken.Token,
+			expectError: usecase.ErrAuthenticationFailed,
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
+				utr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, userToken.Token).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "find user token error",
+			inputToken:  userToken.Token,
+			expectError: sql.ErrConnDone,
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
+				utr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, userToken.Token).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			utr := mockRepository.NewMockUserTokenRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockUserTokenRepository(ctx, utr)
+
+			au := usecase.NewAuthUsecase(nil, nil, utr, nil, nil, nil, nil, nil, nil, time.Time{})
+			if err := au.VerifyRecentAuthentication(ctx, tt.inputToken); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+// 100個のポリシーを持つエージェントのトークンで認可する. リポジトリはモックのため, データベースへの問い合わせの時間は含まない.
+// compiled=falseはコンパイル済みのポリシーを保持していない場合で, memo=falseではパスの分解済みのものも毎回破棄する.
+func BenchmarkAuth_Authorize(b *testing.B) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		b.Fatal(err.Error())
+	}
+	agentToken, err := token.GenerateWithPrefix(token.AgentTokenPrefix)
+	if err != nil {
+		b.Fatal(err.Error())
+	}
+	policies := make([]*entity.Policy, 100)
+	for i := range policies {
+		policies[i] = entity.RestorePolicy(uuid.New(), agent.UserID, "policy"+strconv.Itoa(i), "ALLOW", "STORAGE", "/buckets/bucket"+strconv.Itoa(i)+"/**/objects/:id", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now())
+	}
+	storage := entity.RestoreService(uuid.New(), agent.UserID, "STORAGE", []string{"*"}, "", time.Now(), time.Now())
+
+	for _, bc := range []struct {
+		name     string
+		compiled bool
+		memo     bool
+	}{
+		{name: "compiled=false,memo=false", compiled: false, memo: false},
+		{name: "compiled=false,memo=true", compiled: false, memo: true},
+		{name: "compiled=true", compiled: true, memo: true},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ctrl := gomock.NewController(b)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			to.EXPECT().
+				Transaction(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).
+				AnyTimes()
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			ar.EXPECT().
+				FindOneByTokenAndNotDeleted(ctx, agentToken).
+				Return(agent, nil).
+				AnyTimes()
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			pr.EXPECT().
+				FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), agent.UserID).
+				Return(policies, nil).
+				AnyTimes()
+			sr := mockRepository.NewMockServiceRepository(ctrl)
+			sr.EXPECT().
+				FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", agent.UserID).
+				Return(storage, nil).
+				AnyTimes()
+			ur := mockRepository.NewMockUserRepository(ctrl)
+			ur.EXPECT().
+				FindOneByIDAndNotDeleted(ctx, agent.UserID).
+				Return(nil, nil).
+				AnyTimes()
+			atr := mockRepository.NewMockAgentAttributesRepository(ctrl)
+			atr.EXPECT().
+				FindOneByAgentID(ctx, agent.ID).
+				Return(nil, nil).
+				AnyTimes()
+
+			var mr repository.PolicyMatcherRepository = memory.NewPolicyMatcherMemoryRepository()
+			if !bc.compiled {
+				mockPolicyMatcherRepository := mockRepository.NewMockPolicyMatcherRepository(ctrl)
+				mockPolicyMatcherRepository.EXPECT().
+					FindOneByKeyAndNotExpired(ctx, gomock.Any()).
+					Return(nil, nil).
+					AnyTimes()
+				mockPolicyMatcherRepository.EXPECT().
+					Save(ctx, gomock.Any()).
+					Return(nil).
+					AnyTimes()
+				mr = mockPolicyMatcherRepository
+			}
+
+			as := service.NewAgentService(pr, nil, nil, sr, mr, ur, atr, false)
+			au := usecase.NewAuthUsecase(to, nil, nil, ar, nil, nil, nil, nil, as, time.Time{})
+			authorize := func() {
+				if _, err := au.Authorize(ctx, agentToken, "AGENT", "STORAGE", "/buckets/bucket50/a/b/objects/o1", "GET", "", nil); err != nil {
+					b.Fatal(err.Error())
+				}
+			}
+			authorize()
+
+			b.ResetTimer()
+			for range b.N {
+				if !bc.memo {
+					b.StopTimer()
+					pathpattern.ClearCache()
+					b.StartTimer()
+				}
+				authorize()
 			}
 		})
 	}
@@ -0,0 +1,211 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../test/mock/usecase/$GOFILE
+package usecase
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/domain/service"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	"holos-auth-api/internal/app/api/usecase/mapper"
+
+	"github.com/google/uuid"
+)
+
+type ConfigUsecase interface {
+	Plan(context.Context, uuid.UUID, *dto.PolicyDocumentDTO) (*dto.ConfigPlanDTO, error)
+	Apply(context.Context, uuid.UUID, *dto.PolicyDocumentDTO, string) (*dto.ConfigPlanDTO, error)
+}
+
+type configUsecase struct {
+	transactionObject       domain.TransactionObject
+	policyRepository        repository.PolicyRepository
+	policyVersionRepository repository.PolicyVersionRepository
+	agentRepository         repository.AgentRepository
+	policyService           service.PolicyService
+}
+
+func NewConfigUsecase(
+	transactionObject domain.TransactionObject,
+	policyRepository repository.PolicyRepository,
+	policyVersionRepository repository.PolicyVersionRepository,
+	agentRepository repository.AgentRepository,
+	policyService service.PolicyService,
+) ConfigUsecase {
+	return &configUsecase{
+		transactionObject:       transactionObject,
+		policyRepository:        policyRepository,
+		policyVersionRepository: policyVersionRepository,
+		agentRepository:         agentRepository,
+		policyService:           policyService,
+	}
+}
+
+// 文書を望ましい状態として, 現在の状態との差分を返す. 文書にないポリシーとエージェントは削除する変更となる.
+func (u *configUsecase) Plan(ctx context.Context, userID uuid.UUID, document *dto.PolicyDocumentDTO) (*dto.ConfigPlanDTO, error) {
+	policyDocument, err := newPolicyDocument(userID, document)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan *entity.ConfigPlan
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		plan, err = u.newConfigPlan(ctx, userID, policyDocument, false)
+		if err != nil {
+			return err
+		}
+
+		return validateConfigPlan(ctx, plan, u.policyService)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToConfigPlanDTO(plan), nil
+}
+
+// 計画を作り直して1つのトランザクションで適用する. 計画の作成後に状態か文書が変わっている場合は何も変更しない.
+// 計画の検証から適用までの間に他の変更が入らないよう, ユーザーのポリシーとエージェントの行をロックしてから計画を作り直す.
+func (u *configUsecase) Apply(ctx context.Context, userID uuid.UUID, document *dto.PolicyDocumentDTO, fingerprint string) (*dto.ConfigPlanDTO, error) {
+	policyDocument, err := newPolicyDocument(userID, document)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan *entity.ConfigPlan
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		plan, err = u.newConfigPlan(ctx, userID, policyDocument, true)
+		if err != nil {
+			return err
+		}
+		if err := plan.Verify(fingerprint); err != nil {
+			return err
+		}
+
+		return applyConfigPlan(ctx, plan, userID, u.policyRepository, u.policyVersionRepository, u.agentRepository, u.policyService)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToConfigPlanDTO(plan), nil
+}
+
+// forUpdateがtrueの場合は, 取得したポリシーとエージェントの行をトランザクションの終了までロックする.
+func (u *configUsecase) newConfigPlan(ctx context.Context, userID uuid.UUID, document *entity.PolicyDocument, forUpdate bool) (*entity.ConfigPlan, error) {
+	findPolicies := func() ([]*entity.Policy, error) {
+		return u.policyRepository.FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID)
+	}
+	findAgents := func() ([]*entity.Agent, error) {
+		return u.agentRepository.FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID)
+	}
+	if forUpdate {
+		findPolicies = func() ([]*entity.Policy, error) {
+			return u.policyRepository.FindByUserIDAndNotDeletedForUpdate(ctx, userID)
+		}
+		findAgents = func() ([]*entity.Agent, error) {
+			return u.agentRepository.FindByUserIDAndNotDeletedForUpdate(ctx, userID)
+		}
+	}
+
+	policies, err := findPolicies()
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.ValidateUniquePolicyNames(policies); err != nil {
+		return nil, err
+	}
+	agents, err := findAgents()
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewConfigPlan(document, policies, agents, entity.PolicyImportModeReplace), nil
+}
+
+func validateConfigPlan(ctx context.Context, plan *entity.ConfigPlan, policyService service.PolicyService) error {
+	for _, change := range plan.Changes {
+		if change.Resource != entity.ConfigResourcePolicy || change.Action == entity.ConfigActionDelete {
+			continue
+		}
+		if err := policyService.ValidateService(ctx, change.Policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// トランザクション内で呼び出し, 計画の変更を順に適用する. ポリシーの変更はバージョンとして記録する.
+func applyConfigPlan(
+	ctx context.Context,
+	plan *entity.ConfigPlan,
+	userID uuid.UUID,
+	policyRepository repository.PolicyRepository,
+	policyVersionRepository repository.PolicyVersionRepository,
+	agentRepository repository.AgentRepository,
+	policyService service.PolicyService,
+) error {
+	if err := validateConfigPlan(ctx, plan, policyService); err != nil {
+		return err
+	}
+
+	for _, change := range plan.Changes {
+		switch change.Resource {
+		case entity.ConfigResourcePolicy:
+			var latest *entity.PolicyVersion
+			var operation string
+			switch change.Action {
+			case entity.ConfigActionCreate:
+				if err := policyRepository.Create(ctx, change.Policy); err != nil {
+					return err
+				}
+				operation = entity.PolicyVersionOperationCreate
+			case entity.ConfigActionUpdate:
+				if err := policyRepository.Update(ctx, change.Policy); err != nil {
+					return err
+				}
+				operation = entity.PolicyVersionOperationUpdate
+			case entity.ConfigActionDelete:
+				if err := policyRepository.Delete(ctx, change.Policy); err != nil {
+					return err
+				}
+				operation = entity.PolicyVersionOperationDelete
+			}
+			if operation != entity.PolicyVersionOperationCreate {
+				var err error
+				latest, err = policyVersionRepository.FindOneLatestByPolicyID(ctx, change.Policy.ID)
+				if err != nil {
+					return err
+				}
+			}
+			if err := policyVersionRepository.Create(ctx, entity.NewPolicyVersion(change.Policy, latest, operation, userID)); err != nil {
+				return err
+			}
+		case entity.ConfigResourceAgent:
+			switch change.Action {
+			case entity.ConfigActionCreate:
+				if err := agentRepository.Create(ctx, change.Agent); err != nil {
+					return err
+				}
+				// 作成では紐付けを保存しないため, 紐付けがある場合は続けて更新する.
+				if len(change.Agent.Policies) > 0 {
+					if err := agentRepository.Update(ctx, change.Agent); err != nil {
+						return err
+					}
+				}
+			case entity.ConfigActionUpdate:
+				if err := agentRepository.Update(ctx, change.Agent); err != nil {
+					return err
+				}
+			case entity.ConfigActionDelete:
+				if err := agentRepository.Delete(ctx, change.Agent); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
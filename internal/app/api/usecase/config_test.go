@@ -0,0 +1,382 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/service"
+	"holos-auth-api/internal/app/api/usecase"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	mockDomain "holos-auth-api/test/mock/domain"
+	mockRepository "holos-auth-api/test/mock/domain/repository"
+	mockService "holos-auth-api/test/mock/domain/service"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+)
+
+func TestConfig_Plan(t *testing.T) {
+	userID := uuid.New()
+	newPolicies := func() []*entity.Policy {
+		return []*entity.Policy{entity.RestorePolicy(uuid.New(), userID, "policy", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, []uuid.UUID{}, time.Now(), time.Now())}
+	}
+	newAgents := func() []*entity.Agent {
+		return []*entity.Agent{entity.RestoreAgent(uuid.New(), userID, "agent", []uuid.UUID{}, nil, nil, time.Now(), time.Now())}
+	}
+	document := &dto.PolicyDocumentDTO{
+		Version:  entity.PolicyDocumentVersion,
+		Policies: []*dto.PolicyDocumentPolicyDTO{{Name: "policy", Effect: "DENY", Service: "STORAGE", Path: "/", Methods: []string{"GET"}}},
+		Agents:   []*dto.PolicyDocumentAgentDTO{{Name: "new_agent"}},
+		Bindings: []*dto.PolicyBindingDTO{{Agent: "new_agent", Policies: []string{"policy"}}},
+	}
+
+	tests := []struct {
+		name                     string
+		inputDocument            *dto.PolicyDocumentDTO
+		expectResult             *dto.ConfigPlanDTO
+		expectError              error
+		setMockTransactionObject func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPolicyRepository  func(context.Context, *mockRepository.MockPolicyRepository)
+		setMockAgentRepository   func(context.Context, *mockRepository.MockAgentRepository)
+		setMockPolicyService     func(context.Context, *mockService.MockPolicyService)
+	}{
+		{
+			name:          "success",
+			inputDocument: document,
+			expectResult: &dto.ConfigPlanDTO{
+				Changes: []*dto.ConfigChangeDTO{
+					{Resource: entity.ConfigResourcePolicy, Action: entity.ConfigActionUpdate, Name: "policy", Fields: []string{"effect"}},
+					{Resource: entity.ConfigResourceAgent, Action: entity.ConfigActionCreate, Name: "new_agent", Fields: []string{}},
+					{Resource: entity.ConfigResourceAgent, Action: entity.ConfigActionDelete, Name: "agent", Fields: []string{}},
+				},
+			},
+			expectError: nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(newPolicies(), nil).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(newAgents(), nil).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                     "invalid document",
+			inputDocument:            &dto.PolicyDocumentDTO{Version: entity.PolicyDocumentVersion, Bindings: []*dto.PolicyBindingDTO{{Agent: "agent"}}},
+			expectResult:             nil,
+			expectError:              entity.ErrUnknownAgentInBinding,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockPolicyRepository:  func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockAgentRepository:   func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockPolicyService:     func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+		{
+			name:          "service not registered",
+			inputDocument: document,
+			expectResult:  nil,
+			expectError:   service.ErrServiceNotRegistered,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(newPolicies(), nil).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(newAgents(), nil).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(service.ErrServiceNotRegistered).
+					Times(1)
+			},
+		},
+		{
+			name:          "find error",
+			inputDocument: document,
+			expectResult:  nil,
+			expectError:   sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockPolicyService:   func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			ps := mockService.NewMockPolicyService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockPolicyRepository(ctx, pr)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockPolicyService(ctx, ps)
+
+			cu := usecase.NewConfigUsecase(to, pr, nil, ar, ps)
+			result, err := cu.Plan(ctx, userID, tt.inputDocument)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(dto.ConfigPlanDTO{}, "Fingerprint"),
+			}
+			if diff := cmp.Diff(result, tt.expectResult, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestConfig_Apply(t *testing.T) {
+	userID := uuid.New()
+	newPolicies := func() []*entity.Policy {
+		return []*entity.Policy{entity.RestorePolicy(uuid.New(), userID, "policy", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, []uuid.UUID{}, time.Now(), time.Now())}
+	}
+	newAgents := func() []*entity.Agent {
+		return []*entity.Agent{entity.RestoreAgent(uuid.New(), userID, "agent", []uuid.UUID{}, nil, nil, time.Now(), time.Now())}
+	}
+	document := &dto.PolicyDocumentDTO{
+		Version:  entity.PolicyDocumentVersion,
+		Policies: []*dto.PolicyDocumentPolicyDTO{{Name: "policy", Effect: "DENY", Service: "STORAGE", Path: "/", Methods: []string{"GET"}}},
+		Agents:   []*dto.PolicyDocumentAgentDTO{{Name: "new_agent"}},
+		Bindings: []*dto.PolicyBindingDTO{{Agent: "new_agent", Policies: []string{"policy"}}},
+	}
+
+	// 計画と同じ状態と文書から, 適用時に照合するFingerprintを求める.
+	policyDocument, err := entity.NewPolicyDocument(entity.PolicyDocumentVersion, []*entity.Policy{entity.RestorePolicy(uuid.New(), userID, "policy", "DENY", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now())}, []*entity.Agent{entity.RestoreAgent(uuid.New(), userID, "new_agent", nil, nil, nil, time.Now(), time.Now())}, []*entity.PolicyBinding{{Agent: "new_agent", Policies: []string{"policy"}}})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	fingerprint := entity.NewConfigPlan(policyDocument, newPolicies(), newAgents(), entity.PolicyImportModeReplace).Fingerprint
+
+	tests := []struct {
+		name                           string
+		inputDocument                  *dto.PolicyDocumentDTO
+		inputFingerprint               string
+		expectChanges                  int
+		expectError                    error
+		setMockTransactionObject       func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPolicyRepository        func(context.Context, *mockRepository.MockPolicyRepository)
+		setMockPolicyVersionRepository func(context.Context, *mockRepository.MockPolicyVersionRepository)
+		setMockAgentRepository         func(context.Context, *mockRepository.MockAgentRepository)
+		setMockPolicyService           func(context.Context, *mockService.MockPolicyService)
+	}{
+		{
+			name:             "success",
+			inputDocument:    document,
+			inputFingerprint: fingerprint,
+			expectChanges:    3,
+			expectError:      nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByUserIDAndNotDeletedForUpdate(ctx, userID).
+					Return(newPolicies(), nil).
+					Times(1)
+				pr.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindOneLatestByPolicyID(ctx, gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+				pvr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByUserIDAndNotDeletedForUpdate(ctx, userID).
+					Return(newAgents(), nil).
+					Times(1)
+				ar.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+				ar.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+				ar.EXPECT().
+					Delete(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:             "state changed",
+			inputDocument:    document,
+			inputFingerprint: "fingerprint",
+			expectError:      entity.ErrConfigStateChanged,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByUserIDAndNotDeletedForUpdate(ctx, userID).
+					Return(newPolicies(), nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByUserIDAndNotDeletedForUpdate(ctx, userID).
+					Return(newAgents(), nil).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+		{
+			name:                           "invalid document",
+			inputDocument:                  &dto.PolicyDocumentDTO{Version: 2},
+			inputFingerprint:               fingerprint,
+			expectError:                    entity.ErrUnsupportedPolicyDocumentVersion,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockPolicyRepository:        func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockAgentRepository:         func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+		{
+			name:             "update error",
+			inputDocument:    document,
+			inputFingerprint: fingerprint,
+			expectError:      sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByUserIDAndNotDeletedForUpdate(ctx, userID).
+					Return(newPolicies(), nil).
+					Times(1)
+				pr.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByUserIDAndNotDeletedForUpdate(ctx, userID).
+					Return(newAgents(), nil).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			pvr := mockRepository.NewMockPolicyVersionRepository(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			ps := mockService.NewMockPolicyService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockPolicyRepository(ctx, pr)
+			tt.setMockPolicyVersionRepository(ctx, pvr)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockPolicyService(ctx, ps)
+
+			cu := usecase.NewConfigUsecase(to, pr, pvr, ar, ps)
+			result, err := cu.Apply(ctx, userID, tt.inputDocument, tt.inputFingerprint)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if err == nil && len(result.Changes) != tt.expectChanges {
+				t.Errorf("changes: expect %d but got %d", tt.expectChanges, len(result.Changes))
+			}
+		})
+	}
+}
@@ -20,3 +20,38 @@ type AgentTokenDTO struct {
 	Token       string
 	GeneratedAt time.Time
 }
+
+type AgentCertificateDTO struct {
+	AgentID     uuid.UUID
+	Fingerprint string
+	Subject     string
+	BoundAt     time.Time
+}
+
+type AgentAttributesDTO struct {
+	AgentID uuid.UUID
+	Values  map[string]string
+}
+
+type AgentSecretDTO struct {
+	AgentID     uuid.UUID
+	KeyID       string
+	Secret      string
+	GeneratedAt time.Time
+}
+
+type AgentPublicKeyDTO struct {
+	ID        uuid.UUID
+	AgentID   uuid.UUID
+	Algorithm string
+	PublicKey string
+	CreatedAt time.Time
+}
+
+type AgentDelegatedTokenDTO struct {
+	AgentID   uuid.UUID
+	Token     string
+	Policies  []uuid.UUID
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
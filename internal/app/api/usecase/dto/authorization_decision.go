@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuthorizationDecisionDTO struct {
+	Allowed        bool
+	DecidingPolicy *PolicyDTO
+	Evaluations    []*PolicyEvaluationDTO
+}
+
+type PolicyEvaluationDTO struct {
+	Policy  *PolicyDTO
+	Matched bool
+	Result  string
+}
+
+type AuthorizationResultDTO struct {
+	OperatorType   string
+	UserID         uuid.UUID
+	AgentID        uuid.UUID
+	Allowed        bool
+	DecidingPolicy *PolicyDTO
+	DenyReason     string
+	CacheTTL       time.Duration
+}
+
+type AuthorizationRequestDTO struct {
+	Service    string
+	Path       string
+	Method     string
+	SourceIP   string
+	Attributes map[string]string
+}
@@ -0,0 +1,13 @@
+package dto
+
+type ConfigPlanDTO struct {
+	Fingerprint string
+	Changes     []*ConfigChangeDTO
+}
+
+type ConfigChangeDTO struct {
+	Resource string
+	Action   string
+	Name     string
+	Fields   []string
+}
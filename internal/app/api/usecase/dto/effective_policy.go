@@ -0,0 +1,14 @@
+package dto
+
+import "github.com/google/uuid"
+
+type EffectivePolicyDTO struct {
+	Policy  *PolicyDTO
+	Sources []*PolicySourceDTO
+}
+
+type PolicySourceDTO struct {
+	Type string
+	ID   uuid.UUID
+	Name string
+}
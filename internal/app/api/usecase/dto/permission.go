@@ -0,0 +1,14 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PermissionDTO struct {
+	AgentID    uuid.UUID
+	PolicyID   uuid.UUID
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+}
@@ -7,14 +7,30 @@ import (
 )
 
 type PolicyDTO struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	Name      string
-	Effect    string
-	Service   string
-	Path      string
-	Methods   []string
-	Agents    []uuid.UUID
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Effect     string
+	Service    string
+	Path       string
+	Methods    []string
+	Conditions *PolicyConditionsDTO
+	Expression string
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+	Agents     []uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type PolicyConditionsDTO struct {
+	TimeZone    string
+	DaysOfWeek  []string
+	StartTime   string
+	EndTime     string
+	NotBefore   *time.Time
+	NotAfter    *time.Time
+	SourceCIDRs []string
+	Attributes  map[string][]string
+	PathParams  map[string]string
 }
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PolicyBundleDTO struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Name      string
+	Policies  []uuid.UUID
+	Agents    []uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
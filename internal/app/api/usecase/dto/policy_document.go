@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+type PolicyDocumentDTO struct {
+	Version  int
+	Policies []*PolicyDocumentPolicyDTO
+	Agents   []*PolicyDocumentAgentDTO
+	Bindings []*PolicyBindingDTO
+}
+
+type PolicyDocumentPolicyDTO struct {
+	Name       string
+	Effect     string
+	Service    string
+	Path       string
+	Methods    []string
+	Conditions *PolicyConditionsDTO
+	Expression string
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+}
+
+type PolicyDocumentAgentDTO struct {
+	Name string
+}
+
+type PolicyBindingDTO struct {
+	Agent    string
+	Policies []string
+}
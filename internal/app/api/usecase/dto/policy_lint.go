@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/google/uuid"
+
+type PolicyLintFindingDTO struct {
+	Type      string
+	Severity  string
+	PolicyIDs []uuid.UUID
+	Message   string
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PolicyVersionDTO struct {
+	PolicyID   uuid.UUID
+	Version    int
+	UserID     uuid.UUID
+	AuthorID   uuid.UUID
+	Operation  string
+	Name       string
+	Effect     string
+	Service    string
+	Path       string
+	Methods    []string
+	Conditions *PolicyConditionsDTO
+	Expression string
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+	CreatedAt  time.Time
+}
+
+type PolicyVersionDiffDTO struct {
+	From    *PolicyVersionDTO
+	To      *PolicyVersionDTO
+	Changes []string
+}
@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SecurityEventDTO struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Type       string
+	Detail     string
+	OccurredAt time.Time
+}
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ServiceDTO struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Methods    []string
+	PathSchema string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
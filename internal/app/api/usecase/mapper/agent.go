@@ -31,3 +31,56 @@ func ToAgentTokenDTO(agentToken *entity.AgentToken) *dto.AgentTokenDTO {
 		GeneratedAt: agentToken.GeneratedAt,
 	}
 }
+
+func ToAgentCertificateDTO(agentCertificate *entity.AgentCertificate) *dto.AgentCertificateDTO {
+	return &dto.AgentCertificateDTO{
+		AgentID:     agentCertificate.AgentID,
+		Fingerprint: agentCertificate.Fingerprint,
+		Subject:     agentCertificate.Subject,
+		BoundAt:     agentCertificate.BoundAt,
+	}
+}
+
+func ToAgentAttributesDTO(agentAttributes *entity.AgentAttributes) *dto.AgentAttributesDTO {
+	return &dto.AgentAttributesDTO{
+		AgentID: agentAttributes.AgentID,
+		Values:  agentAttributes.Values,
+	}
+}
+
+func ToAgentSecretDTO(agentSecret *entity.AgentSecret) *dto.AgentSecretDTO {
+	return &dto.AgentSecretDTO{
+		AgentID:     agentSecret.AgentID,
+		KeyID:       agentSecret.KeyID,
+		Secret:      agentSecret.Secret,
+		GeneratedAt: agentSecret.GeneratedAt,
+	}
+}
+
+func ToAgentPublicKeyDTO(agentPublicKey *entity.AgentPublicKey) *dto.AgentPublicKeyDTO {
+	return &dto.AgentPublicKeyDTO{
+		ID:        agentPublicKey.ID,
+		AgentID:   agentPublicKey.AgentID,
+		Algorithm: agentPublicKey.Algorithm,
+		PublicKey: agentPublicKey.PublicKey,
+		CreatedAt: agentPublicKey.CreatedAt,
+	}
+}
+
+func ToAgentPublicKeyDTOs(agentPublicKeys []*entity.AgentPublicKey) []*dto.AgentPublicKeyDTO {
+	dtos := make([]*dto.AgentPublicKeyDTO, len(agentPublicKeys))
+	for i, agentPublicKey := range agentPublicKeys {
+		dtos[i] = ToAgentPublicKeyDTO(agentPublicKey)
+	}
+	return dtos
+}
+
+func ToAgentDelegatedTokenDTO(agentDelegatedToken *entity.AgentDelegatedToken) *dto.AgentDelegatedTokenDTO {
+	return &dto.AgentDelegatedTokenDTO{
+		AgentID:   agentDelegatedToken.AgentID,
+		Token:     agentDelegatedToken.Token,
+		Policies:  agentDelegatedToken.Policies,
+		ExpiresAt: agentDelegatedToken.ExpiresAt,
+		CreatedAt: agentDelegatedToken.CreatedAt,
+	}
+}
@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToAuthorizationDecisionDTO(decision *entity.AuthorizationDecision) *dto.AuthorizationDecisionDTO {
+	var decidingPolicy *dto.PolicyDTO
+	if decision.DecidingPolicy != nil {
+		decidingPolicy = ToPolicyDTO(decision.DecidingPolicy)
+	}
+
+	evaluations := make([]*dto.PolicyEvaluationDTO, len(decision.Evaluations))
+	for i, evaluation := range decision.Evaluations {
+		evaluations[i] = &dto.PolicyEvaluationDTO{
+			Policy:  ToPolicyDTO(evaluation.Policy),
+			Matched: evaluation.Matched,
+			Result:  evaluation.Result,
+		}
+	}
+
+	return &dto.AuthorizationDecisionDTO{
+		Allowed:        decision.Allowed,
+		DecidingPolicy: decidingPolicy,
+		Evaluations:    evaluations,
+	}
+}
+
+func ToAgentAuthorizationResultDTO(agent *entity.Agent, decision *entity.AuthorizationDecision) *dto.AuthorizationResultDTO {
+	var decidingPolicy *dto.PolicyDTO
+	if decision.DecidingPolicy != nil {
+		decidingPolicy = ToPolicyDTO(decision.DecidingPolicy)
+	}
+
+	return &dto.AuthorizationResultDTO{
+		OperatorType:   "AGENT",
+		UserID:         agent.UserID,
+		AgentID:        agent.ID,
+		Allowed:        decision.Allowed,
+		DecidingPolicy: decidingPolicy,
+		DenyReason:     decision.DenyReason(),
+		CacheTTL:       decision.CacheTTL(),
+	}
+}
@@ -0,0 +1,23 @@
+package mapper
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToConfigPlanDTO(plan *entity.ConfigPlan) *dto.ConfigPlanDTO {
+	changes := make([]*dto.ConfigChangeDTO, len(plan.Changes))
+	for i, change := range plan.Changes {
+		changes[i] = &dto.ConfigChangeDTO{
+			Resource: change.Resource,
+			Action:   change.Action,
+			Name:     change.Name,
+			Fields:   change.Fields,
+		}
+	}
+
+	return &dto.ConfigPlanDTO{
+		Fingerprint: plan.Fingerprint,
+		Changes:     changes,
+	}
+}
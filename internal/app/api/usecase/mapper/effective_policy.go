@@ -0,0 +1,30 @@
+package mapper
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToEffectivePolicyDTO(effectivePolicy *entity.EffectivePolicy) *dto.EffectivePolicyDTO {
+	sources := make([]*dto.PolicySourceDTO, len(effectivePolicy.Sources))
+	for i, source := range effectivePolicy.Sources {
+		sources[i] = &dto.PolicySourceDTO{
+			Type: source.Type,
+			ID:   source.ID,
+			Name: source.Name,
+		}
+	}
+
+	return &dto.EffectivePolicyDTO{
+		Policy:  ToPolicyDTO(effectivePolicy.Policy),
+		Sources: sources,
+	}
+}
+
+func ToEffectivePolicyDTOs(effectivePolicies []*entity.EffectivePolicy) []*dto.EffectivePolicyDTO {
+	dtos := make([]*dto.EffectivePolicyDTO, len(effectivePolicies))
+	for i, effectivePolicy := range effectivePolicies {
+		dtos[i] = ToEffectivePolicyDTO(effectivePolicy)
+	}
+	return dtos
+}
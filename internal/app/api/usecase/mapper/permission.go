@@ -0,0 +1,23 @@
+package mapper
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToPermissionDTO(permission *entity.Permission) *dto.PermissionDTO {
+	return &dto.PermissionDTO{
+		AgentID:    permission.AgentID,
+		PolicyID:   permission.PolicyID,
+		ValidFrom:  permission.ValidFrom,
+		ValidUntil: permission.ValidUntil,
+	}
+}
+
+func ToPermissionDTOs(permissions []*entity.Permission) []*dto.PermissionDTO {
+	dtos := make([]*dto.PermissionDTO, len(permissions))
+	for i, permission := range permissions {
+		dtos[i] = ToPermissionDTO(permission)
+	}
+	return dtos
+}
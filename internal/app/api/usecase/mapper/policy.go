@@ -7,16 +7,20 @@ import (
 
 func ToPolicyDTO(policy *entity.Policy) *dto.PolicyDTO {
 	return &dto.PolicyDTO{
-		ID:        policy.ID,
-		UserID:    policy.UserID,
-		Name:      policy.Name,
-		Effect:    policy.Effect,
-		Service:   policy.Service,
-		Path:      policy.Path,
-		Methods:   policy.Methods,
-		Agents:    policy.Agents,
-		CreatedAt: policy.CreatedAt,
-		UpdatedAt: policy.UpdatedAt,
+		ID:         policy.ID,
+		UserID:     policy.UserID,
+		Name:       policy.Name,
+		Effect:     policy.Effect,
+		Service:    policy.Service,
+		Path:       policy.Path,
+		Methods:    policy.Methods,
+		Conditions: ToPolicyConditionsDTO(policy.Conditions),
+		Expression: policy.Expression,
+		ValidFrom:  policy.ValidFrom,
+		ValidUntil: policy.ValidUntil,
+		Agents:     policy.Agents,
+		CreatedAt:  policy.CreatedAt,
+		UpdatedAt:  policy.UpdatedAt,
 	}
 }
 
@@ -27,3 +31,21 @@ func ToPolicyDTOs(policies []*entity.Policy) []*dto.PolicyDTO {
 	}
 	return dtos
 }
+
+func ToPolicyConditionsDTO(conditions *entity.PolicyConditions) *dto.PolicyConditionsDTO {
+	if conditions == nil {
+		return nil
+	}
+
+	return &dto.PolicyConditionsDTO{
+		TimeZone:    conditions.TimeZone,
+		DaysOfWeek:  conditions.DaysOfWeek,
+		StartTime:   conditions.StartTime,
+		EndTime:     conditions.EndTime,
+		NotBefore:   conditions.NotBefore,
+		NotAfter:    conditions.NotAfter,
+		SourceCIDRs: conditions.SourceCIDRs,
+		Attributes:  conditions.Attributes,
+		PathParams:  conditions.PathParams,
+	}
+}
@@ -0,0 +1,26 @@
+package mapper
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToPolicyBundleDTO(bundle *entity.PolicyBundle) *dto.PolicyBundleDTO {
+	return &dto.PolicyBundleDTO{
+		ID:        bundle.ID,
+		UserID:    bundle.UserID,
+		Name:      bundle.Name,
+		Policies:  bundle.Policies,
+		Agents:    bundle.Agents,
+		CreatedAt: bundle.CreatedAt,
+		UpdatedAt: bundle.UpdatedAt,
+	}
+}
+
+func ToPolicyBundleDTOs(bundles []*entity.PolicyBundle) []*dto.PolicyBundleDTO {
+	dtos := make([]*dto.PolicyBundleDTO, len(bundles))
+	for i, bundle := range bundles {
+		dtos[i] = ToPolicyBundleDTO(bundle)
+	}
+	return dtos
+}
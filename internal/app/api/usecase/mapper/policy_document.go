@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToPolicyDocumentDTO(document *entity.PolicyDocument) *dto.PolicyDocumentDTO {
+	policies := make([]*dto.PolicyDocumentPolicyDTO, len(document.Policies))
+	for i, policy := range document.Policies {
+		policies[i] = &dto.PolicyDocumentPolicyDTO{
+			Name:       policy.Name,
+			Effect:     policy.Effect,
+			Service:    policy.Service,
+			Path:       policy.Path,
+			Methods:    policy.Methods,
+			Conditions: ToPolicyConditionsDTO(policy.Conditions),
+			Expression: policy.Expression,
+			ValidFrom:  policy.ValidFrom,
+			ValidUntil: policy.ValidUntil,
+		}
+	}
+
+	agents := make([]*dto.PolicyDocumentAgentDTO, len(document.Agents))
+	for i, agent := range document.Agents {
+		agents[i] = &dto.PolicyDocumentAgentDTO{
+			Name: agent.Name,
+		}
+	}
+
+	bindings := make([]*dto.PolicyBindingDTO, len(document.Bindings))
+	for i, binding := range document.Bindings {
+		bindings[i] = &dto.PolicyBindingDTO{
+			Agent:    binding.Agent,
+			Policies: binding.Policies,
+		}
+	}
+
+	return &dto.PolicyDocumentDTO{
+		Version:  document.Version,
+		Policies: policies,
+		Agents:   agents,
+		Bindings: bindings,
+	}
+}
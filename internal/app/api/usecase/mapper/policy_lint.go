@@ -0,0 +1,23 @@
+package mapper
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToPolicyLintFindingDTO(finding *entity.PolicyLintFinding) *dto.PolicyLintFindingDTO {
+	return &dto.PolicyLintFindingDTO{
+		Type:      finding.Type,
+		Severity:  finding.Severity,
+		PolicyIDs: finding.PolicyIDs,
+		Message:   finding.Message,
+	}
+}
+
+func ToPolicyLintFindingDTOs(findings []*entity.PolicyLintFinding) []*dto.PolicyLintFindingDTO {
+	dtos := make([]*dto.PolicyLintFindingDTO, len(findings))
+	for i, finding := range findings {
+		dtos[i] = ToPolicyLintFindingDTO(finding)
+	}
+	return dtos
+}
@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToPolicyVersionDTO(policyVersion *entity.PolicyVersion) *dto.PolicyVersionDTO {
+	return &dto.PolicyVersionDTO{
+		PolicyID:   policyVersion.PolicyID,
+		Version:    policyVersion.Version,
+		UserID:     policyVersion.UserID,
+		AuthorID:   policyVersion.AuthorID,
+		Operation:  policyVersion.Operation,
+		Name:       policyVersion.Name,
+		Effect:     policyVersion.Effect,
+		Service:    policyVersion.Service,
+		Path:       policyVersion.Path,
+		Methods:    policyVersion.Methods,
+		Conditions: ToPolicyConditionsDTO(policyVersion.Conditions),
+		Expression: policyVersion.Expression,
+		ValidFrom:  policyVersion.ValidFrom,
+		ValidUntil: policyVersion.ValidUntil,
+		CreatedAt:  policyVersion.CreatedAt,
+	}
+}
+
+func ToPolicyVersionDTOs(policyVersions []*entity.PolicyVersion) []*dto.PolicyVersionDTO {
+	dtos := make([]*dto.PolicyVersionDTO, len(policyVersions))
+	for i, policyVersion := range policyVersions {
+		dtos[i] = ToPolicyVersionDTO(policyVersion)
+	}
+	return dtos
+}
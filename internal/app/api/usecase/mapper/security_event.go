@@ -0,0 +1,24 @@
+package mapper
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToSecurityEventDTO(securityEvent *entity.SecurityEvent) *dto.SecurityEventDTO {
+	return &dto.SecurityEventDTO{
+		ID:         securityEvent.ID,
+		UserID:     securityEvent.UserID,
+		Type:       securityEvent.Type,
+		Detail:     securityEvent.Detail,
+		OccurredAt: securityEvent.OccurredAt,
+	}
+}
+
+func ToSecurityEventDTOs(securityEvents []*entity.SecurityEvent) []*dto.SecurityEventDTO {
+	dtos := make([]*dto.SecurityEventDTO, len(securityEvents))
+	for i, securityEvent := range securityEvents {
+		dtos[i] = ToSecurityEventDTO(securityEvent)
+	}
+	return dtos
+}
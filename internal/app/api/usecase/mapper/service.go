@@ -0,0 +1,26 @@
+package mapper
+
+import (
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/usecase/dto"
+)
+
+func ToServiceDTO(service *entity.Service) *dto.ServiceDTO {
+	return &dto.ServiceDTO{
+		ID:         service.ID,
+		UserID:     service.UserID,
+		Name:       service.Name,
+		Methods:    service.Methods,
+		PathSchema: service.PathSchema,
+		CreatedAt:  service.CreatedAt,
+		UpdatedAt:  service.UpdatedAt,
+	}
+}
+
+func ToServiceDTOs(services []*entity.Service) []*dto.ServiceDTO {
+	dtos := make([]*dto.ServiceDTO, len(services))
+	for i, service := range services {
+		dtos[i] = ToServiceDTO(service)
+	}
+	return dtos
+}
@@ -0,0 +1,144 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../test/mock/usecase/$GOFILE
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"holos-auth-api/internal/app/api/domain"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	"holos-auth-api/internal/app/api/usecase/mapper"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPermissionNotFound = status.Error(http.StatusNotFound, "permission not found")
+)
+
+type PermissionUsecase interface {
+	Gets(context.Context, uuid.UUID, uuid.UUID) ([]*dto.PermissionDTO, error)
+	Grant(context.Context, uuid.UUID, uuid.UUID, uuid.UUID, *time.Time, *time.Time) (*dto.PermissionDTO, error)
+	Revoke(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error
+	DeleteExpired(context.Context) error
+}
+
+type permissionUsecase struct {
+	transactionObject       domain.TransactionObject
+	permissionRepository    repository.PermissionRepository
+	agentRepository         repository.AgentRepository
+	policyRepository        repository.PolicyRepository
+	securityEventRepository repository.SecurityEventRepository
+}
+
+func NewPermissionUsecase(
+	transactionObject domain.TransactionObject,
+	permissionRepository repository.PermissionRepository,
+	agentRepository repository.AgentRepository,
+	policyRepository repository.PolicyRepository,
+	securityEventRepository repository.SecurityEventRepository,
+) PermissionUsecase {
+	return &permissionUsecase{
+		transactionObject:       transactionObject,
+		permissionRepository:    permissionRepository,
+		agentRepository:         agentRepository,
+		policyRepository:        policyRepository,
+		securityEventRepository: securityEventRepository,
+	}
+}
+
+func (u *permissionUsecase) Gets(ctx context.Context, agentID uuid.UUID, userID uuid.UUID) ([]*dto.PermissionDTO, error) {
+	agent, err := u.agentRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, agentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if agent == nil {
+		return nil, ErrAgentNotFound
+	}
+
+	permissions, err := u.permissionRepository.FindByAgentIDAndUserID(ctx, agent.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToPermissionDTOs(permissions), nil
+}
+
+// 同じポリシーの紐付けがある場合は期間を置き換える.
+func (u *permissionUsecase) Grant(ctx context.Context, agentID uuid.UUID, policyID uuid.UUID, userID uuid.UUID, validFrom *time.Time, validUntil *time.Time) (*dto.PermissionDTO, error) {
+	var permission *entity.Permission
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		agent, err := u.agentRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, agentID, userID)
+		if err != nil {
+			return err
+		}
+		if agent == nil {
+			return ErrAgentNotFound
+		}
+
+		policy, err := u.policyRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, policyID, userID)
+		if err != nil {
+			return err
+		}
+		if policy == nil {
+			return ErrPolicyNotFound
+		}
+
+		permission, err = entity.NewPermission(agent, policy, validFrom, validUntil, time.Now())
+		if err != nil {
+			return err
+		}
+
+		return u.permissionRepository.Save(ctx, permission)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToPermissionDTO(permission), nil
+}
+
+func (u *permissionUsecase) Revoke(ctx context.Context, agentID uuid.UUID, policyID uuid.UUID, userID uuid.UUID) error {
+	return u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		permission, err := u.permissionRepository.FindOneByAgentIDAndPolicyIDAndUserID(ctx, agentID, policyID, userID)
+		if err != nil {
+			return err
+		}
+		if permission == nil {
+			return ErrPermissionNotFound
+		}
+
+		return u.permissionRepository.Delete(ctx, permission)
+	})
+}
+
+// 有効期間の終了した紐付けを削除し, 削除したことをセキュリティイベントとして記録する.
+// 判定は読み込み時に有効期間で絞り込むため, 削除が遅れても期限切れの紐付けは使われない.
+// 複数のインスタンスで同時に実行しても, 他のインスタンスがロックした紐付けは取得しないため, 記録は重複しない.
+func (u *permissionUsecase) DeleteExpired(ctx context.Context) error {
+	return u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		permissions, err := u.permissionRepository.FindExpired(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		for _, permission := range permissions {
+			if err := u.permissionRepository.Delete(ctx, permission); err != nil {
+				return err
+			}
+
+			securityEvent, err := entity.NewSecurityEvent(permission.UserID, entity.SecurityEventTypePermissionExpired, fmt.Sprintf("agent_id=%s policy_id=%s valid_until=%s", permission.AgentID, permission.PolicyID, permission.ValidUntil.Format(time.RFC3339)))
+			if err != nil {
+				return err
+			}
+			if err := u.securityEventRepository.Create(ctx, securityEvent); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
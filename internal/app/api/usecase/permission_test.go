@@ -0,0 +1,455 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/usecase"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	mockDomain "holos-auth-api/test/mock/domain"
+	mockRepository "holos-auth-api/test/mock/domain/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestPermission_Gets(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	validUntil := time.Now().Add(time.Hour)
+	permission := entity.RestorePermission(agent.ID, uuid.New(), agent.UserID, nil, &validUntil)
+
+	tests := []struct {
+		name                        string
+		inputAgentID                uuid.UUID
+		inputUserID                 uuid.UUID
+		expectResult                []*dto.PermissionDTO
+		expectError                 error
+		setMockAgentRepository      func(context.Context, *mockRepository.MockAgentRepository)
+		setMockPermissionRepository func(context.Context, *mockRepository.MockPermissionRepository)
+	}{
+		{
+			name:         "success",
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: []*dto.PermissionDTO{{AgentID: agent.ID, PolicyID: permission.PolicyID, ValidFrom: nil, ValidUntil: &validUntil}},
+			expectError:  nil,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockPermissionRepository: func(ctx context.Context, pr *mockRepository.MockPermissionRepository) {
+				pr.EXPECT().
+					FindByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return([]*entity.Permission{permission}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "agent not found",
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  usecase.ErrAgentNotFound,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockPermissionRepository: func(ctx context.Context, pr *mockRepository.MockPermissionRepository) {},
+		},
+		{
+			name:         "find error",
+			inputAgentID: agent.ID,
+			inputUserID:  agent.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockPermissionRepository: func(ctx context.Context, pr *mockRepository.MockPermissionRepository) {
+				pr.EXPECT().
+					FindByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			pr := mockRepository.NewMockPermissionRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockPermissionRepository(ctx, pr)
+
+			pu := usecase.NewPermissionUsecase(nil, pr, ar, nil, nil)
+			result, err := pu.Gets(ctx, tt.inputAgentID, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPermission_Grant(t *testing.T) {
+	agent, err := entity.NewAgent(uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(agent.UserID, "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	validFrom := time.Now()
+	validUntil := validFrom.Add(time.Hour)
+	expired := validFrom.Add(-time.Hour)
+
+	setMockTransactionObject := func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+		to.EXPECT().
+			Transaction(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}).
+			Times(1)
+	}
+
+	tests := []struct {
+		name                        string
+		inputValidFrom              *time.Time
+		inputValidUntil             *time.Time
+		expectResult                *dto.PermissionDTO
+		expectError                 error
+		setMockTransactionObject    func(context.Context, *mockDomain.MockTransactionObject)
+		setMockAgentRepository      func(context.Context, *mockRepository.MockAgentRepository)
+		setMockPolicyRepository     func(context.Context, *mockRepository.MockPolicyRepository)
+		setMockPermissionRepository func(context.Context, *mockRepository.MockPermissionRepository)
+	}{
+		{
+			name:                     "success",
+			inputValidFrom:           &validFrom,
+			inputValidUntil:          &validUntil,
+			expectResult:             &dto.PermissionDTO{AgentID: agent.ID, PolicyID: policy.ID, ValidFrom: &validFrom, ValidUntil: &validUntil},
+			expectError:              nil,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, agent.UserID).
+					Return(policy, nil).
+					Times(1)
+			},
+			setMockPermissionRepository: func(ctx context.Context, pr *mockRepository.MockPermissionRepository) {
+				pr.EXPECT().
+					Save(ctx, entity.RestorePermission(agent.ID, policy.ID, agent.UserID, &validFrom, &validUntil)).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                     "agent not found",
+			inputValidFrom:           nil,
+			inputValidUntil:          &validUntil,
+			expectResult:             nil,
+			expectError:              usecase.ErrAgentNotFound,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockPolicyRepository:     func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockPermissionRepository: func(ctx context.Context, pr *mockRepository.MockPermissionRepository) {},
+		},
+		{
+			name:                     "policy not found",
+			inputValidFrom:           nil,
+			inputValidUntil:          &validUntil,
+			expectResult:             nil,
+			expectError:              usecase.ErrPolicyNotFound,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, agent.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockPermissionRepository: func(ctx context.Context, pr *mockRepository.MockPermissionRepository) {},
+		},
+		{
+			name:                     "already expired",
+			inputValidFrom:           nil,
+			inputValidUntil:          &expired,
+			expectResult:             nil,
+			expectError:              entity.ErrPermissionAlreadyExpired,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, agent.UserID).
+					Return(policy, nil).
+					Times(1)
+			},
+			setMockPermissionRepository: func(ctx context.Context, pr *mockRepository.MockPermissionRepository) {},
+		},
+		{
+			name:                     "save error",
+			inputValidFrom:           nil,
+			inputValidUntil:          &validUntil,
+			expectResult:             nil,
+			expectError:              sql.ErrConnDone,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, agent.UserID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, agent.UserID).
+					Return(policy, nil).
+					Times(1)
+			},
+			setMockPermissionRepository: func(ctx context.Context, pr *mockRepository.MockPermissionRepository) {
+				pr.EXPECT().
+					Save(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			per := mockRepository.NewMockPermissionRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockPolicyRepository(ctx, pr)
+			tt.setMockPermissionRepository(ctx, per)
+
+			pu := usecase.NewPermissionUsecase(to, per, ar, pr, nil)
+			result, err := pu.Grant(ctx, agent.ID, policy.ID, agent.UserID, tt.inputValidFrom, tt.inputValidUntil)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPermission_Revoke(t *testing.T) {
+	permission := entity.RestorePermission(uuid.New(), uuid.New(), uuid.New(), nil, nil)
+
+	tests := []struct {
+		name                        string
+		expectError                 error
+		setMockTransactionObject    func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPermissionRepository func(context.Context, *mockRepository.MockPermissionRepository)
+	}{
+		{
+			name:        "success",
+			expectError: nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPermissionRepository: func(ctx context.Context, pr *mockRepository.MockPermissionRepository) {
+				pr.EXPECT().
+					FindOneByAgentIDAndPolicyIDAndUserID(ctx, permission.AgentID, permission.PolicyID, permission.UserID).
+					Return(permission, nil).
+					Times(1)
+				pr.EXPECT().
+					Delete(ctx, permission).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "not found",
+			expectError: usecase.ErrPermissionNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPermissionRepository: func(ctx context.Context, pr *mockRepository.MockPermissionRepository) {
+				pr.EXPECT().
+					FindOneByAgentIDAndPolicyIDAndUserID(ctx, permission.AgentID, permission.PolicyID, permission.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			pr := mockRepository.NewMockPermissionRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockPermissionRepository(ctx, pr)
+
+			pu := usecase.NewPermissionUsecase(to, pr, nil, nil, nil)
+			if err := pu.Revoke(ctx, permission.AgentID, permission.PolicyID, permission.UserID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestPermission_DeleteExpired(t *testing.T) {
+	validUntil := time.Now().Add(-time.Minute)
+	permission := entity.RestorePermission(uuid.New(), uuid.New(), uuid.New(), nil, &validUntil)
+
+	setMockTransactionObject := func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+		to.EXPECT().
+			Transaction(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}).
+			Times(1)
+	}
+
+	tests := []struct {
+		name                           string
+		expectError                    error
+		setMockTransactionObject       func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPermissionRepository    func(context.Context, *mockRepository.MockPermissionRepository)
+		setMockSecurityEventRepository func(context.Context, *mockRepository.MockSecurityEventRepository)
+	}{
+		{
+			name:                     "delete and record",
+			expectError:              nil,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockPermissionRepository: func(ctx context.Context, pr *mockRepository.MockPermissionRepository) {
+				pr.EXPECT().
+					FindExpired(ctx, gomock.Any()).
+					Return([]*entity.Permission{permission}, nil).
+					Times(1)
+				pr.EXPECT().
+					Delete(ctx, permission).
+					Return(nil).
+					Times(1)
+			},
+			setMockSecurityEventRepository: func(ctx context.Context, ser *mockRepository.MockSecurityEventRepository) {
+				ser.EXPECT().
+					Create(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, securityEvent *entity.SecurityEvent) error {
+						if securityEvent.UserID != permission.UserID || securityEvent.Type != entity.SecurityEventTypePermissionExpired {
+							t.Errorf("unexpected security event: %+v", securityEvent)
+						}
+						return nil
+					}).
+					Times(1)
+			},
+		},
+		{
+			name:                     "nothing expired",
+			expectError:              nil,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockPermissionRepository: func(ctx context.Context, pr *mockRepository.MockPermissionRepository) {
+				pr.EXPECT().
+					FindExpired(ctx, gomock.Any()).
+					Return([]*entity.Permission{}, nil).
+					Times(1)
+			},
+			setMockSecurityEventRepository: func(ctx context.Context, ser *mockRepository.MockSecurityEventRepository) {},
+		},
+		{
+			name:                     "delete error",
+			expectError:              sql.ErrConnDone,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockPermissionRepository: func(ctx context.Context, pr *mockRepository.MockPermissionRepository) {
+				pr.EXPECT().
+					FindExpired(ctx, gomock.Any()).
+					Return([]*entity.Permission{permission}, nil).
+					Times(1)
+				pr.EXPECT().
+					Delete(ctx, permission).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockSecurityEventRepository: func(ctx context.Context, ser *mockRepository.MockSecurityEventRepository) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			pr := mockRepository.NewMockPermissionRepository(ctrl)
+			ser := mockRepository.NewMockSecurityEventRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockPermissionRepository(ctx, pr)
+			tt.setMockSecurityEventRepository(ctx, ser)
+
+			pu := usecase.NewPermissionUsecase(to, pr, nil, nil, ser)
+			if err := pu.DeleteExpired(ctx); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
@@ -11,64 +11,104 @@ import (
 	"holos-auth-api/internal/app/api/usecase/dto"
 	"holos-auth-api/internal/app/api/usecase/mapper"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 var (
-	ErrPolicyNotFound = status.Error(http.StatusNotFound, "policy not found")
+	ErrPolicyNotFound        = status.Error(http.StatusNotFound, "policy not found")
+	ErrPolicyVersionNotFound = status.Error(http.StatusNotFound, "policy version not found")
+	// 事前の確認をすり抜けて同時に作成した場合も, データベースの一意制約により同じエラーとなる.
+	ErrPolicyAlreadyExists = entity.ErrPolicyAlreadyExists
 )
 
 type PolicyUsecase interface {
-	Create(context.Context, uuid.UUID, string, string, string, string, []string) (*dto.PolicyDTO, error)
-	Update(context.Context, uuid.UUID, uuid.UUID, string, string, string, string, []string) (*dto.PolicyDTO, error)
+	Create(context.Context, uuid.UUID, string, string, string, string, []string, *dto.PolicyConditionsDTO, string, *time.Time, *time.Time) (*dto.PolicyDTO, error)
+	Update(context.Context, uuid.UUID, uuid.UUID, string, string, string, string, []string, *dto.PolicyConditionsDTO, string, *time.Time, *time.Time) (*dto.PolicyDTO, error)
 	Delete(context.Context, uuid.UUID, uuid.UUID) error
 	Get(context.Context, uuid.UUID, uuid.UUID) (*dto.PolicyDTO, error)
 	Gets(context.Context, string, uuid.UUID) ([]*dto.PolicyDTO, error)
 	UpdateAgents(context.Context, uuid.UUID, uuid.UUID, []uuid.UUID) ([]*dto.AgentDTO, error)
 	GetAgents(context.Context, uuid.UUID, uuid.UUID, string) ([]*dto.AgentDTO, error)
+	GetVersions(context.Context, uuid.UUID, uuid.UUID) ([]*dto.PolicyVersionDTO, error)
+	DiffVersions(context.Context, uuid.UUID, uuid.UUID, int, int) (*dto.PolicyVersionDiffDTO, error)
+	RestoreVersion(context.Context, uuid.UUID, uuid.UUID, int) (*dto.PolicyDTO, error)
+	Export(context.Context, uuid.UUID) (*dto.PolicyDocumentDTO, error)
+	Import(context.Context, uuid.UUID, *dto.PolicyDocumentDTO, string) (*dto.PolicyDocumentDTO, error)
+	Lint(context.Context, uuid.UUID, uuid.UUID) ([]*dto.PolicyLintFindingDTO, error)
 }
 
 type policyUsecase struct {
-	transactionObject domain.TransactionObject
-	policyRepository  repository.PolicyRepository
-	agentRepository   repository.AgentRepository
-	policyService     service.PolicyService
+	transactionObject       domain.TransactionObject
+	policyRepository        repository.PolicyRepository
+	policyVersionRepository repository.PolicyVersionRepository
+	agentRepository         repository.AgentRepository
+	policyService           service.PolicyService
+	agentService            service.AgentService
 }
 
 func NewPolicyUsecase(
 	transactionObject domain.TransactionObject,
 	policyRepository repository.PolicyRepository,
+	policyVersionRepository repository.PolicyVersionRepository,
 	agentRepository repository.AgentRepository,
 	policyService service.PolicyService,
+	agentService service.AgentService,
 ) PolicyUsecase {
 	return &policyUsecase{
-		transactionObject: transactionObject,
-		policyRepository:  policyRepository,
-		agentRepository:   agentRepository,
-		policyService:     policyService,
+		transactionObject:       transactionObject,
+		policyRepository:        policyRepository,
+		policyVersionRepository: policyVersionRepository,
+		agentRepository:         agentRepository,
+		policyService:           policyService,
+		agentService:            agentService,
 	}
 }
 
-func (u *policyUsecase) Create(ctx context.Context, userID uuid.UUID, name string, effect string, service string, path string, methods []string) (*dto.PolicyDTO, error) {
+func (u *policyUsecase) Create(ctx context.Context, userID uuid.UUID, name string, effect string, service string, path string, methods []string, conditions *dto.PolicyConditionsDTO, expression string, validFrom *time.Time, validUntil *time.Time) (*dto.PolicyDTO, error) {
 	policy, err := entity.NewPolicy(userID, name, effect, service, path, methods)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := u.policyRepository.Create(ctx, policy); err != nil {
+	policyConditions, err := newPolicyConditions(conditions)
+	if err != nil {
+		return nil, err
+	}
+	policy.SetConditions(policyConditions)
+	if err := policy.SetExpression(expression); err != nil {
+		return nil, err
+	}
+	if err := policy.SetValidity(validFrom, validUntil); err != nil {
+		return nil, err
+	}
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.validateNameUniqueness(ctx, policy); err != nil {
+			return err
+		}
+		if err := u.policyService.ValidateService(ctx, policy); err != nil {
+			return err
+		}
+		if err := u.policyRepository.Create(ctx, policy); err != nil {
+			return err
+		}
+
+		return u.policyVersionRepository.Create(ctx, entity.NewPolicyVersion(policy, nil, entity.PolicyVersionOperationCreate, userID))
+	}); err != nil {
 		return nil, err
 	}
 
 	return mapper.ToPolicyDTO(policy), nil
 }
 
-func (u *policyUsecase) Update(ctx context.Context, id uuid.UUID, userID uuid.UUID, name string, effect string, service string, path string, methods []string) (*dto.PolicyDTO, error) {
+func (u *policyUsecase) Update(ctx context.Context, id uuid.UUID, userID uuid.UUID, name string, effect string, service string, path string, methods []string, conditions *dto.PolicyConditionsDTO, expression string, validFrom *time.Time, validUntil *time.Time) (*dto.PolicyDTO, error) {
 	var policy *entity.Policy
 
 	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
 		var err error
-		policy, err = u.policyRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, id, userID)
+		policy, err = u.policyRepository.FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, id, userID)
 		if err != nil {
 			return err
 		}
@@ -91,8 +131,29 @@ func (u *policyUsecase) Update(ctx context.Context, id uuid.UUID, userID uuid.UU
 		if err := policy.SetMethods(methods); err != nil {
 			return err
 		}
+		policyConditions, err := newPolicyConditions(conditions)
+		if err != nil {
+			return err
+		}
+		policy.SetConditions(policyConditions)
+		if err := policy.SetExpression(expression); err != nil {
+			return err
+		}
+		if err := policy.SetValidity(validFrom, validUntil); err != nil {
+			return err
+		}
+		if err := u.validateNameUniqueness(ctx, policy); err != nil {
+			return err
+		}
+		if err := u.policyService.ValidateService(ctx, policy); err != nil {
+			return err
+		}
 
-		return u.policyRepository.Update(ctx, policy)
+		if err := u.policyRepository.Update(ctx, policy); err != nil {
+			return err
+		}
+
+		return u.createVersion(ctx, policy, entity.PolicyVersionOperationUpdate, userID)
 	}); err != nil {
 		return nil, err
 	}
@@ -102,7 +163,7 @@ func (u *policyUsecase) Update(ctx context.Context, id uuid.UUID, userID uuid.UU
 
 func (u *policyUsecase) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
 	return u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
-		policy, err := u.policyRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, id, userID)
+		policy, err := u.policyRepository.FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, id, userID)
 		if err != nil {
 			return err
 		}
@@ -110,7 +171,11 @@ func (u *policyUsecase) Delete(ctx context.Context, id uuid.UUID, userID uuid.UU
 			return ErrPolicyNotFound
 		}
 
-		return u.policyRepository.Delete(ctx, policy)
+		if err := u.policyRepository.Delete(ctx, policy); err != nil {
+			return err
+		}
+
+		return u.createVersion(ctx, policy, entity.PolicyVersionOperationDelete, userID)
 	})
 }
 
@@ -182,3 +247,281 @@ func (u *policyUsecase) GetAgents(ctx context.Context, id uuid.UUID, userID uuid
 
 	return mapper.ToAgentDTOs(agents), nil
 }
+
+// 削除済みのポリシーの履歴も返す.
+func (u *policyUsecase) GetVersions(ctx context.Context, id uuid.UUID, userID uuid.UUID) ([]*dto.PolicyVersionDTO, error) {
+	policyVersions, err := u.policyVersionRepository.FindByPolicyIDAndUserID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(policyVersions) == 0 {
+		return nil, ErrPolicyNotFound
+	}
+
+	return mapper.ToPolicyVersionDTOs(policyVersions), nil
+}
+
+func (u *policyUsecase) DiffVersions(ctx context.Context, id uuid.UUID, userID uuid.UUID, from int, to int) (*dto.PolicyVersionDiffDTO, error) {
+	fromVersion, err := u.policyVersionRepository.FindOneByPolicyIDAndVersionAndUserID(ctx, id, from, userID)
+	if err != nil {
+		return nil, err
+	}
+	if fromVersion == nil {
+		return nil, ErrPolicyVersionNotFound
+	}
+
+	toVersion, err := u.policyVersionRepository.FindOneByPolicyIDAndVersionAndUserID(ctx, id, to, userID)
+	if err != nil {
+		return nil, err
+	}
+	if toVersion == nil {
+		return nil, ErrPolicyVersionNotFound
+	}
+
+	return &dto.PolicyVersionDiffDTO{
+		From:    mapper.ToPolicyVersionDTO(fromVersion),
+		To:      mapper.ToPolicyVersionDTO(toVersion),
+		Changes: fromVersion.Diff(toVersion),
+	}, nil
+}
+
+// 指定したバージョンの内容でポリシーを更新し, 新しいバージョンとして記録する. 削除済みのポリシーは復元できない.
+func (u *policyUsecase) RestoreVersion(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int) (*dto.PolicyDTO, error) {
+	var policy *entity.Policy
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		policy, err = u.policyRepository.FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+		if policy == nil {
+			return ErrPolicyNotFound
+		}
+
+		policyVersion, err := u.policyVersionRepository.FindOneByPolicyIDAndVersionAndUserID(ctx, id, version, userID)
+		if err != nil {
+			return err
+		}
+		if policyVersion == nil {
+			return ErrPolicyVersionNotFound
+		}
+
+		if err := policy.Rollback(policyVersion); err != nil {
+			return err
+		}
+		// 復元する名前が, その後に作成された別のポリシーに使われている場合がある.
+		if err := u.validateNameUniqueness(ctx, policy); err != nil {
+			return err
+		}
+		// 復元するバージョンの作成後にサービスの定義が変わっている場合があるため, 改めて検証する.
+		if err := u.policyService.ValidateService(ctx, policy); err != nil {
+			return err
+		}
+		if err := u.policyRepository.Update(ctx, policy); err != nil {
+			return err
+		}
+
+		return u.createVersion(ctx, policy, entity.PolicyVersionOperationRestore, userID)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToPolicyDTO(policy), nil
+}
+
+func (u *policyUsecase) Export(ctx context.Context, userID uuid.UUID) (*dto.PolicyDocumentDTO, error) {
+	policies, err := u.policyRepository.FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	agents, err := u.agentRepository.FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToPolicyDocumentDTO(entity.ExportPolicyDocument(policies, agents)), nil
+}
+
+// 文書全体を検証してから1つのトランザクションで適用し, 取り込み後の状態を文書として返す.
+// 既存のポリシーとエージェントとは名前で照合し, 変更のあったポリシーのみバージョンを記録する. modeを省略した場合は統合する.
+func (u *policyUsecase) Import(ctx context.Context, userID uuid.UUID, document *dto.PolicyDocumentDTO, mode string) (*dto.PolicyDocumentDTO, error) {
+	if mode == "" {
+		mode = entity.PolicyImportModeMerge
+	}
+	if err := entity.ValidatePolicyImportMode(mode); err != nil {
+		return nil, err
+	}
+
+	policyDocument, err := newPolicyDocument(userID, document)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan *entity.ConfigPlan
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		policies, err := u.policyRepository.FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID)
+		if err != nil {
+			return err
+		}
+		if err := entity.ValidateUniquePolicyNames(policies); err != nil {
+			return err
+		}
+		agents, err := u.agentRepository.FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID)
+		if err != nil {
+			return err
+		}
+
+		plan = entity.NewConfigPlan(policyDocument, policies, agents, mode)
+
+		return applyConfigPlan(ctx, plan, userID, u.policyRepository, u.policyVersionRepository, u.agentRepository, u.policyService)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToPolicyDocumentDTO(plan.Result), nil
+}
+
+// agentIDがuuid.Nilの場合はユーザーのすべてのポリシーを, それ以外はエージェントに適用されるポリシーを検査する.
+// どのエージェントにも適用されていないポリシーは, すべてのポリシーを検査する場合のみ指摘する.
+func (u *policyUsecase) Lint(ctx context.Context, agentID uuid.UUID, userID uuid.UUID) ([]*dto.PolicyLintFindingDTO, error) {
+	findings := []*entity.PolicyLintFinding{}
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		if agentID == uuid.Nil {
+			policies, err := u.policyRepository.FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID)
+			if err != nil {
+				return err
+			}
+			unattached, err := u.policyRepository.FindUnattachedByUserIDAndNotDeleted(ctx, userID)
+			if err != nil {
+				return err
+			}
+
+			// 異なるエージェントに適用されるポリシーは同時に評価されないため, グループとロールを展開して適用先を求める.
+			agents, err := u.agentRepository.FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID)
+			if err != nil {
+				return err
+			}
+			attachments := make([][]uuid.UUID, len(agents))
+			for i, agent := range agents {
+				expanded, err := u.agentService.Expand(ctx, agent)
+				if err != nil {
+					return err
+				}
+				attachments[i] = expanded.Policies
+			}
+
+			findings = entity.LintPolicies(policies, attachments, unattached)
+			return nil
+		}
+
+		agent, err := u.agentRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, agentID, userID)
+		if err != nil {
+			return err
+		}
+		if agent == nil {
+			return ErrAgentNotFound
+		}
+		effectivePolicies, err := u.agentService.GetEffectivePolicies(ctx, agent)
+		if err != nil {
+			return err
+		}
+
+		policies := make([]*entity.Policy, len(effectivePolicies))
+		for i, effectivePolicy := range effectivePolicies {
+			policies[i] = effectivePolicy.Policy
+		}
+		findings = entity.LintPolicies(policies, nil, nil)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToPolicyLintFindingDTOs(findings), nil
+}
+
+// 文書はポリシーを名前で参照するため, ユーザー内で名前を一意にする.
+func (u *policyUsecase) validateNameUniqueness(ctx context.Context, policy *entity.Policy) error {
+	found, err := u.policyRepository.FindOneByNameAndUserIDAndNotDeleted(ctx, policy.Name, policy.UserID)
+	if err != nil {
+		return err
+	}
+	if found != nil && found.ID != policy.ID {
+		return ErrPolicyAlreadyExists
+	}
+	return nil
+}
+
+// トランザクション内で呼び出し, ポリシーの変更と同時に記録する.
+// 並行した変更が同じ番号を採番しないよう, 呼び出し元でポリシーの行をロックしてから最新のバージョンを読む.
+func (u *policyUsecase) createVersion(ctx context.Context, policy *entity.Policy, operation string, authorID uuid.UUID) error {
+	latest, err := u.policyVersionRepository.FindOneLatestByPolicyID(ctx, policy.ID)
+	if err != nil {
+		return err
+	}
+
+	return u.policyVersionRepository.Create(ctx, entity.NewPolicyVersion(policy, latest, operation, authorID))
+}
+
+func newPolicyConditions(conditions *dto.PolicyConditionsDTO) (*entity.PolicyConditions, error) {
+	if conditions == nil {
+		return nil, nil
+	}
+
+	return entity.NewPolicyConditions(
+		conditions.TimeZone,
+		conditions.DaysOfWeek,
+		conditions.StartTime,
+		conditions.EndTime,
+		conditions.NotBefore,
+		conditions.NotAfter,
+		conditions.SourceCIDRs,
+		conditions.Attributes,
+		conditions.PathParams,
+	)
+}
+
+// 文書のポリシーとエージェントをすべて新規作成と同じ検証にかける.
+func newPolicyDocument(userID uuid.UUID, document *dto.PolicyDocumentDTO) (*entity.PolicyDocument, error) {
+	policies := make([]*entity.Policy, len(document.Policies))
+	for i, v := range document.Policies {
+		policy, err := entity.NewPolicy(userID, v.Name, v.Effect, v.Service, v.Path, v.Methods)
+		if err != nil {
+			return nil, err
+		}
+		policyConditions, err := newPolicyConditions(v.Conditions)
+		if err != nil {
+			return nil, err
+		}
+		policy.SetConditions(policyConditions)
+		if err := policy.SetExpression(v.Expression); err != nil {
+			return nil, err
+		}
+		if err := policy.SetValidity(v.ValidFrom, v.ValidUntil); err != nil {
+			return nil, err
+		}
+		policies[i] = policy
+	}
+
+	agents := make([]*entity.Agent, len(document.Agents))
+	for i, v := range document.Agents {
+		agent, err := entity.NewAgent(userID, v.Name)
+		if err != nil {
+			return nil, err
+		}
+		agents[i] = agent
+	}
+
+	bindings := make([]*entity.PolicyBinding, len(document.Bindings))
+	for i, v := range document.Bindings {
+		bindings[i] = &entity.PolicyBinding{
+			Agent:    v.Agent,
+			Policies: v.Policies,
+		}
+	}
+
+	return entity.NewPolicyDocument(document.Version, policies, agents, bindings)
+}
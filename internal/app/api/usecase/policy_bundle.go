@@ -0,0 +1,225 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../test/mock/usecase/$GOFILE
+package usecase
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/domain/service"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	"holos-auth-api/internal/app/api/usecase/mapper"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRoleNotFound       = status.Error(http.StatusNotFound, "role not found")
+	ErrAgentGroupNotFound = status.Error(http.StatusNotFound, "agent group not found")
+)
+
+var policyBundleNotFoundErrors = map[entity.PolicyBundleKind]error{
+	entity.PolicyBundleKindRole:       ErrRoleNotFound,
+	entity.PolicyBundleKindAgentGroup: ErrAgentGroupNotFound,
+}
+
+// ロールとエージェントグループで共通のユースケース. 1つのユースケースは1種類のPolicyBundleのみを扱う.
+type PolicyBundleUsecase interface {
+	Create(context.Context, uuid.UUID, string) (*dto.PolicyBundleDTO, error)
+	Update(context.Context, uuid.UUID, uuid.UUID, string) (*dto.PolicyBundleDTO, error)
+	Delete(context.Context, uuid.UUID, uuid.UUID) error
+	Get(context.Context, uuid.UUID, uuid.UUID) (*dto.PolicyBundleDTO, error)
+	Gets(context.Context, string, uuid.UUID) ([]*dto.PolicyBundleDTO, error)
+	UpdatePolicies(context.Context, uuid.UUID, uuid.UUID, []uuid.UUID) ([]*dto.PolicyDTO, error)
+	GetPolicies(context.Context, uuid.UUID, uuid.UUID, string) ([]*dto.PolicyDTO, error)
+	UpdateAgents(context.Context, uuid.UUID, uuid.UUID, []uuid.UUID) ([]*dto.AgentDTO, error)
+	GetAgents(context.Context, uuid.UUID, uuid.UUID, string) ([]*dto.AgentDTO, error)
+}
+
+type policyBundleUsecase struct {
+	kind                   entity.PolicyBundleKind
+	transactionObject      domain.TransactionObject
+	policyBundleRepository repository.PolicyBundleRepository
+	policyRepository       repository.PolicyRepository
+	agentRepository        repository.AgentRepository
+	policyBundleService    service.PolicyBundleService
+}
+
+func NewPolicyBundleUsecase(
+	kind entity.PolicyBundleKind,
+	transactionObject domain.TransactionObject,
+	policyBundleRepository repository.PolicyBundleRepository,
+	policyRepository repository.PolicyRepository,
+	agentRepository repository.AgentRepository,
+	policyBundleService service.PolicyBundleService,
+) PolicyBundleUsecase {
+	return &policyBundleUsecase{
+		kind:                   kind,
+		transactionObject:      transactionObject,
+		policyBundleRepository: policyBundleRepository,
+		policyRepository:       policyRepository,
+		agentRepository:        agentRepository,
+		policyBundleService:    policyBundleService,
+	}
+}
+
+func (u *policyBundleUsecase) findOne(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.PolicyBundle, error) {
+	bundle, err := u.policyBundleRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if bundle == nil {
+		return nil, policyBundleNotFoundErrors[u.kind]
+	}
+	return bundle, nil
+}
+
+func (u *policyBundleUsecase) Create(ctx context.Context, userID uuid.UUID, name string) (*dto.PolicyBundleDTO, error) {
+	bundle, err := entity.NewPolicyBundle(u.kind, userID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.policyBundleRepository.Create(ctx, bundle); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToPolicyBundleDTO(bundle), nil
+}
+
+func (u *policyBundleUsecase) Update(ctx context.Context, id uuid.UUID, userID uuid.UUID, name string) (*dto.PolicyBundleDTO, error) {
+	var bundle *entity.PolicyBundle
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		bundle, err = u.findOne(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := bundle.SetName(name); err != nil {
+			return err
+		}
+
+		return u.policyBundleRepository.Update(ctx, bundle)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToPolicyBundleDTO(bundle), nil
+}
+
+func (u *policyBundleUsecase) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	return u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		bundle, err := u.findOne(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		return u.policyBundleRepository.Delete(ctx, bundle)
+	})
+}
+
+func (u *policyBundleUsecase) Get(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.PolicyBundleDTO, error) {
+	bundle, err := u.findOne(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToPolicyBundleDTO(bundle), nil
+}
+
+func (u *policyBundleUsecase) Gets(ctx context.Context, keyword string, userID uuid.UUID) ([]*dto.PolicyBundleDTO, error) {
+	bundles, err := u.policyBundleRepository.FindByNamePrefixAndUserIDAndNotDeleted(ctx, keyword, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToPolicyBundleDTOs(bundles), nil
+}
+
+func (u *policyBundleUsecase) UpdatePolicies(ctx context.Context, id uuid.UUID, userID uuid.UUID, policyIDs []uuid.UUID) ([]*dto.PolicyDTO, error) {
+	policies := make([]*entity.Policy, len(policyIDs))
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		bundle, err := u.findOne(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		policies, err = u.policyRepository.FindByIDsAndUserIDAndNotDeleted(ctx, policyIDs, userID)
+		if err != nil {
+			return err
+		}
+
+		bundle.SetPolicies(policies)
+
+		return u.policyBundleRepository.Update(ctx, bundle)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToPolicyDTOs(policies), nil
+}
+
+func (u *policyBundleUsecase) GetPolicies(ctx context.Context, id uuid.UUID, userID uuid.UUID, keyword string) ([]*dto.PolicyDTO, error) {
+	policies := []*entity.Policy{}
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		bundle, err := u.findOne(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		policies, err = u.policyBundleService.GetPolicies(ctx, bundle, keyword)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToPolicyDTOs(policies), nil
+}
+
+func (u *policyBundleUsecase) UpdateAgents(ctx context.Context, id uuid.UUID, userID uuid.UUID, agentIDs []uuid.UUID) ([]*dto.AgentDTO, error) {
+	agents := make([]*entity.Agent, len(agentIDs))
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		bundle, err := u.findOne(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		agents, err = u.agentRepository.FindByIDsAndUserIDAndNotDeleted(ctx, agentIDs, userID)
+		if err != nil {
+			return err
+		}
+
+		bundle.SetAgents(agents)
+
+		return u.policyBundleRepository.Update(ctx, bundle)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToAgentDTOs(agents), nil
+}
+
+func (u *policyBundleUsecase) GetAgents(ctx context.Context, id uuid.UUID, userID uuid.UUID, keyword string) ([]*dto.AgentDTO, error) {
+	agents := []*entity.Agent{}
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		bundle, err := u.findOne(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		agents, err = u.policyBundleService.GetAgents(ctx, bundle, keyword)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToAgentDTOs(agents), nil
+}
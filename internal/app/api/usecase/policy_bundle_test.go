@@ -0,0 +1,795 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/usecase"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	mockDomain "holos-auth-api/test/mock/domain"
+	mockRepository "holos-auth-api/test/mock/domain/repository"
+	mockService "holos-auth-api/test/mock/domain/service"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+)
+
+func TestPolicyBundle_Create(t *testing.T) {
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                          string
+		inputName                     string
+		expectResult                  *dto.PolicyBundleDTO
+		expectError                   error
+		setMockPolicyBundleRepository func(context.Context, *mockRepository.MockPolicyBundleRepository)
+	}{
+		{
+			name:         "success",
+			inputName:    "name",
+			expectResult: &dto.PolicyBundleDTO{UserID: role.UserID, Name: role.Name, Policies: []uuid.UUID{}, Agents: []uuid.UUID{}},
+			expectError:  nil,
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                          "invalid name",
+			inputName:                     "なまえ",
+			expectResult:                  nil,
+			expectError:                   entity.ErrInvalidRoleName,
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {},
+		},
+		{
+			name:         "create error",
+			inputName:    "name",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rr := mockRepository.NewMockPolicyBundleRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockPolicyBundleRepository(ctx, rr)
+
+			ru := usecase.NewPolicyBundleUsecase(entity.PolicyBundleKindRole, nil, rr, nil, nil, nil)
+			result, err := ru.Create(ctx, role.UserID, tt.inputName)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(dto.PolicyBundleDTO{}, "ID", "CreatedAt", "UpdatedAt"),
+			}
+			if diff := cmp.Diff(result, tt.expectResult, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_Update(t *testing.T) {
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                          string
+		inputName                     string
+		expectResult                  *dto.PolicyBundleDTO
+		expectError                   error
+		setMockTransactionObject      func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPolicyBundleRepository func(context.Context, *mockRepository.MockPolicyBundleRepository)
+	}{
+		{
+			name:         "success",
+			inputName:    "new_name",
+			expectResult: &dto.PolicyBundleDTO{ID: role.ID, UserID: role.UserID, Name: "new_name", Policies: []uuid.UUID{}, Agents: []uuid.UUID{}, CreatedAt: role.CreatedAt},
+			expectError:  nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(entity.RestorePolicyBundle(entity.PolicyBundleKindRole, role.ID, role.UserID, role.Name, role.Policies, role.Agents, role.CreatedAt, role.UpdatedAt), nil).
+					Times(1)
+				rr.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "role not found",
+			inputName:    "new_name",
+			expectResult: nil,
+			expectError:  usecase.ErrRoleNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "invalid name",
+			inputName:    "なまえ",
+			expectResult: nil,
+			expectError:  entity.ErrInvalidRoleName,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(entity.RestorePolicyBundle(entity.PolicyBundleKindRole, role.ID, role.UserID, role.Name, role.Policies, role.Agents, role.CreatedAt, role.UpdatedAt), nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "update error",
+			inputName:    "new_name",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(entity.RestorePolicyBundle(entity.PolicyBundleKindRole, role.ID, role.UserID, role.Name, role.Policies, role.Agents, role.CreatedAt, role.UpdatedAt), nil).
+					Times(1)
+				rr.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			rr := mockRepository.NewMockPolicyBundleRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockPolicyBundleRepository(ctx, rr)
+
+			ru := usecase.NewPolicyBundleUsecase(entity.PolicyBundleKindRole, to, rr, nil, nil, nil)
+			result, err := ru.Update(ctx, role.ID, role.UserID, tt.inputName)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(dto.PolicyBundleDTO{}, "UpdatedAt"),
+			}
+			if diff := cmp.Diff(result, tt.expectResult, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_Delete(t *testing.T) {
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                          string
+		expectError                   error
+		setMockTransactionObject      func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPolicyBundleRepository func(context.Context, *mockRepository.MockPolicyBundleRepository)
+	}{
+		{
+			name:        "success",
+			expectError: nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(role, nil).
+					Times(1)
+				rr.EXPECT().
+					Delete(ctx, role).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "role not found",
+			expectError: usecase.ErrRoleNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "delete error",
+			expectError: sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(role, nil).
+					Times(1)
+				rr.EXPECT().
+					Delete(ctx, role).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			rr := mockRepository.NewMockPolicyBundleRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockPolicyBundleRepository(ctx, rr)
+
+			ru := usecase.NewPolicyBundleUsecase(entity.PolicyBundleKindRole, to, rr, nil, nil, nil)
+			if err := ru.Delete(ctx, role.ID, role.UserID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_Get(t *testing.T) {
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                          string
+		expectResult                  *dto.PolicyBundleDTO
+		expectError                   error
+		setMockPolicyBundleRepository func(context.Context, *mockRepository.MockPolicyBundleRepository)
+	}{
+		{
+			name:         "success",
+			expectResult: &dto.PolicyBundleDTO{ID: role.ID, UserID: role.UserID, Name: role.Name, Policies: []uuid.UUID{}, Agents: []uuid.UUID{}, CreatedAt: role.CreatedAt, UpdatedAt: role.UpdatedAt},
+			expectError:  nil,
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(role, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "role not found",
+			expectResult: nil,
+			expectError:  usecase.ErrRoleNotFound,
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rr := mockRepository.NewMockPolicyBundleRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockPolicyBundleRepository(ctx, rr)
+
+			ru := usecase.NewPolicyBundleUsecase(entity.PolicyBundleKindRole, nil, rr, nil, nil, nil)
+			result, err := ru.Get(ctx, role.ID, role.UserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_UpdatePolicies(t *testing.T) {
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policy, err := entity.NewPolicy(role.UserID, "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                          string
+		expectResult                  []*dto.PolicyDTO
+		expectError                   error
+		setMockTransactionObject      func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPolicyBundleRepository func(context.Context, *mockRepository.MockPolicyBundleRepository)
+		setMockPolicyRepository       func(context.Context, *mockRepository.MockPolicyRepository)
+	}{
+		{
+			name:         "success",
+			expectResult: []*dto.PolicyDTO{{ID: policy.ID, UserID: policy.UserID, Name: policy.Name, Effect: policy.Effect, Service: policy.Service, Path: policy.Path, Methods: policy.Methods, Agents: []uuid.UUID{}, CreatedAt: policy.CreatedAt, UpdatedAt: policy.UpdatedAt}},
+			expectError:  nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(entity.RestorePolicyBundle(entity.PolicyBundleKindRole, role.ID, role.UserID, role.Name, role.Policies, role.Agents, role.CreatedAt, role.UpdatedAt), nil).
+					Times(1)
+				rr.EXPECT().
+					Update(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, role *entity.PolicyBundle) error {
+						if diff := cmp.Diff([]uuid.UUID{policy.ID}, role.Policies); diff != "" {
+							t.Error(diff)
+						}
+						return nil
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, []uuid.UUID{policy.ID}, role.UserID).
+					Return([]*entity.Policy{policy}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "role not found",
+			expectResult: nil,
+			expectError:  usecase.ErrRoleNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+		},
+		{
+			name:         "find policies error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(entity.RestorePolicyBundle(entity.PolicyBundleKindRole, role.ID, role.UserID, role.Name, role.Policies, role.Agents, role.CreatedAt, role.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			rr := mockRepository.NewMockPolicyBundleRepository(ctrl)
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockPolicyBundleRepository(ctx, rr)
+			tt.setMockPolicyRepository(ctx, pr)
+
+			ru := usecase.NewPolicyBundleUsecase(entity.PolicyBundleKindRole, to, rr, pr, nil, nil)
+			result, err := ru.UpdatePolicies(ctx, role.ID, role.UserID, []uuid.UUID{policy.ID})
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_UpdateAgents(t *testing.T) {
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agent, err := entity.NewAgent(role.UserID, "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                          string
+		expectResult                  []*dto.AgentDTO
+		expectError                   error
+		setMockTransactionObject      func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPolicyBundleRepository func(context.Context, *mockRepository.MockPolicyBundleRepository)
+		setMockAgentRepository        func(context.Context, *mockRepository.MockAgentRepository)
+	}{
+		{
+			name:         "success",
+			expectResult: []*dto.AgentDTO{{ID: agent.ID, UserID: agent.UserID, Name: agent.Name, Policies: []uuid.UUID{}, CreatedAt: agent.CreatedAt, UpdatedAt: agent.UpdatedAt}},
+			expectError:  nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(entity.RestorePolicyBundle(entity.PolicyBundleKindRole, role.ID, role.UserID, role.Name, role.Policies, role.Agents, role.CreatedAt, role.UpdatedAt), nil).
+					Times(1)
+				rr.EXPECT().
+					Update(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, role *entity.PolicyBundle) error {
+						if diff := cmp.Diff([]uuid.UUID{agent.ID}, role.Agents); diff != "" {
+							t.Error(diff)
+						}
+						return nil
+					}).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, []uuid.UUID{agent.ID}, role.UserID).
+					Return([]*entity.Agent{agent}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "role not found",
+			expectResult: nil,
+			expectError:  usecase.ErrRoleNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+		},
+		{
+			name:         "update error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(entity.RestorePolicyBundle(entity.PolicyBundleKindRole, role.ID, role.UserID, role.Name, role.Policies, role.Agents, role.CreatedAt, role.UpdatedAt), nil).
+					Times(1)
+				rr.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return([]*entity.Agent{agent}, nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			rr := mockRepository.NewMockPolicyBundleRepository(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockPolicyBundleRepository(ctx, rr)
+			tt.setMockAgentRepository(ctx, ar)
+
+			ru := usecase.NewPolicyBundleUsecase(entity.PolicyBundleKindRole, to, rr, nil, ar, nil)
+			result, err := ru.UpdateAgents(ctx, role.ID, role.UserID, []uuid.UUID{agent.ID})
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_GetAgents(t *testing.T) {
+	role, err := entity.NewPolicyBundle(entity.PolicyBundleKindRole, uuid.New(), "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agent, err := entity.NewAgent(role.UserID, "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                          string
+		expectResult                  []*dto.AgentDTO
+		expectError                   error
+		setMockTransactionObject      func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPolicyBundleRepository func(context.Context, *mockRepository.MockPolicyBundleRepository)
+		setMockPolicyBundleService    func(context.Context, *mockService.MockPolicyBundleService)
+	}{
+		{
+			name:         "success",
+			expectResult: []*dto.AgentDTO{{ID: agent.ID, UserID: agent.UserID, Name: agent.Name, Policies: []uuid.UUID{}, CreatedAt: agent.CreatedAt, UpdatedAt: agent.UpdatedAt}},
+			expectError:  nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(role, nil).
+					Times(1)
+			},
+			setMockPolicyBundleService: func(ctx context.Context, rs *mockService.MockPolicyBundleService) {
+				rs.EXPECT().
+					GetAgents(ctx, role, "name").
+					Return([]*entity.Agent{agent}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "role not found",
+			expectResult: nil,
+			expectError:  usecase.ErrRoleNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockPolicyBundleService: func(ctx context.Context, rs *mockService.MockPolicyBundleService) {},
+		},
+		{
+			name:         "get agents error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyBundleRepository: func(ctx context.Context, rr *mockRepository.MockPolicyBundleRepository) {
+				rr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, role.ID, role.UserID).
+					Return(role, nil).
+					Times(1)
+			},
+			setMockPolicyBundleService: func(ctx context.Context, rs *mockService.MockPolicyBundleService) {
+				rs.EXPECT().
+					GetAgents(ctx, role, "name").
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			rr := mockRepository.NewMockPolicyBundleRepository(ctrl)
+			rs := mockService.NewMockPolicyBundleService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockPolicyBundleRepository(ctx, rr)
+			tt.setMockPolicyBundleService(ctx, rs)
+
+			ru := usecase.NewPolicyBundleUsecase(entity.PolicyBundleKindRole, to, rr, nil, nil, rs)
+			result, err := ru.GetAgents(ctx, role.ID, role.UserID, "name")
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicyBundle_NotFound(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputKind   entity.PolicyBundleKind
+		expectError error
+	}{
+		{
+			name:        "role",
+			inputKind:   entity.PolicyBundleKindRole,
+			expectError: usecase.ErrRoleNotFound,
+		},
+		{
+			name:        "agent group",
+			inputKind:   entity.PolicyBundleKindAgentGroup,
+			expectError: usecase.ErrAgentGroupNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			id := uuid.New()
+			userID := uuid.New()
+
+			br := mockRepository.NewMockPolicyBundleRepository(ctrl)
+			br.EXPECT().
+				FindOneByIDAndUserIDAndNotDeleted(gomock.Any(), id, userID).
+				Return(nil, nil).
+				Times(1)
+
+			bu := usecase.NewPolicyBundleUsecase(tt.inputKind, nil, br, nil, nil, nil)
+			if _, err := bu.Get(context.Background(), id, userID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
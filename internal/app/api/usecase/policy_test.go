@@ -5,12 +5,15 @@ import (
 	"database/sql"
 	"errors"
 	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/service"
 	"holos-auth-api/internal/app/api/usecase"
 	"holos-auth-api/internal/app/api/usecase/dto"
 	mockDomain "holos-auth-api/test/mock/domain"
 	mockRepository "holos-auth-api/test/mock/domain/repository"
 	mockService "holos-auth-api/test/mock/domain/service"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
@@ -23,18 +26,27 @@ func TestPolicy_Create(t *testing.T) {
 	if err != nil {
 		t.Error(err.Error())
 	}
+	validFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	validUntil := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
 
 	tests := []struct {
-		name                    string
-		inputUserID             uuid.UUID
-		inputName               string
-		inputEffect             string
-		inputService            string
-		inputPath               string
-		inputMethods            []string
-		expectResult            *dto.PolicyDTO
-		expectError             error
-		setMockPolicyRepository func(context.Context, *mockRepository.MockPolicyRepository)
+		name                           string
+		inputUserID                    uuid.UUID
+		inputName                      string
+		inputEffect                    string
+		inputService                   string
+		inputPath                      string
+		inputMethods                   []string
+		inputConditions                *dto.PolicyConditionsDTO
+		inputExpression                string
+		inputValidFrom                 *time.Time
+		inputValidUntil                *time.Time
+		expectResult                   *dto.PolicyDTO
+		expectError                    error
+		setMockTransactionObject       func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPolicyRepository        func(context.Context, *mockRepository.MockPolicyRepository)
+		setMockPolicyVersionRepository func(context.Context, *mockRepository.MockPolicyVersionRepository)
+		setMockPolicyService           func(context.Context, *mockService.MockPolicyService)
 	}{
 		{
 			name:         "success",
@@ -46,72 +58,358 @@ func TestPolicy_Create(t *testing.T) {
 			inputMethods: []string{"GET"},
 			expectResult: &dto.PolicyDTO{ID: policy.ID, UserID: policy.UserID, Name: policy.Name, Effect: policy.Effect, Service: policy.Service, Path: policy.Path, Methods: policy.Methods, Agents: []uuid.UUID{}, CreatedAt: policy.CreatedAt, UpdatedAt: policy.UpdatedAt},
 			expectError:  nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
 				pr.EXPECT().
 					Create(ctx, gomock.Any()).
 					Return(nil).
 					Times(1)
 			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
 		},
 		{
-			name:                    "invalid name",
-			inputUserID:             policy.UserID,
-			inputName:               "なまえ",
-			inputEffect:             "ALLOW",
-			inputService:            "STORAGE",
-			inputPath:               "/",
-			inputMethods:            []string{"GET"},
-			expectResult:            nil,
-			expectError:             entity.ErrInvalidPolicyName,
-			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			name:                           "invalid name",
+			inputUserID:                    policy.UserID,
+			inputName:                      "なまえ",
+			inputEffect:                    "ALLOW",
+			inputService:                   "STORAGE",
+			inputPath:                      "/",
+			inputMethods:                   []string{"GET"},
+			expectResult:                   nil,
+			expectError:                    entity.ErrInvalidPolicyName,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockPolicyRepository:        func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
 		},
 		{
-			name:                    "invalid effect",
-			inputUserID:             policy.UserID,
-			inputName:               "name",
-			inputEffect:             "EFFECT",
-			inputService:            "STORAGE",
-			inputPath:               "/",
-			inputMethods:            []string{"GET"},
-			expectResult:            nil,
-			expectError:             entity.ErrInvalidPolicyEffect,
-			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			name:                           "invalid effect",
+			inputUserID:                    policy.UserID,
+			inputName:                      "name",
+			inputEffect:                    "EFFECT",
+			inputService:                   "STORAGE",
+			inputPath:                      "/",
+			inputMethods:                   []string{"GET"},
+			expectResult:                   nil,
+			expectError:                    entity.ErrInvalidPolicyEffect,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockPolicyRepository:        func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
 		},
 		{
-			name:                    "invalid service",
-			inputUserID:             policy.UserID,
-			inputName:               "name",
-			inputEffect:             "ALLOW",
-			inputService:            "SERVICE",
-			inputPath:               "/",
-			inputMethods:            []string{"GET"},
-			expectResult:            nil,
-			expectError:             entity.ErrInvalidPolicyService,
-			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			name:                           "invalid service",
+			inputUserID:                    policy.UserID,
+			inputName:                      "name",
+			inputEffect:                    "ALLOW",
+			inputService:                   "service",
+			inputPath:                      "/",
+			inputMethods:                   []string{"GET"},
+			expectResult:                   nil,
+			expectError:                    entity.ErrInvalidPolicyService,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockPolicyRepository:        func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
 		},
 		{
-			name:                    "invalid path",
-			inputUserID:             policy.UserID,
-			inputName:               "name",
-			inputEffect:             "ALLOW",
-			inputService:            "STORAGE",
-			inputPath:               "path",
-			inputMethods:            []string{"GET"},
-			expectResult:            nil,
-			expectError:             entity.ErrInvalidPolicyPath,
-			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			name:                           "invalid path",
+			inputUserID:                    policy.UserID,
+			inputName:                      "name",
+			inputEffect:                    "ALLOW",
+			inputService:                   "STORAGE",
+			inputPath:                      "path",
+			inputMethods:                   []string{"GET"},
+			expectResult:                   nil,
+			expectError:                    entity.ErrInvalidPolicyPath,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockPolicyRepository:        func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
 		},
 		{
-			name:                    "invalid methods",
-			inputUserID:             policy.UserID,
-			inputName:               "name",
-			inputEffect:             "ALLOW",
-			inputService:            "STORAGE",
-			inputPath:               "/",
-			inputMethods:            []string{"PATCH"},
-			expectResult:            nil,
-			expectError:             entity.ErrInvalidPolicyMethods,
-			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			name:                           "invalid methods",
+			inputUserID:                    policy.UserID,
+			inputName:                      "name",
+			inputEffect:                    "ALLOW",
+			inputService:                   "STORAGE",
+			inputPath:                      "/",
+			inputMethods:                   []string{"TRACE"},
+			expectResult:                   nil,
+			expectError:                    entity.ErrInvalidPolicyMethods,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockPolicyRepository:        func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+		{
+			name:            "success with conditions",
+			inputUserID:     policy.UserID,
+			inputName:       "name",
+			inputEffect:     "ALLOW",
+			inputService:    "STORAGE",
+			inputPath:       "/",
+			inputMethods:    []string{"GET"},
+			inputConditions: &dto.PolicyConditionsDTO{SourceCIDRs: []string{"10.0.0.0/8"}},
+			expectResult: &dto.PolicyDTO{
+				ID:         policy.ID,
+				UserID:     policy.UserID,
+				Name:       policy.Name,
+				Effect:     policy.Effect,
+				Service:    policy.Service,
+				Path:       policy.Path,
+				Methods:    policy.Methods,
+				Conditions: &dto.PolicyConditionsDTO{TimeZone: "UTC", DaysOfWeek: []string{}, SourceCIDRs: []string{"10.0.0.0/8"}, Attributes: map[string][]string{}, PathParams: map[string]string{}},
+				Agents:     []uuid.UUID{},
+				CreatedAt:  policy.CreatedAt,
+				UpdatedAt:  policy.UpdatedAt,
+			},
+			expectError: nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+				pr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                           "invalid conditions",
+			inputUserID:                    policy.UserID,
+			inputName:                      "name",
+			inputEffect:                    "ALLOW",
+			inputService:                   "STORAGE",
+			inputPath:                      "/",
+			inputMethods:                   []string{"GET"},
+			inputConditions:                &dto.PolicyConditionsDTO{SourceCIDRs: []string{"10.0.0.1"}},
+			expectResult:                   nil,
+			expectError:                    entity.ErrInvalidPolicyConditionsSourceCIDRs,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockPolicyRepository:        func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+		{
+			name:            "success with validity",
+			inputUserID:     policy.UserID,
+			inputName:       "name",
+			inputEffect:     "ALLOW",
+			inputService:    "STORAGE",
+			inputPath:       "/",
+			inputMethods:    []string{"GET"},
+			inputValidFrom:  &validFrom,
+			inputValidUntil: &validUntil,
+			expectResult:    &dto.PolicyDTO{ID: policy.ID, UserID: policy.UserID, Name: policy.Name, Effect: policy.Effect, Service: policy.Service, Path: policy.Path, Methods: policy.Methods, ValidFrom: &validFrom, ValidUntil: &validUntil, Agents: []uuid.UUID{}, CreatedAt: policy.CreatedAt, UpdatedAt: policy.UpdatedAt},
+			expectError:     nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+				pr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "success with expression",
+			inputUserID:     policy.UserID,
+			inputName:       "name",
+			inputEffect:     "ALLOW",
+			inputService:    "STORAGE",
+			inputPath:       "/",
+			inputMethods:    []string{"GET"},
+			inputExpression: `attributes["tenant"] == "holos"`,
+			expectResult:    &dto.PolicyDTO{ID: policy.ID, UserID: policy.UserID, Name: policy.Name, Effect: policy.Effect, Service: policy.Service, Path: policy.Path, Methods: policy.Methods, Expression: `attributes["tenant"] == "holos"`, Agents: []uuid.UUID{}, CreatedAt: policy.CreatedAt, UpdatedAt: policy.UpdatedAt},
+			expectError:     nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+				pr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                           "invalid expression",
+			inputUserID:                    policy.UserID,
+			inputName:                      "name",
+			inputEffect:                    "ALLOW",
+			inputService:                   "STORAGE",
+			inputPath:                      "/",
+			inputMethods:                   []string{"GET"},
+			inputExpression:                strings.Repeat("a", 4097),
+			expectResult:                   nil,
+			expectError:                    entity.ErrPolicyExpressionTooLong,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockPolicyRepository:        func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+		{
+			name:                           "invalid validity",
+			inputUserID:                    policy.UserID,
+			inputName:                      "name",
+			inputEffect:                    "ALLOW",
+			inputService:                   "STORAGE",
+			inputPath:                      "/",
+			inputMethods:                   []string{"GET"},
+			inputValidFrom:                 &validUntil,
+			inputValidUntil:                &validFrom,
+			expectResult:                   nil,
+			expectError:                    entity.ErrInvalidPolicyPeriod,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockPolicyRepository:        func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+		{
+			name:         "already exists",
+			inputUserID:  policy.UserID,
+			inputName:    "name",
+			inputEffect:  "ALLOW",
+			inputService: "STORAGE",
+			inputPath:    "/",
+			inputMethods: []string{"GET"},
+			expectResult: nil,
+			expectError:  usecase.ErrPolicyAlreadyExists,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "name", policy.UserID).
+					Return(policy, nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+		{
+			name:         "service not registered",
+			inputUserID:  policy.UserID,
+			inputName:    "name",
+			inputEffect:  "ALLOW",
+			inputService: "BUILD_CACHE",
+			inputPath:    "/",
+			inputMethods: []string{"GET"},
+			expectResult: nil,
+			expectError:  service.ErrServiceNotRegistered,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(service.ErrServiceNotRegistered).
+					Times(1)
+			},
 		},
 		{
 			name:         "create error",
@@ -123,12 +421,31 @@ func TestPolicy_Create(t *testing.T) {
 			inputMethods: []string{"GET"},
 			expectResult: nil,
 			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
 				pr.EXPECT().
 					Create(ctx, gomock.Any()).
 					Return(sql.ErrConnDone).
 					Times(1)
 			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -136,14 +453,20 @@ func TestPolicy_Create(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
+			to := mockDomain.NewMockTransactionObject(ctrl)
 			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			pvr := mockRepository.NewMockPolicyVersionRepository(ctrl)
+			ps := mockService.NewMockPolicyService(ctrl)
 
 			ctx := context.Background()
 
+			tt.setMockTransactionObject(ctx, to)
 			tt.setMockPolicyRepository(ctx, pr)
+			tt.setMockPolicyVersionRepository(ctx, pvr)
+			tt.setMockPolicyService(ctx, ps)
 
-			pu := usecase.NewPolicyUsecase(nil, pr, nil, nil)
-			result, err := pu.Create(ctx, tt.inputUserID, tt.inputName, tt.inputEffect, tt.inputService, tt.inputPath, tt.inputMethods)
+			pu := usecase.NewPolicyUsecase(to, pr, pvr, nil, ps, nil)
+			result, err := pu.Create(ctx, tt.inputUserID, tt.inputName, tt.inputEffect, tt.inputService, tt.inputPath, tt.inputMethods, tt.inputConditions, tt.inputExpression, tt.inputValidFrom, tt.inputValidUntil)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -164,18 +487,24 @@ func TestPolicy_Update(t *testing.T) {
 	}
 
 	tests := []struct {
-		name                     string
-		inputID                  uuid.UUID
-		inputUserID              uuid.UUID
-		inputName                string
-		inputEffect              string
-		inputService             string
-		inputPath                string
-		inputMethods             []string
-		expectResult             *dto.PolicyDTO
-		expectError              error
-		setMockTransactionObject func(context.Context, *mockDomain.MockTransactionObject)
-		setMockPolicyRepository  func(context.Context, *mockRepository.MockPolicyRepository)
+		name                           string
+		inputID                        uuid.UUID
+		inputUserID                    uuid.UUID
+		inputName                      string
+		inputEffect                    string
+		inputService                   string
+		inputPath                      string
+		inputMethods                   []string
+		inputConditions                *dto.PolicyConditionsDTO
+		inputExpression                string
+		inputValidFrom                 *time.Time
+		inputValidUntil                *time.Time
+		expectResult                   *dto.PolicyDTO
+		expectError                    error
+		setMockTransactionObject       func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPolicyRepository        func(context.Context, *mockRepository.MockPolicyRepository)
+		setMockPolicyVersionRepository func(context.Context, *mockRepository.MockPolicyVersionRepository)
+		setMockPolicyService           func(context.Context, *mockService.MockPolicyService)
 	}{
 		{
 			name:         "success",
@@ -198,14 +527,34 @@ func TestPolicy_Update(t *testing.T) {
 			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
-					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					FindOneByNameAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+				pr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
 					Times(1)
 				pr.EXPECT().
 					Update(ctx, gomock.Any()).
 					Return(nil).
 					Times(1)
 			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindOneLatestByPolicyID(ctx, policy.ID).
+					Return(nil, nil).
+					Times(1)
+				pvr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
 		},
 		{
 			name:         "invalid name",
@@ -228,10 +577,12 @@ func TestPolicy_Update(t *testing.T) {
 			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
-					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
 					Times(1)
 			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
 		},
 		{
 			name:         "invalid effect",
@@ -254,10 +605,12 @@ func TestPolicy_Update(t *testing.T) {
 			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
-					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
 					Times(1)
 			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
 		},
 		{
 			name:         "invalid service",
@@ -265,7 +618,7 @@ func TestPolicy_Update(t *testing.T) {
 			inputUserID:  policy.UserID,
 			inputName:    "update",
 			inputEffect:  "DENY",
-			inputService: "SERVICE",
+			inputService: "service",
 			inputPath:    "/path",
 			inputMethods: []string{"PUT"},
 			expectResult: nil,
@@ -280,10 +633,12 @@ func TestPolicy_Update(t *testing.T) {
 			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
-					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
 					Times(1)
 			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
 		},
 		{
 			name:         "invalid path",
@@ -306,10 +661,12 @@ func TestPolicy_Update(t *testing.T) {
 			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
-					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
 					Times(1)
 			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
 		},
 		{
 			name:         "invalid methods",
@@ -319,7 +676,7 @@ func TestPolicy_Update(t *testing.T) {
 			inputEffect:  "DENY",
 			inputService: "CONTENT",
 			inputPath:    "/path",
-			inputMethods: []string{"PATCH"},
+			inputMethods: []string{"TRACE"},
 			expectResult: nil,
 			expectError:  entity.ErrInvalidPolicyMethods,
 			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
@@ -332,10 +689,41 @@ func TestPolicy_Update(t *testing.T) {
 			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
-					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+		{
+			name:            "invalid conditions",
+			inputID:         policy.ID,
+			inputUserID:     policy.UserID,
+			inputName:       "update",
+			inputEffect:     "DENY",
+			inputService:    "CONTENT",
+			inputPath:       "/path",
+			inputMethods:    []string{"PUT"},
+			inputConditions: &dto.PolicyConditionsDTO{TimeZone: "Asia/Nowhere"},
+			expectResult:    nil,
+			expectError:     entity.ErrInvalidPolicyConditionsTimeZone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
 					Times(1)
 			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
 		},
 		{
 			name:         "policy not found",
@@ -358,10 +746,12 @@ func TestPolicy_Update(t *testing.T) {
 			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
-					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
 					Return(nil, nil).
 					Times(1)
 			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
 		},
 		{
 			name:         "find error",
@@ -384,22 +774,24 @@ func TestPolicy_Update(t *testing.T) {
 			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
-					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
 					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
 		},
 		{
-			name:         "update error",
+			name:         "service not registered",
 			inputID:      policy.ID,
 			inputUserID:  policy.UserID,
 			inputName:    "update",
 			inputEffect:  "DENY",
-			inputService: "CONTENT",
+			inputService: "BUILD_CACHE",
 			inputPath:    "/path",
 			inputMethods: []string{"PUT"},
 			expectResult: nil,
-			expectError:  sql.ErrConnDone,
+			expectError:  service.ErrServiceNotRegistered,
 			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
 				to.EXPECT().
 					Transaction(ctx, gomock.Any()).
@@ -410,32 +802,84 @@ func TestPolicy_Update(t *testing.T) {
 			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
-					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					FindOneByNameAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return(nil, nil).
 					Times(1)
 				pr.EXPECT().
-					Update(ctx, gomock.Any()).
-					Return(sql.ErrConnDone).
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(policy, nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(service.ErrServiceNotRegistered).
 					Times(1)
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			to := mockDomain.NewMockTransactionObject(ctrl)
-			pr := mockRepository.NewMockPolicyRepository(ctrl)
-
-			ctx := context.Background()
-
-			tt.setMockTransactionObject(ctx, to)
-			tt.setMockPolicyRepository(ctx, pr)
-
-			pu := usecase.NewPolicyUsecase(to, pr, nil, nil)
-			result, err := pu.Update(ctx, tt.inputID, tt.inputUserID, tt.inputName, tt.inputEffect, tt.inputService, tt.inputPath, tt.inputMethods)
-			if !errors.Is(err, tt.expectError) {
+		{
+			name:         "update error",
+			inputID:      policy.ID,
+			inputUserID:  policy.UserID,
+			inputName:    "update",
+			inputEffect:  "DENY",
+			inputService: "CONTENT",
+			inputPath:    "/path",
+			inputMethods: []string{"PUT"},
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+				pr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					Times(1)
+				pr.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			pvr := mockRepository.NewMockPolicyVersionRepository(ctrl)
+			ps := mockService.NewMockPolicyService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockPolicyRepository(ctx, pr)
+			tt.setMockPolicyVersionRepository(ctx, pvr)
+			tt.setMockPolicyService(ctx, ps)
+
+			pu := usecase.NewPolicyUsecase(to, pr, pvr, nil, ps, nil)
+			result, err := pu.Update(ctx, tt.inputID, tt.inputUserID, tt.inputName, tt.inputEffect, tt.inputService, tt.inputPath, tt.inputMethods, tt.inputConditions, tt.inputExpression, tt.inputValidFrom, tt.inputValidUntil)
+			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
 			opts := cmp.Options{
@@ -455,12 +899,13 @@ func TestPolicy_Delete(t *testing.T) {
 	}
 
 	tests := []struct {
-		name                     string
-		inputID                  uuid.UUID
-		inputUserID              uuid.UUID
-		expectError              error
-		setMockTransactionObject func(context.Context, *mockDomain.MockTransactionObject)
-		setMockPolicyRepository  func(context.Context, *mockRepository.MockPolicyRepository)
+		name                           string
+		inputID                        uuid.UUID
+		inputUserID                    uuid.UUID
+		expectError                    error
+		setMockTransactionObject       func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPolicyRepository        func(context.Context, *mockRepository.MockPolicyRepository)
+		setMockPolicyVersionRepository func(context.Context, *mockRepository.MockPolicyVersionRepository)
 	}{
 		{
 			name:        "success",
@@ -477,14 +922,24 @@ func TestPolicy_Delete(t *testing.T) {
 			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
-					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
 					Times(1)
 				pr.EXPECT().
 					Delete(ctx, gomock.Any()).
 					Return(nil).
 					Times(1)
 			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindOneLatestByPolicyID(ctx, policy.ID).
+					Return(nil, nil).
+					Times(1)
+				pvr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
 		},
 		{
 			name:        "policy not found",
@@ -501,10 +956,11 @@ func TestPolicy_Delete(t *testing.T) {
 			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
-					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
 					Return(nil, nil).
 					Times(1)
 			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
 		},
 		{
 			name:        "find error",
@@ -521,10 +977,11 @@ func TestPolicy_Delete(t *testing.T) {
 			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
-					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
 					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
 		},
 		{
 			name:        "delete error",
@@ -541,14 +998,15 @@ func TestPolicy_Delete(t *testing.T) {
 			},
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
-					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
 					Times(1)
 				pr.EXPECT().
 					Delete(ctx, gomock.Any()).
 					Return(sql.ErrConnDone).
 					Times(1)
 			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
 		},
 	}
 	for _, tt := range tests {
@@ -558,13 +1016,15 @@ func TestPolicy_Delete(t *testing.T) {
 
 			to := mockDomain.NewMockTransactionObject(ctrl)
 			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			pvr := mockRepository.NewMockPolicyVersionRepository(ctrl)
 
 			ctx := context.Background()
 
 			tt.setMockTransactionObject(ctx, to)
 			tt.setMockPolicyRepository(ctx, pr)
+			tt.setMockPolicyVersionRepository(ctx, pvr)
 
-			pu := usecase.NewPolicyUsecase(to, pr, nil, nil)
+			pu := usecase.NewPolicyUsecase(to, pr, pvr, nil, nil, nil)
 			if err := pu.Delete(ctx, tt.inputID, tt.inputUserID); !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -595,7 +1055,7 @@ func TestPolicy_Get(t *testing.T) {
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
 					Times(1)
 			},
 		},
@@ -637,7 +1097,7 @@ func TestPolicy_Get(t *testing.T) {
 
 			tt.setMockPolicyRepository(ctx, pr)
 
-			pu := usecase.NewPolicyUsecase(nil, pr, nil, nil)
+			pu := usecase.NewPolicyUsecase(nil, pr, nil, nil, nil, nil)
 			result, err := pu.Get(ctx, tt.inputID, tt.inputUserID)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -675,7 +1135,7 @@ func TestPolicy_Gets(t *testing.T) {
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
 					FindByNamePrefixAndUserIDAndNotDeleted(ctx, gomock.Any(), policy.UserID).
-					Return([]*entity.Policy{entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt)}, nil).
+					Return([]*entity.Policy{entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt)}, nil).
 					Times(1)
 			},
 		},
@@ -717,7 +1177,7 @@ func TestPolicy_Gets(t *testing.T) {
 
 			tt.setMockPolicyRepository(ctx, pr)
 
-			pu := usecase.NewPolicyUsecase(nil, pr, nil, nil)
+			pu := usecase.NewPolicyUsecase(nil, pr, nil, nil, nil, nil)
 			result, err := pu.Gets(ctx, tt.inputKeyword, tt.inputUserID)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -768,7 +1228,7 @@ func TestPolicy_UpdateAgents(t *testing.T) {
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
 					Times(1)
 				pr.EXPECT().
 					Update(ctx, gomock.Any()).
@@ -778,7 +1238,7 @@ func TestPolicy_UpdateAgents(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), agent.UserID).
-					Return([]*entity.Agent{entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt)}, nil).
+					Return([]*entity.Agent{entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt)}, nil).
 					Times(1)
 			},
 		},
@@ -846,7 +1306,7 @@ func TestPolicy_UpdateAgents(t *testing.T) {
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
 					Times(1)
 			},
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
@@ -874,7 +1334,7 @@ func TestPolicy_UpdateAgents(t *testing.T) {
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
 					Times(1)
 				pr.EXPECT().
 					Update(ctx, gomock.Any()).
@@ -884,7 +1344,7 @@ func TestPolicy_UpdateAgents(t *testing.T) {
 			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
 				ar.EXPECT().
 					FindByIDsAndUserIDAndNotDeleted(ctx, gomock.Any(), agent.UserID).
-					Return([]*entity.Agent{entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt)}, nil).
+					Return([]*entity.Agent{entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt)}, nil).
 					Times(1)
 			},
 		},
@@ -904,7 +1364,7 @@ func TestPolicy_UpdateAgents(t *testing.T) {
 			tt.setMockPolicyRepository(ctx, pr)
 			tt.setMockAgentRepository(ctx, ar)
 
-			pu := usecase.NewPolicyUsecase(to, pr, ar, nil)
+			pu := usecase.NewPolicyUsecase(to, pr, nil, ar, nil, nil)
 			result, err := pu.UpdateAgents(ctx, tt.inputID, tt.inputUserID, tt.inputAgentIDs)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -955,13 +1415,13 @@ func TestPolicy_GetAgents(t *testing.T) {
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
 					Times(1)
 			},
 			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
 				ps.EXPECT().
 					GetAgents(ctx, gomock.Any(), gomock.Any()).
-					Return([]*entity.Agent{entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.CreatedAt, agent.UpdatedAt)}, nil).
+					Return([]*entity.Agent{entity.RestoreAgent(agent.ID, agent.UserID, agent.Name, agent.Policies, agent.Groups, agent.Roles, agent.CreatedAt, agent.UpdatedAt)}, nil).
 					Times(1)
 			},
 		},
@@ -1006,7 +1466,7 @@ func TestPolicy_GetAgents(t *testing.T) {
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
 					Times(1)
 			},
 			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
@@ -1057,7 +1517,7 @@ func TestPolicy_GetAgents(t *testing.T) {
 			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
 				pr.EXPECT().
 					FindOneByIDAndUserIDAndNotDeleted(ctx, policy.ID, policy.UserID).
-					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
 					Times(1)
 			},
 			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
@@ -1083,7 +1543,7 @@ func TestPolicy_GetAgents(t *testing.T) {
 			tt.setMockPolicyRepository(ctx, pr)
 			tt.setMockPolicyService(ctx, ps)
 
-			pu := usecase.NewPolicyUsecase(to, pr, nil, ps)
+			pu := usecase.NewPolicyUsecase(to, pr, nil, nil, ps, nil)
 			result, err := pu.GetAgents(ctx, tt.inputID, tt.inputUserID, tt.inputKeyword)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -1094,3 +1554,1019 @@ func TestPolicy_GetAgents(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicy_GetVersions(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyVersion := entity.NewPolicyVersion(policy, nil, entity.PolicyVersionOperationCreate, policy.UserID)
+
+	tests := []struct {
+		name                           string
+		expectResult                   []*dto.PolicyVersionDTO
+		expectError                    error
+		setMockPolicyVersionRepository func(context.Context, *mockRepository.MockPolicyVersionRepository)
+	}{
+		{
+			name:         "success",
+			expectResult: []*dto.PolicyVersionDTO{{PolicyID: policy.ID, Version: 1, UserID: policy.UserID, AuthorID: policy.UserID, Operation: "CREATE", Name: policy.Name, Effect: policy.Effect, Service: policy.Service, Path: policy.Path, Methods: policy.Methods, CreatedAt: policyVersion.CreatedAt}},
+			expectError:  nil,
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindByPolicyIDAndUserID(ctx, policy.ID, policy.UserID).
+					Return([]*entity.PolicyVersion{policyVersion}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "policy not found",
+			expectResult: nil,
+			expectError:  usecase.ErrPolicyNotFound,
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindByPolicyIDAndUserID(ctx, policy.ID, policy.UserID).
+					Return([]*entity.PolicyVersion{}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindByPolicyIDAndUserID(ctx, policy.ID, policy.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			pvr := mockRepository.NewMockPolicyVersionRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockPolicyVersionRepository(ctx, pvr)
+
+			pu := usecase.NewPolicyUsecase(nil, nil, pvr, nil, nil, nil)
+			result, err := pu.GetVersions(ctx, policy.ID, policy.UserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicy_DiffVersions(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	fromVersion := entity.NewPolicyVersion(policy, nil, entity.PolicyVersionOperationCreate, policy.UserID)
+	if err := policy.SetEffect("DENY"); err != nil {
+		t.Error(err.Error())
+	}
+	toVersion := entity.NewPolicyVersion(policy, fromVersion, entity.PolicyVersionOperationUpdate, policy.UserID)
+
+	tests := []struct {
+		name                           string
+		expectChanges                  []string
+		expectError                    error
+		setMockPolicyVersionRepository func(context.Context, *mockRepository.MockPolicyVersionRepository)
+	}{
+		{
+			name:          "success",
+			expectChanges: []string{"effect"},
+			expectError:   nil,
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindOneByPolicyIDAndVersionAndUserID(ctx, policy.ID, 1, policy.UserID).
+					Return(fromVersion, nil).
+					Times(1)
+				pvr.EXPECT().
+					FindOneByPolicyIDAndVersionAndUserID(ctx, policy.ID, 2, policy.UserID).
+					Return(toVersion, nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "from version not found",
+			expectChanges: nil,
+			expectError:   usecase.ErrPolicyVersionNotFound,
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindOneByPolicyIDAndVersionAndUserID(ctx, policy.ID, 1, policy.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "to version not found",
+			expectChanges: nil,
+			expectError:   usecase.ErrPolicyVersionNotFound,
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindOneByPolicyIDAndVersionAndUserID(ctx, policy.ID, 1, policy.UserID).
+					Return(fromVersion, nil).
+					Times(1)
+				pvr.EXPECT().
+					FindOneByPolicyIDAndVersionAndUserID(ctx, policy.ID, 2, policy.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "find error",
+			expectChanges: nil,
+			expectError:   sql.ErrConnDone,
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindOneByPolicyIDAndVersionAndUserID(ctx, policy.ID, 1, policy.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			pvr := mockRepository.NewMockPolicyVersionRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockPolicyVersionRepository(ctx, pvr)
+
+			pu := usecase.NewPolicyUsecase(nil, nil, pvr, nil, nil, nil)
+			result, err := pu.DiffVersions(ctx, policy.ID, policy.UserID, 1, 2)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if err == nil {
+				if diff := cmp.Diff(result.Changes, tt.expectChanges); diff != "" {
+					t.Error(diff)
+				}
+			}
+		})
+	}
+}
+
+func TestPolicy_RestoreVersion(t *testing.T) {
+	policy, err := entity.NewPolicy(uuid.New(), "name", "ALLOW", "STORAGE", "/", []string{"GET"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	policyVersion := entity.RestorePolicyVersion(policy.ID, 1, policy.UserID, policy.UserID, entity.PolicyVersionOperationCreate, "old_name", "DENY", "CONTENT", "/path", []string{"PUT"}, nil, "", nil, nil, policy.CreatedAt)
+
+	tests := []struct {
+		name                           string
+		expectResult                   *dto.PolicyDTO
+		expectError                    error
+		setMockTransactionObject       func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPolicyRepository        func(context.Context, *mockRepository.MockPolicyRepository)
+		setMockPolicyVersionRepository func(context.Context, *mockRepository.MockPolicyVersionRepository)
+		setMockPolicyService           func(context.Context, *mockService.MockPolicyService)
+	}{
+		{
+			name:         "success",
+			expectResult: &dto.PolicyDTO{ID: policy.ID, UserID: policy.UserID, Name: "old_name", Effect: "DENY", Service: "CONTENT", Path: "/path", Methods: []string{"PUT"}, Agents: []uuid.UUID{}, CreatedAt: policy.CreatedAt, UpdatedAt: policy.UpdatedAt},
+			expectError:  nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+				pr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					Times(1)
+				pr.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindOneByPolicyIDAndVersionAndUserID(ctx, policy.ID, 1, policy.UserID).
+					Return(policyVersion, nil).
+					Times(1)
+				pvr.EXPECT().
+					FindOneLatestByPolicyID(ctx, policy.ID).
+					Return(entity.NewPolicyVersion(policy, policyVersion, entity.PolicyVersionOperationUpdate, policy.UserID), nil).
+					Times(1)
+				pvr.EXPECT().
+					Create(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, policyVersion *entity.PolicyVersion) error {
+						if policyVersion.Version != 3 || policyVersion.Operation != entity.PolicyVersionOperationRestore {
+							t.Errorf("unexpected policy version: %d %s", policyVersion.Version, policyVersion.Operation)
+						}
+						return nil
+					}).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "policy not found",
+			expectResult: nil,
+			expectError:  usecase.ErrPolicyNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+		{
+			name:         "policy version not found",
+			expectResult: nil,
+			expectError:  usecase.ErrPolicyVersionNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindOneByPolicyIDAndVersionAndUserID(ctx, policy.ID, 1, policy.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+		{
+			name:         "create version error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+				pr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeletedForUpdate(ctx, policy.ID, policy.UserID).
+					Return(entity.RestorePolicy(policy.ID, policy.UserID, policy.Name, policy.Effect, policy.Service, policy.Path, policy.Methods, policy.Conditions, policy.Expression, nil, nil, policy.Agents, policy.CreatedAt, policy.UpdatedAt), nil).
+					Times(1)
+				pr.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindOneByPolicyIDAndVersionAndUserID(ctx, policy.ID, 1, policy.UserID).
+					Return(policyVersion, nil).
+					Times(1)
+				pvr.EXPECT().
+					FindOneLatestByPolicyID(ctx, policy.ID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			pvr := mockRepository.NewMockPolicyVersionRepository(ctrl)
+			ps := mockService.NewMockPolicyService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockPolicyRepository(ctx, pr)
+			tt.setMockPolicyVersionRepository(ctx, pvr)
+			tt.setMockPolicyService(ctx, ps)
+
+			pu := usecase.NewPolicyUsecase(to, pr, pvr, nil, ps, nil)
+			result, err := pu.RestoreVersion(ctx, policy.ID, policy.UserID, 1)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(dto.PolicyDTO{}, "UpdatedAt"),
+			}
+			if diff := cmp.Diff(result, tt.expectResult, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicy_Export(t *testing.T) {
+	userID := uuid.New()
+	policy := entity.RestorePolicy(uuid.New(), userID, "policy", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now())
+	agent := entity.RestoreAgent(uuid.New(), userID, "agent", []uuid.UUID{policy.ID}, nil, nil, time.Now(), time.Now())
+
+	tests := []struct {
+		name                    string
+		expectResult            *dto.PolicyDocumentDTO
+		expectError             error
+		setMockPolicyRepository func(context.Context, *mockRepository.MockPolicyRepository)
+		setMockAgentRepository  func(context.Context, *mockRepository.MockAgentRepository)
+	}{
+		{
+			name: "success",
+			expectResult: &dto.PolicyDocumentDTO{
+				Version:  entity.PolicyDocumentVersion,
+				Policies: []*dto.PolicyDocumentPolicyDTO{{Name: "policy", Effect: "ALLOW", Service: "STORAGE", Path: "/", Methods: []string{"GET"}}},
+				Agents:   []*dto.PolicyDocumentAgentDTO{{Name: "agent"}},
+				Bindings: []*dto.PolicyBindingDTO{{Agent: "agent", Policies: []string{"policy"}}},
+			},
+			expectError: nil,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return([]*entity.Policy{policy}, nil).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return([]*entity.Agent{agent}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "find policies error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+		},
+		{
+			name:         "find agents error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return([]*entity.Policy{policy}, nil).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockPolicyRepository(ctx, pr)
+			tt.setMockAgentRepository(ctx, ar)
+
+			pu := usecase.NewPolicyUsecase(nil, pr, nil, ar, nil, nil)
+			result, err := pu.Export(ctx, userID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicy_Import(t *testing.T) {
+	userID := uuid.New()
+	newExistingPolicies := func() []*entity.Policy {
+		return []*entity.Policy{
+			entity.RestorePolicy(uuid.New(), userID, "policy1", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, []uuid.UUID{}, time.Now(), time.Now()),
+			entity.RestorePolicy(uuid.New(), userID, "other", "ALLOW", "STORAGE", "/", []string{"GET"}, nil, "", nil, nil, []uuid.UUID{}, time.Now(), time.Now()),
+		}
+	}
+	newExistingAgents := func() []*entity.Agent {
+		return []*entity.Agent{
+			entity.RestoreAgent(uuid.New(), userID, "agent1", []uuid.UUID{}, nil, nil, time.Now(), time.Now()),
+			entity.RestoreAgent(uuid.New(), userID, "other_agent", []uuid.UUID{}, nil, nil, time.Now(), time.Now()),
+		}
+	}
+	document := &dto.PolicyDocumentDTO{
+		Version: entity.PolicyDocumentVersion,
+		Policies: []*dto.PolicyDocumentPolicyDTO{
+			{Name: "policy1", Effect: "DENY", Service: "STORAGE", Path: "/", Methods: []string{"GET"}},
+			{Name: "policy2", Effect: "ALLOW", Service: "STORAGE", Path: "/files/*", Methods: []string{"POST"}},
+		},
+		Agents:   []*dto.PolicyDocumentAgentDTO{{Name: "agent1"}, {Name: "agent2"}},
+		Bindings: []*dto.PolicyBindingDTO{{Agent: "agent1", Policies: []string{"policy1", "policy2"}}},
+	}
+
+	tests := []struct {
+		name                           string
+		inputDocument                  *dto.PolicyDocumentDTO
+		inputMode                      string
+		expectPolicies                 []string
+		expectAgents                   []string
+		expectBindings                 []*dto.PolicyBindingDTO
+		expectError                    error
+		setMockTransactionObject       func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPolicyRepository        func(context.Context, *mockRepository.MockPolicyRepository)
+		setMockPolicyVersionRepository func(context.Context, *mockRepository.MockPolicyVersionRepository)
+		setMockAgentRepository         func(context.Context, *mockRepository.MockAgentRepository)
+		setMockPolicyService           func(context.Context, *mockService.MockPolicyService)
+	}{
+		{
+			name:           "merge",
+			inputDocument:  document,
+			inputMode:      "",
+			expectPolicies: []string{"policy1", "policy2", "other"},
+			expectAgents:   []string{"agent1", "agent2", "other_agent"},
+			expectBindings: []*dto.PolicyBindingDTO{{Agent: "agent1", Policies: []string{"policy1", "policy2"}}},
+			expectError:    nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(newExistingPolicies(), nil).
+					Times(1)
+				pr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+				pr.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindOneLatestByPolicyID(ctx, gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+				pvr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(2)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(newExistingAgents(), nil).
+					Times(1)
+				ar.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+				ar.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(nil).
+					Times(2)
+			},
+		},
+		{
+			name:           "replace",
+			inputDocument:  document,
+			inputMode:      entity.PolicyImportModeReplace,
+			expectPolicies: []string{"policy1", "policy2"},
+			expectAgents:   []string{"agent1", "agent2"},
+			expectBindings: []*dto.PolicyBindingDTO{{Agent: "agent1", Policies: []string{"policy1", "policy2"}}},
+			expectError:    nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(newExistingPolicies(), nil).
+					Times(1)
+				pr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+				pr.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+				pr.EXPECT().
+					Delete(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {
+				pvr.EXPECT().
+					FindOneLatestByPolicyID(ctx, gomock.Any()).
+					Return(nil, nil).
+					Times(2)
+				pvr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(3)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(newExistingAgents(), nil).
+					Times(1)
+				ar.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+				ar.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+				ar.EXPECT().
+					Delete(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(nil).
+					Times(2)
+			},
+		},
+		{
+			name:                           "invalid mode",
+			inputDocument:                  document,
+			inputMode:                      "mode",
+			expectError:                    entity.ErrInvalidPolicyImportMode,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockPolicyRepository:        func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockAgentRepository:         func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+		{
+			name:                           "unsupported version",
+			inputDocument:                  &dto.PolicyDocumentDTO{Version: 2},
+			inputMode:                      entity.PolicyImportModeMerge,
+			expectError:                    entity.ErrUnsupportedPolicyDocumentVersion,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockPolicyRepository:        func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockAgentRepository:         func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+		{
+			name: "invalid policy",
+			inputDocument: &dto.PolicyDocumentDTO{
+				Version:  entity.PolicyDocumentVersion,
+				Policies: []*dto.PolicyDocumentPolicyDTO{{Name: "policy1", Effect: "EFFECT", Service: "STORAGE", Path: "/", Methods: []string{"GET"}}},
+			},
+			inputMode:                      entity.PolicyImportModeMerge,
+			expectError:                    entity.ErrInvalidPolicyEffect,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockPolicyRepository:        func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockAgentRepository:         func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+		{
+			name:          "service not registered",
+			inputDocument: document,
+			inputMode:     entity.PolicyImportModeMerge,
+			expectError:   service.ErrServiceNotRegistered,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(newExistingPolicies(), nil).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(newExistingAgents(), nil).
+					Times(1)
+			},
+			setMockPolicyService: func(ctx context.Context, ps *mockService.MockPolicyService) {
+				ps.EXPECT().
+					ValidateService(ctx, gomock.Any()).
+					Return(service.ErrServiceNotRegistered).
+					Times(1)
+			},
+		},
+		{
+			name:          "find error",
+			inputDocument: document,
+			inputMode:     entity.PolicyImportModeMerge,
+			expectError:   sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockPolicyVersionRepository: func(ctx context.Context, pvr *mockRepository.MockPolicyVersionRepository) {},
+			setMockAgentRepository:         func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockPolicyService:           func(ctx context.Context, ps *mockService.MockPolicyService) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			pvr := mockRepository.NewMockPolicyVersionRepository(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			ps := mockService.NewMockPolicyService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockPolicyRepository(ctx, pr)
+			tt.setMockPolicyVersionRepository(ctx, pvr)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockPolicyService(ctx, ps)
+
+			pu := usecase.NewPolicyUsecase(to, pr, pvr, ar, ps, nil)
+			result, err := pu.Import(ctx, userID, tt.inputDocument, tt.inputMode)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if err != nil {
+				return
+			}
+			policies := make([]string, len(result.Policies))
+			for i, policy := range result.Policies {
+				policies[i] = policy.Name
+			}
+			if diff := cmp.Diff(tt.expectPolicies, policies); diff != "" {
+				t.Error(diff)
+			}
+			agents := make([]string, len(result.Agents))
+			for i, agent := range result.Agents {
+				agents[i] = agent.Name
+			}
+			if diff := cmp.Diff(tt.expectAgents, agents); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tt.expectBindings, result.Bindings); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicy_Lint(t *testing.T) {
+	userID := uuid.New()
+	agent := entity.RestoreAgent(uuid.New(), userID, "agent", []uuid.UUID{}, nil, nil, time.Now(), time.Now())
+	allow := entity.RestorePolicy(uuid.New(), userID, "allow", "ALLOW", "STORAGE", "/files", []string{"GET"}, nil, "", nil, nil, nil, time.Now(), time.Now())
+	deny := entity.RestorePolicy(uuid.New(), userID, "deny", "DENY", "STORAGE", "/", []string{"*"}, nil, "", nil, nil, nil, time.Now(), time.Now())
+	unused := entity.RestorePolicy(uuid.New(), userID, "unused", "DENY", "STORAGE", "/images", []string{"*"}, nil, "", nil, nil, nil, time.Now(), time.Now())
+	allowAgent := entity.RestoreAgent(uuid.New(), userID, "allow_agent", []uuid.UUID{allow.ID}, nil, nil, time.Now(), time.Now())
+	denyAgent := entity.RestoreAgent(uuid.New(), userID, "deny_agent", []uuid.UUID{allow.ID, deny.ID}, nil, nil, time.Now(), time.Now())
+
+	tests := []struct {
+		name                     string
+		inputAgentID             uuid.UUID
+		expectResult             []*dto.PolicyLintFindingDTO
+		expectError              error
+		setMockTransactionObject func(context.Context, *mockDomain.MockTransactionObject)
+		setMockPolicyRepository  func(context.Context, *mockRepository.MockPolicyRepository)
+		setMockAgentRepository   func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentService      func(context.Context, *mockService.MockAgentService)
+	}{
+		{
+			name:         "all policies",
+			inputAgentID: uuid.Nil,
+			expectResult: []*dto.PolicyLintFindingDTO{
+				{Type: entity.PolicyLintTypeShadowed, Severity: entity.PolicyLintSeverityError, PolicyIDs: []uuid.UUID{allow.ID, deny.ID}, Message: "policy allow is always overridden by DENY policy deny"},
+				{Type: entity.PolicyLintTypeUnattached, Severity: entity.PolicyLintSeverityWarning, PolicyIDs: []uuid.UUID{unused.ID}, Message: "policy unused is not attached to any agent"},
+			},
+			expectError: nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return([]*entity.Policy{allow, deny, unused}, nil).
+					Times(1)
+				pr.EXPECT().
+					FindUnattachedByUserIDAndNotDeleted(ctx, userID).
+					Return([]*entity.Policy{unused}, nil).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return([]*entity.Agent{allowAgent, denyAgent}, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Expand(ctx, allowAgent).
+					Return(allowAgent, nil).
+					Times(1)
+				as.EXPECT().
+					Expand(ctx, denyAgent).
+					Return(denyAgent, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "policies of different agents",
+			inputAgentID: uuid.Nil,
+			expectResult: []*dto.PolicyLintFindingDTO{},
+			expectError:  nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return([]*entity.Policy{allow, deny}, nil).
+					Times(1)
+				pr.EXPECT().
+					FindUnattachedByUserIDAndNotDeleted(ctx, userID).
+					Return([]*entity.Policy{}, nil).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return([]*entity.Agent{
+						allowAgent,
+						entity.RestoreAgent(uuid.New(), userID, "other_agent", []uuid.UUID{deny.ID}, nil, nil, time.Now(), time.Now()),
+					}, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					Expand(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, agent *entity.Agent) (*entity.Agent, error) {
+						return agent, nil
+					}).
+					Times(2)
+			},
+		},
+		{
+			name:         "agent policies",
+			inputAgentID: agent.ID,
+			expectResult: []*dto.PolicyLintFindingDTO{
+				{Type: entity.PolicyLintTypeShadowed, Severity: entity.PolicyLintSeverityError, PolicyIDs: []uuid.UUID{allow.ID, deny.ID}, Message: "policy allow is always overridden by DENY policy deny"},
+			},
+			expectError: nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, userID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					GetEffectivePolicies(ctx, agent).
+					Return([]*entity.EffectivePolicy{
+						{Policy: allow, Sources: []*entity.PolicySource{{Type: entity.PolicySourceTypeDirect, ID: agent.ID, Name: agent.Name}}},
+						{Policy: deny, Sources: []*entity.PolicySource{{Type: entity.PolicySourceTypeDirect, ID: agent.ID, Name: agent.Name}}},
+					}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "find policies error",
+			inputAgentID: uuid.Nil,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentService:    func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:         "find unattached policies error",
+			inputAgentID: uuid.Nil,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {
+				pr.EXPECT().
+					FindByNamePrefixAndUserIDAndNotDeleted(ctx, "", userID).
+					Return([]*entity.Policy{allow, deny}, nil).
+					Times(1)
+				pr.EXPECT().
+					FindUnattachedByUserIDAndNotDeleted(ctx, userID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentService:    func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:         "agent not found",
+			inputAgentID: agent.ID,
+			expectResult: nil,
+			expectError:  usecase.ErrAgentNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, userID).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {},
+		},
+		{
+			name:         "get effective policies error",
+			inputAgentID: agent.ID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockPolicyRepository: func(ctx context.Context, pr *mockRepository.MockPolicyRepository) {},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, agent.ID, userID).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentService: func(ctx context.Context, as *mockService.MockAgentService) {
+				as.EXPECT().
+					GetEffectivePolicies(ctx, agent).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			pr := mockRepository.NewMockPolicyRepository(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			as := mockService.NewMockAgentService(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockPolicyRepository(ctx, pr)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentService(ctx, as)
+
+			pu := usecase.NewPolicyUsecase(to, pr, nil, ar, nil, as)
+			result, err := pu.Lint(ctx, tt.inputAgentID, userID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
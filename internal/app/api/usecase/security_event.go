@@ -0,0 +1,142 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../test/mock/usecase/$GOFILE
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"holos-auth-api/internal/app/api/domain"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	"holos-auth-api/internal/app/api/usecase/mapper"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SecurityEventUsecase interface {
+	ReportLeakedTokens(context.Context, string, string, string, []byte, []string) error
+	Gets(context.Context, uuid.UUID) ([]*dto.SecurityEventDTO, error)
+}
+
+type securityEventUsecase struct {
+	transactionObject       domain.TransactionObject
+	userTokenRepository     repository.UserTokenRepository
+	agentRepository         repository.AgentRepository
+	agentTokenRepository    repository.AgentTokenRepository
+	securityEventRepository repository.SecurityEventRepository
+	leakedTokenReporters    []*entity.LeakedTokenReporter
+}
+
+func NewSecurityEventUsecase(
+	transactionObject domain.TransactionObject,
+	userTokenRepository repository.UserTokenRepository,
+	agentRepository repository.AgentRepository,
+	agentTokenRepository repository.AgentTokenRepository,
+	securityEventRepository repository.SecurityEventRepository,
+	leakedTokenReporters []*entity.LeakedTokenReporter,
+) SecurityEventUsecase {
+	return &securityEventUsecase{
+		transactionObject:       transactionObject,
+		userTokenRepository:     userTokenRepository,
+		agentRepository:         agentRepository,
+		agentTokenRepository:    agentTokenRepository,
+		securityEventRepository: securityEventRepository,
+		leakedTokenReporters:    leakedTokenReporters,
+	}
+}
+
+func (u *securityEventUsecase) ReportLeakedTokens(ctx context.Context, keyID string, signature string, timestamp string, body []byte, tokens []string) error {
+	var reporter *entity.LeakedTokenReporter
+	for _, leakedTokenReporter := range u.leakedTokenReporters {
+		if leakedTokenReporter.KeyID == keyID {
+			reporter = leakedTokenReporter
+			break
+		}
+	}
+	if reporter == nil {
+		return entity.ErrInvalidLeakedTokenReport
+	}
+	if err := reporter.VerifySignature(signature, timestamp, body, time.Now()); err != nil {
+		return err
+	}
+	if entity.LeakedTokenReportMaxTokens < len(tokens) {
+		return entity.ErrTooManyLeakedTokens
+	}
+
+	// 報告者にトークンの有効性を明かさないため, 失効したかどうかは返さない.
+	return u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		for _, token := range tokens {
+			if err := u.revokeUserToken(ctx, reporter, token); err != nil {
+				return err
+			}
+			if err := u.revokeAgentToken(ctx, reporter, token); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (u *securityEventUsecase) Gets(ctx context.Context, userID uuid.UUID) ([]*dto.SecurityEventDTO, error) {
+	securityEvents, err := u.securityEventRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToSecurityEventDTOs(securityEvents), nil
+}
+
+func (u *securityEventUsecase) revokeUserToken(ctx context.Context, reporter *entity.LeakedTokenReporter, token string) error {
+	// 漏洩したトークンは旧形式であっても失効させる.
+	if err := entity.ValidateUserToken(token, time.Time{}, time.Now()); err != nil {
+		return nil
+	}
+
+	userToken, err := u.userTokenRepository.FindOneByTokenAndNotExpired(ctx, token)
+	if err != nil {
+		return err
+	}
+	if userToken == nil {
+		return nil
+	}
+	if err := u.userTokenRepository.Delete(ctx, userToken); err != nil {
+		return err
+	}
+
+	securityEvent, err := entity.NewSecurityEvent(userToken.UserID, entity.SecurityEventTypeUserTokenLeaked, fmt.Sprintf("reporter=%s", reporter.KeyID))
+	if err != nil {
+		return err
+	}
+	return u.securityEventRepository.Create(ctx, securityEvent)
+}
+
+func (u *securityEventUsecase) revokeAgentToken(ctx context.Context, reporter *entity.LeakedTokenReporter, token string) error {
+	if err := entity.ValidateAgentToken(token, time.Time{}, time.Now()); err != nil {
+		return nil
+	}
+
+	agent, err := u.agentRepository.FindOneByTokenAndNotDeleted(ctx, token)
+	if err != nil {
+		return err
+	}
+	if agent == nil {
+		return nil
+	}
+	agentToken, err := u.agentTokenRepository.FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID)
+	if err != nil {
+		return err
+	}
+	if agentToken == nil {
+		return nil
+	}
+	if err := u.agentTokenRepository.Delete(ctx, agentToken); err != nil {
+		return err
+	}
+
+	securityEvent, err := entity.NewSecurityEvent(agent.UserID, entity.SecurityEventTypeAgentTokenLeaked, fmt.Sprintf("reporter=%s agent_id=%s", reporter.KeyID, agent.ID))
+	if err != nil {
+		return err
+	}
+	return u.securityEventRepository.Create(ctx, securityEvent)
+}
@@ -0,0 +1,312 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/usecase"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	mockDomain "holos-auth-api/test/mock/domain"
+	mockRepository "holos-auth-api/test/mock/domain/repository"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestSecurityEvent_ReportLeakedTokens(t *testing.T) {
+	userToken, err := entity.NewUserToken(uuid.New())
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agent, err := entity.NewAgent(userToken.UserID, "name")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	agentToken, err := entity.NewAgentToken(agent.ID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	reporter := entity.NewLeakedTokenReporter("scanner", "secret")
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	body := []byte(`{"tokens": []}`)
+	signature := reporter.Sign(timestamp, body)
+
+	setMockTransactionObject := func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+		to.EXPECT().
+			Transaction(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}).
+			Times(1)
+	}
+
+	tests := []struct {
+		name                           string
+		inputKeyID                     string
+		inputSignature                 string
+		inputTokens                    []string
+		expectError                    error
+		setMockTransactionObject       func(context.Context, *mockDomain.MockTransactionObject)
+		setMockUserTokenRepository     func(context.Context, *mockRepository.MockUserTokenRepository)
+		setMockAgentRepository         func(context.Context, *mockRepository.MockAgentRepository)
+		setMockAgentTokenRepository    func(context.Context, *mockRepository.MockAgentTokenRepository)
+		setMockSecurityEventRepository func(context.Context, *mockRepository.MockSecurityEventRepository)
+	}{
+		{
+			name:                     "revoke user token",
+			inputKeyID:               reporter.KeyID,
+			inputSignature:           signature,
+			inputTokens:              []string{userToken.Token},
+			expectError:              nil,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
+				utr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, userToken.Token).
+					Return(userToken, nil).
+					Times(1)
+				utr.EXPECT().
+					Delete(ctx, userToken).
+					Return(nil).
+					Times(1)
+			},
+			setMockAgentRepository:      func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentTokenRepository: func(ctx context.Context, atr *mockRepository.MockAgentTokenRepository) {},
+			setMockSecurityEventRepository: func(ctx context.Context, ser *mockRepository.MockSecurityEventRepository) {
+				ser.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                       "revoke agent token",
+			inputKeyID:                 reporter.KeyID,
+			inputSignature:             signature,
+			inputTokens:                []string{agentToken.Token},
+			expectError:                nil,
+			setMockTransactionObject:   setMockTransactionObject,
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+			setMockAgentRepository: func(ctx context.Context, ar *mockRepository.MockAgentRepository) {
+				ar.EXPECT().
+					FindOneByTokenAndNotDeleted(ctx, agentToken.Token).
+					Return(agent, nil).
+					Times(1)
+			},
+			setMockAgentTokenRepository: func(ctx context.Context, atr *mockRepository.MockAgentTokenRepository) {
+				atr.EXPECT().
+					FindOneByAgentIDAndUserID(ctx, agent.ID, agent.UserID).
+					Return(agentToken, nil).
+					Times(1)
+				atr.EXPECT().
+					Delete(ctx, agentToken).
+					Return(nil).
+					Times(1)
+			},
+			setMockSecurityEventRepository: func(ctx context.Context, ser *mockRepository.MockSecurityEventRepository) {
+				ser.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                     "unknown token",
+			inputKeyID:               reporter.KeyID,
+			inputSignature:           signature,
+			inputTokens:              []string{userToken.Token},
+			expectError:              nil,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
+				utr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, userToken.Token).
+					Return(nil, nil).
+					Times(1)
+			},
+			setMockAgentRepository:         func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentTokenRepository:    func(ctx context.Context, atr *mockRepository.MockAgentTokenRepository) {},
+			setMockSecurityEventRepository: func(ctx context.Context, ser *mockRepository.MockSecurityEventRepository) {},
+		},
+		{
+			name:                           "malformed token",
+			inputKeyID:                     reporter.KeyID,
+			inputSignature:                 signature,
+			inputTokens:                    []string{"token"},
+			expectError:                    nil,
+			setMockTransactionObject:       setMockTransactionObject,
+			setMockUserTokenRepository:     func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+			setMockAgentRepository:         func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentTokenRepository:    func(ctx context.Context, atr *mockRepository.MockAgentTokenRepository) {},
+			setMockSecurityEventRepository: func(ctx context.Context, ser *mockRepository.MockSecurityEventRepository) {},
+		},
+		{
+			name:                           "unknown reporter",
+			inputKeyID:                     "unknown",
+			inputSignature:                 signature,
+			inputTokens:                    []string{userToken.Token},
+			expectError:                    entity.ErrInvalidLeakedTokenReport,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockUserTokenRepository:     func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+			setMockAgentRepository:         func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentTokenRepository:    func(ctx context.Context, atr *mockRepository.MockAgentTokenRepository) {},
+			setMockSecurityEventRepository: func(ctx context.Context, ser *mockRepository.MockSecurityEventRepository) {},
+		},
+		{
+			name:                           "invalid signature",
+			inputKeyID:                     reporter.KeyID,
+			inputSignature:                 "signature",
+			inputTokens:                    []string{userToken.Token},
+			expectError:                    entity.ErrInvalidLeakedTokenReport,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockUserTokenRepository:     func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+			setMockAgentRepository:         func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentTokenRepository:    func(ctx context.Context, atr *mockRepository.MockAgentTokenRepository) {},
+			setMockSecurityEventRepository: func(ctx context.Context, ser *mockRepository.MockSecurityEventRepository) {},
+		},
+		{
+			name:                           "too many tokens",
+			inputKeyID:                     reporter.KeyID,
+			inputSignature:                 signature,
+			inputTokens:                    make([]string, entity.LeakedTokenReportMaxTokens+1),
+			expectError:                    entity.ErrTooManyLeakedTokens,
+			setMockTransactionObject:       func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockUserTokenRepository:     func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {},
+			setMockAgentRepository:         func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentTokenRepository:    func(ctx context.Context, atr *mockRepository.MockAgentTokenRepository) {},
+			setMockSecurityEventRepository: func(ctx context.Context, ser *mockRepository.MockSecurityEventRepository) {},
+		},
+		{
+			name:                     "find user token error",
+			inputKeyID:               reporter.KeyID,
+			inputSignature:           signature,
+			inputTokens:              []string{userToken.Token},
+			expectError:              sql.ErrConnDone,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
+				utr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, userToken.Token).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockAgentRepository:         func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentTokenRepository:    func(ctx context.Context, atr *mockRepository.MockAgentTokenRepository) {},
+			setMockSecurityEventRepository: func(ctx context.Context, ser *mockRepository.MockSecurityEventRepository) {},
+		},
+		{
+			name:                     "create security event error",
+			inputKeyID:               reporter.KeyID,
+			inputSignature:           signature,
+			inputTokens:              []string{userToken.Token},
+			expectError:              sql.ErrConnDone,
+			setMockTransactionObject: setMockTransactionObject,
+			setMockUserTokenRepository: func(ctx context.Context, utr *mockRepository.MockUserTokenRepository) {
+				utr.EXPECT().
+					FindOneByTokenAndNotExpired(ctx, userToken.Token).
+					Return(userToken, nil).
+					Times(1)
+				utr.EXPECT().
+					Delete(ctx, userToken).
+					Return(nil).
+					Times(1)
+			},
+			setMockAgentRepository:      func(ctx context.Context, ar *mockRepository.MockAgentRepository) {},
+			setMockAgentTokenRepository: func(ctx context.Context, atr *mockRepository.MockAgentTokenRepository) {},
+			setMockSecurityEventRepository: func(ctx context.Context, ser *mockRepository.MockSecurityEventRepository) {
+				ser.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			utr := mockRepository.NewMockUserTokenRepository(ctrl)
+			ar := mockRepository.NewMockAgentRepository(ctrl)
+			atr := mockRepository.NewMockAgentTokenRepository(ctrl)
+			ser := mockRepository.NewMockSecurityEventRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockUserTokenRepository(ctx, utr)
+			tt.setMockAgentRepository(ctx, ar)
+			tt.setMockAgentTokenRepository(ctx, atr)
+			tt.setMockSecurityEventRepository(ctx, ser)
+
+			su := usecase.NewSecurityEventUsecase(to, utr, ar, atr, ser, []*entity.LeakedTokenReporter{reporter})
+			if err := su.ReportLeakedTokens(ctx, tt.inputKeyID, tt.inputSignature, timestamp, body, tt.inputTokens); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestSecurityEvent_Gets(t *testing.T) {
+	securityEvent, err := entity.NewSecurityEvent(uuid.New(), entity.SecurityEventTypeUserTokenLeaked, "reporter=scanner")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                           string
+		inputUserID                    uuid.UUID
+		expectResult                   []*dto.SecurityEventDTO
+		expectError                    error
+		setMockSecurityEventRepository func(context.Context, *mockRepository.MockSecurityEventRepository)
+	}{
+		{
+			name:         "success",
+			inputUserID:  securityEvent.UserID,
+			expectResult: []*dto.SecurityEventDTO{{ID: securityEvent.ID, UserID: securityEvent.UserID, Type: securityEvent.Type, Detail: securityEvent.Detail, OccurredAt: securityEvent.OccurredAt}},
+			expectError:  nil,
+			setMockSecurityEventRepository: func(ctx context.Context, ser *mockRepository.MockSecurityEventRepository) {
+				ser.EXPECT().
+					FindByUserID(ctx, securityEvent.UserID).
+					Return([]*entity.SecurityEvent{securityEvent}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "find security events error",
+			inputUserID:  securityEvent.UserID,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockSecurityEventRepository: func(ctx context.Context, ser *mockRepository.MockSecurityEventRepository) {
+				ser.EXPECT().
+					FindByUserID(ctx, securityEvent.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ser := mockRepository.NewMockSecurityEventRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockSecurityEventRepository(ctx, ser)
+
+			su := usecase.NewSecurityEventUsecase(nil, nil, nil, nil, ser, nil)
+			result, err := su.Gets(ctx, tt.inputUserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+//go:generate mockgen -source=$GOFILE -destination=../../../../test/mock/usecase/$GOFILE
+package usecase
+
+import (
+	"context"
+	"holos-auth-api/internal/app/api/domain"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/domain/repository"
+	"holos-auth-api/internal/app/api/pkg/status"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	"holos-auth-api/internal/app/api/usecase/mapper"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrServiceNotFound      = status.Error(http.StatusNotFound, "service not found")
+	ErrServiceAlreadyExists = status.Error(http.StatusBadRequest, "service already exists")
+)
+
+type ServiceUsecase interface {
+	Create(context.Context, uuid.UUID, string, []string, string) (*dto.ServiceDTO, error)
+	Update(context.Context, uuid.UUID, uuid.UUID, string, []string, string) (*dto.ServiceDTO, error)
+	Delete(context.Context, uuid.UUID, uuid.UUID) error
+	Get(context.Context, uuid.UUID, uuid.UUID) (*dto.ServiceDTO, error)
+	Gets(context.Context, string, uuid.UUID) ([]*dto.ServiceDTO, error)
+}
+
+type serviceUsecase struct {
+	transactionObject domain.TransactionObject
+	serviceRepository repository.ServiceRepository
+}
+
+func NewServiceUsecase(
+	transactionObject domain.TransactionObject,
+	serviceRepository repository.ServiceRepository,
+) ServiceUsecase {
+	return &serviceUsecase{
+		transactionObject: transactionObject,
+		serviceRepository: serviceRepository,
+	}
+}
+
+func (u *serviceUsecase) Create(ctx context.Context, userID uuid.UUID, name string, methods []string, pathSchema string) (*dto.ServiceDTO, error) {
+	service, err := entity.NewService(userID, name, methods, pathSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.validateNameUniqueness(ctx, service); err != nil {
+			return err
+		}
+
+		return u.serviceRepository.Create(ctx, service)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToServiceDTO(service), nil
+}
+
+// 既存のポリシーは検証し直さない. サービスが許可しなくなったメソッドやパスへのリクエストは認可時に拒否される.
+func (u *serviceUsecase) Update(ctx context.Context, id uuid.UUID, userID uuid.UUID, name string, methods []string, pathSchema string) (*dto.ServiceDTO, error) {
+	var service *entity.Service
+
+	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		service, err = u.serviceRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+		if service == nil {
+			return ErrServiceNotFound
+		}
+
+		if err := service.SetName(name); err != nil {
+			return err
+		}
+		if err := service.SetMethods(methods); err != nil {
+			return err
+		}
+		if err := service.SetPathSchema(pathSchema); err != nil {
+			return err
+		}
+
+		if err := u.validateNameUniqueness(ctx, service); err != nil {
+			return err
+		}
+
+		return u.serviceRepository.Update(ctx, service)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToServiceDTO(service), nil
+}
+
+func (u *serviceUsecase) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	return u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
+		service, err := u.serviceRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+		if service == nil {
+			return ErrServiceNotFound
+		}
+
+		return u.serviceRepository.Delete(ctx, service)
+	})
+}
+
+func (u *serviceUsecase) Get(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.ServiceDTO, error) {
+	service, err := u.serviceRepository.FindOneByIDAndUserIDAndNotDeleted(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if service == nil {
+		return nil, ErrServiceNotFound
+	}
+
+	return mapper.ToServiceDTO(service), nil
+}
+
+func (u *serviceUsecase) Gets(ctx context.Context, keyword string, userID uuid.UUID) ([]*dto.ServiceDTO, error) {
+	services, err := u.serviceRepository.FindByNamePrefixAndUserIDAndNotDeleted(ctx, keyword, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToServiceDTOs(services), nil
+}
+
+// ポリシーと認可リクエストはサービスを名前で参照するため, ユーザー内で名前を一意にする.
+func (u *serviceUsecase) validateNameUniqueness(ctx context.Context, service *entity.Service) error {
+	found, err := u.serviceRepository.FindOneByNameAndUserIDAndNotDeleted(ctx, service.Name, service.UserID)
+	if err != nil {
+		return err
+	}
+	if found != nil && found.ID != service.ID {
+		return ErrServiceAlreadyExists
+	}
+	return nil
+}
@@ -0,0 +1,495 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"holos-auth-api/internal/app/api/domain/entity"
+	"holos-auth-api/internal/app/api/usecase"
+	"holos-auth-api/internal/app/api/usecase/dto"
+	mockDomain "holos-auth-api/test/mock/domain"
+	mockRepository "holos-auth-api/test/mock/domain/repository"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+)
+
+func TestService_Create(t *testing.T) {
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                     string
+		inputName                string
+		inputMethods             []string
+		inputPathSchema          string
+		expectResult             *dto.ServiceDTO
+		expectError              error
+		setMockTransactionObject func(context.Context, *mockDomain.MockTransactionObject)
+		setMockServiceRepository func(context.Context, *mockRepository.MockServiceRepository)
+	}{
+		{
+			name:            "success",
+			inputName:       "BUILD_CACHE",
+			inputMethods:    []string{"PUT", "GET"},
+			inputPathSchema: `/caches/[0-9a-f]+`,
+			expectResult:    &dto.ServiceDTO{UserID: service.UserID, Name: "BUILD_CACHE", Methods: []string{"GET", "PUT"}, PathSchema: `/caches/[0-9a-f]+`},
+			expectError:     nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "BUILD_CACHE", service.UserID).
+					Return(nil, nil).
+					Times(1)
+				sr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                     "invalid name",
+			inputName:                "build_cache",
+			inputMethods:             []string{"GET"},
+			expectResult:             nil,
+			expectError:              entity.ErrInvalidServiceName,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {},
+		},
+		{
+			name:                     "invalid methods",
+			inputName:                "BUILD_CACHE",
+			inputMethods:             []string{"FETCH"},
+			expectResult:             nil,
+			expectError:              entity.ErrInvalidServiceMethods,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {},
+		},
+		{
+			name:                     "invalid path schema",
+			inputName:                "BUILD_CACHE",
+			inputMethods:             []string{"GET"},
+			inputPathSchema:          `/caches/(`,
+			expectResult:             nil,
+			expectError:              entity.ErrInvalidServicePathSchema,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {},
+		},
+		{
+			name:         "service already exists",
+			inputName:    "STORAGE",
+			inputMethods: []string{"GET"},
+			expectResult: nil,
+			expectError:  usecase.ErrServiceAlreadyExists,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", service.UserID).
+					Return(service, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "create error",
+			inputName:    "BUILD_CACHE",
+			inputMethods: []string{"GET"},
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "BUILD_CACHE", service.UserID).
+					Return(nil, nil).
+					Times(1)
+				sr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			sr := mockRepository.NewMockServiceRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockServiceRepository(ctx, sr)
+
+			su := usecase.NewServiceUsecase(to, sr)
+			result, err := su.Create(ctx, service.UserID, tt.inputName, tt.inputMethods, tt.inputPathSchema)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(dto.ServiceDTO{}, "ID", "CreatedAt", "UpdatedAt"),
+			}
+			if diff := cmp.Diff(result, tt.expectResult, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	other, err := entity.NewService(service.UserID, "CONTENT", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                     string
+		inputName                string
+		expectResult             *dto.ServiceDTO
+		expectError              error
+		setMockTransactionObject func(context.Context, *mockDomain.MockTransactionObject)
+		setMockServiceRepository func(context.Context, *mockRepository.MockServiceRepository)
+	}{
+		{
+			name:         "success",
+			inputName:    "STORAGE",
+			expectResult: &dto.ServiceDTO{ID: service.ID, UserID: service.UserID, Name: "STORAGE", Methods: []string{"GET", "PUT"}, PathSchema: `/files(/.*)?`, CreatedAt: service.CreatedAt},
+			expectError:  nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, service.ID, service.UserID).
+					Return(entity.RestoreService(service.ID, service.UserID, service.Name, service.Methods, service.PathSchema, service.CreatedAt, service.UpdatedAt), nil).
+					Times(1)
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", service.UserID).
+					Return(service, nil).
+					Times(1)
+				sr.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "service not found",
+			inputName:    "STORAGE",
+			expectResult: nil,
+			expectError:  usecase.ErrServiceNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, service.ID, service.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "invalid name",
+			inputName:    "storage",
+			expectResult: nil,
+			expectError:  entity.ErrInvalidServiceName,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, service.ID, service.UserID).
+					Return(entity.RestoreService(service.ID, service.UserID, service.Name, service.Methods, service.PathSchema, service.CreatedAt, service.UpdatedAt), nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "service already exists",
+			inputName:    "CONTENT",
+			expectResult: nil,
+			expectError:  usecase.ErrServiceAlreadyExists,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, service.ID, service.UserID).
+					Return(entity.RestoreService(service.ID, service.UserID, service.Name, service.Methods, service.PathSchema, service.CreatedAt, service.UpdatedAt), nil).
+					Times(1)
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "CONTENT", service.UserID).
+					Return(other, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "update error",
+			inputName:    "STORAGE",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, service.ID, service.UserID).
+					Return(entity.RestoreService(service.ID, service.UserID, service.Name, service.Methods, service.PathSchema, service.CreatedAt, service.UpdatedAt), nil).
+					Times(1)
+				sr.EXPECT().
+					FindOneByNameAndUserIDAndNotDeleted(ctx, "STORAGE", service.UserID).
+					Return(service, nil).
+					Times(1)
+				sr.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			sr := mockRepository.NewMockServiceRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockServiceRepository(ctx, sr)
+
+			su := usecase.NewServiceUsecase(to, sr)
+			result, err := su.Update(ctx, service.ID, service.UserID, tt.inputName, []string{"PUT", "GET"}, `/files(/.*)?`)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(dto.ServiceDTO{}, "UpdatedAt"),
+			}
+			if diff := cmp.Diff(result, tt.expectResult, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                     string
+		expectError              error
+		setMockTransactionObject func(context.Context, *mockDomain.MockTransactionObject)
+		setMockServiceRepository func(context.Context, *mockRepository.MockServiceRepository)
+	}{
+		{
+			name:        "success",
+			expectError: nil,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, service.ID, service.UserID).
+					Return(service, nil).
+					Times(1)
+				sr.EXPECT().
+					Delete(ctx, service).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "service not found",
+			expectError: usecase.ErrServiceNotFound,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, service.ID, service.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "delete error",
+			expectError: sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, service.ID, service.UserID).
+					Return(service, nil).
+					Times(1)
+				sr.EXPECT().
+					Delete(ctx, service).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			to := mockDomain.NewMockTransactionObject(ctrl)
+			sr := mockRepository.NewMockServiceRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockTransactionObject(ctx, to)
+			tt.setMockServiceRepository(ctx, sr)
+
+			su := usecase.NewServiceUsecase(to, sr)
+			if err := su.Delete(ctx, service.ID, service.UserID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestService_Get(t *testing.T) {
+	service, err := entity.NewService(uuid.New(), "STORAGE", []string{"GET"}, "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	tests := []struct {
+		name                     string
+		expectResult             *dto.ServiceDTO
+		expectError              error
+		setMockServiceRepository func(context.Context, *mockRepository.MockServiceRepository)
+	}{
+		{
+			name:         "success",
+			expectResult: &dto.ServiceDTO{ID: service.ID, UserID: service.UserID, Name: service.Name, Methods: service.Methods, PathSchema: service.PathSchema, CreatedAt: service.CreatedAt, UpdatedAt: service.UpdatedAt},
+			expectError:  nil,
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, service.ID, service.UserID).
+					Return(service, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "service not found",
+			expectResult: nil,
+			expectError:  usecase.ErrServiceNotFound,
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, service.ID, service.UserID).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					FindOneByIDAndUserIDAndNotDeleted(ctx, service.ID, service.UserID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sr := mockRepository.NewMockServiceRepository(ctrl)
+
+			ctx := context.Background()
+
+			tt.setMockServiceRepository(ctx, sr)
+
+			su := usecase.NewServiceUsecase(nil, sr)
+			result, err := su.Get(ctx, service.ID, service.UserID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
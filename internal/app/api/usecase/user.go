@@ -30,22 +30,29 @@ type UserUsecase interface {
 type userUsecase struct {
 	transactionObject domain.TransactionObject
 	userRepository    repository.UserRepository
+	serviceRepository repository.ServiceRepository
 	userService       service.UserService
 }
 
-func NewUserUsecase(transactionObject domain.TransactionObject, userRepository repository.UserRepository, userService service.UserService) UserUsecase {
+func NewUserUsecase(transactionObject domain.TransactionObject, userRepository repository.UserRepository, serviceRepository repository.ServiceRepository, userService service.UserService) UserUsecase {
 	return &userUsecase{
 		transactionObject: transactionObject,
 		userRepository:    userRepository,
+		serviceRepository: serviceRepository,
 		userService:       userService,
 	}
 }
 
+// 作成したユーザーがすぐにポリシーを作成できるよう, 既定のサービスを同じトランザクションで登録する.
 func (u *userUsecase) Create(ctx context.Context, name string, password string, confirmPassword string) (*dto.UserDTO, error) {
 	user, err := entity.NewUser(name, password, confirmPassword)
 	if err != nil {
 		return nil, err
 	}
+	services, err := entity.NewDefaultServices(user.ID)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := u.transactionObject.Transaction(ctx, func(ctx context.Context) error {
 		if exists, err := u.userService.Exists(ctx, user); err != nil {
@@ -54,7 +61,15 @@ func (u *userUsecase) Create(ctx context.Context, name string, password string,
 			return ErrUserAlreadyExists
 		}
 
-		return u.userRepository.Create(ctx, user)
+		if err := u.userRepository.Create(ctx, user); err != nil {
+			return err
+		}
+		for _, service := range services {
+			if err := u.serviceRepository.Create(ctx, service); err != nil {
+				return err
+			}
+		}
+		return nil
 	}); err != nil {
 		return nil, err
 	}
@@ -11,6 +11,7 @@ import (
 	mockDomain "holos-auth-api/test/mock/domain"
 	mockRepository "holos-auth-api/test/mock/domain/repository"
 	mockService "holos-auth-api/test/mock/domain/service"
+	"slices"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -34,6 +35,7 @@ func TestUser_Create(t *testing.T) {
 		expectError              error
 		setMockTransactionObject func(context.Context, *mockDomain.MockTransactionObject)
 		setMockUserRepository    func(context.Context, *mockRepository.MockUserRepository)
+		setMockServiceRepository func(context.Context, *mockRepository.MockServiceRepository)
 		setMockUserService       func(context.Context, *mockService.MockUserService)
 	}{
 		{
@@ -57,6 +59,17 @@ func TestUser_Create(t *testing.T) {
 					Return(nil).
 					Times(1)
 			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					Create(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, service *entity.Service) error {
+						if service.UserID == uuid.Nil || !slices.Contains(entity.DefaultServiceNames, service.Name) {
+							t.Errorf("unexpected service: %+v", service)
+						}
+						return nil
+					}).
+					Times(len(entity.DefaultServiceNames))
+			},
 			setMockUserService: func(ctx context.Context, us *mockService.MockUserService) {
 				us.EXPECT().
 					Exists(ctx, gomock.Any()).
@@ -73,6 +86,7 @@ func TestUser_Create(t *testing.T) {
 			expectError:              entity.ErrInvalidUserName,
 			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {},
 			setMockUserRepository:    func(ctx context.Context, ur *mockRepository.MockUserRepository) {},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {},
 			setMockUserService:       func(ctx context.Context, us *mockService.MockUserService) {},
 		},
 		{
@@ -90,7 +104,8 @@ func TestUser_Create(t *testing.T) {
 					}).
 					Times(1)
 			},
-			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {},
+			setMockUserRepository:    func(ctx context.Context, ur *mockRepository.MockUserRepository) {},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {},
 			setMockUserService: func(ctx context.Context, us *mockService.MockUserService) {
 				us.EXPECT().
 					Exists(ctx, gomock.Any()).
@@ -113,7 +128,8 @@ func TestUser_Create(t *testing.T) {
 					}).
 					Times(1)
 			},
-			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {},
+			setMockUserRepository:    func(ctx context.Context, ur *mockRepository.MockUserRepository) {},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {},
 			setMockUserService: func(ctx context.Context, us *mockService.MockUserService) {
 				us.EXPECT().
 					Exists(ctx, gomock.Any()).
@@ -142,6 +158,41 @@ func TestUser_Create(t *testing.T) {
 					Return(sql.ErrConnDone).
 					Times(1)
 			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {},
+			setMockUserService: func(ctx context.Context, us *mockService.MockUserService) {
+				us.EXPECT().
+					Exists(ctx, gomock.Any()).
+					Return(false, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                 "service create error",
+			inputName:            "name",
+			inputPassword:        "password",
+			inputConfirmPassword: "password",
+			expectResult:         nil,
+			expectError:          sql.ErrConnDone,
+			setMockTransactionObject: func(ctx context.Context, to *mockDomain.MockTransactionObject) {
+				to.EXPECT().
+					Transaction(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockUserRepository: func(ctx context.Context, ur *mockRepository.MockUserRepository) {
+				ur.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockServiceRepository: func(ctx context.Context, sr *mockRepository.MockServiceRepository) {
+				sr.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
 			setMockUserService: func(ctx context.Context, us *mockService.MockUserService) {
 				us.EXPECT().
 					Exists(ctx, gomock.Any()).
@@ -157,15 +208,17 @@ func TestUser_Create(t *testing.T) {
 
 			to := mockDomain.NewMockTransactionObject(ctrl)
 			ur := mockRepository.NewMockUserRepository(ctrl)
+			sr := mockRepository.NewMockServiceRepository(ctrl)
 			us := mockService.NewMockUserService(ctrl)
 
 			ctx := context.Background()
 
 			tt.setMockTransactionObject(ctx, to)
 			tt.setMockUserRepository(ctx, ur)
+			tt.setMockServiceRepository(ctx, sr)
 			tt.setMockUserService(ctx, us)
 
-			uu := usecase.NewUserUsecase(to, ur, us)
+			uu := usecase.NewUserUsecase(to, ur, sr, us)
 			result, err := uu.Create(ctx, tt.inputName, tt.inputPassword, tt.inputConfirmPassword)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -394,7 +447,7 @@ func TestUser_UpdateName(t *testing.T) {
 			tt.setMockUserRepository(ctx, ur)
 			tt.setMockUserService(ctx, us)
 
-			uu := usecase.NewUserUsecase(to, ur, us)
+			uu := usecase.NewUserUsecase(to, ur, nil, us)
 			result, err := uu.UpdateName(ctx, tt.inputID, tt.inputName)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -609,7 +662,7 @@ func TestUser_UpdatePassword(t *testing.T) {
 			tt.setMockTransactionObject(ctx, to)
 			tt.setMockUserRepository(ctx, ur)
 
-			uu := usecase.NewUserUsecase(to, ur, nil)
+			uu := usecase.NewUserUsecase(to, ur, nil, nil)
 			result, err := uu.UpdatePassword(
 				ctx,
 				tt.inputID,
@@ -766,7 +819,7 @@ func TestUser_Delete(t *testing.T) {
 			tt.setMockTransactionObject(ctx, to)
 			tt.setMockUserRepository(ctx, ur)
 
-			uu := usecase.NewUserUsecase(to, ur, nil)
+			uu := usecase.NewUserUsecase(to, ur, nil, nil)
 			err := uu.Delete(ctx, tt.inputID, tt.inputPassword)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -8,6 +8,18 @@ var (
 	MySQLUser     string
 	MySQLPassword string
 	MySQLDatabase string
+
+	TLSCertFile     string
+	TLSKeyFile      string
+	TLSClientCAFile string
+
+	LegacyTokenDeadline string
+
+	LeakedTokenReporterKeys string
+
+	StepUpRoutes string
+
+	HeadImpliedByGet string
 )
 
 func init() {
@@ -16,4 +28,16 @@ func init() {
 	MySQLUser = os.Getenv("MYSQL_USER")
 	MySQLPassword = os.Getenv("MYSQL_PASSWORD")
 	MySQLDatabase = os.Getenv("MYSQL_DATABASE")
+
+	TLSCertFile = os.Getenv("TLS_CERT_FILE")
+	TLSKeyFile = os.Getenv("TLS_KEY_FILE")
+	TLSClientCAFile = os.Getenv("TLS_CLIENT_CA_FILE")
+
+	LegacyTokenDeadline = os.Getenv("LEGACY_TOKEN_DEADLINE")
+
+	LeakedTokenReporterKeys = os.Getenv("LEAKED_TOKEN_REPORTER_KEYS")
+
+	StepUpRoutes = os.Getenv("STEP_UP_ROUTES")
+
+	HeadImpliedByGet = os.Getenv("HEAD_IMPLIED_BY_GET")
 }
@@ -109,6 +109,36 @@ func (mr *MockAgentRepositoryMockRecorder) FindByNamePrefixAndUserIDAndNotDelete
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByNamePrefixAndUserIDAndNotDeleted", reflect.TypeOf((*MockAgentRepository)(nil).FindByNamePrefixAndUserIDAndNotDeleted), arg0, arg1, arg2)
 }
 
+// FindByUserIDAndNotDeletedForUpdate mocks base method.
+func (m *MockAgentRepository) FindByUserIDAndNotDeletedForUpdate(arg0 context.Context, arg1 uuid.UUID) ([]*entity.Agent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserIDAndNotDeletedForUpdate", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Agent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserIDAndNotDeletedForUpdate indicates an expected call of FindByUserIDAndNotDeletedForUpdate.
+func (mr *MockAgentRepositoryMockRecorder) FindByUserIDAndNotDeletedForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserIDAndNotDeletedForUpdate", reflect.TypeOf((*MockAgentRepository)(nil).FindByUserIDAndNotDeletedForUpdate), arg0, arg1)
+}
+
+// FindOneByCertificateAndNotDeleted mocks base method.
+func (m *MockAgentRepository) FindOneByCertificateAndNotDeleted(arg0 context.Context, arg1, arg2 string) (*entity.Agent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByCertificateAndNotDeleted", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Agent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByCertificateAndNotDeleted indicates an expected call of FindOneByCertificateAndNotDeleted.
+func (mr *MockAgentRepositoryMockRecorder) FindOneByCertificateAndNotDeleted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByCertificateAndNotDeleted", reflect.TypeOf((*MockAgentRepository)(nil).FindOneByCertificateAndNotDeleted), arg0, arg1, arg2)
+}
+
 // FindOneByIDAndUserIDAndNotDeleted mocks base method.
 func (m *MockAgentRepository) FindOneByIDAndUserIDAndNotDeleted(arg0 context.Context, arg1, arg2 uuid.UUID) (*entity.Agent, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +154,36 @@ func (mr *MockAgentRepositoryMockRecorder) FindOneByIDAndUserIDAndNotDeleted(arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByIDAndUserIDAndNotDeleted", reflect.TypeOf((*MockAgentRepository)(nil).FindOneByIDAndUserIDAndNotDeleted), arg0, arg1, arg2)
 }
 
+// FindOneByPublicKeyIDAndNotDeleted mocks base method.
+func (m *MockAgentRepository) FindOneByPublicKeyIDAndNotDeleted(arg0 context.Context, arg1 uuid.UUID) (*entity.Agent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByPublicKeyIDAndNotDeleted", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Agent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByPublicKeyIDAndNotDeleted indicates an expected call of FindOneByPublicKeyIDAndNotDeleted.
+func (mr *MockAgentRepositoryMockRecorder) FindOneByPublicKeyIDAndNotDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByPublicKeyIDAndNotDeleted", reflect.TypeOf((*MockAgentRepository)(nil).FindOneByPublicKeyIDAndNotDeleted), arg0, arg1)
+}
+
+// FindOneBySecretKeyIDAndNotDeleted mocks base method.
+func (m *MockAgentRepository) FindOneBySecretKeyIDAndNotDeleted(arg0 context.Context, arg1 string) (*entity.Agent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneBySecretKeyIDAndNotDeleted", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Agent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneBySecretKeyIDAndNotDeleted indicates an expected call of FindOneBySecretKeyIDAndNotDeleted.
+func (mr *MockAgentRepositoryMockRecorder) FindOneBySecretKeyIDAndNotDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneBySecretKeyIDAndNotDeleted", reflect.TypeOf((*MockAgentRepository)(nil).FindOneBySecretKeyIDAndNotDeleted), arg0, arg1)
+}
+
 // FindOneByTokenAndNotDeleted mocks base method.
 func (m *MockAgentRepository) FindOneByTokenAndNotDeleted(arg0 context.Context, arg1 string) (*entity.Agent, error) {
 	m.ctrl.T.Helper()
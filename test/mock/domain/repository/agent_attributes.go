@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: agent_attributes.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "holos-auth-api/internal/app/api/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockAgentAttributesRepository is a mock of AgentAttributesRepository interface.
+type MockAgentAttributesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgentAttributesRepositoryMockRecorder
+}
+
+// MockAgentAttributesRepositoryMockRecorder is the mock recorder for MockAgentAttributesRepository.
+type MockAgentAttributesRepositoryMockRecorder struct {
+	mock *MockAgentAttributesRepository
+}
+
+// NewMockAgentAttributesRepository creates a new mock instance.
+func NewMockAgentAttributesRepository(ctrl *gomock.Controller) *MockAgentAttributesRepository {
+	mock := &MockAgentAttributesRepository{ctrl: ctrl}
+	mock.recorder = &MockAgentAttributesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgentAttributesRepository) EXPECT() *MockAgentAttributesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindOneByAgentID mocks base method.
+func (m *MockAgentAttributesRepository) FindOneByAgentID(arg0 context.Context, arg1 uuid.UUID) (*entity.AgentAttributes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByAgentID", arg0, arg1)
+	ret0, _ := ret[0].(*entity.AgentAttributes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByAgentID indicates an expected call of FindOneByAgentID.
+func (mr *MockAgentAttributesRepositoryMockRecorder) FindOneByAgentID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByAgentID", reflect.TypeOf((*MockAgentAttributesRepository)(nil).FindOneByAgentID), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockAgentAttributesRepository) Save(arg0 context.Context, arg1 *entity.AgentAttributes) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAgentAttributesRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAgentAttributesRepository)(nil).Save), arg0, arg1)
+}
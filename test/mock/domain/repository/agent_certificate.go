@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: agent_certificate.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "holos-auth-api/internal/app/api/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockAgentCertificateRepository is a mock of AgentCertificateRepository interface.
+type MockAgentCertificateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgentCertificateRepositoryMockRecorder
+}
+
+// MockAgentCertificateRepositoryMockRecorder is the mock recorder for MockAgentCertificateRepository.
+type MockAgentCertificateRepositoryMockRecorder struct {
+	mock *MockAgentCertificateRepository
+}
+
+// NewMockAgentCertificateRepository creates a new mock instance.
+func NewMockAgentCertificateRepository(ctrl *gomock.Controller) *MockAgentCertificateRepository {
+	mock := &MockAgentCertificateRepository{ctrl: ctrl}
+	mock.recorder = &MockAgentCertificateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgentCertificateRepository) EXPECT() *MockAgentCertificateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockAgentCertificateRepository) Delete(arg0 context.Context, arg1 *entity.AgentCertificate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAgentCertificateRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAgentCertificateRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindOneByAgentIDAndUserID mocks base method.
+func (m *MockAgentCertificateRepository) FindOneByAgentIDAndUserID(arg0 context.Context, arg1, arg2 uuid.UUID) (*entity.AgentCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByAgentIDAndUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.AgentCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByAgentIDAndUserID indicates an expected call of FindOneByAgentIDAndUserID.
+func (mr *MockAgentCertificateRepositoryMockRecorder) FindOneByAgentIDAndUserID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByAgentIDAndUserID", reflect.TypeOf((*MockAgentCertificateRepository)(nil).FindOneByAgentIDAndUserID), arg0, arg1, arg2)
+}
+
+// FindOneByFingerprintOrSubjectForUpdate mocks base method.
+func (m *MockAgentCertificateRepository) FindOneByFingerprintOrSubjectForUpdate(arg0 context.Context, arg1, arg2 string) (*entity.AgentCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByFingerprintOrSubjectForUpdate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.AgentCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByFingerprintOrSubjectForUpdate indicates an expected call of FindOneByFingerprintOrSubjectForUpdate.
+func (mr *MockAgentCertificateRepositoryMockRecorder) FindOneByFingerprintOrSubjectForUpdate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByFingerprintOrSubjectForUpdate", reflect.TypeOf((*MockAgentCertificateRepository)(nil).FindOneByFingerprintOrSubjectForUpdate), arg0, arg1, arg2)
+}
+
+// Save mocks base method.
+func (m *MockAgentCertificateRepository) Save(arg0 context.Context, arg1 *entity.AgentCertificate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAgentCertificateRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAgentCertificateRepository)(nil).Save), arg0, arg1)
+}
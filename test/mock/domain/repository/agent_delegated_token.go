@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: agent_delegated_token.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "holos-auth-api/internal/app/api/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockAgentDelegatedTokenRepository is a mock of AgentDelegatedTokenRepository interface.
+type MockAgentDelegatedTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgentDelegatedTokenRepositoryMockRecorder
+}
+
+// MockAgentDelegatedTokenRepositoryMockRecorder is the mock recorder for MockAgentDelegatedTokenRepository.
+type MockAgentDelegatedTokenRepositoryMockRecorder struct {
+	mock *MockAgentDelegatedTokenRepository
+}
+
+// NewMockAgentDelegatedTokenRepository creates a new mock instance.
+func NewMockAgentDelegatedTokenRepository(ctrl *gomock.Controller) *MockAgentDelegatedTokenRepository {
+	mock := &MockAgentDelegatedTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockAgentDelegatedTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgentDelegatedTokenRepository) EXPECT() *MockAgentDelegatedTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAgentDelegatedTokenRepository) Create(arg0 context.Context, arg1 *entity.AgentDelegatedToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAgentDelegatedTokenRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAgentDelegatedTokenRepository)(nil).Create), arg0, arg1)
+}
+
+// DeleteExpiredByAgentID mocks base method.
+func (m *MockAgentDelegatedTokenRepository) DeleteExpiredByAgentID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredByAgentID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredByAgentID indicates an expected call of DeleteExpiredByAgentID.
+func (mr *MockAgentDelegatedTokenRepositoryMockRecorder) DeleteExpiredByAgentID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredByAgentID", reflect.TypeOf((*MockAgentDelegatedTokenRepository)(nil).DeleteExpiredByAgentID), arg0, arg1)
+}
+
+// FindOneByTokenAndNotExpired mocks base method.
+func (m *MockAgentDelegatedTokenRepository) FindOneByTokenAndNotExpired(arg0 context.Context, arg1 string) (*entity.AgentDelegatedToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByTokenAndNotExpired", arg0, arg1)
+	ret0, _ := ret[0].(*entity.AgentDelegatedToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByTokenAndNotExpired indicates an expected call of FindOneByTokenAndNotExpired.
+func (mr *MockAgentDelegatedTokenRepositoryMockRecorder) FindOneByTokenAndNotExpired(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByTokenAndNotExpired", reflect.TypeOf((*MockAgentDelegatedTokenRepository)(nil).FindOneByTokenAndNotExpired), arg0, arg1)
+}
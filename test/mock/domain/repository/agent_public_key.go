@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: agent_public_key.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "holos-auth-api/internal/app/api/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockAgentPublicKeyRepository is a mock of AgentPublicKeyRepository interface.
+type MockAgentPublicKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgentPublicKeyRepositoryMockRecorder
+}
+
+// MockAgentPublicKeyRepositoryMockRecorder is the mock recorder for MockAgentPublicKeyRepository.
+type MockAgentPublicKeyRepositoryMockRecorder struct {
+	mock *MockAgentPublicKeyRepository
+}
+
+// NewMockAgentPublicKeyRepository creates a new mock instance.
+func NewMockAgentPublicKeyRepository(ctrl *gomock.Controller) *MockAgentPublicKeyRepository {
+	mock := &MockAgentPublicKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockAgentPublicKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgentPublicKeyRepository) EXPECT() *MockAgentPublicKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAgentPublicKeyRepository) Create(arg0 context.Context, arg1 *entity.AgentPublicKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAgentPublicKeyRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAgentPublicKeyRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockAgentPublicKeyRepository) Delete(arg0 context.Context, arg1 *entity.AgentPublicKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAgentPublicKeyRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAgentPublicKeyRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindByAgentIDAndUserID mocks base method.
+func (m *MockAgentPublicKeyRepository) FindByAgentIDAndUserID(arg0 context.Context, arg1, arg2 uuid.UUID) ([]*entity.AgentPublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAgentIDAndUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.AgentPublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAgentIDAndUserID indicates an expected call of FindByAgentIDAndUserID.
+func (mr *MockAgentPublicKeyRepositoryMockRecorder) FindByAgentIDAndUserID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAgentIDAndUserID", reflect.TypeOf((*MockAgentPublicKeyRepository)(nil).FindByAgentIDAndUserID), arg0, arg1, arg2)
+}
+
+// FindOneByID mocks base method.
+func (m *MockAgentPublicKeyRepository) FindOneByID(arg0 context.Context, arg1 uuid.UUID) (*entity.AgentPublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByID", arg0, arg1)
+	ret0, _ := ret[0].(*entity.AgentPublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByID indicates an expected call of FindOneByID.
+func (mr *MockAgentPublicKeyRepositoryMockRecorder) FindOneByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByID", reflect.TypeOf((*MockAgentPublicKeyRepository)(nil).FindOneByID), arg0, arg1)
+}
+
+// FindOneByIDAndAgentIDAndUserID mocks base method.
+func (m *MockAgentPublicKeyRepository) FindOneByIDAndAgentIDAndUserID(arg0 context.Context, arg1, arg2, arg3 uuid.UUID) (*entity.AgentPublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByIDAndAgentIDAndUserID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entity.AgentPublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByIDAndAgentIDAndUserID indicates an expected call of FindOneByIDAndAgentIDAndUserID.
+func (mr *MockAgentPublicKeyRepositoryMockRecorder) FindOneByIDAndAgentIDAndUserID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByIDAndAgentIDAndUserID", reflect.TypeOf((*MockAgentPublicKeyRepository)(nil).FindOneByIDAndAgentIDAndUserID), arg0, arg1, arg2, arg3)
+}
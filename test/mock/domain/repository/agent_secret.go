@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: agent_secret.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "holos-auth-api/internal/app/api/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockAgentSecretRepository is a mock of AgentSecretRepository interface.
+type MockAgentSecretRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgentSecretRepositoryMockRecorder
+}
+
+// MockAgentSecretRepositoryMockRecorder is the mock recorder for MockAgentSecretRepository.
+type MockAgentSecretRepositoryMockRecorder struct {
+	mock *MockAgentSecretRepository
+}
+
+// NewMockAgentSecretRepository creates a new mock instance.
+func NewMockAgentSecretRepository(ctrl *gomock.Controller) *MockAgentSecretRepository {
+	mock := &MockAgentSecretRepository{ctrl: ctrl}
+	mock.recorder = &MockAgentSecretRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgentSecretRepository) EXPECT() *MockAgentSecretRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockAgentSecretRepository) Delete(arg0 context.Context, arg1 *entity.AgentSecret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAgentSecretRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAgentSecretRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindOneByAgentIDAndUserID mocks base method.
+func (m *MockAgentSecretRepository) FindOneByAgentIDAndUserID(arg0 context.Context, arg1, arg2 uuid.UUID) (*entity.AgentSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByAgentIDAndUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.AgentSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByAgentIDAndUserID indicates an expected call of FindOneByAgentIDAndUserID.
+func (mr *MockAgentSecretRepositoryMockRecorder) FindOneByAgentIDAndUserID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByAgentIDAndUserID", reflect.TypeOf((*MockAgentSecretRepository)(nil).FindOneByAgentIDAndUserID), arg0, arg1, arg2)
+}
+
+// FindOneByKeyID mocks base method.
+func (m *MockAgentSecretRepository) FindOneByKeyID(arg0 context.Context, arg1 string) (*entity.AgentSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByKeyID", arg0, arg1)
+	ret0, _ := ret[0].(*entity.AgentSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByKeyID indicates an expected call of FindOneByKeyID.
+func (mr *MockAgentSecretRepositoryMockRecorder) FindOneByKeyID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByKeyID", reflect.TypeOf((*MockAgentSecretRepository)(nil).FindOneByKeyID), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockAgentSecretRepository) Save(arg0 context.Context, arg1 *entity.AgentSecret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAgentSecretRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAgentSecretRepository)(nil).Save), arg0, arg1)
+}
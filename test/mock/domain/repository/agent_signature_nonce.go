@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: agent_signature_nonce.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "holos-auth-api/internal/app/api/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockAgentSignatureNonceRepository is a mock of AgentSignatureNonceRepository interface.
+type MockAgentSignatureNonceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgentSignatureNonceRepositoryMockRecorder
+}
+
+// MockAgentSignatureNonceRepositoryMockRecorder is the mock recorder for MockAgentSignatureNonceRepository.
+type MockAgentSignatureNonceRepositoryMockRecorder struct {
+	mock *MockAgentSignatureNonceRepository
+}
+
+// NewMockAgentSignatureNonceRepository creates a new mock instance.
+func NewMockAgentSignatureNonceRepository(ctrl *gomock.Controller) *MockAgentSignatureNonceRepository {
+	mock := &MockAgentSignatureNonceRepository{ctrl: ctrl}
+	mock.recorder = &MockAgentSignatureNonceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgentSignatureNonceRepository) EXPECT() *MockAgentSignatureNonceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAgentSignatureNonceRepository) Create(arg0 context.Context, arg1 *entity.AgentSignatureNonce) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAgentSignatureNonceRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAgentSignatureNonceRepository)(nil).Create), arg0, arg1)
+}
+
+// DeleteExpiredByAgentID mocks base method.
+func (m *MockAgentSignatureNonceRepository) DeleteExpiredByAgentID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredByAgentID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredByAgentID indicates an expected call of DeleteExpiredByAgentID.
+func (mr *MockAgentSignatureNonceRepositoryMockRecorder) DeleteExpiredByAgentID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredByAgentID", reflect.TypeOf((*MockAgentSignatureNonceRepository)(nil).DeleteExpiredByAgentID), arg0, arg1)
+}
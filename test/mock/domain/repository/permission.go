@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: permission.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "holos-auth-api/internal/app/api/domain/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockPermissionRepository is a mock of PermissionRepository interface.
+type MockPermissionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionRepositoryMockRecorder
+}
+
+// MockPermissionRepositoryMockRecorder is the mock recorder for MockPermissionRepository.
+type MockPermissionRepositoryMockRecorder struct {
+	mock *MockPermissionRepository
+}
+
+// NewMockPermissionRepository creates a new mock instance.
+func NewMockPermissionRepository(ctrl *gomock.Controller) *MockPermissionRepository {
+	mock := &MockPermissionRepository{ctrl: ctrl}
+	mock.recorder = &MockPermissionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionRepository) EXPECT() *MockPermissionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockPermissionRepository) Delete(arg0 context.Context, arg1 *entity.Permission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPermissionRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPermissionRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindByAgentIDAndUserID mocks base method.
+func (m *MockPermissionRepository) FindByAgentIDAndUserID(arg0 context.Context, arg1, arg2 uuid.UUID) ([]*entity.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAgentIDAndUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAgentIDAndUserID indicates an expected call of FindByAgentIDAndUserID.
+func (mr *MockPermissionRepositoryMockRecorder) FindByAgentIDAndUserID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAgentIDAndUserID", reflect.TypeOf((*MockPermissionRepository)(nil).FindByAgentIDAndUserID), arg0, arg1, arg2)
+}
+
+// FindExpired mocks base method.
+func (m *MockPermissionRepository) FindExpired(arg0 context.Context, arg1 time.Time) ([]*entity.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpired", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpired indicates an expected call of FindExpired.
+func (mr *MockPermissionRepositoryMockRecorder) FindExpired(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpired", reflect.TypeOf((*MockPermissionRepository)(nil).FindExpired), arg0, arg1)
+}
+
+// FindOneByAgentIDAndPolicyIDAndUserID mocks base method.
+func (m *MockPermissionRepository) FindOneByAgentIDAndPolicyIDAndUserID(arg0 context.Context, arg1, arg2, arg3 uuid.UUID) (*entity.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByAgentIDAndPolicyIDAndUserID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entity.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByAgentIDAndPolicyIDAndUserID indicates an expected call of FindOneByAgentIDAndPolicyIDAndUserID.
+func (mr *MockPermissionRepositoryMockRecorder) FindOneByAgentIDAndPolicyIDAndUserID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByAgentIDAndPolicyIDAndUserID", reflect.TypeOf((*MockPermissionRepository)(nil).FindOneByAgentIDAndPolicyIDAndUserID), arg0, arg1, arg2, arg3)
+}
+
+// Save mocks base method.
+func (m *MockPermissionRepository) Save(arg0 context.Context, arg1 *entity.Permission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPermissionRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPermissionRepository)(nil).Save), arg0, arg1)
+}
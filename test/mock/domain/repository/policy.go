@@ -109,6 +109,21 @@ func (mr *MockPolicyRepositoryMockRecorder) FindByNamePrefixAndUserIDAndNotDelet
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByNamePrefixAndUserIDAndNotDeleted", reflect.TypeOf((*MockPolicyRepository)(nil).FindByNamePrefixAndUserIDAndNotDeleted), arg0, arg1, arg2)
 }
 
+// FindByUserIDAndNotDeletedForUpdate mocks base method.
+func (m *MockPolicyRepository) FindByUserIDAndNotDeletedForUpdate(arg0 context.Context, arg1 uuid.UUID) ([]*entity.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserIDAndNotDeletedForUpdate", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserIDAndNotDeletedForUpdate indicates an expected call of FindByUserIDAndNotDeletedForUpdate.
+func (mr *MockPolicyRepositoryMockRecorder) FindByUserIDAndNotDeletedForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserIDAndNotDeletedForUpdate", reflect.TypeOf((*MockPolicyRepository)(nil).FindByUserIDAndNotDeletedForUpdate), arg0, arg1)
+}
+
 // FindOneByIDAndUserIDAndNotDeleted mocks base method.
 func (m *MockPolicyRepository) FindOneByIDAndUserIDAndNotDeleted(arg0 context.Context, arg1, arg2 uuid.UUID) (*entity.Policy, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +139,51 @@ func (mr *MockPolicyRepositoryMockRecorder) FindOneByIDAndUserIDAndNotDeleted(ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByIDAndUserIDAndNotDeleted", reflect.TypeOf((*MockPolicyRepository)(nil).FindOneByIDAndUserIDAndNotDeleted), arg0, arg1, arg2)
 }
 
+// FindOneByIDAndUserIDAndNotDeletedForUpdate mocks base method.
+func (m *MockPolicyRepository) FindOneByIDAndUserIDAndNotDeletedForUpdate(arg0 context.Context, arg1, arg2 uuid.UUID) (*entity.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByIDAndUserIDAndNotDeletedForUpdate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByIDAndUserIDAndNotDeletedForUpdate indicates an expected call of FindOneByIDAndUserIDAndNotDeletedForUpdate.
+func (mr *MockPolicyRepositoryMockRecorder) FindOneByIDAndUserIDAndNotDeletedForUpdate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByIDAndUserIDAndNotDeletedForUpdate", reflect.TypeOf((*MockPolicyRepository)(nil).FindOneByIDAndUserIDAndNotDeletedForUpdate), arg0, arg1, arg2)
+}
+
+// FindOneByNameAndUserIDAndNotDeleted mocks base method.
+func (m *MockPolicyRepository) FindOneByNameAndUserIDAndNotDeleted(arg0 context.Context, arg1 string, arg2 uuid.UUID) (*entity.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByNameAndUserIDAndNotDeleted", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByNameAndUserIDAndNotDeleted indicates an expected call of FindOneByNameAndUserIDAndNotDeleted.
+func (mr *MockPolicyRepositoryMockRecorder) FindOneByNameAndUserIDAndNotDeleted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByNameAndUserIDAndNotDeleted", reflect.TypeOf((*MockPolicyRepository)(nil).FindOneByNameAndUserIDAndNotDeleted), arg0, arg1, arg2)
+}
+
+// FindUnattachedByUserIDAndNotDeleted mocks base method.
+func (m *MockPolicyRepository) FindUnattachedByUserIDAndNotDeleted(arg0 context.Context, arg1 uuid.UUID) ([]*entity.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUnattachedByUserIDAndNotDeleted", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUnattachedByUserIDAndNotDeleted indicates an expected call of FindUnattachedByUserIDAndNotDeleted.
+func (mr *MockPolicyRepositoryMockRecorder) FindUnattachedByUserIDAndNotDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUnattachedByUserIDAndNotDeleted", reflect.TypeOf((*MockPolicyRepository)(nil).FindUnattachedByUserIDAndNotDeleted), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockPolicyRepository) Update(arg0 context.Context, arg1 *entity.Policy) error {
 	m.ctrl.T.Helper()
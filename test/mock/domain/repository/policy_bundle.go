@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: policy_bundle.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "holos-auth-api/internal/app/api/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockPolicyBundleRepository is a mock of PolicyBundleRepository interface.
+type MockPolicyBundleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyBundleRepositoryMockRecorder
+}
+
+// MockPolicyBundleRepositoryMockRecorder is the mock recorder for MockPolicyBundleRepository.
+type MockPolicyBundleRepositoryMockRecorder struct {
+	mock *MockPolicyBundleRepository
+}
+
+// NewMockPolicyBundleRepository creates a new mock instance.
+func NewMockPolicyBundleRepository(ctrl *gomock.Controller) *MockPolicyBundleRepository {
+	mock := &MockPolicyBundleRepository{ctrl: ctrl}
+	mock.recorder = &MockPolicyBundleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyBundleRepository) EXPECT() *MockPolicyBundleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPolicyBundleRepository) Create(arg0 context.Context, arg1 *entity.PolicyBundle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPolicyBundleRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPolicyBundleRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockPolicyBundleRepository) Delete(arg0 context.Context, arg1 *entity.PolicyBundle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPolicyBundleRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPolicyBundleRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindByIDsAndUserIDAndNotDeleted mocks base method.
+func (m *MockPolicyBundleRepository) FindByIDsAndUserIDAndNotDeleted(arg0 context.Context, arg1 []uuid.UUID, arg2 uuid.UUID) ([]*entity.PolicyBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDsAndUserIDAndNotDeleted", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.PolicyBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDsAndUserIDAndNotDeleted indicates an expected call of FindByIDsAndUserIDAndNotDeleted.
+func (mr *MockPolicyBundleRepositoryMockRecorder) FindByIDsAndUserIDAndNotDeleted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDsAndUserIDAndNotDeleted", reflect.TypeOf((*MockPolicyBundleRepository)(nil).FindByIDsAndUserIDAndNotDeleted), arg0, arg1, arg2)
+}
+
+// FindByNamePrefixAndUserIDAndNotDeleted mocks base method.
+func (m *MockPolicyBundleRepository) FindByNamePrefixAndUserIDAndNotDeleted(arg0 context.Context, arg1 string, arg2 uuid.UUID) ([]*entity.PolicyBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByNamePrefixAndUserIDAndNotDeleted", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.PolicyBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByNamePrefixAndUserIDAndNotDeleted indicates an expected call of FindByNamePrefixAndUserIDAndNotDeleted.
+func (mr *MockPolicyBundleRepositoryMockRecorder) FindByNamePrefixAndUserIDAndNotDeleted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByNamePrefixAndUserIDAndNotDeleted", reflect.TypeOf((*MockPolicyBundleRepository)(nil).FindByNamePrefixAndUserIDAndNotDeleted), arg0, arg1, arg2)
+}
+
+// FindOneByIDAndUserIDAndNotDeleted mocks base method.
+func (m *MockPolicyBundleRepository) FindOneByIDAndUserIDAndNotDeleted(arg0 context.Context, arg1, arg2 uuid.UUID) (*entity.PolicyBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByIDAndUserIDAndNotDeleted", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.PolicyBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByIDAndUserIDAndNotDeleted indicates an expected call of FindOneByIDAndUserIDAndNotDeleted.
+func (mr *MockPolicyBundleRepositoryMockRecorder) FindOneByIDAndUserIDAndNotDeleted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByIDAndUserIDAndNotDeleted", reflect.TypeOf((*MockPolicyBundleRepository)(nil).FindOneByIDAndUserIDAndNotDeleted), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockPolicyBundleRepository) Update(arg0 context.Context, arg1 *entity.PolicyBundle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPolicyBundleRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPolicyBundleRepository)(nil).Update), arg0, arg1)
+}
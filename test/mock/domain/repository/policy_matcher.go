@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: policy_matcher.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "holos-auth-api/internal/app/api/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPolicyMatcherRepository is a mock of PolicyMatcherRepository interface.
+type MockPolicyMatcherRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyMatcherRepositoryMockRecorder
+}
+
+// MockPolicyMatcherRepositoryMockRecorder is the mock recorder for MockPolicyMatcherRepository.
+type MockPolicyMatcherRepositoryMockRecorder struct {
+	mock *MockPolicyMatcherRepository
+}
+
+// NewMockPolicyMatcherRepository creates a new mock instance.
+func NewMockPolicyMatcherRepository(ctrl *gomock.Controller) *MockPolicyMatcherRepository {
+	mock := &MockPolicyMatcherRepository{ctrl: ctrl}
+	mock.recorder = &MockPolicyMatcherRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyMatcherRepository) EXPECT() *MockPolicyMatcherRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteAll mocks base method.
+func (m *MockPolicyMatcherRepository) DeleteAll(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAll", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAll indicates an expected call of DeleteAll.
+func (mr *MockPolicyMatcherRepositoryMockRecorder) DeleteAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockPolicyMatcherRepository)(nil).DeleteAll), arg0)
+}
+
+// FindOneByKeyAndNotExpired mocks base method.
+func (m *MockPolicyMatcherRepository) FindOneByKeyAndNotExpired(arg0 context.Context, arg1 string) (*entity.PolicyMatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByKeyAndNotExpired", arg0, arg1)
+	ret0, _ := ret[0].(*entity.PolicyMatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByKeyAndNotExpired indicates an expected call of FindOneByKeyAndNotExpired.
+func (mr *MockPolicyMatcherRepositoryMockRecorder) FindOneByKeyAndNotExpired(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByKeyAndNotExpired", reflect.TypeOf((*MockPolicyMatcherRepository)(nil).FindOneByKeyAndNotExpired), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockPolicyMatcherRepository) Save(arg0 context.Context, arg1 *entity.PolicyMatcher) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPolicyMatcherRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPolicyMatcherRepository)(nil).Save), arg0, arg1)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: policy_version.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "holos-auth-api/internal/app/api/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockPolicyVersionRepository is a mock of PolicyVersionRepository interface.
+type MockPolicyVersionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyVersionRepositoryMockRecorder
+}
+
+// MockPolicyVersionRepositoryMockRecorder is the mock recorder for MockPolicyVersionRepository.
+type MockPolicyVersionRepositoryMockRecorder struct {
+	mock *MockPolicyVersionRepository
+}
+
+// NewMockPolicyVersionRepository creates a new mock instance.
+func NewMockPolicyVersionRepository(ctrl *gomock.Controller) *MockPolicyVersionRepository {
+	mock := &MockPolicyVersionRepository{ctrl: ctrl}
+	mock.recorder = &MockPolicyVersionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyVersionRepository) EXPECT() *MockPolicyVersionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPolicyVersionRepository) Create(arg0 context.Context, arg1 *entity.PolicyVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPolicyVersionRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPolicyVersionRepository)(nil).Create), arg0, arg1)
+}
+
+// FindByPolicyIDAndUserID mocks base method.
+func (m *MockPolicyVersionRepository) FindByPolicyIDAndUserID(arg0 context.Context, arg1, arg2 uuid.UUID) ([]*entity.PolicyVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPolicyIDAndUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.PolicyVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPolicyIDAndUserID indicates an expected call of FindByPolicyIDAndUserID.
+func (mr *MockPolicyVersionRepositoryMockRecorder) FindByPolicyIDAndUserID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPolicyIDAndUserID", reflect.TypeOf((*MockPolicyVersionRepository)(nil).FindByPolicyIDAndUserID), arg0, arg1, arg2)
+}
+
+// FindOneByPolicyIDAndVersionAndUserID mocks base method.
+func (m *MockPolicyVersionRepository) FindOneByPolicyIDAndVersionAndUserID(arg0 context.Context, arg1 uuid.UUID, arg2 int, arg3 uuid.UUID) (*entity.PolicyVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByPolicyIDAndVersionAndUserID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entity.PolicyVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByPolicyIDAndVersionAndUserID indicates an expected call of FindOneByPolicyIDAndVersionAndUserID.
+func (mr *MockPolicyVersionRepositoryMockRecorder) FindOneByPolicyIDAndVersionAndUserID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByPolicyIDAndVersionAndUserID", reflect.TypeOf((*MockPolicyVersionRepository)(nil).FindOneByPolicyIDAndVersionAndUserID), arg0, arg1, arg2, arg3)
+}
+
+// FindOneLatestByPolicyID mocks base method.
+func (m *MockPolicyVersionRepository) FindOneLatestByPolicyID(arg0 context.Context, arg1 uuid.UUID) (*entity.PolicyVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneLatestByPolicyID", arg0, arg1)
+	ret0, _ := ret[0].(*entity.PolicyVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneLatestByPolicyID indicates an expected call of FindOneLatestByPolicyID.
+func (mr *MockPolicyVersionRepositoryMockRecorder) FindOneLatestByPolicyID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneLatestByPolicyID", reflect.TypeOf((*MockPolicyVersionRepository)(nil).FindOneLatestByPolicyID), arg0, arg1)
+}
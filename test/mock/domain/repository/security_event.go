@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: security_event.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "holos-auth-api/internal/app/api/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockSecurityEventRepository is a mock of SecurityEventRepository interface.
+type MockSecurityEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecurityEventRepositoryMockRecorder
+}
+
+// MockSecurityEventRepositoryMockRecorder is the mock recorder for MockSecurityEventRepository.
+type MockSecurityEventRepositoryMockRecorder struct {
+	mock *MockSecurityEventRepository
+}
+
+// NewMockSecurityEventRepository creates a new mock instance.
+func NewMockSecurityEventRepository(ctrl *gomock.Controller) *MockSecurityEventRepository {
+	mock := &MockSecurityEventRepository{ctrl: ctrl}
+	mock.recorder = &MockSecurityEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecurityEventRepository) EXPECT() *MockSecurityEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSecurityEventRepository) Create(arg0 context.Context, arg1 *entity.SecurityEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSecurityEventRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSecurityEventRepository)(nil).Create), arg0, arg1)
+}
+
+// FindByUserID mocks base method.
+func (m *MockSecurityEventRepository) FindByUserID(arg0 context.Context, arg1 uuid.UUID) ([]*entity.SecurityEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.SecurityEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockSecurityEventRepositoryMockRecorder) FindByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockSecurityEventRepository)(nil).FindByUserID), arg0, arg1)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "holos-auth-api/internal/app/api/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockServiceRepository is a mock of ServiceRepository interface.
+type MockServiceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceRepositoryMockRecorder
+}
+
+// MockServiceRepositoryMockRecorder is the mock recorder for MockServiceRepository.
+type MockServiceRepositoryMockRecorder struct {
+	mock *MockServiceRepository
+}
+
+// NewMockServiceRepository creates a new mock instance.
+func NewMockServiceRepository(ctrl *gomock.Controller) *MockServiceRepository {
+	mock := &MockServiceRepository{ctrl: ctrl}
+	mock.recorder = &MockServiceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceRepository) EXPECT() *MockServiceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockServiceRepository) Create(arg0 context.Context, arg1 *entity.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockServiceRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockServiceRepository) Delete(arg0 context.Context, arg1 *entity.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockServiceRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindByNamePrefixAndUserIDAndNotDeleted mocks base method.
+func (m *MockServiceRepository) FindByNamePrefixAndUserIDAndNotDeleted(arg0 context.Context, arg1 string, arg2 uuid.UUID) ([]*entity.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByNamePrefixAndUserIDAndNotDeleted", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByNamePrefixAndUserIDAndNotDeleted indicates an expected call of FindByNamePrefixAndUserIDAndNotDeleted.
+func (mr *MockServiceRepositoryMockRecorder) FindByNamePrefixAndUserIDAndNotDeleted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByNamePrefixAndUserIDAndNotDeleted", reflect.TypeOf((*MockServiceRepository)(nil).FindByNamePrefixAndUserIDAndNotDeleted), arg0, arg1, arg2)
+}
+
+// FindOneByIDAndUserIDAndNotDeleted mocks base method.
+func (m *MockServiceRepository) FindOneByIDAndUserIDAndNotDeleted(arg0 context.Context, arg1, arg2 uuid.UUID) (*entity.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByIDAndUserIDAndNotDeleted", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByIDAndUserIDAndNotDeleted indicates an expected call of FindOneByIDAndUserIDAndNotDeleted.
+func (mr *MockServiceRepositoryMockRecorder) FindOneByIDAndUserIDAndNotDeleted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByIDAndUserIDAndNotDeleted", reflect.TypeOf((*MockServiceRepository)(nil).FindOneByIDAndUserIDAndNotDeleted), arg0, arg1, arg2)
+}
+
+// FindOneByNameAndUserIDAndNotDeleted mocks base method.
+func (m *MockServiceRepository) FindOneByNameAndUserIDAndNotDeleted(arg0 context.Context, arg1 string, arg2 uuid.UUID) (*entity.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByNameAndUserIDAndNotDeleted", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByNameAndUserIDAndNotDeleted indicates an expected call of FindOneByNameAndUserIDAndNotDeleted.
+func (mr *MockServiceRepositoryMockRecorder) FindOneByNameAndUserIDAndNotDeleted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByNameAndUserIDAndNotDeleted", reflect.TypeOf((*MockServiceRepository)(nil).FindOneByNameAndUserIDAndNotDeleted), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockServiceRepository) Update(arg0 context.Context, arg1 *entity.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockServiceRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockServiceRepository)(nil).Update), arg0, arg1)
+}
@@ -35,6 +35,66 @@ func (m *MockAgentService) EXPECT() *MockAgentServiceMockRecorder {
 	return m.recorder
 }
 
+// Evaluate mocks base method.
+func (m *MockAgentService) Evaluate(arg0 context.Context, arg1 *entity.Agent, arg2 *entity.AuthorizationRequest) (*entity.AuthorizationDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evaluate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.AuthorizationDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockAgentServiceMockRecorder) Evaluate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockAgentService)(nil).Evaluate), arg0, arg1, arg2)
+}
+
+// EvaluateBatch mocks base method.
+func (m *MockAgentService) EvaluateBatch(arg0 context.Context, arg1 *entity.Agent, arg2 []*entity.AuthorizationRequest) ([]*entity.AuthorizationDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvaluateBatch", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.AuthorizationDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EvaluateBatch indicates an expected call of EvaluateBatch.
+func (mr *MockAgentServiceMockRecorder) EvaluateBatch(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateBatch", reflect.TypeOf((*MockAgentService)(nil).EvaluateBatch), arg0, arg1, arg2)
+}
+
+// Expand mocks base method.
+func (m *MockAgentService) Expand(arg0 context.Context, arg1 *entity.Agent) (*entity.Agent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expand", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Agent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Expand indicates an expected call of Expand.
+func (mr *MockAgentServiceMockRecorder) Expand(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expand", reflect.TypeOf((*MockAgentService)(nil).Expand), arg0, arg1)
+}
+
+// GetEffectivePolicies mocks base method.
+func (m *MockAgentService) GetEffectivePolicies(arg0 context.Context, arg1 *entity.Agent) ([]*entity.EffectivePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectivePolicies", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.EffectivePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffectivePolicies indicates an expected call of GetEffectivePolicies.
+func (mr *MockAgentServiceMockRecorder) GetEffectivePolicies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectivePolicies", reflect.TypeOf((*MockAgentService)(nil).GetEffectivePolicies), arg0, arg1)
+}
+
 // GetPolicies mocks base method.
 func (m *MockAgentService) GetPolicies(arg0 context.Context, arg1 *entity.Agent, arg2 string) ([]*entity.Policy, error) {
 	m.ctrl.T.Helper()
@@ -51,16 +111,16 @@ func (mr *MockAgentServiceMockRecorder) GetPolicies(arg0, arg1, arg2 interface{}
 }
 
 // HasPermission mocks base method.
-func (m *MockAgentService) HasPermission(arg0 context.Context, arg1 *entity.Agent, arg2, arg3, arg4 string) (bool, error) {
+func (m *MockAgentService) HasPermission(arg0 context.Context, arg1 *entity.Agent, arg2 *entity.AuthorizationRequest) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HasPermission", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "HasPermission", arg0, arg1, arg2)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // HasPermission indicates an expected call of HasPermission.
-func (mr *MockAgentServiceMockRecorder) HasPermission(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockAgentServiceMockRecorder) HasPermission(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPermission", reflect.TypeOf((*MockAgentService)(nil).HasPermission), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPermission", reflect.TypeOf((*MockAgentService)(nil).HasPermission), arg0, arg1, arg2)
 }
@@ -49,3 +49,17 @@ func (mr *MockPolicyServiceMockRecorder) GetAgents(arg0, arg1, arg2 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgents", reflect.TypeOf((*MockPolicyService)(nil).GetAgents), arg0, arg1, arg2)
 }
+
+// ValidateService mocks base method.
+func (m *MockPolicyService) ValidateService(arg0 context.Context, arg1 *entity.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateService", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateService indicates an expected call of ValidateService.
+func (mr *MockPolicyServiceMockRecorder) ValidateService(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateService", reflect.TypeOf((*MockPolicyService)(nil).ValidateService), arg0, arg1)
+}
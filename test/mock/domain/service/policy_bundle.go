@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: policy_bundle.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	entity "holos-auth-api/internal/app/api/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPolicyBundleService is a mock of PolicyBundleService interface.
+type MockPolicyBundleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyBundleServiceMockRecorder
+}
+
+// MockPolicyBundleServiceMockRecorder is the mock recorder for MockPolicyBundleService.
+type MockPolicyBundleServiceMockRecorder struct {
+	mock *MockPolicyBundleService
+}
+
+// NewMockPolicyBundleService creates a new mock instance.
+func NewMockPolicyBundleService(ctrl *gomock.Controller) *MockPolicyBundleService {
+	mock := &MockPolicyBundleService{ctrl: ctrl}
+	mock.recorder = &MockPolicyBundleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyBundleService) EXPECT() *MockPolicyBundleServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAgents mocks base method.
+func (m *MockPolicyBundleService) GetAgents(arg0 context.Context, arg1 *entity.PolicyBundle, arg2 string) ([]*entity.Agent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAgents", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.Agent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAgents indicates an expected call of GetAgents.
+func (mr *MockPolicyBundleServiceMockRecorder) GetAgents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgents", reflect.TypeOf((*MockPolicyBundleService)(nil).GetAgents), arg0, arg1, arg2)
+}
+
+// GetPolicies mocks base method.
+func (m *MockPolicyBundleService) GetPolicies(arg0 context.Context, arg1 *entity.PolicyBundle, arg2 string) ([]*entity.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicies", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicies indicates an expected call of GetPolicies.
+func (mr *MockPolicyBundleServiceMockRecorder) GetPolicies(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicies", reflect.TypeOf((*MockPolicyBundleService)(nil).GetPolicies), arg0, arg1, arg2)
+}
@@ -36,6 +36,21 @@ func (m *MockAgentUsecase) EXPECT() *MockAgentUsecaseMockRecorder {
 	return m.recorder
 }
 
+// BindCertificate mocks base method.
+func (m *MockAgentUsecase) BindCertificate(arg0 context.Context, arg1, arg2 uuid.UUID, arg3, arg4 string) (*dto.AgentCertificateDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BindCertificate", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*dto.AgentCertificateDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BindCertificate indicates an expected call of BindCertificate.
+func (mr *MockAgentUsecaseMockRecorder) BindCertificate(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindCertificate", reflect.TypeOf((*MockAgentUsecase)(nil).BindCertificate), arg0, arg1, arg2, arg3, arg4)
+}
+
 // Create mocks base method.
 func (m *MockAgentUsecase) Create(arg0 context.Context, arg1 uuid.UUID, arg2 string) (*dto.AgentDTO, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +66,21 @@ func (mr *MockAgentUsecaseMockRecorder) Create(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAgentUsecase)(nil).Create), arg0, arg1, arg2)
 }
 
+// CreatePublicKey mocks base method.
+func (m *MockAgentUsecase) CreatePublicKey(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 string) (*dto.AgentPublicKeyDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePublicKey", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.AgentPublicKeyDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePublicKey indicates an expected call of CreatePublicKey.
+func (mr *MockAgentUsecaseMockRecorder) CreatePublicKey(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePublicKey", reflect.TypeOf((*MockAgentUsecase)(nil).CreatePublicKey), arg0, arg1, arg2, arg3)
+}
+
 // Delete mocks base method.
 func (m *MockAgentUsecase) Delete(arg0 context.Context, arg1, arg2 uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -65,6 +95,34 @@ func (mr *MockAgentUsecaseMockRecorder) Delete(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAgentUsecase)(nil).Delete), arg0, arg1, arg2)
 }
 
+// DeletePublicKey mocks base method.
+func (m *MockAgentUsecase) DeletePublicKey(arg0 context.Context, arg1, arg2, arg3 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublicKey", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePublicKey indicates an expected call of DeletePublicKey.
+func (mr *MockAgentUsecaseMockRecorder) DeletePublicKey(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublicKey", reflect.TypeOf((*MockAgentUsecase)(nil).DeletePublicKey), arg0, arg1, arg2, arg3)
+}
+
+// DeleteSecret mocks base method.
+func (m *MockAgentUsecase) DeleteSecret(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecret", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret.
+func (mr *MockAgentUsecaseMockRecorder) DeleteSecret(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockAgentUsecase)(nil).DeleteSecret), arg0, arg1, arg2)
+}
+
 // DeleteToken mocks base method.
 func (m *MockAgentUsecase) DeleteToken(arg0 context.Context, arg1, arg2 uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -79,6 +137,21 @@ func (mr *MockAgentUsecaseMockRecorder) DeleteToken(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToken", reflect.TypeOf((*MockAgentUsecase)(nil).DeleteToken), arg0, arg1, arg2)
 }
 
+// GenerateSecret mocks base method.
+func (m *MockAgentUsecase) GenerateSecret(arg0 context.Context, arg1, arg2 uuid.UUID) (*dto.AgentSecretDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSecret", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.AgentSecretDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSecret indicates an expected call of GenerateSecret.
+func (mr *MockAgentUsecaseMockRecorder) GenerateSecret(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSecret", reflect.TypeOf((*MockAgentUsecase)(nil).GenerateSecret), arg0, arg1, arg2)
+}
+
 // GenerateToken mocks base method.
 func (m *MockAgentUsecase) GenerateToken(arg0 context.Context, arg1, arg2 uuid.UUID) (string, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +182,51 @@ func (mr *MockAgentUsecaseMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAgentUsecase)(nil).Get), arg0, arg1, arg2)
 }
 
+// GetAttributes mocks base method.
+func (m *MockAgentUsecase) GetAttributes(arg0 context.Context, arg1, arg2 uuid.UUID) (*dto.AgentAttributesDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttributes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.AgentAttributesDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttributes indicates an expected call of GetAttributes.
+func (mr *MockAgentUsecaseMockRecorder) GetAttributes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttributes", reflect.TypeOf((*MockAgentUsecase)(nil).GetAttributes), arg0, arg1, arg2)
+}
+
+// GetCertificate mocks base method.
+func (m *MockAgentUsecase) GetCertificate(arg0 context.Context, arg1, arg2 uuid.UUID) (*dto.AgentCertificateDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertificate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.AgentCertificateDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCertificate indicates an expected call of GetCertificate.
+func (mr *MockAgentUsecaseMockRecorder) GetCertificate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificate", reflect.TypeOf((*MockAgentUsecase)(nil).GetCertificate), arg0, arg1, arg2)
+}
+
+// GetEffectivePolicies mocks base method.
+func (m *MockAgentUsecase) GetEffectivePolicies(arg0 context.Context, arg1, arg2 uuid.UUID) ([]*dto.EffectivePolicyDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectivePolicies", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*dto.EffectivePolicyDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffectivePolicies indicates an expected call of GetEffectivePolicies.
+func (mr *MockAgentUsecaseMockRecorder) GetEffectivePolicies(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectivePolicies", reflect.TypeOf((*MockAgentUsecase)(nil).GetEffectivePolicies), arg0, arg1, arg2)
+}
+
 // GetPolicies mocks base method.
 func (m *MockAgentUsecase) GetPolicies(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 string) ([]*dto.PolicyDTO, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +242,36 @@ func (mr *MockAgentUsecaseMockRecorder) GetPolicies(arg0, arg1, arg2, arg3 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicies", reflect.TypeOf((*MockAgentUsecase)(nil).GetPolicies), arg0, arg1, arg2, arg3)
 }
 
+// GetPublicKeys mocks base method.
+func (m *MockAgentUsecase) GetPublicKeys(arg0 context.Context, arg1, arg2 uuid.UUID) ([]*dto.AgentPublicKeyDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicKeys", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*dto.AgentPublicKeyDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKeys indicates an expected call of GetPublicKeys.
+func (mr *MockAgentUsecaseMockRecorder) GetPublicKeys(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKeys", reflect.TypeOf((*MockAgentUsecase)(nil).GetPublicKeys), arg0, arg1, arg2)
+}
+
+// GetSecret mocks base method.
+func (m *MockAgentUsecase) GetSecret(arg0 context.Context, arg1, arg2 uuid.UUID) (*dto.AgentSecretDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.AgentSecretDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret.
+func (mr *MockAgentUsecaseMockRecorder) GetSecret(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockAgentUsecase)(nil).GetSecret), arg0, arg1, arg2)
+}
+
 // GetToken mocks base method.
 func (m *MockAgentUsecase) GetToken(arg0 context.Context, arg1, arg2 uuid.UUID) (*dto.AgentTokenDTO, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +302,35 @@ func (mr *MockAgentUsecaseMockRecorder) Gets(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gets", reflect.TypeOf((*MockAgentUsecase)(nil).Gets), arg0, arg1, arg2)
 }
 
+// Simulate mocks base method.
+func (m *MockAgentUsecase) Simulate(arg0 context.Context, arg1, arg2 uuid.UUID, arg3, arg4, arg5, arg6 string, arg7 map[string]string) (*dto.AuthorizationDecisionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Simulate", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret0, _ := ret[0].(*dto.AuthorizationDecisionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Simulate indicates an expected call of Simulate.
+func (mr *MockAgentUsecaseMockRecorder) Simulate(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Simulate", reflect.TypeOf((*MockAgentUsecase)(nil).Simulate), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
+
+// UnbindCertificate mocks base method.
+func (m *MockAgentUsecase) UnbindCertificate(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbindCertificate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnbindCertificate indicates an expected call of UnbindCertificate.
+func (mr *MockAgentUsecaseMockRecorder) UnbindCertificate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbindCertificate", reflect.TypeOf((*MockAgentUsecase)(nil).UnbindCertificate), arg0, arg1, arg2)
+}
+
 // Update mocks base method.
 func (m *MockAgentUsecase) Update(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 string) (*dto.AgentDTO, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +346,21 @@ func (mr *MockAgentUsecaseMockRecorder) Update(arg0, arg1, arg2, arg3 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAgentUsecase)(nil).Update), arg0, arg1, arg2, arg3)
 }
 
+// UpdateAttributes mocks base method.
+func (m *MockAgentUsecase) UpdateAttributes(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 map[string]string) (*dto.AgentAttributesDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAttributes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.AgentAttributesDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAttributes indicates an expected call of UpdateAttributes.
+func (mr *MockAgentUsecaseMockRecorder) UpdateAttributes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAttributes", reflect.TypeOf((*MockAgentUsecase)(nil).UpdateAttributes), arg0, arg1, arg2, arg3)
+}
+
 // UpdatePolicies mocks base method.
 func (m *MockAgentUsecase) UpdatePolicies(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 []uuid.UUID) ([]*dto.PolicyDTO, error) {
 	m.ctrl.T.Helper()
@@ -6,7 +6,9 @@ package mock_usecase
 
 import (
 	context "context"
+	dto "holos-auth-api/internal/app/api/usecase/dto"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -51,18 +53,107 @@ func (mr *MockAuthUsecaseMockRecorder) Authenticate(arg0, arg1 interface{}) *gom
 }
 
 // Authorize mocks base method.
-func (m *MockAuthUsecase) Authorize(arg0 context.Context, arg1, arg2, arg3, arg4, arg5 string) (uuid.UUID, error) {
+func (m *MockAuthUsecase) Authorize(arg0 context.Context, arg1, arg2, arg3, arg4, arg5, arg6 string, arg7 map[string]string) (*dto.AuthorizationResultDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Authorize", arg0, arg1, arg2, arg3, arg4, arg5)
-	ret0, _ := ret[0].(uuid.UUID)
+	ret := m.ctrl.Call(m, "Authorize", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret0, _ := ret[0].(*dto.AuthorizationResultDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Authorize indicates an expected call of Authorize.
-func (mr *MockAuthUsecaseMockRecorder) Authorize(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+func (mr *MockAuthUsecaseMockRecorder) Authorize(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockAuthUsecase)(nil).Authorize), arg0, arg1, arg2, arg3, arg4, arg5)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockAuthUsecase)(nil).Authorize), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
+
+// AuthorizeBatch mocks base method.
+func (m *MockAuthUsecase) AuthorizeBatch(arg0 context.Context, arg1, arg2 string, arg3 []*dto.AuthorizationRequestDTO) ([]*dto.AuthorizationResultDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeBatch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*dto.AuthorizationResultDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeBatch indicates an expected call of AuthorizeBatch.
+func (mr *MockAuthUsecaseMockRecorder) AuthorizeBatch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeBatch", reflect.TypeOf((*MockAuthUsecase)(nil).AuthorizeBatch), arg0, arg1, arg2, arg3)
+}
+
+// AuthorizeByAssertion mocks base method.
+func (m *MockAuthUsecase) AuthorizeByAssertion(arg0 context.Context, arg1, arg2, arg3, arg4, arg5 string, arg6 map[string]string) (*dto.AuthorizationResultDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeByAssertion", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*dto.AuthorizationResultDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeByAssertion indicates an expected call of AuthorizeByAssertion.
+func (mr *MockAuthUsecaseMockRecorder) AuthorizeByAssertion(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeByAssertion", reflect.TypeOf((*MockAuthUsecase)(nil).AuthorizeByAssertion), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// AuthorizeByCertificate mocks base method.
+func (m *MockAuthUsecase) AuthorizeByCertificate(arg0 context.Context, arg1, arg2, arg3, arg4, arg5, arg6 string, arg7 map[string]string) (*dto.AuthorizationResultDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeByCertificate", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret0, _ := ret[0].(*dto.AuthorizationResultDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeByCertificate indicates an expected call of AuthorizeByCertificate.
+func (mr *MockAuthUsecaseMockRecorder) AuthorizeByCertificate(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeByCertificate", reflect.TypeOf((*MockAuthUsecase)(nil).AuthorizeByCertificate), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
+
+// AuthorizeBySignature mocks base method.
+func (m *MockAuthUsecase) AuthorizeBySignature(arg0 context.Context, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9 string, arg10 map[string]string) (*dto.AuthorizationResultDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeBySignature", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
+	ret0, _ := ret[0].(*dto.AuthorizationResultDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeBySignature indicates an expected call of AuthorizeBySignature.
+func (mr *MockAuthUsecaseMockRecorder) AuthorizeBySignature(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeBySignature", reflect.TypeOf((*MockAuthUsecase)(nil).AuthorizeBySignature), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
+}
+
+// CreateDelegatedToken mocks base method.
+func (m *MockAuthUsecase) CreateDelegatedToken(arg0 context.Context, arg1 string, arg2 []uuid.UUID, arg3 time.Duration) (*dto.AgentDelegatedTokenDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelegatedToken", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.AgentDelegatedTokenDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDelegatedToken indicates an expected call of CreateDelegatedToken.
+func (mr *MockAuthUsecaseMockRecorder) CreateDelegatedToken(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelegatedToken", reflect.TypeOf((*MockAuthUsecase)(nil).CreateDelegatedToken), arg0, arg1, arg2, arg3)
+}
+
+// Reauthenticate mocks base method.
+func (m *MockAuthUsecase) Reauthenticate(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reauthenticate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reauthenticate indicates an expected call of Reauthenticate.
+func (mr *MockAuthUsecaseMockRecorder) Reauthenticate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reauthenticate", reflect.TypeOf((*MockAuthUsecase)(nil).Reauthenticate), arg0, arg1, arg2)
 }
 
 // Signin mocks base method.
@@ -93,3 +184,17 @@ func (mr *MockAuthUsecaseMockRecorder) Signout(arg0, arg1 interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signout", reflect.TypeOf((*MockAuthUsecase)(nil).Signout), arg0, arg1)
 }
+
+// VerifyRecentAuthentication mocks base method.
+func (m *MockAuthUsecase) VerifyRecentAuthentication(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyRecentAuthentication", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyRecentAuthentication indicates an expected call of VerifyRecentAuthentication.
+func (mr *MockAuthUsecaseMockRecorder) VerifyRecentAuthentication(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyRecentAuthentication", reflect.TypeOf((*MockAuthUsecase)(nil).VerifyRecentAuthentication), arg0, arg1)
+}
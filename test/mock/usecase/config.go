@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: config.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	dto "holos-auth-api/internal/app/api/usecase/dto"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockConfigUsecase is a mock of ConfigUsecase interface.
+type MockConfigUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockConfigUsecaseMockRecorder
+}
+
+// MockConfigUsecaseMockRecorder is the mock recorder for MockConfigUsecase.
+type MockConfigUsecaseMockRecorder struct {
+	mock *MockConfigUsecase
+}
+
+// NewMockConfigUsecase creates a new mock instance.
+func NewMockConfigUsecase(ctrl *gomock.Controller) *MockConfigUsecase {
+	mock := &MockConfigUsecase{ctrl: ctrl}
+	mock.recorder = &MockConfigUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConfigUsecase) EXPECT() *MockConfigUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockConfigUsecase) Apply(arg0 context.Context, arg1 uuid.UUID, arg2 *dto.PolicyDocumentDTO, arg3 string) (*dto.ConfigPlanDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.ConfigPlanDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockConfigUsecaseMockRecorder) Apply(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockConfigUsecase)(nil).Apply), arg0, arg1, arg2, arg3)
+}
+
+// Plan mocks base method.
+func (m *MockConfigUsecase) Plan(arg0 context.Context, arg1 uuid.UUID, arg2 *dto.PolicyDocumentDTO) (*dto.ConfigPlanDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Plan", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.ConfigPlanDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Plan indicates an expected call of Plan.
+func (mr *MockConfigUsecaseMockRecorder) Plan(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Plan", reflect.TypeOf((*MockConfigUsecase)(nil).Plan), arg0, arg1, arg2)
+}
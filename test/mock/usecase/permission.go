@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: permission.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	dto "holos-auth-api/internal/app/api/usecase/dto"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockPermissionUsecase is a mock of PermissionUsecase interface.
+type MockPermissionUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionUsecaseMockRecorder
+}
+
+// MockPermissionUsecaseMockRecorder is the mock recorder for MockPermissionUsecase.
+type MockPermissionUsecaseMockRecorder struct {
+	mock *MockPermissionUsecase
+}
+
+// NewMockPermissionUsecase creates a new mock instance.
+func NewMockPermissionUsecase(ctrl *gomock.Controller) *MockPermissionUsecase {
+	mock := &MockPermissionUsecase{ctrl: ctrl}
+	mock.recorder = &MockPermissionUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionUsecase) EXPECT() *MockPermissionUsecaseMockRecorder {
+	return m.recorder
+}
+
+// DeleteExpired mocks base method.
+func (m *MockPermissionUsecase) DeleteExpired(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockPermissionUsecaseMockRecorder) DeleteExpired(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockPermissionUsecase)(nil).DeleteExpired), arg0)
+}
+
+// Gets mocks base method.
+func (m *MockPermissionUsecase) Gets(arg0 context.Context, arg1, arg2 uuid.UUID) ([]*dto.PermissionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gets", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*dto.PermissionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Gets indicates an expected call of Gets.
+func (mr *MockPermissionUsecaseMockRecorder) Gets(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gets", reflect.TypeOf((*MockPermissionUsecase)(nil).Gets), arg0, arg1, arg2)
+}
+
+// Grant mocks base method.
+func (m *MockPermissionUsecase) Grant(arg0 context.Context, arg1, arg2, arg3 uuid.UUID, arg4, arg5 *time.Time) (*dto.PermissionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Grant", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*dto.PermissionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Grant indicates an expected call of Grant.
+func (mr *MockPermissionUsecaseMockRecorder) Grant(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Grant", reflect.TypeOf((*MockPermissionUsecase)(nil).Grant), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// Revoke mocks base method.
+func (m *MockPermissionUsecase) Revoke(arg0 context.Context, arg1, arg2, arg3 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockPermissionUsecaseMockRecorder) Revoke(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockPermissionUsecase)(nil).Revoke), arg0, arg1, arg2, arg3)
+}
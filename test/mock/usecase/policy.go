@@ -8,6 +8,7 @@ import (
 	context "context"
 	dto "holos-auth-api/internal/app/api/usecase/dto"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -37,18 +38,18 @@ func (m *MockPolicyUsecase) EXPECT() *MockPolicyUsecaseMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockPolicyUsecase) Create(arg0 context.Context, arg1 uuid.UUID, arg2, arg3, arg4, arg5 string, arg6 []string) (*dto.PolicyDTO, error) {
+func (m *MockPolicyUsecase) Create(arg0 context.Context, arg1 uuid.UUID, arg2, arg3, arg4, arg5 string, arg6 []string, arg7 *dto.PolicyConditionsDTO, arg8 string, arg9, arg10 *time.Time) (*dto.PolicyDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
 	ret0, _ := ret[0].(*dto.PolicyDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockPolicyUsecaseMockRecorder) Create(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+func (mr *MockPolicyUsecaseMockRecorder) Create(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPolicyUsecase)(nil).Create), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPolicyUsecase)(nil).Create), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
 }
 
 // Delete mocks base method.
@@ -65,6 +66,36 @@ func (mr *MockPolicyUsecaseMockRecorder) Delete(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPolicyUsecase)(nil).Delete), arg0, arg1, arg2)
 }
 
+// DiffVersions mocks base method.
+func (m *MockPolicyUsecase) DiffVersions(arg0 context.Context, arg1, arg2 uuid.UUID, arg3, arg4 int) (*dto.PolicyVersionDiffDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffVersions", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*dto.PolicyVersionDiffDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffVersions indicates an expected call of DiffVersions.
+func (mr *MockPolicyUsecaseMockRecorder) DiffVersions(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffVersions", reflect.TypeOf((*MockPolicyUsecase)(nil).DiffVersions), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Export mocks base method.
+func (m *MockPolicyUsecase) Export(arg0 context.Context, arg1 uuid.UUID) (*dto.PolicyDocumentDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1)
+	ret0, _ := ret[0].(*dto.PolicyDocumentDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockPolicyUsecaseMockRecorder) Export(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockPolicyUsecase)(nil).Export), arg0, arg1)
+}
+
 // Get mocks base method.
 func (m *MockPolicyUsecase) Get(arg0 context.Context, arg1, arg2 uuid.UUID) (*dto.PolicyDTO, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +126,21 @@ func (mr *MockPolicyUsecaseMockRecorder) GetAgents(arg0, arg1, arg2, arg3 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgents", reflect.TypeOf((*MockPolicyUsecase)(nil).GetAgents), arg0, arg1, arg2, arg3)
 }
 
+// GetVersions mocks base method.
+func (m *MockPolicyUsecase) GetVersions(arg0 context.Context, arg1, arg2 uuid.UUID) ([]*dto.PolicyVersionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*dto.PolicyVersionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersions indicates an expected call of GetVersions.
+func (mr *MockPolicyUsecaseMockRecorder) GetVersions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersions", reflect.TypeOf((*MockPolicyUsecase)(nil).GetVersions), arg0, arg1, arg2)
+}
+
 // Gets mocks base method.
 func (m *MockPolicyUsecase) Gets(arg0 context.Context, arg1 string, arg2 uuid.UUID) ([]*dto.PolicyDTO, error) {
 	m.ctrl.T.Helper()
@@ -110,19 +156,64 @@ func (mr *MockPolicyUsecaseMockRecorder) Gets(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gets", reflect.TypeOf((*MockPolicyUsecase)(nil).Gets), arg0, arg1, arg2)
 }
 
+// Import mocks base method.
+func (m *MockPolicyUsecase) Import(arg0 context.Context, arg1 uuid.UUID, arg2 *dto.PolicyDocumentDTO, arg3 string) (*dto.PolicyDocumentDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.PolicyDocumentDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockPolicyUsecaseMockRecorder) Import(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockPolicyUsecase)(nil).Import), arg0, arg1, arg2, arg3)
+}
+
+// Lint mocks base method.
+func (m *MockPolicyUsecase) Lint(arg0 context.Context, arg1, arg2 uuid.UUID) ([]*dto.PolicyLintFindingDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lint", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*dto.PolicyLintFindingDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lint indicates an expected call of Lint.
+func (mr *MockPolicyUsecaseMockRecorder) Lint(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lint", reflect.TypeOf((*MockPolicyUsecase)(nil).Lint), arg0, arg1, arg2)
+}
+
+// RestoreVersion mocks base method.
+func (m *MockPolicyUsecase) RestoreVersion(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 int) (*dto.PolicyDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreVersion", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.PolicyDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreVersion indicates an expected call of RestoreVersion.
+func (mr *MockPolicyUsecaseMockRecorder) RestoreVersion(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreVersion", reflect.TypeOf((*MockPolicyUsecase)(nil).RestoreVersion), arg0, arg1, arg2, arg3)
+}
+
 // Update mocks base method.
-func (m *MockPolicyUsecase) Update(arg0 context.Context, arg1, arg2 uuid.UUID, arg3, arg4, arg5, arg6 string, arg7 []string) (*dto.PolicyDTO, error) {
+func (m *MockPolicyUsecase) Update(arg0 context.Context, arg1, arg2 uuid.UUID, arg3, arg4, arg5, arg6 string, arg7 []string, arg8 *dto.PolicyConditionsDTO, arg9 string, arg10, arg11 *time.Time) (*dto.PolicyDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11)
 	ret0, _ := ret[0].(*dto.PolicyDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockPolicyUsecaseMockRecorder) Update(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+func (mr *MockPolicyUsecaseMockRecorder) Update(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPolicyUsecase)(nil).Update), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPolicyUsecase)(nil).Update), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11)
 }
 
 // UpdateAgents mocks base method.
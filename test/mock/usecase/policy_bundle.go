@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: policy_bundle.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	dto "holos-auth-api/internal/app/api/usecase/dto"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockPolicyBundleUsecase is a mock of PolicyBundleUsecase interface.
+type MockPolicyBundleUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyBundleUsecaseMockRecorder
+}
+
+// MockPolicyBundleUsecaseMockRecorder is the mock recorder for MockPolicyBundleUsecase.
+type MockPolicyBundleUsecaseMockRecorder struct {
+	mock *MockPolicyBundleUsecase
+}
+
+// NewMockPolicyBundleUsecase creates a new mock instance.
+func NewMockPolicyBundleUsecase(ctrl *gomock.Controller) *MockPolicyBundleUsecase {
+	mock := &MockPolicyBundleUsecase{ctrl: ctrl}
+	mock.recorder = &MockPolicyBundleUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyBundleUsecase) EXPECT() *MockPolicyBundleUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPolicyBundleUsecase) Create(arg0 context.Context, arg1 uuid.UUID, arg2 string) (*dto.PolicyBundleDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.PolicyBundleDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPolicyBundleUsecaseMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPolicyBundleUsecase)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockPolicyBundleUsecase) Delete(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPolicyBundleUsecaseMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPolicyBundleUsecase)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockPolicyBundleUsecase) Get(arg0 context.Context, arg1, arg2 uuid.UUID) (*dto.PolicyBundleDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.PolicyBundleDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPolicyBundleUsecaseMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPolicyBundleUsecase)(nil).Get), arg0, arg1, arg2)
+}
+
+// GetAgents mocks base method.
+func (m *MockPolicyBundleUsecase) GetAgents(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 string) ([]*dto.AgentDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAgents", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*dto.AgentDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAgents indicates an expected call of GetAgents.
+func (mr *MockPolicyBundleUsecaseMockRecorder) GetAgents(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgents", reflect.TypeOf((*MockPolicyBundleUsecase)(nil).GetAgents), arg0, arg1, arg2, arg3)
+}
+
+// GetPolicies mocks base method.
+func (m *MockPolicyBundleUsecase) GetPolicies(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 string) ([]*dto.PolicyDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicies", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*dto.PolicyDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicies indicates an expected call of GetPolicies.
+func (mr *MockPolicyBundleUsecaseMockRecorder) GetPolicies(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicies", reflect.TypeOf((*MockPolicyBundleUsecase)(nil).GetPolicies), arg0, arg1, arg2, arg3)
+}
+
+// Gets mocks base method.
+func (m *MockPolicyBundleUsecase) Gets(arg0 context.Context, arg1 string, arg2 uuid.UUID) ([]*dto.PolicyBundleDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gets", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*dto.PolicyBundleDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Gets indicates an expected call of Gets.
+func (mr *MockPolicyBundleUsecaseMockRecorder) Gets(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gets", reflect.TypeOf((*MockPolicyBundleUsecase)(nil).Gets), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockPolicyBundleUsecase) Update(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 string) (*dto.PolicyBundleDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.PolicyBundleDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPolicyBundleUsecaseMockRecorder) Update(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPolicyBundleUsecase)(nil).Update), arg0, arg1, arg2, arg3)
+}
+
+// UpdateAgents mocks base method.
+func (m *MockPolicyBundleUsecase) UpdateAgents(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 []uuid.UUID) ([]*dto.AgentDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAgents", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*dto.AgentDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAgents indicates an expected call of UpdateAgents.
+func (mr *MockPolicyBundleUsecaseMockRecorder) UpdateAgents(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAgents", reflect.TypeOf((*MockPolicyBundleUsecase)(nil).UpdateAgents), arg0, arg1, arg2, arg3)
+}
+
+// UpdatePolicies mocks base method.
+func (m *MockPolicyBundleUsecase) UpdatePolicies(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 []uuid.UUID) ([]*dto.PolicyDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePolicies", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*dto.PolicyDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePolicies indicates an expected call of UpdatePolicies.
+func (mr *MockPolicyBundleUsecaseMockRecorder) UpdatePolicies(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePolicies", reflect.TypeOf((*MockPolicyBundleUsecase)(nil).UpdatePolicies), arg0, arg1, arg2, arg3)
+}
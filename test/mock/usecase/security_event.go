@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: security_event.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	dto "holos-auth-api/internal/app/api/usecase/dto"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockSecurityEventUsecase is a mock of SecurityEventUsecase interface.
+type MockSecurityEventUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecurityEventUsecaseMockRecorder
+}
+
+// MockSecurityEventUsecaseMockRecorder is the mock recorder for MockSecurityEventUsecase.
+type MockSecurityEventUsecaseMockRecorder struct {
+	mock *MockSecurityEventUsecase
+}
+
+// NewMockSecurityEventUsecase creates a new mock instance.
+func NewMockSecurityEventUsecase(ctrl *gomock.Controller) *MockSecurityEventUsecase {
+	mock := &MockSecurityEventUsecase{ctrl: ctrl}
+	mock.recorder = &MockSecurityEventUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecurityEventUsecase) EXPECT() *MockSecurityEventUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Gets mocks base method.
+func (m *MockSecurityEventUsecase) Gets(arg0 context.Context, arg1 uuid.UUID) ([]*dto.SecurityEventDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gets", arg0, arg1)
+	ret0, _ := ret[0].([]*dto.SecurityEventDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Gets indicates an expected call of Gets.
+func (mr *MockSecurityEventUsecaseMockRecorder) Gets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gets", reflect.TypeOf((*MockSecurityEventUsecase)(nil).Gets), arg0, arg1)
+}
+
+// ReportLeakedTokens mocks base method.
+func (m *MockSecurityEventUsecase) ReportLeakedTokens(arg0 context.Context, arg1, arg2, arg3 string, arg4 []byte, arg5 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportLeakedTokens", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportLeakedTokens indicates an expected call of ReportLeakedTokens.
+func (mr *MockSecurityEventUsecaseMockRecorder) ReportLeakedTokens(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportLeakedTokens", reflect.TypeOf((*MockSecurityEventUsecase)(nil).ReportLeakedTokens), arg0, arg1, arg2, arg3, arg4, arg5)
+}
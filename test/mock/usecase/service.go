@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	dto "holos-auth-api/internal/app/api/usecase/dto"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockServiceUsecase is a mock of ServiceUsecase interface.
+type MockServiceUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceUsecaseMockRecorder
+}
+
+// MockServiceUsecaseMockRecorder is the mock recorder for MockServiceUsecase.
+type MockServiceUsecaseMockRecorder struct {
+	mock *MockServiceUsecase
+}
+
+// NewMockServiceUsecase creates a new mock instance.
+func NewMockServiceUsecase(ctrl *gomock.Controller) *MockServiceUsecase {
+	mock := &MockServiceUsecase{ctrl: ctrl}
+	mock.recorder = &MockServiceUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceUsecase) EXPECT() *MockServiceUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockServiceUsecase) Create(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 []string, arg4 string) (*dto.ServiceDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*dto.ServiceDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceUsecaseMockRecorder) Create(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockServiceUsecase)(nil).Create), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Delete mocks base method.
+func (m *MockServiceUsecase) Delete(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceUsecaseMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockServiceUsecase)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockServiceUsecase) Get(arg0 context.Context, arg1, arg2 uuid.UUID) (*dto.ServiceDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.ServiceDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockServiceUsecaseMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockServiceUsecase)(nil).Get), arg0, arg1, arg2)
+}
+
+// Gets mocks base method.
+func (m *MockServiceUsecase) Gets(arg0 context.Context, arg1 string, arg2 uuid.UUID) ([]*dto.ServiceDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gets", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*dto.ServiceDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Gets indicates an expected call of Gets.
+func (mr *MockServiceUsecaseMockRecorder) Gets(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gets", reflect.TypeOf((*MockServiceUsecase)(nil).Gets), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockServiceUsecase) Update(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 string, arg4 []string, arg5 string) (*dto.ServiceDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*dto.ServiceDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockServiceUsecaseMockRecorder) Update(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockServiceUsecase)(nil).Update), arg0, arg1, arg2, arg3, arg4, arg5)
+}